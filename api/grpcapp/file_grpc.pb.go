@@ -23,8 +23,15 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type FileServiceClient interface {
 	DeleteFileById(ctx context.Context, in *DeleteFileByIdParam, opts ...grpc.CallOption) (*DeleteFileByIdResult, error)
+	RestoreFileById(ctx context.Context, in *RestoreFileByIdParam, opts ...grpc.CallOption) (*RestoreFileByIdResult, error)
 	RetrieveFileById(ctx context.Context, in *RetrieveFileByIdParam, opts ...grpc.CallOption) (FileService_RetrieveFileByIdClient, error)
 	UploadFile(ctx context.Context, opts ...grpc.CallOption) (FileService_UploadFileClient, error)
+	InitiateMultipartUpload(ctx context.Context, in *InitiateMultipartUploadParam, opts ...grpc.CallOption) (*InitiateMultipartUploadResult, error)
+	UploadPart(ctx context.Context, opts ...grpc.CallOption) (FileService_UploadPartClient, error)
+	ListParts(ctx context.Context, in *ListPartsParam, opts ...grpc.CallOption) (*ListPartsResult, error)
+	CompleteMultipartUpload(ctx context.Context, in *CompleteMultipartUploadParam, opts ...grpc.CallOption) (*CompleteMultipartUploadResult, error)
+	AbortMultipartUpload(ctx context.Context, in *AbortMultipartUploadParam, opts ...grpc.CallOption) (*AbortMultipartUploadResult, error)
+	SearchFile(ctx context.Context, in *SearchFileParam, opts ...grpc.CallOption) (*SearchFileResult, error)
 }
 
 type fileServiceClient struct {
@@ -44,6 +51,15 @@ func (c *fileServiceClient) DeleteFileById(ctx context.Context, in *DeleteFileBy
 	return out, nil
 }
 
+func (c *fileServiceClient) RestoreFileById(ctx context.Context, in *RestoreFileByIdParam, opts ...grpc.CallOption) (*RestoreFileByIdResult, error) {
+	out := new(RestoreFileByIdResult)
+	err := c.cc.Invoke(ctx, "/file.v1.FileService/RestoreFileById", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *fileServiceClient) RetrieveFileById(ctx context.Context, in *RetrieveFileByIdParam, opts ...grpc.CallOption) (FileService_RetrieveFileByIdClient, error) {
 	stream, err := c.cc.NewStream(ctx, &FileService_ServiceDesc.Streams[0], "/file.v1.FileService/RetrieveFileById", opts...)
 	if err != nil {
@@ -110,13 +126,99 @@ func (x *fileServiceUploadFileClient) CloseAndRecv() (*UploadFileResult, error)
 	return m, nil
 }
 
+func (c *fileServiceClient) InitiateMultipartUpload(ctx context.Context, in *InitiateMultipartUploadParam, opts ...grpc.CallOption) (*InitiateMultipartUploadResult, error) {
+	out := new(InitiateMultipartUploadResult)
+	err := c.cc.Invoke(ctx, "/file.v1.FileService/InitiateMultipartUpload", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) UploadPart(ctx context.Context, opts ...grpc.CallOption) (FileService_UploadPartClient, error) {
+	stream, err := c.cc.NewStream(ctx, &FileService_ServiceDesc.Streams[2], "/file.v1.FileService/UploadPart", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &fileServiceUploadPartClient{stream}
+	return x, nil
+}
+
+type FileService_UploadPartClient interface {
+	Send(*UploadPartParam) error
+	CloseAndRecv() (*UploadPartResult, error)
+	grpc.ClientStream
+}
+
+type fileServiceUploadPartClient struct {
+	grpc.ClientStream
+}
+
+func (x *fileServiceUploadPartClient) Send(m *UploadPartParam) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *fileServiceUploadPartClient) CloseAndRecv() (*UploadPartResult, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(UploadPartResult)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *fileServiceClient) ListParts(ctx context.Context, in *ListPartsParam, opts ...grpc.CallOption) (*ListPartsResult, error) {
+	out := new(ListPartsResult)
+	err := c.cc.Invoke(ctx, "/file.v1.FileService/ListParts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) CompleteMultipartUpload(ctx context.Context, in *CompleteMultipartUploadParam, opts ...grpc.CallOption) (*CompleteMultipartUploadResult, error) {
+	out := new(CompleteMultipartUploadResult)
+	err := c.cc.Invoke(ctx, "/file.v1.FileService/CompleteMultipartUpload", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) AbortMultipartUpload(ctx context.Context, in *AbortMultipartUploadParam, opts ...grpc.CallOption) (*AbortMultipartUploadResult, error) {
+	out := new(AbortMultipartUploadResult)
+	err := c.cc.Invoke(ctx, "/file.v1.FileService/AbortMultipartUpload", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) SearchFile(ctx context.Context, in *SearchFileParam, opts ...grpc.CallOption) (*SearchFileResult, error) {
+	out := new(SearchFileResult)
+	err := c.cc.Invoke(ctx, "/file.v1.FileService/SearchFile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // FileServiceServer is the server API for FileService service.
 // All implementations should embed UnimplementedFileServiceServer
 // for forward compatibility
 type FileServiceServer interface {
 	DeleteFileById(context.Context, *DeleteFileByIdParam) (*DeleteFileByIdResult, error)
+	RestoreFileById(context.Context, *RestoreFileByIdParam) (*RestoreFileByIdResult, error)
 	RetrieveFileById(*RetrieveFileByIdParam, FileService_RetrieveFileByIdServer) error
 	UploadFile(FileService_UploadFileServer) error
+	InitiateMultipartUpload(context.Context, *InitiateMultipartUploadParam) (*InitiateMultipartUploadResult, error)
+	UploadPart(FileService_UploadPartServer) error
+	ListParts(context.Context, *ListPartsParam) (*ListPartsResult, error)
+	CompleteMultipartUpload(context.Context, *CompleteMultipartUploadParam) (*CompleteMultipartUploadResult, error)
+	AbortMultipartUpload(context.Context, *AbortMultipartUploadParam) (*AbortMultipartUploadResult, error)
+	SearchFile(context.Context, *SearchFileParam) (*SearchFileResult, error)
 }
 
 // UnimplementedFileServiceServer should be embedded to have forward compatible implementations.
@@ -126,12 +228,33 @@ type UnimplementedFileServiceServer struct {
 func (UnimplementedFileServiceServer) DeleteFileById(context.Context, *DeleteFileByIdParam) (*DeleteFileByIdResult, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteFileById not implemented")
 }
+func (UnimplementedFileServiceServer) RestoreFileById(context.Context, *RestoreFileByIdParam) (*RestoreFileByIdResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreFileById not implemented")
+}
 func (UnimplementedFileServiceServer) RetrieveFileById(*RetrieveFileByIdParam, FileService_RetrieveFileByIdServer) error {
 	return status.Errorf(codes.Unimplemented, "method RetrieveFileById not implemented")
 }
 func (UnimplementedFileServiceServer) UploadFile(FileService_UploadFileServer) error {
 	return status.Errorf(codes.Unimplemented, "method UploadFile not implemented")
 }
+func (UnimplementedFileServiceServer) InitiateMultipartUpload(context.Context, *InitiateMultipartUploadParam) (*InitiateMultipartUploadResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InitiateMultipartUpload not implemented")
+}
+func (UnimplementedFileServiceServer) UploadPart(FileService_UploadPartServer) error {
+	return status.Errorf(codes.Unimplemented, "method UploadPart not implemented")
+}
+func (UnimplementedFileServiceServer) ListParts(context.Context, *ListPartsParam) (*ListPartsResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListParts not implemented")
+}
+func (UnimplementedFileServiceServer) CompleteMultipartUpload(context.Context, *CompleteMultipartUploadParam) (*CompleteMultipartUploadResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompleteMultipartUpload not implemented")
+}
+func (UnimplementedFileServiceServer) AbortMultipartUpload(context.Context, *AbortMultipartUploadParam) (*AbortMultipartUploadResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AbortMultipartUpload not implemented")
+}
+func (UnimplementedFileServiceServer) SearchFile(context.Context, *SearchFileParam) (*SearchFileResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchFile not implemented")
+}
 
 // UnsafeFileServiceServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to FileServiceServer will
@@ -162,6 +285,24 @@ func _FileService_DeleteFileById_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FileService_RestoreFileById_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreFileByIdParam)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).RestoreFileById(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/file.v1.FileService/RestoreFileById",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).RestoreFileById(ctx, req.(*RestoreFileByIdParam))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _FileService_RetrieveFileById_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(RetrieveFileByIdParam)
 	if err := stream.RecvMsg(m); err != nil {
@@ -209,6 +350,122 @@ func (x *fileServiceUploadFileServer) Recv() (*UploadFileParam, error) {
 	return m, nil
 }
 
+func _FileService_InitiateMultipartUpload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InitiateMultipartUploadParam)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).InitiateMultipartUpload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/file.v1.FileService/InitiateMultipartUpload",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).InitiateMultipartUpload(ctx, req.(*InitiateMultipartUploadParam))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_UploadPart_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(FileServiceServer).UploadPart(&fileServiceUploadPartServer{stream})
+}
+
+type FileService_UploadPartServer interface {
+	SendAndClose(*UploadPartResult) error
+	Recv() (*UploadPartParam, error)
+	grpc.ServerStream
+}
+
+type fileServiceUploadPartServer struct {
+	grpc.ServerStream
+}
+
+func (x *fileServiceUploadPartServer) SendAndClose(m *UploadPartResult) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *fileServiceUploadPartServer) Recv() (*UploadPartParam, error) {
+	m := new(UploadPartParam)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _FileService_ListParts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPartsParam)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).ListParts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/file.v1.FileService/ListParts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).ListParts(ctx, req.(*ListPartsParam))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_CompleteMultipartUpload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompleteMultipartUploadParam)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).CompleteMultipartUpload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/file.v1.FileService/CompleteMultipartUpload",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).CompleteMultipartUpload(ctx, req.(*CompleteMultipartUploadParam))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_AbortMultipartUpload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AbortMultipartUploadParam)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).AbortMultipartUpload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/file.v1.FileService/AbortMultipartUpload",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).AbortMultipartUpload(ctx, req.(*AbortMultipartUploadParam))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_SearchFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchFileParam)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).SearchFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/file.v1.FileService/SearchFile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).SearchFile(ctx, req.(*SearchFileParam))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // FileService_ServiceDesc is the grpc.ServiceDesc for FileService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -220,6 +477,30 @@ var FileService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteFileById",
 			Handler:    _FileService_DeleteFileById_Handler,
 		},
+		{
+			MethodName: "RestoreFileById",
+			Handler:    _FileService_RestoreFileById_Handler,
+		},
+		{
+			MethodName: "InitiateMultipartUpload",
+			Handler:    _FileService_InitiateMultipartUpload_Handler,
+		},
+		{
+			MethodName: "ListParts",
+			Handler:    _FileService_ListParts_Handler,
+		},
+		{
+			MethodName: "CompleteMultipartUpload",
+			Handler:    _FileService_CompleteMultipartUpload_Handler,
+		},
+		{
+			MethodName: "AbortMultipartUpload",
+			Handler:    _FileService_AbortMultipartUpload_Handler,
+		},
+		{
+			MethodName: "SearchFile",
+			Handler:    _FileService_SearchFile_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -232,6 +513,11 @@ var FileService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _FileService_UploadFile_Handler,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "UploadPart",
+			Handler:       _FileService_UploadPart_Handler,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "api/grpcapp/file.proto",
 }
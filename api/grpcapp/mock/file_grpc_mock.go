@@ -37,6 +37,46 @@ func (m *MockFileServiceClient) EXPECT() *MockFileServiceClientMockRecorder {
 	return m.recorder
 }
 
+// AbortMultipartUpload mocks base method.
+func (m *MockFileServiceClient) AbortMultipartUpload(ctx context.Context, in *grpcapp.AbortMultipartUploadParam, opts ...grpc.CallOption) (*grpcapp.AbortMultipartUploadResult, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AbortMultipartUpload", varargs...)
+	ret0, _ := ret[0].(*grpcapp.AbortMultipartUploadResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AbortMultipartUpload indicates an expected call of AbortMultipartUpload.
+func (mr *MockFileServiceClientMockRecorder) AbortMultipartUpload(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AbortMultipartUpload", reflect.TypeOf((*MockFileServiceClient)(nil).AbortMultipartUpload), varargs...)
+}
+
+// CompleteMultipartUpload mocks base method.
+func (m *MockFileServiceClient) CompleteMultipartUpload(ctx context.Context, in *grpcapp.CompleteMultipartUploadParam, opts ...grpc.CallOption) (*grpcapp.CompleteMultipartUploadResult, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CompleteMultipartUpload", varargs...)
+	ret0, _ := ret[0].(*grpcapp.CompleteMultipartUploadResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteMultipartUpload indicates an expected call of CompleteMultipartUpload.
+func (mr *MockFileServiceClientMockRecorder) CompleteMultipartUpload(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteMultipartUpload", reflect.TypeOf((*MockFileServiceClient)(nil).CompleteMultipartUpload), varargs...)
+}
+
 // DeleteFileById mocks base method.
 func (m *MockFileServiceClient) DeleteFileById(ctx context.Context, in *grpcapp.DeleteFileByIdParam, opts ...grpc.CallOption) (*grpcapp.DeleteFileByIdResult, error) {
 	m.ctrl.T.Helper()
@@ -57,6 +97,66 @@ func (mr *MockFileServiceClientMockRecorder) DeleteFileById(ctx, in interface{},
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFileById", reflect.TypeOf((*MockFileServiceClient)(nil).DeleteFileById), varargs...)
 }
 
+// InitiateMultipartUpload mocks base method.
+func (m *MockFileServiceClient) InitiateMultipartUpload(ctx context.Context, in *grpcapp.InitiateMultipartUploadParam, opts ...grpc.CallOption) (*grpcapp.InitiateMultipartUploadResult, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "InitiateMultipartUpload", varargs...)
+	ret0, _ := ret[0].(*grpcapp.InitiateMultipartUploadResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InitiateMultipartUpload indicates an expected call of InitiateMultipartUpload.
+func (mr *MockFileServiceClientMockRecorder) InitiateMultipartUpload(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InitiateMultipartUpload", reflect.TypeOf((*MockFileServiceClient)(nil).InitiateMultipartUpload), varargs...)
+}
+
+// ListParts mocks base method.
+func (m *MockFileServiceClient) ListParts(ctx context.Context, in *grpcapp.ListPartsParam, opts ...grpc.CallOption) (*grpcapp.ListPartsResult, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListParts", varargs...)
+	ret0, _ := ret[0].(*grpcapp.ListPartsResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListParts indicates an expected call of ListParts.
+func (mr *MockFileServiceClientMockRecorder) ListParts(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListParts", reflect.TypeOf((*MockFileServiceClient)(nil).ListParts), varargs...)
+}
+
+// RestoreFileById mocks base method.
+func (m *MockFileServiceClient) RestoreFileById(ctx context.Context, in *grpcapp.RestoreFileByIdParam, opts ...grpc.CallOption) (*grpcapp.RestoreFileByIdResult, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RestoreFileById", varargs...)
+	ret0, _ := ret[0].(*grpcapp.RestoreFileByIdResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreFileById indicates an expected call of RestoreFileById.
+func (mr *MockFileServiceClientMockRecorder) RestoreFileById(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreFileById", reflect.TypeOf((*MockFileServiceClient)(nil).RestoreFileById), varargs...)
+}
+
 // RetrieveFileById mocks base method.
 func (m *MockFileServiceClient) RetrieveFileById(ctx context.Context, in *grpcapp.RetrieveFileByIdParam, opts ...grpc.CallOption) (grpcapp.FileService_RetrieveFileByIdClient, error) {
 	m.ctrl.T.Helper()
@@ -77,6 +177,26 @@ func (mr *MockFileServiceClientMockRecorder) RetrieveFileById(ctx, in interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetrieveFileById", reflect.TypeOf((*MockFileServiceClient)(nil).RetrieveFileById), varargs...)
 }
 
+// SearchFile mocks base method.
+func (m *MockFileServiceClient) SearchFile(ctx context.Context, in *grpcapp.SearchFileParam, opts ...grpc.CallOption) (*grpcapp.SearchFileResult, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SearchFile", varargs...)
+	ret0, _ := ret[0].(*grpcapp.SearchFileResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchFile indicates an expected call of SearchFile.
+func (mr *MockFileServiceClientMockRecorder) SearchFile(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchFile", reflect.TypeOf((*MockFileServiceClient)(nil).SearchFile), varargs...)
+}
+
 // UploadFile mocks base method.
 func (m *MockFileServiceClient) UploadFile(ctx context.Context, opts ...grpc.CallOption) (grpcapp.FileService_UploadFileClient, error) {
 	m.ctrl.T.Helper()
@@ -97,6 +217,26 @@ func (mr *MockFileServiceClientMockRecorder) UploadFile(ctx interface{}, opts ..
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadFile", reflect.TypeOf((*MockFileServiceClient)(nil).UploadFile), varargs...)
 }
 
+// UploadPart mocks base method.
+func (m *MockFileServiceClient) UploadPart(ctx context.Context, opts ...grpc.CallOption) (grpcapp.FileService_UploadPartClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UploadPart", varargs...)
+	ret0, _ := ret[0].(grpcapp.FileService_UploadPartClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadPart indicates an expected call of UploadPart.
+func (mr *MockFileServiceClientMockRecorder) UploadPart(ctx interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadPart", reflect.TypeOf((*MockFileServiceClient)(nil).UploadPart), varargs...)
+}
+
 // MockFileService_RetrieveFileByIdClient is a mock of FileService_RetrieveFileByIdClient interface.
 type MockFileService_RetrieveFileByIdClient struct {
 	ctrl     *gomock.Controller
@@ -357,6 +497,143 @@ func (mr *MockFileService_UploadFileClientMockRecorder) Trailer() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockFileService_UploadFileClient)(nil).Trailer))
 }
 
+// MockFileService_UploadPartClient is a mock of FileService_UploadPartClient interface.
+type MockFileService_UploadPartClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockFileService_UploadPartClientMockRecorder
+}
+
+// MockFileService_UploadPartClientMockRecorder is the mock recorder for MockFileService_UploadPartClient.
+type MockFileService_UploadPartClientMockRecorder struct {
+	mock *MockFileService_UploadPartClient
+}
+
+// NewMockFileService_UploadPartClient creates a new mock instance.
+func NewMockFileService_UploadPartClient(ctrl *gomock.Controller) *MockFileService_UploadPartClient {
+	mock := &MockFileService_UploadPartClient{ctrl: ctrl}
+	mock.recorder = &MockFileService_UploadPartClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFileService_UploadPartClient) EXPECT() *MockFileService_UploadPartClientMockRecorder {
+	return m.recorder
+}
+
+// CloseAndRecv mocks base method.
+func (m *MockFileService_UploadPartClient) CloseAndRecv() (*grpcapp.UploadPartResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseAndRecv")
+	ret0, _ := ret[0].(*grpcapp.UploadPartResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloseAndRecv indicates an expected call of CloseAndRecv.
+func (mr *MockFileService_UploadPartClientMockRecorder) CloseAndRecv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseAndRecv", reflect.TypeOf((*MockFileService_UploadPartClient)(nil).CloseAndRecv))
+}
+
+// CloseSend mocks base method.
+func (m *MockFileService_UploadPartClient) CloseSend() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseSend")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseSend indicates an expected call of CloseSend.
+func (mr *MockFileService_UploadPartClientMockRecorder) CloseSend() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseSend", reflect.TypeOf((*MockFileService_UploadPartClient)(nil).CloseSend))
+}
+
+// Context mocks base method.
+func (m *MockFileService_UploadPartClient) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockFileService_UploadPartClientMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockFileService_UploadPartClient)(nil).Context))
+}
+
+// Header mocks base method.
+func (m *MockFileService_UploadPartClient) Header() (metadata.MD, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Header")
+	ret0, _ := ret[0].(metadata.MD)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Header indicates an expected call of Header.
+func (mr *MockFileService_UploadPartClientMockRecorder) Header() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Header", reflect.TypeOf((*MockFileService_UploadPartClient)(nil).Header))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockFileService_UploadPartClient) RecvMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockFileService_UploadPartClientMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockFileService_UploadPartClient)(nil).RecvMsg), m)
+}
+
+// Send mocks base method.
+func (m *MockFileService_UploadPartClient) Send(arg0 *grpcapp.UploadPartParam) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockFileService_UploadPartClientMockRecorder) Send(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockFileService_UploadPartClient)(nil).Send), arg0)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockFileService_UploadPartClient) SendMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockFileService_UploadPartClientMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockFileService_UploadPartClient)(nil).SendMsg), m)
+}
+
+// Trailer mocks base method.
+func (m *MockFileService_UploadPartClient) Trailer() metadata.MD {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trailer")
+	ret0, _ := ret[0].(metadata.MD)
+	return ret0
+}
+
+// Trailer indicates an expected call of Trailer.
+func (mr *MockFileService_UploadPartClientMockRecorder) Trailer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockFileService_UploadPartClient)(nil).Trailer))
+}
+
 // MockFileServiceServer is a mock of FileServiceServer interface.
 type MockFileServiceServer struct {
 	ctrl     *gomock.Controller
@@ -380,6 +657,36 @@ func (m *MockFileServiceServer) EXPECT() *MockFileServiceServerMockRecorder {
 	return m.recorder
 }
 
+// AbortMultipartUpload mocks base method.
+func (m *MockFileServiceServer) AbortMultipartUpload(arg0 context.Context, arg1 *grpcapp.AbortMultipartUploadParam) (*grpcapp.AbortMultipartUploadResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AbortMultipartUpload", arg0, arg1)
+	ret0, _ := ret[0].(*grpcapp.AbortMultipartUploadResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AbortMultipartUpload indicates an expected call of AbortMultipartUpload.
+func (mr *MockFileServiceServerMockRecorder) AbortMultipartUpload(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AbortMultipartUpload", reflect.TypeOf((*MockFileServiceServer)(nil).AbortMultipartUpload), arg0, arg1)
+}
+
+// CompleteMultipartUpload mocks base method.
+func (m *MockFileServiceServer) CompleteMultipartUpload(arg0 context.Context, arg1 *grpcapp.CompleteMultipartUploadParam) (*grpcapp.CompleteMultipartUploadResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteMultipartUpload", arg0, arg1)
+	ret0, _ := ret[0].(*grpcapp.CompleteMultipartUploadResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteMultipartUpload indicates an expected call of CompleteMultipartUpload.
+func (mr *MockFileServiceServerMockRecorder) CompleteMultipartUpload(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteMultipartUpload", reflect.TypeOf((*MockFileServiceServer)(nil).CompleteMultipartUpload), arg0, arg1)
+}
+
 // DeleteFileById mocks base method.
 func (m *MockFileServiceServer) DeleteFileById(arg0 context.Context, arg1 *grpcapp.DeleteFileByIdParam) (*grpcapp.DeleteFileByIdResult, error) {
 	m.ctrl.T.Helper()
@@ -395,6 +702,51 @@ func (mr *MockFileServiceServerMockRecorder) DeleteFileById(arg0, arg1 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFileById", reflect.TypeOf((*MockFileServiceServer)(nil).DeleteFileById), arg0, arg1)
 }
 
+// InitiateMultipartUpload mocks base method.
+func (m *MockFileServiceServer) InitiateMultipartUpload(arg0 context.Context, arg1 *grpcapp.InitiateMultipartUploadParam) (*grpcapp.InitiateMultipartUploadResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InitiateMultipartUpload", arg0, arg1)
+	ret0, _ := ret[0].(*grpcapp.InitiateMultipartUploadResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InitiateMultipartUpload indicates an expected call of InitiateMultipartUpload.
+func (mr *MockFileServiceServerMockRecorder) InitiateMultipartUpload(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InitiateMultipartUpload", reflect.TypeOf((*MockFileServiceServer)(nil).InitiateMultipartUpload), arg0, arg1)
+}
+
+// ListParts mocks base method.
+func (m *MockFileServiceServer) ListParts(arg0 context.Context, arg1 *grpcapp.ListPartsParam) (*grpcapp.ListPartsResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListParts", arg0, arg1)
+	ret0, _ := ret[0].(*grpcapp.ListPartsResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListParts indicates an expected call of ListParts.
+func (mr *MockFileServiceServerMockRecorder) ListParts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListParts", reflect.TypeOf((*MockFileServiceServer)(nil).ListParts), arg0, arg1)
+}
+
+// RestoreFileById mocks base method.
+func (m *MockFileServiceServer) RestoreFileById(arg0 context.Context, arg1 *grpcapp.RestoreFileByIdParam) (*grpcapp.RestoreFileByIdResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreFileById", arg0, arg1)
+	ret0, _ := ret[0].(*grpcapp.RestoreFileByIdResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreFileById indicates an expected call of RestoreFileById.
+func (mr *MockFileServiceServerMockRecorder) RestoreFileById(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreFileById", reflect.TypeOf((*MockFileServiceServer)(nil).RestoreFileById), arg0, arg1)
+}
+
 // RetrieveFileById mocks base method.
 func (m *MockFileServiceServer) RetrieveFileById(arg0 *grpcapp.RetrieveFileByIdParam, arg1 grpcapp.FileService_RetrieveFileByIdServer) error {
 	m.ctrl.T.Helper()
@@ -409,6 +761,21 @@ func (mr *MockFileServiceServerMockRecorder) RetrieveFileById(arg0, arg1 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetrieveFileById", reflect.TypeOf((*MockFileServiceServer)(nil).RetrieveFileById), arg0, arg1)
 }
 
+// SearchFile mocks base method.
+func (m *MockFileServiceServer) SearchFile(arg0 context.Context, arg1 *grpcapp.SearchFileParam) (*grpcapp.SearchFileResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchFile", arg0, arg1)
+	ret0, _ := ret[0].(*grpcapp.SearchFileResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchFile indicates an expected call of SearchFile.
+func (mr *MockFileServiceServerMockRecorder) SearchFile(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchFile", reflect.TypeOf((*MockFileServiceServer)(nil).SearchFile), arg0, arg1)
+}
+
 // UploadFile mocks base method.
 func (m *MockFileServiceServer) UploadFile(arg0 grpcapp.FileService_UploadFileServer) error {
 	m.ctrl.T.Helper()
@@ -423,6 +790,20 @@ func (mr *MockFileServiceServerMockRecorder) UploadFile(arg0 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadFile", reflect.TypeOf((*MockFileServiceServer)(nil).UploadFile), arg0)
 }
 
+// UploadPart mocks base method.
+func (m *MockFileServiceServer) UploadPart(arg0 grpcapp.FileService_UploadPartServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadPart", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UploadPart indicates an expected call of UploadPart.
+func (mr *MockFileServiceServerMockRecorder) UploadPart(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadPart", reflect.TypeOf((*MockFileServiceServer)(nil).UploadPart), arg0)
+}
+
 // MockUnsafeFileServiceServer is a mock of UnsafeFileServiceServer interface.
 type MockUnsafeFileServiceServer struct {
 	ctrl     *gomock.Controller
@@ -710,3 +1091,137 @@ func (mr *MockFileService_UploadFileServerMockRecorder) SetTrailer(arg0 interfac
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockFileService_UploadFileServer)(nil).SetTrailer), arg0)
 }
+
+// MockFileService_UploadPartServer is a mock of FileService_UploadPartServer interface.
+type MockFileService_UploadPartServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockFileService_UploadPartServerMockRecorder
+}
+
+// MockFileService_UploadPartServerMockRecorder is the mock recorder for MockFileService_UploadPartServer.
+type MockFileService_UploadPartServerMockRecorder struct {
+	mock *MockFileService_UploadPartServer
+}
+
+// NewMockFileService_UploadPartServer creates a new mock instance.
+func NewMockFileService_UploadPartServer(ctrl *gomock.Controller) *MockFileService_UploadPartServer {
+	mock := &MockFileService_UploadPartServer{ctrl: ctrl}
+	mock.recorder = &MockFileService_UploadPartServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFileService_UploadPartServer) EXPECT() *MockFileService_UploadPartServerMockRecorder {
+	return m.recorder
+}
+
+// Context mocks base method.
+func (m *MockFileService_UploadPartServer) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockFileService_UploadPartServerMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockFileService_UploadPartServer)(nil).Context))
+}
+
+// Recv mocks base method.
+func (m *MockFileService_UploadPartServer) Recv() (*grpcapp.UploadPartParam, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recv")
+	ret0, _ := ret[0].(*grpcapp.UploadPartParam)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recv indicates an expected call of Recv.
+func (mr *MockFileService_UploadPartServerMockRecorder) Recv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recv", reflect.TypeOf((*MockFileService_UploadPartServer)(nil).Recv))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockFileService_UploadPartServer) RecvMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockFileService_UploadPartServerMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockFileService_UploadPartServer)(nil).RecvMsg), m)
+}
+
+// SendAndClose mocks base method.
+func (m *MockFileService_UploadPartServer) SendAndClose(arg0 *grpcapp.UploadPartResult) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendAndClose", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendAndClose indicates an expected call of SendAndClose.
+func (mr *MockFileService_UploadPartServerMockRecorder) SendAndClose(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendAndClose", reflect.TypeOf((*MockFileService_UploadPartServer)(nil).SendAndClose), arg0)
+}
+
+// SendHeader mocks base method.
+func (m *MockFileService_UploadPartServer) SendHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendHeader indicates an expected call of SendHeader.
+func (mr *MockFileService_UploadPartServerMockRecorder) SendHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendHeader", reflect.TypeOf((*MockFileService_UploadPartServer)(nil).SendHeader), arg0)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockFileService_UploadPartServer) SendMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockFileService_UploadPartServerMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockFileService_UploadPartServer)(nil).SendMsg), m)
+}
+
+// SetHeader mocks base method.
+func (m *MockFileService_UploadPartServer) SetHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHeader indicates an expected call of SetHeader.
+func (mr *MockFileService_UploadPartServerMockRecorder) SetHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHeader", reflect.TypeOf((*MockFileService_UploadPartServer)(nil).SetHeader), arg0)
+}
+
+// SetTrailer mocks base method.
+func (m *MockFileService_UploadPartServer) SetTrailer(arg0 metadata.MD) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTrailer", arg0)
+}
+
+// SetTrailer indicates an expected call of SetTrailer.
+func (mr *MockFileService_UploadPartServerMockRecorder) SetTrailer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockFileService_UploadPartServer)(nil).SetTrailer), arg0)
+}
@@ -9,7 +9,16 @@ import (
 )
 
 const (
-	BasicAuthScopes = "basicAuth.Scopes"
+	BasicAuthScopes  = "basicAuth.Scopes"
+	BearerAuthScopes = "bearerAuth.Scopes"
+)
+
+// Defines values for AuthClientScope.
+const (
+	ClientAdmin AuthClientScope = "client:admin"
+	FileDelete  AuthClientScope = "file:delete"
+	FileRead    AuthClientScope = "file:read"
+	FileWrite   AuthClientScope = "file:write"
 )
 
 // Defines values for CreateAuthClientRequestStatus.
@@ -23,12 +32,43 @@ const (
 	CreateAuthClientRequestTypeBasicAuth CreateAuthClientRequestType = "basic_auth"
 )
 
+// Defines values for IssueTokenRequestGrantType.
+const (
+	ClientCredentials IssueTokenRequestGrantType = "client_credentials"
+)
+
+// Defines values for OAuthErrorError.
+const (
+	InvalidClient        OAuthErrorError = "invalid_client"
+	InvalidRequest       OAuthErrorError = "invalid_request"
+	UnsupportedGrantType OAuthErrorError = "unsupported_grant_type"
+)
+
 // Defines values for SearchAuthClientFilterStatusIn.
 const (
 	SearchAuthClientFilterStatusInActive   SearchAuthClientFilterStatusIn = "active"
 	SearchAuthClientFilterStatusInInactive SearchAuthClientFilterStatusIn = "inactive"
 )
 
+// Defines values for SearchFileFilterStatusIn.
+const (
+	SearchFileFilterStatusInAvailable SearchFileFilterStatusIn = "available"
+	SearchFileFilterStatusInDeleted   SearchFileFilterStatusIn = "deleted"
+)
+
+// Defines values for SearchFileSortField.
+const (
+	SearchFileSortFieldName       SearchFileSortField = "name"
+	SearchFileSortFieldSize       SearchFileSortField = "size"
+	SearchFileSortFieldUploadedAt SearchFileSortField = "uploaded_at"
+)
+
+// Defines values for SearchFileSortOrder.
+const (
+	SearchFileSortOrderAsc  SearchFileSortOrder = "asc"
+	SearchFileSortOrderDesc SearchFileSortOrder = "desc"
+)
+
 // Defines values for UpdateAuthClientByIdRequestStatus.
 const (
 	Active   UpdateAuthClientByIdRequestStatus = "active"
@@ -40,6 +80,32 @@ const (
 	UpdateAuthClientByIdRequestTypeBasicAuth UpdateAuthClientByIdRequestType = "basic_auth"
 )
 
+// zero limit means unlimited
+type AuthClientQuota struct {
+	// maximum uploads per day (UTC), min = 0
+	MaxDailyUploads int64 `json:"max_daily_uploads"`
+
+	// maximum single file bytes, min = 0
+	MaxFileSize int64 `json:"max_file_size"`
+
+	// maximum stored bytes, min = 0
+	MaxStoredSize int64 `json:"max_stored_size"`
+
+	// maximum file count, min = 0
+	MaxTotalFiles int64 `json:"max_total_files"`
+}
+
+// permission granted to the auth client
+type AuthClientScope string
+
+// AuthClientUsage defines model for AuthClientUsage.
+type AuthClientUsage struct {
+	// uploads of the current day (UTC)
+	DailyUploads int64 `json:"daily_uploads"`
+	StoredSize   int64 `json:"stored_size"`
+	TotalFiles   int64 `json:"total_files"`
+}
+
 // CheckHealthData defines model for CheckHealthData.
 type CheckHealthData struct {
 	Details CheckHealthData_Details `json:"details"`
@@ -78,11 +144,15 @@ type CreateAuthClientData struct {
 
 // CreateAuthClientRequest defines model for CreateAuthClientRequest.
 type CreateAuthClientRequest struct {
-	ClientId     string                        `json:"client_id"`
-	ClientSecret string                        `json:"client_secret"`
-	Name         string                        `json:"name"`
-	Status       CreateAuthClientRequestStatus `json:"status"`
-	Type         CreateAuthClientRequestType   `json:"type"`
+	ClientId     string           `json:"client_id"`
+	ClientSecret string           `json:"client_secret"`
+	Name         string           `json:"name"`
+	Quota        *AuthClientQuota `json:"quota,omitempty"`
+
+	// file scopes are granted when it's not specified
+	Scopes *[]AuthClientScope            `json:"scopes,omitempty"`
+	Status CreateAuthClientRequestStatus `json:"status"`
+	Type   CreateAuthClientRequestType   `json:"type"`
 }
 
 // CreateAuthClientRequestStatus defines model for CreateAuthClientRequest.Status.
@@ -98,6 +168,20 @@ type CreateAuthClientResponse struct {
 	Message string               `json:"message"`
 }
 
+// DeleteAuthClientByIdData defines model for DeleteAuthClientByIdData.
+type DeleteAuthClientByIdData struct {
+	ClientId  string `json:"client_id"`
+	DeletedAt int64  `json:"deleted_at"`
+	Id        string `json:"id"`
+}
+
+// DeleteAuthClientByIdResponse defines model for DeleteAuthClientByIdResponse.
+type DeleteAuthClientByIdResponse struct {
+	Code    int32                    `json:"code"`
+	Data    DeleteAuthClientByIdData `json:"data"`
+	Message string                   `json:"message"`
+}
+
 // DeleteFileByIdData defines model for DeleteFileByIdData.
 type DeleteFileByIdData struct {
 	DeletedAt int64 `json:"deleted_at"`
@@ -129,9 +213,16 @@ type GetAuthClientByIdData struct {
 	CreatedAt int64  `json:"created_at"`
 	Id        string `json:"id"`
 	Name      string `json:"name"`
-	Status    string `json:"status"`
-	Type      string `json:"type"`
-	UpdatedAt *int64 `json:"updated_at,omitempty"`
+
+	// previous secret is still accepted until this time, it's only available during the rotation grace period
+	PreviousSecretExpiresAt *int64            `json:"previous_secret_expires_at,omitempty"`
+	Quota                   AuthClientQuota   `json:"quota"`
+	Scopes                  []AuthClientScope `json:"scopes"`
+	SecretRotatedAt         *int64            `json:"secret_rotated_at,omitempty"`
+	Status                  string            `json:"status"`
+	Type                    string            `json:"type"`
+	UpdatedAt               *int64            `json:"updated_at,omitempty"`
+	Usage                   AuthClientUsage   `json:"usage"`
 }
 
 // GetAuthClientByIdResponse defines model for GetAuthClientByIdResponse.
@@ -141,6 +232,40 @@ type GetAuthClientByIdResponse struct {
 	Message string                `json:"message"`
 }
 
+// IssueTokenRequest defines model for IssueTokenRequest.
+type IssueTokenRequest struct {
+	// required when the credential is not specified using basic auth
+	ClientId *string `json:"client_id,omitempty"`
+
+	// required when the credential is not specified using basic auth
+	ClientSecret *string                    `json:"client_secret,omitempty"`
+	GrantType    IssueTokenRequestGrantType `json:"grant_type"`
+}
+
+// IssueTokenRequestGrantType defines model for IssueTokenRequest.GrantType.
+type IssueTokenRequestGrantType string
+
+// IssueTokenResponse defines model for IssueTokenResponse.
+type IssueTokenResponse struct {
+	AccessToken string `json:"access_token"`
+
+	// lifetime of the access token in seconds
+	ExpiresIn int64 `json:"expires_in"`
+
+	// space delimited scopes granted to the access token
+	Scope     *string `json:"scope,omitempty"`
+	TokenType string  `json:"token_type"`
+}
+
+// OAuthError defines model for OAuthError.
+type OAuthError struct {
+	Error            OAuthErrorError `json:"error"`
+	ErrorDescription *string         `json:"error_description,omitempty"`
+}
+
+// OAuthErrorError defines model for OAuthError.Error.
+type OAuthErrorError string
+
 // RequestPagination defines model for RequestPagination.
 type RequestPagination struct {
 	// min = 1
@@ -156,9 +281,61 @@ type ResponseBodyInfo struct {
 	Message string `json:"message"`
 }
 
+// RestoreFileByIdData defines model for RestoreFileByIdData.
+type RestoreFileByIdData struct {
+	RestoredAt int64 `json:"restored_at"`
+}
+
+// RestoreFileByIdResponse defines model for RestoreFileByIdResponse.
+type RestoreFileByIdResponse struct {
+	Code    int32               `json:"code"`
+	Data    RestoreFileByIdData `json:"data"`
+	Message string              `json:"message"`
+}
+
 // RetrieveFileByIdResponse defines model for RetrieveFileByIdResponse.
 type RetrieveFileByIdResponse = string
 
+// RevokeAuthClientSecretData defines model for RevokeAuthClientSecretData.
+type RevokeAuthClientSecretData struct {
+	ClientId  string `json:"client_id"`
+	Id        string `json:"id"`
+	RevokedAt int64  `json:"revoked_at"`
+}
+
+// RevokeAuthClientSecretResponse defines model for RevokeAuthClientSecretResponse.
+type RevokeAuthClientSecretResponse struct {
+	Code    int32                      `json:"code"`
+	Data    RevokeAuthClientSecretData `json:"data"`
+	Message string                     `json:"message"`
+}
+
+// RotateAuthClientSecretData defines model for RotateAuthClientSecretData.
+type RotateAuthClientSecretData struct {
+	ClientId string `json:"client_id"`
+
+	// new client secret, it's only returned once
+	ClientSecret string `json:"client_secret"`
+	Id           string `json:"id"`
+
+	// previous secret is still accepted until this time
+	PreviousSecretExpiresAt int64 `json:"previous_secret_expires_at"`
+	SecretRotatedAt         int64 `json:"secret_rotated_at"`
+}
+
+// RotateAuthClientSecretRequest defines model for RotateAuthClientSecretRequest.
+type RotateAuthClientSecretRequest struct {
+	// new client secret is generated when it's not specified
+	ClientSecret *string `json:"client_secret,omitempty"`
+}
+
+// RotateAuthClientSecretResponse defines model for RotateAuthClientSecretResponse.
+type RotateAuthClientSecretResponse struct {
+	Code    int32                      `json:"code"`
+	Data    RotateAuthClientSecretData `json:"data"`
+	Message string                     `json:"message"`
+}
+
 // SearchAuthClientData defines model for SearchAuthClientData.
 type SearchAuthClientData struct {
 	Items   []SearchAuthClientItem  `json:"items"`
@@ -209,6 +386,90 @@ type SearchAuthClientSummary struct {
 	TotalItems int64 `json:"total_items"`
 }
 
+// SearchFileData defines model for SearchFileData.
+type SearchFileData struct {
+	Items   []SearchFileItem  `json:"items"`
+	Summary SearchFileSummary `json:"summary"`
+}
+
+// SearchFileFilter defines model for SearchFileFilter.
+type SearchFileFilter struct {
+	ExtensionIn *[]string `json:"extension_in,omitempty"`
+	MimetypeIn  *[]string `json:"mimetype_in,omitempty"`
+
+	// minimum file size in bytes
+	SizeGte *int64 `json:"size_gte,omitempty"`
+
+	// maximum file size in bytes
+	SizeLte  *int64                      `json:"size_lte,omitempty"`
+	StatusIn *[]SearchFileFilterStatusIn `json:"status_in,omitempty"`
+
+	// minimum upload time in unix milliseconds
+	UploadedAtGte *int64 `json:"uploaded_at_gte,omitempty"`
+
+	// maximum upload time in unix milliseconds
+	UploadedAtLte *int64 `json:"uploaded_at_lte,omitempty"`
+}
+
+// file status, all status are included when it's empty
+type SearchFileFilterStatusIn string
+
+// SearchFileItem defines model for SearchFileItem.
+type SearchFileItem struct {
+	// hex encoded md5 checksum, only available when md5 checksum is enabled
+	ChecksumMd5 *string `json:"checksum_md5,omitempty"`
+
+	// hex encoded sha256 checksum
+	ChecksumSha256 string `json:"checksum_sha256"`
+	DeletedAt      *int64 `json:"deleted_at,omitempty"`
+	Extension      string `json:"extension"`
+	Id             string `json:"id"`
+	Mimetype       string `json:"mimetype"`
+	Name           string `json:"name"`
+	Size           int64  `json:"size"`
+	UploadedAt     int64  `json:"uploaded_at"`
+}
+
+// SearchFileRequest defines model for SearchFileRequest.
+type SearchFileRequest struct {
+	Filter *SearchFileFilter `json:"filter,omitempty"`
+
+	// min = 2 character, matched against file name
+	Keyword    *string            `json:"keyword,omitempty"`
+	Pagination *RequestPagination `json:"pagination,omitempty"`
+	Sort       *SearchFileSort    `json:"sort,omitempty"`
+}
+
+// SearchFileResponse defines model for SearchFileResponse.
+type SearchFileResponse struct {
+	Code    int32          `json:"code"`
+	Data    SearchFileData `json:"data"`
+	Message string         `json:"message"`
+}
+
+// SearchFileSort defines model for SearchFileSort.
+type SearchFileSort struct {
+	Field SearchFileSortField `json:"field"`
+
+	// default = desc
+	Order *SearchFileSortOrder `json:"order,omitempty"`
+}
+
+// SearchFileSortField defines model for SearchFileSort.Field.
+type SearchFileSortField string
+
+// default = desc
+type SearchFileSortOrder string
+
+// SearchFileSummary defines model for SearchFileSummary.
+type SearchFileSummary struct {
+	// current page
+	Page int64 `json:"page"`
+
+	// total matched items with a given parameter
+	TotalItems int64 `json:"total_items"`
+}
+
 // UpdateAuthClientByIdData defines model for UpdateAuthClientByIdData.
 type UpdateAuthClientByIdData struct {
 	ClientId  string `json:"client_id"`
@@ -222,10 +483,14 @@ type UpdateAuthClientByIdData struct {
 
 // UpdateAuthClientByIdRequest defines model for UpdateAuthClientByIdRequest.
 type UpdateAuthClientByIdRequest struct {
-	ClientId string                            `json:"client_id"`
-	Name     string                            `json:"name"`
-	Status   UpdateAuthClientByIdRequestStatus `json:"status"`
-	Type     UpdateAuthClientByIdRequestType   `json:"type"`
+	ClientId string           `json:"client_id"`
+	Name     string           `json:"name"`
+	Quota    *AuthClientQuota `json:"quota,omitempty"`
+
+	// current scopes are kept when it's not specified
+	Scopes *[]AuthClientScope                `json:"scopes,omitempty"`
+	Status UpdateAuthClientByIdRequestStatus `json:"status"`
+	Type   UpdateAuthClientByIdRequestType   `json:"type"`
 }
 
 // UpdateAuthClientByIdRequestStatus defines model for UpdateAuthClientByIdRequest.Status.
@@ -243,17 +508,24 @@ type UpdateAuthClientByIdResponse struct {
 
 // UploadFileData defines model for UploadFileData.
 type UploadFileData struct {
-	Extension  string `json:"extension"`
-	Id         string `json:"id"`
-	Mimetype   string `json:"mimetype"`
-	Name       string `json:"name"`
-	Size       int64  `json:"size"`
-	UploadedAt int64  `json:"uploaded_at"`
+	// hex encoded md5 checksum, only available when md5 checksum is enabled
+	ChecksumMd5 *string `json:"checksum_md5,omitempty"`
+
+	// hex encoded sha256 checksum
+	ChecksumSha256 string `json:"checksum_sha256"`
+	Extension      string `json:"extension"`
+	Id             string `json:"id"`
+	Mimetype       string `json:"mimetype"`
+	Name           string `json:"name"`
+	Size           int64  `json:"size"`
+	UploadedAt     int64  `json:"uploaded_at"`
 }
 
 // UploadFileRequest defines model for UploadFileRequest.
 type UploadFileRequest struct {
-	File string `json:"file"`
+	// used to group the file when upload location strategy is bucket
+	Bucket *string `json:"bucket,omitempty"`
+	File   string  `json:"file"`
 }
 
 // UploadFileResponse defines model for UploadFileResponse.
@@ -269,12 +541,27 @@ type CorrelationId = string
 // ObjectId defines model for ObjectId.
 type ObjectId = string
 
+// TusResumable defines model for TusResumable.
+type TusResumable = string
+
 // BadRequest defines model for BadRequest.
 type BadRequest = ResponseBodyInfo
 
+// Conflict defines model for Conflict.
+type Conflict = ResponseBodyInfo
+
+// Forbidden defines model for Forbidden.
+type Forbidden = ResponseBodyInfo
+
 // NotFound defines model for NotFound.
 type NotFound = ResponseBodyInfo
 
+// PreconditionFailed defines model for PreconditionFailed.
+type PreconditionFailed = ResponseBodyInfo
+
+// RangeNotSatisfiable defines model for RangeNotSatisfiable.
+type RangeNotSatisfiable = ResponseBodyInfo
+
 // ServerError defines model for ServerError.
 type ServerError = ResponseBodyInfo
 
@@ -311,6 +598,12 @@ type SearchAuthClientParams struct {
 	XCorrelationId *CorrelationId `json:"X-Correlation-Id,omitempty"`
 }
 
+// DeleteAuthClientByIdParams defines parameters for DeleteAuthClientById.
+type DeleteAuthClientByIdParams struct {
+	// correlation id for tracing purposes
+	XCorrelationId *CorrelationId `json:"X-Correlation-Id,omitempty"`
+}
+
 // GetAuthClientByIdParams defines parameters for GetAuthClientById.
 type GetAuthClientByIdParams struct {
 	// correlation id for tracing purposes
@@ -326,12 +619,36 @@ type UpdateAuthClientByIdParams struct {
 	XCorrelationId *CorrelationId `json:"X-Correlation-Id,omitempty"`
 }
 
+// RevokeAuthClientSecretParams defines parameters for RevokeAuthClientSecret.
+type RevokeAuthClientSecretParams struct {
+	// correlation id for tracing purposes
+	XCorrelationId *CorrelationId `json:"X-Correlation-Id,omitempty"`
+}
+
+// RotateAuthClientSecretJSONBody defines parameters for RotateAuthClientSecret.
+type RotateAuthClientSecretJSONBody = RotateAuthClientSecretRequest
+
+// RotateAuthClientSecretParams defines parameters for RotateAuthClientSecret.
+type RotateAuthClientSecretParams struct {
+	// correlation id for tracing purposes
+	XCorrelationId *CorrelationId `json:"X-Correlation-Id,omitempty"`
+}
+
 // UploadFileParams defines parameters for UploadFile.
 type UploadFileParams struct {
 	// correlation id for tracing purposes
 	XCorrelationId *CorrelationId `json:"X-Correlation-Id,omitempty"`
 }
 
+// SearchFileJSONBody defines parameters for SearchFile.
+type SearchFileJSONBody = SearchFileRequest
+
+// SearchFileParams defines parameters for SearchFile.
+type SearchFileParams struct {
+	// correlation id for tracing purposes
+	XCorrelationId *CorrelationId `json:"X-Correlation-Id,omitempty"`
+}
+
 // DeleteFileByIdParams defines parameters for DeleteFileById.
 type DeleteFileByIdParams struct {
 	// correlation id for tracing purposes
@@ -340,6 +657,102 @@ type DeleteFileByIdParams struct {
 
 // RetrieveFileByIdParams defines parameters for RetrieveFileById.
 type RetrieveFileByIdParams struct {
+	// verify stored file checksum before the file is returned
+	VerifyChecksum *bool `form:"verify_checksum,omitempty" json:"verify_checksum,omitempty"`
+
+	// correlation id for tracing purposes
+	XCorrelationId *CorrelationId `json:"X-Correlation-Id,omitempty"`
+
+	// single byte range of the file, multiple ranges are ignored
+	Range *string `json:"Range,omitempty"`
+
+	// range is only applied when the entity tag or last modified date is still matched
+	IfRange *string `json:"If-Range,omitempty"`
+
+	// file is not returned when one of the entity tags is matched
+	IfNoneMatch *string `json:"If-None-Match,omitempty"`
+
+	// file is not returned when it's not modified since the date
+	IfModifiedSince *string `json:"If-Modified-Since,omitempty"`
+
+	// compressed file is returned as it is when its encoding is accepted (except for the ranged file)
+	AcceptEncoding *string `json:"Accept-Encoding,omitempty"`
+}
+
+// RetrieveFileMetaByIdParams defines parameters for RetrieveFileMetaById.
+type RetrieveFileMetaByIdParams struct {
+	// correlation id for tracing purposes
+	XCorrelationId *CorrelationId `json:"X-Correlation-Id,omitempty"`
+
+	// not modified is returned when one of the entity tags is matched
+	IfNoneMatch *string `json:"If-None-Match,omitempty"`
+
+	// not modified is returned when the file is not modified since the date
+	IfModifiedSince *string `json:"If-Modified-Since,omitempty"`
+}
+
+// RestoreFileByIdParams defines parameters for RestoreFileById.
+type RestoreFileByIdParams struct {
+	// correlation id for tracing purposes
+	XCorrelationId *CorrelationId `json:"X-Correlation-Id,omitempty"`
+}
+
+// GetUploadOptionsParams defines parameters for GetUploadOptions.
+type GetUploadOptionsParams struct {
+	// correlation id for tracing purposes
+	XCorrelationId *CorrelationId `json:"X-Correlation-Id,omitempty"`
+}
+
+// CreateUploadParams defines parameters for CreateUpload.
+type CreateUploadParams struct {
+	// correlation id for tracing purposes
+	XCorrelationId *CorrelationId `json:"X-Correlation-Id,omitempty"`
+
+	// tus protocol version used by the client
+	TusResumable TusResumable `json:"Tus-Resumable"`
+
+	// size of the entire upload in bytes
+	UploadLength int64 `json:"Upload-Length"`
+
+	// comma separated key and base64 encoded value pairs, `filename` is used as the file name
+	UploadMetadata *string `json:"Upload-Metadata,omitempty"`
+}
+
+// DeleteUploadByIdParams defines parameters for DeleteUploadById.
+type DeleteUploadByIdParams struct {
+	// correlation id for tracing purposes
+	XCorrelationId *CorrelationId `json:"X-Correlation-Id,omitempty"`
+
+	// tus protocol version used by the client
+	TusResumable TusResumable `json:"Tus-Resumable"`
+}
+
+// RetrieveUploadByIdParams defines parameters for RetrieveUploadById.
+type RetrieveUploadByIdParams struct {
+	// correlation id for tracing purposes
+	XCorrelationId *CorrelationId `json:"X-Correlation-Id,omitempty"`
+
+	// tus protocol version used by the client
+	TusResumable TusResumable `json:"Tus-Resumable"`
+}
+
+// AppendUploadByIdParams defines parameters for AppendUploadById.
+type AppendUploadByIdParams struct {
+	// correlation id for tracing purposes
+	XCorrelationId *CorrelationId `json:"X-Correlation-Id,omitempty"`
+
+	// tus protocol version used by the client
+	TusResumable TusResumable `json:"Tus-Resumable"`
+
+	// offset of the chunk, it must be equal to the current upload offset
+	UploadOffset int64 `json:"Upload-Offset"`
+}
+
+// IssueTokenFormdataBody defines parameters for IssueToken.
+type IssueTokenFormdataBody = IssueTokenRequest
+
+// IssueTokenParams defines parameters for IssueToken.
+type IssueTokenParams struct {
 	// correlation id for tracing purposes
 	XCorrelationId *CorrelationId `json:"X-Correlation-Id,omitempty"`
 }
@@ -347,12 +760,21 @@ type RetrieveFileByIdParams struct {
 // CreateAuthClientJSONRequestBody defines body for CreateAuthClient for application/json ContentType.
 type CreateAuthClientJSONRequestBody = CreateAuthClientJSONBody
 
+// RotateAuthClientSecretJSONRequestBody defines body for RotateAuthClientSecret for application/json ContentType.
+type RotateAuthClientSecretJSONRequestBody = RotateAuthClientSecretJSONBody
+
 // SearchAuthClientJSONRequestBody defines body for SearchAuthClient for application/json ContentType.
 type SearchAuthClientJSONRequestBody = SearchAuthClientJSONBody
 
+// SearchFileJSONRequestBody defines body for SearchFile for application/json ContentType.
+type SearchFileJSONRequestBody = SearchFileJSONBody
+
 // UpdateAuthClientByIdJSONRequestBody defines body for UpdateAuthClientById for application/json ContentType.
 type UpdateAuthClientByIdJSONRequestBody = UpdateAuthClientByIdJSONBody
 
+// IssueTokenFormdataRequestBody defines body for IssueToken for application/x-www-form-urlencoded ContentType.
+type IssueTokenFormdataRequestBody = IssueTokenFormdataBody
+
 // Getter for additional properties for CheckHealthData_Details. Returns the specified
 // element and whether it was found
 func (a CheckHealthData_Details) Get(fieldName string) (value CheckHealthDetail, found bool) {
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"os"
 	"os/signal"
@@ -18,8 +19,32 @@ func main() {
 		panic(err)
 	}
 
+	verificationCache, err := app.NewDefaultVerificationCache(config)
+	if err != nil {
+		panic(err)
+	}
+
+	logger, err := app.NewDefaultLog(config, fmt.Sprintf("%s-hybrid", config.AppName))
+	if err != nil {
+		panic(err)
+	}
+
+	repo, err := app.NewDefaultRepository(config)
+	if err != nil {
+		panic(err)
+	}
+
+	// @note: both apps share one scheduler so the jobs are not run twice by the same process
+	jobScheduler, err := app.NewDefaultJobScheduler(config, logger, repo, verificationCache)
+	if err != nil {
+		panic(err)
+	}
+
 	restApp, err := restapp.NewRestApp(
 		restapp.WithConfig(config),
+		restapp.WithRepository(repo),
+		restapp.WithJobScheduler(jobScheduler),
+		restapp.WithVerificationCache(verificationCache),
 	)
 	if err != nil {
 		panic(err)
@@ -27,6 +52,9 @@ func main() {
 
 	grpcApp, err := grpcapp.NewGrpcApp(
 		grpcapp.WithConfig(config),
+		grpcapp.WithRepository(repo),
+		grpcapp.WithJobScheduler(jobScheduler),
+		grpcapp.WithVerificationCache(verificationCache),
 	)
 	if err != nil {
 		panic(err)
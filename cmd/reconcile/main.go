@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/go-seidon/hippo/internal/app"
+	"github.com/go-seidon/hippo/internal/reconcile"
+	"github.com/go-seidon/provider/datetime"
+)
+
+func main() {
+	repair := flag.Bool("repair", false, "remove orphaned blobs and mark dangling records as deleted")
+	checksum := flag.Bool("checksum", false, "re-verify stored checksums against file content")
+	jsonReport := flag.Bool("json", false, "print the report as json")
+	orphanAge := flag.Duration("orphan-age", time.Hour, "minimum age of unreferenced blob before it is reported as orphan")
+	flag.Parse()
+
+	config, err := app.NewDefaultConfig()
+	if err != nil {
+		log.Fatalf("failed load config %v", err)
+	}
+
+	ctx := context.Background()
+	repo, err := app.NewDefaultRepository(config)
+	if err != nil {
+		log.Fatalf("failed create repository %v", err)
+	}
+
+	err = repo.Init(ctx)
+	if err != nil {
+		log.Fatalf("failed init repository %v", err)
+	}
+
+	fileManager, err := app.NewDefaultFileManager(config)
+	if err != nil {
+		log.Fatalf("failed create file manager %v", err)
+	}
+
+	reconciler := reconcile.NewReconciler(reconcile.ReconcilerParam{
+		FileRepo:    repo.GetFile(),
+		FileManager: fileManager,
+		Clock:       datetime.NewClock(),
+		Config: &reconcile.ReconcilerConfig{
+			Storage:    config.UploadStorage,
+			UploadDir:  config.UploadDirectory,
+			PartialDir: config.UploadPartialDirectory,
+			TrashDir:   config.FileTrashDirectory,
+			OrphanAge:  *orphanAge,
+		},
+	})
+
+	res, err := reconciler.Reconcile(ctx, reconcile.ReconcileParam{
+		Repair:         *repair,
+		VerifyChecksum: *checksum,
+	})
+	if err != nil {
+		log.Fatalf("failed reconcile %v", err)
+	}
+
+	if *jsonReport {
+		report, err := json.MarshalIndent(res, "", "  ")
+		if err != nil {
+			log.Fatalf("failed encode report %v", err)
+		}
+		fmt.Println(string(report))
+	} else {
+		printReport(res)
+	}
+
+	// @note: non zero exit code is used by cron alert to detect outstanding issues
+	if res.UnrepairedIssues() > 0 {
+		os.Exit(2)
+	}
+}
+
+func printReport(res *reconcile.ReconcileResult) {
+	fmt.Printf("records: %d, blobs: %d\n", res.Summary.TotalRecords, res.Summary.TotalBlobs)
+	fmt.Printf("orphaned blobs: %d\n", res.Summary.OrphanedBlobs)
+	fmt.Printf("dangling records: %d\n", res.Summary.DanglingRecords)
+	fmt.Printf("size mismatches: %d\n", res.Summary.SizeMismatches)
+	fmt.Printf("checksum mismatches: %d\n", res.Summary.ChecksumMismatches)
+	fmt.Printf("repaired: %d\n", res.Summary.Repaired)
+	for _, issue := range res.Issues {
+		fmt.Printf("- %s path=%s id=%s expected=%s actual=%s repaired=%t %s\n",
+			issue.Type, issue.Path, issue.FileId, issue.Expected, issue.Actual, issue.Repaired, issue.Error)
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+
+	"github.com/go-seidon/hippo/internal/app"
+	"github.com/go-seidon/hippo/internal/job"
+	"github.com/go-seidon/provider/datetime"
+)
+
+func main() {
+	batchSize := flag.Int("batch-size", 100, "number of files re-wrapped in every batch")
+	flag.Parse()
+
+	config, err := app.NewDefaultConfig()
+	if err != nil {
+		log.Fatalf("failed load config %v", err)
+	}
+
+	keyring, err := app.NewDefaultKeyring(config)
+	if err != nil {
+		log.Fatalf("failed create keyring %v", err)
+	}
+	if keyring == nil {
+		log.Fatalf("encryption key is not specified")
+	}
+
+	logger, err := app.NewDefaultLog(config, config.AppName)
+	if err != nil {
+		log.Fatalf("failed create logger %v", err)
+	}
+
+	ctx := context.Background()
+	repo, err := app.NewDefaultRepository(config)
+	if err != nil {
+		log.Fatalf("failed create repository %v", err)
+	}
+
+	err = repo.Init(ctx)
+	if err != nil {
+		log.Fatalf("failed init repository %v", err)
+	}
+
+	rewrapKey := job.NewRewrapKey(job.RewrapKeyParam{
+		FileRepo: repo.GetFile(),
+		Keyring:  keyring,
+		Clock:    datetime.NewClock(),
+		Logger:   logger,
+		Config: &job.RewrapKeyConfig{
+			BatchSize: int32(*batchSize),
+		},
+	})
+
+	err = rewrapKey.Run(ctx)
+	if err != nil {
+		log.Fatalf("failed re-wrap key %v", err)
+	}
+}
@@ -0,0 +1,30 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-seidon/hippo/internal/auth"
+	"github.com/go-seidon/provider/datetime"
+)
+
+// @note: verification cache is disabled when the cache ttl is not specified
+func NewDefaultVerificationCache(config *Config) (auth.VerificationCache, error) {
+	if config == nil {
+		return nil, fmt.Errorf("invalid config")
+	}
+
+	if config.AuthCacheTtl <= 0 {
+		return nil, nil
+	}
+
+	cache, err := auth.NewVerificationCache(auth.NewVerificationCacheParam{
+		Ttl:        time.Duration(config.AuthCacheTtl) * time.Second,
+		MaxEntries: config.AuthCacheMaxEntries,
+		Clock:      datetime.NewClock(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cache, nil
+}
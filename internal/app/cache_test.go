@@ -0,0 +1,55 @@
+package app_test
+
+import (
+	"fmt"
+
+	"github.com/go-seidon/hippo/internal/app"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cache Package", func() {
+
+	Context("NewDefaultVerificationCache function", Label("unit"), func() {
+		When("config is not specified", func() {
+			It("should return error", func() {
+				res, err := app.NewDefaultVerificationCache(nil)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("invalid config")))
+			})
+		})
+
+		When("cache ttl is not specified", func() {
+			It("should return empty result", func() {
+				res, err := app.NewDefaultVerificationCache(&app.Config{})
+
+				Expect(res).To(BeNil())
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("max entries is invalid", func() {
+			It("should return error", func() {
+				res, err := app.NewDefaultVerificationCache(&app.Config{
+					AuthCacheTtl: 60,
+				})
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("invalid cache max entries")))
+			})
+		})
+
+		When("config is valid", func() {
+			It("should return result", func() {
+				res, err := app.NewDefaultVerificationCache(&app.Config{
+					AuthCacheTtl:        60,
+					AuthCacheMaxEntries: 100,
+				})
+
+				Expect(res).ToNot(BeNil())
+				Expect(err).To(BeNil())
+			})
+		})
+	})
+})
@@ -16,6 +16,18 @@ type Config struct {
 	GRPCAppHost string `env:"GRPC_APP_HOST"`
 	GRPCAppPort int    `env:"GRPC_APP_PORT"`
 
+	AuthAdminClients        []string `env:"AUTH_ADMIN_CLIENTS"`
+	AuthTokenAlgorithm      string   `env:"AUTH_TOKEN_ALGORITHM"`
+	AuthTokenSecret         string   `env:"AUTH_TOKEN_SECRET"`
+	AuthTokenPrivateKeyFile string   `env:"AUTH_TOKEN_PRIVATE_KEY_FILE"`
+	AuthTokenPublicKeyFile  string   `env:"AUTH_TOKEN_PUBLIC_KEY_FILE"`
+	AuthTokenIssuer         string   `env:"AUTH_TOKEN_ISSUER"`
+	AuthTokenTtl            int64    `env:"AUTH_TOKEN_TTL"`
+	AuthSecretGracePeriod   int64    `env:"AUTH_SECRET_GRACE_PERIOD"`
+	AuthCacheTtl            int64    `env:"AUTH_CACHE_TTL"`
+	AuthCacheMaxEntries     int      `env:"AUTH_CACHE_MAX_ENTRIES"`
+	AuthCacheStatsInterval  int64    `env:"AUTH_CACHE_STATS_INTERVAL"`
+
 	RepositoryProvider string `env:"REPOSITORY_PROVIDER"`
 
 	MySQLPrimaryHost     string `env:"MYSQL_PRIMARY_HOST"`
@@ -41,8 +53,53 @@ type Config struct {
 	MongoReplicaName    string   `env:"MONGO_REPLICA_NAME"`
 	MongoReplicaHosts   []string `env:"MONGO_REPLICA_HOSTS"`
 
-	UploadFormSize  int64  `env:"UPLOAD_FORM_SIZE"`
-	UploadDirectory string `env:"UPLOAD_DIRECTORY"`
+	UploadFormSize                 int64  `env:"UPLOAD_FORM_SIZE"`
+	UploadDirectory                string `env:"UPLOAD_DIRECTORY"`
+	UploadStorage                  string `env:"UPLOAD_STORAGE"`
+	UploadLocation                 string `env:"UPLOAD_LOCATION"`
+	UploadChecksumMd5              bool   `env:"UPLOAD_CHECKSUM_MD5"`
+	UploadDeduplicate              bool   `env:"UPLOAD_DEDUPLICATE"`
+	UploadPartialDirectory         string `env:"UPLOAD_PARTIAL_DIRECTORY"`
+	UploadPartialSize              int64  `env:"UPLOAD_PARTIAL_SIZE"`
+	UploadMultipartTtl             int64  `env:"UPLOAD_MULTIPART_TTL"`
+	UploadMultipartCleanupInterval int64  `env:"UPLOAD_MULTIPART_CLEANUP_INTERVAL"`
+	UploadTempCleanupInterval      int64  `env:"UPLOAD_TEMP_CLEANUP_INTERVAL"`
+
+	UploadAllowedMimetypes  []string `env:"UPLOAD_ALLOWED_MIMETYPES"`
+	UploadDeniedMimetypes   []string `env:"UPLOAD_DENIED_MIMETYPES"`
+	UploadAllowedExtensions []string `env:"UPLOAD_ALLOWED_EXTENSIONS"`
+	UploadDeniedExtensions  []string `env:"UPLOAD_DENIED_EXTENSIONS"`
+	UploadVerifyExtension   bool     `env:"UPLOAD_VERIFY_EXTENSION"`
+
+	FilePurgeInterval  int64  `env:"FILE_PURGE_INTERVAL"`
+	FilePurgeRetention int64  `env:"FILE_PURGE_RETENTION"`
+	FilePurgeBatchSize int32  `env:"FILE_PURGE_BATCH_SIZE"`
+	FileTrashEnabled   bool   `env:"FILE_TRASH_ENABLED"`
+	FileTrashDirectory string `env:"FILE_TRASH_DIRECTORY"`
+	FileUnownedAccess  bool   `env:"FILE_UNOWNED_ACCESS"`
+
+	EncryptionEnabled bool     `env:"ENCRYPTION_ENABLED"`
+	EncryptionKeyId   string   `env:"ENCRYPTION_KEY_ID"`
+	EncryptionKeys    []string `env:"ENCRYPTION_KEYS"`
+	EncryptionKeyFile string   `env:"ENCRYPTION_KEY_FILE"`
+
+	CompressionAlgorithm string   `env:"COMPRESSION_ALGORITHM"`
+	CompressionMimetypes []string `env:"COMPRESSION_MIMETYPES"`
+
+	ScannerProvider      string `env:"SCANNER_PROVIDER"`
+	ScannerFailOpen      bool   `env:"SCANNER_FAIL_OPEN"`
+	ScannerSkipOversized bool   `env:"SCANNER_SKIP_OVERSIZED"`
+	ScannerTimeout       int64  `env:"SCANNER_TIMEOUT"`
+	ScannerClamdNetwork  string `env:"SCANNER_CLAMD_NETWORK"`
+	ScannerClamdAddress  string `env:"SCANNER_CLAMD_ADDRESS"`
+
+	S3Endpoint        string `env:"S3_ENDPOINT"`
+	S3Region          string `env:"S3_REGION"`
+	S3Bucket          string `env:"S3_BUCKET"`
+	S3AccessKeyId     string `env:"S3_ACCESS_KEY_ID"`
+	S3SecretAccessKey string `env:"S3_SECRET_ACCESS_KEY"`
+	S3UsePathStyle    bool   `env:"S3_USE_PATH_STYLE"`
+	S3PartSize        int64  `env:"S3_PART_SIZE"`
 }
 
 func NewDefaultConfig() (*Config, error) {
@@ -0,0 +1,43 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/go-seidon/hippo/internal/encryption"
+)
+
+// @note: master keys are taken from both the config and the key file (one key per line),
+// no keyring is returned when there is no key specified
+func NewDefaultKeyring(config *Config) (encryption.Keyring, error) {
+	if config == nil {
+		return nil, fmt.Errorf("invalid config")
+	}
+
+	lines := config.EncryptionKeys
+	if config.EncryptionKeyFile != "" {
+		content, err := os.ReadFile(config.EncryptionKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, strings.Split(string(content), "\n")...)
+	}
+
+	keys, err := encryption.ParseKeys(lines)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(keys) == 0 {
+		if config.EncryptionEnabled {
+			return nil, fmt.Errorf("encryption key is not specified")
+		}
+		return nil, nil
+	}
+
+	return encryption.NewKeyring(encryption.KeyringParam{
+		PrimaryKeyId: config.EncryptionKeyId,
+		Keys:         keys,
+	})
+}
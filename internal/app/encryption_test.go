@@ -0,0 +1,106 @@
+package app_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/go-seidon/hippo/internal/app"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Encryption Package", func() {
+
+	Context("NewDefaultKeyring function", Label("unit"), func() {
+		var (
+			key string
+		)
+
+		BeforeEach(func() {
+			key = "MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY="
+		})
+
+		When("config is not specified", func() {
+			It("should return error", func() {
+				res, err := app.NewDefaultKeyring(nil)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("invalid config")))
+			})
+		})
+
+		When("key is not specified", func() {
+			It("should return empty result", func() {
+				res, err := app.NewDefaultKeyring(&app.Config{})
+
+				Expect(res).To(BeNil())
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("key is not specified while encryption is enabled", func() {
+			It("should return error", func() {
+				res, err := app.NewDefaultKeyring(&app.Config{
+					EncryptionEnabled: true,
+				})
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("encryption key is not specified")))
+			})
+		})
+
+		When("key file is not available", func() {
+			It("should return error", func() {
+				res, err := app.NewDefaultKeyring(&app.Config{
+					EncryptionKeyFile: "unavailable-key-file",
+				})
+
+				Expect(res).To(BeNil())
+				Expect(err).ToNot(BeNil())
+			})
+		})
+
+		When("key is invalid", func() {
+			It("should return error", func() {
+				res, err := app.NewDefaultKeyring(&app.Config{
+					EncryptionKeyId: "key-1",
+					EncryptionKeys:  []string{"key-1:invalid"},
+				})
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("invalid key: key-1")))
+			})
+		})
+
+		When("primary key is not available", func() {
+			It("should return error", func() {
+				res, err := app.NewDefaultKeyring(&app.Config{
+					EncryptionKeyId: "key-2",
+					EncryptionKeys:  []string{"key-1:" + key},
+				})
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("primary key is not found")))
+			})
+		})
+
+		When("keys are specified in config and key file", func() {
+			It("should return result", func() {
+				dir, _ := os.MkdirTemp("", "keyring")
+				defer os.RemoveAll(dir)
+				keyFile := filepath.Join(dir, "keys")
+				os.WriteFile(keyFile, []byte("# rotated key\nkey-2:"+key+"\n"), 0600)
+
+				res, err := app.NewDefaultKeyring(&app.Config{
+					EncryptionKeyId:   "key-2",
+					EncryptionKeys:    []string{"key-1:" + key},
+					EncryptionKeyFile: keyFile,
+				})
+
+				Expect(err).To(BeNil())
+				Expect(res.PrimaryKeyId()).To(Equal("key-2"))
+			})
+		})
+	})
+})
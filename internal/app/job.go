@@ -0,0 +1,86 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-seidon/hippo/internal/auth"
+	"github.com/go-seidon/hippo/internal/filesystem"
+	"github.com/go-seidon/hippo/internal/job"
+	"github.com/go-seidon/hippo/internal/repository"
+	"github.com/go-seidon/hippo/internal/service"
+	"github.com/go-seidon/provider/datetime"
+	"github.com/go-seidon/provider/logging"
+)
+
+// @note: cache stats are not logged when the verification cache is disabled
+func NewDefaultJobScheduler(config *Config, logger logging.Logger, repo repository.Repository, cache auth.VerificationCache) (job.Scheduler, error) {
+	if config == nil {
+		return nil, fmt.Errorf("invalid config")
+	}
+
+	if repo == nil {
+		return nil, fmt.Errorf("invalid repository")
+	}
+
+	opts := []job.SchedulerOption{
+		job.WithLogger(logger),
+	}
+
+	// @note: leftover temp files of the writes interrupted by a crash are removed on startup
+	// and on every interval when it's specified
+	if config.UploadStorage == filesystem.PROVIDER_LOCAL {
+		cleanTempFile := job.NewCleanTempFile(job.CleanTempFileParam{
+			Cleaner: filesystem.NewFileManager(),
+			Clock:   datetime.NewClock(),
+			Logger:  logger,
+			Config: &job.CleanTempFileConfig{
+				Directory: config.UploadDirectory,
+				MinAge:    time.Hour,
+			},
+		})
+		opts = append(opts, job.AddJob(&job.BackgroundJob{
+			Name:       "clean-temp-file",
+			Interval:   time.Duration(config.UploadTempCleanupInterval) * time.Second,
+			Runner:     cleanTempFile,
+			RunOnStart: true,
+		}))
+	}
+
+	if config.FilePurgeInterval > 0 {
+		fileManager, err := NewDefaultFileManager(config)
+		if err != nil {
+			return nil, err
+		}
+
+		purgeFile := job.NewPurgeFile(job.PurgeFileParam{
+			FileRepo: repo.GetFile(),
+			Clock:    datetime.NewClock(),
+			Logger:   logger,
+			PurgeFn:  service.NewPurgeFn(fileManager, config.FileTrashDirectory),
+			Config: &job.PurgeFileConfig{
+				Retention: time.Duration(config.FilePurgeRetention) * time.Second,
+				BatchSize: config.FilePurgeBatchSize,
+			},
+		})
+		opts = append(opts, job.AddJob(&job.BackgroundJob{
+			Name:     "purge-file",
+			Interval: time.Duration(config.FilePurgeInterval) * time.Second,
+			Runner:   purgeFile,
+		}))
+	}
+
+	if cache != nil && config.AuthCacheStatsInterval > 0 {
+		logCacheStats := job.NewLogCacheStats(job.LogCacheStatsParam{
+			Cache:  cache,
+			Logger: logger,
+		})
+		opts = append(opts, job.AddJob(&job.BackgroundJob{
+			Name:     "log-cache-stats",
+			Interval: time.Duration(config.AuthCacheStatsInterval) * time.Second,
+			Runner:   logCacheStats,
+		}))
+	}
+
+	return job.NewScheduler(opts...), nil
+}
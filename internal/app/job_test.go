@@ -0,0 +1,109 @@
+package app_test
+
+import (
+	"fmt"
+
+	"github.com/go-seidon/hippo/internal/app"
+	mock_auth "github.com/go-seidon/hippo/internal/auth/mock"
+	mock_repository "github.com/go-seidon/hippo/internal/repository/mock"
+	mock_logging "github.com/go-seidon/provider/logging/mock"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Job Package", func() {
+
+	Context("NewDefaultJobScheduler function", Label("unit"), func() {
+		var (
+			config     *app.Config
+			logger     *mock_logging.MockLogger
+			repository *mock_repository.MockRepository
+			fileRepo   *mock_repository.MockFile
+		)
+
+		BeforeEach(func() {
+			t := GinkgoT()
+			ctrl := gomock.NewController(t)
+			logger = mock_logging.NewMockLogger(ctrl)
+			repository = mock_repository.NewMockRepository(ctrl)
+			fileRepo = mock_repository.NewMockFile(ctrl)
+			config = &app.Config{
+				UploadStorage:      "local",
+				FileTrashDirectory: "storage/trash",
+				FilePurgeInterval:  3600,
+				FilePurgeRetention: 86400,
+				FilePurgeBatchSize: 100,
+			}
+		})
+
+		When("config is not specified", func() {
+			It("should return error", func() {
+				res, err := app.NewDefaultJobScheduler(nil, logger, repository, nil)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("invalid config")))
+			})
+		})
+
+		When("repository is not specified", func() {
+			It("should return error", func() {
+				res, err := app.NewDefaultJobScheduler(config, logger, nil, nil)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("invalid repository")))
+			})
+		})
+
+		When("failed create file manager", func() {
+			It("should return error", func() {
+				config.UploadStorage = "invalid"
+
+				res, err := app.NewDefaultJobScheduler(config, logger, repository, nil)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("invalid storage provider")))
+			})
+		})
+
+		When("purge file is disabled", func() {
+			It("should return result", func() {
+				config.FilePurgeInterval = 0
+
+				res, err := app.NewDefaultJobScheduler(config, logger, repository, nil)
+
+				Expect(res).ToNot(BeNil())
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("cache stats is enabled", func() {
+			It("should return result", func() {
+				config.FilePurgeInterval = 0
+				config.AuthCacheStatsInterval = 300
+				cache := mock_auth.NewMockVerificationCache(gomock.NewController(GinkgoT()))
+
+				res, err := app.NewDefaultJobScheduler(config, logger, repository, cache)
+
+				Expect(res).ToNot(BeNil())
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("success create default job scheduler", func() {
+			It("should return result", func() {
+				repository.
+					EXPECT().
+					GetFile().
+					Return(fileRepo).
+					Times(1)
+
+				res, err := app.NewDefaultJobScheduler(config, logger, repository, nil)
+
+				Expect(res).ToNot(BeNil())
+				Expect(err).To(BeNil())
+			})
+		})
+	})
+
+})
@@ -0,0 +1,28 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/go-seidon/hippo/internal/file"
+)
+
+// @note: daily rotate is used when the location strategy is not specified
+func NewDefaultUploadLocation(config *Config) (file.UploadLocation, error) {
+	if config == nil {
+		return nil, fmt.Errorf("invalid config")
+	}
+
+	switch config.UploadLocation {
+	case "", file.LOCATION_DAILY:
+		return file.NewDailyRotate(file.DailyRotateParam{}), nil
+	case file.LOCATION_HOURLY:
+		return file.NewHourlyRotate(file.HourlyRotateParam{}), nil
+	case file.LOCATION_HASH:
+		return file.NewHashPrefix(file.HashPrefixParam{}), nil
+	case file.LOCATION_CLIENT:
+		return file.NewClientDirectory(file.ClientDirectoryParam{}), nil
+	case file.LOCATION_BUCKET:
+		return file.NewBucketDirectory(file.BucketDirectoryParam{}), nil
+	}
+	return nil, fmt.Errorf("invalid upload location")
+}
@@ -0,0 +1,49 @@
+package app_test
+
+import (
+	"fmt"
+
+	"github.com/go-seidon/hippo/internal/app"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Location Package", func() {
+
+	Context("NewDefaultUploadLocation function", Label("unit"), func() {
+		When("config is not specified", func() {
+			It("should return error", func() {
+				res, err := app.NewDefaultUploadLocation(nil)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("invalid config")))
+			})
+		})
+
+		When("location is not valid", func() {
+			It("should return error", func() {
+				res, err := app.NewDefaultUploadLocation(&app.Config{
+					UploadLocation: "invalid",
+				})
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("invalid upload location")))
+			})
+		})
+
+		When("location is valid", func() {
+			It("should return result", func() {
+				locations := []string{"", "daily", "hourly", "hash", "client", "bucket"}
+				for _, location := range locations {
+					res, err := app.NewDefaultUploadLocation(&app.Config{
+						UploadLocation: location,
+					})
+
+					Expect(res).ToNot(BeNil())
+					Expect(err).To(BeNil())
+				}
+			})
+		})
+	})
+
+})
@@ -0,0 +1,22 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/go-seidon/hippo/internal/file"
+)
+
+// @note: every file is allowed when the allowed and denied lists are empty
+func NewDefaultUploadPolicy(config *Config) (file.UploadPolicy, error) {
+	if config == nil {
+		return nil, fmt.Errorf("invalid config")
+	}
+
+	return file.NewUploadPolicy(file.UploadPolicyParam{
+		AllowedMimetypes:  config.UploadAllowedMimetypes,
+		DeniedMimetypes:   config.UploadDeniedMimetypes,
+		AllowedExtensions: config.UploadAllowedExtensions,
+		DeniedExtensions:  config.UploadDeniedExtensions,
+		VerifyExtension:   config.UploadVerifyExtension,
+	}), nil
+}
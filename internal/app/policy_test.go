@@ -0,0 +1,36 @@
+package app_test
+
+import (
+	"fmt"
+
+	"github.com/go-seidon/hippo/internal/app"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Policy Package", func() {
+
+	Context("NewDefaultUploadPolicy function", Label("unit"), func() {
+		When("config is not specified", func() {
+			It("should return error", func() {
+				res, err := app.NewDefaultUploadPolicy(nil)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("invalid config")))
+			})
+		})
+
+		When("success create policy", func() {
+			It("should return result", func() {
+				res, err := app.NewDefaultUploadPolicy(&app.Config{
+					UploadDeniedMimetypes:  []string{"application/x-msdownload"},
+					UploadDeniedExtensions: []string{"exe"},
+					UploadVerifyExtension:  true,
+				})
+
+				Expect(res).ToNot(BeNil())
+				Expect(err).To(BeNil())
+			})
+		})
+	})
+})
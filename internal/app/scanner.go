@@ -0,0 +1,35 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-seidon/hippo/internal/scanner"
+)
+
+// @note: every file is reported as clean when the scanner provider is not specified
+func NewDefaultScanner(config *Config) (scanner.Scanner, error) {
+	if config == nil {
+		return nil, fmt.Errorf("invalid config")
+	}
+
+	switch config.ScannerProvider {
+	case "", scanner.PROVIDER_NOOP:
+		return scanner.NewNoop(), nil
+	case scanner.PROVIDER_CLAMD:
+		if config.ScannerClamdNetwork != scanner.NETWORK_TCP &&
+			config.ScannerClamdNetwork != scanner.NETWORK_UNIX {
+			return nil, fmt.Errorf("invalid clamd network")
+		}
+		if config.ScannerClamdAddress == "" {
+			return nil, fmt.Errorf("invalid clamd address")
+		}
+
+		return scanner.NewClamd(scanner.ClamdParam{
+			Network: config.ScannerClamdNetwork,
+			Address: config.ScannerClamdAddress,
+			Timeout: time.Duration(config.ScannerTimeout) * time.Second,
+		}), nil
+	}
+	return nil, fmt.Errorf("invalid scanner provider")
+}
@@ -0,0 +1,82 @@
+package app_test
+
+import (
+	"fmt"
+
+	"github.com/go-seidon/hippo/internal/app"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Scanner Package", func() {
+
+	Context("NewDefaultScanner function", Label("unit"), func() {
+		When("config is not specified", func() {
+			It("should return error", func() {
+				res, err := app.NewDefaultScanner(nil)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("invalid config")))
+			})
+		})
+
+		When("scanner provider is invalid", func() {
+			It("should return error", func() {
+				res, err := app.NewDefaultScanner(&app.Config{
+					ScannerProvider: "invalid",
+				})
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("invalid scanner provider")))
+			})
+		})
+
+		When("clamd network is invalid", func() {
+			It("should return error", func() {
+				res, err := app.NewDefaultScanner(&app.Config{
+					ScannerProvider:     "clamd",
+					ScannerClamdNetwork: "udp",
+					ScannerClamdAddress: "localhost:3310",
+				})
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("invalid clamd network")))
+			})
+		})
+
+		When("clamd address is not specified", func() {
+			It("should return error", func() {
+				res, err := app.NewDefaultScanner(&app.Config{
+					ScannerProvider:     "clamd",
+					ScannerClamdNetwork: "tcp",
+				})
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("invalid clamd address")))
+			})
+		})
+
+		When("scanner provider is not specified", func() {
+			It("should return result", func() {
+				res, err := app.NewDefaultScanner(&app.Config{})
+
+				Expect(res).ToNot(BeNil())
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("scanner provider is clamd", func() {
+			It("should return result", func() {
+				res, err := app.NewDefaultScanner(&app.Config{
+					ScannerProvider:     "clamd",
+					ScannerTimeout:      60,
+					ScannerClamdNetwork: "unix",
+					ScannerClamdAddress: "/var/run/clamav/clamd.ctl",
+				})
+
+				Expect(res).ToNot(BeNil())
+				Expect(err).To(BeNil())
+			})
+		})
+	})
+})
@@ -0,0 +1,110 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/go-seidon/hippo/internal/compression"
+	"github.com/go-seidon/hippo/internal/encryption"
+	"github.com/go-seidon/hippo/internal/filesystem"
+	"github.com/go-seidon/hippo/internal/filesystem/s3"
+)
+
+// @note: files are encrypted when encryption is enabled,
+// the encrypted files are still readable as long as their keys are specified
+// and data is compressed before it's encrypted (when compression algorithm is specified)
+func NewDefaultFileManager(config *Config) (filesystem.FileManager, error) {
+	if config == nil {
+		return nil, fmt.Errorf("invalid config")
+	}
+
+	if config.UploadStorage != filesystem.PROVIDER_LOCAL &&
+		config.UploadStorage != filesystem.PROVIDER_S3 {
+		return nil, fmt.Errorf("invalid storage provider")
+	}
+
+	if config.CompressionAlgorithm != "" && !compression.IsSupported(config.CompressionAlgorithm) {
+		return nil, fmt.Errorf("invalid compression algorithm")
+	}
+
+	fileManager, err := newStorageFileManager(config)
+	if err != nil {
+		return nil, err
+	}
+
+	keyring, err := NewDefaultKeyring(config)
+	if err != nil {
+		return nil, err
+	}
+
+	if keyring != nil {
+		fileManager = encryption.NewFileManager(encryption.FileManagerParam{
+			FileManager: fileManager,
+			Keyring:     keyring,
+			Encrypt:     config.EncryptionEnabled,
+		})
+	}
+
+	// @note: compressed files are still readable when compression is disabled
+	return compression.NewFileManager(compression.FileManagerParam{
+		FileManager: fileManager,
+		Algorithm:   config.CompressionAlgorithm,
+		Mimetypes:   config.CompressionMimetypes,
+	}), nil
+}
+
+func newStorageFileManager(config *Config) (filesystem.FileManager, error) {
+	if config.UploadStorage == filesystem.PROVIDER_S3 {
+		client, err := s3.NewClient(s3.ClientParam{
+			Config: newS3Config(config),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return s3.NewFileManager(s3.FileManagerParam{
+			Client: client,
+		})
+	}
+	return filesystem.NewFileManager(), nil
+}
+
+func newS3Config(config *Config) *s3.ClientConfig {
+	return &s3.ClientConfig{
+		Endpoint:        config.S3Endpoint,
+		Region:          config.S3Region,
+		Bucket:          config.S3Bucket,
+		AccessKeyId:     config.S3AccessKeyId,
+		SecretAccessKey: config.S3SecretAccessKey,
+		UsePathStyle:    config.S3UsePathStyle,
+		PartSize:        config.S3PartSize,
+	}
+}
+
+func NewDefaultDirectoryManager(config *Config) (filesystem.DirectoryManager, error) {
+	if config == nil {
+		return nil, fmt.Errorf("invalid config")
+	}
+
+	if config.UploadStorage != filesystem.PROVIDER_LOCAL &&
+		config.UploadStorage != filesystem.PROVIDER_S3 {
+		return nil, fmt.Errorf("invalid storage provider")
+	}
+
+	if config.UploadStorage == filesystem.PROVIDER_S3 {
+		client, err := s3.NewClient(s3.ClientParam{
+			Config: newS3Config(config),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		dirManager, err := s3.NewDirectoryManager(s3.DirectoryManagerParam{
+			Client: client,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return dirManager, nil
+	}
+	return filesystem.NewDirectoryManager(), nil
+}
@@ -0,0 +1,179 @@
+package app_test
+
+import (
+	"fmt"
+
+	"github.com/go-seidon/hippo/internal/app"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Storage Package", func() {
+
+	Context("NewDefaultFileManager function", Label("unit"), func() {
+		When("config is not specified", func() {
+			It("should return error", func() {
+				res, err := app.NewDefaultFileManager(nil)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("invalid config")))
+			})
+		})
+
+		When("storage provider is not valid", func() {
+			It("should return error", func() {
+				res, err := app.NewDefaultFileManager(&app.Config{
+					UploadStorage: "invalid",
+				})
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("invalid storage provider")))
+			})
+		})
+
+		When("success create local file manager", func() {
+			It("should return result", func() {
+				res, err := app.NewDefaultFileManager(&app.Config{
+					UploadStorage: "local",
+				})
+
+				Expect(res).ToNot(BeNil())
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("encryption key is not specified", func() {
+			It("should return error", func() {
+				res, err := app.NewDefaultFileManager(&app.Config{
+					UploadStorage:     "local",
+					EncryptionEnabled: true,
+				})
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("encryption key is not specified")))
+			})
+		})
+
+		When("success create encrypted file manager", func() {
+			It("should return result", func() {
+				res, err := app.NewDefaultFileManager(&app.Config{
+					UploadStorage:     "local",
+					EncryptionEnabled: true,
+					EncryptionKeyId:   "key-1",
+					EncryptionKeys:    []string{"key-1:MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY="},
+				})
+
+				Expect(res).ToNot(BeNil())
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("compression algorithm is not valid", func() {
+			It("should return error", func() {
+				res, err := app.NewDefaultFileManager(&app.Config{
+					UploadStorage:        "local",
+					CompressionAlgorithm: "brotli",
+				})
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("invalid compression algorithm")))
+			})
+		})
+
+		When("success create compressed file manager", func() {
+			It("should return result", func() {
+				res, err := app.NewDefaultFileManager(&app.Config{
+					UploadStorage:        "local",
+					CompressionAlgorithm: "zstd",
+					CompressionMimetypes: []string{"text/*"},
+				})
+
+				Expect(res).ToNot(BeNil())
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("s3 config is not valid", func() {
+			It("should return error", func() {
+				res, err := app.NewDefaultFileManager(&app.Config{
+					UploadStorage: "s3",
+					S3Endpoint:    "http://localhost:9000",
+				})
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("invalid bucket")))
+			})
+		})
+
+		When("success create s3 file manager", func() {
+			It("should return result", func() {
+				res, err := app.NewDefaultFileManager(&app.Config{
+					UploadStorage: "s3",
+					S3Endpoint:    "http://localhost:9000",
+					S3Bucket:      "hippo",
+				})
+
+				Expect(res).ToNot(BeNil())
+				Expect(err).To(BeNil())
+			})
+		})
+	})
+
+	Context("NewDefaultDirectoryManager function", Label("unit"), func() {
+		When("config is not specified", func() {
+			It("should return error", func() {
+				res, err := app.NewDefaultDirectoryManager(nil)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("invalid config")))
+			})
+		})
+
+		When("storage provider is not valid", func() {
+			It("should return error", func() {
+				res, err := app.NewDefaultDirectoryManager(&app.Config{
+					UploadStorage: "invalid",
+				})
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("invalid storage provider")))
+			})
+		})
+
+		When("success create local directory manager", func() {
+			It("should return result", func() {
+				res, err := app.NewDefaultDirectoryManager(&app.Config{
+					UploadStorage: "local",
+				})
+
+				Expect(res).ToNot(BeNil())
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("failed create s3 client", func() {
+			It("should return error", func() {
+				res, err := app.NewDefaultDirectoryManager(&app.Config{
+					UploadStorage: "s3",
+					S3Endpoint:    "http://localhost:9000",
+				})
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("invalid bucket")))
+			})
+		})
+
+		When("success create s3 directory manager", func() {
+			It("should return result", func() {
+				res, err := app.NewDefaultDirectoryManager(&app.Config{
+					UploadStorage: "s3",
+					S3Endpoint:    "http://localhost:9000",
+					S3Bucket:      "hippo",
+				})
+
+				Expect(res).ToNot(BeNil())
+				Expect(err).To(BeNil())
+			})
+		})
+	})
+})
@@ -0,0 +1,63 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/go-seidon/hippo/internal/auth"
+	"github.com/go-seidon/hippo/internal/repository"
+	"github.com/go-seidon/provider/datetime"
+	"github.com/go-seidon/provider/hashing/bcrypt"
+)
+
+// @note: bearer token is disabled when the token algorithm is not specified
+func NewDefaultTokenAuth(config *Config, authRepo repository.Auth, cache auth.VerificationCache) (auth.TokenAuth, error) {
+	if config == nil {
+		return nil, fmt.Errorf("invalid config")
+	}
+
+	var signer auth.TokenSigner
+	var err error
+	switch config.AuthTokenAlgorithm {
+	case "":
+		return nil, nil
+	case auth.ALGORITHM_HS256:
+		signer, err = auth.NewHmacSigner([]byte(config.AuthTokenSecret))
+	case auth.ALGORITHM_RS256:
+		var privateKey, publicKey []byte
+		if config.AuthTokenPrivateKeyFile != "" {
+			privateKey, err = os.ReadFile(config.AuthTokenPrivateKeyFile)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if config.AuthTokenPublicKeyFile != "" {
+			publicKey, err = os.ReadFile(config.AuthTokenPublicKeyFile)
+			if err != nil {
+				return nil, err
+			}
+		}
+		signer, err = auth.NewRsaSigner(privateKey, publicKey)
+	default:
+		return nil, fmt.Errorf("invalid token algorithm")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if config.AuthTokenTtl <= 0 {
+		return nil, fmt.Errorf("invalid token ttl")
+	}
+
+	return auth.NewTokenAuth(auth.NewTokenAuthParam{
+		AuthRepo:     authRepo,
+		Hasher:       bcrypt.NewHasher(),
+		Clock:        datetime.NewClock(),
+		Signer:       signer,
+		Issuer:       config.AuthTokenIssuer,
+		Ttl:          time.Duration(config.AuthTokenTtl) * time.Second,
+		Cache:        cache,
+		AdminClients: config.AuthAdminClients,
+	}), nil
+}
@@ -0,0 +1,135 @@
+package app_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/go-seidon/hippo/internal/app"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Token Package", func() {
+
+	Context("NewDefaultTokenAuth function", Label("unit"), func() {
+		When("config is not specified", func() {
+			It("should return error", func() {
+				res, err := app.NewDefaultTokenAuth(nil, nil, nil)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("invalid config")))
+			})
+		})
+
+		When("algorithm is not specified", func() {
+			It("should return empty result", func() {
+				res, err := app.NewDefaultTokenAuth(&app.Config{}, nil, nil)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("algorithm is invalid", func() {
+			It("should return error", func() {
+				res, err := app.NewDefaultTokenAuth(&app.Config{
+					AuthTokenAlgorithm: "none",
+				}, nil, nil)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("invalid token algorithm")))
+			})
+		})
+
+		When("secret is not specified", func() {
+			It("should return error", func() {
+				res, err := app.NewDefaultTokenAuth(&app.Config{
+					AuthTokenAlgorithm: "HS256",
+					AuthTokenTtl:       3600,
+				}, nil, nil)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("invalid secret key")))
+			})
+		})
+
+		When("ttl is invalid", func() {
+			It("should return error", func() {
+				res, err := app.NewDefaultTokenAuth(&app.Config{
+					AuthTokenAlgorithm: "HS256",
+					AuthTokenSecret:    "secret",
+				}, nil, nil)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("invalid token ttl")))
+			})
+		})
+
+		When("hmac secret is specified", func() {
+			It("should return result", func() {
+				res, err := app.NewDefaultTokenAuth(&app.Config{
+					AuthTokenAlgorithm: "HS256",
+					AuthTokenSecret:    "secret",
+					AuthTokenTtl:       3600,
+				}, nil, nil)
+
+				Expect(res).ToNot(BeNil())
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("private key file is not available", func() {
+			It("should return error", func() {
+				res, err := app.NewDefaultTokenAuth(&app.Config{
+					AuthTokenAlgorithm:      "RS256",
+					AuthTokenPrivateKeyFile: "unavailable-key-file",
+					AuthTokenTtl:            3600,
+				}, nil, nil)
+
+				Expect(res).To(BeNil())
+				Expect(err).ToNot(BeNil())
+			})
+		})
+
+		When("public key file is not available", func() {
+			It("should return error", func() {
+				res, err := app.NewDefaultTokenAuth(&app.Config{
+					AuthTokenAlgorithm:     "RS256",
+					AuthTokenPublicKeyFile: "unavailable-key-file",
+					AuthTokenTtl:           3600,
+				}, nil, nil)
+
+				Expect(res).To(BeNil())
+				Expect(err).ToNot(BeNil())
+			})
+		})
+
+		When("rsa key file is specified", func() {
+			It("should return result", func() {
+				key, err := rsa.GenerateKey(rand.Reader, 2048)
+				Expect(err).To(BeNil())
+
+				keyFile := filepath.Join(GinkgoT().TempDir(), "private.pem")
+				err = os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{
+					Type:  "RSA PRIVATE KEY",
+					Bytes: x509.MarshalPKCS1PrivateKey(key),
+				}), 0600)
+				Expect(err).To(BeNil())
+
+				res, err := app.NewDefaultTokenAuth(&app.Config{
+					AuthTokenAlgorithm:      "RS256",
+					AuthTokenPrivateKeyFile: keyFile,
+					AuthTokenTtl:            3600,
+				}, nil, nil)
+
+				Expect(res).ToNot(BeNil())
+				Expect(err).To(BeNil())
+			})
+		})
+	})
+})
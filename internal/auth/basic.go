@@ -5,8 +5,10 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/go-seidon/hippo/internal/repository"
+	"github.com/go-seidon/provider/datetime"
 	"github.com/go-seidon/provider/encoding"
 	"github.com/go-seidon/provider/hashing"
 )
@@ -20,8 +22,10 @@ type CheckCredentialParam struct {
 	AuthToken string
 }
 
+// @note: identity is only available when the token is valid
 type CheckCredentialResult struct {
 	TokenValid bool
+	Identity   *Identity
 }
 
 func (r *CheckCredentialResult) IsValid() bool {
@@ -38,9 +42,12 @@ type ParseAuthTokenResult struct {
 }
 
 type basicAuth struct {
-	authRepo repository.Auth
-	encoder  encoding.Encoder
-	hasher   hashing.Hasher
+	authRepo     repository.Auth
+	encoder      encoding.Encoder
+	hasher       hashing.Hasher
+	clock        datetime.Clock
+	cache        VerificationCache
+	adminClients map[string]bool
 }
 
 func (a *basicAuth) ParseAuthToken(ctx context.Context, p ParseAuthTokenParam) (*ParseAuthTokenResult, error) {
@@ -73,6 +80,22 @@ func (a *basicAuth) ParseAuthToken(ctx context.Context, p ParseAuthTokenParam) (
 }
 
 func (a *basicAuth) CheckCredential(ctx context.Context, p CheckCredentialParam) (*CheckCredentialResult, error) {
+	if a.cache != nil {
+		identity, ok := a.cache.Get(p.AuthToken)
+		if ok {
+			res := &CheckCredentialResult{
+				TokenValid: true,
+				Identity:   identity,
+			}
+			return res, nil
+		}
+	}
+
+	generation := uint64(0)
+	if a.cache != nil {
+		generation = a.cache.Generation()
+	}
+
 	client, err := a.ParseAuthToken(ctx, ParseAuthTokenParam{
 		Token: p.AuthToken,
 	})
@@ -81,39 +104,96 @@ func (a *basicAuth) CheckCredential(ctx context.Context, p CheckCredentialParam)
 	}
 
 	res := &CheckCredentialResult{TokenValid: false}
-	authClient, err := a.authRepo.FindClient(ctx, repository.FindClientParam{
-		ClientId: client.ClientId,
+	authClient, err := verifyClient(ctx, a.authRepo, a.hasher, a.clock, client.ClientId, client.ClientSecret)
+	if err != nil {
+		if errors.Is(err, ErrInvalidCredential) {
+			return res, nil
+		}
+		return nil, err
+	}
+
+	res.TokenValid = true
+	res.Identity = &Identity{
+		Id:       authClient.Id,
+		ClientId: authClient.ClientId,
+		Admin:    a.adminClients[authClient.Id],
+		Scopes:   authClient.Scopes,
+	}
+
+	if a.cache != nil {
+		// @note: credential verified during the rotation grace period is not cached beyond the previous secret expiry
+		var expiresAt *time.Time
+		if authClient.IsPreviousSecretValid(a.clock.Now()) {
+			expiresAt = authClient.PreviousSecretExpiresAt
+		}
+		a.cache.Set(SetVerificationParam{
+			Token:      p.AuthToken,
+			Id:         authClient.Id,
+			Identity:   *res.Identity,
+			Generation: generation,
+			ExpiresAt:  expiresAt,
+		})
+	}
+	return res, nil
+}
+
+// @note: ErrInvalidCredential is returned when the client is not found, inactive or the secret is mismatch,
+// the previous secret is accepted until it's expired (rotation grace period)
+func verifyClient(ctx context.Context, authRepo repository.Auth, hasher hashing.Hasher, clock datetime.Clock, clientId, clientSecret string) (*repository.FindClientResult, error) {
+	authClient, err := authRepo.FindClient(ctx, repository.FindClientParam{
+		ClientId: clientId,
 	})
 	if err != nil {
 		if errors.Is(err, repository.ErrNotFound) {
-			return res, nil
+			return nil, ErrInvalidCredential
 		}
 		return nil, err
 	}
 
 	if authClient.Status != STATUS_ACTIVE {
-		return res, nil
+		return nil, ErrInvalidCredential
 	}
 
-	err = a.hasher.Verify(authClient.ClientSecret, client.ClientSecret)
-	if err != nil {
-		return res, nil
+	err = hasher.Verify(authClient.ClientSecret, clientSecret)
+	if err == nil {
+		return authClient, nil
 	}
 
-	res.TokenValid = true
-	return res, nil
+	if authClient.PreviousClientSecret == "" || !authClient.IsPreviousSecretValid(clock.Now()) {
+		return nil, ErrInvalidCredential
+	}
+
+	err = hasher.Verify(authClient.PreviousClientSecret, clientSecret)
+	if err != nil {
+		return nil, ErrInvalidCredential
+	}
+	return authClient, nil
 }
 
 type NewBasicAuthParam struct {
 	AuthRepo repository.Auth
 	Encoder  encoding.Encoder
 	Hasher   hashing.Hasher
+	Clock    datetime.Clock
+	// @note: credential is verified on every check when it's not specified
+	Cache VerificationCache
+	// @note: auth client record ids (not the client_id) which have the admin capability,
+	// so the client re-registered using the same client_id doesn't inherit it
+	AdminClients []string
 }
 
 func NewBasicAuth(p NewBasicAuthParam) *basicAuth {
+	adminClients := map[string]bool{}
+	for _, id := range p.AdminClients {
+		adminClients[id] = true
+	}
+
 	return &basicAuth{
-		authRepo: p.AuthRepo,
-		encoder:  p.Encoder,
-		hasher:   p.Hasher,
+		authRepo:     p.AuthRepo,
+		encoder:      p.Encoder,
+		hasher:       p.Hasher,
+		clock:        p.Clock,
+		cache:        p.Cache,
+		adminClients: adminClients,
 	}
 }
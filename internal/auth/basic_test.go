@@ -3,10 +3,13 @@ package auth_test
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-seidon/hippo/internal/auth"
+	mock_auth "github.com/go-seidon/hippo/internal/auth/mock"
 	"github.com/go-seidon/hippo/internal/repository"
 	mock_repository "github.com/go-seidon/hippo/internal/repository/mock"
+	mock_datetime "github.com/go-seidon/provider/datetime/mock"
 	mock_encoding "github.com/go-seidon/provider/encoding/mock"
 	mock_hashing "github.com/go-seidon/provider/hashing/mock"
 	"github.com/golang/mock/gomock"
@@ -138,6 +141,7 @@ var _ = Describe("Basic Auth Package", func() {
 			authRepo  *mock_repository.MockAuth
 			encoder   *mock_encoding.MockEncoder
 			hasher    *mock_hashing.MockHasher
+			clock     *mock_datetime.MockClock
 			basicAuth auth.BasicAuth
 			p         auth.CheckCredentialParam
 			findParam repository.FindClientParam
@@ -151,10 +155,12 @@ var _ = Describe("Basic Auth Package", func() {
 			authRepo = mock_repository.NewMockAuth(ctrl)
 			encoder = mock_encoding.NewMockEncoder(ctrl)
 			hasher = mock_hashing.NewMockHasher(ctrl)
+			clock = mock_datetime.NewMockClock(ctrl)
 			basicAuth = auth.NewBasicAuth(auth.NewBasicAuthParam{
 				AuthRepo: authRepo,
 				Encoder:  encoder,
 				Hasher:   hasher,
+				Clock:    clock,
 			})
 			p = auth.CheckCredentialParam{
 				AuthToken: "mock-token",
@@ -166,6 +172,7 @@ var _ = Describe("Basic Auth Package", func() {
 				Status:       "active",
 				ClientId:     "client_id",
 				ClientSecret: "hashed_client_secret",
+				Scopes:       []string{"file:read", "file:write"},
 			}
 		})
 
@@ -297,6 +304,367 @@ var _ = Describe("Basic Auth Package", func() {
 				res, err := basicAuth.CheckCredential(ctx, p)
 
 				Expect(res.IsValid()).To(BeTrue())
+				Expect(res.Identity).To(Equal(&auth.Identity{
+					ClientId: "client_id",
+					Admin:    false,
+					Scopes:   []string{"file:read", "file:write"},
+				}))
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("previous client secret is in grace period", func() {
+			It("should return result", func() {
+				currentTs := time.Now().UTC()
+				expiresAt := currentTs.Add(1 * time.Hour)
+				findRes.PreviousClientSecret = "hashed_previous_secret"
+				findRes.PreviousSecretExpiresAt = &expiresAt
+
+				encoder.
+					EXPECT().
+					Decode(gomock.Eq(p.AuthToken)).
+					Return([]byte("client_id:client_secret"), nil).
+					Times(1)
+
+				authRepo.
+					EXPECT().
+					FindClient(gomock.Eq(ctx), gomock.Eq(findParam)).
+					Return(findRes, nil).
+					Times(1)
+
+				hasher.
+					EXPECT().
+					Verify(gomock.Eq(findRes.ClientSecret), gomock.Eq("client_secret")).
+					Return(fmt.Errorf("invalid")).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				hasher.
+					EXPECT().
+					Verify(gomock.Eq("hashed_previous_secret"), gomock.Eq("client_secret")).
+					Return(nil).
+					Times(1)
+
+				res, err := basicAuth.CheckCredential(ctx, p)
+
+				Expect(res.IsValid()).To(BeTrue())
+				Expect(res.Identity.ClientId).To(Equal("client_id"))
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("previous client secret is expired", func() {
+			It("should return invalid result", func() {
+				currentTs := time.Now().UTC()
+				expiresAt := currentTs.Add(-1 * time.Second)
+				findRes.PreviousClientSecret = "hashed_previous_secret"
+				findRes.PreviousSecretExpiresAt = &expiresAt
+
+				encoder.
+					EXPECT().
+					Decode(gomock.Eq(p.AuthToken)).
+					Return([]byte("client_id:client_secret"), nil).
+					Times(1)
+
+				authRepo.
+					EXPECT().
+					FindClient(gomock.Eq(ctx), gomock.Eq(findParam)).
+					Return(findRes, nil).
+					Times(1)
+
+				hasher.
+					EXPECT().
+					Verify(gomock.Eq(findRes.ClientSecret), gomock.Eq("client_secret")).
+					Return(fmt.Errorf("invalid")).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				res, err := basicAuth.CheckCredential(ctx, p)
+
+				Expect(res.IsValid()).To(BeFalse())
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("previous client secret is mismatch", func() {
+			It("should return invalid result", func() {
+				currentTs := time.Now().UTC()
+				expiresAt := currentTs.Add(1 * time.Hour)
+				findRes.PreviousClientSecret = "hashed_previous_secret"
+				findRes.PreviousSecretExpiresAt = &expiresAt
+
+				encoder.
+					EXPECT().
+					Decode(gomock.Eq(p.AuthToken)).
+					Return([]byte("client_id:client_secret"), nil).
+					Times(1)
+
+				authRepo.
+					EXPECT().
+					FindClient(gomock.Eq(ctx), gomock.Eq(findParam)).
+					Return(findRes, nil).
+					Times(1)
+
+				hasher.
+					EXPECT().
+					Verify(gomock.Eq(findRes.ClientSecret), gomock.Eq("client_secret")).
+					Return(fmt.Errorf("invalid")).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				hasher.
+					EXPECT().
+					Verify(gomock.Eq("hashed_previous_secret"), gomock.Eq("client_secret")).
+					Return(fmt.Errorf("invalid")).
+					Times(1)
+
+				res, err := basicAuth.CheckCredential(ctx, p)
+
+				Expect(res.IsValid()).To(BeFalse())
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("credential is cached", func() {
+			It("should return cached identity", func() {
+				cache := mock_auth.NewMockVerificationCache(gomock.NewController(GinkgoT()))
+				basicAuth = auth.NewBasicAuth(auth.NewBasicAuthParam{
+					AuthRepo: authRepo,
+					Encoder:  encoder,
+					Hasher:   hasher,
+					Clock:    clock,
+					Cache:    cache,
+				})
+
+				identity := &auth.Identity{
+					ClientId: "client_id",
+					Scopes:   []string{"file:read"},
+				}
+				cache.
+					EXPECT().
+					Get(gomock.Eq(p.AuthToken)).
+					Return(identity, true).
+					Times(1)
+
+				res, err := basicAuth.CheckCredential(ctx, p)
+
+				Expect(res.IsValid()).To(BeTrue())
+				Expect(res.Identity).To(Equal(identity))
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("credential is not cached", func() {
+			It("should cache verified identity", func() {
+				currentTs := time.Now().UTC()
+				findRes.Id = "id"
+				cache := mock_auth.NewMockVerificationCache(gomock.NewController(GinkgoT()))
+				basicAuth = auth.NewBasicAuth(auth.NewBasicAuthParam{
+					AuthRepo: authRepo,
+					Encoder:  encoder,
+					Hasher:   hasher,
+					Clock:    clock,
+					Cache:    cache,
+				})
+
+				cache.
+					EXPECT().
+					Get(gomock.Eq(p.AuthToken)).
+					Return(nil, false).
+					Times(1)
+
+				cache.
+					EXPECT().
+					Generation().
+					Return(uint64(2)).
+					Times(1)
+
+				encoder.
+					EXPECT().
+					Decode(gomock.Eq(p.AuthToken)).
+					Return([]byte("client_id:client_secret"), nil).
+					Times(1)
+
+				authRepo.
+					EXPECT().
+					FindClient(gomock.Eq(ctx), gomock.Eq(findParam)).
+					Return(findRes, nil).
+					Times(1)
+
+				hasher.
+					EXPECT().
+					Verify(gomock.Eq(findRes.ClientSecret), gomock.Eq("client_secret")).
+					Return(nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				cache.
+					EXPECT().
+					Set(gomock.Eq(auth.SetVerificationParam{
+						Token: p.AuthToken,
+						Id:    "id",
+						Identity: auth.Identity{
+							Id:       "id",
+							ClientId: "client_id",
+							Scopes:   []string{"file:read", "file:write"},
+						},
+						Generation: 2,
+					})).
+					Times(1)
+
+				res, err := basicAuth.CheckCredential(ctx, p)
+
+				Expect(res.IsValid()).To(BeTrue())
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("invalid credential is not cached", func() {
+			It("should not cache the result", func() {
+				cache := mock_auth.NewMockVerificationCache(gomock.NewController(GinkgoT()))
+				basicAuth = auth.NewBasicAuth(auth.NewBasicAuthParam{
+					AuthRepo: authRepo,
+					Encoder:  encoder,
+					Hasher:   hasher,
+					Clock:    clock,
+					Cache:    cache,
+				})
+
+				cache.
+					EXPECT().
+					Get(gomock.Eq(p.AuthToken)).
+					Return(nil, false).
+					Times(1)
+
+				cache.
+					EXPECT().
+					Generation().
+					Return(uint64(2)).
+					Times(1)
+
+				encoder.
+					EXPECT().
+					Decode(gomock.Eq(p.AuthToken)).
+					Return([]byte("client_id:client_secret"), nil).
+					Times(1)
+
+				authRepo.
+					EXPECT().
+					FindClient(gomock.Eq(ctx), gomock.Eq(findParam)).
+					Return(findRes, nil).
+					Times(1)
+
+				hasher.
+					EXPECT().
+					Verify(gomock.Eq(findRes.ClientSecret), gomock.Eq("client_secret")).
+					Return(fmt.Errorf("invalid")).
+					Times(1)
+
+				res, err := basicAuth.CheckCredential(ctx, p)
+
+				Expect(res.IsValid()).To(BeFalse())
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("client id of the admin is re-registered", func() {
+			It("should return non admin identity", func() {
+				basicAuth = auth.NewBasicAuth(auth.NewBasicAuthParam{
+					AuthRepo:     authRepo,
+					Encoder:      encoder,
+					Hasher:       hasher,
+					AdminClients: []string{"admin-record-id"},
+				})
+				findRes.Id = "new-record-id"
+
+				encoder.
+					EXPECT().
+					Decode(gomock.Eq(p.AuthToken)).
+					Return([]byte("client_id:client_secret"), nil).
+					Times(1)
+
+				authRepo.
+					EXPECT().
+					FindClient(gomock.Eq(ctx), gomock.Eq(findParam)).
+					Return(findRes, nil).
+					Times(1)
+
+				hasher.
+					EXPECT().
+					Verify(gomock.Eq(findRes.ClientSecret), gomock.Eq("client_secret")).
+					Return(nil).
+					Times(1)
+
+				res, err := basicAuth.CheckCredential(ctx, p)
+
+				Expect(res.IsValid()).To(BeTrue())
+				Expect(res.Identity).To(Equal(&auth.Identity{
+					Id:       "new-record-id",
+					ClientId: "client_id",
+					Admin:    false,
+					Scopes:   []string{"file:read", "file:write"},
+				}))
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("client is admin", func() {
+			It("should return admin identity", func() {
+				basicAuth = auth.NewBasicAuth(auth.NewBasicAuthParam{
+					AuthRepo:     authRepo,
+					Encoder:      encoder,
+					Hasher:       hasher,
+					AdminClients: []string{"admin-record-id"},
+				})
+				findRes.Id = "admin-record-id"
+
+				encoder.
+					EXPECT().
+					Decode(gomock.Eq(p.AuthToken)).
+					Return([]byte("client_id:client_secret"), nil).
+					Times(1)
+
+				authRepo.
+					EXPECT().
+					FindClient(gomock.Eq(ctx), gomock.Eq(findParam)).
+					Return(findRes, nil).
+					Times(1)
+
+				hasher.
+					EXPECT().
+					Verify(gomock.Eq(findRes.ClientSecret), gomock.Eq("client_secret")).
+					Return(nil).
+					Times(1)
+
+				res, err := basicAuth.CheckCredential(ctx, p)
+
+				Expect(res.IsValid()).To(BeTrue())
+				Expect(res.Identity).To(Equal(&auth.Identity{
+					Id:       "admin-record-id",
+					ClientId: "client_id",
+					Admin:    true,
+					Scopes:   []string{"file:read", "file:write"},
+				}))
 				Expect(err).To(BeNil())
 			})
 		})
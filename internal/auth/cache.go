@@ -0,0 +1,252 @@
+package auth
+
+import (
+	"container/list"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-seidon/provider/datetime"
+)
+
+// @note: cache of the verified basic auth credentials,
+// so the client lookup and the secret hashing are skipped for the recently verified token.
+// It's kept in the process memory, other replica keeps serving its cached credential
+// of the changed client until it's expired so the ttl should be kept short
+type VerificationCache interface {
+	Get(token string) (*Identity, bool)
+	// @note: generation is captured before reading the client,
+	// so the credential of the client invalidated during the read is not cached
+	Generation() uint64
+	Set(p SetVerificationParam)
+	// @note: remove every cached credential of the auth client (record id)
+	Invalidate(id string)
+	Stats() VerificationStats
+}
+
+type SetVerificationParam struct {
+	Token    string
+	Id       string
+	Identity Identity
+	// @note: entry is skipped when the client is invalidated after the generation
+	Generation uint64
+	// @note: entry is expired before the ttl when it's specified
+	ExpiresAt *time.Time
+}
+
+type VerificationStats struct {
+	Hits          uint64
+	Misses        uint64
+	Entries       int
+	Invalidations int
+}
+
+type verificationEntry struct {
+	key       string
+	id        string
+	identity  Identity
+	expiresAt time.Time
+}
+
+type invalidation struct {
+	id         string
+	generation uint64
+	expiresAt  time.Time
+}
+
+type verificationCache struct {
+	mu         sync.Mutex
+	key        []byte
+	ttl        time.Duration
+	maxEntries int
+	clock      datetime.Clock
+	entries    map[string]*list.Element
+	clients    map[string]map[string]bool
+	recent     *list.List
+	hits       uint64
+	misses     uint64
+	generation uint64
+	// @note: generation when the client (record id) is invalidated,
+	// it's pruned once the ttl is passed and the credential read before
+	// the last pruned generation is not cached since its invalidation is unknown
+	invalidated   map[string]uint64
+	invalidations *list.List
+	pruned        uint64
+}
+
+func (c *verificationCache) Get(token string) (*Identity, bool) {
+	key := c.hashToken(token)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		c.misses++
+		return nil, false
+	}
+
+	entry := elem.Value.(*verificationEntry)
+	if !c.clock.Now().Before(entry.expiresAt) {
+		c.remove(elem)
+		c.misses++
+		return nil, false
+	}
+
+	c.recent.MoveToFront(elem)
+	c.hits++
+	identity := entry.identity
+	return &identity, true
+}
+
+func (c *verificationCache) Generation() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.generation
+}
+
+func (c *verificationCache) Set(p SetVerificationParam) {
+	key := c.hashToken(p.Token)
+	now := c.clock.Now()
+	expiresAt := now.Add(c.ttl)
+	if p.ExpiresAt != nil && p.ExpiresAt.Before(expiresAt) {
+		expiresAt = *p.ExpiresAt
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.prune(now)
+	if p.Generation < c.pruned || c.invalidated[p.Id] > p.Generation {
+		return
+	}
+
+	if elem, ok := c.entries[key]; ok {
+		c.remove(elem)
+	}
+
+	elem := c.recent.PushFront(&verificationEntry{
+		key:       key,
+		id:        p.Id,
+		identity:  p.Identity,
+		expiresAt: expiresAt,
+	})
+	c.entries[key] = elem
+	if c.clients[p.Id] == nil {
+		c.clients[p.Id] = map[string]bool{}
+	}
+	c.clients[p.Id][key] = true
+
+	// @note: the least recently used entry is evicted when the cache is full
+	for c.recent.Len() > c.maxEntries {
+		c.remove(c.recent.Back())
+	}
+}
+
+func (c *verificationCache) Invalidate(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.clock.Now()
+	c.prune(now)
+	c.generation++
+	c.invalidated[id] = c.generation
+	c.invalidations.PushBack(&invalidation{
+		id:         id,
+		generation: c.generation,
+		expiresAt:  now.Add(c.ttl),
+	})
+
+	for key := range c.clients[id] {
+		c.remove(c.entries[key])
+	}
+}
+
+func (c *verificationCache) Stats() VerificationStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return VerificationStats{
+		Hits:          c.hits,
+		Misses:        c.misses,
+		Entries:       c.recent.Len(),
+		Invalidations: len(c.invalidated),
+	}
+}
+
+// @note: the token is never kept as it is, only its keyed hash
+func (c *verificationCache) hashToken(token string) string {
+	mac := hmac.New(sha256.New, c.key)
+	mac.Write([]byte(token))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// @note: invalidations are ordered by the generation, so only the expired front is removed
+func (c *verificationCache) prune(now time.Time) {
+	for elem := c.invalidations.Front(); elem != nil; elem = c.invalidations.Front() {
+		entry := elem.Value.(*invalidation)
+		if now.Before(entry.expiresAt) {
+			return
+		}
+
+		c.invalidations.Remove(elem)
+		if c.invalidated[entry.id] == entry.generation {
+			delete(c.invalidated, entry.id)
+		}
+		c.pruned = entry.generation
+	}
+}
+
+func (c *verificationCache) remove(elem *list.Element) {
+	entry := c.recent.Remove(elem).(*verificationEntry)
+	delete(c.entries, entry.key)
+
+	keys := c.clients[entry.id]
+	delete(keys, entry.key)
+	if len(keys) == 0 {
+		delete(c.clients, entry.id)
+	}
+}
+
+type NewVerificationCacheParam struct {
+	Ttl        time.Duration
+	MaxEntries int
+	Clock      datetime.Clock
+	// @note: random key is generated when it's not specified
+	Key []byte
+}
+
+func NewVerificationCache(p NewVerificationCacheParam) (*verificationCache, error) {
+	if p.Ttl <= 0 {
+		return nil, fmt.Errorf("invalid cache ttl")
+	}
+	if p.MaxEntries <= 0 {
+		return nil, fmt.Errorf("invalid cache max entries")
+	}
+
+	key := p.Key
+	if len(key) == 0 {
+		key = make([]byte, 32)
+		_, err := rand.Read(key)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &verificationCache{
+		key:           key,
+		ttl:           p.Ttl,
+		maxEntries:    p.MaxEntries,
+		clock:         p.Clock,
+		entries:       map[string]*list.Element{},
+		clients:       map[string]map[string]bool{},
+		recent:        list.New(),
+		invalidated:   map[string]uint64{},
+		invalidations: list.New(),
+	}, nil
+}
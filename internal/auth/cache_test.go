@@ -0,0 +1,284 @@
+package auth_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-seidon/hippo/internal/auth"
+	mock_datetime "github.com/go-seidon/provider/datetime/mock"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Verification Cache Package", func() {
+	Context("NewVerificationCache function", Label("unit"), func() {
+		When("ttl is invalid", func() {
+			It("should return error", func() {
+				res, err := auth.NewVerificationCache(auth.NewVerificationCacheParam{
+					MaxEntries: 10,
+				})
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("invalid cache ttl")))
+			})
+		})
+
+		When("max entries is invalid", func() {
+			It("should return error", func() {
+				res, err := auth.NewVerificationCache(auth.NewVerificationCacheParam{
+					Ttl: time.Minute,
+				})
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("invalid cache max entries")))
+			})
+		})
+
+		When("parameter is valid", func() {
+			It("should return result", func() {
+				res, err := auth.NewVerificationCache(auth.NewVerificationCacheParam{
+					Ttl:        time.Minute,
+					MaxEntries: 10,
+				})
+
+				Expect(res).ToNot(BeNil())
+				Expect(err).To(BeNil())
+			})
+		})
+	})
+
+	Context("Get function", Label("unit"), func() {
+		var (
+			currentTs time.Time
+			clock     *mock_datetime.MockClock
+			cache     auth.VerificationCache
+			identity  auth.Identity
+		)
+
+		BeforeEach(func() {
+			currentTs = time.Now().UTC()
+			t := GinkgoT()
+			ctrl := gomock.NewController(t)
+			clock = mock_datetime.NewMockClock(ctrl)
+			cache, _ = auth.NewVerificationCache(auth.NewVerificationCacheParam{
+				Ttl:        time.Minute,
+				MaxEntries: 2,
+				Clock:      clock,
+			})
+			identity = auth.Identity{
+				ClientId: "client-id",
+				Scopes:   []string{"file:read"},
+			}
+		})
+
+		When("token is not cached", func() {
+			It("should return miss", func() {
+				res, ok := cache.Get("token")
+
+				Expect(res).To(BeNil())
+				Expect(ok).To(BeFalse())
+				Expect(cache.Stats()).To(Equal(auth.VerificationStats{
+					Hits:    0,
+					Misses:  1,
+					Entries: 0,
+				}))
+			})
+		})
+
+		When("token is cached", func() {
+			It("should return hit", func() {
+				clock.EXPECT().Now().Return(currentTs).Times(2)
+
+				cache.Set(auth.SetVerificationParam{
+					Token:    "token",
+					Id:       "id",
+					Identity: identity,
+				})
+				res, ok := cache.Get("token")
+
+				Expect(res).To(Equal(&identity))
+				Expect(ok).To(BeTrue())
+				Expect(cache.Stats()).To(Equal(auth.VerificationStats{
+					Hits:    1,
+					Misses:  0,
+					Entries: 1,
+				}))
+			})
+		})
+
+		When("cached token is expired", func() {
+			It("should return miss", func() {
+				clock.EXPECT().Now().Return(currentTs).Times(1)
+				clock.EXPECT().Now().Return(currentTs.Add(time.Minute)).Times(1)
+
+				cache.Set(auth.SetVerificationParam{
+					Token:    "token",
+					Id:       "id",
+					Identity: identity,
+				})
+				res, ok := cache.Get("token")
+
+				Expect(res).To(BeNil())
+				Expect(ok).To(BeFalse())
+				Expect(cache.Stats()).To(Equal(auth.VerificationStats{
+					Hits:    0,
+					Misses:  1,
+					Entries: 0,
+				}))
+			})
+		})
+
+		When("expiry is before the ttl", func() {
+			It("should return miss after the expiry", func() {
+				expiresAt := currentTs.Add(10 * time.Second)
+				clock.EXPECT().Now().Return(currentTs).Times(1)
+				clock.EXPECT().Now().Return(expiresAt).Times(1)
+
+				cache.Set(auth.SetVerificationParam{
+					Token:     "token",
+					Id:        "id",
+					Identity:  identity,
+					ExpiresAt: &expiresAt,
+				})
+				res, ok := cache.Get("token")
+
+				Expect(res).To(BeNil())
+				Expect(ok).To(BeFalse())
+			})
+		})
+
+		When("cache is full", func() {
+			It("should evict the least recently used token", func() {
+				clock.EXPECT().Now().Return(currentTs).AnyTimes()
+
+				cache.Set(auth.SetVerificationParam{Token: "token-1", Id: "id-1", Identity: identity})
+				cache.Set(auth.SetVerificationParam{Token: "token-2", Id: "id-2", Identity: identity})
+				_, ok := cache.Get("token-1")
+				Expect(ok).To(BeTrue())
+
+				cache.Set(auth.SetVerificationParam{Token: "token-3", Id: "id-3", Identity: identity})
+
+				_, ok = cache.Get("token-2")
+				Expect(ok).To(BeFalse())
+				_, ok = cache.Get("token-1")
+				Expect(ok).To(BeTrue())
+				_, ok = cache.Get("token-3")
+				Expect(ok).To(BeTrue())
+				Expect(cache.Stats().Entries).To(Equal(2))
+			})
+		})
+	})
+
+	Context("Invalidate function", Label("unit"), func() {
+		var (
+			clock *mock_datetime.MockClock
+			cache auth.VerificationCache
+			now   time.Time
+		)
+
+		BeforeEach(func() {
+			t := GinkgoT()
+			ctrl := gomock.NewController(t)
+			clock = mock_datetime.NewMockClock(ctrl)
+			now = time.Now().UTC()
+			clock.EXPECT().Now().DoAndReturn(func() time.Time { return now }).AnyTimes()
+			cache, _ = auth.NewVerificationCache(auth.NewVerificationCacheParam{
+				Ttl:        time.Minute,
+				MaxEntries: 10,
+				Clock:      clock,
+			})
+		})
+
+		When("client has cached tokens", func() {
+			It("should remove the client tokens", func() {
+				cache.Set(auth.SetVerificationParam{Token: "token-1", Id: "id-1"})
+				cache.Set(auth.SetVerificationParam{Token: "token-2", Id: "id-1"})
+				cache.Set(auth.SetVerificationParam{Token: "token-3", Id: "id-2"})
+
+				cache.Invalidate("id-1")
+
+				_, ok := cache.Get("token-1")
+				Expect(ok).To(BeFalse())
+				_, ok = cache.Get("token-2")
+				Expect(ok).To(BeFalse())
+				_, ok = cache.Get("token-3")
+				Expect(ok).To(BeTrue())
+				Expect(cache.Stats().Entries).To(Equal(1))
+			})
+		})
+
+		When("client has no cached token", func() {
+			It("should do nothing", func() {
+				cache.Set(auth.SetVerificationParam{Token: "token-1", Id: "id-1"})
+
+				cache.Invalidate("id-2")
+
+				Expect(cache.Stats().Entries).To(Equal(1))
+			})
+		})
+
+		When("client is invalidated during the verification", func() {
+			It("should not cache the outdated credential", func() {
+				generation := cache.Generation()
+
+				cache.Invalidate("id-1")
+				cache.Set(auth.SetVerificationParam{Token: "token-1", Id: "id-1", Generation: generation})
+				cache.Set(auth.SetVerificationParam{Token: "token-2", Id: "id-2", Generation: generation})
+
+				_, ok := cache.Get("token-1")
+				Expect(ok).To(BeFalse())
+				_, ok = cache.Get("token-2")
+				Expect(ok).To(BeTrue())
+			})
+		})
+
+		When("client is invalidated before the verification", func() {
+			It("should cache the credential", func() {
+				cache.Invalidate("id-1")
+				generation := cache.Generation()
+
+				cache.Set(auth.SetVerificationParam{Token: "token-1", Id: "id-1", Generation: generation})
+
+				_, ok := cache.Get("token-1")
+				Expect(ok).To(BeTrue())
+			})
+		})
+
+		When("invalidation is older than the ttl", func() {
+			It("should prune the invalidation", func() {
+				cache.Invalidate("id-1")
+				now = now.Add(30 * time.Second)
+				cache.Invalidate("id-2")
+				cache.Invalidate("id-1")
+				Expect(cache.Stats().Invalidations).To(Equal(2))
+
+				now = now.Add(31 * time.Second)
+				generation := cache.Generation()
+				cache.Set(auth.SetVerificationParam{Token: "token-1", Id: "id-3", Generation: generation})
+				Expect(cache.Stats().Invalidations).To(Equal(2))
+
+				now = now.Add(30 * time.Second)
+				cache.Set(auth.SetVerificationParam{Token: "token-2", Id: "id-3", Generation: generation})
+				Expect(cache.Stats().Invalidations).To(Equal(0))
+			})
+		})
+
+		When("credential is read before the pruned invalidation", func() {
+			It("should not cache the credential", func() {
+				generation := cache.Generation()
+				cache.Invalidate("id-1")
+
+				now = now.Add(2 * time.Minute)
+				cache.Set(auth.SetVerificationParam{Token: "token-1", Id: "id-1", Generation: generation})
+				cache.Set(auth.SetVerificationParam{Token: "token-2", Id: "id-1", Generation: cache.Generation()})
+
+				Expect(cache.Stats().Invalidations).To(Equal(0))
+				_, ok := cache.Get("token-1")
+				Expect(ok).To(BeFalse())
+				_, ok = cache.Get("token-2")
+				Expect(ok).To(BeTrue())
+			})
+		})
+	})
+})
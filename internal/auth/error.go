@@ -0,0 +1,7 @@
+package auth
+
+import "errors"
+
+var (
+	ErrInvalidCredential = errors.New("invalid credential")
+)
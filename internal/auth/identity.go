@@ -0,0 +1,43 @@
+package auth
+
+import "context"
+
+type identityKey struct{}
+
+// @note: identity of the authenticated client,
+// admin client is allowed to access the files owned by other clients
+// id is the auth client record id which is never reused unlike the client id,
+// so it's used to record the ownership and the usage
+type Identity struct {
+	Id       string
+	ClientId string
+	Admin    bool
+	Scopes   []string
+}
+
+// @note: client admin scope is granted to the admin client (specified in the config)
+// since the scope is not granted to the existing clients by the migration
+func (i *Identity) HasScope(scope string) bool {
+	if i == nil {
+		return false
+	}
+	if scope == SCOPE_CLIENT_ADMIN && i.Admin {
+		return true
+	}
+	for _, s := range i.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+func WithIdentity(ctx context.Context, identity *Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+// @note: nil is returned when the context is not authenticated
+func IdentityFromContext(ctx context.Context) *Identity {
+	identity, _ := ctx.Value(identityKey{}).(*Identity)
+	return identity
+}
@@ -0,0 +1,103 @@
+package auth_test
+
+import (
+	"context"
+
+	"github.com/go-seidon/hippo/internal/auth"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Identity Package", func() {
+
+	Context("HasScope function", Label("unit"), func() {
+		When("identity is nil", func() {
+			It("should return false", func() {
+				var identity *auth.Identity
+
+				res := identity.HasScope(auth.SCOPE_FILE_READ)
+
+				Expect(res).To(BeFalse())
+			})
+		})
+
+		When("scope is not granted", func() {
+			It("should return false", func() {
+				identity := &auth.Identity{
+					ClientId: "client-id",
+					Scopes:   []string{auth.SCOPE_FILE_READ},
+				}
+
+				res := identity.HasScope(auth.SCOPE_FILE_WRITE)
+
+				Expect(res).To(BeFalse())
+			})
+		})
+
+		When("scope is granted", func() {
+			It("should return true", func() {
+				identity := &auth.Identity{
+					ClientId: "client-id",
+					Scopes:   []string{auth.SCOPE_FILE_READ, auth.SCOPE_FILE_WRITE},
+				}
+
+				res := identity.HasScope(auth.SCOPE_FILE_WRITE)
+
+				Expect(res).To(BeTrue())
+			})
+		})
+
+		When("client admin scope is checked for the admin client", func() {
+			It("should return true", func() {
+				identity := &auth.Identity{
+					ClientId: "client-id",
+					Admin:    true,
+					Scopes:   []string{auth.SCOPE_FILE_READ},
+				}
+
+				res := identity.HasScope(auth.SCOPE_CLIENT_ADMIN)
+
+				Expect(res).To(BeTrue())
+			})
+		})
+
+		When("other scope is checked for the admin client", func() {
+			It("should return false", func() {
+				identity := &auth.Identity{
+					ClientId: "client-id",
+					Admin:    true,
+					Scopes:   []string{auth.SCOPE_FILE_READ},
+				}
+
+				res := identity.HasScope(auth.SCOPE_FILE_WRITE)
+
+				Expect(res).To(BeFalse())
+			})
+		})
+	})
+
+	Context("IdentityFromContext function", Label("unit"), func() {
+		When("identity is not available", func() {
+			It("should return nil", func() {
+				res := auth.IdentityFromContext(context.Background())
+
+				Expect(res).To(BeNil())
+			})
+		})
+
+		When("identity is available", func() {
+			It("should return result", func() {
+				identity := &auth.Identity{
+					ClientId: "client-id",
+					Admin:    true,
+				}
+				ctx := auth.WithIdentity(context.Background(), identity)
+
+				res := auth.IdentityFromContext(ctx)
+
+				Expect(res).To(Equal(identity))
+			})
+		})
+	})
+})
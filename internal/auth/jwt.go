@@ -0,0 +1,134 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+)
+
+const (
+	ALGORITHM_HS256 = "HS256"
+	ALGORITHM_RS256 = "RS256"
+)
+
+type TokenSigner interface {
+	Sign(claims TokenClaims) (string, error)
+	Verify(token string) (*TokenClaims, error)
+}
+
+// @note: subject is the auth client record id
+type TokenClaims struct {
+	Issuer    string
+	Subject   string
+	ClientId  string
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+	Scopes    []string
+}
+
+// @note: scopes are encoded as a space delimited "scope" claim (RFC 8693 section 4.2)
+// and the client id as "client_id" claim (RFC 8693 section 4.3)
+type jwtClaims struct {
+	jwt.StandardClaims
+	ClientId string `json:"client_id,omitempty"`
+	Scope    string `json:"scope,omitempty"`
+}
+
+type jwtSigner struct {
+	method    jwt.SigningMethod
+	signKey   interface{}
+	verifyKey interface{}
+}
+
+func (s *jwtSigner) Sign(claims TokenClaims) (string, error) {
+	if s.signKey == nil {
+		return "", fmt.Errorf("signing key is not specified")
+	}
+
+	token := jwt.NewWithClaims(s.method, jwtClaims{
+		StandardClaims: jwt.StandardClaims{
+			Issuer:    claims.Issuer,
+			Subject:   claims.Subject,
+			IssuedAt:  claims.IssuedAt.Unix(),
+			ExpiresAt: claims.ExpiresAt.Unix(),
+		},
+		ClientId: claims.ClientId,
+		Scope:    strings.Join(claims.Scopes, " "),
+	})
+	return token.SignedString(s.signKey)
+}
+
+// @note: only the signature is verified, the claims are validated by the caller
+func (s *jwtSigner) Verify(token string) (*TokenClaims, error) {
+	claims := &jwtClaims{}
+	parser := &jwt.Parser{
+		ValidMethods:         []string{s.method.Alg()},
+		SkipClaimsValidation: true,
+	}
+	_, err := parser.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		return s.verifyKey, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := &TokenClaims{
+		Issuer:    claims.Issuer,
+		Subject:   claims.Subject,
+		ClientId:  claims.ClientId,
+		IssuedAt:  time.Unix(claims.IssuedAt, 0).UTC(),
+		ExpiresAt: time.Unix(claims.ExpiresAt, 0).UTC(),
+		Scopes:    strings.Fields(claims.Scope),
+	}
+	return res, nil
+}
+
+func NewHmacSigner(key []byte) (*jwtSigner, error) {
+	if len(key) == 0 {
+		return nil, fmt.Errorf("invalid secret key")
+	}
+
+	return &jwtSigner{
+		method:    jwt.SigningMethodHS256,
+		signKey:   key,
+		verifyKey: key,
+	}, nil
+}
+
+// @note: private key is optional when the signer is only used to verify the token
+func NewRsaSigner(privateKey []byte, publicKey []byte) (*jwtSigner, error) {
+	var signKey *rsa.PrivateKey
+	var verifyKey *rsa.PublicKey
+	var err error
+
+	if len(privateKey) > 0 {
+		signKey, err = jwt.ParseRSAPrivateKeyFromPEM(privateKey)
+		if err != nil {
+			return nil, err
+		}
+		verifyKey = &signKey.PublicKey
+	}
+
+	if len(publicKey) > 0 {
+		verifyKey, err = jwt.ParseRSAPublicKeyFromPEM(publicKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if verifyKey == nil {
+		return nil, fmt.Errorf("invalid public key")
+	}
+
+	signer := &jwtSigner{
+		method:    jwt.SigningMethodRS256,
+		verifyKey: verifyKey,
+	}
+	if signKey != nil {
+		signer.signKey = signKey
+	}
+	return signer, nil
+}
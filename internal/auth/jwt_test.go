@@ -0,0 +1,197 @@
+package auth_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"time"
+
+	"github.com/go-seidon/hippo/internal/auth"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("JWT Package", func() {
+	Context("NewHmacSigner function", Label("unit"), func() {
+		When("key is empty", func() {
+			It("should return error", func() {
+				res, err := auth.NewHmacSigner(nil)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(MatchError("invalid secret key"))
+			})
+		})
+
+		When("key is specified", func() {
+			It("should return result", func() {
+				res, err := auth.NewHmacSigner([]byte("secret"))
+
+				Expect(res).ToNot(BeNil())
+				Expect(err).To(BeNil())
+			})
+		})
+	})
+
+	Context("NewRsaSigner function", Label("unit"), func() {
+		var (
+			privateKey []byte
+			publicKey  []byte
+		)
+
+		BeforeEach(func() {
+			privateKey, publicKey = generateRsaKey()
+		})
+
+		When("key is not specified", func() {
+			It("should return error", func() {
+				res, err := auth.NewRsaSigner(nil, nil)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(MatchError("invalid public key"))
+			})
+		})
+
+		When("private key is invalid", func() {
+			It("should return error", func() {
+				res, err := auth.NewRsaSigner([]byte("invalid"), nil)
+
+				Expect(res).To(BeNil())
+				Expect(err).ToNot(BeNil())
+			})
+		})
+
+		When("public key is invalid", func() {
+			It("should return error", func() {
+				res, err := auth.NewRsaSigner(nil, []byte("invalid"))
+
+				Expect(res).To(BeNil())
+				Expect(err).ToNot(BeNil())
+			})
+		})
+
+		When("private key is specified", func() {
+			It("should return result", func() {
+				res, err := auth.NewRsaSigner(privateKey, nil)
+
+				Expect(res).ToNot(BeNil())
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("only public key is specified", func() {
+			It("should not be able to sign", func() {
+				res, err := auth.NewRsaSigner(nil, publicKey)
+
+				Expect(res).ToNot(BeNil())
+				Expect(err).To(BeNil())
+
+				token, err := res.Sign(auth.TokenClaims{Subject: "client-id"})
+				Expect(token).To(Equal(""))
+				Expect(err).To(MatchError("signing key is not specified"))
+			})
+		})
+	})
+
+	Context("Sign and Verify function", Label("unit"), func() {
+		var (
+			claims auth.TokenClaims
+		)
+
+		BeforeEach(func() {
+			currentTs := time.Now().UTC().Truncate(time.Second)
+			claims = auth.TokenClaims{
+				Issuer:    "hippo",
+				Subject:   "id",
+				ClientId:  "client-id",
+				IssuedAt:  currentTs,
+				ExpiresAt: currentTs.Add(time.Hour),
+				Scopes:    []string{"file:read", "file:write"},
+			}
+		})
+
+		When("token is signed using hmac", func() {
+			It("should return claims", func() {
+				signer, _ := auth.NewHmacSigner([]byte("secret"))
+
+				token, err := signer.Sign(claims)
+				Expect(err).To(BeNil())
+
+				res, err := signer.Verify(token)
+				Expect(res).To(Equal(&claims))
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("token is signed using rsa", func() {
+			It("should return claims", func() {
+				privateKey, publicKey := generateRsaKey()
+				signer, _ := auth.NewRsaSigner(privateKey, nil)
+				verifier, _ := auth.NewRsaSigner(nil, publicKey)
+
+				token, err := signer.Sign(claims)
+				Expect(err).To(BeNil())
+
+				res, err := verifier.Verify(token)
+				Expect(res).To(Equal(&claims))
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("token is signed using other key", func() {
+			It("should return error", func() {
+				signer, _ := auth.NewHmacSigner([]byte("other-secret"))
+				verifier, _ := auth.NewHmacSigner([]byte("secret"))
+
+				token, _ := signer.Sign(claims)
+				res, err := verifier.Verify(token)
+
+				Expect(res).To(BeNil())
+				Expect(err).ToNot(BeNil())
+			})
+		})
+
+		When("token is signed using other algorithm", func() {
+			It("should return error", func() {
+				privateKey, _ := generateRsaKey()
+				signer, _ := auth.NewRsaSigner(privateKey, nil)
+				verifier, _ := auth.NewHmacSigner([]byte("secret"))
+
+				token, _ := signer.Sign(claims)
+				res, err := verifier.Verify(token)
+
+				Expect(res).To(BeNil())
+				Expect(err).ToNot(BeNil())
+			})
+		})
+
+		When("token is malformed", func() {
+			It("should return error", func() {
+				verifier, _ := auth.NewHmacSigner([]byte("secret"))
+
+				res, err := verifier.Verify("malformed-token")
+
+				Expect(res).To(BeNil())
+				Expect(err).ToNot(BeNil())
+			})
+		})
+	})
+})
+
+func generateRsaKey() (privateKey []byte, publicKey []byte) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	Expect(err).To(BeNil())
+
+	publicDer, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	Expect(err).To(BeNil())
+
+	privateKey = pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(key),
+	})
+	publicKey = pem.EncodeToMemory(&pem.Block{
+		Type:  "PUBLIC KEY",
+		Bytes: publicDer,
+	})
+	return privateKey, publicKey
+}
@@ -0,0 +1,102 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/auth/cache.go
+
+// Package mock_auth is a generated GoMock package.
+package mock_auth
+
+import (
+	reflect "reflect"
+
+	auth "github.com/go-seidon/hippo/internal/auth"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockVerificationCache is a mock of VerificationCache interface.
+type MockVerificationCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockVerificationCacheMockRecorder
+}
+
+// MockVerificationCacheMockRecorder is the mock recorder for MockVerificationCache.
+type MockVerificationCacheMockRecorder struct {
+	mock *MockVerificationCache
+}
+
+// NewMockVerificationCache creates a new mock instance.
+func NewMockVerificationCache(ctrl *gomock.Controller) *MockVerificationCache {
+	mock := &MockVerificationCache{ctrl: ctrl}
+	mock.recorder = &MockVerificationCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVerificationCache) EXPECT() *MockVerificationCacheMockRecorder {
+	return m.recorder
+}
+
+// Generation mocks base method.
+func (m *MockVerificationCache) Generation() uint64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Generation")
+	ret0, _ := ret[0].(uint64)
+	return ret0
+}
+
+// Generation indicates an expected call of Generation.
+func (mr *MockVerificationCacheMockRecorder) Generation() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Generation", reflect.TypeOf((*MockVerificationCache)(nil).Generation))
+}
+
+// Get mocks base method.
+func (m *MockVerificationCache) Get(token string) (*auth.Identity, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", token)
+	ret0, _ := ret[0].(*auth.Identity)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockVerificationCacheMockRecorder) Get(token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockVerificationCache)(nil).Get), token)
+}
+
+// Invalidate mocks base method.
+func (m *MockVerificationCache) Invalidate(id string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Invalidate", id)
+}
+
+// Invalidate indicates an expected call of Invalidate.
+func (mr *MockVerificationCacheMockRecorder) Invalidate(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invalidate", reflect.TypeOf((*MockVerificationCache)(nil).Invalidate), id)
+}
+
+// Set mocks base method.
+func (m *MockVerificationCache) Set(p auth.SetVerificationParam) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Set", p)
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockVerificationCacheMockRecorder) Set(p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockVerificationCache)(nil).Set), p)
+}
+
+// Stats mocks base method.
+func (m *MockVerificationCache) Stats() auth.VerificationStats {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stats")
+	ret0, _ := ret[0].(auth.VerificationStats)
+	return ret0
+}
+
+// Stats indicates an expected call of Stats.
+func (mr *MockVerificationCacheMockRecorder) Stats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stats", reflect.TypeOf((*MockVerificationCache)(nil).Stats))
+}
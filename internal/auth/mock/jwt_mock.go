@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/auth/jwt.go
+
+// Package mock_auth is a generated GoMock package.
+package mock_auth
+
+import (
+	reflect "reflect"
+
+	auth "github.com/go-seidon/hippo/internal/auth"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTokenSigner is a mock of TokenSigner interface.
+type MockTokenSigner struct {
+	ctrl     *gomock.Controller
+	recorder *MockTokenSignerMockRecorder
+}
+
+// MockTokenSignerMockRecorder is the mock recorder for MockTokenSigner.
+type MockTokenSignerMockRecorder struct {
+	mock *MockTokenSigner
+}
+
+// NewMockTokenSigner creates a new mock instance.
+func NewMockTokenSigner(ctrl *gomock.Controller) *MockTokenSigner {
+	mock := &MockTokenSigner{ctrl: ctrl}
+	mock.recorder = &MockTokenSignerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTokenSigner) EXPECT() *MockTokenSignerMockRecorder {
+	return m.recorder
+}
+
+// Sign mocks base method.
+func (m *MockTokenSigner) Sign(claims auth.TokenClaims) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sign", claims)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Sign indicates an expected call of Sign.
+func (mr *MockTokenSignerMockRecorder) Sign(claims interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sign", reflect.TypeOf((*MockTokenSigner)(nil).Sign), claims)
+}
+
+// Verify mocks base method.
+func (m *MockTokenSigner) Verify(token string) (*auth.TokenClaims, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", token)
+	ret0, _ := ret[0].(*auth.TokenClaims)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockTokenSignerMockRecorder) Verify(token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockTokenSigner)(nil).Verify), token)
+}
@@ -0,0 +1,8 @@
+package auth
+
+const (
+	SCOPE_FILE_READ    = "file:read"
+	SCOPE_FILE_WRITE   = "file:write"
+	SCOPE_FILE_DELETE  = "file:delete"
+	SCOPE_CLIENT_ADMIN = "client:admin"
+)
@@ -0,0 +1,197 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/go-seidon/hippo/internal/repository"
+	"github.com/go-seidon/provider/datetime"
+	"github.com/go-seidon/provider/hashing"
+)
+
+const (
+	TOKEN_TYPE_BEARER = "Bearer"
+)
+
+type TokenAuth interface {
+	IssueToken(ctx context.Context, p IssueTokenParam) (*IssueTokenResult, error)
+	CheckToken(ctx context.Context, p CheckTokenParam) (*CheckCredentialResult, error)
+}
+
+type IssueTokenParam struct {
+	ClientId     string
+	ClientSecret string
+}
+
+type IssueTokenResult struct {
+	AccessToken string
+	TokenType   string
+	ExpiresIn   int64
+	ExpiresAt   time.Time
+	Scopes      []string
+}
+
+type CheckTokenParam struct {
+	AccessToken string
+}
+
+type tokenAuth struct {
+	authRepo     repository.Auth
+	hasher       hashing.Hasher
+	clock        datetime.Clock
+	signer       TokenSigner
+	issuer       string
+	ttl          time.Duration
+	cache        VerificationCache
+	adminClients map[string]bool
+}
+
+// @note: ErrInvalidCredential is returned when the client credential is not valid
+func (a *tokenAuth) IssueToken(ctx context.Context, p IssueTokenParam) (*IssueTokenResult, error) {
+	if strings.TrimSpace(p.ClientId) == "" || strings.TrimSpace(p.ClientSecret) == "" {
+		return nil, ErrInvalidCredential
+	}
+
+	authClient, err := verifyClient(ctx, a.authRepo, a.hasher, a.clock, p.ClientId, p.ClientSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	currentTs := a.clock.Now()
+	expiresAt := currentTs.Add(a.ttl)
+	token, err := a.signer.Sign(TokenClaims{
+		Issuer:    a.issuer,
+		Subject:   authClient.Id,
+		ClientId:  authClient.ClientId,
+		IssuedAt:  currentTs,
+		ExpiresAt: expiresAt,
+		Scopes:    authClient.Scopes,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := &IssueTokenResult{
+		AccessToken: token,
+		TokenType:   TOKEN_TYPE_BEARER,
+		ExpiresIn:   int64(a.ttl.Seconds()),
+		ExpiresAt:   expiresAt,
+		Scopes:      authClient.Scopes,
+	}
+	return res, nil
+}
+
+// @note: the client is looked up so the token is rejected once the client is deactivated or deleted,
+// token issued before the secret rotation is rejected once the previous secret is revoked or expired
+func (a *tokenAuth) CheckToken(ctx context.Context, p CheckTokenParam) (*CheckCredentialResult, error) {
+	res := &CheckCredentialResult{TokenValid: false}
+	if strings.TrimSpace(p.AccessToken) == "" {
+		return res, nil
+	}
+
+	if a.cache != nil {
+		identity, ok := a.cache.Get(p.AccessToken)
+		if ok {
+			res.TokenValid = true
+			res.Identity = identity
+			return res, nil
+		}
+	}
+
+	claims, err := a.signer.Verify(p.AccessToken)
+	if err != nil {
+		return res, nil
+	}
+
+	if claims.Issuer != a.issuer || claims.Subject == "" || claims.ClientId == "" {
+		return res, nil
+	}
+
+	currentTs := a.clock.Now()
+	if !currentTs.Before(claims.ExpiresAt) {
+		return res, nil
+	}
+
+	generation := uint64(0)
+	if a.cache != nil {
+		generation = a.cache.Generation()
+	}
+
+	authClient, err := a.authRepo.FindClient(ctx, repository.FindClientParam{
+		Id: claims.Subject,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return res, nil
+		}
+		return nil, err
+	}
+
+	if authClient.Status != STATUS_ACTIVE || authClient.ClientId != claims.ClientId {
+		return res, nil
+	}
+
+	// @note: issued at claim is truncated to the second
+	expiresAt := claims.ExpiresAt
+	if authClient.SecretRotatedAt != nil && claims.IssuedAt.Before(authClient.SecretRotatedAt.Truncate(time.Second)) {
+		if !authClient.IsPreviousSecretValid(currentTs) {
+			return res, nil
+		}
+		if authClient.PreviousSecretExpiresAt.Before(expiresAt) {
+			expiresAt = *authClient.PreviousSecretExpiresAt
+		}
+	}
+
+	res.TokenValid = true
+	res.Identity = &Identity{
+		Id:       authClient.Id,
+		ClientId: authClient.ClientId,
+		Admin:    a.adminClients[authClient.Id],
+		Scopes:   authClient.Scopes,
+	}
+
+	if a.cache != nil {
+		a.cache.Set(SetVerificationParam{
+			Token:      p.AccessToken,
+			Id:         authClient.Id,
+			Identity:   *res.Identity,
+			Generation: generation,
+			ExpiresAt:  &expiresAt,
+		})
+	}
+	return res, nil
+}
+
+type NewTokenAuthParam struct {
+	AuthRepo repository.Auth
+	Hasher   hashing.Hasher
+	Clock    datetime.Clock
+	Signer   TokenSigner
+	Issuer   string
+	Ttl      time.Duration
+	// @note: client is looked up on every check when it's not specified
+	Cache VerificationCache
+	// @note: auth client record ids (not the client_id) which have the admin capability,
+	// so the client re-registered using the same client_id doesn't inherit it
+	AdminClients []string
+}
+
+func NewTokenAuth(p NewTokenAuthParam) *tokenAuth {
+	adminClients := map[string]bool{}
+	for _, id := range p.AdminClients {
+		adminClients[id] = true
+	}
+
+	return &tokenAuth{
+		authRepo:     p.AuthRepo,
+		hasher:       p.Hasher,
+		clock:        p.Clock,
+		signer:       p.Signer,
+		issuer:       p.Issuer,
+		ttl:          p.Ttl,
+		cache:        p.Cache,
+		adminClients: adminClients,
+	}
+}
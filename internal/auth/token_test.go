@@ -0,0 +1,664 @@
+package auth_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-seidon/hippo/internal/auth"
+	mock_auth "github.com/go-seidon/hippo/internal/auth/mock"
+	"github.com/go-seidon/hippo/internal/repository"
+	mock_repository "github.com/go-seidon/hippo/internal/repository/mock"
+	mock_datetime "github.com/go-seidon/provider/datetime/mock"
+	mock_hashing "github.com/go-seidon/provider/hashing/mock"
+	"github.com/go-seidon/provider/typeconv"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Token Auth Package", func() {
+	Context("IssueToken function", Label("unit"), func() {
+		var (
+			ctx       context.Context
+			currentTs time.Time
+			authRepo  *mock_repository.MockAuth
+			hasher    *mock_hashing.MockHasher
+			clock     *mock_datetime.MockClock
+			signer    *mock_auth.MockTokenSigner
+			tokenAuth auth.TokenAuth
+			p         auth.IssueTokenParam
+			findParam repository.FindClientParam
+			findRes   *repository.FindClientResult
+			claims    auth.TokenClaims
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			currentTs = time.Now().UTC()
+			t := GinkgoT()
+			ctrl := gomock.NewController(t)
+			authRepo = mock_repository.NewMockAuth(ctrl)
+			hasher = mock_hashing.NewMockHasher(ctrl)
+			clock = mock_datetime.NewMockClock(ctrl)
+			signer = mock_auth.NewMockTokenSigner(ctrl)
+			tokenAuth = auth.NewTokenAuth(auth.NewTokenAuthParam{
+				AuthRepo: authRepo,
+				Hasher:   hasher,
+				Clock:    clock,
+				Signer:   signer,
+				Issuer:   "hippo",
+				Ttl:      time.Hour,
+			})
+			p = auth.IssueTokenParam{
+				ClientId:     "client_id",
+				ClientSecret: "client_secret",
+			}
+			findParam = repository.FindClientParam{
+				ClientId: "client_id",
+			}
+			findRes = &repository.FindClientResult{
+				Id:           "id",
+				Status:       "active",
+				ClientId:     "client_id",
+				ClientSecret: "hashed_client_secret",
+				Scopes:       []string{"file:read"},
+			}
+			claims = auth.TokenClaims{
+				Issuer:    "hippo",
+				Subject:   "id",
+				ClientId:  "client_id",
+				IssuedAt:  currentTs,
+				ExpiresAt: currentTs.Add(time.Hour),
+				Scopes:    []string{"file:read"},
+			}
+		})
+
+		When("credential is not specified", func() {
+			It("should return error", func() {
+				p.ClientSecret = ""
+				res, err := tokenAuth.IssueToken(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(auth.ErrInvalidCredential))
+			})
+		})
+
+		When("failed find client", func() {
+			It("should return error", func() {
+				authRepo.
+					EXPECT().
+					FindClient(gomock.Eq(ctx), gomock.Eq(findParam)).
+					Return(nil, fmt.Errorf("db error")).
+					Times(1)
+
+				res, err := tokenAuth.IssueToken(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("db error")))
+			})
+		})
+
+		When("client is not found", func() {
+			It("should return error", func() {
+				authRepo.
+					EXPECT().
+					FindClient(gomock.Eq(ctx), gomock.Eq(findParam)).
+					Return(nil, repository.ErrNotFound).
+					Times(1)
+
+				res, err := tokenAuth.IssueToken(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(auth.ErrInvalidCredential))
+			})
+		})
+
+		When("client is inactive", func() {
+			It("should return error", func() {
+				findRes.Status = "inactive"
+				authRepo.
+					EXPECT().
+					FindClient(gomock.Eq(ctx), gomock.Eq(findParam)).
+					Return(findRes, nil).
+					Times(1)
+
+				res, err := tokenAuth.IssueToken(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(auth.ErrInvalidCredential))
+			})
+		})
+
+		When("client secret is invalid", func() {
+			It("should return error", func() {
+				authRepo.
+					EXPECT().
+					FindClient(gomock.Eq(ctx), gomock.Eq(findParam)).
+					Return(findRes, nil).
+					Times(1)
+
+				hasher.
+					EXPECT().
+					Verify(gomock.Eq("hashed_client_secret"), gomock.Eq("client_secret")).
+					Return(fmt.Errorf("invalid secret")).
+					Times(1)
+
+				res, err := tokenAuth.IssueToken(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(auth.ErrInvalidCredential))
+			})
+		})
+
+		When("failed sign token", func() {
+			It("should return error", func() {
+				authRepo.
+					EXPECT().
+					FindClient(gomock.Eq(ctx), gomock.Eq(findParam)).
+					Return(findRes, nil).
+					Times(1)
+
+				hasher.
+					EXPECT().
+					Verify(gomock.Eq("hashed_client_secret"), gomock.Eq("client_secret")).
+					Return(nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				signer.
+					EXPECT().
+					Sign(gomock.Eq(claims)).
+					Return("", fmt.Errorf("sign error")).
+					Times(1)
+
+				res, err := tokenAuth.IssueToken(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("sign error")))
+			})
+		})
+
+		When("success issue token", func() {
+			It("should return result", func() {
+				authRepo.
+					EXPECT().
+					FindClient(gomock.Eq(ctx), gomock.Eq(findParam)).
+					Return(findRes, nil).
+					Times(1)
+
+				hasher.
+					EXPECT().
+					Verify(gomock.Eq("hashed_client_secret"), gomock.Eq("client_secret")).
+					Return(nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				signer.
+					EXPECT().
+					Sign(gomock.Eq(claims)).
+					Return("access-token", nil).
+					Times(1)
+
+				res, err := tokenAuth.IssueToken(ctx, p)
+
+				expectedRes := &auth.IssueTokenResult{
+					AccessToken: "access-token",
+					TokenType:   "Bearer",
+					ExpiresIn:   3600,
+					ExpiresAt:   currentTs.Add(time.Hour),
+					Scopes:      []string{"file:read"},
+				}
+				Expect(res).To(Equal(expectedRes))
+				Expect(err).To(BeNil())
+			})
+		})
+	})
+
+	Context("CheckToken function", Label("unit"), func() {
+		var (
+			ctx       context.Context
+			currentTs time.Time
+			authRepo  *mock_repository.MockAuth
+			clock     *mock_datetime.MockClock
+			signer    *mock_auth.MockTokenSigner
+			tokenAuth auth.TokenAuth
+			p         auth.CheckTokenParam
+			claims    *auth.TokenClaims
+			findParam repository.FindClientParam
+			findRes   *repository.FindClientResult
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			currentTs = time.Now().UTC()
+			t := GinkgoT()
+			ctrl := gomock.NewController(t)
+			authRepo = mock_repository.NewMockAuth(ctrl)
+			clock = mock_datetime.NewMockClock(ctrl)
+			signer = mock_auth.NewMockTokenSigner(ctrl)
+			tokenAuth = auth.NewTokenAuth(auth.NewTokenAuthParam{
+				AuthRepo:     authRepo,
+				Clock:        clock,
+				Signer:       signer,
+				Issuer:       "hippo",
+				Ttl:          time.Hour,
+				AdminClients: []string{"admin-record-id"},
+			})
+			p = auth.CheckTokenParam{
+				AccessToken: "access-token",
+			}
+			claims = &auth.TokenClaims{
+				Issuer:    "hippo",
+				Subject:   "id",
+				ClientId:  "client_id",
+				IssuedAt:  currentTs.Add(-time.Minute),
+				ExpiresAt: currentTs.Add(time.Hour),
+				Scopes:    []string{"file:read"},
+			}
+			findParam = repository.FindClientParam{
+				Id: "id",
+			}
+			findRes = &repository.FindClientResult{
+				Id:       "id",
+				ClientId: "client_id",
+				Status:   "active",
+				Scopes:   []string{"file:read", "file:write"},
+			}
+		})
+
+		When("token is empty", func() {
+			It("should return invalid result", func() {
+				p.AccessToken = ""
+				res, err := tokenAuth.CheckToken(ctx, p)
+
+				Expect(res.IsValid()).To(BeFalse())
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("token is not verified", func() {
+			It("should return invalid result", func() {
+				signer.
+					EXPECT().
+					Verify(gomock.Eq("access-token")).
+					Return(nil, fmt.Errorf("signature is invalid")).
+					Times(1)
+
+				res, err := tokenAuth.CheckToken(ctx, p)
+
+				Expect(res.IsValid()).To(BeFalse())
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("token is issued by other issuer", func() {
+			It("should return invalid result", func() {
+				claims.Issuer = "other"
+				signer.
+					EXPECT().
+					Verify(gomock.Eq("access-token")).
+					Return(claims, nil).
+					Times(1)
+
+				res, err := tokenAuth.CheckToken(ctx, p)
+
+				Expect(res.IsValid()).To(BeFalse())
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("token has no client id", func() {
+			It("should return invalid result", func() {
+				claims.ClientId = ""
+				signer.
+					EXPECT().
+					Verify(gomock.Eq("access-token")).
+					Return(claims, nil).
+					Times(1)
+
+				res, err := tokenAuth.CheckToken(ctx, p)
+
+				Expect(res.IsValid()).To(BeFalse())
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("token is expired", func() {
+			It("should return invalid result", func() {
+				signer.
+					EXPECT().
+					Verify(gomock.Eq("access-token")).
+					Return(claims, nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(claims.ExpiresAt).
+					Times(1)
+
+				res, err := tokenAuth.CheckToken(ctx, p)
+
+				Expect(res.IsValid()).To(BeFalse())
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("failed find client", func() {
+			It("should return error", func() {
+				signer.
+					EXPECT().
+					Verify(gomock.Eq("access-token")).
+					Return(claims, nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				authRepo.
+					EXPECT().
+					FindClient(gomock.Eq(ctx), gomock.Eq(findParam)).
+					Return(nil, fmt.Errorf("db error")).
+					Times(1)
+
+				res, err := tokenAuth.CheckToken(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("db error")))
+			})
+		})
+
+		When("client is deleted", func() {
+			It("should return invalid result", func() {
+				signer.
+					EXPECT().
+					Verify(gomock.Eq("access-token")).
+					Return(claims, nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				authRepo.
+					EXPECT().
+					FindClient(gomock.Eq(ctx), gomock.Eq(findParam)).
+					Return(nil, repository.ErrNotFound).
+					Times(1)
+
+				res, err := tokenAuth.CheckToken(ctx, p)
+
+				Expect(res.IsValid()).To(BeFalse())
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("client is inactive", func() {
+			It("should return invalid result", func() {
+				findRes.Status = "inactive"
+				signer.
+					EXPECT().
+					Verify(gomock.Eq("access-token")).
+					Return(claims, nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				authRepo.
+					EXPECT().
+					FindClient(gomock.Eq(ctx), gomock.Eq(findParam)).
+					Return(findRes, nil).
+					Times(1)
+
+				res, err := tokenAuth.CheckToken(ctx, p)
+
+				Expect(res.IsValid()).To(BeFalse())
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("client id is changed", func() {
+			It("should return invalid result", func() {
+				findRes.ClientId = "other_client_id"
+				signer.
+					EXPECT().
+					Verify(gomock.Eq("access-token")).
+					Return(claims, nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				authRepo.
+					EXPECT().
+					FindClient(gomock.Eq(ctx), gomock.Eq(findParam)).
+					Return(findRes, nil).
+					Times(1)
+
+				res, err := tokenAuth.CheckToken(ctx, p)
+
+				Expect(res.IsValid()).To(BeFalse())
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("token is issued before the secret is revoked", func() {
+			It("should return invalid result", func() {
+				findRes.SecretRotatedAt = typeconv.Time(currentTs)
+				signer.
+					EXPECT().
+					Verify(gomock.Eq("access-token")).
+					Return(claims, nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				authRepo.
+					EXPECT().
+					FindClient(gomock.Eq(ctx), gomock.Eq(findParam)).
+					Return(findRes, nil).
+					Times(1)
+
+				res, err := tokenAuth.CheckToken(ctx, p)
+
+				Expect(res.IsValid()).To(BeFalse())
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("token is valid", func() {
+			It("should return result", func() {
+				signer.
+					EXPECT().
+					Verify(gomock.Eq("access-token")).
+					Return(claims, nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				authRepo.
+					EXPECT().
+					FindClient(gomock.Eq(ctx), gomock.Eq(findParam)).
+					Return(findRes, nil).
+					Times(1)
+
+				res, err := tokenAuth.CheckToken(ctx, p)
+
+				Expect(res.IsValid()).To(BeTrue())
+				Expect(res.Identity).To(Equal(&auth.Identity{
+					Id:       "id",
+					ClientId: "client_id",
+					Admin:    false,
+					Scopes:   []string{"file:read", "file:write"},
+				}))
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("token is valid and client is admin", func() {
+			It("should return result", func() {
+				claims.Subject = "admin-record-id"
+				findParam.Id = "admin-record-id"
+				findRes.Id = "admin-record-id"
+				signer.
+					EXPECT().
+					Verify(gomock.Eq("access-token")).
+					Return(claims, nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				authRepo.
+					EXPECT().
+					FindClient(gomock.Eq(ctx), gomock.Eq(findParam)).
+					Return(findRes, nil).
+					Times(1)
+
+				res, err := tokenAuth.CheckToken(ctx, p)
+
+				Expect(res.IsValid()).To(BeTrue())
+				Expect(res.Identity).To(Equal(&auth.Identity{
+					Id:       "admin-record-id",
+					ClientId: "client_id",
+					Admin:    true,
+					Scopes:   []string{"file:read", "file:write"},
+				}))
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("token is cached", func() {
+			It("should return cached identity", func() {
+				cache := mock_auth.NewMockVerificationCache(gomock.NewController(GinkgoT()))
+				tokenAuth = auth.NewTokenAuth(auth.NewTokenAuthParam{
+					AuthRepo: authRepo,
+					Clock:    clock,
+					Signer:   signer,
+					Issuer:   "hippo",
+					Ttl:      time.Hour,
+					Cache:    cache,
+				})
+
+				identity := &auth.Identity{
+					Id:       "id",
+					ClientId: "client_id",
+					Scopes:   []string{"file:read"},
+				}
+				cache.
+					EXPECT().
+					Get(gomock.Eq("access-token")).
+					Return(identity, true).
+					Times(1)
+
+				res, err := tokenAuth.CheckToken(ctx, p)
+
+				Expect(res.IsValid()).To(BeTrue())
+				Expect(res.Identity).To(Equal(identity))
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("token is issued during the rotation grace period", func() {
+			It("should cache identity until the previous secret is expired", func() {
+				previousExpiresAt := currentTs.Add(time.Minute)
+				findRes.SecretRotatedAt = typeconv.Time(currentTs)
+				findRes.PreviousClientSecret = "hashed_previous_secret"
+				findRes.PreviousSecretExpiresAt = &previousExpiresAt
+				cache := mock_auth.NewMockVerificationCache(gomock.NewController(GinkgoT()))
+				tokenAuth = auth.NewTokenAuth(auth.NewTokenAuthParam{
+					AuthRepo: authRepo,
+					Clock:    clock,
+					Signer:   signer,
+					Issuer:   "hippo",
+					Ttl:      time.Hour,
+					Cache:    cache,
+				})
+
+				cache.
+					EXPECT().
+					Get(gomock.Eq("access-token")).
+					Return(nil, false).
+					Times(1)
+
+				cache.
+					EXPECT().
+					Generation().
+					Return(uint64(2)).
+					Times(1)
+
+				signer.
+					EXPECT().
+					Verify(gomock.Eq("access-token")).
+					Return(claims, nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				authRepo.
+					EXPECT().
+					FindClient(gomock.Eq(ctx), gomock.Eq(findParam)).
+					Return(findRes, nil).
+					Times(1)
+
+				cache.
+					EXPECT().
+					Set(gomock.Eq(auth.SetVerificationParam{
+						Token: "access-token",
+						Id:    "id",
+						Identity: auth.Identity{
+							Id:       "id",
+							ClientId: "client_id",
+							Scopes:   []string{"file:read", "file:write"},
+						},
+						Generation: 2,
+						ExpiresAt:  &previousExpiresAt,
+					})).
+					Times(1)
+
+				res, err := tokenAuth.CheckToken(ctx, p)
+
+				Expect(res.IsValid()).To(BeTrue())
+				Expect(err).To(BeNil())
+			})
+		})
+	})
+})
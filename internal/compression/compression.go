@@ -0,0 +1,100 @@
+package compression
+
+import (
+	"compress/gzip"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	ALGORITHM_GZIP = "gzip"
+	ALGORITHM_ZSTD = "zstd"
+)
+
+var (
+	ErrorUnsupportedAlgorithm = errors.New("unsupported compression algorithm")
+)
+
+// @note: formats which are already compressed (as detected by the content sniffing)
+// are not compressed again since it only wastes the cpu time
+var compressedMimetypes = map[string]bool{
+	"application/x-gzip":           true,
+	"application/zip":              true,
+	"application/x-rar-compressed": true,
+	"application/x-7z-compressed":  true,
+	"application/x-bzip2":          true,
+	"application/x-xz":             true,
+	"application/zstd":             true,
+	"application/pdf":              true,
+	"application/ogg":              true,
+	"application/wasm":             true,
+	"font/woff":                    true,
+	"font/woff2":                   true,
+	"image/gif":                    true,
+	"image/jpeg":                   true,
+	"image/png":                    true,
+	"image/webp":                   true,
+	"image/heic":                   true,
+	"image/avif":                   true,
+	"audio/mpeg":                   true,
+	"audio/flac":                   true,
+	"video/quicktime":              true,
+	"video/mp4":                    true,
+	"video/webm":                   true,
+	"video/avi":                    true,
+}
+
+func IsSupported(algorithm string) bool {
+	return algorithm == ALGORITHM_GZIP || algorithm == ALGORITHM_ZSTD
+}
+
+func IsCompressed(mimetype string) bool {
+	return compressedMimetypes[baseMimetype(mimetype)]
+}
+
+// @note: pattern is either the exact mimetype (e.g: application/json) or the type wildcard (e.g: text/*),
+// mimetype parameters (e.g: charset) are ignored
+func MatchMimetype(mimetype string, patterns []string) bool {
+	mimetype = baseMimetype(mimetype)
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+		if pattern == mimetype {
+			return true
+		}
+		if strings.HasSuffix(pattern, "/*") && strings.HasPrefix(mimetype, strings.TrimSuffix(pattern, "*")) {
+			return true
+		}
+	}
+	return false
+}
+
+func baseMimetype(mimetype string) string {
+	return strings.ToLower(strings.TrimSpace(strings.Split(mimetype, ";")[0]))
+}
+
+func NewWriter(algorithm string, w io.Writer) (io.WriteCloser, error) {
+	switch algorithm {
+	case ALGORITHM_GZIP:
+		return gzip.NewWriter(w), nil
+	case ALGORITHM_ZSTD:
+		return zstd.NewWriter(w, zstd.WithEncoderConcurrency(1))
+	}
+	return nil, ErrorUnsupportedAlgorithm
+}
+
+func NewReader(algorithm string, r io.Reader) (io.ReadCloser, error) {
+	switch algorithm {
+	case ALGORITHM_GZIP:
+		return gzip.NewReader(r)
+	case ALGORITHM_ZSTD:
+		decoder, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, err
+		}
+		return decoder.IOReadCloser(), nil
+	}
+	return nil, ErrorUnsupportedAlgorithm
+}
@@ -0,0 +1,110 @@
+package compression_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/go-seidon/hippo/internal/compression"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestCompression(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Compression Package")
+}
+
+var _ = Describe("Compression", func() {
+
+	Context("MatchMimetype function", Label("unit"), func() {
+		When("mimetype is matched", func() {
+			It("should return true", func() {
+				patterns := []string{"text/*", "application/json"}
+
+				Expect(compression.MatchMimetype("text/plain", patterns)).To(BeTrue())
+				Expect(compression.MatchMimetype("text/csv; charset=utf-8", patterns)).To(BeTrue())
+				Expect(compression.MatchMimetype("Application/JSON", patterns)).To(BeTrue())
+			})
+		})
+
+		When("mimetype is not matched", func() {
+			It("should return false", func() {
+				patterns := []string{"text/*", "application/json"}
+
+				Expect(compression.MatchMimetype("image/png", patterns)).To(BeFalse())
+				Expect(compression.MatchMimetype("application/jsonl", patterns)).To(BeFalse())
+				Expect(compression.MatchMimetype("text/plain", nil)).To(BeFalse())
+			})
+		})
+	})
+
+	Context("IsCompressed function", Label("unit"), func() {
+		When("mimetype is already compressed", func() {
+			It("should return true", func() {
+				Expect(compression.IsCompressed("application/x-gzip")).To(BeTrue())
+				Expect(compression.IsCompressed("image/png")).To(BeTrue())
+			})
+		})
+
+		When("mimetype is not compressed", func() {
+			It("should return false", func() {
+				Expect(compression.IsCompressed("text/plain; charset=utf-8")).To(BeFalse())
+				Expect(compression.IsCompressed("application/octet-stream")).To(BeFalse())
+			})
+		})
+	})
+
+	Context("NewWriter function", Label("unit"), func() {
+		When("algorithm is not supported", func() {
+			It("should return error", func() {
+				res, err := compression.NewWriter("brotli", &bytes.Buffer{})
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(compression.ErrorUnsupportedAlgorithm))
+			})
+		})
+
+		When("algorithm is supported", func() {
+			It("should be readable by the reader", func() {
+				for _, algorithm := range []string{compression.ALGORITHM_GZIP, compression.ALGORITHM_ZSTD} {
+					plain := bytes.Repeat([]byte("hippo,"), 1000)
+					buff := &bytes.Buffer{}
+					w, err := compression.NewWriter(algorithm, buff)
+					Expect(err).To(BeNil())
+					w.Write(plain)
+					w.Close()
+					Expect(buff.Len()).To(BeNumerically("<", len(plain)))
+
+					r, err := compression.NewReader(algorithm, buff)
+					Expect(err).To(BeNil())
+					data, err := io.ReadAll(r)
+					r.Close()
+
+					Expect(err).To(BeNil())
+					Expect(data).To(Equal(plain))
+				}
+			})
+		})
+	})
+
+	Context("NewReader function", Label("unit"), func() {
+		When("algorithm is not supported", func() {
+			It("should return error", func() {
+				res, err := compression.NewReader("brotli", &bytes.Buffer{})
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(compression.ErrorUnsupportedAlgorithm))
+			})
+		})
+
+		When("data is not compressed", func() {
+			It("should return error", func() {
+				res, err := compression.NewReader(compression.ALGORITHM_GZIP, bytes.NewReader([]byte("plain")))
+
+				Expect(res).To(BeNil())
+				Expect(err).ToNot(BeNil())
+			})
+		})
+	})
+})
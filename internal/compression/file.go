@@ -0,0 +1,192 @@
+package compression
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+
+	"github.com/go-seidon/hippo/internal/file"
+	"github.com/go-seidon/hippo/internal/filesystem"
+)
+
+// @note: file manager decorator which compresses the saved data of the allowed mimetypes
+// and decompresses the opened data when its compression is specified,
+// compressed data is read from the start since it can't be seeked
+type fileManager struct {
+	filesystem.FileManager
+	algorithm string
+	mimetypes []string
+}
+
+func (fm *fileManager) SaveFile(ctx context.Context, p filesystem.SaveFileParam) (*filesystem.SaveFileResult, error) {
+	if fm.algorithm == "" || p.Reader == nil || !MatchMimetype(p.Mimetype, fm.mimetypes) {
+		return fm.FileManager.SaveFile(ctx, p)
+	}
+
+	// @note: the claimed mimetype may not be sniffed (e.g: gRPC upload)
+	// so the content is sniffed again using the same rule
+	reader := bufio.NewReaderSize(p.Reader, file.SNIFF_SIZE)
+	head, err := reader.Peek(file.SNIFF_SIZE)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return nil, err
+	}
+	p.Reader = reader
+	if IsCompressed(file.DetectMimetype(head)) {
+		return fm.FileManager.SaveFile(ctx, p)
+	}
+
+	compressReader, err := newCompressReader(fm.algorithm, reader)
+	if err != nil {
+		return nil, err
+	}
+
+	save, err := fm.FileManager.SaveFile(ctx, filesystem.SaveFileParam{
+		Name:       p.Name,
+		Reader:     compressReader,
+		Permission: p.Permission,
+		Exclusive:  p.Exclusive,
+		Mimetype:   p.Mimetype,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := &filesystem.SaveFileResult{
+		Size:          compressReader.size,
+		StoredSize:    save.StoredSize,
+		SavedAt:       save.SavedAt,
+		EncryptionKey: save.EncryptionKey,
+		Compression:   fm.algorithm,
+	}
+	return res, nil
+}
+
+func (fm *fileManager) OpenFile(ctx context.Context, p filesystem.OpenFileParam) (*filesystem.OpenFileResult, error) {
+	if p.Compression == "" {
+		return fm.FileManager.OpenFile(ctx, p)
+	}
+
+	open, err := fm.FileManager.OpenFile(ctx, filesystem.OpenFileParam{
+		Path:          p.Path,
+		EncryptionKey: p.EncryptionKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	decompressReader, err := NewReader(p.Compression, open.File)
+	if err != nil {
+		open.File.Close()
+		return nil, err
+	}
+
+	_, err = io.CopyN(io.Discard, decompressReader, p.Offset)
+	if err != nil && err != io.EOF {
+		decompressReader.Close()
+		open.File.Close()
+		return nil, err
+	}
+
+	var reader io.Reader = decompressReader
+	if p.Length > 0 {
+		reader = io.LimitReader(decompressReader, p.Length)
+	}
+
+	res := &filesystem.OpenFileResult{
+		File: &decompressedFile{
+			Reader:  reader,
+			closers: []io.Closer{decompressReader, open.File},
+		},
+	}
+	return res, nil
+}
+
+type decompressedFile struct {
+	io.Reader
+	closers []io.Closer
+}
+
+func (f *decompressedFile) Close() error {
+	var err error
+	for _, closer := range f.closers {
+		cerr := closer.Close()
+		if cerr != nil && err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
+// @note: data is compressed while it's read
+type compressReader struct {
+	reader io.Reader
+	writer io.WriteCloser
+	buff   *bytes.Buffer
+	chunk  []byte
+	size   int64
+	done   bool
+}
+
+func (r *compressReader) Read(p []byte) (int, error) {
+	for r.buff.Len() == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+
+		err := r.compress()
+		if err != nil {
+			return 0, err
+		}
+	}
+	return r.buff.Read(p)
+}
+
+func (r *compressReader) compress() error {
+	n, err := r.reader.Read(r.chunk)
+	if n > 0 {
+		r.size += int64(n)
+		_, werr := r.writer.Write(r.chunk[:n])
+		if werr != nil {
+			return werr
+		}
+	}
+
+	if err == io.EOF {
+		r.done = true
+		return r.writer.Close()
+	}
+	return err
+}
+
+func newCompressReader(algorithm string, r io.Reader) (*compressReader, error) {
+	buff := &bytes.Buffer{}
+	writer, err := NewWriter(algorithm, buff)
+	if err != nil {
+		return nil, err
+	}
+
+	cr := &compressReader{
+		reader: r,
+		writer: writer,
+		buff:   buff,
+		chunk:  make([]byte, 32*1024),
+	}
+	return cr, nil
+}
+
+type FileManagerParam struct {
+	FileManager filesystem.FileManager
+	// @note: when not specified the saved data is not compressed
+	// while the already compressed data is still decompressed on open
+	Algorithm string
+	Mimetypes []string
+}
+
+func NewFileManager(p FileManagerParam) *fileManager {
+	return &fileManager{
+		FileManager: p.FileManager,
+		algorithm:   p.Algorithm,
+		mimetypes:   p.Mimetypes,
+	}
+}
@@ -0,0 +1,353 @@
+package compression_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/go-seidon/hippo/internal/compression"
+	"github.com/go-seidon/hippo/internal/filesystem"
+	mock_filesystem "github.com/go-seidon/hippo/internal/filesystem/mock"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("File Manager", func() {
+
+	Context("NewFileManager function", Label("unit"), func() {
+		When("success create file manager", func() {
+			It("should return result", func() {
+				res := compression.NewFileManager(compression.FileManagerParam{})
+
+				Expect(res).ToNot(BeNil())
+			})
+		})
+	})
+
+	Context("SaveFile function", Label("unit"), func() {
+		var (
+			ctx       context.Context
+			fm        filesystem.FileManager
+			baseFm    *mock_filesystem.MockFileManager
+			currentTs time.Time
+			plain     []byte
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			t := GinkgoT()
+			ctrl := gomock.NewController(t)
+			baseFm = mock_filesystem.NewMockFileManager(ctrl)
+			fm = compression.NewFileManager(compression.FileManagerParam{
+				FileManager: baseFm,
+				Algorithm:   compression.ALGORITHM_GZIP,
+				Mimetypes:   []string{"text/*", "application/json"},
+			})
+			currentTs = time.Now()
+			plain = bytes.Repeat([]byte("id,name,size\n"), 1000)
+		})
+
+		When("compression is disabled", func() {
+			It("should save the plain data", func() {
+				fm = compression.NewFileManager(compression.FileManagerParam{
+					FileManager: baseFm,
+				})
+				param := filesystem.SaveFileParam{
+					Name:     "name",
+					Reader:   bytes.NewReader(plain),
+					Mimetype: "text/csv",
+				}
+				baseFm.
+					EXPECT().
+					SaveFile(gomock.Eq(ctx), gomock.Eq(param)).
+					Return(&filesystem.SaveFileResult{Size: 13000, StoredSize: 13000, SavedAt: currentTs}, nil).
+					Times(1)
+
+				res, err := fm.SaveFile(ctx, param)
+
+				Expect(err).To(BeNil())
+				Expect(res.Compression).To(BeEmpty())
+			})
+		})
+
+		When("mimetype is not allowed", func() {
+			It("should save the plain data", func() {
+				param := filesystem.SaveFileParam{
+					Name:     "name",
+					Reader:   bytes.NewReader(plain),
+					Mimetype: "application/octet-stream",
+				}
+				baseFm.
+					EXPECT().
+					SaveFile(gomock.Eq(ctx), gomock.Eq(param)).
+					Return(&filesystem.SaveFileResult{Size: 13000, StoredSize: 13000, SavedAt: currentTs}, nil).
+					Times(1)
+
+				res, err := fm.SaveFile(ctx, param)
+
+				Expect(err).To(BeNil())
+				Expect(res.Compression).To(BeEmpty())
+			})
+		})
+
+		When("content is already compressed", func() {
+			It("should save the plain data", func() {
+				gz := &bytes.Buffer{}
+				w, _ := compression.NewWriter(compression.ALGORITHM_GZIP, gz)
+				w.Write(plain)
+				w.Close()
+				content := gz.Bytes()
+
+				var saved []byte
+				baseFm.
+					EXPECT().
+					SaveFile(gomock.Eq(ctx), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, p filesystem.SaveFileParam) (*filesystem.SaveFileResult, error) {
+						saved, _ = io.ReadAll(p.Reader)
+						return &filesystem.SaveFileResult{
+							Size:       int64(len(saved)),
+							StoredSize: int64(len(saved)),
+							SavedAt:    currentTs,
+						}, nil
+					}).
+					Times(1)
+
+				res, err := fm.SaveFile(ctx, filesystem.SaveFileParam{
+					Name:     "name",
+					Reader:   bytes.NewReader(content),
+					Mimetype: "text/plain",
+				})
+
+				Expect(err).To(BeNil())
+				Expect(res.Compression).To(BeEmpty())
+				Expect(saved).To(Equal(content))
+			})
+		})
+
+		When("failed save file", func() {
+			It("should return error", func() {
+				baseFm.
+					EXPECT().
+					SaveFile(gomock.Eq(ctx), gomock.Any()).
+					Return(nil, fmt.Errorf("disk error")).
+					Times(1)
+
+				res, err := fm.SaveFile(ctx, filesystem.SaveFileParam{
+					Name:     "name",
+					Reader:   bytes.NewReader(plain),
+					Mimetype: "text/csv",
+				})
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("disk error")))
+			})
+		})
+
+		When("success save file", func() {
+			It("should return result", func() {
+				var saved []byte
+				baseFm.
+					EXPECT().
+					SaveFile(gomock.Eq(ctx), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, p filesystem.SaveFileParam) (*filesystem.SaveFileResult, error) {
+						Expect(p.Name).To(Equal("name"))
+						Expect(p.Exclusive).To(BeTrue())
+						saved, _ = io.ReadAll(p.Reader)
+						return &filesystem.SaveFileResult{
+							Size:       int64(len(saved)),
+							StoredSize: int64(len(saved)),
+							SavedAt:    currentTs,
+						}, nil
+					}).
+					Times(1)
+
+				res, err := fm.SaveFile(ctx, filesystem.SaveFileParam{
+					Name:      "name",
+					Reader:    bytes.NewReader(plain),
+					Exclusive: true,
+					Mimetype:  "text/csv; charset=utf-8",
+				})
+
+				Expect(err).To(BeNil())
+				Expect(res.Size).To(Equal(int64(len(plain))))
+				Expect(res.StoredSize).To(Equal(int64(len(saved))))
+				Expect(res.StoredSize).To(BeNumerically("<", len(plain)))
+				Expect(res.SavedAt).To(Equal(currentTs))
+				Expect(res.Compression).To(Equal(compression.ALGORITHM_GZIP))
+			})
+		})
+	})
+
+	Context("OpenFile function", Label("unit"), func() {
+		var (
+			ctx    context.Context
+			fm     filesystem.FileManager
+			baseFm *mock_filesystem.MockFileManager
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			t := GinkgoT()
+			ctrl := gomock.NewController(t)
+			baseFm = mock_filesystem.NewMockFileManager(ctrl)
+			fm = compression.NewFileManager(compression.FileManagerParam{
+				FileManager: baseFm,
+			})
+		})
+
+		When("compression is not specified", func() {
+			It("should open the stored data", func() {
+				param := filesystem.OpenFileParam{
+					Path:   "path",
+					Offset: 2,
+				}
+				openRes := &filesystem.OpenFileResult{
+					File: io.NopCloser(bytes.NewReader([]byte("content"))),
+				}
+				baseFm.
+					EXPECT().
+					OpenFile(gomock.Eq(ctx), gomock.Eq(param)).
+					Return(openRes, nil).
+					Times(1)
+
+				res, err := fm.OpenFile(ctx, param)
+
+				Expect(err).To(BeNil())
+				Expect(res).To(Equal(openRes))
+			})
+		})
+
+		When("failed open file", func() {
+			It("should return error", func() {
+				baseFm.
+					EXPECT().
+					OpenFile(gomock.Eq(ctx), gomock.Eq(filesystem.OpenFileParam{
+						Path: "path",
+					})).
+					Return(nil, filesystem.ErrorFileNotFound).
+					Times(1)
+
+				res, err := fm.OpenFile(ctx, filesystem.OpenFileParam{
+					Path:        "path",
+					Offset:      2,
+					Length:      3,
+					Compression: compression.ALGORITHM_GZIP,
+				})
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(filesystem.ErrorFileNotFound))
+			})
+		})
+
+		When("stored data is not compressed", func() {
+			It("should return error", func() {
+				baseFm.
+					EXPECT().
+					OpenFile(gomock.Eq(ctx), gomock.Any()).
+					Return(&filesystem.OpenFileResult{
+						File: io.NopCloser(bytes.NewReader([]byte("content"))),
+					}, nil).
+					Times(1)
+
+				res, err := fm.OpenFile(ctx, filesystem.OpenFileParam{
+					Path:        "path",
+					Compression: compression.ALGORITHM_GZIP,
+				})
+
+				Expect(res).To(BeNil())
+				Expect(err).ToNot(BeNil())
+			})
+		})
+
+		When("success open file", func() {
+			It("should return ranged plain data", func() {
+				gz := &bytes.Buffer{}
+				w, _ := compression.NewWriter(compression.ALGORITHM_GZIP, gz)
+				w.Write([]byte("content"))
+				w.Close()
+				baseFm.
+					EXPECT().
+					OpenFile(gomock.Eq(ctx), gomock.Any()).
+					Return(&filesystem.OpenFileResult{
+						File: io.NopCloser(gz),
+					}, nil).
+					Times(1)
+
+				res, err := fm.OpenFile(ctx, filesystem.OpenFileParam{
+					Path:        "path",
+					Offset:      2,
+					Length:      3,
+					Compression: compression.ALGORITHM_GZIP,
+				})
+				Expect(err).To(BeNil())
+				data, err := io.ReadAll(res.File)
+
+				Expect(err).To(BeNil())
+				Expect(string(data)).To(Equal("nte"))
+				Expect(res.File.Close()).To(BeNil())
+			})
+		})
+	})
+
+	Describe("File Manager", Label("integration"), func() {
+		var (
+			ctx   context.Context
+			fm    filesystem.FileManager
+			dir   string
+			plain []byte
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			dir, _ = os.MkdirTemp("", "compression-")
+			plain = bytes.Repeat([]byte(`{"id":"1","name":"hippo"}`+"\n"), 10000)
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		When("file is saved and opened", func() {
+			It("should return the plain data", func() {
+				for _, algorithm := range []string{compression.ALGORITHM_GZIP, compression.ALGORITHM_ZSTD} {
+					fm = compression.NewFileManager(compression.FileManagerParam{
+						FileManager: filesystem.NewFileManager(),
+						Algorithm:   algorithm,
+						Mimetypes:   []string{"application/json"},
+					})
+					path := filepath.Join(dir, algorithm)
+					save, err := fm.SaveFile(ctx, filesystem.SaveFileParam{
+						Name:       path,
+						Reader:     bytes.NewReader(plain),
+						Permission: 0644,
+						Mimetype:   "application/json",
+					})
+					Expect(err).To(BeNil())
+					Expect(save.Size).To(Equal(int64(len(plain))))
+					Expect(save.Compression).To(Equal(algorithm))
+
+					stat, _ := os.Stat(path)
+					Expect(stat.Size()).To(Equal(save.StoredSize))
+					Expect(stat.Size()).To(BeNumerically("<", len(plain)/5))
+
+					res, err := fm.OpenFile(ctx, filesystem.OpenFileParam{
+						Path:        path,
+						Offset:      100,
+						Compression: save.Compression,
+					})
+					Expect(err).To(BeNil())
+					data, err := io.ReadAll(res.File)
+					res.File.Close()
+
+					Expect(err).To(BeNil())
+					Expect(bytes.Equal(data, plain[100:])).To(BeTrue())
+				}
+			})
+		})
+	})
+})
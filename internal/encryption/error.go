@@ -0,0 +1,11 @@
+package encryption
+
+import "errors"
+
+var (
+	ErrorInvalidKey    = errors.New("invalid encryption key")
+	ErrorKeyNotFound   = errors.New("encryption key not found")
+	ErrorInvalidHeader = errors.New("invalid encryption header")
+	ErrorInvalidChunk  = errors.New("invalid encrypted chunk")
+	ErrorTruncated     = errors.New("encrypted data is truncated")
+)
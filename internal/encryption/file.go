@@ -0,0 +1,162 @@
+package encryption
+
+import (
+	"context"
+	"io"
+
+	"github.com/go-seidon/hippo/internal/filesystem"
+)
+
+// @note: file manager decorator which encrypts the saved data using a new data key
+// and decrypts the opened data when its encryption key is specified,
+// file saved without encryption key (e.g: before encryption is enabled) is opened as it is
+type fileManager struct {
+	filesystem.FileManager
+	keyring Keyring
+	encrypt bool
+}
+
+func (fm *fileManager) SaveFile(ctx context.Context, p filesystem.SaveFileParam) (*filesystem.SaveFileResult, error) {
+	if !fm.encrypt || p.Reader == nil {
+		return fm.FileManager.SaveFile(ctx, p)
+	}
+
+	dataKey, err := GenerateDataKey()
+	if err != nil {
+		return nil, err
+	}
+
+	wrap, err := fm.keyring.WrapKey(WrapKeyParam{
+		DataKey: dataKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	reader := &countReader{reader: p.Reader}
+	encryptReader, err := NewEncryptReader(reader, dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	save, err := fm.FileManager.SaveFile(ctx, filesystem.SaveFileParam{
+		Name:       p.Name,
+		Reader:     encryptReader,
+		Permission: p.Permission,
+		Exclusive:  p.Exclusive,
+		Mimetype:   p.Mimetype,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := &filesystem.SaveFileResult{
+		Size:       reader.size,
+		StoredSize: save.StoredSize,
+		SavedAt:    save.SavedAt,
+		EncryptionKey: &filesystem.EncryptionKey{
+			KeyId:   wrap.KeyId,
+			DataKey: wrap.WrappedKey,
+		},
+	}
+	return res, nil
+}
+
+// @note: only the chunks covering the requested range are read and decrypted
+func (fm *fileManager) OpenFile(ctx context.Context, p filesystem.OpenFileParam) (*filesystem.OpenFileResult, error) {
+	if p.EncryptionKey == nil {
+		return fm.FileManager.OpenFile(ctx, p)
+	}
+
+	unwrap, err := fm.keyring.UnwrapKey(UnwrapKeyParam{
+		KeyId:      p.EncryptionKey.KeyId,
+		WrappedKey: p.EncryptionKey.DataKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	headerFile, err := fm.FileManager.OpenFile(ctx, filesystem.OpenFileParam{
+		Path:   p.Path,
+		Length: HEADER_SIZE,
+	})
+	if err != nil {
+		return nil, err
+	}
+	header, err := ReadHeader(headerFile.File)
+	headerFile.File.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	index, sealedOffset := header.ChunkOffset(p.Offset)
+	sealedFile, err := fm.FileManager.OpenFile(ctx, filesystem.OpenFileParam{
+		Path:   p.Path,
+		Offset: sealedOffset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	decryptReader, err := NewDecryptReader(DecryptReaderParam{
+		Reader:     sealedFile.File,
+		DataKey:    unwrap.DataKey,
+		Header:     header,
+		ChunkIndex: index,
+	})
+	if err != nil {
+		sealedFile.File.Close()
+		return nil, err
+	}
+
+	_, err = io.CopyN(io.Discard, decryptReader, p.Offset-index*header.ChunkSize)
+	if err != nil && err != io.EOF {
+		sealedFile.File.Close()
+		return nil, err
+	}
+
+	var reader io.Reader = decryptReader
+	if p.Length > 0 {
+		reader = io.LimitReader(decryptReader, p.Length)
+	}
+
+	res := &filesystem.OpenFileResult{
+		File: &decryptedFile{
+			Reader: reader,
+			Closer: sealedFile.File,
+		},
+	}
+	return res, nil
+}
+
+type decryptedFile struct {
+	io.Reader
+	io.Closer
+}
+
+type countReader struct {
+	reader io.Reader
+	size   int64
+}
+
+func (r *countReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.size += int64(n)
+	return n, err
+}
+
+type FileManagerParam struct {
+	FileManager filesystem.FileManager
+	Keyring     Keyring
+	// @note: when disabled the saved data is not encrypted
+	// while the already encrypted data is still decrypted on open
+	Encrypt bool
+}
+
+func NewFileManager(p FileManagerParam) *fileManager {
+	return &fileManager{
+		FileManager: p.FileManager,
+		keyring:     p.Keyring,
+		encrypt:     p.Encrypt,
+	}
+}
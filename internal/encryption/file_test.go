@@ -0,0 +1,368 @@
+package encryption_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/go-seidon/hippo/internal/encryption"
+	"github.com/go-seidon/hippo/internal/filesystem"
+	mock_filesystem "github.com/go-seidon/hippo/internal/filesystem/mock"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("File Manager", func() {
+
+	Context("NewFileManager function", Label("unit"), func() {
+		When("success create file manager", func() {
+			It("should return result", func() {
+				res := encryption.NewFileManager(encryption.FileManagerParam{})
+
+				Expect(res).ToNot(BeNil())
+			})
+		})
+	})
+
+	Context("SaveFile function", Label("unit"), func() {
+		var (
+			ctx       context.Context
+			fm        filesystem.FileManager
+			baseFm    *mock_filesystem.MockFileManager
+			keyring   encryption.Keyring
+			currentTs time.Time
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			t := GinkgoT()
+			ctrl := gomock.NewController(t)
+			baseFm = mock_filesystem.NewMockFileManager(ctrl)
+			keyring, _ = encryption.NewKeyring(encryption.KeyringParam{
+				PrimaryKeyId: "key-1",
+				Keys: map[string][]byte{
+					"key-1": bytes.Repeat([]byte("a"), encryption.KEY_SIZE),
+				},
+			})
+			fm = encryption.NewFileManager(encryption.FileManagerParam{
+				FileManager: baseFm,
+				Keyring:     keyring,
+				Encrypt:     true,
+			})
+			currentTs = time.Now()
+		})
+
+		When("encryption is disabled", func() {
+			It("should save the plain data", func() {
+				fm = encryption.NewFileManager(encryption.FileManagerParam{
+					FileManager: baseFm,
+					Keyring:     keyring,
+				})
+				param := filesystem.SaveFileParam{
+					Name:   "name",
+					Reader: bytes.NewReader([]byte("content")),
+				}
+				baseFm.
+					EXPECT().
+					SaveFile(gomock.Eq(ctx), gomock.Eq(param)).
+					Return(&filesystem.SaveFileResult{Size: 7, SavedAt: currentTs}, nil).
+					Times(1)
+
+				res, err := fm.SaveFile(ctx, param)
+
+				Expect(err).To(BeNil())
+				Expect(res.EncryptionKey).To(BeNil())
+			})
+		})
+
+		When("failed save file", func() {
+			It("should return error", func() {
+				baseFm.
+					EXPECT().
+					SaveFile(gomock.Eq(ctx), gomock.Any()).
+					Return(nil, fmt.Errorf("disk error")).
+					Times(1)
+
+				res, err := fm.SaveFile(ctx, filesystem.SaveFileParam{
+					Name:   "name",
+					Reader: bytes.NewReader([]byte("content")),
+				})
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("disk error")))
+			})
+		})
+
+		When("success save file", func() {
+			It("should return result", func() {
+				var saved []byte
+				baseFm.
+					EXPECT().
+					SaveFile(gomock.Eq(ctx), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, p filesystem.SaveFileParam) (*filesystem.SaveFileResult, error) {
+						Expect(p.Name).To(Equal("name"))
+						Expect(p.Exclusive).To(BeTrue())
+						saved, _ = io.ReadAll(p.Reader)
+						return &filesystem.SaveFileResult{
+							Size:    int64(len(saved)),
+							SavedAt: currentTs,
+						}, nil
+					}).
+					Times(1)
+
+				res, err := fm.SaveFile(ctx, filesystem.SaveFileParam{
+					Name:      "name",
+					Reader:    bytes.NewReader([]byte("content")),
+					Exclusive: true,
+				})
+
+				Expect(err).To(BeNil())
+				Expect(res.Size).To(Equal(int64(7)))
+				Expect(res.SavedAt).To(Equal(currentTs))
+				Expect(res.EncryptionKey.KeyId).To(Equal("key-1"))
+				Expect(res.EncryptionKey.DataKey).ToNot(BeEmpty())
+				Expect(int64(len(saved))).To(Equal(encryption.EncryptedSize(7)))
+				Expect(bytes.Contains(saved, []byte("content"))).To(BeFalse())
+			})
+		})
+	})
+
+	Context("OpenFile function", Label("unit"), func() {
+		var (
+			ctx     context.Context
+			fm      filesystem.FileManager
+			baseFm  *mock_filesystem.MockFileManager
+			keyring encryption.Keyring
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			t := GinkgoT()
+			ctrl := gomock.NewController(t)
+			baseFm = mock_filesystem.NewMockFileManager(ctrl)
+			keyring, _ = encryption.NewKeyring(encryption.KeyringParam{
+				PrimaryKeyId: "key-1",
+				Keys: map[string][]byte{
+					"key-1": bytes.Repeat([]byte("a"), encryption.KEY_SIZE),
+				},
+			})
+			fm = encryption.NewFileManager(encryption.FileManagerParam{
+				FileManager: baseFm,
+				Keyring:     keyring,
+				Encrypt:     true,
+			})
+		})
+
+		When("encryption key is not specified", func() {
+			It("should open the plain data", func() {
+				param := filesystem.OpenFileParam{
+					Path: "path",
+				}
+				openRes := &filesystem.OpenFileResult{
+					File: io.NopCloser(bytes.NewReader([]byte("content"))),
+				}
+				baseFm.
+					EXPECT().
+					OpenFile(gomock.Eq(ctx), gomock.Eq(param)).
+					Return(openRes, nil).
+					Times(1)
+
+				res, err := fm.OpenFile(ctx, param)
+
+				Expect(err).To(BeNil())
+				Expect(res).To(Equal(openRes))
+			})
+		})
+
+		When("encryption key is not available", func() {
+			It("should return error", func() {
+				res, err := fm.OpenFile(ctx, filesystem.OpenFileParam{
+					Path: "path",
+					EncryptionKey: &filesystem.EncryptionKey{
+						KeyId:   "key-2",
+						DataKey: "data-key",
+					},
+				})
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(encryption.ErrorKeyNotFound))
+			})
+		})
+
+		When("failed open file", func() {
+			It("should return error", func() {
+				dataKey, _ := encryption.GenerateDataKey()
+				wrap, _ := keyring.WrapKey(encryption.WrapKeyParam{DataKey: dataKey})
+				baseFm.
+					EXPECT().
+					OpenFile(gomock.Eq(ctx), gomock.Eq(filesystem.OpenFileParam{
+						Path:   "path",
+						Length: encryption.HEADER_SIZE,
+					})).
+					Return(nil, filesystem.ErrorFileNotFound).
+					Times(1)
+
+				res, err := fm.OpenFile(ctx, filesystem.OpenFileParam{
+					Path: "path",
+					EncryptionKey: &filesystem.EncryptionKey{
+						KeyId:   wrap.KeyId,
+						DataKey: wrap.WrappedKey,
+					},
+				})
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(filesystem.ErrorFileNotFound))
+			})
+		})
+
+		When("header is invalid", func() {
+			It("should return error", func() {
+				dataKey, _ := encryption.GenerateDataKey()
+				wrap, _ := keyring.WrapKey(encryption.WrapKeyParam{DataKey: dataKey})
+				baseFm.
+					EXPECT().
+					OpenFile(gomock.Eq(ctx), gomock.Any()).
+					Return(&filesystem.OpenFileResult{
+						File: io.NopCloser(bytes.NewReader([]byte("plain content without header"))),
+					}, nil).
+					Times(1)
+
+				res, err := fm.OpenFile(ctx, filesystem.OpenFileParam{
+					Path: "path",
+					EncryptionKey: &filesystem.EncryptionKey{
+						KeyId:   wrap.KeyId,
+						DataKey: wrap.WrappedKey,
+					},
+				})
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(encryption.ErrorInvalidHeader))
+			})
+		})
+	})
+
+	Describe("File Manager", Label("integration"), func() {
+		var (
+			ctx     context.Context
+			fm      filesystem.FileManager
+			keyring encryption.Keyring
+			dir     string
+			plain   []byte
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			dir, _ = os.MkdirTemp("", "encryption-")
+			keyring, _ = encryption.NewKeyring(encryption.KeyringParam{
+				PrimaryKeyId: "key-1",
+				Keys: map[string][]byte{
+					"key-1": bytes.Repeat([]byte("a"), encryption.KEY_SIZE),
+				},
+			})
+			fm = encryption.NewFileManager(encryption.FileManagerParam{
+				FileManager: filesystem.NewFileManager(),
+				Keyring:     keyring,
+				Encrypt:     true,
+			})
+			plain = make([]byte, 2*encryption.DEFAULT_CHUNK_SIZE+500)
+			rand.Read(plain)
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		When("file is saved and opened", func() {
+			It("should return the plain data", func() {
+				path := filepath.Join(dir, "file")
+				save, err := fm.SaveFile(ctx, filesystem.SaveFileParam{
+					Name:       path,
+					Reader:     bytes.NewReader(plain),
+					Permission: 0644,
+				})
+				Expect(err).To(BeNil())
+				Expect(save.Size).To(Equal(int64(len(plain))))
+
+				stat, _ := os.Stat(path)
+				Expect(stat.Size()).To(Equal(encryption.EncryptedSize(int64(len(plain)))))
+
+				res, err := fm.OpenFile(ctx, filesystem.OpenFileParam{
+					Path:          path,
+					EncryptionKey: save.EncryptionKey,
+				})
+				Expect(err).To(BeNil())
+				data, err := io.ReadAll(res.File)
+				res.File.Close()
+
+				Expect(err).To(BeNil())
+				Expect(bytes.Equal(data, plain)).To(BeTrue())
+			})
+		})
+
+		When("range is specified", func() {
+			It("should return ranged plain data", func() {
+				path := filepath.Join(dir, "file")
+				save, _ := fm.SaveFile(ctx, filesystem.SaveFileParam{
+					Name:       path,
+					Reader:     bytes.NewReader(plain),
+					Permission: 0644,
+				})
+
+				ranges := [][2]int64{
+					{0, 10},
+					{encryption.DEFAULT_CHUNK_SIZE - 5, 10},
+					{encryption.DEFAULT_CHUNK_SIZE + 100, encryption.DEFAULT_CHUNK_SIZE},
+					{int64(len(plain)) - 20, 0},
+				}
+				for _, r := range ranges {
+					res, err := fm.OpenFile(ctx, filesystem.OpenFileParam{
+						Path:          path,
+						Offset:        r[0],
+						Length:        r[1],
+						EncryptionKey: save.EncryptionKey,
+					})
+					Expect(err).To(BeNil())
+					data, err := io.ReadAll(res.File)
+					res.File.Close()
+
+					end := int64(len(plain))
+					if r[1] > 0 {
+						end = r[0] + r[1]
+					}
+					Expect(err).To(BeNil())
+					Expect(bytes.Equal(data, plain[r[0]:end])).To(BeTrue())
+				}
+			})
+		})
+
+		When("file is tampered", func() {
+			It("should return error", func() {
+				path := filepath.Join(dir, "file")
+				save, _ := fm.SaveFile(ctx, filesystem.SaveFileParam{
+					Name:       path,
+					Reader:     bytes.NewReader(plain),
+					Permission: 0644,
+				})
+				sealed, _ := os.ReadFile(path)
+				sealed[len(sealed)-1] ^= 0xff
+				os.WriteFile(path, sealed, 0644)
+
+				res, err := fm.OpenFile(ctx, filesystem.OpenFileParam{
+					Path:          path,
+					EncryptionKey: save.EncryptionKey,
+				})
+				Expect(err).To(BeNil())
+				_, err = io.ReadAll(res.File)
+				res.File.Close()
+
+				Expect(err).To(Equal(encryption.ErrorInvalidChunk))
+			})
+		})
+	})
+})
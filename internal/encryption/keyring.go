@@ -0,0 +1,174 @@
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	KEY_SIZE = 32
+)
+
+// @note: master keys are only used to wrap the data keys,
+// the content is encrypted using the data key of each file
+type Keyring interface {
+	PrimaryKeyId() string
+	WrapKey(p WrapKeyParam) (*WrapKeyResult, error)
+	UnwrapKey(p UnwrapKeyParam) (*UnwrapKeyResult, error)
+}
+
+// @note: data key is wrapped by the primary key
+type WrapKeyParam struct {
+	DataKey []byte
+}
+
+type WrapKeyResult struct {
+	KeyId      string
+	WrappedKey string
+}
+
+type UnwrapKeyParam struct {
+	KeyId      string
+	WrappedKey string
+}
+
+type UnwrapKeyResult struct {
+	DataKey []byte
+}
+
+type keyring struct {
+	primaryKeyId string
+	keys         map[string][]byte
+}
+
+func (k *keyring) PrimaryKeyId() string {
+	return k.primaryKeyId
+}
+
+// @note: wrapped key is encoded as base64 of nonce and sealed data key,
+// key id is authenticated to prevent using the wrapped key with another master key
+func (k *keyring) WrapKey(p WrapKeyParam) (*WrapKeyResult, error) {
+	if len(p.DataKey) != KEY_SIZE {
+		return nil, ErrorInvalidKey
+	}
+
+	aead, err := newAead(k.keys[k.primaryKeyId])
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	_, err = io.ReadFull(rand.Reader, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	sealed := aead.Seal(nonce, nonce, p.DataKey, []byte(k.primaryKeyId))
+	res := &WrapKeyResult{
+		KeyId:      k.primaryKeyId,
+		WrappedKey: base64.StdEncoding.EncodeToString(sealed),
+	}
+	return res, nil
+}
+
+func (k *keyring) UnwrapKey(p UnwrapKeyParam) (*UnwrapKeyResult, error) {
+	masterKey, ok := k.keys[p.KeyId]
+	if !ok {
+		return nil, ErrorKeyNotFound
+	}
+
+	aead, err := newAead(masterKey)
+	if err != nil {
+		return nil, err
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(p.WrappedKey)
+	if err != nil {
+		return nil, ErrorInvalidKey
+	}
+	if len(sealed) < aead.NonceSize() {
+		return nil, ErrorInvalidKey
+	}
+
+	nonce := sealed[:aead.NonceSize()]
+	dataKey, err := aead.Open(nil, nonce, sealed[aead.NonceSize():], []byte(p.KeyId))
+	if err != nil {
+		return nil, ErrorInvalidKey
+	}
+
+	res := &UnwrapKeyResult{
+		DataKey: dataKey,
+	}
+	return res, nil
+}
+
+func newAead(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// @note: generate random data key for a file
+func GenerateDataKey() ([]byte, error) {
+	key := make([]byte, KEY_SIZE)
+	_, err := io.ReadFull(rand.Reader, key)
+	if err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// @note: each key is formatted as `<key_id>:<base64 key>`,
+// empty lines and lines started with `#` are ignored
+func ParseKeys(lines []string) (map[string][]byte, error) {
+	keys := map[string][]byte{}
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		splits := strings.SplitN(line, ":", 2)
+		if len(splits) != 2 || strings.TrimSpace(splits[0]) == "" {
+			return nil, fmt.Errorf("invalid key format")
+		}
+
+		keyId := strings.TrimSpace(splits[0])
+		key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(splits[1]))
+		if err != nil || len(key) != KEY_SIZE {
+			return nil, fmt.Errorf("invalid key: %s", keyId)
+		}
+		keys[keyId] = key
+	}
+	return keys, nil
+}
+
+type KeyringParam struct {
+	PrimaryKeyId string
+	Keys         map[string][]byte
+}
+
+func NewKeyring(p KeyringParam) (*keyring, error) {
+	for keyId, key := range p.Keys {
+		if len(key) != KEY_SIZE {
+			return nil, fmt.Errorf("invalid key: %s", keyId)
+		}
+	}
+
+	if _, ok := p.Keys[p.PrimaryKeyId]; !ok {
+		return nil, fmt.Errorf("primary key is not found")
+	}
+
+	k := &keyring{
+		primaryKeyId: p.PrimaryKeyId,
+		keys:         p.Keys,
+	}
+	return k, nil
+}
@@ -0,0 +1,220 @@
+package encryption_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/go-seidon/hippo/internal/encryption"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Keyring", func() {
+
+	Context("ParseKeys function", Label("unit"), func() {
+		var (
+			key string
+		)
+
+		BeforeEach(func() {
+			key = base64.StdEncoding.EncodeToString(bytes.Repeat([]byte("k"), encryption.KEY_SIZE))
+		})
+
+		When("key format is invalid", func() {
+			It("should return error", func() {
+				res, err := encryption.ParseKeys([]string{"invalid"})
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("invalid key format")))
+			})
+		})
+
+		When("key is not base64", func() {
+			It("should return error", func() {
+				res, err := encryption.ParseKeys([]string{"key-1:%%%"})
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("invalid key: key-1")))
+			})
+		})
+
+		When("key size is invalid", func() {
+			It("should return error", func() {
+				short := base64.StdEncoding.EncodeToString([]byte("short"))
+				res, err := encryption.ParseKeys([]string{"key-1:" + short})
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("invalid key: key-1")))
+			})
+		})
+
+		When("keys are valid", func() {
+			It("should return result", func() {
+				res, err := encryption.ParseKeys([]string{
+					"# comment",
+					"",
+					" key-1 : " + key,
+					"key-2:" + key,
+				})
+
+				Expect(err).To(BeNil())
+				Expect(res).To(HaveLen(2))
+				Expect(res["key-1"]).To(Equal(bytes.Repeat([]byte("k"), encryption.KEY_SIZE)))
+				Expect(res["key-2"]).To(Equal(bytes.Repeat([]byte("k"), encryption.KEY_SIZE)))
+			})
+		})
+	})
+
+	Context("NewKeyring function", Label("unit"), func() {
+		When("key size is invalid", func() {
+			It("should return error", func() {
+				res, err := encryption.NewKeyring(encryption.KeyringParam{
+					PrimaryKeyId: "key-1",
+					Keys: map[string][]byte{
+						"key-1": []byte("short"),
+					},
+				})
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("invalid key: key-1")))
+			})
+		})
+
+		When("primary key is not available", func() {
+			It("should return error", func() {
+				res, err := encryption.NewKeyring(encryption.KeyringParam{
+					PrimaryKeyId: "key-2",
+					Keys: map[string][]byte{
+						"key-1": bytes.Repeat([]byte("a"), encryption.KEY_SIZE),
+					},
+				})
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("primary key is not found")))
+			})
+		})
+
+		When("success create keyring", func() {
+			It("should return result", func() {
+				res, err := encryption.NewKeyring(encryption.KeyringParam{
+					PrimaryKeyId: "key-1",
+					Keys: map[string][]byte{
+						"key-1": bytes.Repeat([]byte("a"), encryption.KEY_SIZE),
+					},
+				})
+
+				Expect(err).To(BeNil())
+				Expect(res).ToNot(BeNil())
+				Expect(res.PrimaryKeyId()).To(Equal("key-1"))
+			})
+		})
+	})
+
+	Context("WrapKey and UnwrapKey function", Label("unit"), func() {
+		var (
+			keyring encryption.Keyring
+			dataKey []byte
+		)
+
+		BeforeEach(func() {
+			keyring, _ = encryption.NewKeyring(encryption.KeyringParam{
+				PrimaryKeyId: "key-2",
+				Keys: map[string][]byte{
+					"key-1": bytes.Repeat([]byte("a"), encryption.KEY_SIZE),
+					"key-2": bytes.Repeat([]byte("b"), encryption.KEY_SIZE),
+				},
+			})
+			dataKey, _ = encryption.GenerateDataKey()
+		})
+
+		When("data key size is invalid", func() {
+			It("should return error", func() {
+				res, err := keyring.WrapKey(encryption.WrapKeyParam{
+					DataKey: []byte("short"),
+				})
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(encryption.ErrorInvalidKey))
+			})
+		})
+
+		When("key is wrapped", func() {
+			It("should be wrapped by the primary key", func() {
+				res, err := keyring.WrapKey(encryption.WrapKeyParam{
+					DataKey: dataKey,
+				})
+
+				Expect(err).To(BeNil())
+				Expect(res.KeyId).To(Equal("key-2"))
+				Expect(res.WrappedKey).ToNot(ContainSubstring(base64.StdEncoding.EncodeToString(dataKey)))
+			})
+		})
+
+		When("wrapped key is unwrapped", func() {
+			It("should return the data key", func() {
+				wrap, _ := keyring.WrapKey(encryption.WrapKeyParam{
+					DataKey: dataKey,
+				})
+				res, err := keyring.UnwrapKey(encryption.UnwrapKeyParam{
+					KeyId:      wrap.KeyId,
+					WrappedKey: wrap.WrappedKey,
+				})
+
+				Expect(err).To(BeNil())
+				Expect(res.DataKey).To(Equal(dataKey))
+			})
+		})
+
+		When("key id is not available", func() {
+			It("should return error", func() {
+				res, err := keyring.UnwrapKey(encryption.UnwrapKeyParam{
+					KeyId:      "key-3",
+					WrappedKey: "wrapped",
+				})
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(encryption.ErrorKeyNotFound))
+			})
+		})
+
+		When("wrapped key is not base64", func() {
+			It("should return error", func() {
+				res, err := keyring.UnwrapKey(encryption.UnwrapKeyParam{
+					KeyId:      "key-2",
+					WrappedKey: "%%%",
+				})
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(encryption.ErrorInvalidKey))
+			})
+		})
+
+		When("wrapped key is too short", func() {
+			It("should return error", func() {
+				res, err := keyring.UnwrapKey(encryption.UnwrapKeyParam{
+					KeyId:      "key-2",
+					WrappedKey: base64.StdEncoding.EncodeToString([]byte("short")),
+				})
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(encryption.ErrorInvalidKey))
+			})
+		})
+
+		When("wrapped key is unwrapped using another key id", func() {
+			It("should return error", func() {
+				wrap, _ := keyring.WrapKey(encryption.WrapKeyParam{
+					DataKey: dataKey,
+				})
+				res, err := keyring.UnwrapKey(encryption.UnwrapKeyParam{
+					KeyId:      "key-1",
+					WrappedKey: wrap.WrappedKey,
+				})
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(encryption.ErrorInvalidKey))
+			})
+		})
+	})
+})
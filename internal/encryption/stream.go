@@ -0,0 +1,246 @@
+package encryption
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+const (
+	HEADER_SIZE        = 16
+	TAG_SIZE           = 16
+	DEFAULT_CHUNK_SIZE = 65536    //64KB
+	MAX_CHUNK_SIZE     = 16777216 //16MB
+
+	noncePrefixSize = 8
+)
+
+var (
+	headerMagic = []byte("HPE1")
+)
+
+// @note: encrypted content is started with the header followed by the sealed chunks,
+// header: magic (4 bytes), chunk size (4 bytes) and nonce prefix (8 bytes)
+// chunk: sealed data of chunk size followed by the tag, the last chunk is always shorter (may be empty)
+// nonce of each chunk is the nonce prefix followed by the chunk index
+// and the last chunk is authenticated as the last one to detect truncation
+type Header struct {
+	ChunkSize   int64
+	NoncePrefix []byte
+}
+
+func (h *Header) Bytes() []byte {
+	b := make([]byte, HEADER_SIZE)
+	copy(b[0:4], headerMagic)
+	binary.BigEndian.PutUint32(b[4:8], uint32(h.ChunkSize))
+	copy(b[8:16], h.NoncePrefix)
+	return b
+}
+
+// @note: position of the sealed chunk which contains the given plain offset
+func (h *Header) ChunkOffset(offset int64) (index int64, sealedOffset int64) {
+	index = offset / h.ChunkSize
+	sealedOffset = HEADER_SIZE + index*(h.ChunkSize+TAG_SIZE)
+	return index, sealedOffset
+}
+
+func ReadHeader(r io.Reader) (*Header, error) {
+	b := make([]byte, HEADER_SIZE)
+	_, err := io.ReadFull(r, b)
+	if err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, ErrorInvalidHeader
+		}
+		return nil, err
+	}
+
+	if !bytes.Equal(b[0:4], headerMagic) {
+		return nil, ErrorInvalidHeader
+	}
+
+	chunkSize := int64(binary.BigEndian.Uint32(b[4:8]))
+	if chunkSize == 0 || chunkSize > MAX_CHUNK_SIZE {
+		return nil, ErrorInvalidHeader
+	}
+
+	h := &Header{
+		ChunkSize:   chunkSize,
+		NoncePrefix: b[8:16],
+	}
+	return h, nil
+}
+
+// @note: size of the encrypted content of the given plain size
+func EncryptedSize(size int64) int64 {
+	fullChunks := size / DEFAULT_CHUNK_SIZE
+	lastChunk := size % DEFAULT_CHUNK_SIZE
+	return HEADER_SIZE + fullChunks*(DEFAULT_CHUNK_SIZE+TAG_SIZE) + lastChunk + TAG_SIZE
+}
+
+func chunkNonce(prefix []byte, index int64) []byte {
+	nonce := make([]byte, noncePrefixSize+4)
+	copy(nonce, prefix)
+	binary.BigEndian.PutUint32(nonce[noncePrefixSize:], uint32(index))
+	return nonce
+}
+
+func chunkAad(last bool) []byte {
+	if last {
+		return []byte{1}
+	}
+	return []byte{0}
+}
+
+type encryptReader struct {
+	reader io.Reader
+	aead   cipher.AEAD
+	header *Header
+	plain  []byte
+	sealed []byte
+	buff   []byte
+	index  int64
+	done   bool
+}
+
+func (r *encryptReader) Read(p []byte) (int, error) {
+	for len(r.buff) == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+
+		err := r.seal()
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	n := copy(p, r.buff)
+	r.buff = r.buff[n:]
+	return n, nil
+}
+
+func (r *encryptReader) seal() error {
+	n, err := io.ReadFull(r.reader, r.plain)
+	last := false
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		last = true
+	} else if err != nil {
+		return err
+	}
+
+	nonce := chunkNonce(r.header.NoncePrefix, r.index)
+	r.buff = r.aead.Seal(r.sealed[:0], nonce, r.plain[:n], chunkAad(last))
+	r.index++
+	r.done = last
+	return nil
+}
+
+// @note: data is encrypted chunk by chunk while it's read
+func NewEncryptReader(r io.Reader, dataKey []byte) (io.Reader, error) {
+	aead, err := newAead(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := make([]byte, noncePrefixSize)
+	_, err = io.ReadFull(rand.Reader, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	header := &Header{
+		ChunkSize:   DEFAULT_CHUNK_SIZE,
+		NoncePrefix: prefix,
+	}
+	er := &encryptReader{
+		reader: r,
+		aead:   aead,
+		header: header,
+		plain:  make([]byte, DEFAULT_CHUNK_SIZE),
+		sealed: make([]byte, 0, DEFAULT_CHUNK_SIZE+TAG_SIZE),
+		buff:   header.Bytes(),
+	}
+	return er, nil
+}
+
+type decryptReader struct {
+	reader io.Reader
+	aead   cipher.AEAD
+	header *Header
+	sealed []byte
+	plain  []byte
+	buff   []byte
+	index  int64
+	done   bool
+}
+
+func (r *decryptReader) Read(p []byte) (int, error) {
+	for len(r.buff) == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+
+		err := r.open()
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	n := copy(p, r.buff)
+	r.buff = r.buff[n:]
+	return n, nil
+}
+
+func (r *decryptReader) open() error {
+	n, err := io.ReadFull(r.reader, r.sealed)
+	last := false
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		last = true
+	} else if err != nil {
+		return err
+	}
+
+	if n < TAG_SIZE {
+		return ErrorTruncated
+	}
+
+	nonce := chunkNonce(r.header.NoncePrefix, r.index)
+	plain, err := r.aead.Open(r.plain[:0], nonce, r.sealed[:n], chunkAad(last))
+	if err != nil {
+		return ErrorInvalidChunk
+	}
+
+	r.buff = plain
+	r.index++
+	r.done = last
+	return nil
+}
+
+type DecryptReaderParam struct {
+	Reader  io.Reader
+	DataKey []byte
+	Header  *Header
+	// @note: index of the first chunk available in the reader
+	ChunkIndex int64
+}
+
+// @note: reader is expected to be positioned after the header or at the start of the chunk index
+func NewDecryptReader(p DecryptReaderParam) (io.Reader, error) {
+	aead, err := newAead(p.DataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	dr := &decryptReader{
+		reader: p.Reader,
+		aead:   aead,
+		header: p.Header,
+		sealed: make([]byte, p.Header.ChunkSize+TAG_SIZE),
+		plain:  make([]byte, 0, p.Header.ChunkSize),
+		index:  p.ChunkIndex,
+	}
+	return dr, nil
+}
@@ -0,0 +1,205 @@
+package encryption_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"testing/iotest"
+
+	"github.com/go-seidon/hippo/internal/encryption"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Stream", func() {
+
+	Context("EncryptReader and DecryptReader", Label("unit"), func() {
+		var (
+			dataKey []byte
+		)
+
+		BeforeEach(func() {
+			dataKey, _ = encryption.GenerateDataKey()
+		})
+
+		encrypt := func(plain []byte) []byte {
+			reader, err := encryption.NewEncryptReader(bytes.NewReader(plain), dataKey)
+			Expect(err).To(BeNil())
+			sealed, err := io.ReadAll(reader)
+			Expect(err).To(BeNil())
+			return sealed
+		}
+
+		decrypt := func(sealed []byte) ([]byte, error) {
+			reader := bytes.NewReader(sealed)
+			header, err := encryption.ReadHeader(reader)
+			if err != nil {
+				return nil, err
+			}
+			decryptReader, err := encryption.NewDecryptReader(encryption.DecryptReaderParam{
+				Reader:  reader,
+				DataKey: dataKey,
+				Header:  header,
+			})
+			if err != nil {
+				return nil, err
+			}
+			return io.ReadAll(decryptReader)
+		}
+
+		When("data key is invalid", func() {
+			It("should return error", func() {
+				res, err := encryption.NewEncryptReader(bytes.NewReader([]byte{}), []byte("short"))
+
+				Expect(res).To(BeNil())
+				Expect(err).ToNot(BeNil())
+			})
+		})
+
+		When("source reader is failed", func() {
+			It("should return error", func() {
+				reader, _ := encryption.NewEncryptReader(iotest.ErrReader(fmt.Errorf("read error")), dataKey)
+				res, err := io.ReadAll(reader)
+
+				Expect(res).To(HaveLen(encryption.HEADER_SIZE))
+				Expect(err).To(Equal(fmt.Errorf("read error")))
+			})
+		})
+
+		for _, size := range []int{
+			0, 1,
+			encryption.DEFAULT_CHUNK_SIZE - 1,
+			encryption.DEFAULT_CHUNK_SIZE,
+			encryption.DEFAULT_CHUNK_SIZE + 1,
+			3*encryption.DEFAULT_CHUNK_SIZE + 100,
+		} {
+			size := size
+			When(fmt.Sprintf("data size is %d", size), func() {
+				It("should be decrypted to the same data", func() {
+					plain := make([]byte, size)
+					rand.Read(plain)
+
+					sealed := encrypt(plain)
+					res, err := decrypt(sealed)
+
+					Expect(err).To(BeNil())
+					Expect(res).To(HaveLen(size))
+					Expect(bytes.Equal(res, plain)).To(BeTrue())
+					Expect(int64(len(sealed))).To(Equal(encryption.EncryptedSize(int64(size))))
+				})
+			})
+		}
+
+		When("data is decrypted from the middle chunk", func() {
+			It("should return the remaining data", func() {
+				plain := make([]byte, 3*encryption.DEFAULT_CHUNK_SIZE+10)
+				rand.Read(plain)
+				sealed := encrypt(plain)
+
+				header, _ := encryption.ReadHeader(bytes.NewReader(sealed))
+				index, offset := header.ChunkOffset(encryption.DEFAULT_CHUNK_SIZE + 5)
+				reader, _ := encryption.NewDecryptReader(encryption.DecryptReaderParam{
+					Reader:     bytes.NewReader(sealed[offset:]),
+					DataKey:    dataKey,
+					Header:     header,
+					ChunkIndex: index,
+				})
+				res, err := io.ReadAll(reader)
+
+				Expect(err).To(BeNil())
+				Expect(index).To(Equal(int64(1)))
+				Expect(bytes.Equal(res, plain[encryption.DEFAULT_CHUNK_SIZE:])).To(BeTrue())
+			})
+		})
+
+		When("data is tampered", func() {
+			It("should return error", func() {
+				sealed := encrypt([]byte("secret content"))
+				sealed[encryption.HEADER_SIZE] ^= 0xff
+
+				res, err := decrypt(sealed)
+
+				Expect(res).To(BeEmpty())
+				Expect(err).To(Equal(encryption.ErrorInvalidChunk))
+			})
+		})
+
+		When("data is decrypted using another key", func() {
+			It("should return error", func() {
+				sealed := encrypt([]byte("secret content"))
+				dataKey, _ = encryption.GenerateDataKey()
+
+				res, err := decrypt(sealed)
+
+				Expect(res).To(BeEmpty())
+				Expect(err).To(Equal(encryption.ErrorInvalidChunk))
+			})
+		})
+
+		When("last chunk is removed", func() {
+			It("should return error", func() {
+				plain := make([]byte, 2*encryption.DEFAULT_CHUNK_SIZE+10)
+				sealed := encrypt(plain)
+				sealed = sealed[:encryption.HEADER_SIZE+2*(encryption.DEFAULT_CHUNK_SIZE+encryption.TAG_SIZE)]
+
+				_, err := decrypt(sealed)
+
+				Expect(err).To(Equal(encryption.ErrorTruncated))
+			})
+		})
+
+		When("data is truncated in the middle of chunk", func() {
+			It("should return error", func() {
+				plain := make([]byte, 2*encryption.DEFAULT_CHUNK_SIZE+10)
+				sealed := encrypt(plain)
+				sealed = sealed[:encryption.HEADER_SIZE+encryption.DEFAULT_CHUNK_SIZE+encryption.TAG_SIZE+100]
+
+				_, err := decrypt(sealed)
+
+				Expect(err).To(Equal(encryption.ErrorInvalidChunk))
+			})
+		})
+	})
+
+	Context("ReadHeader function", Label("unit"), func() {
+		When("header is too short", func() {
+			It("should return error", func() {
+				res, err := encryption.ReadHeader(bytes.NewReader([]byte("HPE1")))
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(encryption.ErrorInvalidHeader))
+			})
+		})
+
+		When("magic is invalid", func() {
+			It("should return error", func() {
+				res, err := encryption.ReadHeader(bytes.NewReader(make([]byte, encryption.HEADER_SIZE)))
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(encryption.ErrorInvalidHeader))
+			})
+		})
+
+		When("chunk size is invalid", func() {
+			It("should return error", func() {
+				b := make([]byte, encryption.HEADER_SIZE)
+				copy(b, "HPE1")
+
+				res, err := encryption.ReadHeader(bytes.NewReader(b))
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(encryption.ErrorInvalidHeader))
+			})
+		})
+
+		When("reader is failed", func() {
+			It("should return error", func() {
+				res, err := encryption.ReadHeader(iotest.ErrReader(fmt.Errorf("read error")))
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("read error")))
+			})
+		})
+	})
+})
@@ -0,0 +1,72 @@
+package file
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"io"
+)
+
+type Checksum struct {
+	Sha256 string
+	Md5    string
+}
+
+// checksumReader compute checksum of the data while it's being read
+type checksumReader struct {
+	reader io.Reader
+	sha256 hash.Hash
+	md5    hash.Hash
+}
+
+func (r *checksumReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	if n > 0 {
+		r.sha256.Write(p[:n])
+		if r.md5 != nil {
+			r.md5.Write(p[:n])
+		}
+	}
+	return n, err
+}
+
+// @note: md5 is empty when it's not enabled
+func (r *checksumReader) Checksum() Checksum {
+	res := Checksum{
+		Sha256: hex.EncodeToString(r.sha256.Sum(nil)),
+	}
+	if r.md5 != nil {
+		res.Md5 = hex.EncodeToString(r.md5.Sum(nil))
+	}
+	return res
+}
+
+type ChecksumReaderParam struct {
+	Reader io.Reader
+	Md5    bool
+}
+
+func NewChecksumReader(p ChecksumReaderParam) *checksumReader {
+	r := &checksumReader{
+		reader: p.Reader,
+		sha256: sha256.New(),
+	}
+	if p.Md5 {
+		r.md5 = md5.New()
+	}
+	return r
+}
+
+func ComputeChecksum(r io.Reader) (*Checksum, error) {
+	reader := NewChecksumReader(ChecksumReaderParam{
+		Reader: r,
+	})
+	_, err := io.Copy(io.Discard, reader)
+	if err != nil {
+		return nil, err
+	}
+
+	res := reader.Checksum()
+	return &res, nil
+}
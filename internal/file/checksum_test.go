@@ -0,0 +1,68 @@
+package file_test
+
+import (
+	"io"
+	"strings"
+	"testing/iotest"
+
+	"github.com/go-seidon/hippo/internal/file"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Checksum", func() {
+	Context("ChecksumReader", Label("unit"), func() {
+		When("md5 is not enabled", func() {
+			It("should return sha256 checksum", func() {
+				reader := file.NewChecksumReader(file.ChecksumReaderParam{
+					Reader: strings.NewReader("hippo"),
+				})
+				data, err := io.ReadAll(reader)
+
+				Expect(err).To(BeNil())
+				Expect(data).To(Equal([]byte("hippo")))
+				Expect(reader.Checksum()).To(Equal(file.Checksum{
+					Sha256: "877cc977e7b033e10d6e0b0d666da1f463bc51b1de48869250a0347ec1b2b8b3",
+				}))
+			})
+		})
+
+		When("md5 is enabled", func() {
+			It("should return sha256 and md5 checksum", func() {
+				reader := file.NewChecksumReader(file.ChecksumReaderParam{
+					Reader: strings.NewReader("hippo"),
+					Md5:    true,
+				})
+				_, err := io.ReadAll(reader)
+
+				Expect(err).To(BeNil())
+				Expect(reader.Checksum()).To(Equal(file.Checksum{
+					Sha256: "877cc977e7b033e10d6e0b0d666da1f463bc51b1de48869250a0347ec1b2b8b3",
+					Md5:    "3a0689aa9e31a50b5621971fc89f0c64",
+				}))
+			})
+		})
+	})
+
+	Context("ComputeChecksum function", Label("unit"), func() {
+		When("failed read data", func() {
+			It("should return error", func() {
+				res, err := file.ComputeChecksum(iotest.ErrReader(io.ErrUnexpectedEOF))
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(io.ErrUnexpectedEOF))
+			})
+		})
+
+		When("success compute checksum", func() {
+			It("should return result", func() {
+				res, err := file.ComputeChecksum(strings.NewReader(""))
+
+				Expect(err).To(BeNil())
+				Expect(res).To(Equal(&file.Checksum{
+					Sha256: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+				}))
+			})
+		})
+	})
+})
@@ -5,4 +5,11 @@ import "errors"
 var (
 	ErrNotFound = errors.New("not found")
 	ErrExists   = errors.New("already exists")
+	ErrExceeded = errors.New("size exceeded")
+
+	ErrMimetypeNotAllowed  = errors.New("mimetype is not allowed")
+	ErrExtensionNotAllowed = errors.New("extension is not allowed")
+	ErrExtensionMismatch   = errors.New("extension does not match the content")
+
+	ErrInfected = errors.New("file is infected")
 )
@@ -1,20 +1,41 @@
 package file
 
 import (
+	"crypto/md5"
+	"encoding/hex"
 	"fmt"
+	"strings"
 
 	"github.com/go-seidon/provider/datetime"
 )
 
+const (
+	LOCATION_DAILY  = "daily"
+	LOCATION_HOURLY = "hourly"
+	LOCATION_HASH   = "hash"
+	LOCATION_CLIENT = "client"
+	LOCATION_BUCKET = "bucket"
+
+	DEFAULT_LOCATION_SEGMENT = "_default"
+)
+
 type UploadLocation interface {
-	GetLocation() string
+	GetLocation(p GetLocationParam) string
+}
+
+// @note: upload context which may be used by the strategy to determine the location
+type GetLocationParam struct {
+	UniqueId string
+	ClientId string
+	Bucket   string
+	Mimetype string
 }
 
 type dailyRotate struct {
 	clock datetime.Clock
 }
 
-func (l *dailyRotate) GetLocation() string {
+func (l *dailyRotate) GetLocation(p GetLocationParam) string {
 	currentTs := l.clock.Now()
 	year := currentTs.Format("2006")
 	month := currentTs.Format("01")
@@ -39,3 +60,150 @@ func NewDailyRotate(p DailyRotateParam) *dailyRotate {
 	}
 	return l
 }
+
+type hourlyRotate struct {
+	clock datetime.Clock
+}
+
+func (l *hourlyRotate) GetLocation(p GetLocationParam) string {
+	currentTs := l.clock.Now()
+	year := currentTs.Format("2006")
+	month := currentTs.Format("01")
+	day := currentTs.Format("02")
+	hour := currentTs.Format("15")
+	return fmt.Sprintf("%s/%s/%s/%s", year, month, day, hour)
+}
+
+type HourlyRotateParam struct {
+	Clock datetime.Clock
+}
+
+func NewHourlyRotate(p HourlyRotateParam) *hourlyRotate {
+	var clock datetime.Clock
+	if p.Clock != nil {
+		clock = p.Clock
+	} else {
+		clock = datetime.NewClock()
+	}
+
+	l := &hourlyRotate{
+		clock: clock,
+	}
+	return l
+}
+
+// @note: the id is hashed before it's sharded since sequential id (e.g: ksuid)
+// shares the same prefix and would end up in the same directory
+type hashPrefix struct {
+	levels int
+	width  int
+}
+
+func (l *hashPrefix) GetLocation(p GetLocationParam) string {
+	sum := md5.Sum([]byte(p.UniqueId))
+	hash := hex.EncodeToString(sum[:])
+
+	segments := []string{}
+	for i := 0; i < l.levels; i++ {
+		segments = append(segments, hash[i*l.width:(i+1)*l.width])
+	}
+	return strings.Join(segments, "/")
+}
+
+type HashPrefixParam struct {
+	Levels int
+	Width  int
+}
+
+// @note: default to 2 levels of 2 characters (e.g: ab/cd)
+func NewHashPrefix(p HashPrefixParam) *hashPrefix {
+	levels := 2
+	if p.Levels > 0 {
+		levels = p.Levels
+	}
+
+	width := 2
+	if p.Width > 0 {
+		width = p.Width
+	}
+
+	// @note: md5 hex has 32 characters
+	if levels*width > 32 {
+		levels = 32 / width
+	}
+
+	l := &hashPrefix{
+		levels: levels,
+		width:  width,
+	}
+	return l
+}
+
+type clientDirectory struct {
+	location UploadLocation
+}
+
+func (l *clientDirectory) GetLocation(p GetLocationParam) string {
+	return fmt.Sprintf("%s/%s", locationSegment(p.ClientId), l.location.GetLocation(p))
+}
+
+type ClientDirectoryParam struct {
+	Location UploadLocation
+}
+
+// @note: files are grouped by the client then by the given location (daily rotate by default)
+func NewClientDirectory(p ClientDirectoryParam) *clientDirectory {
+	location := p.Location
+	if location == nil {
+		location = NewDailyRotate(DailyRotateParam{})
+	}
+
+	l := &clientDirectory{
+		location: location,
+	}
+	return l
+}
+
+type bucketDirectory struct {
+	location UploadLocation
+}
+
+func (l *bucketDirectory) GetLocation(p GetLocationParam) string {
+	return fmt.Sprintf("%s/%s", locationSegment(p.Bucket), l.location.GetLocation(p))
+}
+
+type BucketDirectoryParam struct {
+	Location UploadLocation
+}
+
+// @note: files are grouped by the bucket then by the given location (daily rotate by default)
+func NewBucketDirectory(p BucketDirectoryParam) *bucketDirectory {
+	location := p.Location
+	if location == nil {
+		location = NewDailyRotate(DailyRotateParam{})
+	}
+
+	l := &bucketDirectory{
+		location: location,
+	}
+	return l
+}
+
+// @note: value is supplied by the client so only safe characters are kept
+// to prevent escaping the upload directory
+func locationSegment(value string) string {
+	segment := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		case r == '-', r == '_', r == '.':
+			return r
+		}
+		return '_'
+	}, value)
+
+	if strings.Trim(segment, ".") == "" {
+		return DEFAULT_LOCATION_SEGMENT
+	}
+	return segment
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/go-seidon/hippo/internal/file"
+	mock_file "github.com/go-seidon/hippo/internal/file/mock"
 	mock_datetime "github.com/go-seidon/provider/datetime/mock"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo/v2"
@@ -67,7 +68,7 @@ var _ = Describe("Daily Rotate Location", func() {
 					Return(currentTimestamp).
 					Times(1)
 
-				res := s.GetLocation()
+				res := s.GetLocation(file.GetLocationParam{})
 
 				Expect(res).To(Equal("2022/02/28"))
 			})
@@ -82,10 +83,204 @@ var _ = Describe("Daily Rotate Location", func() {
 					Return(currentTimestamp).
 					Times(1)
 
-				res := s.GetLocation()
+				res := s.GetLocation(file.GetLocationParam{})
 
 				Expect(res).To(Equal("1990/02/01"))
 			})
 		})
 	})
 })
+
+var _ = Describe("Hourly Rotate Location", func() {
+	Context("NewHourlyRotate function", Label("unit"), func() {
+		When("clock is not specified", func() {
+			It("should return result", func() {
+				res := file.NewHourlyRotate(file.HourlyRotateParam{})
+
+				Expect(res).ToNot(BeNil())
+			})
+		})
+	})
+
+	Context("GetLocation function", Label("unit"), func() {
+		When("function is called", func() {
+			It("should return result", func() {
+				t := GinkgoT()
+				ctrl := gomock.NewController(t)
+				clock := mock_datetime.NewMockClock(ctrl)
+				s := file.NewHourlyRotate(file.HourlyRotateParam{
+					Clock: clock,
+				})
+
+				currentTimestamp, _ := time.Parse("2006-1-2 15", "1990-2-1 07")
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTimestamp).
+					Times(1)
+
+				res := s.GetLocation(file.GetLocationParam{})
+
+				Expect(res).To(Equal("1990/02/01/07"))
+			})
+		})
+	})
+})
+
+var _ = Describe("Hash Prefix Location", func() {
+	Context("GetLocation function", Label("unit"), func() {
+		When("levels and width are not specified", func() {
+			It("should return result", func() {
+				s := file.NewHashPrefix(file.HashPrefixParam{})
+
+				res := s.GetLocation(file.GetLocationParam{
+					UniqueId: "mock-unique-id",
+				})
+
+				Expect(res).To(Equal("aa/57"))
+			})
+		})
+
+		When("levels and width are specified", func() {
+			It("should return result", func() {
+				s := file.NewHashPrefix(file.HashPrefixParam{
+					Levels: 3,
+					Width:  1,
+				})
+
+				res := s.GetLocation(file.GetLocationParam{
+					UniqueId: "mock-unique-id",
+				})
+
+				Expect(res).To(Equal("a/a/5"))
+			})
+		})
+
+		When("levels exceed the hash length", func() {
+			It("should return result", func() {
+				s := file.NewHashPrefix(file.HashPrefixParam{
+					Levels: 20,
+					Width:  2,
+				})
+
+				res := s.GetLocation(file.GetLocationParam{
+					UniqueId: "mock-unique-id",
+				})
+
+				Expect(res).To(HaveLen(47))
+			})
+		})
+	})
+})
+
+var _ = Describe("Client Directory Location", func() {
+	Context("GetLocation function", Label("unit"), func() {
+		var (
+			s       file.UploadLocation
+			locator *mock_file.MockUploadLocation
+		)
+
+		BeforeEach(func() {
+			t := GinkgoT()
+			ctrl := gomock.NewController(t)
+			locator = mock_file.NewMockUploadLocation(ctrl)
+			s = file.NewClientDirectory(file.ClientDirectoryParam{
+				Location: locator,
+			})
+		})
+
+		When("location is not specified", func() {
+			It("should return result", func() {
+				res := file.NewClientDirectory(file.ClientDirectoryParam{})
+
+				Expect(res).ToNot(BeNil())
+			})
+		})
+
+		When("client is specified", func() {
+			It("should return result", func() {
+				p := file.GetLocationParam{
+					UniqueId: "mock-unique-id",
+					ClientId: "mock-client-id",
+				}
+				locator.
+					EXPECT().
+					GetLocation(gomock.Eq(p)).
+					Return("2022/02/28").
+					Times(1)
+
+				res := s.GetLocation(p)
+
+				Expect(res).To(Equal("mock-client-id/2022/02/28"))
+			})
+		})
+
+		When("client is not specified", func() {
+			It("should use default directory", func() {
+				locator.
+					EXPECT().
+					GetLocation(gomock.Any()).
+					Return("2022/02/28").
+					Times(1)
+
+				res := s.GetLocation(file.GetLocationParam{})
+
+				Expect(res).To(Equal("_default/2022/02/28"))
+			})
+		})
+	})
+})
+
+var _ = Describe("Bucket Directory Location", func() {
+	Context("GetLocation function", Label("unit"), func() {
+		var (
+			s       file.UploadLocation
+			locator *mock_file.MockUploadLocation
+		)
+
+		BeforeEach(func() {
+			t := GinkgoT()
+			ctrl := gomock.NewController(t)
+			locator = mock_file.NewMockUploadLocation(ctrl)
+			s = file.NewBucketDirectory(file.BucketDirectoryParam{
+				Location: locator,
+			})
+
+			locator.
+				EXPECT().
+				GetLocation(gomock.Any()).
+				Return("2022/02/28").
+				Times(1)
+		})
+
+		When("bucket is specified", func() {
+			It("should return result", func() {
+				res := s.GetLocation(file.GetLocationParam{
+					Bucket: "avatar",
+				})
+
+				Expect(res).To(Equal("avatar/2022/02/28"))
+			})
+		})
+
+		When("bucket contains unsafe characters", func() {
+			It("should return sanitized result", func() {
+				res := s.GetLocation(file.GetLocationParam{
+					Bucket: "../etc/pass wd",
+				})
+
+				Expect(res).To(Equal(".._etc_pass_wd/2022/02/28"))
+			})
+		})
+
+		When("bucket is a relative directory", func() {
+			It("should use default directory", func() {
+				res := s.GetLocation(file.GetLocationParam{
+					Bucket: "..",
+				})
+
+				Expect(res).To(Equal("_default/2022/02/28"))
+			})
+		})
+	})
+})
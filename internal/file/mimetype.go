@@ -0,0 +1,213 @@
+package file
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+)
+
+const (
+	SNIFF_SIZE = 512
+)
+
+type signature struct {
+	offset int
+	magic  []byte
+}
+
+type magicNumber struct {
+	mimetype   string
+	signatures []signature
+}
+
+// @note: the first matched entry is used so the more specific entry must be listed first,
+// content which is not matched is detected using http.DetectContentType
+var magicNumbers = []magicNumber{
+	{"image/png", []signature{{0, []byte("\x89PNG\r\n\x1a\n")}}},
+	{"image/jpeg", []signature{{0, []byte("\xff\xd8\xff")}}},
+	{"image/gif", []signature{{0, []byte("GIF87a")}}},
+	{"image/gif", []signature{{0, []byte("GIF89a")}}},
+	{"image/webp", []signature{{0, []byte("RIFF")}, {8, []byte("WEBPVP")}}},
+	{"image/bmp", []signature{{0, []byte("BM")}, {6, []byte("\x00\x00\x00\x00")}}},
+	{"image/tiff", []signature{{0, []byte("II*\x00")}}},
+	{"image/tiff", []signature{{0, []byte("MM\x00*")}}},
+	{"image/x-icon", []signature{{0, []byte("\x00\x00\x01\x00")}}},
+	{"image/vnd.adobe.photoshop", []signature{{0, []byte("8BPS")}}},
+	{"image/heic", []signature{{4, []byte("ftypheic")}}},
+	{"image/heic", []signature{{4, []byte("ftypheix")}}},
+	{"image/heic", []signature{{4, []byte("ftypmif1")}}},
+	{"image/avif", []signature{{4, []byte("ftypavif")}}},
+	{"video/quicktime", []signature{{4, []byte("ftypqt")}}},
+	{"video/mp4", []signature{{4, []byte("ftyp")}}},
+	{"video/webm", []signature{{0, []byte("\x1a\x45\xdf\xa3")}}},
+	{"video/avi", []signature{{0, []byte("RIFF")}, {8, []byte("AVI ")}}},
+	{"audio/wave", []signature{{0, []byte("RIFF")}, {8, []byte("WAVE")}}},
+	{"audio/mpeg", []signature{{0, []byte("ID3")}}},
+	// @note: mpeg audio layer 3 frame sync, mp3 is not always started by the id3 tag
+	{"audio/mpeg", []signature{{0, []byte("\xff\xfb")}}},
+	{"audio/mpeg", []signature{{0, []byte("\xff\xfa")}}},
+	{"audio/mpeg", []signature{{0, []byte("\xff\xf3")}}},
+	{"audio/mpeg", []signature{{0, []byte("\xff\xf2")}}},
+	{"audio/flac", []signature{{0, []byte("fLaC")}}},
+	{"application/ogg", []signature{{0, []byte("OggS")}}},
+	{"application/pdf", []signature{{0, []byte("%PDF-")}}},
+	{"text/rtf", []signature{{0, []byte("{\\rtf")}}},
+	{"application/zip", []signature{{0, []byte("PK\x03\x04")}}},
+	{"application/zip", []signature{{0, []byte("PK\x05\x06")}}},
+	{"application/x-gzip", []signature{{0, []byte("\x1f\x8b\x08")}}},
+	{"application/x-bzip2", []signature{{0, []byte("BZh")}}},
+	{"application/x-xz", []signature{{0, []byte("\xfd7zXZ\x00")}}},
+	{"application/zstd", []signature{{0, []byte("\x28\xb5\x2f\xfd")}}},
+	{"application/x-7z-compressed", []signature{{0, []byte("7z\xbc\xaf\x27\x1c")}}},
+	{"application/x-rar-compressed", []signature{{0, []byte("Rar!\x1a\x07")}}},
+	{"application/x-tar", []signature{{257, []byte("ustar")}}},
+	{"application/x-ole-storage", []signature{{0, []byte("\xd0\xcf\x11\xe0\xa1\xb1\x1a\xe1")}}},
+	{"application/vnd.sqlite3", []signature{{0, []byte("SQLite format 3\x00")}}},
+	{"application/wasm", []signature{{0, []byte("\x00asm")}}},
+	{"application/x-msdownload", []signature{{0, []byte("MZ")}}},
+	{"application/x-executable", []signature{{0, []byte("\x7fELF")}}},
+	{"application/x-mach-binary", []signature{{0, []byte("\xfe\xed\xfa\xce")}}},
+	{"application/x-mach-binary", []signature{{0, []byte("\xfe\xed\xfa\xcf")}}},
+	{"application/x-mach-binary", []signature{{0, []byte("\xce\xfa\xed\xfe")}}},
+	{"application/x-mach-binary", []signature{{0, []byte("\xcf\xfa\xed\xfe")}}},
+	{"application/java-vm", []signature{{0, []byte("\xca\xfe\xba\xbe")}}},
+	{"text/x-shellscript", []signature{{0, []byte("#!")}}},
+}
+
+// @note: plain text content may be sniffed as markup (e.g: markdown started by html tag)
+var textMimetypes = []string{"text/plain", "text/html", "text/xml"}
+
+// @note: expected mimetypes of the common extensions,
+// extension which is not listed can't be verified against the content
+var extensionMimetypes = map[string][]string{
+	"jpg":    {"image/jpeg"},
+	"jpeg":   {"image/jpeg"},
+	"png":    {"image/png"},
+	"gif":    {"image/gif"},
+	"webp":   {"image/webp"},
+	"bmp":    {"image/bmp"},
+	"tif":    {"image/tiff"},
+	"tiff":   {"image/tiff"},
+	"ico":    {"image/x-icon"},
+	"psd":    {"image/vnd.adobe.photoshop"},
+	"heic":   {"image/heic"},
+	"heif":   {"image/heic"},
+	"avif":   {"image/avif"},
+	"svg":    {"image/svg+xml", "text/xml", "text/plain"},
+	"mp4":    {"video/mp4"},
+	"m4v":    {"video/mp4"},
+	"m4a":    {"video/mp4"},
+	"mov":    {"video/quicktime"},
+	"webm":   {"video/webm"},
+	"mkv":    {"video/webm"},
+	"avi":    {"video/avi"},
+	"wav":    {"audio/wave"},
+	"mp3":    {"audio/mpeg"},
+	"flac":   {"audio/flac"},
+	"ogg":    {"application/ogg"},
+	"oga":    {"application/ogg"},
+	"ogv":    {"application/ogg"},
+	"opus":   {"application/ogg"},
+	"pdf":    {"application/pdf"},
+	"rtf":    {"text/rtf"},
+	"zip":    {"application/zip"},
+	"docx":   {"application/zip"},
+	"xlsx":   {"application/zip"},
+	"pptx":   {"application/zip"},
+	"odt":    {"application/zip"},
+	"ods":    {"application/zip"},
+	"odp":    {"application/zip"},
+	"epub":   {"application/zip"},
+	"jar":    {"application/zip"},
+	"apk":    {"application/zip"},
+	"doc":    {"application/x-ole-storage"},
+	"xls":    {"application/x-ole-storage"},
+	"ppt":    {"application/x-ole-storage"},
+	"msi":    {"application/x-ole-storage"},
+	"gz":     {"application/x-gzip"},
+	"tgz":    {"application/x-gzip"},
+	"bz2":    {"application/x-bzip2"},
+	"xz":     {"application/x-xz"},
+	"zst":    {"application/zstd"},
+	"7z":     {"application/x-7z-compressed"},
+	"rar":    {"application/x-rar-compressed"},
+	"tar":    {"application/x-tar"},
+	"sqlite": {"application/vnd.sqlite3"},
+	"wasm":   {"application/wasm"},
+	"exe":    {"application/x-msdownload"},
+	"dll":    {"application/x-msdownload"},
+	"class":  {"application/java-vm"},
+	"sh":     {"text/x-shellscript", "text/plain"},
+	"txt":    textMimetypes,
+	"log":    textMimetypes,
+	"md":     textMimetypes,
+	"csv":    textMimetypes,
+	"tsv":    textMimetypes,
+	"json":   textMimetypes,
+	"ndjson": textMimetypes,
+	"yaml":   textMimetypes,
+	"yml":    textMimetypes,
+	"xml":    {"text/xml", "text/plain"},
+	"html":   textMimetypes,
+	"htm":    textMimetypes,
+}
+
+// @note: header is the first bytes of the content (up to SNIFF_SIZE)
+func DetectMimetype(header []byte) string {
+	if len(header) > SNIFF_SIZE {
+		header = header[:SNIFF_SIZE]
+	}
+
+	for _, m := range magicNumbers {
+		if matchSignatures(header, m.signatures) {
+			return m.mimetype
+		}
+	}
+	return http.DetectContentType(header)
+}
+
+func matchSignatures(header []byte, signatures []signature) bool {
+	for _, s := range signatures {
+		end := s.offset + len(s.magic)
+		if len(header) < end || !bytes.Equal(header[s.offset:end], s.magic) {
+			return false
+		}
+	}
+	return true
+}
+
+// @note: returns true when the extension is unknown
+func MatchExtension(extension, mimetype string) bool {
+	mimetypes, ok := extensionMimetypes[strings.ToLower(extension)]
+	if !ok {
+		return true
+	}
+
+	mimetype = BaseMimetype(mimetype)
+	for _, m := range mimetypes {
+		if m == mimetype {
+			return true
+		}
+	}
+	return false
+}
+
+// @note: pattern is either the exact mimetype (e.g: application/json) or the type wildcard (e.g: image/*),
+// mimetype parameters (e.g: charset) are ignored
+func MatchMimetype(mimetype string, patterns []string) bool {
+	mimetype = BaseMimetype(mimetype)
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+		if pattern == mimetype {
+			return true
+		}
+		if strings.HasSuffix(pattern, "/*") && strings.HasPrefix(mimetype, strings.TrimSuffix(pattern, "*")) {
+			return true
+		}
+	}
+	return false
+}
+
+func BaseMimetype(mimetype string) string {
+	return strings.ToLower(strings.TrimSpace(strings.Split(mimetype, ";")[0]))
+}
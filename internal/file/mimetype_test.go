@@ -0,0 +1,103 @@
+package file_test
+
+import (
+	"bytes"
+
+	"github.com/go-seidon/hippo/internal/file"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Mimetype", func() {
+
+	Context("DetectMimetype function", Label("unit"), func() {
+		When("magic number is matched", func() {
+			It("should return result", func() {
+				tar := make([]byte, 512)
+				copy(tar[257:], "ustar")
+
+				Expect(file.DetectMimetype([]byte("\x89PNG\r\n\x1a\n0000"))).To(Equal("image/png"))
+				Expect(file.DetectMimetype([]byte("\xff\xd8\xff\xe0"))).To(Equal("image/jpeg"))
+				Expect(file.DetectMimetype([]byte("RIFF0000WEBPVP8 "))).To(Equal("image/webp"))
+				Expect(file.DetectMimetype([]byte("RIFF0000WAVEfmt "))).To(Equal("audio/wave"))
+				Expect(file.DetectMimetype([]byte("\x00\x00\x00\x18ftypheic"))).To(Equal("image/heic"))
+				Expect(file.DetectMimetype([]byte("\x00\x00\x00\x18ftypisom"))).To(Equal("video/mp4"))
+				Expect(file.DetectMimetype([]byte("ID3\x04\x00"))).To(Equal("audio/mpeg"))
+				Expect(file.DetectMimetype([]byte("\xff\xfb\x90\x64\x00"))).To(Equal("audio/mpeg"))
+				Expect(file.DetectMimetype([]byte("\xff\xf3\x90\x64\x00"))).To(Equal("audio/mpeg"))
+				Expect(file.DetectMimetype([]byte("MZ\x90\x00"))).To(Equal("application/x-msdownload"))
+				Expect(file.DetectMimetype([]byte("\x7fELF\x02\x01"))).To(Equal("application/x-executable"))
+				Expect(file.DetectMimetype([]byte("7z\xbc\xaf\x27\x1c\x00\x04"))).To(Equal("application/x-7z-compressed"))
+				Expect(file.DetectMimetype(tar)).To(Equal("application/x-tar"))
+			})
+		})
+
+		When("magic number is not matched", func() {
+			It("should return the sniffed mimetype", func() {
+				Expect(file.DetectMimetype([]byte("id,name\n1,hippo\n"))).To(Equal("text/plain; charset=utf-8"))
+				Expect(file.DetectMimetype([]byte("\x00\x01\x02\x03"))).To(Equal("application/octet-stream"))
+				Expect(file.DetectMimetype([]byte{})).To(Equal("text/plain; charset=utf-8"))
+			})
+		})
+
+		When("header is larger than the sniff size", func() {
+			It("should only use the sniff size", func() {
+				header := append(bytes.Repeat([]byte("a"), 512), 0x00)
+
+				Expect(file.DetectMimetype(header)).To(Equal("text/plain; charset=utf-8"))
+			})
+		})
+	})
+
+	Context("MatchExtension function", Label("unit"), func() {
+		When("extension is matched", func() {
+			It("should return true", func() {
+				Expect(file.MatchExtension("JPG", "image/jpeg")).To(BeTrue())
+				Expect(file.MatchExtension("csv", "text/plain; charset=utf-8")).To(BeTrue())
+				Expect(file.MatchExtension("docx", "application/zip")).To(BeTrue())
+			})
+		})
+
+		When("text content is sniffed as markup", func() {
+			It("should return true", func() {
+				Expect(file.MatchExtension("md", "text/html; charset=utf-8")).To(BeTrue())
+				Expect(file.MatchExtension("json", "text/xml; charset=utf-8")).To(BeTrue())
+				Expect(file.MatchExtension("html", "text/plain; charset=utf-8")).To(BeTrue())
+			})
+		})
+
+		When("extension is unknown", func() {
+			It("should return true", func() {
+				Expect(file.MatchExtension("", "application/x-msdownload")).To(BeTrue())
+				Expect(file.MatchExtension("bin", "application/x-msdownload")).To(BeTrue())
+			})
+		})
+
+		When("extension is not matched", func() {
+			It("should return false", func() {
+				Expect(file.MatchExtension("jpg", "application/x-msdownload")).To(BeFalse())
+				Expect(file.MatchExtension("txt", "image/png")).To(BeFalse())
+			})
+		})
+	})
+
+	Context("MatchMimetype function", Label("unit"), func() {
+		When("mimetype is matched", func() {
+			It("should return true", func() {
+				patterns := []string{"image/*", "application/pdf"}
+
+				Expect(file.MatchMimetype("image/png", patterns)).To(BeTrue())
+				Expect(file.MatchMimetype("Application/PDF", patterns)).To(BeTrue())
+			})
+		})
+
+		When("mimetype is not matched", func() {
+			It("should return false", func() {
+				patterns := []string{"image/*", "application/pdf"}
+
+				Expect(file.MatchMimetype("text/plain; charset=utf-8", patterns)).To(BeFalse())
+				Expect(file.MatchMimetype("image/png", nil)).To(BeFalse())
+			})
+		})
+	})
+})
@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/file/policy.go
+
+// Package mock_file is a generated GoMock package.
+package mock_file
+
+import (
+	reflect "reflect"
+
+	file "github.com/go-seidon/hippo/internal/file"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUploadPolicy is a mock of UploadPolicy interface.
+type MockUploadPolicy struct {
+	ctrl     *gomock.Controller
+	recorder *MockUploadPolicyMockRecorder
+}
+
+// MockUploadPolicyMockRecorder is the mock recorder for MockUploadPolicy.
+type MockUploadPolicyMockRecorder struct {
+	mock *MockUploadPolicy
+}
+
+// NewMockUploadPolicy creates a new mock instance.
+func NewMockUploadPolicy(ctrl *gomock.Controller) *MockUploadPolicy {
+	mock := &MockUploadPolicy{ctrl: ctrl}
+	mock.recorder = &MockUploadPolicyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUploadPolicy) EXPECT() *MockUploadPolicyMockRecorder {
+	return m.recorder
+}
+
+// CheckFile mocks base method.
+func (m *MockUploadPolicy) CheckFile(p file.CheckFileParam) (*file.CheckFileResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckFile", p)
+	ret0, _ := ret[0].(*file.CheckFileResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckFile indicates an expected call of CheckFile.
+func (mr *MockUploadPolicyMockRecorder) CheckFile(p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckFile", reflect.TypeOf((*MockUploadPolicy)(nil).CheckFile), p)
+}
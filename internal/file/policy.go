@@ -0,0 +1,85 @@
+package file
+
+import (
+	"strings"
+)
+
+type UploadPolicy interface {
+	CheckFile(p CheckFileParam) (*CheckFileResult, error)
+}
+
+// @note: header is the first bytes of the content used to detect the actual mimetype,
+// the declared mimetype is supplied by the client and is not trusted
+type CheckFileParam struct {
+	Header    []byte
+	Mimetype  string
+	Extension string
+}
+
+type CheckFileResult struct {
+	Mimetype string
+}
+
+type uploadPolicy struct {
+	allowedMimetypes  []string
+	deniedMimetypes   []string
+	allowedExtensions map[string]bool
+	deniedExtensions  map[string]bool
+	verifyExtension   bool
+}
+
+// @note: denied list takes precedence over allowed list,
+// everything is allowed when the allowed list is empty
+func (u *uploadPolicy) CheckFile(p CheckFileParam) (*CheckFileResult, error) {
+	extension := strings.ToLower(p.Extension)
+	if u.deniedExtensions[extension] {
+		return nil, ErrExtensionNotAllowed
+	}
+	if len(u.allowedExtensions) > 0 && !u.allowedExtensions[extension] {
+		return nil, ErrExtensionNotAllowed
+	}
+
+	mimetype := DetectMimetype(p.Header)
+	if MatchMimetype(mimetype, u.deniedMimetypes) {
+		return nil, ErrMimetypeNotAllowed
+	}
+	if len(u.allowedMimetypes) > 0 && !MatchMimetype(mimetype, u.allowedMimetypes) {
+		return nil, ErrMimetypeNotAllowed
+	}
+
+	if u.verifyExtension && !MatchExtension(extension, mimetype) {
+		return nil, ErrExtensionMismatch
+	}
+
+	res := &CheckFileResult{
+		Mimetype: mimetype,
+	}
+	return res, nil
+}
+
+type UploadPolicyParam struct {
+	AllowedMimetypes  []string
+	DeniedMimetypes   []string
+	AllowedExtensions []string
+	DeniedExtensions  []string
+	VerifyExtension   bool
+}
+
+func NewUploadPolicy(p UploadPolicyParam) *uploadPolicy {
+	return &uploadPolicy{
+		allowedMimetypes:  p.AllowedMimetypes,
+		deniedMimetypes:   p.DeniedMimetypes,
+		allowedExtensions: extensionSet(p.AllowedExtensions),
+		deniedExtensions:  extensionSet(p.DeniedExtensions),
+		verifyExtension:   p.VerifyExtension,
+	}
+}
+
+func extensionSet(extensions []string) map[string]bool {
+	set := map[string]bool{}
+	for _, extension := range extensions {
+		extension = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(extension), "."))
+		set[extension] = true
+	}
+	return set
+}
@@ -0,0 +1,175 @@
+package file_test
+
+import (
+	"github.com/go-seidon/hippo/internal/file"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Upload Policy", func() {
+
+	Context("NewUploadPolicy function", Label("unit"), func() {
+		When("success create policy", func() {
+			It("should return result", func() {
+				res := file.NewUploadPolicy(file.UploadPolicyParam{})
+
+				Expect(res).ToNot(BeNil())
+			})
+		})
+	})
+
+	Context("CheckFile function", Label("unit"), func() {
+		var (
+			png []byte
+			exe []byte
+		)
+
+		BeforeEach(func() {
+			png = []byte("\x89PNG\r\n\x1a\n0000")
+			exe = []byte("MZ\x90\x00\x03\x00")
+		})
+
+		When("policy is empty", func() {
+			It("should return the detected mimetype", func() {
+				policy := file.NewUploadPolicy(file.UploadPolicyParam{})
+
+				res, err := policy.CheckFile(file.CheckFileParam{
+					Header:    exe,
+					Mimetype:  "image/jpeg",
+					Extension: "jpg",
+				})
+
+				Expect(err).To(BeNil())
+				Expect(res).To(Equal(&file.CheckFileResult{
+					Mimetype: "application/x-msdownload",
+				}))
+			})
+		})
+
+		When("extension is denied", func() {
+			It("should return error", func() {
+				policy := file.NewUploadPolicy(file.UploadPolicyParam{
+					DeniedExtensions: []string{".EXE"},
+				})
+
+				res, err := policy.CheckFile(file.CheckFileParam{
+					Header:    exe,
+					Extension: "exe",
+				})
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(file.ErrExtensionNotAllowed))
+			})
+		})
+
+		When("extension is not allowed", func() {
+			It("should return error", func() {
+				policy := file.NewUploadPolicy(file.UploadPolicyParam{
+					AllowedExtensions: []string{"png", "jpg"},
+				})
+
+				res, err := policy.CheckFile(file.CheckFileParam{
+					Header:    png,
+					Extension: "",
+				})
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(file.ErrExtensionNotAllowed))
+			})
+		})
+
+		When("mimetype is denied", func() {
+			It("should return error", func() {
+				policy := file.NewUploadPolicy(file.UploadPolicyParam{
+					DeniedMimetypes: []string{"application/x-msdownload"},
+				})
+
+				res, err := policy.CheckFile(file.CheckFileParam{
+					Header:    exe,
+					Mimetype:  "image/png",
+					Extension: "png",
+				})
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(file.ErrMimetypeNotAllowed))
+			})
+		})
+
+		When("mimetype is not allowed", func() {
+			It("should return error", func() {
+				policy := file.NewUploadPolicy(file.UploadPolicyParam{
+					AllowedMimetypes: []string{"image/*"},
+				})
+
+				res, err := policy.CheckFile(file.CheckFileParam{
+					Header:    []byte("plain text"),
+					Mimetype:  "image/png",
+					Extension: "png",
+				})
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(file.ErrMimetypeNotAllowed))
+			})
+		})
+
+		When("extension does not match the content", func() {
+			It("should return error", func() {
+				policy := file.NewUploadPolicy(file.UploadPolicyParam{
+					VerifyExtension: true,
+				})
+
+				res, err := policy.CheckFile(file.CheckFileParam{
+					Header:    exe,
+					Mimetype:  "image/jpeg",
+					Extension: "jpg",
+				})
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(file.ErrExtensionMismatch))
+			})
+		})
+
+		When("extension is verified against the common content", func() {
+			It("should return result", func() {
+				policy := file.NewUploadPolicy(file.UploadPolicyParam{
+					VerifyExtension: true,
+				})
+
+				files := []file.CheckFileParam{
+					{Header: []byte("\xff\xfb\x90\x64\x00\x00"), Extension: "mp3"},
+					{Header: []byte("name;city\r\nJos\xe9;M\xfcnchen\r\n"), Extension: "csv"},
+					{Header: []byte("price \x80 10\r\n"), Extension: "txt"},
+					{Header: []byte("<p align=\"center\">hippo</p>\n# Readme"), Extension: "md"},
+					{Header: []byte("<?xml version=\"1.0\"?>"), Extension: "json"},
+				}
+				for _, f := range files {
+					_, err := policy.CheckFile(f)
+					Expect(err).To(BeNil(), f.Extension)
+				}
+			})
+		})
+
+		When("file is allowed", func() {
+			It("should return result", func() {
+				policy := file.NewUploadPolicy(file.UploadPolicyParam{
+					AllowedMimetypes:  []string{"image/*"},
+					DeniedMimetypes:   []string{"image/svg+xml"},
+					AllowedExtensions: []string{"png"},
+					DeniedExtensions:  []string{"exe"},
+					VerifyExtension:   true,
+				})
+
+				res, err := policy.CheckFile(file.CheckFileParam{
+					Header:    png,
+					Mimetype:  "application/octet-stream",
+					Extension: "PNG",
+				})
+
+				Expect(err).To(BeNil())
+				Expect(res).To(Equal(&file.CheckFileResult{
+					Mimetype: "image/png",
+				}))
+			})
+		})
+	})
+})
@@ -3,5 +3,8 @@ package filesystem
 import "errors"
 
 var (
-	ErrorFileNotFound = errors.New("file not found")
+	ErrorFileNotFound  = errors.New("file not found")
+	ErrorFileExists    = errors.New("file already exists")
+	ErrorInvalidReader = errors.New("invalid reader")
+	ErrorInvalidOffset = errors.New("invalid offset")
 )
@@ -3,38 +3,74 @@ package filesystem
 import (
 	"context"
 	"errors"
+	"io"
 	"io/fs"
 	"os"
+	"path/filepath"
+	"strings"
 	"time"
 )
 
+const (
+	TEMP_FILE_PREFIX = ".tmp-"
+)
+
 type FileManager interface {
 	IsFileExists(ctx context.Context, p IsFileExistsParam) (bool, error)
 	OpenFile(ctx context.Context, p OpenFileParam) (*OpenFileResult, error)
 	SaveFile(ctx context.Context, p SaveFileParam) (*SaveFileResult, error)
 	RemoveFile(ctx context.Context, p RemoveFileParam) (*RemoveFileResult, error)
+	MoveFile(ctx context.Context, p MoveFileParam) (*MoveFileResult, error)
+}
+
+// @note: leftover temp files of the interrupted writes are removed
+type TempFileCleaner interface {
+	CleanTempFiles(ctx context.Context, p CleanTempFilesParam) (*CleanTempFilesResult, error)
 }
 
 type IsFileExistsParam struct {
 	Path string
 }
 
+// @note: file is read from the offset until the end when length is not specified,
+// encryption key and compression are the ones returned when the file is saved (if any)
+// compressed file is read as it is when the compression is not specified
 type OpenFileParam struct {
-	Path string
+	Path          string
+	Offset        int64
+	Length        int64
+	EncryptionKey *EncryptionKey
+	Compression   string
 }
 
 type OpenFileResult struct {
-	File *os.File
+	File io.ReadCloser
 }
 
+// @note: ErrorFileExists is returned when exclusive is set and the file already exists,
+// mimetype is the type of the given data which may be used to decide how it's stored
 type SaveFileParam struct {
 	Name       string
-	Data       []byte
+	Reader     io.Reader
 	Permission fs.FileMode
+	Exclusive  bool
+	Mimetype   string
 }
 
+// @note: size is the size of the given data while stored size is the size written to the storage,
+// encryption key and compression are only available when the data is encrypted or compressed
 type SaveFileResult struct {
-	SavedAt time.Time
+	Size          int64
+	StoredSize    int64
+	SavedAt       time.Time
+	EncryptionKey *EncryptionKey
+	Compression   string
+}
+
+// @note: data key of the file wrapped by the master key
+type EncryptionKey struct {
+	KeyId   string
+	DataKey string
 }
 
 type RemoveFileParam struct {
@@ -45,6 +81,26 @@ type RemoveFileResult struct {
 	RemovedAt time.Time
 }
 
+// @note: destination is overwritten if exists
+type MoveFileParam struct {
+	SourcePath      string
+	DestinationPath string
+}
+
+type MoveFileResult struct {
+	MovedAt time.Time
+}
+
+// @note: temp files modified before the given time are removed recursively from the directory
+type CleanTempFilesParam struct {
+	Directory      string
+	ModifiedBefore time.Time
+}
+
+type CleanTempFilesResult struct {
+	TotalRemoved int64
+}
+
 type fileManager struct {
 }
 
@@ -69,26 +125,130 @@ func (fm *fileManager) OpenFile(ctx context.Context, p OpenFileParam) (*OpenFile
 		return nil, err
 	}
 
+	if p.Offset > 0 {
+		_, err = file.Seek(p.Offset, io.SeekStart)
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
+	}
+
+	var rc io.ReadCloser = file
+	if p.Length > 0 {
+		rc = &limitedFile{
+			Reader: io.LimitReader(file, p.Length),
+			Closer: file,
+		}
+	}
+
 	res := &OpenFileResult{
-		File: file,
+		File: rc,
 	}
 	return res, nil
 }
 
-// @note: save file/overwrite if exists
+type limitedFile struct {
+	io.Reader
+	io.Closer
+}
+
+// @note: data is streamed into a temp file in the same directory which is synced
+// and then atomically renamed (or linked when exclusive) into the destination,
+// so the destination is never observed partially written
 func (fm *fileManager) SaveFile(ctx context.Context, p SaveFileParam) (*SaveFileResult, error) {
-	err := os.WriteFile(p.Name, p.Data, p.Permission)
+	if p.Reader == nil {
+		return nil, ErrorInvalidReader
+	}
+
+	dir, base := filepath.Split(p.Name)
+	if dir == "" {
+		dir = "."
+	}
+
+	file, err := os.CreateTemp(dir, TEMP_FILE_PREFIX+base+"-*")
+	if err != nil {
+		return nil, err
+	}
+	tempName := file.Name()
+
+	size, err := fm.writeTempFile(file, p.Reader, p.Permission)
+	if err != nil {
+		os.Remove(tempName)
+		return nil, err
+	}
+
+	if p.Exclusive {
+		// @note: link fails when the destination exists which is not the case for rename
+		err = os.Link(tempName, p.Name)
+		os.Remove(tempName)
+		if errors.Is(err, os.ErrExist) {
+			return nil, ErrorFileExists
+		}
+	} else {
+		err = os.Rename(tempName, p.Name)
+		if err != nil {
+			os.Remove(tempName)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = syncDirectory(dir)
 	if err != nil {
 		return nil, err
 	}
 
 	currentTs := time.Now()
 	res := &SaveFileResult{
-		SavedAt: currentTs,
+		Size:       size,
+		StoredSize: size,
+		SavedAt:    currentTs,
 	}
 	return res, nil
 }
 
+func (fm *fileManager) writeTempFile(file *os.File, r io.Reader, perm fs.FileMode) (int64, error) {
+	size, err := io.Copy(file, r)
+	if err != nil {
+		file.Close()
+		return 0, err
+	}
+
+	err = file.Chmod(perm)
+	if err != nil {
+		file.Close()
+		return 0, err
+	}
+
+	err = file.Sync()
+	if err != nil {
+		file.Close()
+		return 0, err
+	}
+
+	err = file.Close()
+	if err != nil {
+		return 0, err
+	}
+	return size, nil
+}
+
+// @note: directory is synced to persist the renamed entry
+func syncDirectory(path string) error {
+	dir, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+
+	err = dir.Sync()
+	if err != nil {
+		dir.Close()
+		return err
+	}
+	return dir.Close()
+}
+
 func (fm *fileManager) RemoveFile(ctx context.Context, p RemoveFileParam) (*RemoveFileResult, error) {
 	err := os.Remove(p.Path)
 	if err != nil {
@@ -105,6 +265,56 @@ func (fm *fileManager) RemoveFile(ctx context.Context, p RemoveFileParam) (*Remo
 	return res, nil
 }
 
+func (fm *fileManager) MoveFile(ctx context.Context, p MoveFileParam) (*MoveFileResult, error) {
+	err := os.Rename(p.SourcePath, p.DestinationPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrorFileNotFound
+		}
+		return nil, err
+	}
+
+	res := &MoveFileResult{
+		MovedAt: time.Now(),
+	}
+	return res, nil
+}
+
+func (fm *fileManager) CleanTempFiles(ctx context.Context, p CleanTempFilesParam) (*CleanTempFilesResult, error) {
+	res := &CleanTempFilesResult{}
+	err := filepath.WalkDir(p.Directory, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) && path == p.Directory {
+				return filepath.SkipDir
+			}
+			return err
+		}
+
+		if d.IsDir() || !strings.HasPrefix(d.Name(), TEMP_FILE_PREFIX) {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if !info.ModTime().Before(p.ModifiedBefore) {
+			return nil
+		}
+
+		err = os.Remove(path)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		res.TotalRemoved++
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
 func NewFileManager() *fileManager {
 	s := &fileManager{}
 	return s
@@ -2,9 +2,16 @@ package filesystem_test
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
 	"io/fs"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"testing/iotest"
+	"time"
 
 	"github.com/go-seidon/hippo/internal/filesystem"
 	. "github.com/onsi/ginkgo/v2"
@@ -85,6 +92,21 @@ var _ = Describe("File Manager", func() {
 				})
 			})
 
+			When("range is specified", func() {
+				It("should return ranged content", func() {
+					res, err := fm.OpenFile(ctx, filesystem.OpenFileParam{
+						Path:   "file.go",
+						Offset: 8,
+						Length: 10,
+					})
+					data, _ := io.ReadAll(res.File)
+					res.File.Close()
+
+					Expect(err).To(BeNil())
+					Expect(string(data)).To(Equal("filesystem"))
+				})
+			})
+
 			When("file is unavailable", func() {
 				It("should return error", func() {
 					res, err := fm.OpenFile(ctx, filesystem.OpenFileParam{
@@ -124,11 +146,24 @@ var _ = Describe("File Manager", func() {
 				}
 			})
 
+			When("reader is not specified", func() {
+				It("should return error", func() {
+					res, err := fm.SaveFile(ctx, filesystem.SaveFileParam{
+						Name:       fileName,
+						Reader:     nil,
+						Permission: fs.ModeTemporary,
+					})
+
+					Expect(res).To(BeNil())
+					Expect(err).To(Equal(filesystem.ErrorInvalidReader))
+				})
+			})
+
 			When("failed save file", func() {
 				It("should return error", func() {
 					res, err := fm.SaveFile(ctx, filesystem.SaveFileParam{
 						Name:       "", //should specify file name
-						Data:       nil,
+						Reader:     strings.NewReader(""),
 						Permission: fs.ModeTemporary,
 					})
 
@@ -137,16 +172,151 @@ var _ = Describe("File Manager", func() {
 				})
 			})
 
+			When("failed read data", func() {
+				It("should return error and remove partial file", func() {
+					reader := io.MultiReader(
+						strings.NewReader("partial"),
+						iotest.ErrReader(fmt.Errorf("read error")),
+					)
+					res, err := fm.SaveFile(ctx, filesystem.SaveFileParam{
+						Name:       "temp-partial-file.txt",
+						Reader:     reader,
+						Permission: 0644,
+					})
+
+					Expect(res).To(BeNil())
+					Expect(err).To(Equal(fmt.Errorf("read error")))
+
+					_, serr := os.Stat("temp-partial-file.txt")
+					Expect(errors.Is(serr, os.ErrNotExist)).To(BeTrue())
+					temps, _ := filepath.Glob(filesystem.TEMP_FILE_PREFIX + "temp-partial-file.txt-*")
+					Expect(temps).To(BeEmpty())
+				})
+			})
+
 			When("success save file", func() {
 				It("should return result", func() {
 					res, err := fm.SaveFile(ctx, filesystem.SaveFileParam{
 						Name:       fileName,
-						Data:       nil,
+						Reader:     strings.NewReader("content"),
 						Permission: fs.ModeTemporary,
 					})
 
 					Expect(res).ToNot(BeNil())
+					Expect(res.Size).To(Equal(int64(7)))
+					Expect(err).To(BeNil())
+				})
+			})
+
+			When("file already exists", func() {
+				It("should overwrite the file", func() {
+					res, err := fm.SaveFile(ctx, filesystem.SaveFileParam{
+						Name:       fileName,
+						Reader:     strings.NewReader("new content"),
+						Permission: 0644,
+					})
+
+					Expect(res.Size).To(Equal(int64(11)))
+					Expect(err).To(BeNil())
+					data, _ := os.ReadFile(fileName)
+					Expect(data).To(Equal([]byte("new content")))
+				})
+			})
+
+			When("file already exists and exclusive is set", func() {
+				It("should return error", func() {
+					res, err := fm.SaveFile(ctx, filesystem.SaveFileParam{
+						Name:       fileName,
+						Reader:     strings.NewReader("other content"),
+						Permission: 0644,
+						Exclusive:  true,
+					})
+
+					Expect(res).To(BeNil())
+					Expect(err).To(Equal(filesystem.ErrorFileExists))
+					data, _ := os.ReadFile(fileName)
+					Expect(data).To(Equal([]byte("new content")))
+					temps, _ := filepath.Glob(filesystem.TEMP_FILE_PREFIX + fileName + "-*")
+					Expect(temps).To(BeEmpty())
+				})
+			})
+
+			When("file does not exist and exclusive is set", func() {
+				It("should return result", func() {
+					name := "temp-exclusive-file.txt"
+					defer os.Remove(name)
+
+					res, err := fm.SaveFile(ctx, filesystem.SaveFileParam{
+						Name:       name,
+						Reader:     strings.NewReader("content"),
+						Permission: 0640,
+						Exclusive:  true,
+					})
+
+					Expect(res.Size).To(Equal(int64(7)))
+					Expect(err).To(BeNil())
+					info, _ := os.Stat(name)
+					Expect(info.Mode().Perm()).To(Equal(fs.FileMode(0640)))
+					temps, _ := filepath.Glob(filesystem.TEMP_FILE_PREFIX + name + "-*")
+					Expect(temps).To(BeEmpty())
+				})
+			})
+		})
+
+		Context("CleanTempFiles function", func() {
+			var (
+				dir string
+			)
+
+			BeforeEach(func() {
+				var err error
+				dir, err = os.MkdirTemp("", "clean-temp")
+				if err != nil {
+					AbortSuite("failed settingup temp dir: " + err.Error())
+				}
+				DeferCleanup(func() {
+					os.RemoveAll(dir)
+				})
+			})
+
+			When("directory does not exist", func() {
+				It("should return result", func() {
+					res, err := filesystem.NewFileManager().CleanTempFiles(ctx, filesystem.CleanTempFilesParam{
+						Directory:      filepath.Join(dir, "unavailable"),
+						ModifiedBefore: time.Now(),
+					})
+
+					Expect(res).To(Equal(&filesystem.CleanTempFilesResult{}))
+					Expect(err).To(BeNil())
+				})
+			})
+
+			When("there are leftover temp files", func() {
+				It("should remove the old temp files", func() {
+					oldTs := time.Now().Add(-2 * time.Hour)
+					oldTemp := filepath.Join(dir, "2023", filesystem.TEMP_FILE_PREFIX+"a.txt-123")
+					newTemp := filepath.Join(dir, filesystem.TEMP_FILE_PREFIX+"b.txt-456")
+					regular := filepath.Join(dir, "2023", "c.txt")
+					os.MkdirAll(filepath.Join(dir, "2023"), 0755)
+					os.WriteFile(oldTemp, []byte("partial"), 0644)
+					os.WriteFile(newTemp, []byte("partial"), 0644)
+					os.WriteFile(regular, []byte("content"), 0644)
+					os.Chtimes(oldTemp, oldTs, oldTs)
+					os.Chtimes(regular, oldTs, oldTs)
+
+					res, err := filesystem.NewFileManager().CleanTempFiles(ctx, filesystem.CleanTempFilesParam{
+						Directory:      dir,
+						ModifiedBefore: time.Now().Add(-time.Hour),
+					})
+
+					Expect(res).To(Equal(&filesystem.CleanTempFilesResult{TotalRemoved: 1}))
 					Expect(err).To(BeNil())
+					_, serr := os.Stat(oldTemp)
+					Expect(os.IsNotExist(serr)).To(BeTrue())
+					_, serr = os.Stat(newTemp)
+					Expect(serr).To(BeNil())
+					_, serr = os.Stat(regular)
+					Expect(serr).To(BeNil())
 				})
 			})
 		})
@@ -197,5 +367,67 @@ var _ = Describe("File Manager", func() {
 				})
 			})
 		})
+
+		Context("MoveFile function", Ordered, func() {
+			var (
+				fileName string
+				destName string
+			)
+
+			BeforeAll(func() {
+				fileName = "temp-move-file.txt"
+				destName = "temp-moved-file.txt"
+				err := os.WriteFile(fileName, []byte("content"), 0644)
+				if err != nil {
+					AbortSuite("failed settingup temp file: " + err.Error())
+				}
+			})
+
+			AfterAll(func() {
+				os.Remove(fileName)
+				os.Remove(destName)
+			})
+
+			When("source file is unavailable", func() {
+				It("should return error", func() {
+					res, err := fm.MoveFile(ctx, filesystem.MoveFileParam{
+						SourcePath:      "unavailable-file",
+						DestinationPath: destName,
+					})
+
+					Expect(res).To(BeNil())
+					Expect(err).To(Equal(filesystem.ErrorFileNotFound))
+				})
+			})
+
+			When("failed move file", func() {
+				It("should return error", func() {
+					res, err := fm.MoveFile(ctx, filesystem.MoveFileParam{
+						SourcePath:      fileName,
+						DestinationPath: "\000",
+					})
+
+					Expect(res).To(BeNil())
+					Expect(err).ToNot(BeNil())
+				})
+			})
+
+			When("source file is available", func() {
+				It("should return result", func() {
+					res, err := fm.MoveFile(ctx, filesystem.MoveFileParam{
+						SourcePath:      fileName,
+						DestinationPath: destName,
+					})
+
+					Expect(res).ToNot(BeNil())
+					Expect(err).To(BeNil())
+
+					data, _ := os.ReadFile(destName)
+					Expect(data).To(Equal([]byte("content")))
+					_, serr := os.Stat(fileName)
+					Expect(os.IsNotExist(serr)).To(BeTrue())
+				})
+			})
+		})
 	})
 })
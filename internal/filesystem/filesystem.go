@@ -0,0 +1,6 @@
+package filesystem
+
+const (
+	PROVIDER_LOCAL = "local"
+	PROVIDER_S3    = "s3"
+)
@@ -50,6 +50,21 @@ func (mr *MockFileManagerMockRecorder) IsFileExists(ctx, p interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsFileExists", reflect.TypeOf((*MockFileManager)(nil).IsFileExists), ctx, p)
 }
 
+// MoveFile mocks base method.
+func (m *MockFileManager) MoveFile(ctx context.Context, p filesystem.MoveFileParam) (*filesystem.MoveFileResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveFile", ctx, p)
+	ret0, _ := ret[0].(*filesystem.MoveFileResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveFile indicates an expected call of MoveFile.
+func (mr *MockFileManagerMockRecorder) MoveFile(ctx, p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveFile", reflect.TypeOf((*MockFileManager)(nil).MoveFile), ctx, p)
+}
+
 // OpenFile mocks base method.
 func (m *MockFileManager) OpenFile(ctx context.Context, p filesystem.OpenFileParam) (*filesystem.OpenFileResult, error) {
 	m.ctrl.T.Helper()
@@ -94,3 +109,41 @@ func (mr *MockFileManagerMockRecorder) SaveFile(ctx, p interface{}) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveFile", reflect.TypeOf((*MockFileManager)(nil).SaveFile), ctx, p)
 }
+
+// MockTempFileCleaner is a mock of TempFileCleaner interface.
+type MockTempFileCleaner struct {
+	ctrl     *gomock.Controller
+	recorder *MockTempFileCleanerMockRecorder
+}
+
+// MockTempFileCleanerMockRecorder is the mock recorder for MockTempFileCleaner.
+type MockTempFileCleanerMockRecorder struct {
+	mock *MockTempFileCleaner
+}
+
+// NewMockTempFileCleaner creates a new mock instance.
+func NewMockTempFileCleaner(ctrl *gomock.Controller) *MockTempFileCleaner {
+	mock := &MockTempFileCleaner{ctrl: ctrl}
+	mock.recorder = &MockTempFileCleanerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTempFileCleaner) EXPECT() *MockTempFileCleanerMockRecorder {
+	return m.recorder
+}
+
+// CleanTempFiles mocks base method.
+func (m *MockTempFileCleaner) CleanTempFiles(ctx context.Context, p filesystem.CleanTempFilesParam) (*filesystem.CleanTempFilesResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CleanTempFiles", ctx, p)
+	ret0, _ := ret[0].(*filesystem.CleanTempFilesResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CleanTempFiles indicates an expected call of CleanTempFiles.
+func (mr *MockTempFileCleanerMockRecorder) CleanTempFiles(ctx, p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CleanTempFiles", reflect.TypeOf((*MockTempFileCleaner)(nil).CleanTempFiles), ctx, p)
+}
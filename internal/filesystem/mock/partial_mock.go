@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/filesystem/partial.go
+
+// Package mock_filesystem is a generated GoMock package.
+package mock_filesystem
+
+import (
+	context "context"
+	reflect "reflect"
+
+	filesystem "github.com/go-seidon/hippo/internal/filesystem"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPartialManager is a mock of PartialManager interface.
+type MockPartialManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockPartialManagerMockRecorder
+}
+
+// MockPartialManagerMockRecorder is the mock recorder for MockPartialManager.
+type MockPartialManagerMockRecorder struct {
+	mock *MockPartialManager
+}
+
+// NewMockPartialManager creates a new mock instance.
+func NewMockPartialManager(ctrl *gomock.Controller) *MockPartialManager {
+	mock := &MockPartialManager{ctrl: ctrl}
+	mock.recorder = &MockPartialManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPartialManager) EXPECT() *MockPartialManagerMockRecorder {
+	return m.recorder
+}
+
+// AppendPartial mocks base method.
+func (m *MockPartialManager) AppendPartial(ctx context.Context, p filesystem.AppendPartialParam) (*filesystem.AppendPartialResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AppendPartial", ctx, p)
+	ret0, _ := ret[0].(*filesystem.AppendPartialResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AppendPartial indicates an expected call of AppendPartial.
+func (mr *MockPartialManagerMockRecorder) AppendPartial(ctx, p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendPartial", reflect.TypeOf((*MockPartialManager)(nil).AppendPartial), ctx, p)
+}
+
+// CreatePartial mocks base method.
+func (m *MockPartialManager) CreatePartial(ctx context.Context, p filesystem.CreatePartialParam) (*filesystem.CreatePartialResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePartial", ctx, p)
+	ret0, _ := ret[0].(*filesystem.CreatePartialResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePartial indicates an expected call of CreatePartial.
+func (mr *MockPartialManagerMockRecorder) CreatePartial(ctx, p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePartial", reflect.TypeOf((*MockPartialManager)(nil).CreatePartial), ctx, p)
+}
+
+// OpenPartial mocks base method.
+func (m *MockPartialManager) OpenPartial(ctx context.Context, p filesystem.OpenPartialParam) (*filesystem.OpenPartialResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenPartial", ctx, p)
+	ret0, _ := ret[0].(*filesystem.OpenPartialResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OpenPartial indicates an expected call of OpenPartial.
+func (mr *MockPartialManagerMockRecorder) OpenPartial(ctx, p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenPartial", reflect.TypeOf((*MockPartialManager)(nil).OpenPartial), ctx, p)
+}
+
+// RemovePartial mocks base method.
+func (m *MockPartialManager) RemovePartial(ctx context.Context, p filesystem.RemovePartialParam) (*filesystem.RemovePartialResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemovePartial", ctx, p)
+	ret0, _ := ret[0].(*filesystem.RemovePartialResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemovePartial indicates an expected call of RemovePartial.
+func (mr *MockPartialManagerMockRecorder) RemovePartial(ctx, p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemovePartial", reflect.TypeOf((*MockPartialManager)(nil).RemovePartial), ctx, p)
+}
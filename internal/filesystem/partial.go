@@ -0,0 +1,155 @@
+package filesystem
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"time"
+)
+
+// @note: partial file is an incomplete upload which is appended chunk by chunk
+// it's always stored in local disk regardless of the storage provider
+type PartialManager interface {
+	CreatePartial(ctx context.Context, p CreatePartialParam) (*CreatePartialResult, error)
+	AppendPartial(ctx context.Context, p AppendPartialParam) (*AppendPartialResult, error)
+	OpenPartial(ctx context.Context, p OpenPartialParam) (*OpenPartialResult, error)
+	RemovePartial(ctx context.Context, p RemovePartialParam) (*RemovePartialResult, error)
+}
+
+type CreatePartialParam struct {
+	Path       string
+	Permission fs.FileMode
+}
+
+type CreatePartialResult struct {
+	CreatedAt time.Time
+}
+
+type AppendPartialParam struct {
+	Path   string
+	Offset int64
+	Reader io.Reader
+}
+
+type AppendPartialResult struct {
+	Size       int64
+	AppendedAt time.Time
+}
+
+type OpenPartialParam struct {
+	Path string
+}
+
+type OpenPartialResult struct {
+	File io.ReadCloser
+}
+
+type RemovePartialParam struct {
+	Path string
+}
+
+type RemovePartialResult struct {
+	RemovedAt time.Time
+}
+
+type partialManager struct {
+}
+
+func (pm *partialManager) CreatePartial(ctx context.Context, p CreatePartialParam) (*CreatePartialResult, error) {
+	file, err := os.OpenFile(p.Path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, p.Permission)
+	if err != nil {
+		return nil, err
+	}
+
+	err = file.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	res := &CreatePartialResult{
+		CreatedAt: time.Now(),
+	}
+	return res, nil
+}
+
+// @note: data is appended only when the current size is equal to the offset
+// partially appended data is truncated on failure so the chunk can be re-sent
+func (pm *partialManager) AppendPartial(ctx context.Context, p AppendPartialParam) (*AppendPartialResult, error) {
+	if p.Reader == nil {
+		return nil, ErrorInvalidReader
+	}
+
+	file, err := os.OpenFile(p.Path, os.O_WRONLY, 0)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrorFileNotFound
+		}
+		return nil, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() != p.Offset {
+		return nil, ErrorInvalidOffset
+	}
+
+	_, err = file.Seek(p.Offset, io.SeekStart)
+	if err != nil {
+		return nil, err
+	}
+
+	size, err := io.Copy(file, p.Reader)
+	if err == nil {
+		err = file.Sync()
+	}
+	if err != nil {
+		file.Truncate(p.Offset)
+		return nil, err
+	}
+
+	res := &AppendPartialResult{
+		Size:       p.Offset + size,
+		AppendedAt: time.Now(),
+	}
+	return res, nil
+}
+
+func (pm *partialManager) OpenPartial(ctx context.Context, p OpenPartialParam) (*OpenPartialResult, error) {
+	file, err := os.Open(p.Path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrorFileNotFound
+		}
+		return nil, err
+	}
+
+	res := &OpenPartialResult{
+		File: file,
+	}
+	return res, nil
+}
+
+func (pm *partialManager) RemovePartial(ctx context.Context, p RemovePartialParam) (*RemovePartialResult, error) {
+	err := os.Remove(p.Path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrorFileNotFound
+		}
+		return nil, err
+	}
+
+	res := &RemovePartialResult{
+		RemovedAt: time.Now(),
+	}
+	return res, nil
+}
+
+func NewPartialManager() *partialManager {
+	s := &partialManager{}
+	return s
+}
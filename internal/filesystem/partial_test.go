@@ -0,0 +1,204 @@
+package filesystem_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing/iotest"
+
+	"github.com/go-seidon/hippo/internal/filesystem"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Partial Manager", func() {
+	Context("NewPartialManager function", Label("unit"), func() {
+		When("success create partial manager", func() {
+			It("should return result", func() {
+				res := filesystem.NewPartialManager()
+
+				Expect(res).ToNot(BeNil())
+			})
+		})
+	})
+
+	Describe("Partial Manager", Label("integration"), Ordered, func() {
+		var (
+			ctx      context.Context
+			pm       filesystem.PartialManager
+			fileName string
+		)
+
+		BeforeAll(func() {
+			ctx = context.Background()
+			pm = filesystem.NewPartialManager()
+			fileName = "temp-upload-partial.txt"
+		})
+
+		AfterAll(func() {
+			os.Remove(fileName)
+		})
+
+		Context("CreatePartial function", func() {
+			When("success create partial", func() {
+				It("should return result", func() {
+					res, err := pm.CreatePartial(ctx, filesystem.CreatePartialParam{
+						Path:       fileName,
+						Permission: 0644,
+					})
+
+					Expect(res).ToNot(BeNil())
+					Expect(err).To(BeNil())
+				})
+			})
+
+			When("partial is already exists", func() {
+				It("should return error", func() {
+					res, err := pm.CreatePartial(ctx, filesystem.CreatePartialParam{
+						Path:       fileName,
+						Permission: 0644,
+					})
+
+					Expect(res).To(BeNil())
+					Expect(os.IsExist(err)).To(BeTrue())
+				})
+			})
+		})
+
+		Context("AppendPartial function", func() {
+			When("reader is not specified", func() {
+				It("should return error", func() {
+					res, err := pm.AppendPartial(ctx, filesystem.AppendPartialParam{
+						Path: fileName,
+					})
+
+					Expect(res).To(BeNil())
+					Expect(err).To(Equal(filesystem.ErrorInvalidReader))
+				})
+			})
+
+			When("partial is not available", func() {
+				It("should return error", func() {
+					res, err := pm.AppendPartial(ctx, filesystem.AppendPartialParam{
+						Path:   "unavailable-partial.txt",
+						Reader: strings.NewReader("hippo"),
+					})
+
+					Expect(res).To(BeNil())
+					Expect(err).To(Equal(filesystem.ErrorFileNotFound))
+				})
+			})
+
+			When("offset is not matched", func() {
+				It("should return error", func() {
+					res, err := pm.AppendPartial(ctx, filesystem.AppendPartialParam{
+						Path:   fileName,
+						Offset: 2,
+						Reader: strings.NewReader("hippo"),
+					})
+
+					Expect(res).To(BeNil())
+					Expect(err).To(Equal(filesystem.ErrorInvalidOffset))
+				})
+			})
+
+			When("success append partial", func() {
+				It("should return result", func() {
+					res, err := pm.AppendPartial(ctx, filesystem.AppendPartialParam{
+						Path:   fileName,
+						Offset: 0,
+						Reader: strings.NewReader("hip"),
+					})
+
+					Expect(err).To(BeNil())
+					Expect(res.Size).To(Equal(int64(3)))
+				})
+			})
+
+			When("failed read data", func() {
+				It("should truncate appended data", func() {
+					reader := io.MultiReader(
+						strings.NewReader("p"),
+						iotest.ErrReader(fmt.Errorf("read error")),
+					)
+					res, err := pm.AppendPartial(ctx, filesystem.AppendPartialParam{
+						Path:   fileName,
+						Offset: 3,
+						Reader: reader,
+					})
+
+					Expect(res).To(BeNil())
+					Expect(err).To(Equal(fmt.Errorf("read error")))
+
+					info, _ := os.Stat(fileName)
+					Expect(info.Size()).To(Equal(int64(3)))
+				})
+			})
+
+			When("success append next chunk", func() {
+				It("should return result", func() {
+					res, err := pm.AppendPartial(ctx, filesystem.AppendPartialParam{
+						Path:   fileName,
+						Offset: 3,
+						Reader: strings.NewReader("po"),
+					})
+
+					Expect(err).To(BeNil())
+					Expect(res.Size).To(Equal(int64(5)))
+				})
+			})
+		})
+
+		Context("OpenPartial function", func() {
+			When("partial is not available", func() {
+				It("should return error", func() {
+					res, err := pm.OpenPartial(ctx, filesystem.OpenPartialParam{
+						Path: "unavailable-partial.txt",
+					})
+
+					Expect(res).To(BeNil())
+					Expect(err).To(Equal(filesystem.ErrorFileNotFound))
+				})
+			})
+
+			When("partial is available", func() {
+				It("should return result", func() {
+					res, err := pm.OpenPartial(ctx, filesystem.OpenPartialParam{
+						Path: fileName,
+					})
+					Expect(err).To(BeNil())
+					defer res.File.Close()
+
+					data, _ := io.ReadAll(res.File)
+					Expect(string(data)).To(Equal("hippo"))
+				})
+			})
+		})
+
+		Context("RemovePartial function", func() {
+			When("partial is available", func() {
+				It("should return result", func() {
+					res, err := pm.RemovePartial(ctx, filesystem.RemovePartialParam{
+						Path: fileName,
+					})
+
+					Expect(res).ToNot(BeNil())
+					Expect(err).To(BeNil())
+				})
+			})
+
+			When("partial is not available", func() {
+				It("should return error", func() {
+					res, err := pm.RemovePartial(ctx, filesystem.RemovePartialParam{
+						Path: fileName,
+					})
+
+					Expect(res).To(BeNil())
+					Expect(err).To(Equal(filesystem.ErrorFileNotFound))
+				})
+			})
+		})
+	})
+})
@@ -0,0 +1,88 @@
+package s3
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+const (
+	DEFAULT_PART_SIZE = 5 * 1024 * 1024 //5MB, minimum part size allowed by s3
+)
+
+type ClientConfig struct {
+	Endpoint        string
+	Region          string
+	Bucket          string
+	AccessKeyId     string
+	SecretAccessKey string
+	UsePathStyle    bool
+	PartSize        int64
+}
+
+type client struct {
+	core     *minio.Core
+	bucket   string
+	partSize int64
+}
+
+func (c *client) objectKey(path string) string {
+	return strings.TrimPrefix(path, "/")
+}
+
+type ClientParam struct {
+	Config *ClientConfig
+	// @note: default transport is used when it's not specified
+	Transport http.RoundTripper
+}
+
+// @note: requests are signed and sent by the minio client,
+// the bucket location is looked up when the region is not specified
+func NewClient(p ClientParam) (*client, error) {
+	if p.Config == nil {
+		return nil, fmt.Errorf("invalid config")
+	}
+	if strings.TrimSpace(p.Config.Bucket) == "" {
+		return nil, fmt.Errorf("invalid bucket")
+	}
+
+	endpoint, err := url.Parse(p.Config.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if endpoint.Scheme == "" || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid endpoint")
+	}
+
+	partSize := p.Config.PartSize
+	if partSize <= 0 {
+		partSize = DEFAULT_PART_SIZE
+	}
+
+	bucketLookup := minio.BucketLookupDNS
+	if p.Config.UsePathStyle {
+		bucketLookup = minio.BucketLookupPath
+	}
+
+	core, err := minio.NewCore(endpoint.Host, &minio.Options{
+		Creds:        credentials.NewStaticV4(p.Config.AccessKeyId, p.Config.SecretAccessKey, ""),
+		Secure:       endpoint.Scheme == "https",
+		Region:       p.Config.Region,
+		BucketLookup: bucketLookup,
+		Transport:    p.Transport,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	c := &client{
+		core:     core,
+		bucket:   p.Config.Bucket,
+		partSize: partSize,
+	}
+	return c, nil
+}
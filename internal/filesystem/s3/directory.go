@@ -0,0 +1,65 @@
+package s3
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-seidon/hippo/internal/filesystem"
+	"github.com/minio/minio-go/v7"
+)
+
+// @note: s3 has no real directory, the directory exists when there is an object under its prefix
+// and it's created by saving an empty marker object (same as the s3 console folder)
+type directoryManager struct {
+	client *client
+}
+
+func (dm *directoryManager) prefix(path string) string {
+	return strings.TrimSuffix(dm.client.objectKey(path), "/") + "/"
+}
+
+func (dm *directoryManager) IsDirectoryExists(ctx context.Context, p filesystem.IsDirectoryExistsParam) (bool, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	objects := dm.client.core.Client.ListObjects(ctx, dm.client.bucket, minio.ListObjectsOptions{
+		Prefix:  dm.prefix(p.Path),
+		MaxKeys: 1,
+	})
+	for object := range objects {
+		if object.Err != nil {
+			return false, object.Err
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+func (dm *directoryManager) CreateDir(ctx context.Context, p filesystem.CreateDirParam) (*filesystem.CreateDirResult, error) {
+	_, err := dm.client.core.PutObject(ctx, dm.client.bucket, dm.prefix(p.Path), strings.NewReader(""), 0, "", "", minio.PutObjectOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	res := &filesystem.CreateDirResult{
+		CreatedAt: time.Now(),
+	}
+	return res, nil
+}
+
+type DirectoryManagerParam struct {
+	Client *client
+}
+
+func NewDirectoryManager(p DirectoryManagerParam) (*directoryManager, error) {
+	if p.Client == nil {
+		return nil, fmt.Errorf("invalid client")
+	}
+
+	s := &directoryManager{
+		client: p.Client,
+	}
+	return s, nil
+}
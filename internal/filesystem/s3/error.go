@@ -0,0 +1,18 @@
+package s3
+
+import (
+	"net/http"
+
+	"github.com/minio/minio-go/v7"
+)
+
+func IsNotFound(err error) bool {
+	res := minio.ToErrorResponse(err)
+	return res.StatusCode == http.StatusNotFound || res.Code == "NoSuchKey"
+}
+
+// @note: conflict is returned when the conditional write is raced by another one
+func IsPreconditionFailed(err error) bool {
+	res := minio.ToErrorResponse(err)
+	return res.StatusCode == http.StatusPreconditionFailed || res.StatusCode == http.StatusConflict
+}
@@ -0,0 +1,215 @@
+package s3
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/go-seidon/hippo/internal/filesystem"
+	"github.com/minio/minio-go/v7"
+)
+
+type fileManager struct {
+	client *client
+}
+
+func (fm *fileManager) IsFileExists(ctx context.Context, p filesystem.IsFileExistsParam) (bool, error) {
+	_, err := fm.client.core.StatObject(ctx, fm.client.bucket, fm.client.objectKey(p.Path), minio.StatObjectOptions{})
+	if err != nil {
+		if IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// @note: object is read from the offset until the end when length is not specified
+func (fm *fileManager) OpenFile(ctx context.Context, p filesystem.OpenFileParam) (*filesystem.OpenFileResult, error) {
+	opts := minio.GetObjectOptions{}
+	if p.Length > 0 {
+		opts.SetRange(p.Offset, p.Offset+p.Length-1)
+	} else if p.Offset > 0 {
+		opts.SetRange(p.Offset, 0)
+	}
+
+	obj, _, _, err := fm.client.core.GetObject(ctx, fm.client.bucket, fm.client.objectKey(p.Path), opts)
+	if err != nil {
+		if IsNotFound(err) {
+			return nil, filesystem.ErrorFileNotFound
+		}
+		return nil, err
+	}
+
+	res := &filesystem.OpenFileResult{
+		File: obj,
+	}
+	return res, nil
+}
+
+// @note: save object/overwrite if exists
+// data is streamed from reader in parts, object smaller than a part is sent using single request
+// otherwise multipart upload is used and aborted on failure
+func (fm *fileManager) SaveFile(ctx context.Context, p filesystem.SaveFileParam) (*filesystem.SaveFileResult, error) {
+	if p.Reader == nil {
+		return nil, filesystem.ErrorInvalidReader
+	}
+
+	// @note: exclusive is checked by the provider using the conditional write (`If-None-Match: *`)
+	// on the single request or the multipart completion, so the concurrent save can not overwrite it
+	opts := minio.PutObjectOptions{}
+	if p.Exclusive {
+		opts.SetMatchETagExcept("*")
+	}
+
+	key := fm.client.objectKey(p.Name)
+	buff := make([]byte, fm.client.partSize)
+	n, err := io.ReadFull(p.Reader, buff)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		_, err = fm.client.core.PutObject(ctx, fm.client.bucket, key, bytes.NewReader(buff[:n]), int64(n), "", "", opts)
+		if err != nil {
+			if p.Exclusive && IsPreconditionFailed(err) {
+				return nil, filesystem.ErrorFileExists
+			}
+			return nil, err
+		}
+
+		res := &filesystem.SaveFileResult{
+			Size:       int64(n),
+			StoredSize: int64(n),
+			SavedAt:    time.Now(),
+		}
+		return res, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	uploadId, err := fm.client.core.NewMultipartUpload(ctx, fm.client.bucket, key, minio.PutObjectOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	size, err := fm.uploadParts(ctx, key, uploadId, p.Reader, buff, opts)
+	if err != nil {
+		fm.client.core.AbortMultipartUpload(context.Background(), fm.client.bucket, key, uploadId)
+		if p.Exclusive && IsPreconditionFailed(err) {
+			return nil, filesystem.ErrorFileExists
+		}
+		return nil, err
+	}
+
+	res := &filesystem.SaveFileResult{
+		Size:       size,
+		StoredSize: size,
+		SavedAt:    time.Now(),
+	}
+	return res, nil
+}
+
+// @note: buff is expected to be filled with the first part
+func (fm *fileManager) uploadParts(ctx context.Context, key, uploadId string, r io.Reader, buff []byte, opts minio.PutObjectOptions) (int64, error) {
+	size := int64(0)
+	parts := []minio.CompletePart{}
+	n := len(buff)
+	for n > 0 {
+		partNumber := len(parts) + 1
+		part, err := fm.client.core.PutObjectPart(ctx, fm.client.bucket, key, uploadId, partNumber, bytes.NewReader(buff[:n]), int64(n), minio.PutObjectPartOptions{})
+		if err != nil {
+			return 0, err
+		}
+		if part.ETag == "" {
+			return 0, fmt.Errorf("invalid part etag")
+		}
+		size += int64(n)
+		parts = append(parts, minio.CompletePart{
+			PartNumber: partNumber,
+			ETag:       part.ETag,
+		})
+
+		n, err = io.ReadFull(r, buff)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return 0, err
+		}
+	}
+
+	_, err := fm.client.core.CompleteMultipartUpload(ctx, fm.client.bucket, key, uploadId, parts, opts)
+	if err != nil {
+		return 0, err
+	}
+	return size, nil
+}
+
+func (fm *fileManager) RemoveFile(ctx context.Context, p filesystem.RemoveFileParam) (*filesystem.RemoveFileResult, error) {
+	exists, err := fm.IsFileExists(ctx, filesystem.IsFileExistsParam{
+		Path: p.Path,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, filesystem.ErrorFileNotFound
+	}
+
+	err = fm.client.core.RemoveObject(ctx, fm.client.bucket, fm.client.objectKey(p.Path), minio.RemoveObjectOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	res := &filesystem.RemoveFileResult{
+		RemovedAt: time.Now(),
+	}
+	return res, nil
+}
+
+// @note: object is copied into the destination then removed from the source,
+// server side copy of object larger than 5GB can not be done using single request
+func (fm *fileManager) MoveFile(ctx context.Context, p filesystem.MoveFileParam) (*filesystem.MoveFileResult, error) {
+	exists, err := fm.IsFileExists(ctx, filesystem.IsFileExistsParam{
+		Path: p.SourcePath,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, filesystem.ErrorFileNotFound
+	}
+
+	sourceKey := fm.client.objectKey(p.SourcePath)
+	_, err = fm.client.core.CopyObject(
+		ctx,
+		fm.client.bucket, sourceKey,
+		fm.client.bucket, fm.client.objectKey(p.DestinationPath),
+		nil, minio.CopySrcOptions{}, minio.PutObjectOptions{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	err = fm.client.core.RemoveObject(ctx, fm.client.bucket, sourceKey, minio.RemoveObjectOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	res := &filesystem.MoveFileResult{
+		MovedAt: time.Now(),
+	}
+	return res, nil
+}
+
+type FileManagerParam struct {
+	Client *client
+}
+
+func NewFileManager(p FileManagerParam) (*fileManager, error) {
+	if p.Client == nil {
+		return nil, fmt.Errorf("invalid client")
+	}
+
+	s := &fileManager{
+		client: p.Client,
+	}
+	return s, nil
+}
@@ -0,0 +1,687 @@
+package s3_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/go-seidon/hippo/internal/filesystem"
+	"github.com/go-seidon/hippo/internal/filesystem/s3"
+	"github.com/minio/minio-go/v7"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestS3(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "S3 Package")
+}
+
+type roundTripFunc func(r *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+var _ = Describe("File Manager", func() {
+	Context("NewClient function", Label("unit"), func() {
+		var (
+			p s3.ClientParam
+		)
+
+		BeforeEach(func() {
+			p = s3.ClientParam{
+				Config: &s3.ClientConfig{
+					Endpoint: "http://localhost:9000",
+					Region:   "us-east-1",
+					Bucket:   "hippo",
+				},
+			}
+		})
+
+		When("config is not specified", func() {
+			It("should return error", func() {
+				p.Config = nil
+				res, err := s3.NewClient(p)
+
+				Expect(res).To(BeNil())
+				Expect(err.Error()).To(Equal("invalid config"))
+			})
+		})
+
+		When("bucket is not specified", func() {
+			It("should return error", func() {
+				p.Config.Bucket = ""
+				res, err := s3.NewClient(p)
+
+				Expect(res).To(BeNil())
+				Expect(err.Error()).To(Equal("invalid bucket"))
+			})
+		})
+
+		When("endpoint is invalid", func() {
+			It("should return error", func() {
+				p.Config.Endpoint = "localhost"
+				res, err := s3.NewClient(p)
+
+				Expect(res).To(BeNil())
+				Expect(err.Error()).To(Equal("invalid endpoint"))
+			})
+		})
+
+		When("success create client", func() {
+			It("should return result", func() {
+				res, err := s3.NewClient(p)
+
+				Expect(res).ToNot(BeNil())
+				Expect(err).To(BeNil())
+			})
+		})
+	})
+
+	Context("NewFileManager function", Label("unit"), func() {
+		When("client is not specified", func() {
+			It("should return error", func() {
+				res, err := s3.NewFileManager(s3.FileManagerParam{})
+
+				Expect(res).To(BeNil())
+				Expect(err.Error()).To(Equal("invalid client"))
+			})
+		})
+
+		When("success create file manager", func() {
+			It("should return result", func() {
+				client, _ := s3.NewClient(s3.ClientParam{
+					Config: &s3.ClientConfig{
+						Endpoint: "http://localhost:9000",
+						Bucket:   "hippo",
+					},
+				})
+				res, err := s3.NewFileManager(s3.FileManagerParam{
+					Client: client,
+				})
+
+				Expect(res).ToNot(BeNil())
+				Expect(err).To(BeNil())
+			})
+		})
+	})
+
+	Context("File operation", Label("unit"), func() {
+		var (
+			ctx    context.Context
+			server *fakeServer
+			fm     filesystem.FileManager
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			server = newFakeServer("hippo")
+			client, err := s3.NewClient(s3.ClientParam{
+				Config: &s3.ClientConfig{
+					Endpoint:        server.URL,
+					Region:          "us-east-1",
+					Bucket:          "hippo",
+					AccessKeyId:     "access-key",
+					SecretAccessKey: "secret-key",
+					UsePathStyle:    true,
+					PartSize:        4,
+				},
+			})
+			if err != nil {
+				Fail("failed create client: " + err.Error())
+			}
+			fm, _ = s3.NewFileManager(s3.FileManagerParam{
+				Client: client,
+			})
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		When("checking missing file", func() {
+			It("should return false", func() {
+				res, err := fm.IsFileExists(ctx, filesystem.IsFileExistsParam{
+					Path: "storage/2022/file.jpg",
+				})
+
+				Expect(res).To(BeFalse())
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("checking existing file", func() {
+			It("should return true", func() {
+				server.objects["storage/2022/file.jpg"] = []byte("content")
+				res, err := fm.IsFileExists(ctx, filesystem.IsFileExistsParam{
+					Path: "storage/2022/file.jpg",
+				})
+
+				Expect(res).To(BeTrue())
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("failed check file", func() {
+			It("should return error", func() {
+				server.failOn = http.MethodHead
+				res, err := fm.IsFileExists(ctx, filesystem.IsFileExistsParam{
+					Path: "storage/2022/file.jpg",
+				})
+
+				Expect(res).To(BeFalse())
+				Expect(minio.ToErrorResponse(err).StatusCode).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		When("opening missing file", func() {
+			It("should return error", func() {
+				res, err := fm.OpenFile(ctx, filesystem.OpenFileParam{
+					Path: "storage/2022/file.jpg",
+				})
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(filesystem.ErrorFileNotFound))
+			})
+		})
+
+		When("failed open file", func() {
+			It("should return error", func() {
+				server.failOn = http.MethodGet
+				res, err := fm.OpenFile(ctx, filesystem.OpenFileParam{
+					Path: "storage/2022/file.jpg",
+				})
+
+				Expect(res).To(BeNil())
+				Expect(minio.ToErrorResponse(err).StatusCode).To(Equal(http.StatusBadRequest))
+				Expect(minio.ToErrorResponse(err).Code).To(Equal("InvalidRequest"))
+			})
+		})
+
+		When("success open file", func() {
+			It("should return result", func() {
+				server.objects["storage/2022/file.jpg"] = []byte("content")
+				res, err := fm.OpenFile(ctx, filesystem.OpenFileParam{
+					Path: "/storage/2022/file.jpg",
+				})
+				data, _ := io.ReadAll(res.File)
+				res.File.Close()
+
+				Expect(err).To(BeNil())
+				Expect(data).To(Equal([]byte("content")))
+			})
+		})
+
+		When("range is specified", func() {
+			It("should return ranged content", func() {
+				server.objects["storage/2022/file.jpg"] = []byte("content")
+				res, err := fm.OpenFile(ctx, filesystem.OpenFileParam{
+					Path:   "storage/2022/file.jpg",
+					Offset: 2,
+					Length: 3,
+				})
+				data, _ := io.ReadAll(res.File)
+				res.File.Close()
+
+				Expect(err).To(BeNil())
+				Expect(data).To(Equal([]byte("nte")))
+			})
+		})
+
+		When("reader is not specified", func() {
+			It("should return error", func() {
+				res, err := fm.SaveFile(ctx, filesystem.SaveFileParam{
+					Name: "storage/2022/file.jpg",
+				})
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(filesystem.ErrorInvalidReader))
+			})
+		})
+
+		When("failed read from reader", func() {
+			It("should return error", func() {
+				res, err := fm.SaveFile(ctx, filesystem.SaveFileParam{
+					Name:   "storage/2022/file.jpg",
+					Reader: iotest.ErrReader(io.ErrClosedPipe),
+				})
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(io.ErrClosedPipe))
+				Expect(server.requests).To(BeEmpty())
+			})
+		})
+
+		When("file is smaller than a part", func() {
+			It("should save using single request", func() {
+				res, err := fm.SaveFile(ctx, filesystem.SaveFileParam{
+					Name:   "storage/2022/file.jpg",
+					Reader: strings.NewReader("abc"),
+				})
+
+				Expect(err).To(BeNil())
+				Expect(res.Size).To(Equal(int64(3)))
+				Expect(server.objects["storage/2022/file.jpg"]).To(Equal([]byte("abc")))
+				Expect(server.requests).To(Equal([]string{http.MethodPut}))
+			})
+		})
+
+		When("file already exists and exclusive is set", func() {
+			It("should return error", func() {
+				server.objects["storage/2022/file.jpg"] = []byte("old")
+				res, err := fm.SaveFile(ctx, filesystem.SaveFileParam{
+					Name:      "storage/2022/file.jpg",
+					Reader:    strings.NewReader("abc"),
+					Exclusive: true,
+				})
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(filesystem.ErrorFileExists))
+				Expect(server.objects["storage/2022/file.jpg"]).To(Equal([]byte("old")))
+				Expect(server.requests).To(Equal([]string{http.MethodPut}))
+			})
+		})
+
+		When("large file already exists and exclusive is set", func() {
+			It("should abort multipart upload", func() {
+				server.objects["storage/2022/file.jpg"] = []byte("old")
+				res, err := fm.SaveFile(ctx, filesystem.SaveFileParam{
+					Name:      "storage/2022/file.jpg",
+					Reader:    strings.NewReader("0123456789"),
+					Exclusive: true,
+				})
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(filesystem.ErrorFileExists))
+				Expect(server.objects["storage/2022/file.jpg"]).To(Equal([]byte("old")))
+				Expect(server.uploads).To(BeEmpty())
+				Expect(server.requests).To(Equal([]string{
+					"CreateMultipartUpload",
+					"UploadPart",
+					"UploadPart",
+					"UploadPart",
+					"CompleteMultipartUpload",
+					"AbortMultipartUpload",
+				}))
+			})
+		})
+
+		When("file does not exist and exclusive is set", func() {
+			It("should save the file", func() {
+				res, err := fm.SaveFile(ctx, filesystem.SaveFileParam{
+					Name:      "storage/2022/file.jpg",
+					Reader:    strings.NewReader("abc"),
+					Exclusive: true,
+				})
+
+				Expect(err).To(BeNil())
+				Expect(res.Size).To(Equal(int64(3)))
+				Expect(server.objects["storage/2022/file.jpg"]).To(Equal([]byte("abc")))
+				Expect(server.requests).To(Equal([]string{http.MethodPut}))
+			})
+		})
+
+		When("failed save small file", func() {
+			It("should return error", func() {
+				server.failOn = http.MethodPut
+				res, err := fm.SaveFile(ctx, filesystem.SaveFileParam{
+					Name:   "storage/2022/file.jpg",
+					Reader: strings.NewReader("abc"),
+				})
+
+				Expect(res).To(BeNil())
+				Expect(err).ToNot(BeNil())
+			})
+		})
+
+		When("file is larger than a part", func() {
+			It("should save using multipart upload", func() {
+				res, err := fm.SaveFile(ctx, filesystem.SaveFileParam{
+					Name:   "storage/2022/file.jpg",
+					Reader: strings.NewReader("0123456789"),
+				})
+
+				Expect(err).To(BeNil())
+				Expect(res.Size).To(Equal(int64(10)))
+				Expect(server.objects["storage/2022/file.jpg"]).To(Equal([]byte("0123456789")))
+				Expect(server.requests).To(Equal([]string{
+					"CreateMultipartUpload",
+					"UploadPart",
+					"UploadPart",
+					"UploadPart",
+					"CompleteMultipartUpload",
+				}))
+			})
+		})
+
+		When("file size is multiple of part size", func() {
+			It("should not upload empty part", func() {
+				res, err := fm.SaveFile(ctx, filesystem.SaveFileParam{
+					Name:   "storage/2022/file.jpg",
+					Reader: strings.NewReader("01234567"),
+				})
+
+				Expect(err).To(BeNil())
+				Expect(res.Size).To(Equal(int64(8)))
+				Expect(server.objects["storage/2022/file.jpg"]).To(Equal([]byte("01234567")))
+				Expect(server.requests).To(HaveLen(4))
+			})
+		})
+
+		When("failed create multipart upload", func() {
+			It("should return error", func() {
+				server.failOn = "CreateMultipartUpload"
+				res, err := fm.SaveFile(ctx, filesystem.SaveFileParam{
+					Name:   "storage/2022/file.jpg",
+					Reader: strings.NewReader("0123456789"),
+				})
+
+				Expect(res).To(BeNil())
+				Expect(err).ToNot(BeNil())
+			})
+		})
+
+		When("failed upload part", func() {
+			It("should abort multipart upload", func() {
+				server.failOn = "UploadPart"
+				res, err := fm.SaveFile(ctx, filesystem.SaveFileParam{
+					Name:   "storage/2022/file.jpg",
+					Reader: strings.NewReader("0123456789"),
+				})
+
+				Expect(res).To(BeNil())
+				Expect(err).ToNot(BeNil())
+				Expect(server.requests).To(Equal([]string{
+					"CreateMultipartUpload",
+					"UploadPart",
+					"AbortMultipartUpload",
+				}))
+				Expect(server.uploads).To(BeEmpty())
+				Expect(server.objects).To(BeEmpty())
+			})
+		})
+
+		When("failed read next part", func() {
+			It("should abort multipart upload", func() {
+				reader := io.MultiReader(
+					strings.NewReader("0123"),
+					iotest.ErrReader(io.ErrClosedPipe),
+				)
+				res, err := fm.SaveFile(ctx, filesystem.SaveFileParam{
+					Name:   "storage/2022/file.jpg",
+					Reader: reader,
+				})
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(io.ErrClosedPipe))
+				Expect(server.uploads).To(BeEmpty())
+				Expect(server.objects).To(BeEmpty())
+			})
+		})
+
+		When("failed complete multipart upload", func() {
+			It("should abort multipart upload", func() {
+				server.failOn = "CompleteMultipartUpload"
+				res, err := fm.SaveFile(ctx, filesystem.SaveFileParam{
+					Name:   "storage/2022/file.jpg",
+					Reader: strings.NewReader("0123456789"),
+				})
+
+				Expect(res).To(BeNil())
+				Expect(err).ToNot(BeNil())
+				Expect(server.uploads).To(BeEmpty())
+			})
+		})
+
+		When("removing missing file", func() {
+			It("should return error", func() {
+				res, err := fm.RemoveFile(ctx, filesystem.RemoveFileParam{
+					Path: "storage/2022/file.jpg",
+				})
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(filesystem.ErrorFileNotFound))
+			})
+		})
+
+		When("failed remove file", func() {
+			It("should return error", func() {
+				server.objects["storage/2022/file.jpg"] = []byte("content")
+				server.failOn = http.MethodDelete
+				res, err := fm.RemoveFile(ctx, filesystem.RemoveFileParam{
+					Path: "storage/2022/file.jpg",
+				})
+
+				Expect(res).To(BeNil())
+				Expect(err).ToNot(BeNil())
+			})
+		})
+
+		When("success remove file", func() {
+			It("should return result", func() {
+				server.objects["storage/2022/file.jpg"] = []byte("content")
+				res, err := fm.RemoveFile(ctx, filesystem.RemoveFileParam{
+					Path: "storage/2022/file.jpg",
+				})
+
+				Expect(res).ToNot(BeNil())
+				Expect(err).To(BeNil())
+				Expect(server.objects).To(BeEmpty())
+			})
+		})
+
+		When("moving missing file", func() {
+			It("should return error", func() {
+				res, err := fm.MoveFile(ctx, filesystem.MoveFileParam{
+					SourcePath:      "storage/2022/file.jpg",
+					DestinationPath: "trash/file.jpg",
+				})
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(filesystem.ErrorFileNotFound))
+			})
+		})
+
+		When("failed copy file", func() {
+			It("should return error", func() {
+				server.objects["storage/2022/file.jpg"] = []byte("content")
+				server.failOn = "CopyObject"
+				res, err := fm.MoveFile(ctx, filesystem.MoveFileParam{
+					SourcePath:      "storage/2022/file.jpg",
+					DestinationPath: "trash/file.jpg",
+				})
+
+				Expect(res).To(BeNil())
+				Expect(err).ToNot(BeNil())
+				Expect(server.objects).To(HaveKey("storage/2022/file.jpg"))
+			})
+		})
+
+		When("failed remove source file", func() {
+			It("should return error", func() {
+				server.objects["storage/2022/file.jpg"] = []byte("content")
+				server.failOn = http.MethodDelete
+				res, err := fm.MoveFile(ctx, filesystem.MoveFileParam{
+					SourcePath:      "storage/2022/file.jpg",
+					DestinationPath: "trash/file.jpg",
+				})
+
+				Expect(res).To(BeNil())
+				Expect(err).ToNot(BeNil())
+			})
+		})
+
+		When("success move file", func() {
+			It("should return result", func() {
+				server.objects["storage/2022/file.jpg"] = []byte("content")
+				res, err := fm.MoveFile(ctx, filesystem.MoveFileParam{
+					SourcePath:      "storage/2022/file.jpg",
+					DestinationPath: "trash/file.jpg",
+				})
+
+				Expect(res).ToNot(BeNil())
+				Expect(err).To(BeNil())
+				Expect(server.objects).To(Equal(map[string][]byte{
+					"trash/file.jpg": []byte("content"),
+				}))
+				Expect(server.requests).To(Equal([]string{http.MethodHead, "CopyObject", http.MethodDelete}))
+			})
+		})
+	})
+
+	Context("Virtual hosted style", Label("unit"), func() {
+		When("path style is disabled", func() {
+			It("should send request to bucket host", func() {
+				var url string
+				client, _ := s3.NewClient(s3.ClientParam{
+					Config: &s3.ClientConfig{
+						Endpoint: "https://storage.example.com",
+						Region:   "us-east-1",
+						Bucket:   "hippo",
+					},
+					Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+						url = r.URL.String()
+						return &http.Response{
+							StatusCode: http.StatusOK,
+							Body:       io.NopCloser(strings.NewReader("")),
+							Header: http.Header{
+								"Content-Length": []string{"0"},
+								"Last-Modified":  []string{time.Now().UTC().Format(http.TimeFormat)},
+								"Etag":           []string{"\"etag\""},
+							},
+						}, nil
+					}),
+				})
+				fm, _ := s3.NewFileManager(s3.FileManagerParam{
+					Client: client,
+				})
+				res, err := fm.IsFileExists(context.Background(), filesystem.IsFileExistsParam{
+					Path: "storage/2022/file name.jpg",
+				})
+
+				Expect(res).To(BeTrue())
+				Expect(err).To(BeNil())
+				Expect(url).To(Equal("https://hippo.storage.example.com/storage/2022/file%20name.jpg"))
+			})
+		})
+	})
+})
+
+var _ = Describe("Directory Manager", func() {
+	Context("NewDirectoryManager function", Label("unit"), func() {
+		When("client is not specified", func() {
+			It("should return error", func() {
+				res, err := s3.NewDirectoryManager(s3.DirectoryManagerParam{})
+
+				Expect(res).To(BeNil())
+				Expect(err.Error()).To(Equal("invalid client"))
+			})
+		})
+	})
+
+	Context("Directory operation", Label("unit"), func() {
+		var (
+			ctx    context.Context
+			server *fakeServer
+			dm     filesystem.DirectoryManager
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			server = newFakeServer("hippo")
+			client, err := s3.NewClient(s3.ClientParam{
+				Config: &s3.ClientConfig{
+					Endpoint:        server.URL,
+					Region:          "us-east-1",
+					Bucket:          "hippo",
+					AccessKeyId:     "access-key",
+					SecretAccessKey: "secret-key",
+					UsePathStyle:    true,
+				},
+			})
+			if err != nil {
+				Fail("failed create client: " + err.Error())
+			}
+			dm, _ = s3.NewDirectoryManager(s3.DirectoryManagerParam{
+				Client: client,
+			})
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		When("there is no object under the directory", func() {
+			It("should return false", func() {
+				server.objects["storage/2022-backup/file.jpg"] = []byte("content")
+				res, err := dm.IsDirectoryExists(ctx, filesystem.IsDirectoryExistsParam{
+					Path: "storage/2022",
+				})
+
+				Expect(res).To(BeFalse())
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("there is object under the directory", func() {
+			It("should return true", func() {
+				server.objects["storage/2022/file.jpg"] = []byte("content")
+				res, err := dm.IsDirectoryExists(ctx, filesystem.IsDirectoryExistsParam{
+					Path: "storage/2022",
+				})
+
+				Expect(res).To(BeTrue())
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("failed list directory", func() {
+			It("should return error", func() {
+				server.failOn = "ListObjectsV2"
+				res, err := dm.IsDirectoryExists(ctx, filesystem.IsDirectoryExistsParam{
+					Path: "storage/2022",
+				})
+
+				Expect(res).To(BeFalse())
+				Expect(minio.ToErrorResponse(err).Code).To(Equal("InvalidRequest"))
+			})
+		})
+
+		When("failed create directory", func() {
+			It("should return error", func() {
+				server.failOn = http.MethodPut
+				res, err := dm.CreateDir(ctx, filesystem.CreateDirParam{
+					Path: "storage/2022",
+				})
+
+				Expect(res).To(BeNil())
+				Expect(minio.ToErrorResponse(err).Code).To(Equal("InvalidRequest"))
+			})
+		})
+
+		When("success create directory", func() {
+			It("should save the marker object", func() {
+				res, err := dm.CreateDir(ctx, filesystem.CreateDirParam{
+					Path: "/storage/2022",
+				})
+
+				Expect(res).ToNot(BeNil())
+				Expect(err).To(BeNil())
+				Expect(server.objects).To(Equal(map[string][]byte{
+					"storage/2022/": {},
+				}))
+
+				exists, err := dm.IsDirectoryExists(ctx, filesystem.IsDirectoryExistsParam{
+					Path: "storage/2022",
+				})
+				Expect(exists).To(BeTrue())
+				Expect(err).To(BeNil())
+			})
+		})
+	})
+})
@@ -0,0 +1,229 @@
+package s3_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// fakeServer is an in-process s3 compatible server (path-style)
+// supporting the object, listing and multipart operations used by the file and directory manager
+type fakeServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	bucket   string
+	objects  map[string][]byte
+	uploads  map[string]map[int][]byte
+	requests []string
+	counter  int
+	failOn   string
+}
+
+func newFakeServer(bucket string) *fakeServer {
+	s := &fakeServer{
+		bucket:  bucket,
+		objects: map[string][]byte{},
+		uploads: map[string]map[int][]byte{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+func (s *fakeServer) writeError(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	fmt.Fprintf(w, "<Error><Code>%s</Code><Message>%s</Message></Error>", code, code)
+}
+
+// readBody decodes the aws-chunked body sent using the streaming signature
+func (s *fakeServer) readBody(r *http.Request) []byte {
+	if !strings.HasPrefix(r.Header.Get("X-Amz-Content-Sha256"), "STREAMING-") {
+		data, _ := io.ReadAll(r.Body)
+		return data
+	}
+
+	data := []byte{}
+	reader := bufio.NewReader(r.Body)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return data
+		}
+		size, err := strconv.ParseInt(strings.SplitN(strings.TrimSpace(line), ";", 2)[0], 16, 64)
+		if err != nil || size == 0 {
+			return data
+		}
+		chunk := make([]byte, size)
+		io.ReadFull(reader, chunk)
+		data = append(data, chunk...)
+		reader.Discard(2)
+	}
+}
+
+func (s *fakeServer) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	query := r.URL.Query()
+	op := r.Method
+	if _, ok := query["uploads"]; ok {
+		op = "CreateMultipartUpload"
+	} else if query.Get("partNumber") != "" {
+		op = "UploadPart"
+	} else if query.Get("uploadId") != "" && r.Method == http.MethodPost {
+		op = "CompleteMultipartUpload"
+	} else if query.Get("uploadId") != "" && r.Method == http.MethodDelete {
+		op = "AbortMultipartUpload"
+	} else if r.Header.Get("X-Amz-Copy-Source") != "" && r.Method == http.MethodPut {
+		op = "CopyObject"
+	} else if query.Get("list-type") == "2" {
+		op = "ListObjectsV2"
+	}
+	s.requests = append(s.requests, op)
+
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 ") ||
+		r.Header.Get("X-Amz-Content-Sha256") == "" {
+		s.writeError(w, http.StatusForbidden, "AccessDenied")
+		return
+	}
+	if s.failOn == op {
+		s.writeError(w, http.StatusBadRequest, "InvalidRequest")
+		return
+	}
+
+	prefix := "/" + s.bucket + "/"
+	if !strings.HasPrefix(r.URL.Path+"/", prefix) {
+		s.writeError(w, http.StatusNotFound, "NoSuchBucket")
+		return
+	}
+	key := strings.TrimPrefix(r.URL.Path, prefix)
+	_, exists := s.objects[key]
+	exclusive := r.Header.Get("If-None-Match") == "*"
+
+	switch op {
+	case http.MethodHead, http.MethodGet:
+		data, ok := s.objects[key]
+		if !ok {
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			s.writeError(w, http.StatusNotFound, "NoSuchKey")
+			return
+		}
+		status := http.StatusOK
+		if r.Method == http.MethodGet && r.Header.Get("Range") != "" {
+			var start, end int
+			n, _ := fmt.Sscanf(r.Header.Get("Range"), "bytes=%d-%d", &start, &end)
+			if n < 2 || end >= len(data) {
+				end = len(data) - 1
+			}
+			data = data[start : end+1]
+			status = http.StatusPartialContent
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+		w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+		w.Header().Set("ETag", "\"etag\"")
+		w.WriteHeader(status)
+		if r.Method == http.MethodGet {
+			w.Write(data)
+		}
+	case http.MethodPut:
+		if exclusive && exists {
+			s.writeError(w, http.StatusPreconditionFailed, "PreconditionFailed")
+			return
+		}
+		s.objects[key] = s.readBody(r)
+		w.Header().Set("ETag", "\"etag\"")
+		w.WriteHeader(http.StatusOK)
+	case http.MethodDelete:
+		delete(s.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	case "CopyObject":
+		source, _ := url.PathUnescape(r.Header.Get("X-Amz-Copy-Source"))
+		data, ok := s.objects[strings.TrimPrefix("/"+strings.TrimPrefix(source, "/"), prefix)]
+		if !ok {
+			s.writeError(w, http.StatusNotFound, "NoSuchKey")
+			return
+		}
+		s.objects[key] = data
+		fmt.Fprintf(w, "<CopyObjectResult><ETag>\"etag\"</ETag></CopyObjectResult>")
+	case "ListObjectsV2":
+		keys := []string{}
+		for objectKey := range s.objects {
+			if strings.HasPrefix(objectKey, query.Get("prefix")) {
+				keys = append(keys, objectKey)
+			}
+		}
+		sort.Strings(keys)
+		maxKeys, _ := strconv.Atoi(query.Get("max-keys"))
+		if maxKeys > 0 && len(keys) > maxKeys {
+			keys = keys[:maxKeys]
+		}
+		contents := bytes.NewBufferString("")
+		for _, objectKey := range keys {
+			fmt.Fprintf(contents, "<Contents><Key>%s</Key><Size>%d</Size></Contents>", objectKey, len(s.objects[objectKey]))
+		}
+		fmt.Fprintf(w, "<ListBucketResult><Name>%s</Name><Prefix>%s</Prefix><KeyCount>%d</KeyCount><MaxKeys>%d</MaxKeys><IsTruncated>false</IsTruncated>%s</ListBucketResult>",
+			s.bucket, query.Get("prefix"), len(keys), maxKeys, contents.String())
+	case "CreateMultipartUpload":
+		s.counter++
+		uploadId := fmt.Sprintf("upload-%d", s.counter)
+		s.uploads[uploadId] = map[int][]byte{}
+		fmt.Fprintf(w, "<InitiateMultipartUploadResult><Bucket>%s</Bucket><Key>%s</Key><UploadId>%s</UploadId></InitiateMultipartUploadResult>", s.bucket, key, uploadId)
+	case "UploadPart":
+		parts, ok := s.uploads[query.Get("uploadId")]
+		if !ok {
+			s.writeError(w, http.StatusNotFound, "NoSuchUpload")
+			return
+		}
+		partNumber, _ := strconv.Atoi(query.Get("partNumber"))
+		parts[partNumber] = s.readBody(r)
+		w.Header().Set("ETag", fmt.Sprintf("\"etag-%d\"", partNumber))
+		w.WriteHeader(http.StatusOK)
+	case "CompleteMultipartUpload":
+		parts, ok := s.uploads[query.Get("uploadId")]
+		if !ok {
+			s.writeError(w, http.StatusNotFound, "NoSuchUpload")
+			return
+		}
+		if exclusive && exists {
+			s.writeError(w, http.StatusPreconditionFailed, "PreconditionFailed")
+			return
+		}
+		body := struct {
+			Parts []struct {
+				PartNumber int
+				ETag       string
+			} `xml:"Part"`
+		}{}
+		xml.NewDecoder(r.Body).Decode(&body)
+		numbers := []int{}
+		for _, part := range body.Parts {
+			numbers = append(numbers, part.PartNumber)
+		}
+		sort.Ints(numbers)
+		data := []byte{}
+		for _, number := range numbers {
+			data = append(data, parts[number]...)
+		}
+		s.objects[key] = data
+		delete(s.uploads, query.Get("uploadId"))
+		fmt.Fprintf(w, "<CompleteMultipartUploadResult><Bucket>%s</Bucket><Key>%s</Key><ETag>\"etag\"</ETag></CompleteMultipartUploadResult>", s.bucket, key)
+	case "AbortMultipartUpload":
+		delete(s.uploads, query.Get("uploadId"))
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		s.writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed")
+	}
+}
@@ -3,15 +3,16 @@ package grpcapp
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-seidon/hippo/api/grpcapp"
 	"github.com/go-seidon/hippo/internal/app"
 	"github.com/go-seidon/hippo/internal/auth"
-	"github.com/go-seidon/hippo/internal/file"
 	"github.com/go-seidon/hippo/internal/filesystem"
 	"github.com/go-seidon/hippo/internal/grpcauth"
 	"github.com/go-seidon/hippo/internal/grpchandler"
 	"github.com/go-seidon/hippo/internal/healthcheck"
+	"github.com/go-seidon/hippo/internal/job"
 	"github.com/go-seidon/hippo/internal/repository"
 	"github.com/go-seidon/hippo/internal/service"
 	"github.com/go-seidon/provider/datetime"
@@ -31,6 +32,7 @@ type grpcApp struct {
 	logger       logging.Logger
 	repository   repository.Repository
 	healthClient health.HealthCheck
+	jobScheduler job.Scheduler
 }
 
 func (a *grpcApp) Run(ctx context.Context) error {
@@ -46,6 +48,11 @@ func (a *grpcApp) Run(ctx context.Context) error {
 		return err
 	}
 
+	err = a.jobScheduler.Start(ctx)
+	if err != nil {
+		return err
+	}
+
 	a.logger.Infof("Listening on: %s", a.config.GetAddress())
 	err = a.server.ListenAndServe()
 	if err != grpc.ErrServerStopped {
@@ -63,6 +70,11 @@ func (a *grpcApp) Stop(ctx context.Context) error {
 		a.logger.Errorf("Failed stopping healthcheck, err: %s", err.Error())
 	}
 
+	err = a.jobScheduler.Stop(ctx)
+	if err != nil {
+		a.logger.Errorf("Failed stopping job scheduler, err: %s", err.Error())
+	}
+
 	return a.server.Shutdown(ctx)
 }
 
@@ -77,11 +89,12 @@ func NewGrpcApp(opts ...GrpcAppOption) (*grpcApp, error) {
 	}
 
 	config := &GrpcAppConfig{
-		AppName:        fmt.Sprintf("%s-grpc", p.Config.AppName),
-		AppVersion:     p.Config.AppVersion,
-		AppHost:        p.Config.GRPCAppHost,
-		AppPort:        p.Config.GRPCAppPort,
-		UploadFormSize: p.Config.UploadFormSize,
+		AppName:                  fmt.Sprintf("%s-grpc", p.Config.AppName),
+		AppVersion:               p.Config.AppVersion,
+		AppHost:                  p.Config.GRPCAppHost,
+		AppPort:                  p.Config.GRPCAppPort,
+		UploadFormSize:           p.Config.UploadFormSize,
+		MultipartCleanupInterval: time.Duration(p.Config.UploadMultipartCleanupInterval) * time.Second,
 	}
 
 	var err error
@@ -109,34 +122,121 @@ func NewGrpcApp(opts ...GrpcAppOption) (*grpcApp, error) {
 		}
 	}
 
-	fileManager := filesystem.NewFileManager()
-	dirManager := filesystem.NewDirectoryManager()
+	verificationCache := p.VerificationCache
+	if verificationCache == nil {
+		verificationCache, err = app.NewDefaultVerificationCache(p.Config)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	jobScheduler := p.JobScheduler
+	if jobScheduler == nil {
+		jobScheduler, err = app.NewDefaultJobScheduler(p.Config, logger, repo, verificationCache)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	fileManager, err := app.NewDefaultFileManager(p.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	dirManager, err := app.NewDefaultDirectoryManager(p.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	locator, err := app.NewDefaultUploadLocation(p.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := app.NewDefaultUploadPolicy(p.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	fileScanner, err := app.NewDefaultScanner(p.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenClient, err := app.NewDefaultTokenAuth(p.Config, repo.GetAuth(), verificationCache)
+	if err != nil {
+		return nil, err
+	}
+
 	ksuIdentifier := ksuid.NewIdentifier()
 	govalidator := govalidator.NewValidator()
 	clock := datetime.NewClock()
-	locator := file.NewDailyRotate(file.DailyRotateParam{})
 
 	fileClient := service.NewFile(service.FileParam{
 		FileRepo:    repo.GetFile(),
+		AuthRepo:    repo.GetAuth(),
 		FileManager: fileManager,
 		Logger:      logger,
 		Identifier:  ksuIdentifier,
 		Clock:       clock,
 		DirManager:  dirManager,
 		Locator:     locator,
+		Policy:      policy,
+		Scanner:     fileScanner,
 		Validator:   govalidator,
 		Config: &service.FileConfig{
-			UploadDir: p.Config.UploadDirectory,
+			UploadDir:         p.Config.UploadDirectory,
+			ChecksumMd5:       p.Config.UploadChecksumMd5,
+			Deduplicate:       p.Config.UploadDeduplicate,
+			TrashEnabled:      p.Config.FileTrashEnabled,
+			TrashDir:          p.Config.FileTrashDirectory,
+			ScanFailOpen:      p.Config.ScannerFailOpen,
+			ScanSkipOversized: p.Config.ScannerSkipOversized,
+			UnownedAccess:     p.Config.FileUnownedAccess,
+		},
+	})
+
+	multipartClient := service.NewMultipart(service.MultipartParam{
+		MultipartRepo:  repo.GetMultipart(),
+		FileClient:     fileClient,
+		PartialManager: filesystem.NewPartialManager(),
+		DirManager:     filesystem.NewDirectoryManager(),
+		Logger:         logger,
+		Identifier:     ksuIdentifier,
+		Clock:          clock,
+		Validator:      govalidator,
+		Config: &service.MultipartConfig{
+			PartialDir: p.Config.UploadPartialDirectory,
+			Ttl:        time.Duration(p.Config.UploadMultipartTtl) * time.Second,
+			MaxSize:    p.Config.UploadPartialSize,
 		},
 	})
 
+	if config.MultipartCleanupInterval > 0 {
+		cleanupMultipart := job.NewCleanupMultipart(job.CleanupMultipartParam{
+			MultipartClient: multipartClient,
+			Logger:          logger,
+			Config: &job.CleanupMultipartConfig{
+				BatchSize: 100,
+			},
+		})
+		jobScheduler.Schedule(&job.BackgroundJob{
+			Name:     "cleanup-multipart",
+			Interval: config.MultipartCleanupInterval,
+			Runner:   cleanupMultipart,
+		})
+	}
+
 	base64Encoder := base64.NewEncoder()
 	bcryptHasher := bcrypt.NewHasher()
 
 	basicClient := auth.NewBasicAuth(auth.NewBasicAuthParam{
-		AuthRepo: repo.GetAuth(),
-		Encoder:  base64Encoder,
-		Hasher:   bcryptHasher,
+		AuthRepo:     repo.GetAuth(),
+		Encoder:      base64Encoder,
+		Hasher:       bcryptHasher,
+		Clock:        clock,
+		AdminClients: p.Config.AuthAdminClients,
+		Cache:        verificationCache,
 	})
 
 	grpcLogOpt := []grpclog.LogInterceptorOption{
@@ -148,14 +248,32 @@ func NewGrpcApp(opts ...GrpcAppOption) (*grpcApp, error) {
 			"X-Correlation-Id",
 		}),
 	}
-	grpcBasicAuth := grpcauth.WithAuth(BasicAuth(basicClient))
+	checkCredential := BasicAuth(basicClient)
+	// @note: bearer token is accepted along with the basic auth when it's enabled
+	if tokenClient != nil {
+		checkCredential = BearerAuth(tokenClient, checkCredential)
+	}
+	grpcAuth := grpcauth.WithAuth(checkCredential)
+	grpcPermission := grpcauth.WithPermission(ScopePermission(map[string]string{
+		"/health.v1.HealthService/CheckHealth":         "",
+		"/file.v1.FileService/DeleteFileById":          auth.SCOPE_FILE_DELETE,
+		"/file.v1.FileService/RestoreFileById":         auth.SCOPE_FILE_DELETE,
+		"/file.v1.FileService/RetrieveFileById":        auth.SCOPE_FILE_READ,
+		"/file.v1.FileService/SearchFile":              auth.SCOPE_FILE_READ,
+		"/file.v1.FileService/UploadFile":              auth.SCOPE_FILE_WRITE,
+		"/file.v1.FileService/InitiateMultipartUpload": auth.SCOPE_FILE_WRITE,
+		"/file.v1.FileService/UploadPart":              auth.SCOPE_FILE_WRITE,
+		"/file.v1.FileService/ListParts":               auth.SCOPE_FILE_WRITE,
+		"/file.v1.FileService/CompleteMultipartUpload": auth.SCOPE_FILE_WRITE,
+		"/file.v1.FileService/AbortMultipartUpload":    auth.SCOPE_FILE_WRITE,
+	}))
 	grpcServer := grpc.NewServer(
 		grpc.ChainUnaryInterceptor(
 			grpclog.UnaryServerInterceptor(grpcLogOpt...),
-			grpcauth.UnaryServerInterceptor(grpcBasicAuth),
+			grpcauth.UnaryServerInterceptor(grpcAuth, grpcPermission),
 		),
 		grpc.ChainStreamInterceptor(
-			grpcauth.StreamServerInterceptor(grpcBasicAuth),
+			grpcauth.StreamServerInterceptor(grpcAuth, grpcPermission),
 		),
 	)
 	healthCheck := healthcheck.NewHealthCheck(healthcheck.HealthCheckParam{
@@ -165,7 +283,8 @@ func NewGrpcApp(opts ...GrpcAppOption) (*grpcApp, error) {
 		HealthClient: healthCheck,
 	})
 	fileHandler := grpchandler.NewFile(grpchandler.FileParam{
-		FileClient: fileClient,
+		FileClient:      fileClient,
+		MultipartClient: multipartClient,
 		Config: &grpchandler.FileConfig{
 			UploadFormSize: config.UploadFormSize,
 		},
@@ -187,6 +306,7 @@ func NewGrpcApp(opts ...GrpcAppOption) (*grpcApp, error) {
 		config:       config,
 		repository:   repo,
 		healthClient: healthClient,
+		jobScheduler: jobScheduler,
 	}
 	return app, nil
 }
@@ -4,10 +4,14 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/go-seidon/hippo/internal/app"
+	mock_auth "github.com/go-seidon/hippo/internal/auth/mock"
 	"github.com/go-seidon/hippo/internal/grpcapp"
 	mock_grpcapp "github.com/go-seidon/hippo/internal/grpcapp/mock"
+	"github.com/go-seidon/hippo/internal/job"
+	mock_job "github.com/go-seidon/hippo/internal/job/mock"
 	mock_repository "github.com/go-seidon/hippo/internal/repository/mock"
 	mock_healthcheck "github.com/go-seidon/provider/health/mock"
 	mock_logging "github.com/go-seidon/provider/logging/mock"
@@ -30,6 +34,7 @@ var _ = Describe("App Package", func() {
 			logger        *mock_logging.MockLogger
 			repository    *mock_repository.MockRepository
 			healthService *mock_healthcheck.MockHealthCheck
+			cache         *mock_auth.MockVerificationCache
 		)
 
 		BeforeEach(func() {
@@ -39,14 +44,18 @@ var _ = Describe("App Package", func() {
 				AppDebug:           true,
 				AppEnv:             "local",
 				RepositoryProvider: "mongo",
+				UploadStorage:      "local",
 			}
 			logger = mock_logging.NewMockLogger(ctrl)
 			repository = mock_repository.NewMockRepository(ctrl)
 			fileRepo := mock_repository.NewMockFile(ctrl)
 			authRepo := mock_repository.NewMockAuth(ctrl)
+			multipartRepo := mock_repository.NewMockMultipart(ctrl)
 			repository.EXPECT().GetFile().Return(fileRepo).AnyTimes()
 			repository.EXPECT().GetAuth().Return(authRepo).AnyTimes()
+			repository.EXPECT().GetMultipart().Return(multipartRepo).AnyTimes()
 			healthService = mock_healthcheck.NewMockHealthCheck(ctrl)
+			cache = mock_auth.NewMockVerificationCache(ctrl)
 		})
 
 		When("config is not specified", func() {
@@ -58,6 +67,20 @@ var _ = Describe("App Package", func() {
 			})
 		})
 
+		When("storage provider is invalid", func() {
+			It("should return error", func() {
+				cfg.UploadStorage = "invalid"
+				res, err := grpcapp.NewGrpcApp(
+					grpcapp.WithConfig(cfg),
+					grpcapp.WithLogger(logger),
+					grpcapp.WithRepository(repository),
+				)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("invalid storage provider")))
+			})
+		})
+
 		When("logger is specified", func() {
 			It("should return result", func() {
 				res, err := grpcapp.NewGrpcApp(
@@ -84,6 +107,62 @@ var _ = Describe("App Package", func() {
 			})
 		})
 
+		When("verification cache config is invalid", func() {
+			It("should return error", func() {
+				cfg.AuthCacheTtl = 60
+				res, err := grpcapp.NewGrpcApp(
+					grpcapp.WithConfig(cfg),
+					grpcapp.WithLogger(logger),
+					grpcapp.WithRepository(repository),
+					grpcapp.WithService(healthService),
+				)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("invalid cache max entries")))
+			})
+		})
+
+		When("verification cache is specified", func() {
+			It("should return result", func() {
+				res, err := grpcapp.NewGrpcApp(
+					grpcapp.WithConfig(cfg),
+					grpcapp.WithLogger(logger),
+					grpcapp.WithRepository(repository),
+					grpcapp.WithService(healthService),
+					grpcapp.WithVerificationCache(cache),
+				)
+
+				Expect(res).ToNot(BeNil())
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("multipart cleanup is enabled", func() {
+			It("should schedule the cleanup job", func() {
+				cfg.UploadMultipartCleanupInterval = 3600
+				jobScheduler := mock_job.NewMockScheduler(gomock.NewController(GinkgoT()))
+				jobScheduler.
+					EXPECT().
+					Schedule(gomock.Any()).
+					Do(func(j *job.BackgroundJob) {
+						Expect(j.Name).To(Equal("cleanup-multipart"))
+						Expect(j.Interval).To(Equal(time.Hour))
+					}).
+					Times(1)
+
+				res, err := grpcapp.NewGrpcApp(
+					grpcapp.WithConfig(cfg),
+					grpcapp.WithLogger(logger),
+					grpcapp.WithRepository(repository),
+					grpcapp.WithService(healthService),
+					grpcapp.WithJobScheduler(jobScheduler),
+				)
+
+				Expect(res).ToNot(BeNil())
+				Expect(err).To(BeNil())
+			})
+		})
+
 		When("all parameters are specified", func() {
 			It("should return result", func() {
 				res, err := grpcapp.NewGrpcApp(
@@ -144,6 +223,7 @@ var _ = Describe("App Package", func() {
 			ctx           context.Context
 			logger        *mock_logging.MockLogger
 			healthService *mock_healthcheck.MockHealthCheck
+			jobScheduler  *mock_job.MockScheduler
 			server        *mock_grpcapp.MockServer
 			repository    *mock_repository.MockRepository
 		)
@@ -152,27 +232,32 @@ var _ = Describe("App Package", func() {
 			t := GinkgoT()
 			ctrl := gomock.NewController(t)
 			cfg := &app.Config{
-				AppDebug:    true,
-				AppEnv:      "local",
-				AppName:     "mock-name",
-				AppVersion:  "mock-version",
-				GRPCAppHost: "localhost",
-				GRPCAppPort: 4949,
+				AppDebug:      true,
+				AppEnv:        "local",
+				AppName:       "mock-name",
+				AppVersion:    "mock-version",
+				GRPCAppHost:   "localhost",
+				GRPCAppPort:   4949,
+				UploadStorage: "local",
 			}
 
 			logger = mock_logging.NewMockLogger(ctrl)
 			healthService = mock_healthcheck.NewMockHealthCheck(ctrl)
+			jobScheduler = mock_job.NewMockScheduler(ctrl)
 			server = mock_grpcapp.NewMockServer(ctrl)
 			repository = mock_repository.NewMockRepository(ctrl)
 			fileRepo := mock_repository.NewMockFile(ctrl)
 			authRepo := mock_repository.NewMockAuth(ctrl)
+			multipartRepo := mock_repository.NewMockMultipart(ctrl)
 			repository.EXPECT().GetFile().Return(fileRepo).AnyTimes()
 			repository.EXPECT().GetAuth().Return(authRepo).AnyTimes()
+			repository.EXPECT().GetMultipart().Return(multipartRepo).AnyTimes()
 
 			grpcApp, _ = grpcapp.NewGrpcApp(
 				grpcapp.WithConfig(cfg),
 				grpcapp.WithLogger(logger),
 				grpcapp.WithService(healthService),
+				grpcapp.WithJobScheduler(jobScheduler),
 				grpcapp.WithServer(server),
 				grpcapp.WithRepository(repository),
 			)
@@ -223,6 +308,37 @@ var _ = Describe("App Package", func() {
 			})
 		})
 
+		When("failed start job scheduler", func() {
+			It("should return error", func() {
+				logger.
+					EXPECT().
+					Infof(gomock.Eq("Running %s:%s"), gomock.Eq("mock-name-grpc"), gomock.Eq("mock-version")).
+					Times(1)
+
+				healthService.
+					EXPECT().
+					Start(gomock.Eq(ctx)).
+					Return(nil).
+					Times(1)
+
+				repository.
+					EXPECT().
+					Init(gomock.Eq(ctx)).
+					Return(nil).
+					Times(1)
+
+				jobScheduler.
+					EXPECT().
+					Start(gomock.Eq(ctx)).
+					Return(fmt.Errorf("scheduler error")).
+					Times(1)
+
+				err := grpcApp.Run(ctx)
+
+				Expect(err).To(Equal(fmt.Errorf("scheduler error")))
+			})
+		})
+
 		When("failed listen and serve", func() {
 			It("should return error", func() {
 				logger.
@@ -242,6 +358,12 @@ var _ = Describe("App Package", func() {
 					Return(nil).
 					Times(1)
 
+				jobScheduler.
+					EXPECT().
+					Start(gomock.Eq(ctx)).
+					Return(nil).
+					Times(1)
+
 				logger.
 					EXPECT().
 					Infof(gomock.Eq("Listening on: %s"), gomock.Eq("localhost:4949")).
@@ -278,6 +400,12 @@ var _ = Describe("App Package", func() {
 					Return(nil).
 					Times(1)
 
+				jobScheduler.
+					EXPECT().
+					Start(gomock.Eq(ctx)).
+					Return(nil).
+					Times(1)
+
 				logger.
 					EXPECT().
 					Infof(gomock.Eq("Listening on: %s"), gomock.Eq("localhost:4949")).
@@ -302,6 +430,7 @@ var _ = Describe("App Package", func() {
 			ctx           context.Context
 			logger        *mock_logging.MockLogger
 			healthService *mock_healthcheck.MockHealthCheck
+			jobScheduler  *mock_job.MockScheduler
 			server        *mock_grpcapp.MockServer
 		)
 
@@ -309,27 +438,32 @@ var _ = Describe("App Package", func() {
 			t := GinkgoT()
 			ctrl := gomock.NewController(t)
 			cfg := &app.Config{
-				AppDebug:    true,
-				AppEnv:      "local",
-				AppName:     "mock-name",
-				AppVersion:  "mock-version",
-				GRPCAppHost: "localhost",
-				GRPCAppPort: 4949,
+				AppDebug:      true,
+				AppEnv:        "local",
+				AppName:       "mock-name",
+				AppVersion:    "mock-version",
+				GRPCAppHost:   "localhost",
+				GRPCAppPort:   4949,
+				UploadStorage: "local",
 			}
 
 			logger = mock_logging.NewMockLogger(ctrl)
 			healthService = mock_healthcheck.NewMockHealthCheck(ctrl)
+			jobScheduler = mock_job.NewMockScheduler(ctrl)
 			server = mock_grpcapp.NewMockServer(ctrl)
 			repository := mock_repository.NewMockRepository(ctrl)
 			fileRepo := mock_repository.NewMockFile(ctrl)
 			authRepo := mock_repository.NewMockAuth(ctrl)
+			multipartRepo := mock_repository.NewMockMultipart(ctrl)
 			repository.EXPECT().GetFile().Return(fileRepo).AnyTimes()
 			repository.EXPECT().GetAuth().Return(authRepo).AnyTimes()
+			repository.EXPECT().GetMultipart().Return(multipartRepo).AnyTimes()
 
 			grpcApp, _ = grpcapp.NewGrpcApp(
 				grpcapp.WithConfig(cfg),
 				grpcapp.WithLogger(logger),
 				grpcapp.WithService(healthService),
+				grpcapp.WithJobScheduler(jobScheduler),
 				grpcapp.WithServer(server),
 				grpcapp.WithRepository(repository),
 			)
@@ -349,6 +483,12 @@ var _ = Describe("App Package", func() {
 					Return(nil).
 					Times(1)
 
+				jobScheduler.
+					EXPECT().
+					Stop(gomock.Eq(ctx)).
+					Return(nil).
+					Times(1)
+
 				server.
 					EXPECT().
 					Shutdown(gomock.Eq(context.Background())).
@@ -374,6 +514,12 @@ var _ = Describe("App Package", func() {
 					Return(nil).
 					Times(1)
 
+				jobScheduler.
+					EXPECT().
+					Stop(gomock.Eq(ctx)).
+					Return(nil).
+					Times(1)
+
 				server.
 					EXPECT().
 					Shutdown(gomock.Eq(context.Background())).
@@ -386,6 +532,39 @@ var _ = Describe("App Package", func() {
 			})
 		})
 
+		When("app is stopped twice", func() {
+			It("should not panic", func() {
+				logger.
+					EXPECT().
+					Infof(gomock.Eq("Stopping %s on: %s"), gomock.Eq("mock-name-grpc"), gomock.Eq("localhost:4949")).
+					Times(2)
+
+				healthService.
+					EXPECT().
+					Stop(gomock.Eq(ctx)).
+					Return(nil).
+					Times(2)
+
+				jobScheduler.
+					EXPECT().
+					Stop(gomock.Eq(ctx)).
+					Return(nil).
+					Times(2)
+
+				server.
+					EXPECT().
+					Shutdown(gomock.Eq(context.Background())).
+					Return(nil).
+					Times(2)
+
+				err := grpcApp.Stop(ctx)
+				Expect(err).To(BeNil())
+
+				err = grpcApp.Stop(ctx)
+				Expect(err).To(BeNil())
+			})
+		})
+
 		When("failed stop healthcheck", func() {
 			It("should log the error", func() {
 				logger.
@@ -404,6 +583,12 @@ var _ = Describe("App Package", func() {
 					Errorf(gomock.Eq("Failed stopping healthcheck, err: %s"), gomock.Eq("routine error")).
 					Times(1)
 
+				jobScheduler.
+					EXPECT().
+					Stop(gomock.Eq(ctx)).
+					Return(nil).
+					Times(1)
+
 				server.
 					EXPECT().
 					Shutdown(gomock.Eq(context.Background())).
@@ -415,5 +600,41 @@ var _ = Describe("App Package", func() {
 				Expect(err).To(Equal(fmt.Errorf("cant stop app")))
 			})
 		})
+
+		When("failed stop job scheduler", func() {
+			It("should log the error", func() {
+				logger.
+					EXPECT().
+					Infof(gomock.Eq("Stopping %s on: %s"), gomock.Eq("mock-name-grpc"), gomock.Eq("localhost:4949")).
+					Times(1)
+
+				healthService.
+					EXPECT().
+					Stop(gomock.Eq(ctx)).
+					Return(nil).
+					Times(1)
+
+				jobScheduler.
+					EXPECT().
+					Stop(gomock.Eq(ctx)).
+					Return(fmt.Errorf("context deadline exceeded")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					Errorf(gomock.Eq("Failed stopping job scheduler, err: %s"), gomock.Eq("context deadline exceeded")).
+					Times(1)
+
+				server.
+					EXPECT().
+					Shutdown(gomock.Eq(context.Background())).
+					Return(nil).
+					Times(1)
+
+				err := grpcApp.Stop(ctx)
+
+				Expect(err).To(BeNil())
+			})
+		})
 	})
 })
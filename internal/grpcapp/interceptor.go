@@ -10,22 +10,67 @@ import (
 )
 
 func BasicAuth(basicAuth auth.BasicAuth) grpcauth.CheckCredential {
-	return func(ctx context.Context) error {
+	return func(ctx context.Context) (context.Context, error) {
 		token, err := grpcauth.AuthFromMD(ctx, grpcauth.BasicKey)
 		if err != nil {
-			return status.Errorf(codes.Unauthenticated, err.Error())
+			return nil, status.Errorf(codes.Unauthenticated, err.Error())
 		}
 
 		res, err := basicAuth.CheckCredential(ctx, auth.CheckCredentialParam{
 			AuthToken: token,
 		})
 		if err != nil {
-			return status.Errorf(codes.Unknown, err.Error())
+			return nil, status.Errorf(codes.Unknown, err.Error())
 		}
 
 		if !res.IsValid() {
-			return status.Errorf(codes.Unauthenticated, grpcauth.ErrorInvalidCredential.Error())
+			return nil, status.Errorf(codes.Unauthenticated, grpcauth.ErrorInvalidCredential.Error())
+		}
+		return auth.WithIdentity(ctx, res.Identity), nil
+	}
+}
+
+// @note: method which is not listed is denied,
+// method listed with empty scope is allowed for any authenticated client
+func ScopePermission(methodScopes map[string]string) grpcauth.CheckPermission {
+	return func(ctx context.Context, fullMethod string) error {
+		scope, ok := methodScopes[fullMethod]
+		if !ok {
+			return status.Errorf(codes.PermissionDenied, "method is not permitted")
+		}
+		if scope == "" {
+			return nil
+		}
+
+		identity := auth.IdentityFromContext(ctx)
+		if !identity.HasScope(scope) {
+			return status.Errorf(codes.PermissionDenied, "insufficient scope")
 		}
 		return nil
 	}
 }
+
+// @note: request without bearer token is passed to the fallback (e.g: basic auth)
+func BearerAuth(tokenAuth auth.TokenAuth, fallback grpcauth.CheckCredential) grpcauth.CheckCredential {
+	return func(ctx context.Context) (context.Context, error) {
+		token, err := grpcauth.AuthFromMD(ctx, grpcauth.BearerKey)
+		if err != nil {
+			if fallback != nil {
+				return fallback(ctx)
+			}
+			return nil, status.Errorf(codes.Unauthenticated, err.Error())
+		}
+
+		res, err := tokenAuth.CheckToken(ctx, auth.CheckTokenParam{
+			AccessToken: token,
+		})
+		if err != nil {
+			return nil, status.Errorf(codes.Unknown, err.Error())
+		}
+
+		if !res.IsValid() {
+			return nil, status.Errorf(codes.Unauthenticated, grpcauth.ErrorInvalidCredential.Error())
+		}
+		return auth.WithIdentity(ctx, res.Identity), nil
+	}
+}
@@ -39,6 +39,9 @@ var _ = Describe("Interceptor Package", func() {
 			}
 			ccRes = &auth.CheckCredentialResult{
 				TokenValid: true,
+				Identity: &auth.Identity{
+					ClientId: "client-id",
+				},
 			}
 		})
 
@@ -49,8 +52,9 @@ var _ = Describe("Interceptor Package", func() {
 				cc := grpcapp.BasicAuth(ba)
 
 				ctx := context.Background()
-				err := cc(ctx)
+				res, err := cc(ctx)
 
+				Expect(res).To(BeNil())
 				expectErr := status.Errorf(codes.Unauthenticated, ccErr.Error())
 				Expect(err).To(Equal(expectErr))
 			})
@@ -68,8 +72,9 @@ var _ = Describe("Interceptor Package", func() {
 
 				cc := grpcapp.BasicAuth(ba)
 
-				err := cc(ctx)
+				res, err := cc(ctx)
 
+				Expect(res).To(BeNil())
 				expectErr := status.Errorf(codes.Unknown, ccErr.Error())
 				Expect(err).To(Equal(expectErr))
 			})
@@ -88,8 +93,9 @@ var _ = Describe("Interceptor Package", func() {
 
 				cc := grpcapp.BasicAuth(ba)
 
-				err := cc(ctx)
+				res, err := cc(ctx)
 
+				Expect(res).To(BeNil())
 				expectErr := status.Errorf(codes.Unauthenticated, grpcauth.ErrorInvalidCredential.Error())
 				Expect(err).To(Equal(expectErr))
 			})
@@ -105,7 +111,187 @@ var _ = Describe("Interceptor Package", func() {
 
 				cc := grpcapp.BasicAuth(ba)
 
-				err := cc(ctx)
+				res, err := cc(ctx)
+
+				Expect(err).To(BeNil())
+				Expect(auth.IdentityFromContext(res)).To(Equal(ccRes.Identity))
+			})
+		})
+	})
+
+	Context("BearerAuth function", Label("unit"), func() {
+		var (
+			ctx     context.Context
+			ta      *mock_auth.MockTokenAuth
+			ctParam auth.CheckTokenParam
+			ctRes   *auth.CheckCredentialResult
+		)
+
+		BeforeEach(func() {
+			ctx = metadata.NewIncomingContext(context.Background(), metadata.MD{
+				grpcauth.AuthKey: []string{grpcauth.BearerKey + " token"},
+			})
+			t := GinkgoT()
+			ctrl := gomock.NewController(t)
+			ta = mock_auth.NewMockTokenAuth(ctrl)
+			ctParam = auth.CheckTokenParam{
+				AccessToken: "token",
+			}
+			ctRes = &auth.CheckCredentialResult{
+				TokenValid: true,
+				Identity: &auth.Identity{
+					ClientId: "client-id",
+				},
+			}
+		})
+
+		When("auth is not specified", func() {
+			It("should return error", func() {
+				ccErr := fmt.Errorf("request unauthenticated with bearer")
+
+				cc := grpcapp.BearerAuth(ta, nil)
+
+				ctx := context.Background()
+				res, err := cc(ctx)
+
+				Expect(res).To(BeNil())
+				expectErr := status.Errorf(codes.Unauthenticated, ccErr.Error())
+				Expect(err).To(Equal(expectErr))
+			})
+		})
+
+		When("bearer token is not specified and fallback is available", func() {
+			It("should return fallback result", func() {
+				ctx := metadata.NewIncomingContext(context.Background(), metadata.MD{
+					grpcauth.AuthKey: []string{grpcauth.BasicKey + " token"},
+				})
+				fallbackCtx := auth.WithIdentity(ctx, &auth.Identity{
+					ClientId: "basic-client-id",
+				})
+
+				cc := grpcapp.BearerAuth(ta, func(ctx context.Context) (context.Context, error) {
+					return fallbackCtx, nil
+				})
+
+				res, err := cc(ctx)
+
+				Expect(res).To(Equal(fallbackCtx))
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("failed check token", func() {
+			It("should return error", func() {
+				ccErr := fmt.Errorf("network error")
+
+				ta.
+					EXPECT().
+					CheckToken(gomock.Eq(ctx), gomock.Eq(ctParam)).
+					Return(nil, ccErr).
+					Times(1)
+
+				cc := grpcapp.BearerAuth(ta, nil)
+
+				res, err := cc(ctx)
+
+				Expect(res).To(BeNil())
+				expectErr := status.Errorf(codes.Unknown, ccErr.Error())
+				Expect(err).To(Equal(expectErr))
+			})
+		})
+
+		When("token is not valid", func() {
+			It("should return error", func() {
+				ctRes := &auth.CheckCredentialResult{
+					TokenValid: false,
+				}
+				ta.
+					EXPECT().
+					CheckToken(gomock.Eq(ctx), gomock.Eq(ctParam)).
+					Return(ctRes, nil).
+					Times(1)
+
+				cc := grpcapp.BearerAuth(ta, nil)
+
+				res, err := cc(ctx)
+
+				Expect(res).To(BeNil())
+				expectErr := status.Errorf(codes.Unauthenticated, grpcauth.ErrorInvalidCredential.Error())
+				Expect(err).To(Equal(expectErr))
+			})
+		})
+
+		When("token is valid", func() {
+			It("should return result", func() {
+				ta.
+					EXPECT().
+					CheckToken(gomock.Eq(ctx), gomock.Eq(ctParam)).
+					Return(ctRes, nil).
+					Times(1)
+
+				cc := grpcapp.BearerAuth(ta, nil)
+
+				res, err := cc(ctx)
+
+				Expect(err).To(BeNil())
+				Expect(auth.IdentityFromContext(res)).To(Equal(ctRes.Identity))
+			})
+		})
+	})
+
+	Context("ScopePermission function", Label("unit"), func() {
+		var (
+			ctx context.Context
+			cp  func(ctx context.Context, fullMethod string) error
+		)
+
+		BeforeEach(func() {
+			ctx = auth.WithIdentity(context.Background(), &auth.Identity{
+				ClientId: "client-id",
+				Scopes:   []string{auth.SCOPE_FILE_READ},
+			})
+			cp = grpcapp.ScopePermission(map[string]string{
+				"/health.v1.HealthService/CheckHealth": "",
+				"/file.v1.FileService/SearchFile":      auth.SCOPE_FILE_READ,
+				"/file.v1.FileService/UploadFile":      auth.SCOPE_FILE_WRITE,
+			})
+		})
+
+		When("method is not listed", func() {
+			It("should return error", func() {
+				err := cp(ctx, "/file.v1.FileService/UnknownMethod")
+
+				Expect(err).To(Equal(status.Errorf(codes.PermissionDenied, "method is not permitted")))
+			})
+		})
+
+		When("method scope is empty", func() {
+			It("should return result", func() {
+				err := cp(ctx, "/health.v1.HealthService/CheckHealth")
+
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("identity is not available", func() {
+			It("should return error", func() {
+				err := cp(context.Background(), "/file.v1.FileService/SearchFile")
+
+				Expect(err).To(Equal(status.Errorf(codes.PermissionDenied, "insufficient scope")))
+			})
+		})
+
+		When("scope is not granted", func() {
+			It("should return error", func() {
+				err := cp(ctx, "/file.v1.FileService/UploadFile")
+
+				Expect(err).To(Equal(status.Errorf(codes.PermissionDenied, "insufficient scope")))
+			})
+		})
+
+		When("scope is granted", func() {
+			It("should return result", func() {
+				err := cp(ctx, "/file.v1.FileService/SearchFile")
 
 				Expect(err).To(BeNil())
 			})
@@ -2,19 +2,23 @@ package grpcapp
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/go-seidon/hippo/internal/app"
+	"github.com/go-seidon/hippo/internal/auth"
+	"github.com/go-seidon/hippo/internal/job"
 	"github.com/go-seidon/hippo/internal/repository"
 	"github.com/go-seidon/provider/health"
 	"github.com/go-seidon/provider/logging"
 )
 
 type GrpcAppConfig struct {
-	AppName        string
-	AppVersion     string
-	AppHost        string
-	AppPort        int
-	UploadFormSize int64
+	AppName                  string
+	AppVersion               string
+	AppHost                  string
+	AppPort                  int
+	UploadFormSize           int64
+	MultipartCleanupInterval time.Duration
 }
 
 func (c *GrpcAppConfig) GetAppName() string {
@@ -35,6 +39,9 @@ type GrpcAppParam struct {
 	Server       Server
 	Repository   repository.Repository
 	HealthClient health.HealthCheck
+	JobScheduler job.Scheduler
+	// @note: specify the same cache to share it between apps in the same process
+	VerificationCache auth.VerificationCache
 }
 
 type GrpcAppOption = func(*GrpcAppParam)
@@ -68,3 +75,15 @@ func WithRepository(repo repository.Repository) GrpcAppOption {
 		p.Repository = repo
 	}
 }
+
+func WithJobScheduler(scheduler job.Scheduler) GrpcAppOption {
+	return func(p *GrpcAppParam) {
+		p.JobScheduler = scheduler
+	}
+}
+
+func WithVerificationCache(cache auth.VerificationCache) GrpcAppOption {
+	return func(p *GrpcAppParam) {
+		p.VerificationCache = cache
+	}
+}
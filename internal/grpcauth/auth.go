@@ -2,6 +2,7 @@ package grpcauth
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
 	"strings"
 
@@ -28,3 +29,23 @@ func AuthFromMD(ctx context.Context, scheme string) (string, error) {
 	}
 	return splits[1], nil
 }
+
+// @note: client id of the basic credential, empty when it's not available
+// the credential is expected to be already checked by the auth interceptor
+func BasicClientIdFromMD(ctx context.Context) string {
+	token, err := AuthFromMD(ctx, BasicKey)
+	if err != nil {
+		return ""
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(token)
+	if err != nil {
+		return ""
+	}
+
+	splits := strings.SplitN(string(decoded), ":", 2)
+	if len(splits) != 2 {
+		return ""
+	}
+	return splits[0]
+}
@@ -2,6 +2,7 @@ package grpcauth_test
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
 	"testing"
 
@@ -73,4 +74,47 @@ var _ = Describe("Auth Package", func() {
 			})
 		})
 	})
+
+	Context("BasicClientIdFromMD function", Label("unit"), func() {
+		When("auth is not available", func() {
+			It("should return empty result", func() {
+				res := grpc_auth.BasicClientIdFromMD(context.Background())
+
+				Expect(res).To(Equal(""))
+			})
+		})
+
+		When("token is not valid base64", func() {
+			It("should return empty result", func() {
+				ctx := metadata.NewIncomingContext(context.Background(), metadata.MD{
+					grpc_auth.AuthKey: []string{"basic %%%"},
+				})
+				res := grpc_auth.BasicClientIdFromMD(ctx)
+
+				Expect(res).To(Equal(""))
+			})
+		})
+
+		When("token is not valid credential", func() {
+			It("should return empty result", func() {
+				ctx := metadata.NewIncomingContext(context.Background(), metadata.MD{
+					grpc_auth.AuthKey: []string{"basic " + base64.StdEncoding.EncodeToString([]byte("client-id"))},
+				})
+				res := grpc_auth.BasicClientIdFromMD(ctx)
+
+				Expect(res).To(Equal(""))
+			})
+		})
+
+		When("token is valid credential", func() {
+			It("should return result", func() {
+				ctx := metadata.NewIncomingContext(context.Background(), metadata.MD{
+					grpc_auth.AuthKey: []string{"basic " + base64.StdEncoding.EncodeToString([]byte("client-id:secret"))},
+				})
+				res := grpc_auth.BasicClientIdFromMD(ctx)
+
+				Expect(res).To(Equal("client-id"))
+			})
+		})
+	})
 })
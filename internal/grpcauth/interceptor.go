@@ -9,25 +9,50 @@ import (
 func UnaryServerInterceptor(opts ...AuthInterceptorOption) grpc.UnaryServerInterceptor {
 	cfg := buildConfig(opts...)
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-		err := cfg.CheckCredential(ctx)
+		newCtx, err := cfg.CheckCredential(ctx)
 		if err != nil {
 			return nil, err
 		}
-		return handler(ctx, req)
+		if cfg.CheckPermission != nil {
+			err = cfg.CheckPermission(newCtx, info.FullMethod)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return handler(newCtx, req)
 	}
 }
 
 func StreamServerInterceptor(opts ...AuthInterceptorOption) grpc.StreamServerInterceptor {
 	cfg := buildConfig(opts...)
 	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
-		err := cfg.CheckCredential(ss.Context())
+		newCtx, err := cfg.CheckCredential(ss.Context())
 		if err != nil {
 			return err
 		}
-		return handler(srv, ss)
+		if cfg.CheckPermission != nil {
+			err = cfg.CheckPermission(newCtx, info.FullMethod)
+			if err != nil {
+				return err
+			}
+		}
+		return handler(srv, &serverStream{
+			ServerStream: ss,
+			ctx:          newCtx,
+		})
 	}
 }
 
+// @note: server stream which carries the authenticated context
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
 func buildConfig(opts ...AuthInterceptorOption) *AuthInterceptorConfig {
 	cfg := &AuthInterceptorConfig{}
 	for _, opt := range opts {
@@ -2,6 +2,7 @@ package grpcauth_test
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/go-seidon/hippo/internal/grpcauth"
 	mock_grpc "github.com/go-seidon/provider/grpc/mock"
@@ -34,12 +35,35 @@ var _ = Describe("Auth Package", func() {
 		When("credential is not valid", func() {
 			It("should return error", func() {
 				expectErr := grpcauth.ErrorInvalidCredential
-				cc := func(ctx context.Context) error {
+				cc := func(ctx context.Context) (context.Context, error) {
+					return nil, expectErr
+				}
+				interceptor := grpcauth.UnaryServerInterceptor(
+					grpcauth.WithAuth(cc),
+				)
+
+				res, err := interceptor(ctx, req, info, handler)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(expectErr))
+			})
+		})
+
+		When("permission is denied", func() {
+			It("should return error", func() {
+				expectErr := fmt.Errorf("permission denied")
+				cc := func(ctx context.Context) (context.Context, error) {
+					return ctx, nil
+				}
+				cp := func(ctx context.Context, fullMethod string) error {
+					Expect(fullMethod).To(Equal("/file.v1.FileService/UploadFile"))
 					return expectErr
 				}
 				interceptor := grpcauth.UnaryServerInterceptor(
 					grpcauth.WithAuth(cc),
+					grpcauth.WithPermission(cp),
 				)
+				info.FullMethod = "/file.v1.FileService/UploadFile"
 
 				res, err := interceptor(ctx, req, info, handler)
 
@@ -50,16 +74,20 @@ var _ = Describe("Auth Package", func() {
 
 		When("credential is valid", func() {
 			It("should return result", func() {
-				cc := func(ctx context.Context) error {
-					return nil
+				type key struct{}
+				cc := func(ctx context.Context) (context.Context, error) {
+					return context.WithValue(ctx, key{}, "client-id"), nil
 				}
 				interceptor := grpcauth.UnaryServerInterceptor(
 					grpcauth.WithAuth(cc),
 				)
+				handler = func(ctx context.Context, req interface{}) (interface{}, error) {
+					return ctx.Value(key{}), nil
+				}
 
 				res, err := interceptor(ctx, req, info, handler)
 
-				Expect(res).ToNot(BeNil())
+				Expect(res).To(Equal("client-id"))
 				Expect(err).To(BeNil())
 			})
 		})
@@ -92,12 +120,34 @@ var _ = Describe("Auth Package", func() {
 		When("credential is not valid", func() {
 			It("should return error", func() {
 				expectErr := grpcauth.ErrorInvalidCredential
-				cc := func(ctx context.Context) error {
+				cc := func(ctx context.Context) (context.Context, error) {
+					return nil, expectErr
+				}
+				interceptor := grpcauth.StreamServerInterceptor(
+					grpcauth.WithAuth(cc),
+				)
+
+				err := interceptor(srv, ss, info, handler)
+
+				Expect(err).To(Equal(expectErr))
+			})
+		})
+
+		When("permission is denied", func() {
+			It("should return error", func() {
+				expectErr := fmt.Errorf("permission denied")
+				cc := func(ctx context.Context) (context.Context, error) {
+					return ctx, nil
+				}
+				cp := func(ctx context.Context, fullMethod string) error {
+					Expect(fullMethod).To(Equal("/file.v1.FileService/RetrieveFileById"))
 					return expectErr
 				}
 				interceptor := grpcauth.StreamServerInterceptor(
 					grpcauth.WithAuth(cc),
+					grpcauth.WithPermission(cp),
 				)
+				info.FullMethod = "/file.v1.FileService/RetrieveFileById"
 
 				err := interceptor(srv, ss, info, handler)
 
@@ -107,12 +157,17 @@ var _ = Describe("Auth Package", func() {
 
 		When("credential is valid", func() {
 			It("should return result", func() {
-				cc := func(ctx context.Context) error {
-					return nil
+				type key struct{}
+				cc := func(ctx context.Context) (context.Context, error) {
+					return context.WithValue(ctx, key{}, "client-id"), nil
 				}
 				interceptor := grpcauth.StreamServerInterceptor(
 					grpcauth.WithAuth(cc),
 				)
+				handler = func(srv interface{}, stream grpc.ServerStream) error {
+					Expect(stream.Context().Value(key{})).To(Equal("client-id"))
+					return nil
+				}
 
 				err := interceptor(srv, ss, info, handler)
 
@@ -4,14 +4,25 @@ import "context"
 
 type AuthInterceptorConfig struct {
 	CheckCredential CheckCredential
+	CheckPermission CheckPermission
 }
 
 type AuthInterceptorOption = func(*AuthInterceptorConfig)
 
-type CheckCredential = func(ctx context.Context) error
+// @note: the returned context is passed to the handler (e.g: with the authenticated identity)
+type CheckCredential = func(ctx context.Context) (context.Context, error)
+
+// @note: permission is checked using the authenticated context and the full method name
+type CheckPermission = func(ctx context.Context, fullMethod string) error
 
 func WithAuth(cc CheckCredential) AuthInterceptorOption {
 	return func(cfg *AuthInterceptorConfig) {
 		cfg.CheckCredential = cc
 	}
 }
+
+func WithPermission(cp CheckPermission) AuthInterceptorOption {
+	return func(cfg *AuthInterceptorConfig) {
+		cfg.CheckPermission = cp
+	}
+}
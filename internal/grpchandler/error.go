@@ -0,0 +1,25 @@
+package grpchandler
+
+import (
+	"errors"
+
+	"github.com/go-seidon/hippo/internal/service"
+	"github.com/go-seidon/provider/system"
+	"google.golang.org/grpc/codes"
+	grpc_status "google.golang.org/grpc/status"
+)
+
+var (
+	ErrorFileTooLarge    = errors.New("file is too large")
+	ErrorPartInfoMissing = errors.New("part info is not specified")
+)
+
+// @note: exceeded quota is returned as grpc error so the client can back off,
+// nil is returned for the other errors which are returned in the result
+func quotaError(err *system.Error) error {
+	switch err.Code {
+	case service.QUOTA_EXCEEDED, service.QUOTA_SIZE_EXCEEDED:
+		return grpc_status.Error(codes.ResourceExhausted, err.Message)
+	}
+	return nil
+}
@@ -1,22 +1,30 @@
 package grpchandler
 
 import (
-	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/go-seidon/hippo/api/grpcapp"
+	"github.com/go-seidon/hippo/internal/grpcauth"
 	"github.com/go-seidon/hippo/internal/service"
 	"github.com/go-seidon/provider/status"
+	"github.com/go-seidon/provider/typeconv"
 	"google.golang.org/grpc/metadata"
 )
 
+const (
+	DEFAULT_CHUNK_SIZE = 102400  //100KB
+	MAX_CHUNK_SIZE     = 1048576 //1MB
+)
+
 type fileHandler struct {
 	grpcapp.UnimplementedFileServiceServer
-	fileClient service.File
-	config     *FileConfig
+	fileClient      service.File
+	multipartClient service.Multipart
+	config          *FileConfig
 }
 
 func (h *fileHandler) DeleteFileById(ctx context.Context, p *grpcapp.DeleteFileByIdParam) (*grpcapp.DeleteFileByIdResult, error) {
@@ -41,9 +49,47 @@ func (h *fileHandler) DeleteFileById(ctx context.Context, p *grpcapp.DeleteFileB
 	return res, nil
 }
 
+func (h *fileHandler) RestoreFileById(ctx context.Context, p *grpcapp.RestoreFileByIdParam) (*grpcapp.RestoreFileByIdResult, error) {
+	restoration, err := h.fileClient.RestoreFile(ctx, service.RestoreFileParam{
+		FileId: p.FileId,
+	})
+	if err != nil {
+		res := &grpcapp.RestoreFileByIdResult{
+			Code:    err.Code,
+			Message: err.Message,
+		}
+		return res, nil
+	}
+
+	res := &grpcapp.RestoreFileByIdResult{
+		Code:    restoration.Success.Code,
+		Message: restoration.Success.Message,
+		Data: &grpcapp.RestoreFileByIdData{
+			RestoredAt: restoration.RestoredAt.UnixMilli(),
+		},
+	}
+	return res, nil
+}
+
+// @note: file is read from the offset until the end when length is not specified,
+// the served range is reported in the header metadata
 func (h *fileHandler) RetrieveFileById(p *grpcapp.RetrieveFileByIdParam, stream grpcapp.FileService_RetrieveFileByIdServer) error {
+	chunkSize := int(p.ChunkSize)
+	if chunkSize == 0 {
+		chunkSize = DEFAULT_CHUNK_SIZE
+	}
+	if chunkSize < 0 || chunkSize > MAX_CHUNK_SIZE {
+		return stream.Send(&grpcapp.RetrieveFileByIdResult{
+			Code:    status.INVALID_PARAM,
+			Message: fmt.Sprintf("chunk_size must be between 1 and %d", MAX_CHUNK_SIZE),
+		})
+	}
+
 	retrieval, rerr := h.fileClient.RetrieveFile(stream.Context(), service.RetrieveFileParam{
-		FileId: p.FileId,
+		FileId:         p.FileId,
+		VerifyChecksum: p.VerifyChecksum,
+		Offset:         p.Offset,
+		Length:         p.Length,
 	})
 	if rerr != nil {
 		res := &grpcapp.RetrieveFileByIdResult{
@@ -58,10 +104,14 @@ func (h *fileHandler) RetrieveFileById(p *grpcapp.RetrieveFileByIdParam, stream
 	}
 
 	err := stream.SendHeader(metadata.New(map[string]string{
-		"file_name":      retrieval.Name,
-		"file_mimetype":  retrieval.MimeType,
-		"file_extension": retrieval.Extension,
-		"file_size":      fmt.Sprintf("%d", retrieval.Size),
+		"file_name":            retrieval.Name,
+		"file_mimetype":        retrieval.MimeType,
+		"file_extension":       retrieval.Extension,
+		"file_size":            fmt.Sprintf("%d", retrieval.Size),
+		"file_checksum_sha256": retrieval.Checksum.Sha256,
+		"file_checksum_md5":    retrieval.Checksum.Md5,
+		"file_offset":          fmt.Sprintf("%d", retrieval.Offset),
+		"file_length":          fmt.Sprintf("%d", retrieval.Length),
 	}))
 	if err != nil {
 		return err
@@ -77,7 +127,6 @@ func (h *fileHandler) RetrieveFileById(p *grpcapp.RetrieveFileByIdParam, stream
 
 	defer retrieval.Data.Close()
 
-	const chunkSize = 102400 //100KB
 	for {
 		err = stream.Context().Err()
 		if err != nil {
@@ -92,14 +141,16 @@ func (h *fileHandler) RetrieveFileById(p *grpcapp.RetrieveFileByIdParam, stream
 		}
 
 		chunks := make([]byte, chunkSize)
-		_, err := retrieval.Data.Read(chunks)
-		if err == nil {
-			err = stream.Send(&grpcapp.RetrieveFileByIdResult{
-				Chunks: chunks,
+		n, err := retrieval.Data.Read(chunks)
+		if n > 0 {
+			serr := stream.Send(&grpcapp.RetrieveFileByIdResult{
+				Chunks: chunks[:n],
 			})
-			if err != nil {
-				return err
+			if serr != nil {
+				return serr
 			}
+		}
+		if err == nil {
 			continue
 		}
 
@@ -127,58 +178,13 @@ func (h *fileHandler) RetrieveFileById(p *grpcapp.RetrieveFileByIdParam, stream
 }
 
 func (h *fileHandler) UploadFile(stream grpcapp.FileService_UploadFileServer) error {
-	fileSize := int64(0)
-	fileInfo := grpcapp.UploadFileInfo{}
-	fileReader := &bytes.Buffer{}
-
-	for {
-		err := stream.Context().Err()
-		if err != nil {
-			err = stream.SendAndClose(&grpcapp.UploadFileResult{
-				Code:    status.ACTION_FAILED,
-				Message: err.Error(),
-			})
-			if err != nil {
-				return err
-			}
-			return nil
-		}
-
-		param, err := stream.Recv()
-		if err == nil {
-			info := param.GetInfo()
-			if info != nil {
-				fileInfo = grpcapp.UploadFileInfo{
-					Name:      info.GetName(),
-					Mimetype:  info.GetMimetype(),
-					Extension: info.GetExtension(),
-				}
-			}
-
-			chunks := param.GetChunks()
-			if chunks != nil {
-				fileSize += int64(len(chunks))
-
-				if fileSize > h.config.UploadFormSize {
-					err = stream.SendAndClose(&grpcapp.UploadFileResult{
-						Code:    status.ACTION_FAILED,
-						Message: "file is too large",
-					})
-					if err != nil {
-						return err
-					}
-					return nil
-				}
-
-				fileReader.Write(chunks)
-			}
-			continue
-		}
-
-		if errors.Is(err, io.EOF) {
-			break
-		}
+	reader := NewUploadReader(UploadReaderParam{
+		Stream:  stream,
+		MaxSize: h.config.UploadFormSize,
+	})
 
+	fileInfo, err := reader.ReadInfo()
+	if err != nil {
 		err = stream.SendAndClose(&grpcapp.UploadFileResult{
 			Code:    status.ACTION_FAILED,
 			Message: err.Error(),
@@ -189,17 +195,26 @@ func (h *fileHandler) UploadFile(stream grpcapp.FileService_UploadFileServer) er
 		return nil
 	}
 
+	ctx := stream.Context()
 	upload, uerr := h.fileClient.UploadFile(
-		stream.Context(),
+		ctx,
 		service.WithFileInfo(
 			fileInfo.Name,
 			fileInfo.Mimetype,
 			fileInfo.Extension,
-			fileSize,
+			0,
+		),
+		service.WithReader(reader),
+		service.WithLocation(
+			grpcauth.BasicClientIdFromMD(ctx),
+			fileInfo.Bucket,
 		),
-		service.WithReader(fileReader),
 	)
 	if uerr != nil {
+		if qerr := quotaError(uerr); qerr != nil {
+			return qerr
+		}
+
 		res := &grpcapp.UploadFileResult{
 			Code:    uerr.Code,
 			Message: uerr.Message,
@@ -212,17 +227,19 @@ func (h *fileHandler) UploadFile(stream grpcapp.FileService_UploadFileServer) er
 		return nil
 	}
 
-	err := stream.SendAndClose(&grpcapp.UploadFileResult{
+	err = stream.SendAndClose(&grpcapp.UploadFileResult{
 		Code:    upload.Success.Code,
 		Message: upload.Success.Message,
 		Data: &grpcapp.UploadFileData{
-			Id:         upload.UniqueId,
-			Name:       upload.Name,
-			Path:       upload.Path,
-			Mimetype:   upload.Mimetype,
-			Extension:  upload.Extension,
-			Size:       upload.Size,
-			UploadedAt: upload.UploadedAt.UnixMilli(),
+			Id:             upload.UniqueId,
+			Name:           upload.Name,
+			Path:           upload.Path,
+			Mimetype:       upload.Mimetype,
+			Extension:      upload.Extension,
+			Size:           upload.Size,
+			UploadedAt:     upload.UploadedAt.UnixMilli(),
+			ChecksumSha256: upload.Checksum.Sha256,
+			ChecksumMd5:    upload.Checksum.Md5,
 		},
 	})
 	if err != nil {
@@ -231,18 +248,91 @@ func (h *fileHandler) UploadFile(stream grpcapp.FileService_UploadFileServer) er
 	return nil
 }
 
+func (h *fileHandler) SearchFile(ctx context.Context, p *grpcapp.SearchFileParam) (*grpcapp.SearchFileResult, error) {
+	param := service.SearchFileParam{
+		Keyword: p.Keyword,
+	}
+	if p.Filter != nil {
+		param.Mimetypes = p.Filter.MimetypeIn
+		param.Extensions = p.Filter.ExtensionIn
+		param.Statuses = p.Filter.StatusIn
+		param.MinSize = p.Filter.SizeGte
+		param.MaxSize = p.Filter.SizeLte
+		if p.Filter.UploadedAtGte != nil {
+			param.UploadedFrom = typeconv.Time(time.UnixMilli(*p.Filter.UploadedAtGte).UTC())
+		}
+		if p.Filter.UploadedAtLte != nil {
+			param.UploadedTo = typeconv.Time(time.UnixMilli(*p.Filter.UploadedAtLte).UTC())
+		}
+	}
+
+	if p.Pagination != nil {
+		param.TotalItems = p.Pagination.TotalItems
+		param.Page = p.Pagination.Page
+	}
+
+	if p.Sort != nil {
+		param.SortBy = p.Sort.Field
+		param.SortOrder = p.Sort.Order
+	}
+
+	searchRes, err := h.fileClient.SearchFile(ctx, param)
+	if err != nil {
+		res := &grpcapp.SearchFileResult{
+			Code:    err.Code,
+			Message: err.Message,
+		}
+		return res, nil
+	}
+
+	items := []*grpcapp.SearchFileItem{}
+	for _, searchItem := range searchRes.Items {
+		var deletedAt *int64
+		if searchItem.DeletedAt != nil {
+			deletedAt = typeconv.Int64(searchItem.DeletedAt.UnixMilli())
+		}
+
+		items = append(items, &grpcapp.SearchFileItem{
+			Id:             searchItem.UniqueId,
+			Name:           searchItem.Name,
+			Mimetype:       searchItem.MimeType,
+			Extension:      searchItem.Extension,
+			Size:           searchItem.Size,
+			UploadedAt:     searchItem.UploadedAt.UnixMilli(),
+			DeletedAt:      deletedAt,
+			ChecksumSha256: searchItem.Checksum.Sha256,
+			ChecksumMd5:    searchItem.Checksum.Md5,
+		})
+	}
+
+	res := &grpcapp.SearchFileResult{
+		Code:    searchRes.Success.Code,
+		Message: searchRes.Success.Message,
+		Data: &grpcapp.SearchFileData{
+			Items: items,
+			Summary: &grpcapp.SearchFileSummary{
+				TotalItems: searchRes.Summary.TotalItems,
+				Page:       searchRes.Summary.Page,
+			},
+		},
+	}
+	return res, nil
+}
+
 type FileConfig struct {
 	UploadFormSize int64
 }
 
 type FileParam struct {
-	FileClient service.File
-	Config     *FileConfig
+	FileClient      service.File
+	MultipartClient service.Multipart
+	Config          *FileConfig
 }
 
 func NewFile(p FileParam) *fileHandler {
 	return &fileHandler{
-		fileClient: p.FileClient,
-		config:     p.Config,
+		fileClient:      p.FileClient,
+		multipartClient: p.MultipartClient,
+		config:          p.Config,
 	}
 }
@@ -4,19 +4,24 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"strings"
 	"time"
 
 	api "github.com/go-seidon/hippo/api/grpcapp"
 	mock_grpcapp "github.com/go-seidon/hippo/api/grpcapp/mock"
+	"github.com/go-seidon/hippo/internal/file"
 	"github.com/go-seidon/hippo/internal/grpchandler"
 	"github.com/go-seidon/hippo/internal/service"
 	mock_service "github.com/go-seidon/hippo/internal/service/mock"
 	mock_context "github.com/go-seidon/provider/context/mock"
 	mock_io "github.com/go-seidon/provider/io/mock"
 	"github.com/go-seidon/provider/system"
+	"github.com/go-seidon/provider/typeconv"
 	"github.com/golang/mock/gomock"
 
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	grpc_status "google.golang.org/grpc/status"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -149,6 +154,132 @@ var _ = Describe("File Handler", func() {
 		})
 	})
 
+	Context("RestoreFileById function", Label("unit"), func() {
+		var (
+			handler      api.FileServiceServer
+			fileService  *mock_service.MockFile
+			ctx          context.Context
+			currentTs    time.Time
+			p            *api.RestoreFileByIdParam
+			r            *api.RestoreFileByIdResult
+			restoreParam service.RestoreFileParam
+			restoreRes   *service.RestoreFileResult
+		)
+
+		BeforeEach(func() {
+			t := GinkgoT()
+			ctrl := gomock.NewController(t)
+			fileService = mock_service.NewMockFile(ctrl)
+			handler = grpchandler.NewFile(grpchandler.FileParam{
+				FileClient: fileService,
+				Config:     &grpchandler.FileConfig{},
+			})
+			ctx = context.Background()
+			currentTs = time.Now()
+			p = &api.RestoreFileByIdParam{
+				FileId: "file-id",
+			}
+			r = &api.RestoreFileByIdResult{
+				Code:    1000,
+				Message: "success restore file",
+				Data: &api.RestoreFileByIdData{
+					RestoredAt: currentTs.UnixMilli(),
+				},
+			}
+			restoreParam = service.RestoreFileParam{
+				FileId: "file-id",
+			}
+			restoreRes = &service.RestoreFileResult{
+				Success: system.Success{
+					Code:    1000,
+					Message: "success restore file",
+				},
+				RestoredAt: currentTs,
+			}
+		})
+
+		When("file is not deleted", func() {
+			It("should return error", func() {
+				fileService.
+					EXPECT().
+					RestoreFile(gomock.Eq(ctx), gomock.Eq(restoreParam)).
+					Return(nil, &system.Error{
+						Code:    2006,
+						Message: "file is not deleted",
+					}).
+					Times(1)
+
+				res, err := handler.RestoreFileById(ctx, p)
+
+				r := &api.RestoreFileByIdResult{
+					Code:    2006,
+					Message: "file is not deleted",
+				}
+				Expect(res).To(Equal(r))
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("there is invalid param", func() {
+			It("should return error", func() {
+				fileService.
+					EXPECT().
+					RestoreFile(gomock.Eq(ctx), gomock.Eq(restoreParam)).
+					Return(nil, &system.Error{
+						Code:    1002,
+						Message: "invalid data",
+					}).
+					Times(1)
+
+				res, err := handler.RestoreFileById(ctx, p)
+
+				r := &api.RestoreFileByIdResult{
+					Code:    1002,
+					Message: "invalid data",
+				}
+				Expect(res).To(Equal(r))
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("failed restore file", func() {
+			It("should return error", func() {
+				fileService.
+					EXPECT().
+					RestoreFile(gomock.Eq(ctx), gomock.Eq(restoreParam)).
+					Return(nil, &system.Error{
+						Code:    1001,
+						Message: "network error",
+					}).
+					Times(1)
+
+				res, err := handler.RestoreFileById(ctx, p)
+
+				r := &api.RestoreFileByIdResult{
+					Code:    1001,
+					Message: "network error",
+				}
+				Expect(res).To(Equal(r))
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("success restore file", func() {
+			It("should return result", func() {
+				fileService.
+					EXPECT().
+					RestoreFile(gomock.Eq(ctx), gomock.Eq(restoreParam)).
+					Return(restoreRes, nil).
+					Times(1)
+
+				res, err := handler.RestoreFileById(ctx, p)
+
+				Expect(res).To(Equal(r))
+				Expect(err).To(BeNil())
+			})
+		})
+	})
+
 	Context("RetrieveFileById function", Label("unit"), func() {
 		var (
 			handler     api.FileServiceServer
@@ -175,7 +306,8 @@ var _ = Describe("File Handler", func() {
 			})
 			ctx = mock_context.NewMockContext(ctrl)
 			p = &api.RetrieveFileByIdParam{
-				FileId: "file-id",
+				FileId:         "file-id",
+				VerifyChecksum: true,
 			}
 			pendingRes = &api.RetrieveFileByIdResult{
 				Code:    1005,
@@ -195,7 +327,8 @@ var _ = Describe("File Handler", func() {
 			}
 			stream = mock_grpcapp.NewMockFileService_RetrieveFileByIdServer(ctrl)
 			retParam = service.RetrieveFileParam{
-				FileId: "file-id",
+				FileId:         "file-id",
+				VerifyChecksum: true,
 			}
 			rc = mock_io.NewMockReadCloser(ctrl)
 			retRes = &service.RetrieveFileResult{
@@ -208,6 +341,9 @@ var _ = Describe("File Handler", func() {
 				MimeType:  "image/jpeg",
 				Extension: "jpg",
 				Data:      rc,
+				Checksum: file.Checksum{
+					Sha256: "file-sha256",
+				},
 			}
 			stream.
 				EXPECT().
@@ -387,10 +523,14 @@ var _ = Describe("File Handler", func() {
 					Times(1)
 
 				md := metadata.New(map[string]string{
-					"file_name":      retRes.Name,
-					"file_mimetype":  retRes.MimeType,
-					"file_extension": retRes.Extension,
-					"file_size":      fmt.Sprintf("%d", retRes.Size),
+					"file_name":            retRes.Name,
+					"file_mimetype":        retRes.MimeType,
+					"file_extension":       retRes.Extension,
+					"file_size":            fmt.Sprintf("%d", retRes.Size),
+					"file_checksum_sha256": retRes.Checksum.Sha256,
+					"file_checksum_md5":    retRes.Checksum.Md5,
+					"file_offset":          fmt.Sprintf("%d", retRes.Offset),
+					"file_length":          fmt.Sprintf("%d", retRes.Length),
 				})
 				stream.
 					EXPECT().
@@ -413,10 +553,14 @@ var _ = Describe("File Handler", func() {
 					Times(1)
 
 				md := metadata.New(map[string]string{
-					"file_name":      retRes.Name,
-					"file_mimetype":  retRes.MimeType,
-					"file_extension": retRes.Extension,
-					"file_size":      fmt.Sprintf("%d", retRes.Size),
+					"file_name":            retRes.Name,
+					"file_mimetype":        retRes.MimeType,
+					"file_extension":       retRes.Extension,
+					"file_size":            fmt.Sprintf("%d", retRes.Size),
+					"file_checksum_sha256": retRes.Checksum.Sha256,
+					"file_checksum_md5":    retRes.Checksum.Md5,
+					"file_offset":          fmt.Sprintf("%d", retRes.Offset),
+					"file_length":          fmt.Sprintf("%d", retRes.Length),
 				})
 				stream.
 					EXPECT().
@@ -451,10 +595,14 @@ var _ = Describe("File Handler", func() {
 					Times(1)
 
 				md := metadata.New(map[string]string{
-					"file_name":      retRes.Name,
-					"file_mimetype":  retRes.MimeType,
-					"file_extension": retRes.Extension,
-					"file_size":      fmt.Sprintf("%d", retRes.Size),
+					"file_name":            retRes.Name,
+					"file_mimetype":        retRes.MimeType,
+					"file_extension":       retRes.Extension,
+					"file_size":            fmt.Sprintf("%d", retRes.Size),
+					"file_checksum_sha256": retRes.Checksum.Sha256,
+					"file_checksum_md5":    retRes.Checksum.Md5,
+					"file_offset":          fmt.Sprintf("%d", retRes.Offset),
+					"file_length":          fmt.Sprintf("%d", retRes.Length),
 				})
 				stream.
 					EXPECT().
@@ -506,10 +654,14 @@ var _ = Describe("File Handler", func() {
 					Times(1)
 
 				md := metadata.New(map[string]string{
-					"file_name":      retRes.Name,
-					"file_mimetype":  retRes.MimeType,
-					"file_extension": retRes.Extension,
-					"file_size":      fmt.Sprintf("%d", retRes.Size),
+					"file_name":            retRes.Name,
+					"file_mimetype":        retRes.MimeType,
+					"file_extension":       retRes.Extension,
+					"file_size":            fmt.Sprintf("%d", retRes.Size),
+					"file_checksum_sha256": retRes.Checksum.Sha256,
+					"file_checksum_md5":    retRes.Checksum.Md5,
+					"file_offset":          fmt.Sprintf("%d", retRes.Offset),
+					"file_length":          fmt.Sprintf("%d", retRes.Length),
 				})
 				stream.
 					EXPECT().
@@ -561,10 +713,14 @@ var _ = Describe("File Handler", func() {
 					Times(1)
 
 				md := metadata.New(map[string]string{
-					"file_name":      retRes.Name,
-					"file_mimetype":  retRes.MimeType,
-					"file_extension": retRes.Extension,
-					"file_size":      fmt.Sprintf("%d", retRes.Size),
+					"file_name":            retRes.Name,
+					"file_mimetype":        retRes.MimeType,
+					"file_extension":       retRes.Extension,
+					"file_size":            fmt.Sprintf("%d", retRes.Size),
+					"file_checksum_sha256": retRes.Checksum.Sha256,
+					"file_checksum_md5":    retRes.Checksum.Md5,
+					"file_offset":          fmt.Sprintf("%d", retRes.Offset),
+					"file_length":          fmt.Sprintf("%d", retRes.Length),
 				})
 				stream.
 					EXPECT().
@@ -622,10 +778,14 @@ var _ = Describe("File Handler", func() {
 					Times(1)
 
 				md := metadata.New(map[string]string{
-					"file_name":      retRes.Name,
-					"file_mimetype":  retRes.MimeType,
-					"file_extension": retRes.Extension,
-					"file_size":      fmt.Sprintf("%d", retRes.Size),
+					"file_name":            retRes.Name,
+					"file_mimetype":        retRes.MimeType,
+					"file_extension":       retRes.Extension,
+					"file_size":            fmt.Sprintf("%d", retRes.Size),
+					"file_checksum_sha256": retRes.Checksum.Sha256,
+					"file_checksum_md5":    retRes.Checksum.Md5,
+					"file_offset":          fmt.Sprintf("%d", retRes.Offset),
+					"file_length":          fmt.Sprintf("%d", retRes.Length),
 				})
 				stream.
 					EXPECT().
@@ -683,10 +843,14 @@ var _ = Describe("File Handler", func() {
 					Times(1)
 
 				md := metadata.New(map[string]string{
-					"file_name":      retRes.Name,
-					"file_mimetype":  retRes.MimeType,
-					"file_extension": retRes.Extension,
-					"file_size":      fmt.Sprintf("%d", retRes.Size),
+					"file_name":            retRes.Name,
+					"file_mimetype":        retRes.MimeType,
+					"file_extension":       retRes.Extension,
+					"file_size":            fmt.Sprintf("%d", retRes.Size),
+					"file_checksum_sha256": retRes.Checksum.Sha256,
+					"file_checksum_md5":    retRes.Checksum.Md5,
+					"file_offset":          fmt.Sprintf("%d", retRes.Offset),
+					"file_length":          fmt.Sprintf("%d", retRes.Length),
 				})
 				stream.
 					EXPECT().
@@ -744,10 +908,14 @@ var _ = Describe("File Handler", func() {
 					Times(1)
 
 				md := metadata.New(map[string]string{
-					"file_name":      retRes.Name,
-					"file_mimetype":  retRes.MimeType,
-					"file_extension": retRes.Extension,
-					"file_size":      fmt.Sprintf("%d", retRes.Size),
+					"file_name":            retRes.Name,
+					"file_mimetype":        retRes.MimeType,
+					"file_extension":       retRes.Extension,
+					"file_size":            fmt.Sprintf("%d", retRes.Size),
+					"file_checksum_sha256": retRes.Checksum.Sha256,
+					"file_checksum_md5":    retRes.Checksum.Md5,
+					"file_offset":          fmt.Sprintf("%d", retRes.Offset),
+					"file_length":          fmt.Sprintf("%d", retRes.Length),
 				})
 				stream.
 					EXPECT().
@@ -821,10 +989,14 @@ var _ = Describe("File Handler", func() {
 					Times(1)
 
 				md := metadata.New(map[string]string{
-					"file_name":      retRes.Name,
-					"file_mimetype":  retRes.MimeType,
-					"file_extension": retRes.Extension,
-					"file_size":      fmt.Sprintf("%d", retRes.Size),
+					"file_name":            retRes.Name,
+					"file_mimetype":        retRes.MimeType,
+					"file_extension":       retRes.Extension,
+					"file_size":            fmt.Sprintf("%d", retRes.Size),
+					"file_checksum_sha256": retRes.Checksum.Sha256,
+					"file_checksum_md5":    retRes.Checksum.Md5,
+					"file_offset":          fmt.Sprintf("%d", retRes.Offset),
+					"file_length":          fmt.Sprintf("%d", retRes.Length),
 				})
 				stream.
 					EXPECT().
@@ -884,6 +1056,144 @@ var _ = Describe("File Handler", func() {
 		})
 	})
 
+	Context("RetrieveFileById function with range", Label("unit"), func() {
+		var (
+			handler     api.FileServiceServer
+			fileService *mock_service.MockFile
+			ctx         context.Context
+			stream      *mock_grpcapp.MockFileService_RetrieveFileByIdServer
+			p           *api.RetrieveFileByIdParam
+		)
+
+		BeforeEach(func() {
+			t := GinkgoT()
+			ctrl := gomock.NewController(t)
+			fileService = mock_service.NewMockFile(ctrl)
+			handler = grpchandler.NewFile(grpchandler.FileParam{
+				FileClient: fileService,
+				Config:     &grpchandler.FileConfig{},
+			})
+			ctx = context.Background()
+			stream = mock_grpcapp.NewMockFileService_RetrieveFileByIdServer(ctrl)
+			stream.
+				EXPECT().
+				Context().
+				Return(ctx).
+				AnyTimes()
+			p = &api.RetrieveFileByIdParam{
+				FileId:    "file-id",
+				Offset:    2,
+				Length:    5,
+				ChunkSize: 2,
+			}
+		})
+
+		When("chunk size is invalid", func() {
+			It("should return error", func() {
+				p.ChunkSize = 1048577
+				stream.
+					EXPECT().
+					Send(gomock.Eq(&api.RetrieveFileByIdResult{
+						Code:    1002,
+						Message: "chunk_size must be between 1 and 1048576",
+					})).
+					Return(nil).
+					Times(1)
+
+				err := handler.RetrieveFileById(p, stream)
+
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("range is not satisfiable", func() {
+			It("should return error", func() {
+				fileService.
+					EXPECT().
+					RetrieveFile(gomock.Eq(ctx), gomock.Eq(service.RetrieveFileParam{
+						FileId: "file-id",
+						Offset: 2,
+						Length: 5,
+					})).
+					Return(nil, &system.Error{
+						Code:    2005,
+						Message: "file range is not satisfiable",
+					}).
+					Times(1)
+
+				stream.
+					EXPECT().
+					Send(gomock.Eq(&api.RetrieveFileByIdResult{
+						Code:    2005,
+						Message: "file range is not satisfiable",
+					})).
+					Return(nil).
+					Times(1)
+
+				err := handler.RetrieveFileById(p, stream)
+
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("success retrieve ranged file", func() {
+			It("should send the range in chunks", func() {
+				fileService.
+					EXPECT().
+					RetrieveFile(gomock.Eq(ctx), gomock.Eq(service.RetrieveFileParam{
+						FileId: "file-id",
+						Offset: 2,
+						Length: 5,
+					})).
+					Return(&service.RetrieveFileResult{
+						Success: system.Success{
+							Code:    1000,
+							Message: "success retrieve file",
+						},
+						Name:      "file-name",
+						MimeType:  "image/jpeg",
+						Extension: "jpg",
+						Size:      10,
+						Data:      io.NopCloser(strings.NewReader("ntent")),
+						Offset:    2,
+						Length:    5,
+					}, nil).
+					Times(1)
+
+				stream.
+					EXPECT().
+					SendHeader(gomock.Eq(metadata.New(map[string]string{
+						"file_name":            "file-name",
+						"file_mimetype":        "image/jpeg",
+						"file_extension":       "jpg",
+						"file_size":            "10",
+						"file_checksum_sha256": "",
+						"file_checksum_md5":    "",
+						"file_offset":          "2",
+						"file_length":          "5",
+					}))).
+					Return(nil).
+					Times(1)
+
+				chunks := []byte{}
+				stream.
+					EXPECT().
+					Send(gomock.Any()).
+					DoAndReturn(func(res *api.RetrieveFileByIdResult) error {
+						Expect(len(res.Chunks)).To(BeNumerically("<=", 2))
+						chunks = append(chunks, res.Chunks...)
+						return nil
+					}).
+					Times(5)
+
+				err := handler.RetrieveFileById(p, stream)
+
+				Expect(err).To(BeNil())
+				Expect(string(chunks)).To(Equal("ntent"))
+			})
+		})
+	})
+
 	Context("UploadFile function", Label("unit"), func() {
 		var (
 			handler     api.FileServiceServer
@@ -906,6 +1216,12 @@ var _ = Describe("File Handler", func() {
 			ctx = mock_context.NewMockContext(ctrl)
 			currentTs = time.Now()
 			stream = mock_grpcapp.NewMockFileService_UploadFileServer(ctrl)
+
+			ctx.
+				EXPECT().
+				Value(gomock.Any()).
+				Return(nil).
+				AnyTimes()
 		})
 
 		When("failed send stream during action cancelled by client", func() {
@@ -1142,13 +1458,13 @@ var _ = Describe("File Handler", func() {
 					EXPECT().
 					Err().
 					Return(nil).
-					Times(3)
+					Times(1)
 
 				stream.
 					EXPECT().
 					Context().
 					Return(ctx).
-					Times(3)
+					Times(1)
 
 				infoParam := &api.UploadFileParam{
 					Data: &api.UploadFileParam_Info{
@@ -1165,23 +1481,6 @@ var _ = Describe("File Handler", func() {
 					Return(infoParam, nil).
 					Times(1)
 
-				chunkParam := &api.UploadFileParam{
-					Data: &api.UploadFileParam_Chunks{
-						Chunks: []byte{1, 2, 3},
-					},
-				}
-				stream.
-					EXPECT().
-					Recv().
-					Return(chunkParam, nil).
-					Times(1)
-
-				stream.
-					EXPECT().
-					Recv().
-					Return(nil, io.EOF).
-					Times(1)
-
 				stream.
 					EXPECT().
 					Context().
@@ -1193,13 +1492,13 @@ var _ = Describe("File Handler", func() {
 					UploadFile(gomock.Eq(ctx), gomock.Any()).
 					Return(nil, &system.Error{
 						Code:    1001,
-						Message: "network error",
+						Message: "disk error",
 					}).
 					Times(1)
 
 				failedRes := &api.UploadFileResult{
 					Code:    1001,
-					Message: "network error",
+					Message: "disk error",
 				}
 				stream.
 					EXPECT().
@@ -1219,13 +1518,13 @@ var _ = Describe("File Handler", func() {
 					EXPECT().
 					Err().
 					Return(nil).
-					Times(3)
+					Times(1)
 
 				stream.
 					EXPECT().
 					Context().
 					Return(ctx).
-					Times(3)
+					Times(1)
 
 				infoParam := &api.UploadFileParam{
 					Data: &api.UploadFileParam_Info{
@@ -1242,23 +1541,6 @@ var _ = Describe("File Handler", func() {
 					Return(infoParam, nil).
 					Times(1)
 
-				chunkParam := &api.UploadFileParam{
-					Data: &api.UploadFileParam_Chunks{
-						Chunks: []byte{1, 2, 3},
-					},
-				}
-				stream.
-					EXPECT().
-					Recv().
-					Return(chunkParam, nil).
-					Times(1)
-
-				stream.
-					EXPECT().
-					Recv().
-					Return(nil, io.EOF).
-					Times(1)
-
 				stream.
 					EXPECT().
 					Context().
@@ -1270,13 +1552,13 @@ var _ = Describe("File Handler", func() {
 					UploadFile(gomock.Eq(ctx), gomock.Any()).
 					Return(nil, &system.Error{
 						Code:    1001,
-						Message: "network error",
+						Message: "disk error",
 					}).
 					Times(1)
 
 				failedRes := &api.UploadFileResult{
 					Code:    1001,
-					Message: "network error",
+					Message: "disk error",
 				}
 				stream.
 					EXPECT().
@@ -1290,19 +1572,19 @@ var _ = Describe("File Handler", func() {
 			})
 		})
 
-		When("failed send stream during invalid data", func() {
+		When("client quota is exceeded", func() {
 			It("should return error", func() {
 				ctx.
 					EXPECT().
 					Err().
 					Return(nil).
-					Times(3)
+					Times(1)
 
 				stream.
 					EXPECT().
 					Context().
 					Return(ctx).
-					Times(3)
+					Times(2)
 
 				infoParam := &api.UploadFileParam{
 					Data: &api.UploadFileParam_Info{
@@ -1319,67 +1601,35 @@ var _ = Describe("File Handler", func() {
 					Return(infoParam, nil).
 					Times(1)
 
-				chunkParam := &api.UploadFileParam{
-					Data: &api.UploadFileParam_Chunks{
-						Chunks: []byte{1, 2, 3},
-					},
-				}
-				stream.
-					EXPECT().
-					Recv().
-					Return(chunkParam, nil).
-					Times(1)
-
-				stream.
-					EXPECT().
-					Recv().
-					Return(nil, io.EOF).
-					Times(1)
-
-				stream.
-					EXPECT().
-					Context().
-					Return(ctx).
-					Times(1)
-
 				fileService.
 					EXPECT().
 					UploadFile(gomock.Eq(ctx), gomock.Any()).
 					Return(nil, &system.Error{
-						Code:    1002,
-						Message: "invalid data",
+						Code:    2010,
+						Message: "quota exceeded: max stored size",
 					}).
 					Times(1)
 
-				failedRes := &api.UploadFileResult{
-					Code:    1002,
-					Message: "invalid data",
-				}
-				stream.
-					EXPECT().
-					SendAndClose(gomock.Eq(failedRes)).
-					Return(fmt.Errorf("network error")).
-					Times(1)
-
 				err := handler.UploadFile(stream)
 
-				Expect(err).To(Equal(fmt.Errorf("network error")))
+				Expect(grpc_status.Code(err)).To(Equal(codes.ResourceExhausted))
+				Expect(grpc_status.Convert(err).Message()).To(Equal("quota exceeded: max stored size"))
 			})
 		})
 
-		When("there is invalid data", func() {
+		When("failed send stream during success upload file", func() {
 			It("should return error", func() {
 				ctx.
 					EXPECT().
 					Err().
 					Return(nil).
-					Times(3)
+					Times(1)
 
 				stream.
 					EXPECT().
 					Context().
 					Return(ctx).
-					Times(3)
+					Times(1)
 
 				infoParam := &api.UploadFileParam{
 					Data: &api.UploadFileParam_Info{
@@ -1396,67 +1646,75 @@ var _ = Describe("File Handler", func() {
 					Return(infoParam, nil).
 					Times(1)
 
-				chunkParam := &api.UploadFileParam{
-					Data: &api.UploadFileParam_Chunks{
-						Chunks: []byte{1, 2, 3},
-					},
-				}
-				stream.
-					EXPECT().
-					Recv().
-					Return(chunkParam, nil).
-					Times(1)
-
-				stream.
-					EXPECT().
-					Recv().
-					Return(nil, io.EOF).
-					Times(1)
-
 				stream.
 					EXPECT().
 					Context().
 					Return(ctx).
 					Times(1)
 
+				uploadRes := &service.UploadFileResult{
+					Success: system.Success{
+						Code:    1000,
+						Message: "success upload file",
+					},
+					UniqueId:   "file-id",
+					Name:       "file-name",
+					Path:       "file/path",
+					Mimetype:   "file-mime-type",
+					Extension:  "jpeg",
+					Size:       100,
+					UploadedAt: currentTs,
+					Checksum: file.Checksum{
+						Sha256: "file-sha256",
+						Md5:    "file-md5",
+					},
+				}
 				fileService.
 					EXPECT().
 					UploadFile(gomock.Eq(ctx), gomock.Any()).
-					Return(nil, &system.Error{
-						Code:    1002,
-						Message: "invalid data",
-					}).
+					Return(uploadRes, nil).
 					Times(1)
 
-				failedRes := &api.UploadFileResult{
-					Code:    1002,
-					Message: "invalid data",
+				successRes := &api.UploadFileResult{
+					Code:    1000,
+					Message: "success upload file",
+					Data: &api.UploadFileData{
+						Id:             uploadRes.UniqueId,
+						Name:           uploadRes.Name,
+						Path:           uploadRes.Path,
+						Mimetype:       uploadRes.Mimetype,
+						Extension:      uploadRes.Extension,
+						Size:           uploadRes.Size,
+						UploadedAt:     uploadRes.UploadedAt.UnixMilli(),
+						ChecksumSha256: uploadRes.Checksum.Sha256,
+						ChecksumMd5:    uploadRes.Checksum.Md5,
+					},
 				}
 				stream.
 					EXPECT().
-					SendAndClose(gomock.Eq(failedRes)).
-					Return(nil).
+					SendAndClose(gomock.Eq(successRes)).
+					Return(fmt.Errorf("network error")).
 					Times(1)
 
 				err := handler.UploadFile(stream)
 
-				Expect(err).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("network error")))
 			})
 		})
 
-		When("failed send stream during success upload file", func() {
-			It("should return error", func() {
+		When("success upload file", func() {
+			It("should return result", func() {
 				ctx.
 					EXPECT().
 					Err().
 					Return(nil).
-					Times(3)
+					Times(1)
 
 				stream.
 					EXPECT().
 					Context().
 					Return(ctx).
-					Times(3)
+					Times(1)
 
 				infoParam := &api.UploadFileParam{
 					Data: &api.UploadFileParam_Info{
@@ -1473,23 +1731,6 @@ var _ = Describe("File Handler", func() {
 					Return(infoParam, nil).
 					Times(1)
 
-				chunkParam := &api.UploadFileParam{
-					Data: &api.UploadFileParam_Chunks{
-						Chunks: []byte{1, 2, 3},
-					},
-				}
-				stream.
-					EXPECT().
-					Recv().
-					Return(chunkParam, nil).
-					Times(1)
-
-				stream.
-					EXPECT().
-					Recv().
-					Return(nil, io.EOF).
-					Times(1)
-
 				stream.
 					EXPECT().
 					Context().
@@ -1508,6 +1749,10 @@ var _ = Describe("File Handler", func() {
 					Extension:  "jpeg",
 					Size:       100,
 					UploadedAt: currentTs,
+					Checksum: file.Checksum{
+						Sha256: "file-sha256",
+						Md5:    "file-md5",
+					},
 				}
 				fileService.
 					EXPECT().
@@ -1515,123 +1760,228 @@ var _ = Describe("File Handler", func() {
 					Return(uploadRes, nil).
 					Times(1)
 
-				failedRes := &api.UploadFileResult{
+				successRes := &api.UploadFileResult{
 					Code:    1000,
 					Message: "success upload file",
 					Data: &api.UploadFileData{
-						Id:         uploadRes.UniqueId,
-						Name:       uploadRes.Name,
-						Path:       uploadRes.Path,
-						Mimetype:   uploadRes.Mimetype,
-						Extension:  uploadRes.Extension,
-						Size:       uploadRes.Size,
-						UploadedAt: uploadRes.UploadedAt.UnixMilli(),
+						Id:             uploadRes.UniqueId,
+						Name:           uploadRes.Name,
+						Path:           uploadRes.Path,
+						Mimetype:       uploadRes.Mimetype,
+						Extension:      uploadRes.Extension,
+						Size:           uploadRes.Size,
+						UploadedAt:     uploadRes.UploadedAt.UnixMilli(),
+						ChecksumSha256: uploadRes.Checksum.Sha256,
+						ChecksumMd5:    uploadRes.Checksum.Md5,
 					},
 				}
 				stream.
 					EXPECT().
-					SendAndClose(gomock.Eq(failedRes)).
-					Return(fmt.Errorf("network error")).
+					SendAndClose(gomock.Eq(successRes)).
+					Return(nil).
 					Times(1)
 
 				err := handler.UploadFile(stream)
 
-				Expect(err).To(Equal(fmt.Errorf("network error")))
+				Expect(err).To(BeNil())
 			})
 		})
+	})
 
-		When("success upload file", func() {
-			It("should return result", func() {
-				ctx.
-					EXPECT().
-					Err().
-					Return(nil).
-					Times(3)
-
-				stream.
-					EXPECT().
-					Context().
-					Return(ctx).
-					Times(3)
+	Context("SearchFile function", Label("unit"), func() {
+		var (
+			handler     api.FileServiceServer
+			fileService *mock_service.MockFile
+			ctx         context.Context
+			currentTs   time.Time
+			p           *api.SearchFileParam
+			searchParam service.SearchFileParam
+			searchRes   *service.SearchFileResult
+		)
 
-				infoParam := &api.UploadFileParam{
-					Data: &api.UploadFileParam_Info{
-						Info: &api.UploadFileInfo{
-							Name:      "file-name",
-							Mimetype:  "file-mimetype",
-							Extension: "file-extension",
+		BeforeEach(func() {
+			t := GinkgoT()
+			ctrl := gomock.NewController(t)
+			fileService = mock_service.NewMockFile(ctrl)
+			handler = grpchandler.NewFile(grpchandler.FileParam{
+				FileClient: fileService,
+				Config:     &grpchandler.FileConfig{},
+			})
+			ctx = context.Background()
+			currentTs = time.Now().UTC()
+			p = &api.SearchFileParam{
+				Keyword: "dolpin",
+				Pagination: &api.SearchFilePagination{
+					TotalItems: 24,
+					Page:       2,
+				},
+				Filter: &api.SearchFileFilter{
+					MimetypeIn:    []string{"image/jpeg"},
+					ExtensionIn:   []string{"jpg"},
+					StatusIn:      []string{"available"},
+					SizeGte:       typeconv.Int64(100),
+					SizeLte:       typeconv.Int64(200),
+					UploadedAtGte: typeconv.Int64(1000),
+					UploadedAtLte: typeconv.Int64(2000),
+				},
+				Sort: &api.SearchFileSort{
+					Field: "size",
+					Order: "asc",
+				},
+			}
+			searchParam = service.SearchFileParam{
+				Keyword:      "dolpin",
+				TotalItems:   24,
+				Page:         2,
+				Mimetypes:    []string{"image/jpeg"},
+				Extensions:   []string{"jpg"},
+				MinSize:      typeconv.Int64(100),
+				MaxSize:      typeconv.Int64(200),
+				UploadedFrom: typeconv.Time(time.UnixMilli(1000).UTC()),
+				UploadedTo:   typeconv.Time(time.UnixMilli(2000).UTC()),
+				Statuses:     []string{"available"},
+				SortBy:       "size",
+				SortOrder:    "asc",
+			}
+			searchRes = &service.SearchFileResult{
+				Success: system.Success{
+					Code:    1000,
+					Message: "success search file",
+				},
+				Items: []service.SearchFileItem{
+					{
+						UniqueId:  "id-1",
+						Name:      "dolpin-1",
+						Path:      "/storage/id-1.jpg",
+						MimeType:  "image/jpeg",
+						Extension: "jpg",
+						Size:      100,
+						Checksum: file.Checksum{
+							Sha256: "sha256-1",
+							Md5:    "md5-1",
 						},
+						UploadedAt: currentTs,
+						DeletedAt:  &currentTs,
 					},
-				}
-				stream.
+				},
+				Summary: service.SearchFileSummary{
+					TotalItems: 1,
+					Page:       2,
+				},
+			}
+		})
+
+		When("there is invalid param", func() {
+			It("should return error", func() {
+				fileService.
 					EXPECT().
-					Recv().
-					Return(infoParam, nil).
+					SearchFile(gomock.Eq(ctx), gomock.Eq(searchParam)).
+					Return(nil, &system.Error{
+						Code:    1002,
+						Message: "invalid data",
+					}).
 					Times(1)
 
-				chunkParam := &api.UploadFileParam{
-					Data: &api.UploadFileParam_Chunks{
-						Chunks: []byte{1, 2, 3},
-					},
+				res, err := handler.SearchFile(ctx, p)
+
+				r := &api.SearchFileResult{
+					Code:    1002,
+					Message: "invalid data",
 				}
-				stream.
-					EXPECT().
-					Recv().
-					Return(chunkParam, nil).
-					Times(1)
+				Expect(res).To(Equal(r))
+				Expect(err).To(BeNil())
+			})
+		})
 
-				stream.
+		When("failed search file", func() {
+			It("should return error", func() {
+				fileService.
 					EXPECT().
-					Recv().
-					Return(nil, io.EOF).
+					SearchFile(gomock.Eq(ctx), gomock.Eq(searchParam)).
+					Return(nil, &system.Error{
+						Code:    1001,
+						Message: "db error",
+					}).
 					Times(1)
 
-				stream.
-					EXPECT().
-					Context().
-					Return(ctx).
-					Times(1)
+				res, err := handler.SearchFile(ctx, p)
 
-				uploadRes := &service.UploadFileResult{
+				r := &api.SearchFileResult{
+					Code:    1001,
+					Message: "db error",
+				}
+				Expect(res).To(Equal(r))
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("parameter is empty", func() {
+			It("should search with the default param", func() {
+				searchRes := &service.SearchFileResult{
 					Success: system.Success{
 						Code:    1000,
-						Message: "success upload file",
+						Message: "success search file",
+					},
+					Items: []service.SearchFileItem{},
+					Summary: service.SearchFileSummary{
+						TotalItems: 0,
 					},
-					UniqueId:   "file-id",
-					Name:       "file-name",
-					Path:       "file/path",
-					Mimetype:   "file-mime-type",
-					Extension:  "jpeg",
-					Size:       100,
-					UploadedAt: currentTs,
 				}
 				fileService.
 					EXPECT().
-					UploadFile(gomock.Eq(ctx), gomock.Any()).
-					Return(uploadRes, nil).
+					SearchFile(gomock.Eq(ctx), gomock.Eq(service.SearchFileParam{})).
+					Return(searchRes, nil).
 					Times(1)
 
-				failedRes := &api.UploadFileResult{
+				res, err := handler.SearchFile(ctx, &api.SearchFileParam{})
+
+				r := &api.SearchFileResult{
 					Code:    1000,
-					Message: "success upload file",
-					Data: &api.UploadFileData{
-						Id:         uploadRes.UniqueId,
-						Name:       uploadRes.Name,
-						Path:       uploadRes.Path,
-						Mimetype:   uploadRes.Mimetype,
-						Extension:  uploadRes.Extension,
-						Size:       uploadRes.Size,
-						UploadedAt: uploadRes.UploadedAt.UnixMilli(),
+					Message: "success search file",
+					Data: &api.SearchFileData{
+						Items:   []*api.SearchFileItem{},
+						Summary: &api.SearchFileSummary{},
 					},
 				}
-				stream.
+				Expect(res).To(Equal(r))
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("success search file", func() {
+			It("should return result", func() {
+				fileService.
 					EXPECT().
-					SendAndClose(gomock.Eq(failedRes)).
-					Return(nil).
+					SearchFile(gomock.Eq(ctx), gomock.Eq(searchParam)).
+					Return(searchRes, nil).
 					Times(1)
 
-				err := handler.UploadFile(stream)
+				res, err := handler.SearchFile(ctx, p)
 
+				r := &api.SearchFileResult{
+					Code:    1000,
+					Message: "success search file",
+					Data: &api.SearchFileData{
+						Items: []*api.SearchFileItem{
+							{
+								Id:             "id-1",
+								Name:           "dolpin-1",
+								Mimetype:       "image/jpeg",
+								Extension:      "jpg",
+								Size:           100,
+								UploadedAt:     currentTs.UnixMilli(),
+								DeletedAt:      typeconv.Int64(currentTs.UnixMilli()),
+								ChecksumSha256: "sha256-1",
+								ChecksumMd5:    "md5-1",
+							},
+						},
+						Summary: &api.SearchFileSummary{
+							TotalItems: 1,
+							Page:       2,
+						},
+					},
+				}
+				Expect(res).To(Equal(r))
 				Expect(err).To(BeNil())
 			})
 		})
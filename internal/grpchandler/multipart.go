@@ -0,0 +1,185 @@
+package grpchandler
+
+import (
+	"context"
+
+	"github.com/go-seidon/hippo/api/grpcapp"
+	"github.com/go-seidon/hippo/internal/service"
+	"github.com/go-seidon/provider/status"
+)
+
+func (h *fileHandler) InitiateMultipartUpload(ctx context.Context, p *grpcapp.InitiateMultipartUploadParam) (*grpcapp.InitiateMultipartUploadResult, error) {
+	initiation, err := h.multipartClient.InitiateMultipart(ctx, service.InitiateMultipartParam{
+		Name:      p.Name,
+		Mimetype:  p.Mimetype,
+		Extension: p.Extension,
+	})
+	if err != nil {
+		res := &grpcapp.InitiateMultipartUploadResult{
+			Code:    err.Code,
+			Message: err.Message,
+		}
+		return res, nil
+	}
+
+	res := &grpcapp.InitiateMultipartUploadResult{
+		Code:    initiation.Success.Code,
+		Message: initiation.Success.Message,
+		Data: &grpcapp.InitiateMultipartUploadData{
+			UploadId:  initiation.UniqueId,
+			CreatedAt: initiation.CreatedAt.UnixMilli(),
+		},
+	}
+	return res, nil
+}
+
+func (h *fileHandler) UploadPart(stream grpcapp.FileService_UploadPartServer) error {
+	reader := NewPartReader(PartReaderParam{
+		Stream:  stream,
+		MaxSize: h.config.UploadFormSize,
+	})
+
+	partInfo, err := reader.ReadInfo()
+	if err != nil {
+		code := status.ACTION_FAILED
+		if err == ErrorPartInfoMissing {
+			code = status.INVALID_PARAM
+		}
+		err = stream.SendAndClose(&grpcapp.UploadPartResult{
+			Code:    code,
+			Message: err.Error(),
+		})
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	upload, uerr := h.multipartClient.UploadPart(stream.Context(), service.UploadPartParam{
+		MultipartId: partInfo.UploadId,
+		PartNumber:  partInfo.PartNumber,
+		Reader:      reader,
+	})
+	if uerr != nil {
+		err := stream.SendAndClose(&grpcapp.UploadPartResult{
+			Code:    uerr.Code,
+			Message: uerr.Message,
+		})
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	err = stream.SendAndClose(&grpcapp.UploadPartResult{
+		Code:    upload.Success.Code,
+		Message: upload.Success.Message,
+		Data: &grpcapp.UploadPartData{
+			PartNumber:     upload.PartNumber,
+			Size:           upload.Size,
+			ChecksumSha256: upload.ChecksumSha256,
+			UploadedAt:     upload.UploadedAt.UnixMilli(),
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (h *fileHandler) ListParts(ctx context.Context, p *grpcapp.ListPartsParam) (*grpcapp.ListPartsResult, error) {
+	list, err := h.multipartClient.ListPart(ctx, service.ListPartParam{
+		MultipartId: p.UploadId,
+	})
+	if err != nil {
+		res := &grpcapp.ListPartsResult{
+			Code:    err.Code,
+			Message: err.Message,
+		}
+		return res, nil
+	}
+
+	parts := []*grpcapp.UploadPartData{}
+	for _, part := range list.Items {
+		parts = append(parts, &grpcapp.UploadPartData{
+			PartNumber:     part.PartNumber,
+			Size:           part.Size,
+			ChecksumSha256: part.ChecksumSha256,
+			UploadedAt:     part.UploadedAt.UnixMilli(),
+		})
+	}
+
+	res := &grpcapp.ListPartsResult{
+		Code:    list.Success.Code,
+		Message: list.Success.Message,
+		Data: &grpcapp.ListPartsData{
+			Parts: parts,
+		},
+	}
+	return res, nil
+}
+
+func (h *fileHandler) CompleteMultipartUpload(ctx context.Context, p *grpcapp.CompleteMultipartUploadParam) (*grpcapp.CompleteMultipartUploadResult, error) {
+	parts := []service.CompletePartParam{}
+	for _, part := range p.Parts {
+		parts = append(parts, service.CompletePartParam{
+			PartNumber:     part.PartNumber,
+			ChecksumSha256: part.ChecksumSha256,
+		})
+	}
+
+	completion, err := h.multipartClient.CompleteMultipart(ctx, service.CompleteMultipartParam{
+		MultipartId: p.UploadId,
+		Parts:       parts,
+	})
+	if err != nil {
+		if qerr := quotaError(err); qerr != nil {
+			return nil, qerr
+		}
+
+		res := &grpcapp.CompleteMultipartUploadResult{
+			Code:    err.Code,
+			Message: err.Message,
+		}
+		return res, nil
+	}
+
+	res := &grpcapp.CompleteMultipartUploadResult{
+		Code:    completion.Success.Code,
+		Message: completion.Success.Message,
+		Data: &grpcapp.UploadFileData{
+			Id:             completion.UniqueId,
+			Name:           completion.Name,
+			Path:           completion.Path,
+			Mimetype:       completion.Mimetype,
+			Extension:      completion.Extension,
+			Size:           completion.Size,
+			UploadedAt:     completion.UploadedAt.UnixMilli(),
+			ChecksumSha256: completion.Checksum.Sha256,
+			ChecksumMd5:    completion.Checksum.Md5,
+		},
+	}
+	return res, nil
+}
+
+func (h *fileHandler) AbortMultipartUpload(ctx context.Context, p *grpcapp.AbortMultipartUploadParam) (*grpcapp.AbortMultipartUploadResult, error) {
+	abortion, err := h.multipartClient.AbortMultipart(ctx, service.AbortMultipartParam{
+		MultipartId: p.UploadId,
+	})
+	if err != nil {
+		res := &grpcapp.AbortMultipartUploadResult{
+			Code:    err.Code,
+			Message: err.Message,
+		}
+		return res, nil
+	}
+
+	res := &grpcapp.AbortMultipartUploadResult{
+		Code:    abortion.Success.Code,
+		Message: abortion.Success.Message,
+		Data: &grpcapp.AbortMultipartUploadData{
+			AbortedAt: abortion.AbortedAt.UnixMilli(),
+		},
+	}
+	return res, nil
+}
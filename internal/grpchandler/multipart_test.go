@@ -0,0 +1,524 @@
+package grpchandler_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	api "github.com/go-seidon/hippo/api/grpcapp"
+	mock_grpcapp "github.com/go-seidon/hippo/api/grpcapp/mock"
+	"github.com/go-seidon/hippo/internal/file"
+	"github.com/go-seidon/hippo/internal/grpchandler"
+	"github.com/go-seidon/hippo/internal/service"
+	mock_service "github.com/go-seidon/hippo/internal/service/mock"
+	"github.com/go-seidon/provider/system"
+	"github.com/golang/mock/gomock"
+	"google.golang.org/grpc/codes"
+	grpc_status "google.golang.org/grpc/status"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Multipart Handler", func() {
+	var (
+		handler          api.FileServiceServer
+		multipartService *mock_service.MockMultipart
+		ctx              context.Context
+		currentTs        time.Time
+	)
+
+	BeforeEach(func() {
+		t := GinkgoT()
+		ctrl := gomock.NewController(t)
+		multipartService = mock_service.NewMockMultipart(ctrl)
+		handler = grpchandler.NewFile(grpchandler.FileParam{
+			MultipartClient: multipartService,
+			Config: &grpchandler.FileConfig{
+				UploadFormSize: 100,
+			},
+		})
+		ctx = context.Background()
+		currentTs = time.Now()
+	})
+
+	Context("InitiateMultipartUpload function", Label("unit"), func() {
+		var (
+			p         *api.InitiateMultipartUploadParam
+			initParam service.InitiateMultipartParam
+		)
+
+		BeforeEach(func() {
+			p = &api.InitiateMultipartUploadParam{
+				Name:      "dolphin",
+				Mimetype:  "image/jpeg",
+				Extension: "jpg",
+			}
+			initParam = service.InitiateMultipartParam{
+				Name:      "dolphin",
+				Mimetype:  "image/jpeg",
+				Extension: "jpg",
+			}
+		})
+
+		When("failed initiate multipart", func() {
+			It("should return error", func() {
+				multipartService.
+					EXPECT().
+					InitiateMultipart(gomock.Eq(ctx), gomock.Eq(initParam)).
+					Return(nil, &system.Error{
+						Code:    1001,
+						Message: "db error",
+					}).
+					Times(1)
+
+				res, err := handler.InitiateMultipartUpload(ctx, p)
+
+				Expect(err).To(BeNil())
+				Expect(res).To(Equal(&api.InitiateMultipartUploadResult{
+					Code:    1001,
+					Message: "db error",
+				}))
+			})
+		})
+
+		When("success initiate multipart", func() {
+			It("should return result", func() {
+				multipartService.
+					EXPECT().
+					InitiateMultipart(gomock.Eq(ctx), gomock.Eq(initParam)).
+					Return(&service.InitiateMultipartResult{
+						Success: system.Success{
+							Code:    1000,
+							Message: "success initiate multipart",
+						},
+						UniqueId:  "mock-multipart-id",
+						CreatedAt: currentTs,
+					}, nil).
+					Times(1)
+
+				res, err := handler.InitiateMultipartUpload(ctx, p)
+
+				Expect(err).To(BeNil())
+				Expect(res).To(Equal(&api.InitiateMultipartUploadResult{
+					Code:    1000,
+					Message: "success initiate multipart",
+					Data: &api.InitiateMultipartUploadData{
+						UploadId:  "mock-multipart-id",
+						CreatedAt: currentTs.UnixMilli(),
+					},
+				}))
+			})
+		})
+	})
+
+	Context("UploadPart function", Label("unit"), func() {
+		var (
+			stream     *mock_grpcapp.MockFileService_UploadPartServer
+			infoParam  *api.UploadPartParam
+			chunkParam *api.UploadPartParam
+		)
+
+		BeforeEach(func() {
+			t := GinkgoT()
+			ctrl := gomock.NewController(t)
+			stream = mock_grpcapp.NewMockFileService_UploadPartServer(ctrl)
+			stream.
+				EXPECT().
+				Context().
+				Return(ctx).
+				AnyTimes()
+			infoParam = &api.UploadPartParam{
+				Data: &api.UploadPartParam_Info{
+					Info: &api.UploadPartInfo{
+						UploadId:   "mock-multipart-id",
+						PartNumber: 1,
+					},
+				},
+			}
+			chunkParam = &api.UploadPartParam{
+				Data: &api.UploadPartParam_Chunks{
+					Chunks: []byte{1, 2, 3},
+				},
+			}
+		})
+
+		When("part info is not specified", func() {
+			It("should return error", func() {
+				stream.
+					EXPECT().
+					Recv().
+					Return(chunkParam, nil).
+					Times(1)
+
+				stream.
+					EXPECT().
+					SendAndClose(gomock.Eq(&api.UploadPartResult{
+						Code:    1002,
+						Message: "part info is not specified",
+					})).
+					Return(nil).
+					Times(1)
+
+				err := handler.UploadPart(stream)
+
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("failed receive stream", func() {
+			It("should return error", func() {
+				stream.
+					EXPECT().
+					Recv().
+					Return(nil, fmt.Errorf("client cancelled")).
+					Times(1)
+
+				stream.
+					EXPECT().
+					SendAndClose(gomock.Eq(&api.UploadPartResult{
+						Code:    1001,
+						Message: "client cancelled",
+					})).
+					Return(fmt.Errorf("network error")).
+					Times(1)
+
+				err := handler.UploadPart(stream)
+
+				Expect(err).To(Equal(fmt.Errorf("network error")))
+			})
+		})
+
+		When("failed upload part", func() {
+			It("should return error", func() {
+				stream.
+					EXPECT().
+					Recv().
+					Return(infoParam, nil).
+					Times(1)
+
+				multipartService.
+					EXPECT().
+					UploadPart(gomock.Eq(ctx), gomock.Any()).
+					Return(nil, &system.Error{
+						Code:    1004,
+						Message: "multipart is not found",
+					}).
+					Times(1)
+
+				stream.
+					EXPECT().
+					SendAndClose(gomock.Eq(&api.UploadPartResult{
+						Code:    1004,
+						Message: "multipart is not found",
+					})).
+					Return(nil).
+					Times(1)
+
+				err := handler.UploadPart(stream)
+
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("success upload part", func() {
+			It("should return result", func() {
+				gomock.InOrder(
+					stream.EXPECT().Recv().Return(infoParam, nil),
+					stream.EXPECT().Recv().Return(chunkParam, nil),
+					stream.EXPECT().Recv().Return(nil, io.EOF),
+				)
+
+				multipartService.
+					EXPECT().
+					UploadPart(gomock.Eq(ctx), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, p service.UploadPartParam) (*service.UploadPartResult, *system.Error) {
+						data, err := io.ReadAll(p.Reader)
+						Expect(err).To(BeNil())
+						Expect(data).To(Equal([]byte{1, 2, 3}))
+						Expect(p.MultipartId).To(Equal("mock-multipart-id"))
+						Expect(p.PartNumber).To(Equal(int32(1)))
+
+						return &service.UploadPartResult{
+							Success: system.Success{
+								Code:    1000,
+								Message: "success upload part",
+							},
+							PartNumber:     1,
+							Size:           3,
+							ChecksumSha256: "mock-sha256",
+							UploadedAt:     currentTs,
+						}, nil
+					}).
+					Times(1)
+
+				stream.
+					EXPECT().
+					SendAndClose(gomock.Eq(&api.UploadPartResult{
+						Code:    1000,
+						Message: "success upload part",
+						Data: &api.UploadPartData{
+							PartNumber:     1,
+							Size:           3,
+							ChecksumSha256: "mock-sha256",
+							UploadedAt:     currentTs.UnixMilli(),
+						},
+					})).
+					Return(nil).
+					Times(1)
+
+				err := handler.UploadPart(stream)
+
+				Expect(err).To(BeNil())
+			})
+		})
+	})
+
+	Context("ListParts function", Label("unit"), func() {
+		var (
+			p *api.ListPartsParam
+		)
+
+		BeforeEach(func() {
+			p = &api.ListPartsParam{
+				UploadId: "mock-multipart-id",
+			}
+		})
+
+		When("failed list part", func() {
+			It("should return error", func() {
+				multipartService.
+					EXPECT().
+					ListPart(gomock.Eq(ctx), gomock.Eq(service.ListPartParam{
+						MultipartId: "mock-multipart-id",
+					})).
+					Return(nil, &system.Error{
+						Code:    1004,
+						Message: "multipart is not found",
+					}).
+					Times(1)
+
+				res, err := handler.ListParts(ctx, p)
+
+				Expect(err).To(BeNil())
+				Expect(res).To(Equal(&api.ListPartsResult{
+					Code:    1004,
+					Message: "multipart is not found",
+				}))
+			})
+		})
+
+		When("success list part", func() {
+			It("should return result", func() {
+				multipartService.
+					EXPECT().
+					ListPart(gomock.Eq(ctx), gomock.Any()).
+					Return(&service.ListPartResult{
+						Success: system.Success{
+							Code:    1000,
+							Message: "success list part",
+						},
+						Items: []service.ListPartItem{
+							{
+								PartNumber:     1,
+								Size:           3,
+								ChecksumSha256: "mock-sha256",
+								UploadedAt:     currentTs,
+							},
+						},
+					}, nil).
+					Times(1)
+
+				res, err := handler.ListParts(ctx, p)
+
+				Expect(err).To(BeNil())
+				Expect(res).To(Equal(&api.ListPartsResult{
+					Code:    1000,
+					Message: "success list part",
+					Data: &api.ListPartsData{
+						Parts: []*api.UploadPartData{
+							{
+								PartNumber:     1,
+								Size:           3,
+								ChecksumSha256: "mock-sha256",
+								UploadedAt:     currentTs.UnixMilli(),
+							},
+						},
+					},
+				}))
+			})
+		})
+	})
+
+	Context("CompleteMultipartUpload function", Label("unit"), func() {
+		var (
+			p             *api.CompleteMultipartUploadParam
+			completeParam service.CompleteMultipartParam
+		)
+
+		BeforeEach(func() {
+			p = &api.CompleteMultipartUploadParam{
+				UploadId: "mock-multipart-id",
+				Parts: []*api.CompletePart{
+					{PartNumber: 1, ChecksumSha256: "sha256-1"},
+					{PartNumber: 2, ChecksumSha256: "sha256-2"},
+				},
+			}
+			completeParam = service.CompleteMultipartParam{
+				MultipartId: "mock-multipart-id",
+				Parts: []service.CompletePartParam{
+					{PartNumber: 1, ChecksumSha256: "sha256-1"},
+					{PartNumber: 2, ChecksumSha256: "sha256-2"},
+				},
+			}
+		})
+
+		When("failed complete multipart", func() {
+			It("should return error", func() {
+				multipartService.
+					EXPECT().
+					CompleteMultipart(gomock.Eq(ctx), gomock.Eq(completeParam)).
+					Return(nil, &system.Error{
+						Code:    2004,
+						Message: "multipart part is not matched",
+					}).
+					Times(1)
+
+				res, err := handler.CompleteMultipartUpload(ctx, p)
+
+				Expect(err).To(BeNil())
+				Expect(res).To(Equal(&api.CompleteMultipartUploadResult{
+					Code:    2004,
+					Message: "multipart part is not matched",
+				}))
+			})
+		})
+
+		When("file size quota is exceeded", func() {
+			It("should return error", func() {
+				multipartService.
+					EXPECT().
+					CompleteMultipart(gomock.Eq(ctx), gomock.Eq(completeParam)).
+					Return(nil, &system.Error{
+						Code:    2011,
+						Message: "file size quota is exceeded",
+					}).
+					Times(1)
+
+				res, err := handler.CompleteMultipartUpload(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(grpc_status.Code(err)).To(Equal(codes.ResourceExhausted))
+			})
+		})
+
+		When("success complete multipart", func() {
+			It("should return result", func() {
+				multipartService.
+					EXPECT().
+					CompleteMultipart(gomock.Eq(ctx), gomock.Eq(completeParam)).
+					Return(&service.CompleteMultipartResult{
+						Success: system.Success{
+							Code:    1000,
+							Message: "success complete multipart",
+						},
+						UniqueId:   "mock-file-id",
+						Name:       "dolphin",
+						Path:       "temp/mock-file-id.jpg",
+						Mimetype:   "image/jpeg",
+						Extension:  "jpg",
+						Size:       6,
+						UploadedAt: currentTs,
+						Checksum: file.Checksum{
+							Sha256: "mock-sha256",
+						},
+						CompletedAt: currentTs,
+					}, nil).
+					Times(1)
+
+				res, err := handler.CompleteMultipartUpload(ctx, p)
+
+				Expect(err).To(BeNil())
+				Expect(res).To(Equal(&api.CompleteMultipartUploadResult{
+					Code:    1000,
+					Message: "success complete multipart",
+					Data: &api.UploadFileData{
+						Id:             "mock-file-id",
+						Name:           "dolphin",
+						Path:           "temp/mock-file-id.jpg",
+						Mimetype:       "image/jpeg",
+						Extension:      "jpg",
+						Size:           6,
+						UploadedAt:     currentTs.UnixMilli(),
+						ChecksumSha256: "mock-sha256",
+					},
+				}))
+			})
+		})
+	})
+
+	Context("AbortMultipartUpload function", Label("unit"), func() {
+		var (
+			p          *api.AbortMultipartUploadParam
+			abortParam service.AbortMultipartParam
+		)
+
+		BeforeEach(func() {
+			p = &api.AbortMultipartUploadParam{
+				UploadId: "mock-multipart-id",
+			}
+			abortParam = service.AbortMultipartParam{
+				MultipartId: "mock-multipart-id",
+			}
+		})
+
+		When("failed abort multipart", func() {
+			It("should return error", func() {
+				multipartService.
+					EXPECT().
+					AbortMultipart(gomock.Eq(ctx), gomock.Eq(abortParam)).
+					Return(nil, &system.Error{
+						Code:    2003,
+						Message: "multipart is already completed",
+					}).
+					Times(1)
+
+				res, err := handler.AbortMultipartUpload(ctx, p)
+
+				Expect(err).To(BeNil())
+				Expect(res).To(Equal(&api.AbortMultipartUploadResult{
+					Code:    2003,
+					Message: "multipart is already completed",
+				}))
+			})
+		})
+
+		When("success abort multipart", func() {
+			It("should return result", func() {
+				multipartService.
+					EXPECT().
+					AbortMultipart(gomock.Eq(ctx), gomock.Eq(abortParam)).
+					Return(&service.AbortMultipartResult{
+						Success: system.Success{
+							Code:    1000,
+							Message: "success abort multipart",
+						},
+						AbortedAt: currentTs,
+					}, nil).
+					Times(1)
+
+				res, err := handler.AbortMultipartUpload(ctx, p)
+
+				Expect(err).To(BeNil())
+				Expect(res).To(Equal(&api.AbortMultipartUploadResult{
+					Code:    1000,
+					Message: "success abort multipart",
+					Data: &api.AbortMultipartUploadData{
+						AbortedAt: currentTs.UnixMilli(),
+					},
+				}))
+			})
+		})
+	})
+})
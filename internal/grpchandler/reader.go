@@ -0,0 +1,175 @@
+package grpchandler
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/go-seidon/hippo/api/grpcapp"
+)
+
+type chunkParam interface {
+	GetChunks() []byte
+}
+
+// chunkReader reads the chunks directly from the client stream
+// so the content is never buffered as a whole
+type chunkReader struct {
+	ctx     context.Context
+	recvFn  func() (chunkParam, error)
+	maxSize int64
+	size    int64
+	chunks  []byte
+	eof     bool
+}
+
+// @note: the first message is expected to contain the info,
+// nil param is returned when the stream is closed without any message
+func (r *chunkReader) first() (chunkParam, error) {
+	param, err := r.recv()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			r.eof = true
+			return nil, nil
+		}
+		return nil, err
+	}
+	return param, nil
+}
+
+func (r *chunkReader) Read(b []byte) (int, error) {
+	for len(r.chunks) == 0 {
+		if r.eof {
+			return 0, io.EOF
+		}
+
+		param, err := r.recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				r.eof = true
+				continue
+			}
+			return 0, err
+		}
+
+		err = r.append(param.GetChunks())
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	n := copy(b, r.chunks)
+	r.chunks = r.chunks[n:]
+	return n, nil
+}
+
+func (r *chunkReader) recv() (chunkParam, error) {
+	err := r.ctx.Err()
+	if err != nil {
+		return nil, err
+	}
+	return r.recvFn()
+}
+
+func (r *chunkReader) append(chunks []byte) error {
+	r.size += int64(len(chunks))
+	if r.size > r.maxSize {
+		return ErrorFileTooLarge
+	}
+	r.chunks = chunks
+	return nil
+}
+
+// uploadReader reads the uploaded file info and chunks from the upload file stream
+type uploadReader struct {
+	chunkReader
+}
+
+// @note: file info is expected to be sent before the chunks,
+// chunk received before the info is kept and returned by the next read
+func (r *uploadReader) ReadInfo() (*grpcapp.UploadFileInfo, error) {
+	info := &grpcapp.UploadFileInfo{}
+	param, err := r.first()
+	if err != nil {
+		return nil, err
+	}
+	if param == nil {
+		return info, nil
+	}
+
+	p := param.(*grpcapp.UploadFileParam)
+	if p.GetInfo() != nil {
+		info = p.GetInfo()
+		return info, nil
+	}
+
+	err = r.append(p.GetChunks())
+	if err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+type UploadReaderParam struct {
+	Stream  grpcapp.FileService_UploadFileServer
+	MaxSize int64
+}
+
+func NewUploadReader(p UploadReaderParam) *uploadReader {
+	return &uploadReader{
+		chunkReader: chunkReader{
+			ctx: p.Stream.Context(),
+			recvFn: func() (chunkParam, error) {
+				param, err := p.Stream.Recv()
+				if err != nil {
+					return nil, err
+				}
+				return param, nil
+			},
+			maxSize: p.MaxSize,
+		},
+	}
+}
+
+// partReader reads the part info and chunks from the upload part stream
+type partReader struct {
+	chunkReader
+}
+
+// @note: part info is required to be sent before the chunks
+func (r *partReader) ReadInfo() (*grpcapp.UploadPartInfo, error) {
+	param, err := r.first()
+	if err != nil {
+		return nil, err
+	}
+	if param == nil {
+		return nil, ErrorPartInfoMissing
+	}
+
+	info := param.(*grpcapp.UploadPartParam).GetInfo()
+	if info == nil {
+		return nil, ErrorPartInfoMissing
+	}
+	return info, nil
+}
+
+type PartReaderParam struct {
+	Stream  grpcapp.FileService_UploadPartServer
+	MaxSize int64
+}
+
+func NewPartReader(p PartReaderParam) *partReader {
+	return &partReader{
+		chunkReader: chunkReader{
+			ctx: p.Stream.Context(),
+			recvFn: func() (chunkParam, error) {
+				param, err := p.Stream.Recv()
+				if err != nil {
+					return nil, err
+				}
+				return param, nil
+			},
+			maxSize: p.MaxSize,
+		},
+	}
+}
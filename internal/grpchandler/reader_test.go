@@ -0,0 +1,193 @@
+package grpchandler_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	api "github.com/go-seidon/hippo/api/grpcapp"
+	mock_grpcapp "github.com/go-seidon/hippo/api/grpcapp/mock"
+	"github.com/go-seidon/hippo/internal/grpchandler"
+	"github.com/golang/mock/gomock"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Upload Reader", func() {
+	Context("UploadReader", Label("unit"), func() {
+		var (
+			ctx        context.Context
+			stream     *mock_grpcapp.MockFileService_UploadFileServer
+			infoParam  *api.UploadFileParam
+			chunkParam *api.UploadFileParam
+		)
+
+		BeforeEach(func() {
+			t := GinkgoT()
+			ctrl := gomock.NewController(t)
+			ctx = context.Background()
+			stream = mock_grpcapp.NewMockFileService_UploadFileServer(ctrl)
+			stream.
+				EXPECT().
+				Context().
+				Return(ctx).
+				AnyTimes()
+			infoParam = &api.UploadFileParam{
+				Data: &api.UploadFileParam_Info{
+					Info: &api.UploadFileInfo{
+						Name:      "file-name",
+						Mimetype:  "file-mimetype",
+						Extension: "file-extension",
+					},
+				},
+			}
+			chunkParam = &api.UploadFileParam{
+				Data: &api.UploadFileParam_Chunks{
+					Chunks: []byte{1, 2, 3},
+				},
+			}
+		})
+
+		When("info is sent before the chunks", func() {
+			It("should return info and chunks", func() {
+				gomock.InOrder(
+					stream.EXPECT().Recv().Return(infoParam, nil),
+					stream.EXPECT().Recv().Return(chunkParam, nil),
+					stream.EXPECT().Recv().Return(chunkParam, nil),
+					stream.EXPECT().Recv().Return(nil, io.EOF),
+				)
+
+				reader := grpchandler.NewUploadReader(grpchandler.UploadReaderParam{
+					Stream:  stream,
+					MaxSize: 100,
+				})
+				info, ierr := reader.ReadInfo()
+				data, rerr := io.ReadAll(reader)
+
+				Expect(ierr).To(BeNil())
+				Expect(info).To(Equal(infoParam.GetInfo()))
+				Expect(rerr).To(BeNil())
+				Expect(data).To(Equal([]byte{1, 2, 3, 1, 2, 3}))
+			})
+		})
+
+		When("chunk is sent before the info", func() {
+			It("should return empty info and chunks", func() {
+				gomock.InOrder(
+					stream.EXPECT().Recv().Return(chunkParam, nil),
+					stream.EXPECT().Recv().Return(nil, io.EOF),
+				)
+
+				reader := grpchandler.NewUploadReader(grpchandler.UploadReaderParam{
+					Stream:  stream,
+					MaxSize: 100,
+				})
+				info, ierr := reader.ReadInfo()
+				data, rerr := io.ReadAll(reader)
+
+				Expect(ierr).To(BeNil())
+				Expect(info).To(Equal(&api.UploadFileInfo{}))
+				Expect(rerr).To(BeNil())
+				Expect(data).To(Equal([]byte{1, 2, 3}))
+			})
+		})
+
+		When("stream is empty", func() {
+			It("should return empty info and data", func() {
+				stream.
+					EXPECT().
+					Recv().
+					Return(nil, io.EOF).
+					Times(1)
+
+				reader := grpchandler.NewUploadReader(grpchandler.UploadReaderParam{
+					Stream:  stream,
+					MaxSize: 100,
+				})
+				info, ierr := reader.ReadInfo()
+				data, rerr := io.ReadAll(reader)
+
+				Expect(ierr).To(BeNil())
+				Expect(info).To(Equal(&api.UploadFileInfo{}))
+				Expect(rerr).To(BeNil())
+				Expect(data).To(BeEmpty())
+			})
+		})
+
+		When("failed receive chunks", func() {
+			It("should return error", func() {
+				gomock.InOrder(
+					stream.EXPECT().Recv().Return(infoParam, nil),
+					stream.EXPECT().Recv().Return(nil, fmt.Errorf("network error")),
+				)
+
+				reader := grpchandler.NewUploadReader(grpchandler.UploadReaderParam{
+					Stream:  stream,
+					MaxSize: 100,
+				})
+				_, ierr := reader.ReadInfo()
+				_, rerr := io.ReadAll(reader)
+
+				Expect(ierr).To(BeNil())
+				Expect(rerr).To(Equal(fmt.Errorf("network error")))
+			})
+		})
+
+		When("max file size reached", func() {
+			It("should return error", func() {
+				gomock.InOrder(
+					stream.EXPECT().Recv().Return(infoParam, nil),
+					stream.EXPECT().Recv().Return(chunkParam, nil),
+					stream.EXPECT().Recv().Return(chunkParam, nil),
+				)
+
+				reader := grpchandler.NewUploadReader(grpchandler.UploadReaderParam{
+					Stream:  stream,
+					MaxSize: 5,
+				})
+				_, ierr := reader.ReadInfo()
+				_, rerr := io.ReadAll(reader)
+
+				Expect(ierr).To(BeNil())
+				Expect(rerr).To(Equal(grpchandler.ErrorFileTooLarge))
+			})
+		})
+	})
+
+	Context("UploadReader with cancelled context", Label("unit"), func() {
+		When("action is cancelled by client", func() {
+			It("should return error", func() {
+				t := GinkgoT()
+				ctrl := gomock.NewController(t)
+				ctx, cancel := context.WithCancel(context.Background())
+				stream := mock_grpcapp.NewMockFileService_UploadFileServer(ctrl)
+				stream.
+					EXPECT().
+					Context().
+					Return(ctx).
+					AnyTimes()
+				stream.
+					EXPECT().
+					Recv().
+					Return(&api.UploadFileParam{
+						Data: &api.UploadFileParam_Info{
+							Info: &api.UploadFileInfo{},
+						},
+					}, nil).
+					Times(1)
+
+				reader := grpchandler.NewUploadReader(grpchandler.UploadReaderParam{
+					Stream:  stream,
+					MaxSize: 100,
+				})
+				_, ierr := reader.ReadInfo()
+				cancel()
+				_, rerr := io.ReadAll(reader)
+
+				Expect(ierr).To(BeNil())
+				Expect(rerr).To(Equal(context.Canceled))
+			})
+		})
+	})
+})
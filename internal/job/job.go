@@ -0,0 +1,173 @@
+package job
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-seidon/provider/logging"
+	"github.com/go-seidon/provider/logging/logrus"
+)
+
+type Scheduler interface {
+	Start(ctx context.Context) error
+	Stop(ctx context.Context) error
+	// @note: job is run right away when the scheduler is already started
+	Schedule(job *BackgroundJob)
+}
+
+type Runner interface {
+	Run(ctx context.Context) error
+}
+
+type BackgroundJob struct {
+	Name     string
+	Interval time.Duration
+	Runner   Runner
+	// @note: job with interval is run on start as well instead of waiting for the first interval
+	RunOnStart bool
+}
+
+type scheduler struct {
+	logger    logging.Logger
+	jobs      []*BackgroundJob
+	mu        sync.Mutex
+	wg        sync.WaitGroup
+	ctx       context.Context
+	cancel    context.CancelFunc
+	runStatus bool
+}
+
+// @note: each job is run on its own interval until the scheduler is stopped,
+// a failed run is logged and retried on the next interval,
+// job without interval is run once when the scheduler is started
+func (s *scheduler) Start(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.runStatus {
+		return nil
+	}
+
+	runCtx, cancel := context.WithCancel(context.Background())
+	for _, job := range s.jobs {
+		s.wg.Add(1)
+		go s.run(runCtx, job)
+	}
+
+	s.ctx = runCtx
+	s.cancel = cancel
+	s.runStatus = true
+	return nil
+}
+
+// @note: running job is cancelled and awaited until the given context is done
+func (s *scheduler) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	if !s.runStatus {
+		s.mu.Unlock()
+		return nil
+	}
+	s.cancel()
+	s.runStatus = false
+	s.mu.Unlock()
+
+	stopped := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *scheduler) Schedule(job *BackgroundJob) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.jobs = append(s.jobs, job)
+	if s.runStatus {
+		s.wg.Add(1)
+		go s.run(s.ctx, job)
+	}
+}
+
+func (s *scheduler) run(ctx context.Context, job *BackgroundJob) {
+	defer s.wg.Done()
+
+	if job.Interval <= 0 {
+		s.runJob(ctx, job)
+		return
+	}
+
+	if job.RunOnStart {
+		s.runJob(ctx, job)
+	}
+
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// @note: tick may be picked along with the cancellation
+			if ctx.Err() != nil {
+				return
+			}
+			s.runJob(ctx, job)
+		}
+	}
+}
+
+func (s *scheduler) runJob(ctx context.Context, job *BackgroundJob) {
+	s.logger.Debugf("Running job: %s", job.Name)
+	err := job.Runner.Run(ctx)
+	if err != nil {
+		s.logger.Errorf("Failed running job %s, err: %s", job.Name, err.Error())
+	}
+}
+
+type SchedulerParam struct {
+	Logger logging.Logger
+	Jobs   []*BackgroundJob
+}
+
+type SchedulerOption = func(*SchedulerParam)
+
+func WithLogger(logger logging.Logger) SchedulerOption {
+	return func(p *SchedulerParam) {
+		p.Logger = logger
+	}
+}
+
+func AddJob(job *BackgroundJob) SchedulerOption {
+	return func(p *SchedulerParam) {
+		p.Jobs = append(p.Jobs, job)
+	}
+}
+
+func NewScheduler(opts ...SchedulerOption) *scheduler {
+	p := SchedulerParam{
+		Jobs: []*BackgroundJob{},
+	}
+	for _, opt := range opts {
+		opt(&p)
+	}
+
+	logger := p.Logger
+	if logger == nil {
+		logger = logrus.NewLogger()
+	}
+
+	return &scheduler{
+		logger: logger,
+		jobs:   p.Jobs,
+	}
+}
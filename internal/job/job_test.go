@@ -0,0 +1,282 @@
+package job_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-seidon/hippo/internal/job"
+	mock_job "github.com/go-seidon/hippo/internal/job/mock"
+	mock_logging "github.com/go-seidon/provider/logging/mock"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestJob(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Job Package")
+}
+
+var _ = Describe("Scheduler", func() {
+	Context("NewScheduler function", Label("unit"), func() {
+		When("logger is not specified", func() {
+			It("should return result", func() {
+				res := job.NewScheduler()
+
+				Expect(res).ToNot(BeNil())
+			})
+		})
+
+		When("parameter is specified", func() {
+			It("should return result", func() {
+				t := GinkgoT()
+				ctrl := gomock.NewController(t)
+				logger := mock_logging.NewMockLogger(ctrl)
+				runner := mock_job.NewMockRunner(ctrl)
+
+				res := job.NewScheduler(
+					job.WithLogger(logger),
+					job.AddJob(&job.BackgroundJob{
+						Name:     "mock-job",
+						Interval: time.Second,
+						Runner:   runner,
+					}),
+				)
+
+				Expect(res).ToNot(BeNil())
+			})
+		})
+	})
+
+	Context("Start and Stop function", Label("unit"), func() {
+		var (
+			ctx       context.Context
+			logger    *mock_logging.MockLogger
+			runner    *mock_job.MockRunner
+			scheduler job.Scheduler
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			t := GinkgoT()
+			ctrl := gomock.NewController(t)
+			logger = mock_logging.NewMockLogger(ctrl)
+			runner = mock_job.NewMockRunner(ctrl)
+			scheduler = job.NewScheduler(
+				job.WithLogger(logger),
+				job.AddJob(&job.BackgroundJob{
+					Name:     "mock-job",
+					Interval: 5 * time.Millisecond,
+					Runner:   runner,
+				}),
+			)
+
+			logger.
+				EXPECT().
+				Debugf(gomock.Eq("Running job: %s"), gomock.Eq("mock-job")).
+				AnyTimes()
+		})
+
+		When("scheduler is not started", func() {
+			It("should not return error on stop", func() {
+				err := scheduler.Stop(ctx)
+
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("job is run successfully", func() {
+			It("should run the job on every interval", func() {
+				runs := make(chan struct{}, 10)
+				runner.
+					EXPECT().
+					Run(gomock.Any()).
+					DoAndReturn(func(ctx context.Context) error {
+						select {
+						case runs <- struct{}{}:
+						default:
+						}
+						return nil
+					}).
+					MinTimes(2)
+
+				err := scheduler.Start(ctx)
+				Expect(err).To(BeNil())
+
+				err = scheduler.Start(ctx)
+				Expect(err).To(BeNil())
+
+				Eventually(runs).Should(Receive())
+				Eventually(runs).Should(Receive())
+
+				err = scheduler.Stop(ctx)
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("job has no interval", func() {
+			It("should run the job once on start", func() {
+				scheduler := job.NewScheduler(
+					job.WithLogger(logger),
+					job.AddJob(&job.BackgroundJob{
+						Name:   "mock-job",
+						Runner: runner,
+					}),
+				)
+
+				runner.
+					EXPECT().
+					Run(gomock.Any()).
+					Return(nil).
+					Times(1)
+
+				err := scheduler.Start(ctx)
+				Expect(err).To(BeNil())
+
+				err = scheduler.Stop(ctx)
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("job is run on start", func() {
+			It("should run the job before the first interval", func() {
+				scheduler := job.NewScheduler(
+					job.WithLogger(logger),
+					job.AddJob(&job.BackgroundJob{
+						Name:       "mock-job",
+						Interval:   time.Hour,
+						Runner:     runner,
+						RunOnStart: true,
+					}),
+				)
+
+				runs := make(chan struct{}, 1)
+				runner.
+					EXPECT().
+					Run(gomock.Any()).
+					DoAndReturn(func(ctx context.Context) error {
+						runs <- struct{}{}
+						return nil
+					}).
+					Times(1)
+
+				err := scheduler.Start(ctx)
+				Expect(err).To(BeNil())
+
+				Eventually(runs).Should(Receive())
+
+				err = scheduler.Stop(ctx)
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("job is failed", func() {
+			It("should log the error", func() {
+				failed := make(chan struct{}, 10)
+				runner.
+					EXPECT().
+					Run(gomock.Any()).
+					Return(fmt.Errorf("db error")).
+					MinTimes(1)
+
+				logger.
+					EXPECT().
+					Errorf(gomock.Eq("Failed running job %s, err: %s"), gomock.Eq("mock-job"), gomock.Eq("db error")).
+					Do(func(format string, args ...interface{}) {
+						select {
+						case failed <- struct{}{}:
+						default:
+						}
+					}).
+					MinTimes(1)
+
+				err := scheduler.Start(ctx)
+				Expect(err).To(BeNil())
+
+				Eventually(failed).Should(Receive())
+
+				err = scheduler.Stop(ctx)
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("running job is not finished before stop deadline", func() {
+			It("should return error", func() {
+				started := make(chan struct{})
+				release := make(chan struct{})
+				runner.
+					EXPECT().
+					Run(gomock.Any()).
+					DoAndReturn(func(ctx context.Context) error {
+						close(started)
+						<-release
+						return nil
+					}).
+					Times(1)
+
+				err := scheduler.Start(ctx)
+				Expect(err).To(BeNil())
+
+				Eventually(started).Should(BeClosed())
+
+				stopCtx, cancel := context.WithCancel(ctx)
+				cancel()
+				err = scheduler.Stop(stopCtx)
+				close(release)
+
+				Expect(err).To(Equal(context.Canceled))
+			})
+		})
+
+		When("job is scheduled after start", func() {
+			It("should run the job right away", func() {
+				scheduler := job.NewScheduler(job.WithLogger(logger))
+				err := scheduler.Start(ctx)
+				Expect(err).To(BeNil())
+
+				runs := make(chan struct{}, 1)
+				runner.
+					EXPECT().
+					Run(gomock.Any()).
+					DoAndReturn(func(ctx context.Context) error {
+						runs <- struct{}{}
+						return nil
+					}).
+					Times(1)
+
+				scheduler.Schedule(&job.BackgroundJob{
+					Name:   "mock-job",
+					Runner: runner,
+				})
+				Eventually(runs).Should(Receive())
+
+				err = scheduler.Stop(ctx)
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("job is scheduled before start", func() {
+			It("should run the job on start", func() {
+				scheduler := job.NewScheduler(job.WithLogger(logger))
+				scheduler.Schedule(&job.BackgroundJob{
+					Name:   "mock-job",
+					Runner: runner,
+				})
+
+				runner.
+					EXPECT().
+					Run(gomock.Any()).
+					Return(nil).
+					Times(1)
+
+				err := scheduler.Start(ctx)
+				Expect(err).To(BeNil())
+
+				err = scheduler.Stop(ctx)
+				Expect(err).To(BeNil())
+			})
+		})
+	})
+})
@@ -0,0 +1,57 @@
+package job
+
+import (
+	"context"
+
+	"github.com/go-seidon/hippo/internal/service"
+	"github.com/go-seidon/provider/logging"
+)
+
+type cleanupMultipart struct {
+	multipartClient service.Multipart
+	logger          logging.Logger
+	config          *CleanupMultipartConfig
+}
+
+// @note: expired multipart is removed in batches until no more full batch is found
+func (j *cleanupMultipart) Run(ctx context.Context) error {
+	total := int32(0)
+	for {
+		cleanup, err := j.multipartClient.CleanupMultipart(ctx, service.CleanupMultipartParam{
+			Limit: j.config.BatchSize,
+		})
+		if err != nil {
+			return err
+		}
+
+		total += cleanup.Total
+		if cleanup.Total < j.config.BatchSize {
+			break
+		}
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+
+	j.logger.Infof("Finished cleaning up multipart, total: %d", total)
+	return nil
+}
+
+type CleanupMultipartConfig struct {
+	BatchSize int32
+}
+
+type CleanupMultipartParam struct {
+	MultipartClient service.Multipart
+	Logger          logging.Logger
+	Config          *CleanupMultipartConfig
+}
+
+func NewCleanupMultipart(p CleanupMultipartParam) *cleanupMultipart {
+	return &cleanupMultipart{
+		multipartClient: p.MultipartClient,
+		logger:          p.Logger,
+		config:          p.Config,
+	}
+}
@@ -0,0 +1,108 @@
+package job_test
+
+import (
+	"context"
+
+	"github.com/go-seidon/hippo/internal/job"
+	"github.com/go-seidon/hippo/internal/service"
+	mock_service "github.com/go-seidon/hippo/internal/service/mock"
+	mock_logging "github.com/go-seidon/provider/logging/mock"
+	"github.com/go-seidon/provider/system"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cleanup Multipart Job", func() {
+	Context("Run function", Label("unit"), func() {
+		var (
+			ctx             context.Context
+			multipartClient *mock_service.MockMultipart
+			logger          *mock_logging.MockLogger
+			runner          job.Runner
+			cleanupParam    service.CleanupMultipartParam
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			t := GinkgoT()
+			ctrl := gomock.NewController(t)
+			multipartClient = mock_service.NewMockMultipart(ctrl)
+			logger = mock_logging.NewMockLogger(ctrl)
+			runner = job.NewCleanupMultipart(job.CleanupMultipartParam{
+				MultipartClient: multipartClient,
+				Logger:          logger,
+				Config: &job.CleanupMultipartConfig{
+					BatchSize: 2,
+				},
+			})
+			cleanupParam = service.CleanupMultipartParam{
+				Limit: 2,
+			}
+		})
+
+		When("failed cleanup multipart", func() {
+			It("should return error", func() {
+				multipartClient.
+					EXPECT().
+					CleanupMultipart(gomock.Eq(ctx), gomock.Eq(cleanupParam)).
+					Return(nil, &system.Error{
+						Code:    1001,
+						Message: "db error",
+					}).
+					Times(1)
+
+				err := runner.Run(ctx)
+
+				Expect(err).To(Equal(&system.Error{
+					Code:    1001,
+					Message: "db error",
+				}))
+			})
+		})
+
+		When("removed multiparts are more than batch size", func() {
+			It("should cleanup in batches", func() {
+				firstCleanup := multipartClient.
+					EXPECT().
+					CleanupMultipart(gomock.Eq(ctx), gomock.Eq(cleanupParam)).
+					Return(&service.CleanupMultipartResult{Total: 2}, nil).
+					Times(1)
+
+				multipartClient.
+					EXPECT().
+					CleanupMultipart(gomock.Eq(ctx), gomock.Eq(cleanupParam)).
+					Return(&service.CleanupMultipartResult{Total: 1}, nil).
+					After(firstCleanup).
+					Times(1)
+
+				logger.
+					EXPECT().
+					Infof(gomock.Eq("Finished cleaning up multipart, total: %d"), gomock.Eq(int32(3))).
+					Times(1)
+
+				err := runner.Run(ctx)
+
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("context is cancelled between batches", func() {
+			It("should return error", func() {
+				ctx, cancel := context.WithCancel(ctx)
+				multipartClient.
+					EXPECT().
+					CleanupMultipart(gomock.Eq(ctx), gomock.Eq(cleanupParam)).
+					DoAndReturn(func(ctx context.Context, p service.CleanupMultipartParam) (*service.CleanupMultipartResult, *system.Error) {
+						cancel()
+						return &service.CleanupMultipartResult{Total: 2}, nil
+					}).
+					Times(1)
+
+				err := runner.Run(ctx)
+
+				Expect(err).To(Equal(context.Canceled))
+			})
+		})
+	})
+})
@@ -0,0 +1,84 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-seidon/hippo/internal/repository"
+	"github.com/go-seidon/provider/datetime"
+	"github.com/go-seidon/provider/logging"
+)
+
+const (
+	DEFAULT_PURGE_BATCH_SIZE = 100
+)
+
+type purgeFile struct {
+	fileRepo repository.File
+	clock    datetime.Clock
+	logger   logging.Logger
+	purgeFn  repository.PurgeFn
+	config   *PurgeFileConfig
+}
+
+// @note: soft deleted files older than the retention are removed in batches
+// until no more full batch is found
+func (j *purgeFile) Run(ctx context.Context) error {
+	deletedBefore := j.clock.Now().Add(-j.config.Retention)
+	j.logger.Infof("Purging files deleted before: %s", deletedBefore.Format(time.RFC3339))
+
+	// @note: unspecified batch size is purged using the default size,
+	// otherwise an empty batch would never reach the end
+	batchSize := j.config.BatchSize
+	if batchSize <= 0 {
+		batchSize = DEFAULT_PURGE_BATCH_SIZE
+	}
+
+	total := int64(0)
+	for {
+		purgeRes, err := j.fileRepo.PurgeFile(ctx, repository.PurgeFileParam{
+			DeletedBefore: deletedBefore,
+			Limit:         batchSize,
+			PurgeFn:       j.purgeFn,
+		})
+		if err != nil {
+			return err
+		}
+
+		total += purgeRes.TotalItems
+		if purgeRes.TotalItems < int64(batchSize) {
+			break
+		}
+		j.logger.Infof("Purged %d files, total: %d", purgeRes.TotalItems, total)
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+
+	j.logger.Infof("Finished purging files, total: %d", total)
+	return nil
+}
+
+type PurgeFileConfig struct {
+	Retention time.Duration
+	BatchSize int32
+}
+
+type PurgeFileParam struct {
+	FileRepo repository.File
+	Clock    datetime.Clock
+	Logger   logging.Logger
+	PurgeFn  repository.PurgeFn
+	Config   *PurgeFileConfig
+}
+
+func NewPurgeFile(p PurgeFileParam) *purgeFile {
+	return &purgeFile{
+		fileRepo: p.FileRepo,
+		clock:    p.Clock,
+		logger:   p.Logger,
+		purgeFn:  p.PurgeFn,
+		config:   p.Config,
+	}
+}
@@ -0,0 +1,185 @@
+package job_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-seidon/hippo/internal/job"
+	"github.com/go-seidon/hippo/internal/repository"
+	mock_repository "github.com/go-seidon/hippo/internal/repository/mock"
+	mock_datetime "github.com/go-seidon/provider/datetime/mock"
+	mock_logging "github.com/go-seidon/provider/logging/mock"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Purge File Job", func() {
+	Context("Run function", Label("unit"), func() {
+		var (
+			ctx        context.Context
+			currentTs  time.Time
+			fileRepo   *mock_repository.MockFile
+			clock      *mock_datetime.MockClock
+			logger     *mock_logging.MockLogger
+			runner     job.Runner
+			purgeParam repository.PurgeFileParam
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			currentTs = time.Date(2023, 1, 31, 0, 0, 0, 0, time.UTC)
+			t := GinkgoT()
+			ctrl := gomock.NewController(t)
+			fileRepo = mock_repository.NewMockFile(ctrl)
+			clock = mock_datetime.NewMockClock(ctrl)
+			logger = mock_logging.NewMockLogger(ctrl)
+			runner = job.NewPurgeFile(job.PurgeFileParam{
+				FileRepo: fileRepo,
+				Clock:    clock,
+				Logger:   logger,
+				Config: &job.PurgeFileConfig{
+					Retention: 30 * 24 * time.Hour,
+					BatchSize: 2,
+				},
+			})
+			purgeParam = repository.PurgeFileParam{
+				DeletedBefore: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+				Limit:         2,
+			}
+
+			clock.
+				EXPECT().
+				Now().
+				Return(currentTs).
+				Times(1)
+
+			logger.
+				EXPECT().
+				Infof(gomock.Eq("Purging files deleted before: %s"), gomock.Eq("2023-01-01T00:00:00Z")).
+				Times(1)
+		})
+
+		When("failed purge file", func() {
+			It("should return error", func() {
+				fileRepo.
+					EXPECT().
+					PurgeFile(gomock.Eq(ctx), gomock.Eq(purgeParam)).
+					Return(nil, fmt.Errorf("db error")).
+					Times(1)
+
+				err := runner.Run(ctx)
+
+				Expect(err).To(Equal(fmt.Errorf("db error")))
+			})
+		})
+
+		When("purged files are less than batch size", func() {
+			It("should return result", func() {
+				fileRepo.
+					EXPECT().
+					PurgeFile(gomock.Eq(ctx), gomock.Eq(purgeParam)).
+					Return(&repository.PurgeFileResult{TotalItems: 1}, nil).
+					Times(1)
+
+				logger.
+					EXPECT().
+					Infof(gomock.Eq("Finished purging files, total: %d"), gomock.Eq(int64(1))).
+					Times(1)
+
+				err := runner.Run(ctx)
+
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("batch size is not specified", func() {
+			It("should purge using the default batch size", func() {
+				runner = job.NewPurgeFile(job.PurgeFileParam{
+					FileRepo: fileRepo,
+					Clock:    clock,
+					Logger:   logger,
+					Config: &job.PurgeFileConfig{
+						Retention: 30 * 24 * time.Hour,
+					},
+				})
+				purgeParam.Limit = 100
+				fileRepo.
+					EXPECT().
+					PurgeFile(gomock.Eq(ctx), gomock.Eq(purgeParam)).
+					Return(&repository.PurgeFileResult{TotalItems: 0}, nil).
+					Times(1)
+
+				logger.
+					EXPECT().
+					Infof(gomock.Eq("Finished purging files, total: %d"), gomock.Eq(int64(0))).
+					Times(1)
+
+				err := runner.Run(ctx)
+
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("purged files are more than batch size", func() {
+			It("should purge in batches", func() {
+				firstPurge := fileRepo.
+					EXPECT().
+					PurgeFile(gomock.Eq(ctx), gomock.Eq(purgeParam)).
+					Return(&repository.PurgeFileResult{TotalItems: 2}, nil).
+					Times(2)
+
+				fileRepo.
+					EXPECT().
+					PurgeFile(gomock.Eq(ctx), gomock.Eq(purgeParam)).
+					Return(&repository.PurgeFileResult{TotalItems: 0}, nil).
+					After(firstPurge).
+					Times(1)
+
+				logger.
+					EXPECT().
+					Infof(gomock.Eq("Purged %d files, total: %d"), gomock.Eq(int64(2)), gomock.Eq(int64(2))).
+					Times(1)
+
+				logger.
+					EXPECT().
+					Infof(gomock.Eq("Purged %d files, total: %d"), gomock.Eq(int64(2)), gomock.Eq(int64(4))).
+					Times(1)
+
+				logger.
+					EXPECT().
+					Infof(gomock.Eq("Finished purging files, total: %d"), gomock.Eq(int64(4))).
+					Times(1)
+
+				err := runner.Run(ctx)
+
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("context is cancelled between batches", func() {
+			It("should return error", func() {
+				cancelCtx, cancel := context.WithCancel(ctx)
+
+				fileRepo.
+					EXPECT().
+					PurgeFile(gomock.Eq(cancelCtx), gomock.Eq(purgeParam)).
+					DoAndReturn(func(ctx context.Context, p repository.PurgeFileParam) (*repository.PurgeFileResult, error) {
+						cancel()
+						return &repository.PurgeFileResult{TotalItems: 2}, nil
+					}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					Infof(gomock.Eq("Purged %d files, total: %d"), gomock.Eq(int64(2)), gomock.Eq(int64(2))).
+					Times(1)
+
+				err := runner.Run(cancelCtx)
+
+				Expect(err).To(Equal(context.Canceled))
+			})
+		})
+	})
+})
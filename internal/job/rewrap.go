@@ -0,0 +1,117 @@
+package job
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-seidon/hippo/internal/encryption"
+	"github.com/go-seidon/hippo/internal/repository"
+	"github.com/go-seidon/provider/datetime"
+	"github.com/go-seidon/provider/logging"
+)
+
+type rewrapKey struct {
+	fileRepo repository.File
+	keyring  encryption.Keyring
+	clock    datetime.Clock
+	logger   logging.Logger
+	config   *RewrapKeyConfig
+}
+
+// @note: data keys which are not wrapped by the primary key are re-wrapped in batches,
+// the content is not rewritten since only the data key wrapper is changed
+// file which can't be unwrapped (e.g: its key is missing) is skipped and reported
+func (j *rewrapKey) Run(ctx context.Context) error {
+	keyId := j.keyring.PrimaryKeyId()
+	j.logger.Infof("Re-wrapping data keys using key: %s", keyId)
+
+	total := int64(0)
+	failed := int64(0)
+	afterId := ""
+	for {
+		searchRes, err := j.fileRepo.SearchFileKey(ctx, repository.SearchFileKeyParam{
+			ExcludeKeyId: keyId,
+			AfterId:      afterId,
+			Limit:        j.config.BatchSize,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, item := range searchRes.Items {
+			afterId = item.UniqueId
+
+			err := j.rewrap(ctx, item)
+			if errors.Is(err, repository.ErrNotFound) {
+				continue
+			}
+			if err != nil {
+				failed++
+				j.logger.Errorf("Failed re-wrapping data key of file: %s, %s", item.UniqueId, err.Error())
+				continue
+			}
+			total++
+		}
+
+		if len(searchRes.Items) < int(j.config.BatchSize) {
+			break
+		}
+		j.logger.Infof("Re-wrapped %d data keys, total: %d", len(searchRes.Items), total)
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+
+	j.logger.Infof("Finished re-wrapping data keys, total: %d, failed: %d", total, failed)
+	return nil
+}
+
+func (j *rewrapKey) rewrap(ctx context.Context, item repository.SearchFileKeyItem) error {
+	unwrap, err := j.keyring.UnwrapKey(encryption.UnwrapKeyParam{
+		KeyId:      item.EncryptionKeyId,
+		WrappedKey: item.EncryptionDataKey,
+	})
+	if err != nil {
+		return err
+	}
+
+	wrap, err := j.keyring.WrapKey(encryption.WrapKeyParam{
+		DataKey: unwrap.DataKey,
+	})
+	if err != nil {
+		return err
+	}
+
+	// @note: the key is not updated when it's changed meanwhile (e.g: by another running rotation)
+	_, err = j.fileRepo.UpdateFileKey(ctx, repository.UpdateFileKeyParam{
+		UniqueId:          item.UniqueId,
+		CurrentDataKey:    item.EncryptionDataKey,
+		EncryptionKeyId:   wrap.KeyId,
+		EncryptionDataKey: wrap.WrappedKey,
+		UpdatedAt:         j.clock.Now(),
+	})
+	return err
+}
+
+type RewrapKeyConfig struct {
+	BatchSize int32
+}
+
+type RewrapKeyParam struct {
+	FileRepo repository.File
+	Keyring  encryption.Keyring
+	Clock    datetime.Clock
+	Logger   logging.Logger
+	Config   *RewrapKeyConfig
+}
+
+func NewRewrapKey(p RewrapKeyParam) *rewrapKey {
+	return &rewrapKey{
+		fileRepo: p.FileRepo,
+		keyring:  p.Keyring,
+		clock:    p.Clock,
+		logger:   p.Logger,
+		config:   p.Config,
+	}
+}
@@ -0,0 +1,182 @@
+package job_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-seidon/hippo/internal/encryption"
+	"github.com/go-seidon/hippo/internal/job"
+	"github.com/go-seidon/hippo/internal/repository"
+	mock_repository "github.com/go-seidon/hippo/internal/repository/mock"
+	mock_datetime "github.com/go-seidon/provider/datetime/mock"
+	mock_logging "github.com/go-seidon/provider/logging/mock"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Rewrap Key Job", func() {
+	Context("Run function", Label("unit"), func() {
+		var (
+			ctx         context.Context
+			currentTs   time.Time
+			fileRepo    *mock_repository.MockFile
+			clock       *mock_datetime.MockClock
+			logger      *mock_logging.MockLogger
+			oldKeyring  encryption.Keyring
+			keyring     encryption.Keyring
+			runner      job.Runner
+			searchParam repository.SearchFileKeyParam
+			dataKey     []byte
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			currentTs = time.Date(2023, 1, 31, 0, 0, 0, 0, time.UTC)
+			t := GinkgoT()
+			ctrl := gomock.NewController(t)
+			fileRepo = mock_repository.NewMockFile(ctrl)
+			clock = mock_datetime.NewMockClock(ctrl)
+			logger = mock_logging.NewMockLogger(ctrl)
+			oldKeyring, _ = encryption.NewKeyring(encryption.KeyringParam{
+				PrimaryKeyId: "key-1",
+				Keys: map[string][]byte{
+					"key-1": bytes.Repeat([]byte("a"), encryption.KEY_SIZE),
+				},
+			})
+			keyring, _ = encryption.NewKeyring(encryption.KeyringParam{
+				PrimaryKeyId: "key-2",
+				Keys: map[string][]byte{
+					"key-1": bytes.Repeat([]byte("a"), encryption.KEY_SIZE),
+					"key-2": bytes.Repeat([]byte("b"), encryption.KEY_SIZE),
+				},
+			})
+			runner = job.NewRewrapKey(job.RewrapKeyParam{
+				FileRepo: fileRepo,
+				Keyring:  keyring,
+				Clock:    clock,
+				Logger:   logger,
+				Config: &job.RewrapKeyConfig{
+					BatchSize: 2,
+				},
+			})
+			searchParam = repository.SearchFileKeyParam{
+				ExcludeKeyId: "key-2",
+				Limit:        2,
+			}
+			dataKey, _ = encryption.GenerateDataKey()
+
+			clock.
+				EXPECT().
+				Now().
+				Return(currentTs).
+				AnyTimes()
+
+			logger.
+				EXPECT().
+				Infof(gomock.Eq("Re-wrapping data keys using key: %s"), gomock.Eq("key-2")).
+				Times(1)
+		})
+
+		When("failed search file", func() {
+			It("should return error", func() {
+				fileRepo.
+					EXPECT().
+					SearchFileKey(gomock.Eq(ctx), gomock.Eq(searchParam)).
+					Return(nil, fmt.Errorf("db error")).
+					Times(1)
+
+				err := runner.Run(ctx)
+
+				Expect(err).To(Equal(fmt.Errorf("db error")))
+			})
+		})
+
+		When("there are data keys wrapped by the old key", func() {
+			It("should re-wrap them using the primary key", func() {
+				wrapA, _ := oldKeyring.WrapKey(encryption.WrapKeyParam{DataKey: dataKey})
+				wrapB, _ := oldKeyring.WrapKey(encryption.WrapKeyParam{DataKey: dataKey})
+				wrapC, _ := oldKeyring.WrapKey(encryption.WrapKeyParam{DataKey: dataKey})
+
+				fileRepo.
+					EXPECT().
+					SearchFileKey(gomock.Eq(ctx), gomock.Eq(searchParam)).
+					Return(&repository.SearchFileKeyResult{
+						Items: []repository.SearchFileKeyItem{
+							{UniqueId: "a", EncryptionKeyId: wrapA.KeyId, EncryptionDataKey: wrapA.WrappedKey},
+							{UniqueId: "b", EncryptionKeyId: "key-0", EncryptionDataKey: "missing-key"},
+						},
+					}, nil).
+					Times(1)
+
+				secondParam := searchParam
+				secondParam.AfterId = "b"
+				fileRepo.
+					EXPECT().
+					SearchFileKey(gomock.Eq(ctx), gomock.Eq(secondParam)).
+					Return(&repository.SearchFileKeyResult{
+						Items: []repository.SearchFileKeyItem{
+							{UniqueId: "c", EncryptionKeyId: wrapB.KeyId, EncryptionDataKey: wrapB.WrappedKey},
+							{UniqueId: "d", EncryptionKeyId: wrapC.KeyId, EncryptionDataKey: wrapC.WrappedKey},
+						},
+					}, nil).
+					Times(1)
+
+				thirdParam := searchParam
+				thirdParam.AfterId = "d"
+				fileRepo.
+					EXPECT().
+					SearchFileKey(gomock.Eq(ctx), gomock.Eq(thirdParam)).
+					Return(&repository.SearchFileKeyResult{
+						Items: []repository.SearchFileKeyItem{},
+					}, nil).
+					Times(1)
+
+				updated := map[string]repository.UpdateFileKeyParam{}
+				fileRepo.
+					EXPECT().
+					UpdateFileKey(gomock.Eq(ctx), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, p repository.UpdateFileKeyParam) (*repository.UpdateFileKeyResult, error) {
+						if p.UniqueId == "c" {
+							return nil, repository.ErrNotFound
+						}
+						updated[p.UniqueId] = p
+						return &repository.UpdateFileKeyResult{UpdatedAt: p.UpdatedAt}, nil
+					}).
+					Times(3)
+
+				logger.
+					EXPECT().
+					Errorf(gomock.Eq("Failed re-wrapping data key of file: %s, %s"), gomock.Eq("b"), gomock.Eq("encryption key not found")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					Infof(gomock.Eq("Re-wrapped %d data keys, total: %d"), gomock.Any(), gomock.Any()).
+					Times(2)
+
+				logger.
+					EXPECT().
+					Infof(gomock.Eq("Finished re-wrapping data keys, total: %d, failed: %d"), gomock.Eq(int64(2)), gomock.Eq(int64(1))).
+					Times(1)
+
+				err := runner.Run(ctx)
+
+				Expect(err).To(BeNil())
+				Expect(updated).To(HaveLen(2))
+				Expect(updated["a"].CurrentDataKey).To(Equal(wrapA.WrappedKey))
+				Expect(updated["a"].EncryptionKeyId).To(Equal("key-2"))
+				Expect(updated["a"].UpdatedAt).To(Equal(currentTs))
+
+				unwrap, err := keyring.UnwrapKey(encryption.UnwrapKeyParam{
+					KeyId:      updated["d"].EncryptionKeyId,
+					WrappedKey: updated["d"].EncryptionDataKey,
+				})
+				Expect(err).To(BeNil())
+				Expect(unwrap.DataKey).To(Equal(dataKey))
+			})
+		})
+	})
+})
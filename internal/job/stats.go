@@ -0,0 +1,32 @@
+package job
+
+import (
+	"context"
+
+	"github.com/go-seidon/hippo/internal/auth"
+	"github.com/go-seidon/provider/logging"
+)
+
+type logCacheStats struct {
+	cache  auth.VerificationCache
+	logger logging.Logger
+}
+
+// @note: the counters are cumulative since the cache is created
+func (j *logCacheStats) Run(ctx context.Context) error {
+	stats := j.cache.Stats()
+	j.logger.Infof("Verification cache stats, hits: %d, misses: %d, entries: %d", stats.Hits, stats.Misses, stats.Entries)
+	return nil
+}
+
+type LogCacheStatsParam struct {
+	Cache  auth.VerificationCache
+	Logger logging.Logger
+}
+
+func NewLogCacheStats(p LogCacheStatsParam) *logCacheStats {
+	return &logCacheStats{
+		cache:  p.Cache,
+		logger: p.Logger,
+	}
+}
@@ -0,0 +1,62 @@
+package job_test
+
+import (
+	"context"
+
+	"github.com/go-seidon/hippo/internal/auth"
+	mock_auth "github.com/go-seidon/hippo/internal/auth/mock"
+	"github.com/go-seidon/hippo/internal/job"
+	mock_logging "github.com/go-seidon/provider/logging/mock"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Log Cache Stats Job", func() {
+	Context("Run function", Label("unit"), func() {
+		var (
+			ctx    context.Context
+			cache  *mock_auth.MockVerificationCache
+			logger *mock_logging.MockLogger
+			runner job.Runner
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			t := GinkgoT()
+			ctrl := gomock.NewController(t)
+			cache = mock_auth.NewMockVerificationCache(ctrl)
+			logger = mock_logging.NewMockLogger(ctrl)
+			runner = job.NewLogCacheStats(job.LogCacheStatsParam{
+				Cache:  cache,
+				Logger: logger,
+			})
+		})
+
+		When("success log cache stats", func() {
+			It("should return nil", func() {
+				cache.
+					EXPECT().
+					Stats().
+					Return(auth.VerificationStats{
+						Hits:    8,
+						Misses:  2,
+						Entries: 1,
+					}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					Infof(
+						gomock.Eq("Verification cache stats, hits: %d, misses: %d, entries: %d"),
+						gomock.Eq(uint64(8)), gomock.Eq(uint64(2)), gomock.Eq(1),
+					).
+					Times(1)
+
+				err := runner.Run(ctx)
+
+				Expect(err).To(BeNil())
+			})
+		})
+	})
+})
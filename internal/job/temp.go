@@ -0,0 +1,56 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-seidon/hippo/internal/filesystem"
+	"github.com/go-seidon/provider/datetime"
+	"github.com/go-seidon/provider/logging"
+)
+
+type cleanTempFile struct {
+	cleaner filesystem.TempFileCleaner
+	clock   datetime.Clock
+	logger  logging.Logger
+	config  *CleanTempFileConfig
+}
+
+// @note: only temp files older than the min age are removed
+// to keep the in progress writes of the other running instances
+func (j *cleanTempFile) Run(ctx context.Context) error {
+	modifiedBefore := j.clock.Now().Add(-j.config.MinAge)
+	j.logger.Infof("Cleaning temp files modified before: %s", modifiedBefore.Format(time.RFC3339))
+
+	cleanRes, err := j.cleaner.CleanTempFiles(ctx, filesystem.CleanTempFilesParam{
+		Directory:      j.config.Directory,
+		ModifiedBefore: modifiedBefore,
+	})
+	if err != nil {
+		return err
+	}
+
+	j.logger.Infof("Finished cleaning temp files, total: %d", cleanRes.TotalRemoved)
+	return nil
+}
+
+type CleanTempFileConfig struct {
+	Directory string
+	MinAge    time.Duration
+}
+
+type CleanTempFileParam struct {
+	Cleaner filesystem.TempFileCleaner
+	Clock   datetime.Clock
+	Logger  logging.Logger
+	Config  *CleanTempFileConfig
+}
+
+func NewCleanTempFile(p CleanTempFileParam) *cleanTempFile {
+	return &cleanTempFile{
+		cleaner: p.Cleaner,
+		clock:   p.Clock,
+		logger:  p.Logger,
+		config:  p.Config,
+	}
+}
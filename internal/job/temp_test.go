@@ -0,0 +1,99 @@
+package job_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-seidon/hippo/internal/filesystem"
+	mock_filesystem "github.com/go-seidon/hippo/internal/filesystem/mock"
+	"github.com/go-seidon/hippo/internal/job"
+	mock_datetime "github.com/go-seidon/provider/datetime/mock"
+	mock_logging "github.com/go-seidon/provider/logging/mock"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Clean Temp File Job", func() {
+	Context("Run function", Label("unit"), func() {
+		var (
+			ctx        context.Context
+			currentTs  time.Time
+			cleaner    *mock_filesystem.MockTempFileCleaner
+			clock      *mock_datetime.MockClock
+			logger     *mock_logging.MockLogger
+			runner     job.Runner
+			cleanParam filesystem.CleanTempFilesParam
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			currentTs = time.Date(2023, 1, 31, 1, 0, 0, 0, time.UTC)
+			t := GinkgoT()
+			ctrl := gomock.NewController(t)
+			cleaner = mock_filesystem.NewMockTempFileCleaner(ctrl)
+			clock = mock_datetime.NewMockClock(ctrl)
+			logger = mock_logging.NewMockLogger(ctrl)
+			runner = job.NewCleanTempFile(job.CleanTempFileParam{
+				Cleaner: cleaner,
+				Clock:   clock,
+				Logger:  logger,
+				Config: &job.CleanTempFileConfig{
+					Directory: "storage",
+					MinAge:    time.Hour,
+				},
+			})
+			cleanParam = filesystem.CleanTempFilesParam{
+				Directory:      "storage",
+				ModifiedBefore: time.Date(2023, 1, 31, 0, 0, 0, 0, time.UTC),
+			}
+
+			clock.
+				EXPECT().
+				Now().
+				Return(currentTs).
+				Times(1)
+
+			logger.
+				EXPECT().
+				Infof(gomock.Eq("Cleaning temp files modified before: %s"), gomock.Eq("2023-01-31T00:00:00Z")).
+				Times(1)
+		})
+
+		When("failed clean temp files", func() {
+			It("should return error", func() {
+				cleaner.
+					EXPECT().
+					CleanTempFiles(gomock.Eq(ctx), gomock.Eq(cleanParam)).
+					Return(nil, fmt.Errorf("disk error")).
+					Times(1)
+
+				err := runner.Run(ctx)
+
+				Expect(err).To(Equal(fmt.Errorf("disk error")))
+			})
+		})
+
+		When("success clean temp files", func() {
+			It("should return result", func() {
+				cleaner.
+					EXPECT().
+					CleanTempFiles(gomock.Eq(ctx), gomock.Eq(cleanParam)).
+					Return(&filesystem.CleanTempFilesResult{
+						TotalRemoved: 3,
+					}, nil).
+					Times(1)
+
+				logger.
+					EXPECT().
+					Infof(gomock.Eq("Finished cleaning temp files, total: %d"), gomock.Eq(int64(3))).
+					Times(1)
+
+				err := runner.Run(ctx)
+
+				Expect(err).To(BeNil())
+			})
+		})
+	})
+})
@@ -0,0 +1,385 @@
+package reconcile
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/go-seidon/hippo/internal/encryption"
+	"github.com/go-seidon/hippo/internal/file"
+	"github.com/go-seidon/hippo/internal/filesystem"
+	"github.com/go-seidon/hippo/internal/repository"
+	"github.com/go-seidon/provider/datetime"
+)
+
+const (
+	ISSUE_ORPHANED_BLOB     = "orphaned_blob"
+	ISSUE_DANGLING_RECORD   = "dangling_record"
+	ISSUE_SIZE_MISMATCH     = "size_mismatch"
+	ISSUE_CHECKSUM_MISMATCH = "checksum_mismatch"
+
+	DEFAULT_BATCH_SIZE = 100
+)
+
+type Reconciler interface {
+	Reconcile(ctx context.Context, p ReconcileParam) (*ReconcileResult, error)
+}
+
+// @note: when repair is enabled orphaned blob is removed and dangling record is marked as deleted,
+// size and checksum mismatch are only reported since the correct side can not be determined
+type ReconcileParam struct {
+	Repair         bool
+	VerifyChecksum bool
+}
+
+type ReconcileResult struct {
+	StartedAt  time.Time        `json:"started_at"`
+	FinishedAt time.Time        `json:"finished_at"`
+	Repair     bool             `json:"repair"`
+	Summary    ReconcileSummary `json:"summary"`
+	Issues     []ReconcileIssue `json:"issues"`
+}
+
+type ReconcileSummary struct {
+	TotalRecords       int64 `json:"total_records"`
+	TotalBlobs         int64 `json:"total_blobs"`
+	OrphanedBlobs      int64 `json:"orphaned_blobs"`
+	DanglingRecords    int64 `json:"dangling_records"`
+	SizeMismatches     int64 `json:"size_mismatches"`
+	ChecksumMismatches int64 `json:"checksum_mismatches"`
+	Repaired           int64 `json:"repaired"`
+}
+
+type ReconcileIssue struct {
+	Type     string `json:"type"`
+	FileId   string `json:"file_id,omitempty"`
+	Path     string `json:"path"`
+	Expected string `json:"expected,omitempty"`
+	Actual   string `json:"actual,omitempty"`
+	Repaired bool   `json:"repaired"`
+	Error    string `json:"error,omitempty"`
+}
+
+// @note: unrepaired issues are the issues left after the repair
+func (r *ReconcileResult) UnrepairedIssues() int64 {
+	return int64(len(r.Issues)) - r.Summary.Repaired
+}
+
+type blob struct {
+	Size       int64
+	ModifiedAt time.Time
+}
+
+type reconciler struct {
+	fileRepo    repository.File
+	fileManager filesystem.FileManager
+	clock       datetime.Clock
+	config      *ReconcilerConfig
+}
+
+func (r *reconciler) Reconcile(ctx context.Context, p ReconcileParam) (*ReconcileResult, error) {
+	res := &ReconcileResult{
+		StartedAt: r.clock.Now(),
+		Repair:    p.Repair,
+		Issues:    []ReconcileIssue{},
+	}
+
+	// @note: blobs are walked on the local directory, other storage is rejected instead of reporting every record as dangling
+	if r.config.Storage != filesystem.PROVIDER_LOCAL {
+		return nil, fmt.Errorf("reconcile is only supported on %s storage", filesystem.PROVIDER_LOCAL)
+	}
+
+	blobs, err := r.walkBlobs()
+	if err != nil {
+		return nil, err
+	}
+	res.Summary.TotalBlobs = int64(len(blobs))
+
+	// @note: records are paged by the id so the page is not shifted by the concurrent upload or purge
+	referenced := map[string]bool{}
+	afterId := ""
+	for {
+		search, err := r.fileRepo.SearchFile(ctx, repository.SearchFileParam{
+			Limit:     r.config.BatchSize,
+			AfterId:   afterId,
+			SortBy:    repository.FILE_SORT_ID,
+			SortOrder: repository.SORT_ASC,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range search.Items {
+			path := filepath.Clean(item.Path)
+			referenced[path] = true
+			res.Summary.TotalRecords++
+
+			// @note: deleted file content is removed or kept in the trash
+			if item.DeletedAt != nil {
+				continue
+			}
+
+			issue := r.checkRecord(ctx, item, blobs[path], p)
+			if issue == nil {
+				continue
+			}
+
+			switch issue.Type {
+			case ISSUE_DANGLING_RECORD:
+				res.Summary.DanglingRecords++
+				if p.Repair {
+					r.repairDanglingRecord(ctx, issue)
+				}
+			case ISSUE_SIZE_MISMATCH:
+				res.Summary.SizeMismatches++
+			case ISSUE_CHECKSUM_MISMATCH:
+				res.Summary.ChecksumMismatches++
+			}
+			if issue.Repaired {
+				res.Summary.Repaired++
+			}
+			res.Issues = append(res.Issues, *issue)
+		}
+
+		if len(search.Items) < int(r.config.BatchSize) {
+			break
+		}
+		afterId = search.Items[len(search.Items)-1].UniqueId
+	}
+
+	paths := []string{}
+	for path := range blobs {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	currentTs := r.clock.Now()
+	for _, path := range paths {
+		if referenced[path] {
+			continue
+		}
+
+		// @note: recently written file may belong to an upload which is not committed yet
+		if currentTs.Sub(blobs[path].ModifiedAt) < r.config.OrphanAge {
+			continue
+		}
+
+		issue := &ReconcileIssue{
+			Type:   ISSUE_ORPHANED_BLOB,
+			Path:   path,
+			Actual: fmt.Sprintf("%d", blobs[path].Size),
+		}
+		if p.Repair {
+			// @note: blob may be referenced by the file created after the records are scanned
+			search, err := r.fileRepo.SearchFile(ctx, repository.SearchFileParam{
+				Limit: 1,
+				Paths: []string{path},
+			})
+			if err != nil {
+				issue.Error = err.Error()
+			} else if len(search.Items) > 0 {
+				continue
+			} else {
+				r.repairOrphanedBlob(ctx, issue)
+			}
+		}
+		res.Summary.OrphanedBlobs++
+		if issue.Repaired {
+			res.Summary.Repaired++
+		}
+		res.Issues = append(res.Issues, *issue)
+	}
+
+	res.FinishedAt = r.clock.Now()
+	return res, nil
+}
+
+func (r *reconciler) checkRecord(ctx context.Context, item repository.SearchFileItem, b *blob, p ReconcileParam) *ReconcileIssue {
+	if b == nil {
+		return &ReconcileIssue{
+			Type:   ISSUE_DANGLING_RECORD,
+			FileId: item.UniqueId,
+			Path:   item.Path,
+		}
+	}
+
+	// @note: encrypted content is larger than the stored size because of the header and chunk tags,
+	// the stored size is recorded since compression is introduced
+	size := item.Size
+	if item.StoredSize > 0 {
+		size = item.StoredSize
+	} else if item.EncryptionKeyId != "" {
+		size = encryption.EncryptedSize(item.Size)
+	}
+
+	if b.Size != size {
+		return &ReconcileIssue{
+			Type:     ISSUE_SIZE_MISMATCH,
+			FileId:   item.UniqueId,
+			Path:     item.Path,
+			Expected: fmt.Sprintf("%d", size),
+			Actual:   fmt.Sprintf("%d", b.Size),
+		}
+	}
+
+	// @note: file uploaded before checksum is introduced has no stored checksum to verify against
+	if !p.VerifyChecksum || item.ChecksumSha256 == "" {
+		return nil
+	}
+
+	checksum, err := r.computeChecksum(ctx, item)
+	if err != nil {
+		return &ReconcileIssue{
+			Type:     ISSUE_CHECKSUM_MISMATCH,
+			FileId:   item.UniqueId,
+			Path:     item.Path,
+			Expected: item.ChecksumSha256,
+			Error:    err.Error(),
+		}
+	}
+
+	if checksum.Sha256 != item.ChecksumSha256 {
+		return &ReconcileIssue{
+			Type:     ISSUE_CHECKSUM_MISMATCH,
+			FileId:   item.UniqueId,
+			Path:     item.Path,
+			Expected: item.ChecksumSha256,
+			Actual:   checksum.Sha256,
+		}
+	}
+	return nil
+}
+
+func (r *reconciler) computeChecksum(ctx context.Context, item repository.SearchFileItem) (*file.Checksum, error) {
+	param := filesystem.OpenFileParam{
+		Path:        item.Path,
+		Compression: item.Compression,
+	}
+	if item.EncryptionKeyId != "" {
+		param.EncryptionKey = &filesystem.EncryptionKey{
+			KeyId:   item.EncryptionKeyId,
+			DataKey: item.EncryptionDataKey,
+		}
+	}
+
+	open, err := r.fileManager.OpenFile(ctx, param)
+	if err != nil {
+		return nil, err
+	}
+	defer open.File.Close()
+
+	return file.ComputeChecksum(open.File)
+}
+
+// @note: the content is already gone so there is nothing to remove
+func (r *reconciler) repairDanglingRecord(ctx context.Context, issue *ReconcileIssue) {
+	_, err := r.fileRepo.DeleteFile(ctx, repository.DeleteFileParam{
+		UniqueId:  issue.FileId,
+		DeletedAt: r.clock.Now(),
+		DeleteFn: func(ctx context.Context, p repository.DeleteFnParam) error {
+			return nil
+		},
+	})
+	if err != nil {
+		issue.Error = err.Error()
+		return
+	}
+	issue.Repaired = true
+}
+
+func (r *reconciler) repairOrphanedBlob(ctx context.Context, issue *ReconcileIssue) {
+	_, err := r.fileManager.RemoveFile(ctx, filesystem.RemoveFileParam{
+		Path: issue.Path,
+	})
+	if err != nil {
+		issue.Error = err.Error()
+		return
+	}
+	issue.Repaired = true
+}
+
+// @note: the excluded directories (e.g: partial upload and trash) are skipped
+// @note: partial and trash directories are excluded since their content is not referenced by the records,
+// the directories are compared using the absolute path so they're matched regardless of how they're configured
+func (r *reconciler) walkBlobs() (map[string]*blob, error) {
+	excluded := map[string]bool{}
+	for _, dir := range []string{r.config.PartialDir, r.config.TrashDir} {
+		if dir == "" {
+			continue
+		}
+		abs, err := filepath.Abs(dir)
+		if err != nil {
+			return nil, err
+		}
+		excluded[abs] = true
+	}
+
+	blobs := map[string]*blob{}
+	err := filepath.WalkDir(r.config.UploadDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) && path == r.config.UploadDir {
+				return filepath.SkipDir
+			}
+			return err
+		}
+
+		if d.IsDir() {
+			abs, err := filepath.Abs(path)
+			if err != nil {
+				return err
+			}
+			if excluded[abs] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		blobs[filepath.Clean(path)] = &blob{
+			Size:       info.Size(),
+			ModifiedAt: info.ModTime(),
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return blobs, nil
+}
+
+type ReconcilerConfig struct {
+	Storage    string
+	UploadDir  string
+	PartialDir string
+	TrashDir   string
+	BatchSize  int32
+	OrphanAge  time.Duration
+}
+
+type ReconcilerParam struct {
+	FileRepo    repository.File
+	FileManager filesystem.FileManager
+	Clock       datetime.Clock
+	Config      *ReconcilerConfig
+}
+
+func NewReconciler(p ReconcilerParam) *reconciler {
+	config := *p.Config
+	if config.BatchSize <= 0 {
+		config.BatchSize = DEFAULT_BATCH_SIZE
+	}
+
+	return &reconciler{
+		fileRepo:    p.FileRepo,
+		fileManager: p.FileManager,
+		clock:       p.Clock,
+		config:      &config,
+	}
+}
@@ -0,0 +1,573 @@
+package reconcile_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-seidon/hippo/internal/encryption"
+	"github.com/go-seidon/hippo/internal/filesystem"
+	mock_filesystem "github.com/go-seidon/hippo/internal/filesystem/mock"
+	"github.com/go-seidon/hippo/internal/reconcile"
+	"github.com/go-seidon/hippo/internal/repository"
+	mock_repository "github.com/go-seidon/hippo/internal/repository/mock"
+	mock_datetime "github.com/go-seidon/provider/datetime/mock"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestReconcile(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Reconcile Package")
+}
+
+var _ = Describe("Reconciler", func() {
+	Context("Reconcile function", Label("unit"), func() {
+		var (
+			ctx         context.Context
+			currentTs   time.Time
+			uploadDir   string
+			fileRepo    *mock_repository.MockFile
+			fileManager *mock_filesystem.MockFileManager
+			clock       *mock_datetime.MockClock
+			reconciler  reconcile.Reconciler
+			searchParam repository.SearchFileParam
+		)
+
+		writeBlob := func(name, content string, modifiedAt time.Time) string {
+			path := filepath.Join(uploadDir, name)
+			err := os.MkdirAll(filepath.Dir(path), 0755)
+			Expect(err).To(BeNil())
+			err = os.WriteFile(path, []byte(content), 0644)
+			Expect(err).To(BeNil())
+			err = os.Chtimes(path, modifiedAt, modifiedAt)
+			Expect(err).To(BeNil())
+			return path
+		}
+
+		BeforeEach(func() {
+			var err error
+			ctx = context.Background()
+			currentTs = time.Now()
+			uploadDir, err = os.MkdirTemp("", "reconcile")
+			Expect(err).To(BeNil())
+			DeferCleanup(func() {
+				os.RemoveAll(uploadDir)
+			})
+
+			t := GinkgoT()
+			ctrl := gomock.NewController(t)
+			fileRepo = mock_repository.NewMockFile(ctrl)
+			fileManager = mock_filesystem.NewMockFileManager(ctrl)
+			clock = mock_datetime.NewMockClock(ctrl)
+			reconciler = reconcile.NewReconciler(reconcile.ReconcilerParam{
+				FileRepo:    fileRepo,
+				FileManager: fileManager,
+				Clock:       clock,
+				Config: &reconcile.ReconcilerConfig{
+					Storage:    "local",
+					UploadDir:  uploadDir,
+					PartialDir: filepath.Join(uploadDir, "partial"),
+					TrashDir:   filepath.Join(uploadDir, "trash"),
+					BatchSize:  2,
+					OrphanAge:  time.Hour,
+				},
+			})
+			searchParam = repository.SearchFileParam{
+				Limit:     2,
+				SortBy:    repository.FILE_SORT_ID,
+				SortOrder: repository.SORT_ASC,
+			}
+
+			clock.
+				EXPECT().
+				Now().
+				Return(currentTs).
+				AnyTimes()
+		})
+
+		When("storage is not local", func() {
+			It("should return error", func() {
+				reconciler = reconcile.NewReconciler(reconcile.ReconcilerParam{
+					FileRepo:    fileRepo,
+					FileManager: fileManager,
+					Clock:       clock,
+					Config: &reconcile.ReconcilerConfig{
+						Storage:   "s3",
+						UploadDir: uploadDir,
+					},
+				})
+
+				res, err := reconciler.Reconcile(ctx, reconcile.ReconcileParam{})
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("reconcile is only supported on local storage")))
+			})
+		})
+
+		When("excluded directories are configured relatively", func() {
+			It("should exclude them", func() {
+				wd, err := os.Getwd()
+				Expect(err).To(BeNil())
+				partialDir, err := filepath.Rel(wd, filepath.Join(uploadDir, "partial"))
+				Expect(err).To(BeNil())
+				trashDir, err := filepath.Rel(wd, filepath.Join(uploadDir, "trash"))
+				Expect(err).To(BeNil())
+
+				reconciler = reconcile.NewReconciler(reconcile.ReconcilerParam{
+					FileRepo:    fileRepo,
+					FileManager: fileManager,
+					Clock:       clock,
+					Config: &reconcile.ReconcilerConfig{
+						Storage:    "local",
+						UploadDir:  uploadDir,
+						PartialDir: partialDir,
+						TrashDir:   trashDir,
+						BatchSize:  2,
+						OrphanAge:  time.Hour,
+					},
+				})
+				oldTs := currentTs.Add(-2 * time.Hour)
+				writeBlob("partial/c.txt", "partial", oldTs)
+				writeBlob("trash/d.txt", "trash", oldTs)
+
+				fileRepo.
+					EXPECT().
+					SearchFile(gomock.Eq(ctx), gomock.Eq(searchParam)).
+					Return(&repository.SearchFileResult{
+						Items: []repository.SearchFileItem{},
+					}, nil).
+					Times(1)
+
+				res, err := reconciler.Reconcile(ctx, reconcile.ReconcileParam{})
+
+				Expect(err).To(BeNil())
+				Expect(res.Summary).To(Equal(reconcile.ReconcileSummary{}))
+				Expect(res.Issues).To(Equal([]reconcile.ReconcileIssue{}))
+			})
+		})
+
+		When("failed search file", func() {
+			It("should return error", func() {
+				fileRepo.
+					EXPECT().
+					SearchFile(gomock.Eq(ctx), gomock.Eq(searchParam)).
+					Return(nil, fmt.Errorf("db error")).
+					Times(1)
+
+				res, err := reconciler.Reconcile(ctx, reconcile.ReconcileParam{})
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("db error")))
+			})
+		})
+
+		When("storage and repository are consistent", func() {
+			It("should return result", func() {
+				oldTs := currentTs.Add(-2 * time.Hour)
+				pathA := writeBlob("2023/01/a.txt", "hello", oldTs)
+				pathB := writeBlob("2023/01/b.txt", "world!", oldTs)
+				writeBlob("partial/c.txt", "partial", oldTs)
+				writeBlob("trash/d.txt", "trash", oldTs)
+				deletedAt := currentTs
+
+				fileRepo.
+					EXPECT().
+					SearchFile(gomock.Eq(ctx), gomock.Eq(searchParam)).
+					Return(&repository.SearchFileResult{
+						Items: []repository.SearchFileItem{
+							{UniqueId: "a", Path: pathA, Size: 5},
+							{UniqueId: "b", Path: pathB, Size: 6},
+						},
+					}, nil).
+					Times(1)
+
+				secondParam := searchParam
+				secondParam.AfterId = "b"
+				fileRepo.
+					EXPECT().
+					SearchFile(gomock.Eq(ctx), gomock.Eq(secondParam)).
+					Return(&repository.SearchFileResult{
+						Items: []repository.SearchFileItem{
+							{UniqueId: "d", Path: filepath.Join(uploadDir, "2023/01/d.txt"), Size: 5, DeletedAt: &deletedAt},
+						},
+					}, nil).
+					Times(1)
+
+				res, err := reconciler.Reconcile(ctx, reconcile.ReconcileParam{Repair: true})
+
+				Expect(err).To(BeNil())
+				Expect(res.Repair).To(BeTrue())
+				Expect(res.Summary).To(Equal(reconcile.ReconcileSummary{
+					TotalRecords: 3,
+					TotalBlobs:   2,
+				}))
+				Expect(res.Issues).To(Equal([]reconcile.ReconcileIssue{}))
+				Expect(res.UnrepairedIssues()).To(Equal(int64(0)))
+			})
+		})
+
+		When("there are issues and repair is disabled", func() {
+			It("should only report the issues", func() {
+				oldTs := currentTs.Add(-2 * time.Hour)
+				pathA := writeBlob("2023/01/a.txt", "hello", oldTs)
+				pathB := writeBlob("2023/01/b.txt", "hello", oldTs)
+				pathOrphan := writeBlob("2023/01/orphan.txt", "orphan", oldTs)
+				writeBlob("2023/01/recent.txt", "recent", currentTs)
+				pathDangling := filepath.Join(uploadDir, "2023/01/dangling.txt")
+
+				fileRepo.
+					EXPECT().
+					SearchFile(gomock.Eq(ctx), gomock.Eq(searchParam)).
+					Return(&repository.SearchFileResult{
+						Items: []repository.SearchFileItem{
+							{UniqueId: "a", Path: pathA, Size: 10},
+							{
+								UniqueId:       "b",
+								Path:           pathB,
+								Size:           5,
+								ChecksumSha256: "invalid-checksum",
+							},
+						},
+					}, nil).
+					Times(1)
+
+				secondParam := searchParam
+				secondParam.AfterId = "b"
+				fileRepo.
+					EXPECT().
+					SearchFile(gomock.Eq(ctx), gomock.Eq(secondParam)).
+					Return(&repository.SearchFileResult{
+						Items: []repository.SearchFileItem{
+							{UniqueId: "c", Path: pathDangling, Size: 5},
+						},
+					}, nil).
+					Times(1)
+
+				fileManager.
+					EXPECT().
+					OpenFile(gomock.Eq(ctx), gomock.Eq(filesystem.OpenFileParam{Path: pathB})).
+					Return(&filesystem.OpenFileResult{
+						File: io.NopCloser(strings.NewReader("hello")),
+					}, nil).
+					Times(1)
+
+				res, err := reconciler.Reconcile(ctx, reconcile.ReconcileParam{VerifyChecksum: true})
+
+				Expect(err).To(BeNil())
+				Expect(res.Summary).To(Equal(reconcile.ReconcileSummary{
+					TotalRecords:       3,
+					TotalBlobs:         4,
+					OrphanedBlobs:      1,
+					DanglingRecords:    1,
+					SizeMismatches:     1,
+					ChecksumMismatches: 1,
+				}))
+				Expect(res.Issues).To(Equal([]reconcile.ReconcileIssue{
+					{
+						Type:     reconcile.ISSUE_SIZE_MISMATCH,
+						FileId:   "a",
+						Path:     pathA,
+						Expected: "10",
+						Actual:   "5",
+					},
+					{
+						Type:     reconcile.ISSUE_CHECKSUM_MISMATCH,
+						FileId:   "b",
+						Path:     pathB,
+						Expected: "invalid-checksum",
+						Actual:   "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",
+					},
+					{
+						Type:   reconcile.ISSUE_DANGLING_RECORD,
+						FileId: "c",
+						Path:   pathDangling,
+					},
+					{
+						Type:   reconcile.ISSUE_ORPHANED_BLOB,
+						Path:   pathOrphan,
+						Actual: "6",
+					},
+				}))
+				Expect(res.UnrepairedIssues()).To(Equal(int64(4)))
+			})
+		})
+
+		When("there are issues and repair is enabled", func() {
+			It("should repair the issues", func() {
+				oldTs := currentTs.Add(-2 * time.Hour)
+				pathOrphanA := writeBlob("2023/01/orphan-a.txt", "orphan", oldTs)
+				pathOrphanB := writeBlob("2023/01/orphan-b.txt", "orphan", oldTs)
+				pathOrphanC := writeBlob("2023/01/orphan-c.txt", "orphan", oldTs)
+				pathOrphanD := writeBlob("2023/01/orphan-d.txt", "orphan", oldTs)
+				pathDanglingA := filepath.Join(uploadDir, "2023/01/dangling-a.txt")
+				pathDanglingB := filepath.Join(uploadDir, "2023/01/dangling-b.txt")
+
+				fileRepo.
+					EXPECT().
+					SearchFile(gomock.Eq(ctx), gomock.Eq(searchParam)).
+					Return(&repository.SearchFileResult{
+						Items: []repository.SearchFileItem{
+							{UniqueId: "a", Path: pathDanglingA, Size: 5},
+							{UniqueId: "b", Path: pathDanglingB, Size: 5},
+						},
+					}, nil).
+					Times(1)
+
+				secondParam := searchParam
+				secondParam.AfterId = "b"
+				fileRepo.
+					EXPECT().
+					SearchFile(gomock.Eq(ctx), gomock.Eq(secondParam)).
+					Return(&repository.SearchFileResult{
+						Items: []repository.SearchFileItem{},
+					}, nil).
+					Times(1)
+
+				fileRepo.
+					EXPECT().
+					DeleteFile(gomock.Eq(ctx), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, p repository.DeleteFileParam) (*repository.DeleteFileResult, error) {
+						Expect(p.DeletedAt).To(Equal(currentTs))
+						Expect(p.DeleteFn(ctx, repository.DeleteFnParam{})).To(BeNil())
+						if p.UniqueId == "b" {
+							return nil, fmt.Errorf("db error")
+						}
+						return &repository.DeleteFileResult{DeletedAt: currentTs}, nil
+					}).
+					Times(2)
+
+				for _, path := range []string{pathOrphanA, pathOrphanB} {
+					fileRepo.
+						EXPECT().
+						SearchFile(gomock.Eq(ctx), gomock.Eq(repository.SearchFileParam{
+							Limit: 1,
+							Paths: []string{path},
+						})).
+						Return(&repository.SearchFileResult{
+							Items: []repository.SearchFileItem{},
+						}, nil).
+						Times(1)
+				}
+
+				fileRepo.
+					EXPECT().
+					SearchFile(gomock.Eq(ctx), gomock.Eq(repository.SearchFileParam{
+						Limit: 1,
+						Paths: []string{pathOrphanC},
+					})).
+					Return(&repository.SearchFileResult{
+						Items: []repository.SearchFileItem{
+							{UniqueId: "c", Path: pathOrphanC, Size: 6},
+						},
+					}, nil).
+					Times(1)
+
+				fileRepo.
+					EXPECT().
+					SearchFile(gomock.Eq(ctx), gomock.Eq(repository.SearchFileParam{
+						Limit: 1,
+						Paths: []string{pathOrphanD},
+					})).
+					Return(nil, fmt.Errorf("network error")).
+					Times(1)
+
+				fileManager.
+					EXPECT().
+					RemoveFile(gomock.Eq(ctx), gomock.Eq(filesystem.RemoveFileParam{Path: pathOrphanA})).
+					Return(&filesystem.RemoveFileResult{RemovedAt: currentTs}, nil).
+					Times(1)
+
+				fileManager.
+					EXPECT().
+					RemoveFile(gomock.Eq(ctx), gomock.Eq(filesystem.RemoveFileParam{Path: pathOrphanB})).
+					Return(nil, fmt.Errorf("disk error")).
+					Times(1)
+
+				res, err := reconciler.Reconcile(ctx, reconcile.ReconcileParam{Repair: true})
+
+				Expect(err).To(BeNil())
+				Expect(res.Summary).To(Equal(reconcile.ReconcileSummary{
+					TotalRecords:    2,
+					TotalBlobs:      4,
+					OrphanedBlobs:   3,
+					DanglingRecords: 2,
+					Repaired:        2,
+				}))
+				Expect(res.Issues).To(Equal([]reconcile.ReconcileIssue{
+					{
+						Type:     reconcile.ISSUE_DANGLING_RECORD,
+						FileId:   "a",
+						Path:     pathDanglingA,
+						Repaired: true,
+					},
+					{
+						Type:   reconcile.ISSUE_DANGLING_RECORD,
+						FileId: "b",
+						Path:   pathDanglingB,
+						Error:  "db error",
+					},
+					{
+						Type:     reconcile.ISSUE_ORPHANED_BLOB,
+						Path:     pathOrphanA,
+						Actual:   "6",
+						Repaired: true,
+					},
+					{
+						Type:   reconcile.ISSUE_ORPHANED_BLOB,
+						Path:   pathOrphanB,
+						Actual: "6",
+						Error:  "disk error",
+					},
+					{
+						Type:   reconcile.ISSUE_ORPHANED_BLOB,
+						Path:   pathOrphanD,
+						Actual: "6",
+						Error:  "network error",
+					},
+				}))
+				Expect(res.UnrepairedIssues()).To(Equal(int64(3)))
+			})
+		})
+
+		When("failed open file during checksum verification", func() {
+			It("should report checksum mismatch with error", func() {
+				oldTs := currentTs.Add(-2 * time.Hour)
+				pathA := writeBlob("a.txt", "hello", oldTs)
+
+				fileRepo.
+					EXPECT().
+					SearchFile(gomock.Eq(ctx), gomock.Eq(searchParam)).
+					Return(&repository.SearchFileResult{
+						Items: []repository.SearchFileItem{
+							{UniqueId: "a", Path: pathA, Size: 5, ChecksumSha256: "sha"},
+						},
+					}, nil).
+					Times(1)
+
+				fileManager.
+					EXPECT().
+					OpenFile(gomock.Eq(ctx), gomock.Eq(filesystem.OpenFileParam{Path: pathA})).
+					Return(nil, fmt.Errorf("disk error")).
+					Times(1)
+
+				res, err := reconciler.Reconcile(ctx, reconcile.ReconcileParam{VerifyChecksum: true})
+
+				Expect(err).To(BeNil())
+				Expect(res.Summary.ChecksumMismatches).To(Equal(int64(1)))
+				Expect(res.Issues).To(Equal([]reconcile.ReconcileIssue{
+					{
+						Type:     reconcile.ISSUE_CHECKSUM_MISMATCH,
+						FileId:   "a",
+						Path:     pathA,
+						Expected: "sha",
+						Error:    "disk error",
+					},
+				}))
+			})
+		})
+
+		When("file is encrypted", func() {
+			It("should verify the encrypted size and decrypted checksum", func() {
+				oldTs := currentTs.Add(-2 * time.Hour)
+				pathA := writeBlob("a.txt", strings.Repeat("x", int(encryption.EncryptedSize(5))), oldTs)
+				key := &filesystem.EncryptionKey{
+					KeyId:   "key-1",
+					DataKey: "mock-data-key",
+				}
+
+				fileRepo.
+					EXPECT().
+					SearchFile(gomock.Eq(ctx), gomock.Eq(searchParam)).
+					Return(&repository.SearchFileResult{
+						Items: []repository.SearchFileItem{
+							{
+								UniqueId:          "a",
+								Path:              pathA,
+								Size:              5,
+								ChecksumSha256:    "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",
+								EncryptionKeyId:   key.KeyId,
+								EncryptionDataKey: key.DataKey,
+							},
+						},
+					}, nil).
+					Times(1)
+
+				fileManager.
+					EXPECT().
+					OpenFile(gomock.Eq(ctx), gomock.Eq(filesystem.OpenFileParam{Path: pathA, EncryptionKey: key})).
+					Return(&filesystem.OpenFileResult{
+						File: io.NopCloser(strings.NewReader("hello")),
+					}, nil).
+					Times(1)
+
+				res, err := reconciler.Reconcile(ctx, reconcile.ReconcileParam{VerifyChecksum: true})
+
+				Expect(err).To(BeNil())
+				Expect(res.Issues).To(Equal([]reconcile.ReconcileIssue{}))
+			})
+		})
+
+		When("file is compressed", func() {
+			It("should verify the stored size and decompressed checksum", func() {
+				oldTs := currentTs.Add(-2 * time.Hour)
+				pathA := writeBlob("a.txt", "xxx", oldTs)
+
+				fileRepo.
+					EXPECT().
+					SearchFile(gomock.Eq(ctx), gomock.Eq(searchParam)).
+					Return(&repository.SearchFileResult{
+						Items: []repository.SearchFileItem{
+							{
+								UniqueId:       "a",
+								Path:           pathA,
+								Size:           5,
+								StoredSize:     3,
+								ChecksumSha256: "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",
+								Compression:    "gzip",
+							},
+						},
+					}, nil).
+					Times(1)
+
+				fileManager.
+					EXPECT().
+					OpenFile(gomock.Eq(ctx), gomock.Eq(filesystem.OpenFileParam{Path: pathA, Compression: "gzip"})).
+					Return(&filesystem.OpenFileResult{
+						File: io.NopCloser(strings.NewReader("hello")),
+					}, nil).
+					Times(1)
+
+				res, err := reconciler.Reconcile(ctx, reconcile.ReconcileParam{VerifyChecksum: true})
+
+				Expect(err).To(BeNil())
+				Expect(res.Issues).To(Equal([]reconcile.ReconcileIssue{}))
+			})
+		})
+
+		When("upload directory does not exist", func() {
+			It("should return result", func() {
+				os.RemoveAll(uploadDir)
+
+				fileRepo.
+					EXPECT().
+					SearchFile(gomock.Eq(ctx), gomock.Eq(searchParam)).
+					Return(&repository.SearchFileResult{
+						Items: []repository.SearchFileItem{},
+					}, nil).
+					Times(1)
+
+				res, err := reconciler.Reconcile(ctx, reconcile.ReconcileParam{})
+
+				Expect(err).To(BeNil())
+				Expect(res.Summary).To(Equal(reconcile.ReconcileSummary{}))
+			})
+		})
+	})
+})
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"fmt"
 	"time"
 )
 
@@ -10,6 +11,68 @@ type Auth interface {
 	FindClient(ctx context.Context, p FindClientParam) (*FindClientResult, error)
 	UpdateClient(ctx context.Context, p UpdateClientParam) (*UpdateClientResult, error)
 	SearchClient(ctx context.Context, p SearchClientParam) (*SearchClientResult, error)
+	UpdateClientUsage(ctx context.Context, p UpdateClientUsageParam) (*UpdateClientUsageResult, error)
+	RotateClientSecret(ctx context.Context, p RotateClientSecretParam) (*RotateClientSecretResult, error)
+	RevokeClientSecret(ctx context.Context, p RevokeClientSecretParam) (*RevokeClientSecretResult, error)
+	DeleteClient(ctx context.Context, p DeleteClientParam) (*DeleteClientResult, error)
+}
+
+// @note: zero limit is unlimited
+type ClientQuota struct {
+	MaxStoredSize   int64
+	MaxTotalFiles   int64
+	MaxFileSize     int64
+	MaxDailyUploads int64
+}
+
+// @note: daily uploads is counted from the start of the day (utc) specified in daily at
+type ClientUsage struct {
+	StoredSize   int64
+	TotalFiles   int64
+	DailyUploads int64
+	DailyAt      time.Time
+}
+
+// @note: daily uploads is restarted when the usage is on the other day
+// and the usage is never decreased below zero
+func (u ClientUsage) Add(p UpdateClientUsageParam) ClientUsage {
+	day := p.UsedAt.UTC().Truncate(24 * time.Hour)
+	usage := ClientUsage{
+		StoredSize:   u.StoredSize + p.StoredSize,
+		TotalFiles:   u.TotalFiles + p.TotalFiles,
+		DailyUploads: u.DailyUploads + p.DailyUploads,
+		DailyAt:      u.DailyAt,
+	}
+	if !u.DailyAt.Equal(day) {
+		usage.DailyUploads = p.DailyUploads
+		usage.DailyAt = day
+	}
+
+	if usage.StoredSize < 0 {
+		usage.StoredSize = 0
+	}
+	if usage.TotalFiles < 0 {
+		usage.TotalFiles = 0
+	}
+	if usage.DailyUploads < 0 {
+		usage.DailyUploads = 0
+	}
+	return usage
+}
+
+// @note: return `ErrExceeded` when the usage is above the quota,
+// max file size is checked by the uploader since it's not part of the usage
+func (q ClientQuota) Check(u ClientUsage) error {
+	if q.MaxStoredSize > 0 && u.StoredSize > q.MaxStoredSize {
+		return fmt.Errorf("%w: max stored size", ErrExceeded)
+	}
+	if q.MaxTotalFiles > 0 && u.TotalFiles > q.MaxTotalFiles {
+		return fmt.Errorf("%w: max total files", ErrExceeded)
+	}
+	if q.MaxDailyUploads > 0 && u.DailyUploads > q.MaxDailyUploads {
+		return fmt.Errorf("%w: max daily uploads", ErrExceeded)
+	}
+	return nil
 }
 
 type CreateClientParam struct {
@@ -19,6 +82,8 @@ type CreateClientParam struct {
 	Name         string
 	Type         string
 	Status       string
+	Scopes       []string
+	Quota        ClientQuota
 	CreatedAt    time.Time
 }
 
@@ -44,16 +109,35 @@ type FindClientResult struct {
 	Name         string
 	Type         string
 	Status       string
-	CreatedAt    time.Time
-	UpdatedAt    *time.Time
+	Scopes       []string
+	Quota        ClientQuota
+	Usage        ClientUsage
+	// @note: previous secret is accepted until it's expired
+	PreviousClientSecret    string
+	PreviousSecretExpiresAt *time.Time
+	SecretRotatedAt         *time.Time
+	CreatedAt               time.Time
+	UpdatedAt               *time.Time
+}
+
+// @note: previous secret is only valid when it's available and not expired yet
+func (r *FindClientResult) IsPreviousSecretValid(now time.Time) bool {
+	if r.PreviousClientSecret == "" || r.PreviousSecretExpiresAt == nil {
+		return false
+	}
+	return now.Before(*r.PreviousSecretExpiresAt)
 }
 
 type UpdateClientParam struct {
-	Id        string
-	ClientId  string
-	Name      string
-	Type      string
-	Status    string
+	Id       string
+	ClientId string
+	Name     string
+	Type     string
+	Status   string
+	// @note: current scopes are kept when it's nil
+	Scopes []string
+	// @note: current quota is kept when it's not specified
+	Quota     *ClientQuota
 	UpdatedAt time.Time
 }
 
@@ -94,3 +178,59 @@ type SearchClientItem struct {
 	CreatedAt    time.Time
 	UpdatedAt    *time.Time
 }
+
+// @note: negative value decreases the usage,
+// the client is identified by the record id since the client_id may be reused
+type UpdateClientUsageParam struct {
+	Id           string
+	StoredSize   int64
+	TotalFiles   int64
+	DailyUploads int64
+	UsedAt       time.Time
+	// @note: return `ErrExceeded` (and the usage is not updated) when the updated usage is above the quota
+	CheckQuota bool
+}
+
+type UpdateClientUsageResult struct {
+	Quota ClientQuota
+	Usage ClientUsage
+}
+
+// @note: current secret is kept as the previous secret until the expiry
+type RotateClientSecretParam struct {
+	Id                      string
+	ClientSecret            string
+	PreviousSecretExpiresAt time.Time
+	RotatedAt               time.Time
+}
+
+type RotateClientSecretResult struct {
+	Id                      string
+	ClientId                string
+	PreviousSecretExpiresAt time.Time
+	SecretRotatedAt         time.Time
+}
+
+type RevokeClientSecretParam struct {
+	Id        string
+	RevokedAt time.Time
+}
+
+type RevokeClientSecretResult struct {
+	Id        string
+	ClientId  string
+	RevokedAt time.Time
+}
+
+// @note: client is soft deleted, it's excluded from the other operations
+// and the client_id is available to be reused
+type DeleteClientParam struct {
+	Id        string
+	DeletedAt time.Time
+}
+
+type DeleteClientResult struct {
+	Id        string
+	ClientId  string
+	DeletedAt time.Time
+}
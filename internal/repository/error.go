@@ -5,6 +5,9 @@ import "errors"
 var (
 	ErrNotFound     = errors.New("record not found")
 	ErrDeleted      = errors.New("record deleted")
+	ErrNotDeleted   = errors.New("record not deleted")
 	ErrInvalidParam = errors.New("invalid param")
 	ErrExists       = errors.New("resource already exists")
+	ErrConflict     = errors.New("resource conflict")
+	ErrExceeded     = errors.New("quota exceeded")
 )
@@ -5,15 +5,36 @@ import (
 	"time"
 )
 
+const (
+	FILE_STATUS_AVAILABLE = "available"
+	FILE_STATUS_DELETED   = "deleted"
+
+	FILE_SORT_NAME        = "name"
+	FILE_SORT_SIZE        = "size"
+	FILE_SORT_UPLOADED_AT = "uploaded_at"
+	FILE_SORT_ID          = "id"
+
+	SORT_ASC  = "asc"
+	SORT_DESC = "desc"
+)
+
 type (
-	DeleteFn func(ctx context.Context, p DeleteFnParam) error
-	CreateFn func(ctx context.Context, p CreateFnParam) error
+	DeleteFn    func(ctx context.Context, p DeleteFnParam) error
+	CreateFn    func(ctx context.Context, p CreateFnParam) (*CreateFnResult, error)
+	DuplicateFn func(ctx context.Context, p DuplicateFnParam) error
+	RestoreFn   func(ctx context.Context, p RestoreFnParam) error
+	PurgeFn     func(ctx context.Context, p PurgeFnParam) error
 )
 
 type File interface {
 	CreateFile(ctx context.Context, p CreateFileParam) (*CreateFileResult, error)
 	RetrieveFile(ctx context.Context, p RetrieveFileParam) (*RetrieveFileResult, error)
 	DeleteFile(ctx context.Context, p DeleteFileParam) (*DeleteFileResult, error)
+	RestoreFile(ctx context.Context, p RestoreFileParam) (*RestoreFileResult, error)
+	SearchFile(ctx context.Context, p SearchFileParam) (*SearchFileResult, error)
+	PurgeFile(ctx context.Context, p PurgeFileParam) (*PurgeFileResult, error)
+	SearchFileKey(ctx context.Context, p SearchFileKeyParam) (*SearchFileKeyResult, error)
+	UpdateFileKey(ctx context.Context, p UpdateFileKeyParam) (*UpdateFileKeyResult, error)
 }
 
 type CreateFileParam struct {
@@ -25,20 +46,46 @@ type CreateFileParam struct {
 	Size      int64
 	CreatedAt time.Time
 	CreateFn  CreateFn
+	// @note: id of the auth client record which uploaded the file (not the client_id),
+	// empty when it's not uploaded by a client
+	OwnerClientId string
+	// @note: when deduplication is enabled identical content is stored once
+	// the written file is passed to DuplicateFn when the content is already stored
+	Deduplicate bool
+	DuplicateFn DuplicateFn
 }
 
 type CreateFnParam struct {
 	FilePath string
 }
 
+// @note: encryption key is only available when the written file is encrypted
+// and compression is only available when the written file is compressed
+type CreateFnResult struct {
+	Size              int64
+	StoredSize        int64
+	ChecksumSha256    string
+	ChecksumMd5       string
+	EncryptionKeyId   string
+	EncryptionDataKey string
+	Compression       string
+}
+
+type DuplicateFnParam struct {
+	FilePath string
+	BlobPath string
+}
+
 type CreateFileResult struct {
-	UniqueId  string
-	Name      string
-	Path      string
-	Mimetype  string
-	Extension string
-	Size      int64
-	CreatedAt time.Time
+	UniqueId       string
+	Name           string
+	Path           string
+	Mimetype       string
+	Extension      string
+	Size           int64
+	CreatedAt      time.Time
+	ChecksumSha256 string
+	ChecksumMd5    string
 }
 
 type RetrieveFileParam struct {
@@ -46,14 +93,21 @@ type RetrieveFileParam struct {
 }
 
 type RetrieveFileResult struct {
-	UniqueId  string
-	Name      string
-	Path      string
-	Mimetype  string
-	Extension string
-	Size      int64
-	CreatedAt time.Time
-	DeletedAt *time.Time
+	UniqueId          string
+	Name              string
+	Path              string
+	Mimetype          string
+	Extension         string
+	Size              int64
+	CreatedAt         time.Time
+	DeletedAt         *time.Time
+	ChecksumSha256    string
+	ChecksumMd5       string
+	EncryptionKeyId   string
+	EncryptionDataKey string
+	StoredSize        int64
+	Compression       string
+	OwnerClientId     string
 }
 
 type DeleteFileParam struct {
@@ -62,10 +116,131 @@ type DeleteFileParam struct {
 	DeleteFn  DeleteFn
 }
 
+// @note: references is the number of remaining files sharing the same stored content
 type DeleteFnParam struct {
-	FilePath string
+	FilePath   string
+	References int64
 }
 
 type DeleteFileResult struct {
 	DeletedAt time.Time
 }
+
+// @note: when deduplication is enabled the content is registered again
+// if it's no longer referenced by other files
+type RestoreFileParam struct {
+	UniqueId    string
+	RestoredAt  time.Time
+	Deduplicate bool
+	RestoreFn   RestoreFn
+}
+
+// @note: references is the number of other files sharing the same stored content
+// the content is still stored when it's referenced by other files
+type RestoreFnParam struct {
+	FilePath   string
+	References int64
+}
+
+type RestoreFileResult struct {
+	RestoredAt time.Time
+}
+
+type SearchFileParam struct {
+	Limit        int32
+	Offset       int64
+	Keyword      string
+	Mimetypes    []string
+	Extensions   []string
+	MinSize      *int64
+	MaxSize      *int64
+	UploadedFrom *time.Time
+	UploadedTo   *time.Time
+	Statuses     []string
+	SortBy       string
+	SortOrder    string
+	// @note: files are not filtered by the owner when it's empty,
+	// empty owner client id matches the unowned files
+	OwnerClientIds []string
+	// @note: files are filtered by the stored path when it's specified
+	Paths []string
+	// @note: keyset pagination, only files after the id are searched,
+	// it's expected to be sorted by the id instead of using the offset
+	AfterId string
+}
+
+type SearchFileResult struct {
+	Summary SearchFileSummary
+	Items   []SearchFileItem
+}
+
+type SearchFileSummary struct {
+	TotalItems int64
+}
+
+type SearchFileItem struct {
+	UniqueId          string
+	Name              string
+	Path              string
+	Mimetype          string
+	Extension         string
+	Size              int64
+	CreatedAt         time.Time
+	DeletedAt         *time.Time
+	ChecksumSha256    string
+	ChecksumMd5       string
+	EncryptionKeyId   string
+	EncryptionDataKey string
+	StoredSize        int64
+	Compression       string
+}
+
+// @note: hard delete at most limit of the files deleted before the given time
+// and PurgeFn is called once for every path of the removed files
+type PurgeFileParam struct {
+	DeletedBefore time.Time
+	Limit         int32
+	PurgeFn       PurgeFn
+}
+
+// @note: references is the number of remaining files with the same path
+type PurgeFnParam struct {
+	FilePath   string
+	References int64
+}
+
+type PurgeFileResult struct {
+	TotalItems int64
+}
+
+// @note: search encrypted files (including the deleted ones) ordered by id
+// whose data key is not wrapped by the excluded key
+type SearchFileKeyParam struct {
+	ExcludeKeyId string
+	AfterId      string
+	Limit        int32
+}
+
+type SearchFileKeyResult struct {
+	Items []SearchFileKeyItem
+}
+
+type SearchFileKeyItem struct {
+	UniqueId          string
+	EncryptionKeyId   string
+	EncryptionDataKey string
+}
+
+// @note: the key is only updated when the current data key is unchanged,
+// ErrNotFound is returned otherwise
+type UpdateFileKeyParam struct {
+	UniqueId          string
+	CurrentDataKey    string
+	EncryptionKeyId   string
+	EncryptionDataKey string
+	UpdatedAt         time.Time
+}
+
+type UpdateFileKeyResult struct {
+	UpdatedAt time.Time
+}
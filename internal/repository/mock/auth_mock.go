@@ -50,6 +50,21 @@ func (mr *MockAuthMockRecorder) CreateClient(ctx, p interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateClient", reflect.TypeOf((*MockAuth)(nil).CreateClient), ctx, p)
 }
 
+// DeleteClient mocks base method.
+func (m *MockAuth) DeleteClient(ctx context.Context, p repository.DeleteClientParam) (*repository.DeleteClientResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteClient", ctx, p)
+	ret0, _ := ret[0].(*repository.DeleteClientResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteClient indicates an expected call of DeleteClient.
+func (mr *MockAuthMockRecorder) DeleteClient(ctx, p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteClient", reflect.TypeOf((*MockAuth)(nil).DeleteClient), ctx, p)
+}
+
 // FindClient mocks base method.
 func (m *MockAuth) FindClient(ctx context.Context, p repository.FindClientParam) (*repository.FindClientResult, error) {
 	m.ctrl.T.Helper()
@@ -65,6 +80,36 @@ func (mr *MockAuthMockRecorder) FindClient(ctx, p interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindClient", reflect.TypeOf((*MockAuth)(nil).FindClient), ctx, p)
 }
 
+// RevokeClientSecret mocks base method.
+func (m *MockAuth) RevokeClientSecret(ctx context.Context, p repository.RevokeClientSecretParam) (*repository.RevokeClientSecretResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeClientSecret", ctx, p)
+	ret0, _ := ret[0].(*repository.RevokeClientSecretResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeClientSecret indicates an expected call of RevokeClientSecret.
+func (mr *MockAuthMockRecorder) RevokeClientSecret(ctx, p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeClientSecret", reflect.TypeOf((*MockAuth)(nil).RevokeClientSecret), ctx, p)
+}
+
+// RotateClientSecret mocks base method.
+func (m *MockAuth) RotateClientSecret(ctx context.Context, p repository.RotateClientSecretParam) (*repository.RotateClientSecretResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateClientSecret", ctx, p)
+	ret0, _ := ret[0].(*repository.RotateClientSecretResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateClientSecret indicates an expected call of RotateClientSecret.
+func (mr *MockAuthMockRecorder) RotateClientSecret(ctx, p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateClientSecret", reflect.TypeOf((*MockAuth)(nil).RotateClientSecret), ctx, p)
+}
+
 // SearchClient mocks base method.
 func (m *MockAuth) SearchClient(ctx context.Context, p repository.SearchClientParam) (*repository.SearchClientResult, error) {
 	m.ctrl.T.Helper()
@@ -94,3 +139,18 @@ func (mr *MockAuthMockRecorder) UpdateClient(ctx, p interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateClient", reflect.TypeOf((*MockAuth)(nil).UpdateClient), ctx, p)
 }
+
+// UpdateClientUsage mocks base method.
+func (m *MockAuth) UpdateClientUsage(ctx context.Context, p repository.UpdateClientUsageParam) (*repository.UpdateClientUsageResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateClientUsage", ctx, p)
+	ret0, _ := ret[0].(*repository.UpdateClientUsageResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateClientUsage indicates an expected call of UpdateClientUsage.
+func (mr *MockAuthMockRecorder) UpdateClientUsage(ctx, p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateClientUsage", reflect.TypeOf((*MockAuth)(nil).UpdateClientUsage), ctx, p)
+}
@@ -65,6 +65,36 @@ func (mr *MockFileMockRecorder) DeleteFile(ctx, p interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFile", reflect.TypeOf((*MockFile)(nil).DeleteFile), ctx, p)
 }
 
+// PurgeFile mocks base method.
+func (m *MockFile) PurgeFile(ctx context.Context, p repository.PurgeFileParam) (*repository.PurgeFileResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeFile", ctx, p)
+	ret0, _ := ret[0].(*repository.PurgeFileResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeFile indicates an expected call of PurgeFile.
+func (mr *MockFileMockRecorder) PurgeFile(ctx, p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeFile", reflect.TypeOf((*MockFile)(nil).PurgeFile), ctx, p)
+}
+
+// RestoreFile mocks base method.
+func (m *MockFile) RestoreFile(ctx context.Context, p repository.RestoreFileParam) (*repository.RestoreFileResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreFile", ctx, p)
+	ret0, _ := ret[0].(*repository.RestoreFileResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreFile indicates an expected call of RestoreFile.
+func (mr *MockFileMockRecorder) RestoreFile(ctx, p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreFile", reflect.TypeOf((*MockFile)(nil).RestoreFile), ctx, p)
+}
+
 // RetrieveFile mocks base method.
 func (m *MockFile) RetrieveFile(ctx context.Context, p repository.RetrieveFileParam) (*repository.RetrieveFileResult, error) {
 	m.ctrl.T.Helper()
@@ -79,3 +109,48 @@ func (mr *MockFileMockRecorder) RetrieveFile(ctx, p interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetrieveFile", reflect.TypeOf((*MockFile)(nil).RetrieveFile), ctx, p)
 }
+
+// SearchFile mocks base method.
+func (m *MockFile) SearchFile(ctx context.Context, p repository.SearchFileParam) (*repository.SearchFileResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchFile", ctx, p)
+	ret0, _ := ret[0].(*repository.SearchFileResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchFile indicates an expected call of SearchFile.
+func (mr *MockFileMockRecorder) SearchFile(ctx, p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchFile", reflect.TypeOf((*MockFile)(nil).SearchFile), ctx, p)
+}
+
+// SearchFileKey mocks base method.
+func (m *MockFile) SearchFileKey(ctx context.Context, p repository.SearchFileKeyParam) (*repository.SearchFileKeyResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchFileKey", ctx, p)
+	ret0, _ := ret[0].(*repository.SearchFileKeyResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchFileKey indicates an expected call of SearchFileKey.
+func (mr *MockFileMockRecorder) SearchFileKey(ctx, p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchFileKey", reflect.TypeOf((*MockFile)(nil).SearchFileKey), ctx, p)
+}
+
+// UpdateFileKey mocks base method.
+func (m *MockFile) UpdateFileKey(ctx context.Context, p repository.UpdateFileKeyParam) (*repository.UpdateFileKeyResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFileKey", ctx, p)
+	ret0, _ := ret[0].(*repository.UpdateFileKeyResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateFileKey indicates an expected call of UpdateFileKey.
+func (mr *MockFileMockRecorder) UpdateFileKey(ctx, p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFileKey", reflect.TypeOf((*MockFile)(nil).UpdateFileKey), ctx, p)
+}
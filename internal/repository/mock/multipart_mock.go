@@ -0,0 +1,141 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/multipart.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	repository "github.com/go-seidon/hippo/internal/repository"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockMultipart is a mock of Multipart interface.
+type MockMultipart struct {
+	ctrl     *gomock.Controller
+	recorder *MockMultipartMockRecorder
+}
+
+// MockMultipartMockRecorder is the mock recorder for MockMultipart.
+type MockMultipartMockRecorder struct {
+	mock *MockMultipart
+}
+
+// NewMockMultipart creates a new mock instance.
+func NewMockMultipart(ctrl *gomock.Controller) *MockMultipart {
+	mock := &MockMultipart{ctrl: ctrl}
+	mock.recorder = &MockMultipartMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMultipart) EXPECT() *MockMultipartMockRecorder {
+	return m.recorder
+}
+
+// CompleteMultipart mocks base method.
+func (m *MockMultipart) CompleteMultipart(ctx context.Context, p repository.CompleteMultipartParam) (*repository.CompleteMultipartResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteMultipart", ctx, p)
+	ret0, _ := ret[0].(*repository.CompleteMultipartResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteMultipart indicates an expected call of CompleteMultipart.
+func (mr *MockMultipartMockRecorder) CompleteMultipart(ctx, p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteMultipart", reflect.TypeOf((*MockMultipart)(nil).CompleteMultipart), ctx, p)
+}
+
+// CreateMultipart mocks base method.
+func (m *MockMultipart) CreateMultipart(ctx context.Context, p repository.CreateMultipartParam) (*repository.CreateMultipartResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMultipart", ctx, p)
+	ret0, _ := ret[0].(*repository.CreateMultipartResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMultipart indicates an expected call of CreateMultipart.
+func (mr *MockMultipartMockRecorder) CreateMultipart(ctx, p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMultipart", reflect.TypeOf((*MockMultipart)(nil).CreateMultipart), ctx, p)
+}
+
+// DeleteMultipart mocks base method.
+func (m *MockMultipart) DeleteMultipart(ctx context.Context, p repository.DeleteMultipartParam) (*repository.DeleteMultipartResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMultipart", ctx, p)
+	ret0, _ := ret[0].(*repository.DeleteMultipartResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteMultipart indicates an expected call of DeleteMultipart.
+func (mr *MockMultipartMockRecorder) DeleteMultipart(ctx, p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMultipart", reflect.TypeOf((*MockMultipart)(nil).DeleteMultipart), ctx, p)
+}
+
+// ListPart mocks base method.
+func (m *MockMultipart) ListPart(ctx context.Context, p repository.ListPartParam) (*repository.ListPartResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPart", ctx, p)
+	ret0, _ := ret[0].(*repository.ListPartResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPart indicates an expected call of ListPart.
+func (mr *MockMultipartMockRecorder) ListPart(ctx, p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPart", reflect.TypeOf((*MockMultipart)(nil).ListPart), ctx, p)
+}
+
+// RetrieveMultipart mocks base method.
+func (m *MockMultipart) RetrieveMultipart(ctx context.Context, p repository.RetrieveMultipartParam) (*repository.RetrieveMultipartResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetrieveMultipart", ctx, p)
+	ret0, _ := ret[0].(*repository.RetrieveMultipartResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RetrieveMultipart indicates an expected call of RetrieveMultipart.
+func (mr *MockMultipartMockRecorder) RetrieveMultipart(ctx, p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetrieveMultipart", reflect.TypeOf((*MockMultipart)(nil).RetrieveMultipart), ctx, p)
+}
+
+// SearchMultipart mocks base method.
+func (m *MockMultipart) SearchMultipart(ctx context.Context, p repository.SearchMultipartParam) (*repository.SearchMultipartResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchMultipart", ctx, p)
+	ret0, _ := ret[0].(*repository.SearchMultipartResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchMultipart indicates an expected call of SearchMultipart.
+func (mr *MockMultipartMockRecorder) SearchMultipart(ctx, p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchMultipart", reflect.TypeOf((*MockMultipart)(nil).SearchMultipart), ctx, p)
+}
+
+// UploadPart mocks base method.
+func (m *MockMultipart) UploadPart(ctx context.Context, p repository.UploadPartParam) (*repository.UploadPartResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadPart", ctx, p)
+	ret0, _ := ret[0].(*repository.UploadPartResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadPart indicates an expected call of UploadPart.
+func (mr *MockMultipartMockRecorder) UploadPart(ctx, p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadPart", reflect.TypeOf((*MockMultipart)(nil).UploadPart), ctx, p)
+}
@@ -63,6 +63,34 @@ func (mr *MockRepositoryMockRecorder) GetFile() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFile", reflect.TypeOf((*MockRepository)(nil).GetFile))
 }
 
+// GetMultipart mocks base method.
+func (m *MockRepository) GetMultipart() repository.Multipart {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMultipart")
+	ret0, _ := ret[0].(repository.Multipart)
+	return ret0
+}
+
+// GetMultipart indicates an expected call of GetMultipart.
+func (mr *MockRepositoryMockRecorder) GetMultipart() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMultipart", reflect.TypeOf((*MockRepository)(nil).GetMultipart))
+}
+
+// GetUpload mocks base method.
+func (m *MockRepository) GetUpload() repository.Upload {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUpload")
+	ret0, _ := ret[0].(repository.Upload)
+	return ret0
+}
+
+// GetUpload indicates an expected call of GetUpload.
+func (mr *MockRepositoryMockRecorder) GetUpload() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUpload", reflect.TypeOf((*MockRepository)(nil).GetUpload))
+}
+
 // Init mocks base method.
 func (m *MockRepository) Init(ctx context.Context) error {
 	m.ctrl.T.Helper()
@@ -39,6 +39,10 @@ func (r *auth) CreateClient(ctx context.Context, p repository.CreateClientParam)
 			Key:   "client_id",
 			Value: p.ClientId,
 		},
+		{
+			Key:   "deleted_at",
+			Value: nil,
+		},
 	}).Decode(&currentClient)
 	if !errors.Is(err, mongo.ErrNoDocuments) {
 		if err == nil {
@@ -72,6 +76,14 @@ func (r *auth) CreateClient(ctx context.Context, p repository.CreateClientParam)
 			Key:   "status",
 			Value: p.Status,
 		},
+		{
+			Key:   "scopes",
+			Value: newScopes(p.Scopes),
+		},
+		{
+			Key:   "quota",
+			Value: newQuotaDoc(p.Quota),
+		},
 		{
 			Key:   "created_at",
 			Value: p.CreatedAt,
@@ -136,6 +148,7 @@ func (r *auth) FindClient(ctx context.Context, p repository.FindClientParam) (*r
 			},
 		}
 	}
+	filter = append(filter, primitive.E{Key: "deleted_at", Value: nil})
 
 	projection := options.FindOne().SetProjection(bson.D{
 		{
@@ -162,6 +175,30 @@ func (r *auth) FindClient(ctx context.Context, p repository.FindClientParam) (*r
 			Key:   "client_secret",
 			Value: 1,
 		},
+		{
+			Key:   "scopes",
+			Value: 1,
+		},
+		{
+			Key:   "quota",
+			Value: 1,
+		},
+		{
+			Key:   "usage",
+			Value: 1,
+		},
+		{
+			Key:   "previous_client_secret",
+			Value: 1,
+		},
+		{
+			Key:   "previous_secret_expires_at",
+			Value: 1,
+		},
+		{
+			Key:   "secret_rotated_at",
+			Value: 1,
+		},
 		{
 			Key:   "created_at",
 			Value: 1,
@@ -173,14 +210,20 @@ func (r *auth) FindClient(ctx context.Context, p repository.FindClientParam) (*r
 	})
 
 	client := struct {
-		Id           string     `bson:"_id"`
-		Name         string     `bson:"name"`
-		Type         string     `bson:"type"`
-		Status       string     `bson:"status"`
-		ClientId     string     `bson:"client_id"`
-		ClientSecret string     `bson:"client_secret"`
-		CreatedAt    time.Time  `bson:"created_at"`
-		UpdatedAt    *time.Time `bson:"updated_at"`
+		Id                      string     `bson:"_id"`
+		Name                    string     `bson:"name"`
+		Type                    string     `bson:"type"`
+		Status                  string     `bson:"status"`
+		ClientId                string     `bson:"client_id"`
+		ClientSecret            string     `bson:"client_secret"`
+		Scopes                  []string   `bson:"scopes"`
+		Quota                   quotaDoc   `bson:"quota"`
+		Usage                   *usageDoc  `bson:"usage"`
+		PreviousClientSecret    string     `bson:"previous_client_secret"`
+		PreviousSecretExpiresAt *time.Time `bson:"previous_secret_expires_at"`
+		SecretRotatedAt         *time.Time `bson:"secret_rotated_at"`
+		CreatedAt               time.Time  `bson:"created_at"`
+		UpdatedAt               *time.Time `bson:"updated_at"`
 	}{}
 	err := cl.FindOne(ctx, filter, projection).Decode(&client)
 	if err != nil {
@@ -197,9 +240,17 @@ func (r *auth) FindClient(ctx context.Context, p repository.FindClientParam) (*r
 		Status:       client.Status,
 		ClientId:     client.ClientId,
 		ClientSecret: client.ClientSecret,
+		Scopes:       client.Scopes,
+		Quota:        client.Quota.quota(),
+		Usage:        client.Usage.usage(),
 		CreatedAt:    client.CreatedAt,
 		UpdatedAt:    client.UpdatedAt,
 	}
+	if client.PreviousClientSecret != "" {
+		res.PreviousClientSecret = client.PreviousClientSecret
+		res.PreviousSecretExpiresAt = client.PreviousSecretExpiresAt
+	}
+	res.SecretRotatedAt = client.SecretRotatedAt
 	return res, nil
 }
 
@@ -220,6 +271,10 @@ func (r *auth) UpdateClient(ctx context.Context, p repository.UpdateClientParam)
 			Key:   "_id",
 			Value: p.Id,
 		},
+		{
+			Key:   "deleted_at",
+			Value: nil,
+		},
 	}).Decode(&currentClient)
 	if err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
@@ -234,13 +289,20 @@ func (r *auth) UpdateClient(ctx context.Context, p repository.UpdateClientParam)
 			Value: p.Id,
 		},
 	}
+	set := bson.M{
+		"name":       p.Name,
+		"type":       p.Type,
+		"status":     p.Status,
+		"updated_at": p.UpdatedAt,
+	}
+	if p.Scopes != nil {
+		set["scopes"] = p.Scopes
+	}
+	if p.Quota != nil {
+		set["quota"] = newQuotaDoc(*p.Quota)
+	}
 	data := bson.M{
-		"$set": bson.M{
-			"name":       p.Name,
-			"type":       p.Type,
-			"status":     p.Status,
-			"updated_at": p.UpdatedAt,
-		},
+		"$set": set,
 	}
 	_, err = cl.UpdateOne(ctx, updateFilter, data)
 	if err != nil {
@@ -283,7 +345,12 @@ func (r *auth) UpdateClient(ctx context.Context, p repository.UpdateClientParam)
 func (r *auth) SearchClient(ctx context.Context, p repository.SearchClientParam) (*repository.SearchClientResult, error) {
 	cl := r.dbClient.Database(r.dbConfig.DbName).Collection("auth_client")
 
-	filter := bson.D{}
+	filter := bson.D{
+		{
+			Key:   "deleted_at",
+			Value: nil,
+		},
+	}
 
 	if len(p.Statuses) > 0 {
 		filter = append(filter, primitive.E{
@@ -391,6 +458,269 @@ func (r *auth) SearchClient(ctx context.Context, p repository.SearchClientParam)
 	return res, nil
 }
 
+func (r *auth) UpdateClientUsage(ctx context.Context, p repository.UpdateClientUsageParam) (*repository.UpdateClientUsageResult, error) {
+	cl := r.dbClient.
+		Database(
+			r.dbConfig.DbName,
+			options.Database().SetReadPreference(readpref.Primary()),
+		).
+		Collection("auth_client")
+
+	return updateClientUsage(ctx, cl, p)
+}
+
+func (r *auth) RotateClientSecret(ctx context.Context, p repository.RotateClientSecretParam) (*repository.RotateClientSecretResult, error) {
+	cl := r.dbClient.
+		Database(
+			r.dbConfig.DbName,
+			options.Database().SetReadPreference(readpref.Primary()),
+		).
+		Collection("auth_client")
+
+	client := struct {
+		Id           string `bson:"_id"`
+		ClientId     string `bson:"client_id"`
+		ClientSecret string `bson:"client_secret"`
+	}{}
+	err := cl.FindOne(ctx, bson.D{
+		{
+			Key:   "_id",
+			Value: p.Id,
+		},
+		{
+			Key:   "deleted_at",
+			Value: nil,
+		},
+	}).Decode(&client)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, err
+	}
+
+	// @note: secret is matched to prevent overriding the concurrent rotation
+	updateFilter := bson.D{
+		{
+			Key:   "_id",
+			Value: client.Id,
+		},
+		{
+			Key:   "client_secret",
+			Value: client.ClientSecret,
+		},
+	}
+	data := bson.M{
+		"$set": bson.M{
+			"client_secret":              p.ClientSecret,
+			"previous_client_secret":     client.ClientSecret,
+			"previous_secret_expires_at": p.PreviousSecretExpiresAt,
+			"secret_rotated_at":          p.RotatedAt,
+			"updated_at":                 p.RotatedAt,
+		},
+	}
+	updateRes, err := cl.UpdateOne(ctx, updateFilter, data)
+	if err != nil {
+		return nil, err
+	}
+	if updateRes.MatchedCount == 0 {
+		return nil, fmt.Errorf("client secret is rotated concurrently")
+	}
+
+	res := &repository.RotateClientSecretResult{
+		Id:                      client.Id,
+		ClientId:                client.ClientId,
+		PreviousSecretExpiresAt: p.PreviousSecretExpiresAt,
+		SecretRotatedAt:         p.RotatedAt,
+	}
+	return res, nil
+}
+
+func (r *auth) RevokeClientSecret(ctx context.Context, p repository.RevokeClientSecretParam) (*repository.RevokeClientSecretResult, error) {
+	cl := r.dbClient.
+		Database(
+			r.dbConfig.DbName,
+			options.Database().SetReadPreference(readpref.Primary()),
+		).
+		Collection("auth_client")
+
+	client := struct {
+		Id       string `bson:"_id"`
+		ClientId string `bson:"client_id"`
+	}{}
+	err := cl.FindOne(ctx, bson.D{
+		{
+			Key:   "_id",
+			Value: p.Id,
+		},
+		{
+			Key:   "deleted_at",
+			Value: nil,
+		},
+	}).Decode(&client)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, err
+	}
+
+	updateFilter := bson.D{
+		{
+			Key:   "_id",
+			Value: client.Id,
+		},
+	}
+	data := bson.M{
+		"$set": bson.M{
+			"updated_at": p.RevokedAt,
+		},
+		"$unset": bson.M{
+			"previous_client_secret":     "",
+			"previous_secret_expires_at": "",
+		},
+	}
+	_, err = cl.UpdateOne(ctx, updateFilter, data)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &repository.RevokeClientSecretResult{
+		Id:        client.Id,
+		ClientId:  client.ClientId,
+		RevokedAt: p.RevokedAt,
+	}
+	return res, nil
+}
+
+func (r *auth) DeleteClient(ctx context.Context, p repository.DeleteClientParam) (*repository.DeleteClientResult, error) {
+	cl := r.dbClient.
+		Database(
+			r.dbConfig.DbName,
+			options.Database().SetReadPreference(readpref.Primary()),
+		).
+		Collection("auth_client")
+
+	client := struct {
+		Id       string `bson:"_id"`
+		ClientId string `bson:"client_id"`
+	}{}
+	err := cl.FindOne(ctx, bson.D{
+		{
+			Key:   "_id",
+			Value: p.Id,
+		},
+		{
+			Key:   "deleted_at",
+			Value: nil,
+		},
+	}).Decode(&client)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, err
+	}
+
+	updateFilter := bson.D{
+		{
+			Key:   "_id",
+			Value: client.Id,
+		},
+		{
+			Key:   "deleted_at",
+			Value: nil,
+		},
+	}
+	data := bson.M{
+		"$set": bson.M{
+			"updated_at": p.DeletedAt,
+			"deleted_at": p.DeletedAt,
+		},
+	}
+	updateRes, err := cl.UpdateOne(ctx, updateFilter, data)
+	if err != nil {
+		return nil, err
+	}
+	if updateRes.MatchedCount == 0 {
+		return nil, repository.ErrNotFound
+	}
+
+	res := &repository.DeleteClientResult{
+		Id:        client.Id,
+		ClientId:  client.ClientId,
+		DeletedAt: p.DeletedAt,
+	}
+	return res, nil
+}
+
+// @note: usage is only updated when it's not changed since it's read (compare and set),
+// the update is retried a few times before returning `ErrConflict`
+func updateClientUsage(ctx context.Context, cl *mongo.Collection, p repository.UpdateClientUsageParam) (*repository.UpdateClientUsageResult, error) {
+	for i := 0; i < USAGE_UPDATE_ATTEMPTS; i++ {
+		client := struct {
+			Id    string    `bson:"_id"`
+			Quota quotaDoc  `bson:"quota"`
+			Usage *usageDoc `bson:"usage"`
+		}{}
+		err := cl.FindOne(ctx, bson.D{
+			{
+				Key:   "_id",
+				Value: p.Id,
+			},
+			{
+				Key:   "deleted_at",
+				Value: nil,
+			},
+		}).Decode(&client)
+		if err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				return nil, repository.ErrNotFound
+			}
+			return nil, err
+		}
+
+		quota := client.Quota.quota()
+		usage := client.Usage.usage().Add(p)
+		if p.CheckQuota {
+			err := quota.Check(usage)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		updateFilter := bson.D{
+			{
+				Key:   "_id",
+				Value: client.Id,
+			},
+			{
+				Key:   "usage",
+				Value: client.Usage,
+			},
+		}
+		data := bson.M{
+			"$set": bson.M{
+				"usage": newUsageDoc(usage),
+			},
+		}
+		updateRes, err := cl.UpdateOne(ctx, updateFilter, data)
+		if err != nil {
+			return nil, err
+		}
+		if updateRes.MatchedCount == 0 {
+			continue
+		}
+
+		res := &repository.UpdateClientUsageResult{
+			Quota: quota,
+			Usage: usage,
+		}
+		return res, nil
+	}
+	return nil, repository.ErrConflict
+}
+
 func NewAuth(opts ...RepoOption) *auth {
 	p := RepositoryParam{}
 	for _, opt := range opts {
@@ -402,3 +732,69 @@ func NewAuth(opts ...RepoOption) *auth {
 		dbConfig: p.dbConfig,
 	}
 }
+
+const (
+	USAGE_UPDATE_ATTEMPTS = 5
+)
+
+// @note: scopes is always stored as an array
+func newScopes(scopes []string) []string {
+	if scopes == nil {
+		return []string{}
+	}
+	return scopes
+}
+
+type quotaDoc struct {
+	MaxStoredSize   int64 `bson:"max_stored_size"`
+	MaxTotalFiles   int64 `bson:"max_total_files"`
+	MaxFileSize     int64 `bson:"max_file_size"`
+	MaxDailyUploads int64 `bson:"max_daily_uploads"`
+}
+
+func newQuotaDoc(q repository.ClientQuota) quotaDoc {
+	return quotaDoc{
+		MaxStoredSize:   q.MaxStoredSize,
+		MaxTotalFiles:   q.MaxTotalFiles,
+		MaxFileSize:     q.MaxFileSize,
+		MaxDailyUploads: q.MaxDailyUploads,
+	}
+}
+
+func (d quotaDoc) quota() repository.ClientQuota {
+	return repository.ClientQuota{
+		MaxStoredSize:   d.MaxStoredSize,
+		MaxTotalFiles:   d.MaxTotalFiles,
+		MaxFileSize:     d.MaxFileSize,
+		MaxDailyUploads: d.MaxDailyUploads,
+	}
+}
+
+// @note: usage is not available until the client is used for the first time
+type usageDoc struct {
+	StoredSize   int64     `bson:"stored_size"`
+	TotalFiles   int64     `bson:"total_files"`
+	DailyUploads int64     `bson:"daily_uploads"`
+	DailyAt      time.Time `bson:"daily_at"`
+}
+
+func newUsageDoc(u repository.ClientUsage) *usageDoc {
+	return &usageDoc{
+		StoredSize:   u.StoredSize,
+		TotalFiles:   u.TotalFiles,
+		DailyUploads: u.DailyUploads,
+		DailyAt:      u.DailyAt,
+	}
+}
+
+func (d *usageDoc) usage() repository.ClientUsage {
+	if d == nil {
+		return repository.ClientUsage{}
+	}
+	return repository.ClientUsage{
+		StoredSize:   d.StoredSize,
+		TotalFiles:   d.TotalFiles,
+		DailyUploads: d.DailyUploads,
+		DailyAt:      d.DailyAt.UTC(),
+	}
+}
@@ -2,6 +2,7 @@ package mongo_test
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/go-seidon/hippo/internal/repository"
@@ -176,6 +177,7 @@ var _ = Describe("Auth Repository", func() {
 				ClientSecret: "mock-client-secret",
 				Type:         "basic",
 				Status:       "active",
+				Scopes:       []string{"file:read"},
 			}
 			err := InsertAuthClient(client, InsertAuthClientParam{
 				Id:           "mock-id",
@@ -184,6 +186,7 @@ var _ = Describe("Auth Repository", func() {
 				ClientSecret: "mock-client-secret",
 				Type:         "basic",
 				Status:       "active",
+				Scopes:       []string{"file:read"},
 				DbName:       "hippo_test",
 			})
 			if err != nil {
@@ -570,4 +573,488 @@ var _ = Describe("Auth Repository", func() {
 		})
 	})
 
+	Context("UpdateClientUsage function", Label("integration"), Ordered, func() {
+		var (
+			ctx       context.Context
+			currentTs time.Time
+			client    *mongo.Client
+			repo      repository.Auth
+			p         repository.UpdateClientUsageParam
+		)
+
+		BeforeAll(func() {
+			dbClient, err := OpenDb("")
+			if err != nil {
+				AbortSuite("failed open test db: " + err.Error())
+			}
+			client = dbClient
+
+			err = RunDbMigration(dbClient, RunDbMigrationParam{
+				DbName: "hippo_test",
+			})
+			if err != nil {
+				AbortSuite("failed prepare db migration: " + err.Error())
+			}
+			ctx = context.Background()
+			dbCfgOpt := repository_mongo.WithDbConfig(&repository_mongo.DbConfig{
+				DbName: "hippo_test",
+			})
+			dbClientOpt := repository_mongo.WithDbClient(client)
+			repo = repository_mongo.NewAuth(dbClientOpt, dbCfgOpt)
+		})
+
+		BeforeEach(func() {
+			currentTs = time.Now().UTC()
+			p = repository.UpdateClientUsageParam{
+				Id:           "usage-id",
+				StoredSize:   600,
+				TotalFiles:   1,
+				DailyUploads: 1,
+				UsedAt:       currentTs,
+				CheckQuota:   true,
+			}
+			err := InsertAuthClient(client, InsertAuthClientParam{
+				Id:           "usage-id",
+				Name:         "usage-client-name",
+				ClientId:     "usage-client-id",
+				ClientSecret: "usage-client-secret",
+				Type:         "basic",
+				Status:       "active",
+				Quota: bson.M{
+					"max_stored_size":   int64(1024),
+					"max_total_files":   int64(10),
+					"max_file_size":     int64(512),
+					"max_daily_uploads": int64(5),
+				},
+				DbName: "hippo_test",
+			})
+			if err != nil {
+				AbortSuite("failed prepare seed data: " + err.Error())
+			}
+		})
+
+		AfterEach(func() {
+			_, err := client.
+				Database("hippo_test").
+				Collection("auth_client").
+				DeleteMany(ctx, bson.D{
+					{
+						Key:   "_id",
+						Value: "usage-id",
+					},
+				})
+			if err != nil {
+				AbortSuite("failed cleaning seed data: " + err.Error())
+			}
+		})
+
+		AfterAll(func() {
+			err := client.Disconnect(ctx)
+			if err != nil {
+				AbortSuite("failed close test db: " + err.Error())
+			}
+		})
+
+		When("client is not available", func() {
+			It("should return error", func() {
+				p.Id = "invalid-id"
+				res, err := repo.UpdateClientUsage(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(repository.ErrNotFound))
+			})
+		})
+
+		When("quota is exceeded", func() {
+			It("should return error", func() {
+				_, err := repo.UpdateClientUsage(ctx, p)
+				Expect(err).To(BeNil())
+
+				res, err := repo.UpdateClientUsage(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(errors.Is(err, repository.ErrExceeded)).To(BeTrue())
+			})
+		})
+
+		When("success update usage", func() {
+			It("should return result", func() {
+				_, err := repo.UpdateClientUsage(ctx, p)
+				Expect(err).To(BeNil())
+
+				p.StoredSize = -100
+				p.TotalFiles = 0
+				p.DailyUploads = 0
+				p.CheckQuota = false
+				res, err := repo.UpdateClientUsage(ctx, p)
+
+				dailyAt := currentTs.Truncate(24 * time.Hour)
+				Expect(res).To(Equal(&repository.UpdateClientUsageResult{
+					Quota: repository.ClientQuota{
+						MaxStoredSize:   1024,
+						MaxTotalFiles:   10,
+						MaxFileSize:     512,
+						MaxDailyUploads: 5,
+					},
+					Usage: repository.ClientUsage{
+						StoredSize:   500,
+						TotalFiles:   1,
+						DailyUploads: 1,
+						DailyAt:      dailyAt,
+					},
+				}))
+				Expect(err).To(BeNil())
+
+				find, err := repo.FindClient(ctx, repository.FindClientParam{
+					ClientId: "usage-client-id",
+				})
+				Expect(err).To(BeNil())
+				Expect(find.Usage).To(Equal(res.Usage))
+			})
+		})
+	})
+
+	Context("RotateClientSecret function", Label("integration"), Ordered, func() {
+		var (
+			ctx       context.Context
+			currentTs time.Time
+			client    *mongo.Client
+			repo      repository.Auth
+			p         repository.RotateClientSecretParam
+		)
+
+		BeforeAll(func() {
+			dbClient, err := OpenDb("")
+			if err != nil {
+				AbortSuite("failed open test db: " + err.Error())
+			}
+			client = dbClient
+
+			err = RunDbMigration(dbClient, RunDbMigrationParam{
+				DbName: "hippo_test",
+			})
+			if err != nil {
+				AbortSuite("failed prepare db migration: " + err.Error())
+			}
+			ctx = context.Background()
+			dbCfgOpt := repository_mongo.WithDbConfig(&repository_mongo.DbConfig{
+				DbName: "hippo_test",
+			})
+			dbClientOpt := repository_mongo.WithDbClient(client)
+			repo = repository_mongo.NewAuth(dbClientOpt, dbCfgOpt)
+		})
+
+		BeforeEach(func() {
+			currentTs = time.Now().UTC()
+			p = repository.RotateClientSecretParam{
+				Id:                      "rotate-id",
+				ClientSecret:            "new-secret",
+				PreviousSecretExpiresAt: currentTs.Add(time.Hour),
+				RotatedAt:               currentTs,
+			}
+			err := InsertAuthClient(client, InsertAuthClientParam{
+				Id:           "rotate-id",
+				Name:         "rotate-id-name",
+				ClientId:     "rotate-id-client-id",
+				ClientSecret: "old-secret",
+				Type:         "basic",
+				Status:       "active",
+				CreatedAt:    currentTs,
+				UpdatedAt:    currentTs,
+				DbName:       "hippo_test",
+			})
+			if err != nil {
+				AbortSuite("failed prepare seed data: " + err.Error())
+			}
+		})
+
+		AfterEach(func() {
+			_, err := client.
+				Database("hippo_test").
+				Collection("auth_client").
+				DeleteMany(ctx, bson.D{
+					{
+						Key: "_id",
+						Value: bson.D{
+							{
+								Key:   "$in",
+								Value: []string{"rotate-id"},
+							},
+						},
+					},
+				})
+			if err != nil {
+				AbortSuite("failed cleaning seed data: " + err.Error())
+			}
+		})
+
+		AfterAll(func() {
+			err := client.Disconnect(ctx)
+			if err != nil {
+				AbortSuite("failed close test db: " + err.Error())
+			}
+		})
+
+		When("client is not available", func() {
+			It("should return error", func() {
+				p.Id = "invalid-id"
+				res, err := repo.RotateClientSecret(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(repository.ErrNotFound))
+			})
+		})
+
+		When("success rotate secret", func() {
+			It("should return result", func() {
+				res, err := repo.RotateClientSecret(ctx, p)
+
+				Expect(err).To(BeNil())
+				Expect(res.ClientId).To(Equal("rotate-id-client-id"))
+
+				findRes, err := repo.FindClient(ctx, repository.FindClientParam{
+					Id: "rotate-id",
+				})
+				Expect(err).To(BeNil())
+				Expect(findRes.ClientSecret).To(Equal("new-secret"))
+				Expect(findRes.PreviousClientSecret).To(Equal("old-secret"))
+				Expect(findRes.IsPreviousSecretValid(currentTs)).To(BeTrue())
+			})
+		})
+	})
+
+	Context("RevokeClientSecret function", Label("integration"), Ordered, func() {
+		var (
+			ctx       context.Context
+			currentTs time.Time
+			client    *mongo.Client
+			repo      repository.Auth
+			p         repository.RevokeClientSecretParam
+		)
+
+		BeforeAll(func() {
+			dbClient, err := OpenDb("")
+			if err != nil {
+				AbortSuite("failed open test db: " + err.Error())
+			}
+			client = dbClient
+
+			err = RunDbMigration(dbClient, RunDbMigrationParam{
+				DbName: "hippo_test",
+			})
+			if err != nil {
+				AbortSuite("failed prepare db migration: " + err.Error())
+			}
+			ctx = context.Background()
+			dbCfgOpt := repository_mongo.WithDbConfig(&repository_mongo.DbConfig{
+				DbName: "hippo_test",
+			})
+			dbClientOpt := repository_mongo.WithDbClient(client)
+			repo = repository_mongo.NewAuth(dbClientOpt, dbCfgOpt)
+		})
+
+		BeforeEach(func() {
+			currentTs = time.Now().UTC()
+			p = repository.RevokeClientSecretParam{
+				Id:        "revoke-id",
+				RevokedAt: currentTs,
+			}
+			err := InsertAuthClient(client, InsertAuthClientParam{
+				Id:           "revoke-id",
+				Name:         "revoke-id-name",
+				ClientId:     "revoke-id-client-id",
+				ClientSecret: "old-secret",
+				Type:         "basic",
+				Status:       "active",
+				CreatedAt:    currentTs,
+				UpdatedAt:    currentTs,
+				DbName:       "hippo_test",
+			})
+			if err != nil {
+				AbortSuite("failed prepare seed data: " + err.Error())
+			}
+		})
+
+		AfterEach(func() {
+			_, err := client.
+				Database("hippo_test").
+				Collection("auth_client").
+				DeleteMany(ctx, bson.D{
+					{
+						Key: "_id",
+						Value: bson.D{
+							{
+								Key:   "$in",
+								Value: []string{"revoke-id"},
+							},
+						},
+					},
+				})
+			if err != nil {
+				AbortSuite("failed cleaning seed data: " + err.Error())
+			}
+		})
+
+		AfterAll(func() {
+			err := client.Disconnect(ctx)
+			if err != nil {
+				AbortSuite("failed close test db: " + err.Error())
+			}
+		})
+
+		When("client is not available", func() {
+			It("should return error", func() {
+				p.Id = "invalid-id"
+				res, err := repo.RevokeClientSecret(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(repository.ErrNotFound))
+			})
+		})
+
+		When("success revoke secret", func() {
+			It("should return result", func() {
+				res, err := repo.RevokeClientSecret(ctx, p)
+
+				Expect(err).To(BeNil())
+				Expect(res.ClientId).To(Equal("revoke-id-client-id"))
+
+				findRes, err := repo.FindClient(ctx, repository.FindClientParam{
+					Id: "revoke-id",
+				})
+				Expect(err).To(BeNil())
+				Expect(findRes.ClientSecret).To(Equal("old-secret"))
+				Expect(findRes.PreviousClientSecret).To(Equal(""))
+				Expect(findRes.IsPreviousSecretValid(currentTs)).To(BeFalse())
+			})
+		})
+	})
+	Context("DeleteClient function", Label("integration"), Ordered, func() {
+		var (
+			ctx       context.Context
+			currentTs time.Time
+			client    *mongo.Client
+			repo      repository.Auth
+			p         repository.DeleteClientParam
+		)
+
+		BeforeAll(func() {
+			dbClient, err := OpenDb("")
+			if err != nil {
+				AbortSuite("failed open test db: " + err.Error())
+			}
+			client = dbClient
+
+			err = RunDbMigration(dbClient, RunDbMigrationParam{
+				DbName: "hippo_test",
+			})
+			if err != nil {
+				AbortSuite("failed prepare db migration: " + err.Error())
+			}
+			ctx = context.Background()
+			dbCfgOpt := repository_mongo.WithDbConfig(&repository_mongo.DbConfig{
+				DbName: "hippo_test",
+			})
+			dbClientOpt := repository_mongo.WithDbClient(client)
+			repo = repository_mongo.NewAuth(dbClientOpt, dbCfgOpt)
+		})
+
+		BeforeEach(func() {
+			currentTs = time.Now().UTC()
+			p = repository.DeleteClientParam{
+				Id:        "delete-id",
+				DeletedAt: currentTs,
+			}
+			err := InsertAuthClient(client, InsertAuthClientParam{
+				Id:           "delete-id",
+				Name:         "delete-id-name",
+				ClientId:     "delete-id-client-id",
+				ClientSecret: "secret",
+				Type:         "basic",
+				Status:       "active",
+				CreatedAt:    currentTs,
+				UpdatedAt:    currentTs,
+				DbName:       "hippo_test",
+			})
+			if err != nil {
+				AbortSuite("failed prepare seed data: " + err.Error())
+			}
+		})
+
+		AfterEach(func() {
+			_, err := client.
+				Database("hippo_test").
+				Collection("auth_client").
+				DeleteMany(ctx, bson.D{
+					{
+						Key: "_id",
+						Value: bson.D{
+							{
+								Key:   "$in",
+								Value: []string{"delete-id", "delete-id-2"},
+							},
+						},
+					},
+				})
+			if err != nil {
+				AbortSuite("failed cleaning seed data: " + err.Error())
+			}
+		})
+
+		AfterAll(func() {
+			err := client.Disconnect(ctx)
+			if err != nil {
+				AbortSuite("failed close test db: " + err.Error())
+			}
+		})
+
+		When("client is not available", func() {
+			It("should return error", func() {
+				p.Id = "invalid-id"
+				res, err := repo.DeleteClient(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(repository.ErrNotFound))
+			})
+		})
+
+		When("client is already deleted", func() {
+			It("should return error", func() {
+				_, err := repo.DeleteClient(ctx, p)
+				Expect(err).To(BeNil())
+
+				res, err := repo.DeleteClient(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(repository.ErrNotFound))
+			})
+		})
+
+		When("success delete client", func() {
+			It("should exclude the client and free the client_id", func() {
+				res, err := repo.DeleteClient(ctx, p)
+
+				Expect(err).To(BeNil())
+				Expect(res.ClientId).To(Equal("delete-id-client-id"))
+
+				findRes, err := repo.FindClient(ctx, repository.FindClientParam{
+					ClientId: "delete-id-client-id",
+				})
+				Expect(findRes).To(BeNil())
+				Expect(err).To(Equal(repository.ErrNotFound))
+
+				createRes, err := repo.CreateClient(ctx, repository.CreateClientParam{
+					Id:           "delete-id-2",
+					ClientId:     "delete-id-client-id",
+					ClientSecret: "secret",
+					Name:         "delete-id-name",
+					Type:         "basic",
+					Status:       "active",
+					CreatedAt:    currentTs,
+				})
+				Expect(err).To(BeNil())
+				Expect(createRes.Id).To(Equal("delete-id-2"))
+			})
+		})
+	})
 })
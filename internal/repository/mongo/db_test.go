@@ -66,6 +66,8 @@ type InsertAuthClientParam struct {
 	ClientSecret string
 	Type         string
 	Status       string
+	Scopes       []string
+	Quota        bson.M
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
 	DbName       string
@@ -101,6 +103,20 @@ func InsertAuthClient(dbClient *mongo.Client, p InsertAuthClientParam) error {
 		},
 	}
 
+	if p.Scopes != nil {
+		data = append(data, primitive.E{
+			Key:   "scopes",
+			Value: p.Scopes,
+		})
+	}
+
+	if p.Quota != nil {
+		data = append(data, primitive.E{
+			Key:   "quota",
+			Value: p.Quota,
+		})
+	}
+
 	if !p.CreatedAt.IsZero() {
 		data = append(data, primitive.E{
 			Key:   "created_at",
@@ -133,6 +149,12 @@ type InsertFileParam struct {
 	UpdatedAt int64
 	DeletedAt int64
 	DbName    string
+
+	EncryptionKeyId   string
+	EncryptionDataKey string
+	StoredSize        int64
+	Compression       string
+	OwnerClientId     string
 }
 
 func InsertFile(dbClient *mongo.Client, p InsertFileParam) error {
@@ -184,6 +206,33 @@ func InsertFile(dbClient *mongo.Client, p InsertFileParam) error {
 		})
 	}
 
+	if p.EncryptionKeyId != "" {
+		data = append(data, primitive.E{
+			Key:   "encryption_key_id",
+			Value: p.EncryptionKeyId,
+		}, primitive.E{
+			Key:   "encryption_data_key",
+			Value: p.EncryptionDataKey,
+		})
+	}
+
+	if p.Compression != "" {
+		data = append(data, primitive.E{
+			Key:   "stored_size",
+			Value: p.StoredSize,
+		}, primitive.E{
+			Key:   "compression",
+			Value: p.Compression,
+		})
+	}
+
+	if p.OwnerClientId != "" {
+		data = append(data, primitive.E{
+			Key:   "owner_client_id",
+			Value: p.OwnerClientId,
+		})
+	}
+
 	_, err := cl.InsertOne(ctx, data)
 	if err != nil {
 		return err
@@ -2,12 +2,17 @@ package mongo
 
 import (
 	"context"
+	"errors"
+	"regexp"
 	"time"
 
 	"github.com/go-seidon/hippo/internal/repository"
 	db_mongo "github.com/go-seidon/provider/mongo"
+	"github.com/go-seidon/provider/typeconv"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type file struct {
@@ -16,13 +21,48 @@ type file struct {
 }
 
 func (r *file) CreateFile(ctx context.Context, p repository.CreateFileParam) (*repository.CreateFileResult, error) {
-	err := p.CreateFn(ctx, repository.CreateFnParam{
+	fn, err := p.CreateFn(ctx, repository.CreateFnParam{
 		FilePath: p.Path,
 	})
 	if err != nil {
 		return nil, err
 	}
 
+	path := p.Path
+	keyId := fn.EncryptionKeyId
+	dataKey := fn.EncryptionDataKey
+	storedSize := fn.StoredSize
+	compression := fn.Compression
+	referenced := false
+	if p.Deduplicate {
+		blobPath, err := r.referenceBlob(ctx, fn.ChecksumSha256, p.Path, p.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		referenced = true
+		path = blobPath
+
+		if blobPath != p.Path {
+			err = p.DuplicateFn(ctx, repository.DuplicateFnParam{
+				FilePath: p.Path,
+				BlobPath: blobPath,
+			})
+			if err != nil {
+				return nil, r.releaseBlob(ctx, fn.ChecksumSha256, path, err)
+			}
+
+			// @note: stored content is read using the encryption key and compression of the file which stored it
+			blobFile, err := r.findBlobFile(ctx, blobPath, p.UniqueId)
+			if err != nil {
+				return nil, r.releaseBlob(ctx, fn.ChecksumSha256, path, err)
+			}
+			keyId = blobFile.EncryptionKeyId
+			dataKey = blobFile.EncryptionDataKey
+			storedSize = blobFile.StoredSize
+			compression = blobFile.Compression
+		}
+	}
+
 	cl := r.dbClient.Database(r.dbConfig.DbName).Collection("file")
 	data := bson.D{
 		{
@@ -35,7 +75,7 @@ func (r *file) CreateFile(ctx context.Context, p repository.CreateFileParam) (*r
 		},
 		{
 			Key:   "path",
-			Value: p.Path,
+			Value: path,
 		},
 		{
 			Key:   "mimetype",
@@ -47,7 +87,35 @@ func (r *file) CreateFile(ctx context.Context, p repository.CreateFileParam) (*r
 		},
 		{
 			Key:   "size",
-			Value: p.Size,
+			Value: fn.Size,
+		},
+		{
+			Key:   "checksum_sha256",
+			Value: fn.ChecksumSha256,
+		},
+		{
+			Key:   "checksum_md5",
+			Value: fn.ChecksumMd5,
+		},
+		{
+			Key:   "encryption_key_id",
+			Value: keyId,
+		},
+		{
+			Key:   "encryption_data_key",
+			Value: dataKey,
+		},
+		{
+			Key:   "stored_size",
+			Value: storedSize,
+		},
+		{
+			Key:   "compression",
+			Value: compression,
+		},
+		{
+			Key:   "owner_client_id",
+			Value: p.OwnerClientId,
 		},
 		{
 			Key:   "created_at",
@@ -60,17 +128,22 @@ func (r *file) CreateFile(ctx context.Context, p repository.CreateFileParam) (*r
 	}
 	_, err = cl.InsertOne(ctx, data)
 	if err != nil {
+		if referenced {
+			return nil, r.releaseBlob(ctx, fn.ChecksumSha256, path, err)
+		}
 		return nil, err
 	}
 
 	res := &repository.CreateFileResult{
-		UniqueId:  p.UniqueId,
-		Name:      p.Name,
-		Path:      p.Path,
-		Mimetype:  p.Mimetype,
-		Extension: p.Extension,
-		Size:      p.Size,
-		CreatedAt: p.CreatedAt,
+		UniqueId:       p.UniqueId,
+		Name:           p.Name,
+		Path:           path,
+		Mimetype:       p.Mimetype,
+		Extension:      p.Extension,
+		Size:           fn.Size,
+		CreatedAt:      p.CreatedAt,
+		ChecksumSha256: fn.ChecksumSha256,
+		ChecksumMd5:    fn.ChecksumMd5,
 	}
 	return res, nil
 }
@@ -84,14 +157,21 @@ func (r *file) RetrieveFile(ctx context.Context, p repository.RetrieveFileParam)
 		},
 	}
 	file := struct {
-		Id        string     `bson:"_id"`
-		Name      string     `bson:"name"`
-		Path      string     `bson:"path"`
-		Mimetype  string     `bson:"mimetype"`
-		Extension string     `bson:"extension"`
-		Size      int64      `bson:"size"`
-		CreatedAt time.Time  `bson:"created_at"`
-		DeletedAt *time.Time `bson:"deleted_at"`
+		Id                string     `bson:"_id"`
+		Name              string     `bson:"name"`
+		Path              string     `bson:"path"`
+		Mimetype          string     `bson:"mimetype"`
+		Extension         string     `bson:"extension"`
+		Size              int64      `bson:"size"`
+		CreatedAt         time.Time  `bson:"created_at"`
+		DeletedAt         *time.Time `bson:"deleted_at"`
+		ChecksumSha256    string     `bson:"checksum_sha256"`
+		ChecksumMd5       string     `bson:"checksum_md5"`
+		EncryptionKeyId   string     `bson:"encryption_key_id"`
+		EncryptionDataKey string     `bson:"encryption_data_key"`
+		StoredSize        int64      `bson:"stored_size"`
+		Compression       string     `bson:"compression"`
+		OwnerClientId     string     `bson:"owner_client_id"`
 	}{}
 	err := cl.FindOne(ctx, findFilter).Decode(&file)
 	if err != nil {
@@ -102,73 +182,855 @@ func (r *file) RetrieveFile(ctx context.Context, p repository.RetrieveFileParam)
 	}
 
 	res := &repository.RetrieveFileResult{
-		UniqueId:  file.Id,
-		Name:      file.Name,
-		Path:      file.Path,
-		Mimetype:  file.Mimetype,
-		Extension: file.Extension,
-		Size:      file.Size,
-		CreatedAt: file.CreatedAt,
-		DeletedAt: file.DeletedAt,
+		UniqueId:          file.Id,
+		Name:              file.Name,
+		Path:              file.Path,
+		Mimetype:          file.Mimetype,
+		Extension:         file.Extension,
+		Size:              file.Size,
+		CreatedAt:         file.CreatedAt,
+		DeletedAt:         file.DeletedAt,
+		ChecksumSha256:    file.ChecksumSha256,
+		ChecksumMd5:       file.ChecksumMd5,
+		EncryptionKeyId:   file.EncryptionKeyId,
+		EncryptionDataKey: file.EncryptionDataKey,
+		StoredSize:        file.StoredSize,
+		Compression:       file.Compression,
+		OwnerClientId:     file.OwnerClientId,
 	}
 	return res, nil
 }
 
+// @note: file is marked as deleted before the blob is dereferenced, so the concurrent delete
+// doesn't dereference it twice, the mark is reverted when the deletion can not be proceeded
 func (r *file) DeleteFile(ctx context.Context, p repository.DeleteFileParam) (*repository.DeleteFileResult, error) {
 	cl := r.dbClient.Database(r.dbConfig.DbName).Collection("file")
-	findFilter := bson.D{
+	claimFilter := bson.D{
 		{
 			Key:   "_id",
 			Value: p.UniqueId,
 		},
+		{
+			Key:   "deleted_at",
+			Value: nil,
+		},
+	}
+	claimData := bson.M{
+		"$set": bson.M{
+			"deleted_at": p.DeletedAt,
+		},
 	}
 	file := struct {
-		Id        string     `bson:"_id"`
-		Name      string     `bson:"name"`
-		Path      string     `bson:"path"`
-		DeletedAt *time.Time `bson:"deleted_at"`
+		Id             string     `bson:"_id"`
+		Name           string     `bson:"name"`
+		Path           string     `bson:"path"`
+		Size           int64      `bson:"size"`
+		ChecksumSha256 string     `bson:"checksum_sha256"`
+		OwnerClientId  string     `bson:"owner_client_id"`
+		DeletedAt      *time.Time `bson:"deleted_at"`
 	}{}
-	err := cl.FindOne(ctx, findFilter).Decode(&file)
+	err := cl.FindOneAndUpdate(ctx, claimFilter, claimData).Decode(&file)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			return nil, repository.ErrNotFound
+			return nil, r.checkFile(ctx, p.UniqueId, repository.ErrDeleted)
 		}
 		return nil, err
 	}
 
-	if file.DeletedAt != nil {
-		return nil, repository.ErrDeleted
+	unclaimData := bson.M{
+		"$unset": bson.M{
+			"deleted_at": "",
+		},
+	}
+
+	references, dereferenced, err := r.dereferenceBlob(ctx, file.ChecksumSha256, file.Path)
+	if err != nil {
+		return nil, r.revertFile(ctx, file.Id, unclaimData, err)
 	}
 
 	err = p.DeleteFn(ctx, repository.DeleteFnParam{
-		FilePath: file.Path,
+		FilePath:   file.Path,
+		References: references,
 	})
+	if err != nil {
+		_, rerr := r.restoreBlob(ctx, file.ChecksumSha256, file.Path, dereferenced, p.DeletedAt)
+		if rerr != nil {
+			return nil, rerr
+		}
+		return nil, r.revertFile(ctx, file.Id, unclaimData, err)
+	}
+
+	err = r.updateOwnerUsage(ctx, file.OwnerClientId, -file.Size, p.DeletedAt)
 	if err != nil {
 		return nil, err
 	}
 
-	updateFilter := bson.D{
+	res := &repository.DeleteFileResult{
+		DeletedAt: p.DeletedAt,
+	}
+	return res, nil
+}
+
+// @note: register the content or add reference to the already stored content
+// the returned path is the path of the stored content,
+// file is unmarked as deleted before the usage is taken back so the concurrent restore doesn't take it twice
+func (r *file) RestoreFile(ctx context.Context, p repository.RestoreFileParam) (*repository.RestoreFileResult, error) {
+	cl := r.dbClient.Database(r.dbConfig.DbName).Collection("file")
+	claimFilter := bson.D{
 		{
 			Key:   "_id",
 			Value: p.UniqueId,
 		},
+		{
+			Key: "deleted_at",
+			Value: bson.M{
+				"$ne": nil,
+			},
+		},
+	}
+	claimData := bson.M{
+		"$set": bson.M{
+			"updated_at": p.RestoredAt,
+		},
+		"$unset": bson.M{
+			"deleted_at": "",
+		},
+	}
+	file := struct {
+		Id             string     `bson:"_id"`
+		Path           string     `bson:"path"`
+		Size           int64      `bson:"size"`
+		ChecksumSha256 string     `bson:"checksum_sha256"`
+		OwnerClientId  string     `bson:"owner_client_id"`
+		DeletedAt      *time.Time `bson:"deleted_at"`
+	}{}
+	err := cl.FindOneAndUpdate(ctx, claimFilter, claimData).Decode(&file)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, r.checkFile(ctx, p.UniqueId, repository.ErrNotDeleted)
+		}
+		return nil, err
+	}
+
+	unclaimData := bson.M{
+		"$set": bson.M{
+			"deleted_at": file.DeletedAt,
+		},
+	}
+
+	err = r.updateOwnerUsage(ctx, file.OwnerClientId, file.Size, p.RestoredAt)
+	if err != nil {
+		return nil, r.revertFile(ctx, file.Id, unclaimData, err)
+	}
+
+	references, err := r.restoreBlob(ctx, file.ChecksumSha256, file.Path, p.Deduplicate, p.RestoredAt)
+	if err != nil {
+		err = r.releaseOwnerUsage(ctx, file.OwnerClientId, file.Size, p.RestoredAt, err)
+		return nil, r.revertFile(ctx, file.Id, unclaimData, err)
+	}
+
+	err = p.RestoreFn(ctx, repository.RestoreFnParam{
+		FilePath:   file.Path,
+		References: references,
+	})
+	if err != nil {
+		_, _, derr := r.dereferenceBlob(ctx, file.ChecksumSha256, file.Path)
+		if derr != nil {
+			return nil, derr
+		}
+		err = r.releaseOwnerUsage(ctx, file.OwnerClientId, file.Size, p.RestoredAt, err)
+		return nil, r.revertFile(ctx, file.Id, unclaimData, err)
+	}
+
+	res := &repository.RestoreFileResult{
+		RestoredAt: p.RestoredAt,
+	}
+	return res, nil
+}
+
+// @note: return not found error when the file doesn't exist, otherwise the given error is returned
+func (r *file) checkFile(ctx context.Context, id string, err error) error {
+	cl := r.dbClient.Database(r.dbConfig.DbName).Collection("file")
+	count, cerr := cl.CountDocuments(ctx, bson.D{
+		{
+			Key:   "_id",
+			Value: id,
+		},
+	})
+	if cerr != nil {
+		return cerr
+	}
+	if count == 0 {
+		return repository.ErrNotFound
+	}
+	return err
+}
+
+// @note: revert the claimed deletion or restoration of the file, the given error is returned
+func (r *file) revertFile(ctx context.Context, id string, data bson.M, err error) error {
+	cl := r.dbClient.Database(r.dbConfig.DbName).Collection("file")
+	_, uerr := cl.UpdateOne(ctx, bson.D{
+		{
+			Key:   "_id",
+			Value: id,
+		},
+	}, data)
+	if uerr != nil {
+		return uerr
+	}
+	return err
+}
+
+func (r *file) SearchFile(ctx context.Context, p repository.SearchFileParam) (*repository.SearchFileResult, error) {
+	cl := r.dbClient.Database(r.dbConfig.DbName).Collection("file")
+
+	filter := bson.D{}
+
+	if p.Keyword != "" {
+		filter = append(filter, primitive.E{
+			Key: "name",
+			Value: bson.D{
+				{
+					Key:   "$regex",
+					Value: regexp.QuoteMeta(p.Keyword),
+				},
+			},
+		})
+	}
+
+	if len(p.Mimetypes) > 0 {
+		filter = append(filter, primitive.E{
+			Key: "mimetype",
+			Value: bson.D{
+				{
+					Key:   "$in",
+					Value: p.Mimetypes,
+				},
+			},
+		})
+	}
+
+	if len(p.Extensions) > 0 {
+		filter = append(filter, primitive.E{
+			Key: "extension",
+			Value: bson.D{
+				{
+					Key:   "$in",
+					Value: p.Extensions,
+				},
+			},
+		})
+	}
+
+	size := bson.D{}
+	if p.MinSize != nil {
+		size = append(size, primitive.E{Key: "$gte", Value: *p.MinSize})
+	}
+	if p.MaxSize != nil {
+		size = append(size, primitive.E{Key: "$lte", Value: *p.MaxSize})
+	}
+	if len(size) > 0 {
+		filter = append(filter, primitive.E{Key: "size", Value: size})
+	}
+
+	createdAt := bson.D{}
+	if p.UploadedFrom != nil {
+		createdAt = append(createdAt, primitive.E{Key: "$gte", Value: p.UploadedFrom.UTC()})
+	}
+	if p.UploadedTo != nil {
+		createdAt = append(createdAt, primitive.E{Key: "$lte", Value: p.UploadedTo.UTC()})
+	}
+	if len(createdAt) > 0 {
+		filter = append(filter, primitive.E{Key: "created_at", Value: createdAt})
+	}
+
+	available, deleted := searchFileStatus(p.Statuses)
+	if available && !deleted {
+		filter = append(filter, primitive.E{Key: "deleted_at", Value: nil})
+	} else if deleted && !available {
+		filter = append(filter, primitive.E{
+			Key: "deleted_at",
+			Value: bson.D{
+				{
+					Key:   "$ne",
+					Value: nil,
+				},
+			},
+		})
+	}
+
+	if len(p.OwnerClientIds) > 0 {
+		owners := bson.A{}
+		for _, owner := range p.OwnerClientIds {
+			owners = append(owners, owner)
+			// @note: file uploaded before the ownership is recorded has no owner field
+			if owner == "" {
+				owners = append(owners, nil)
+			}
+		}
+		filter = append(filter, primitive.E{
+			Key: "owner_client_id",
+			Value: bson.D{
+				{
+					Key:   "$in",
+					Value: owners,
+				},
+			},
+		})
+	}
+
+	if len(p.Paths) > 0 {
+		filter = append(filter, primitive.E{
+			Key: "path",
+			Value: bson.D{
+				{
+					Key:   "$in",
+					Value: p.Paths,
+				},
+			},
+		})
+	}
+
+	if p.AfterId != "" {
+		filter = append(filter, primitive.E{
+			Key: "_id",
+			Value: bson.D{
+				{
+					Key:   "$gt",
+					Value: p.AfterId,
+				},
+			},
+		})
+	}
+
+	options := options.Find()
+	if field, ok := fileSortFields[p.SortBy]; ok {
+		order := 1
+		if p.SortOrder == repository.SORT_DESC {
+			order = -1
+		}
+		options.SetSort(bson.D{{Key: field, Value: order}})
+	}
+	if p.Limit > 0 {
+		options.SetLimit(int64(p.Limit))
+	}
+	if p.Offset > 0 {
+		options.SetSkip(p.Offset)
+	}
+
+	findRes, err := cl.Find(ctx, filter, options)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := cl.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []struct {
+		Id                string     `bson:"_id"`
+		Name              string     `bson:"name"`
+		Path              string     `bson:"path"`
+		Mimetype          string     `bson:"mimetype"`
+		Extension         string     `bson:"extension"`
+		Size              int64      `bson:"size"`
+		CreatedAt         time.Time  `bson:"created_at"`
+		DeletedAt         *time.Time `bson:"deleted_at"`
+		ChecksumSha256    string     `bson:"checksum_sha256"`
+		ChecksumMd5       string     `bson:"checksum_md5"`
+		EncryptionKeyId   string     `bson:"encryption_key_id"`
+		EncryptionDataKey string     `bson:"encryption_data_key"`
+		StoredSize        int64      `bson:"stored_size"`
+		Compression       string     `bson:"compression"`
+	}{}
+	err = findRes.All(ctx, &files)
+	if err != nil {
+		return nil, err
+	}
+
+	items := []repository.SearchFileItem{}
+	for _, file := range files {
+		var deletedAt *time.Time
+		if file.DeletedAt != nil {
+			deletedAt = typeconv.Time(file.DeletedAt.UTC())
+		}
+
+		items = append(items, repository.SearchFileItem{
+			UniqueId:          file.Id,
+			Name:              file.Name,
+			Path:              file.Path,
+			Mimetype:          file.Mimetype,
+			Extension:         file.Extension,
+			Size:              file.Size,
+			CreatedAt:         file.CreatedAt.UTC(),
+			DeletedAt:         deletedAt,
+			ChecksumSha256:    file.ChecksumSha256,
+			ChecksumMd5:       file.ChecksumMd5,
+			EncryptionKeyId:   file.EncryptionKeyId,
+			EncryptionDataKey: file.EncryptionDataKey,
+			StoredSize:        file.StoredSize,
+			Compression:       file.Compression,
+		})
+	}
+
+	res := &repository.SearchFileResult{
+		Summary: repository.SearchFileSummary{
+			TotalItems: total,
+		},
+		Items: items,
+	}
+	return res, nil
+}
+
+func (r *file) PurgeFile(ctx context.Context, p repository.PurgeFileParam) (*repository.PurgeFileResult, error) {
+	cl := r.dbClient.Database(r.dbConfig.DbName).Collection("file")
+
+	filter := bson.D{
+		{
+			Key: "deleted_at",
+			Value: bson.D{
+				{
+					Key:   "$lt",
+					Value: p.DeletedBefore.UTC(),
+				},
+			},
+		},
+	}
+	findOpt := options.Find().
+		SetProjection(bson.D{{Key: "_id", Value: 1}, {Key: "path", Value: 1}}).
+		SetSort(bson.D{{Key: "deleted_at", Value: 1}}).
+		SetLimit(int64(p.Limit))
+	findRes, err := cl.Find(ctx, filter, findOpt)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []struct {
+		Id   string `bson:"_id"`
+		Path string `bson:"path"`
+	}{}
+	err = findRes.All(ctx, &files)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &repository.PurgeFileResult{}
+	if len(files) == 0 {
+		return res, nil
+	}
+
+	ids := bson.A{}
+	paths := []string{}
+	purgedPaths := map[string]bool{}
+	for _, file := range files {
+		ids = append(ids, file.Id)
+		if !purgedPaths[file.Path] {
+			purgedPaths[file.Path] = true
+			paths = append(paths, file.Path)
+		}
+	}
+
+	// @note: deleted_at is rechecked so only the deleted files are removed
+	deleteRes, err := cl.DeleteMany(ctx, append(filter, primitive.E{
+		Key: "_id",
+		Value: bson.D{
+			{
+				Key:   "$in",
+				Value: ids,
+			},
+		},
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, path := range paths {
+		references, err := cl.CountDocuments(ctx, bson.D{
+			{
+				Key:   "path",
+				Value: path,
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		err = p.PurgeFn(ctx, repository.PurgeFnParam{
+			FilePath:   path,
+			References: references,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	res.TotalItems = deleteRes.DeletedCount
+	return res, nil
+}
+
+func (r *file) SearchFileKey(ctx context.Context, p repository.SearchFileKeyParam) (*repository.SearchFileKeyResult, error) {
+	cl := r.dbClient.Database(r.dbConfig.DbName).Collection("file")
+
+	filter := bson.D{
+		{
+			Key: "encryption_key_id",
+			Value: bson.D{
+				{
+					Key:   "$nin",
+					Value: bson.A{"", nil, p.ExcludeKeyId},
+				},
+			},
+		},
+	}
+	if p.AfterId != "" {
+		filter = append(filter, primitive.E{
+			Key: "_id",
+			Value: bson.D{
+				{
+					Key:   "$gt",
+					Value: p.AfterId,
+				},
+			},
+		})
+	}
+
+	findOpt := options.Find().
+		SetProjection(bson.D{
+			{Key: "_id", Value: 1},
+			{Key: "encryption_key_id", Value: 1},
+			{Key: "encryption_data_key", Value: 1},
+		}).
+		SetSort(bson.D{{Key: "_id", Value: 1}})
+	if p.Limit > 0 {
+		findOpt.SetLimit(int64(p.Limit))
+	}
+
+	findRes, err := cl.Find(ctx, filter, findOpt)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []struct {
+		Id                string `bson:"_id"`
+		EncryptionKeyId   string `bson:"encryption_key_id"`
+		EncryptionDataKey string `bson:"encryption_data_key"`
+	}{}
+	err = findRes.All(ctx, &files)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &repository.SearchFileKeyResult{
+		Items: []repository.SearchFileKeyItem{},
+	}
+	for _, file := range files {
+		res.Items = append(res.Items, repository.SearchFileKeyItem{
+			UniqueId:          file.Id,
+			EncryptionKeyId:   file.EncryptionKeyId,
+			EncryptionDataKey: file.EncryptionDataKey,
+		})
+	}
+	return res, nil
+}
+
+func (r *file) UpdateFileKey(ctx context.Context, p repository.UpdateFileKeyParam) (*repository.UpdateFileKeyResult, error) {
+	cl := r.dbClient.Database(r.dbConfig.DbName).Collection("file")
+	filter := bson.D{
+		{
+			Key:   "_id",
+			Value: p.UniqueId,
+		},
+		{
+			Key:   "encryption_data_key",
+			Value: p.CurrentDataKey,
+		},
 	}
 	data := bson.M{
 		"$set": bson.M{
-			"deleted_at": p.DeletedAt,
+			"encryption_key_id":   p.EncryptionKeyId,
+			"encryption_data_key": p.EncryptionDataKey,
+			"updated_at":          p.UpdatedAt,
 		},
 	}
-	_, err = cl.UpdateOne(ctx, updateFilter, data)
+	updateRes, err := cl.UpdateOne(ctx, filter, data)
 	if err != nil {
 		return nil, err
 	}
 
-	res := &repository.DeleteFileResult{
-		DeletedAt: p.DeletedAt,
+	if updateRes.MatchedCount == 0 {
+		return nil, repository.ErrNotFound
+	}
+
+	res := &repository.UpdateFileKeyResult{
+		UpdatedAt: p.UpdatedAt,
 	}
 	return res, nil
 }
 
+var fileSortFields = map[string]string{
+	repository.FILE_SORT_NAME:        "name",
+	repository.FILE_SORT_SIZE:        "size",
+	repository.FILE_SORT_UPLOADED_AT: "created_at",
+	repository.FILE_SORT_ID:          "_id",
+}
+
+// @note: status filter is only applied when exactly one of the status is requested
+func searchFileStatus(statuses []string) (available bool, deleted bool) {
+	for _, status := range statuses {
+		switch status {
+		case repository.FILE_STATUS_AVAILABLE:
+			available = true
+		case repository.FILE_STATUS_DELETED:
+			deleted = true
+		}
+	}
+	return available, deleted
+}
+
+func (r *file) referenceBlob(ctx context.Context, checksum, path string, createdAt time.Time) (string, error) {
+	cl := r.dbClient.Database(r.dbConfig.DbName).Collection("file_blob")
+	filter := bson.D{
+		{
+			Key:   "_id",
+			Value: checksum,
+		},
+	}
+	data := bson.M{
+		"$inc": bson.M{
+			"ref_count": int64(1),
+		},
+		"$set": bson.M{
+			"updated_at": createdAt,
+		},
+		"$setOnInsert": bson.M{
+			"path":       path,
+			"created_at": createdAt,
+		},
+	}
+	opts := options.
+		FindOneAndUpdate().
+		SetUpsert(true).
+		SetReturnDocument(options.After)
+
+	blob := struct {
+		Path string `bson:"path"`
+	}{}
+	err := cl.FindOneAndUpdate(ctx, filter, data, opts).Decode(&blob)
+	if err != nil {
+		return "", err
+	}
+	return blob.Path, nil
+}
+
+// @note: undo the reference added for the file which failed to be created,
+// so the blob is not kept referenced forever, the given error is returned
+func (r *file) releaseBlob(ctx context.Context, checksum, path string, err error) error {
+	_, _, derr := r.dereferenceBlob(ctx, checksum, path)
+	if derr != nil {
+		return derr
+	}
+	return err
+}
+
+type blobFile struct {
+	EncryptionKeyId   string `bson:"encryption_key_id"`
+	EncryptionDataKey string `bson:"encryption_data_key"`
+	StoredSize        int64  `bson:"stored_size"`
+	Compression       string `bson:"compression"`
+}
+
+// @note: encryption key and compression of another file stored in the given path
+func (r *file) findBlobFile(ctx context.Context, path, excludeId string) (*blobFile, error) {
+	cl := r.dbClient.Database(r.dbConfig.DbName).Collection("file")
+	filter := bson.D{
+		{
+			Key:   "path",
+			Value: path,
+		},
+		{
+			Key: "_id",
+			Value: bson.D{
+				{
+					Key:   "$ne",
+					Value: excludeId,
+				},
+			},
+		},
+	}
+	opts := options.
+		FindOne().
+		SetProjection(bson.D{
+			{Key: "encryption_key_id", Value: 1},
+			{Key: "encryption_data_key", Value: 1},
+			{Key: "stored_size", Value: 1},
+			{Key: "compression", Value: 1},
+		})
+
+	file := &blobFile{}
+	err := cl.FindOne(ctx, filter, opts).Decode(file)
+	if err != nil && err != mongo.ErrNoDocuments {
+		return nil, err
+	}
+	return file, nil
+}
+
+// @note: remove file reference from the stored content (if it's deduplicated)
+// and return the remaining references and whether the file referenced the content,
+// blob record is removed when its last reference goes
+func (r *file) dereferenceBlob(ctx context.Context, checksum, path string) (int64, bool, error) {
+	if checksum == "" {
+		return 0, false, nil
+	}
+
+	cl := r.dbClient.Database(r.dbConfig.DbName).Collection("file_blob")
+	filter := bson.D{
+		{
+			Key:   "_id",
+			Value: checksum,
+		},
+		{
+			Key:   "path",
+			Value: path,
+		},
+		{
+			Key: "ref_count",
+			Value: bson.M{
+				"$gt": int64(1),
+			},
+		},
+	}
+	data := bson.M{
+		"$inc": bson.M{
+			"ref_count": int64(-1),
+		},
+	}
+	opts := options.
+		FindOneAndUpdate().
+		SetReturnDocument(options.After)
+
+	blob := struct {
+		RefCount int64 `bson:"ref_count"`
+	}{}
+	err := cl.FindOneAndUpdate(ctx, filter, data, opts).Decode(&blob)
+	if err == nil {
+		return blob.RefCount, true, nil
+	}
+	if err != mongo.ErrNoDocuments {
+		return 0, false, err
+	}
+
+	deleteRes, err := cl.DeleteOne(ctx, bson.D{
+		{
+			Key:   "_id",
+			Value: checksum,
+		},
+		{
+			Key:   "path",
+			Value: path,
+		},
+	})
+	if err != nil {
+		return 0, false, err
+	}
+	return 0, deleteRes.DeletedCount > 0, nil
+}
+
+// @note: add file reference back to the stored content and return the other references,
+// the content is registered again when it's no longer referenced and deduplication is enabled
+// file is not linked when the same content has been stored in another path meanwhile
+func (r *file) restoreBlob(ctx context.Context, checksum, path string, deduplicate bool, updatedAt time.Time) (int64, error) {
+	if checksum == "" {
+		return 0, nil
+	}
+
+	cl := r.dbClient.Database(r.dbConfig.DbName).Collection("file_blob")
+	filter := bson.D{
+		{
+			Key:   "_id",
+			Value: checksum,
+		},
+		{
+			Key:   "path",
+			Value: path,
+		},
+	}
+	data := bson.M{
+		"$inc": bson.M{
+			"ref_count": int64(1),
+		},
+		"$set": bson.M{
+			"updated_at": updatedAt,
+		},
+	}
+	opts := options.
+		FindOneAndUpdate().
+		SetReturnDocument(options.Before)
+
+	blob := struct {
+		RefCount int64 `bson:"ref_count"`
+	}{}
+	err := cl.FindOneAndUpdate(ctx, filter, data, opts).Decode(&blob)
+	if err == nil {
+		return blob.RefCount, nil
+	}
+	if err != mongo.ErrNoDocuments {
+		return 0, err
+	}
+
+	if !deduplicate {
+		return 0, nil
+	}
+
+	_, err = cl.InsertOne(ctx, bson.M{
+		"_id":        checksum,
+		"path":       path,
+		"ref_count":  int64(1),
+		"created_at": updatedAt,
+		"updated_at": updatedAt,
+	})
+	if err != nil && !mongo.IsDuplicateKeyError(err) {
+		return 0, err
+	}
+	return 0, nil
+}
+
+// @note: usage of the owner is released when the file is deleted (negative size)
+// and it's added back when the file is restored (positive size) as long as it's within the quota,
+// the usage of unowned file or the file of deleted client is not tracked
+func (r *file) updateOwnerUsage(ctx context.Context, ownerClientId string, size int64, usedAt time.Time) error {
+	if ownerClientId == "" {
+		return nil
+	}
+
+	totalFiles := int64(1)
+	if size < 0 {
+		totalFiles = -1
+	}
+	cl := r.dbClient.Database(r.dbConfig.DbName).Collection("auth_client")
+	_, err := updateClientUsage(ctx, cl, repository.UpdateClientUsageParam{
+		Id:         ownerClientId,
+		StoredSize: size,
+		TotalFiles: totalFiles,
+		UsedAt:     usedAt,
+		CheckQuota: size >= 0,
+	})
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil
+	}
+	return err
+}
+
+// @note: undo the usage added for the file which failed to be restored,
+// so the usage is not kept forever, the given error is returned
+func (r *file) releaseOwnerUsage(ctx context.Context, ownerClientId string, size int64, usedAt time.Time, err error) error {
+	uerr := r.updateOwnerUsage(ctx, ownerClientId, -size, usedAt)
+	if uerr != nil {
+		return uerr
+	}
+	return err
+}
+
 func NewFile(opts ...RepoOption) *file {
 	p := RepositoryParam{}
 	for _, opt := range opts {
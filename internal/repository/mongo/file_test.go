@@ -16,10 +16,11 @@ import (
 var _ = Describe("File Repository", func() {
 	Context("DeleteFile function", Label("integration"), Ordered, func() {
 		var (
-			ctx    context.Context
-			client *mongo.Client
-			repo   repository.File
-			p      repository.DeleteFileParam
+			ctx      context.Context
+			client   *mongo.Client
+			repo     repository.File
+			authRepo repository.Auth
+			p        repository.DeleteFileParam
 		)
 
 		BeforeAll(func() {
@@ -41,6 +42,7 @@ var _ = Describe("File Repository", func() {
 			})
 			dbClientOpt := repository_mongo.WithDbClient(client)
 			repo = repository_mongo.NewFile(dbClientOpt, dbCfgOpt)
+			authRepo = repository_mongo.NewAuth(dbClientOpt, dbCfgOpt)
 		})
 
 		BeforeEach(func() {
@@ -80,6 +82,38 @@ var _ = Describe("File Repository", func() {
 			if err != nil {
 				AbortSuite("failed prepare seed data: " + err.Error())
 			}
+
+			err = InsertFile(client, InsertFileParam{
+				Id:            "owned-unique-id",
+				Name:          "image",
+				Path:          "/file/2022/owned",
+				Mimetype:      "image/jpeg",
+				Extension:     "jpeg",
+				Size:          200,
+				CreatedAt:     1660380011999,
+				UpdatedAt:     1660380011999,
+				OwnerClientId: "owner-id",
+				DbName:        "hippo_test",
+			})
+			if err != nil {
+				AbortSuite("failed prepare seed data: " + err.Error())
+			}
+
+			err = InsertAuthClient(client, InsertAuthClientParam{
+				Id:           "owner-id",
+				Name:         "owner-client-name",
+				ClientId:     "owner-client-id",
+				ClientSecret: "owner-client-secret",
+				Type:         "basic",
+				Status:       "active",
+				Quota: bson.M{
+					"max_stored_size": int64(1024),
+				},
+				DbName: "hippo_test",
+			})
+			if err != nil {
+				AbortSuite("failed prepare seed data: " + err.Error())
+			}
 		})
 
 		AfterEach(func() {
@@ -92,7 +126,7 @@ var _ = Describe("File Repository", func() {
 						Value: bson.D{
 							{
 								Key:   "$in",
-								Value: []string{"mock-unique-id", "deleted-unique-id"},
+								Value: []string{"mock-unique-id", "deleted-unique-id", "owned-unique-id"},
 							},
 						},
 					},
@@ -100,6 +134,19 @@ var _ = Describe("File Repository", func() {
 			if err != nil {
 				AbortSuite("failed cleaning seed data: " + err.Error())
 			}
+
+			_, err = client.
+				Database("hippo_test").
+				Collection("auth_client").
+				DeleteMany(ctx, bson.D{
+					{
+						Key:   "_id",
+						Value: "owner-id",
+					},
+				})
+			if err != nil {
+				AbortSuite("failed cleaning seed data: " + err.Error())
+			}
 		})
 
 		AfterAll(func() {
@@ -139,6 +186,54 @@ var _ = Describe("File Repository", func() {
 
 				Expect(res).To(BeNil())
 				Expect(err).To(Equal(fmt.Errorf("failed proceed callback")))
+
+				file, err := repo.RetrieveFile(ctx, repository.RetrieveFileParam{
+					UniqueId: "mock-unique-id",
+				})
+				Expect(err).To(BeNil())
+				Expect(file.DeletedAt).To(BeNil())
+			})
+		})
+
+		When("file is deleted concurrently", func() {
+			It("should only delete once", func() {
+				p.UniqueId = "mock-unique-id"
+				p.DeletedAt = time.Now().UTC()
+				res, err := repo.DeleteFile(ctx, p)
+
+				Expect(res).ToNot(BeNil())
+				Expect(err).To(BeNil())
+
+				res, err = repo.DeleteFile(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(repository.ErrDeleted))
+			})
+		})
+
+		When("owned file is deleted", func() {
+			It("should release the owner usage", func() {
+				_, err := authRepo.UpdateClientUsage(ctx, repository.UpdateClientUsageParam{
+					Id:         "owner-id",
+					StoredSize: 500,
+					TotalFiles: 2,
+					UsedAt:     time.Now().UTC(),
+				})
+				Expect(err).To(BeNil())
+
+				p.UniqueId = "owned-unique-id"
+				p.DeletedAt = time.Now().UTC()
+				res, err := repo.DeleteFile(ctx, p)
+
+				Expect(res).ToNot(BeNil())
+				Expect(err).To(BeNil())
+
+				owner, err := authRepo.FindClient(ctx, repository.FindClientParam{
+					Id: "owner-id",
+				})
+				Expect(err).To(BeNil())
+				Expect(owner.Usage.StoredSize).To(Equal(int64(300)))
+				Expect(owner.Usage.TotalFiles).To(Equal(int64(1)))
 			})
 		})
 
@@ -152,12 +247,13 @@ var _ = Describe("File Repository", func() {
 		})
 	})
 
-	Context("RetrieveFile function", Label("integration"), Ordered, func() {
+	Context("RestoreFile function", Label("integration"), Ordered, func() {
 		var (
-			ctx    context.Context
-			client *mongo.Client
-			repo   repository.File
-			p      repository.RetrieveFileParam
+			ctx      context.Context
+			client   *mongo.Client
+			repo     repository.File
+			authRepo repository.Auth
+			p        repository.RestoreFileParam
 		)
 
 		BeforeAll(func() {
@@ -179,11 +275,16 @@ var _ = Describe("File Repository", func() {
 			})
 			dbClientOpt := repository_mongo.WithDbClient(client)
 			repo = repository_mongo.NewFile(dbClientOpt, dbCfgOpt)
+			authRepo = repository_mongo.NewAuth(dbClientOpt, dbCfgOpt)
 		})
 
 		BeforeEach(func() {
-			p = repository.RetrieveFileParam{
-				UniqueId: "mock-unique-id",
+			p = repository.RestoreFileParam{
+				UniqueId:   "deleted-unique-id",
+				RestoredAt: time.UnixMilli(1660380012999).UTC(),
+				RestoreFn: func(ctx context.Context, p repository.RestoreFnParam) error {
+					return nil
+				},
 			}
 			err := InsertFile(client, InsertFileParam{
 				Id:        "mock-unique-id",
@@ -199,6 +300,251 @@ var _ = Describe("File Repository", func() {
 			if err != nil {
 				AbortSuite("failed prepare seed data: " + err.Error())
 			}
+
+			err = InsertFile(client, InsertFileParam{
+				Id:        "deleted-unique-id",
+				Name:      "image",
+				Path:      "/file/2022",
+				Mimetype:  "image/jpeg",
+				Extension: "jpeg",
+				Size:      200,
+				CreatedAt: 1660380011999,
+				UpdatedAt: 1660380011999,
+				DeletedAt: 1660380011999,
+				DbName:    "hippo_test",
+			})
+			if err != nil {
+				AbortSuite("failed prepare seed data: " + err.Error())
+			}
+
+			err = InsertFile(client, InsertFileParam{
+				Id:            "owned-unique-id",
+				Name:          "image",
+				Path:          "/file/2022/owned",
+				Mimetype:      "image/jpeg",
+				Extension:     "jpeg",
+				Size:          200,
+				CreatedAt:     1660380011999,
+				UpdatedAt:     1660380011999,
+				DeletedAt:     1660380011999,
+				OwnerClientId: "owner-id",
+				DbName:        "hippo_test",
+			})
+			if err != nil {
+				AbortSuite("failed prepare seed data: " + err.Error())
+			}
+
+			err = InsertAuthClient(client, InsertAuthClientParam{
+				Id:           "owner-id",
+				Name:         "owner-client-name",
+				ClientId:     "owner-client-id",
+				ClientSecret: "owner-client-secret",
+				Type:         "basic",
+				Status:       "active",
+				Quota: bson.M{
+					"max_stored_size": int64(1024),
+				},
+				DbName: "hippo_test",
+			})
+			if err != nil {
+				AbortSuite("failed prepare seed data: " + err.Error())
+			}
+		})
+
+		AfterEach(func() {
+			_, err := client.
+				Database("hippo_test").
+				Collection("file").
+				DeleteMany(ctx, bson.D{
+					{
+						Key: "_id",
+						Value: bson.D{
+							{
+								Key:   "$in",
+								Value: []string{"mock-unique-id", "deleted-unique-id", "owned-unique-id"},
+							},
+						},
+					},
+				})
+			if err != nil {
+				AbortSuite("failed cleaning seed data: " + err.Error())
+			}
+
+			_, err = client.
+				Database("hippo_test").
+				Collection("auth_client").
+				DeleteMany(ctx, bson.D{
+					{
+						Key:   "_id",
+						Value: "owner-id",
+					},
+				})
+			if err != nil {
+				AbortSuite("failed cleaning seed data: " + err.Error())
+			}
+		})
+
+		AfterAll(func() {
+			err := client.Disconnect(ctx)
+			if err != nil {
+				AbortSuite("failed close test db: " + err.Error())
+			}
+		})
+
+		When("file is not available", func() {
+			It("should return error", func() {
+				p.UniqueId = "invalid-file-id"
+				res, err := repo.RestoreFile(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(repository.ErrNotFound))
+			})
+		})
+
+		When("file is not deleted", func() {
+			It("should return error", func() {
+				p.UniqueId = "mock-unique-id"
+				res, err := repo.RestoreFile(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(repository.ErrNotDeleted))
+			})
+		})
+
+		When("failed proceed callback", func() {
+			It("should return error", func() {
+				p.RestoreFn = func(ctx context.Context, p repository.RestoreFnParam) error {
+					return fmt.Errorf("failed proceed callback")
+				}
+				res, err := repo.RestoreFile(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("failed proceed callback")))
+
+				retrieve, err := repo.RetrieveFile(ctx, repository.RetrieveFileParam{
+					UniqueId: p.UniqueId,
+				})
+				Expect(err).To(BeNil())
+				Expect(retrieve.DeletedAt).ToNot(BeNil())
+			})
+		})
+
+		When("file is restored concurrently", func() {
+			It("should only restore once", func() {
+				res, err := repo.RestoreFile(ctx, p)
+
+				Expect(res).ToNot(BeNil())
+				Expect(err).To(BeNil())
+
+				res, err = repo.RestoreFile(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(repository.ErrNotDeleted))
+			})
+		})
+
+		When("restored file exceeds the owner quota", func() {
+			It("should return error", func() {
+				_, err := authRepo.UpdateClientUsage(ctx, repository.UpdateClientUsageParam{
+					Id:         "owner-id",
+					StoredSize: 900,
+					TotalFiles: 1,
+					UsedAt:     time.Now().UTC(),
+				})
+				Expect(err).To(BeNil())
+
+				p.UniqueId = "owned-unique-id"
+				res, err := repo.RestoreFile(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(MatchError(repository.ErrExceeded))
+			})
+		})
+
+		When("owned file is restored", func() {
+			It("should add the owner usage", func() {
+				p.UniqueId = "owned-unique-id"
+				res, err := repo.RestoreFile(ctx, p)
+
+				Expect(res).ToNot(BeNil())
+				Expect(err).To(BeNil())
+
+				owner, err := authRepo.FindClient(ctx, repository.FindClientParam{
+					Id: "owner-id",
+				})
+				Expect(err).To(BeNil())
+				Expect(owner.Usage.StoredSize).To(Equal(int64(200)))
+				Expect(owner.Usage.TotalFiles).To(Equal(int64(1)))
+			})
+		})
+
+		When("success restore file", func() {
+			It("should return result", func() {
+				res, err := repo.RestoreFile(ctx, p)
+
+				Expect(res).To(Equal(&repository.RestoreFileResult{
+					RestoredAt: p.RestoredAt,
+				}))
+				Expect(err).To(BeNil())
+
+				retrieve, err := repo.RetrieveFile(ctx, repository.RetrieveFileParam{
+					UniqueId: p.UniqueId,
+				})
+				Expect(err).To(BeNil())
+				Expect(retrieve.DeletedAt).To(BeNil())
+			})
+		})
+	})
+
+	Context("RetrieveFile function", Label("integration"), Ordered, func() {
+		var (
+			ctx    context.Context
+			client *mongo.Client
+			repo   repository.File
+			p      repository.RetrieveFileParam
+		)
+
+		BeforeAll(func() {
+			dbClient, err := OpenDb("")
+			if err != nil {
+				AbortSuite("failed open test db: " + err.Error())
+			}
+			client = dbClient
+
+			err = RunDbMigration(dbClient, RunDbMigrationParam{
+				DbName: "hippo_test",
+			})
+			if err != nil {
+				AbortSuite("failed prepare db migration: " + err.Error())
+			}
+			ctx = context.Background()
+			dbCfgOpt := repository_mongo.WithDbConfig(&repository_mongo.DbConfig{
+				DbName: "hippo_test",
+			})
+			dbClientOpt := repository_mongo.WithDbClient(client)
+			repo = repository_mongo.NewFile(dbClientOpt, dbCfgOpt)
+		})
+
+		BeforeEach(func() {
+			p = repository.RetrieveFileParam{
+				UniqueId: "mock-unique-id",
+			}
+			err := InsertFile(client, InsertFileParam{
+				Id:          "mock-unique-id",
+				Name:        "image",
+				Path:        "/file/2022",
+				Mimetype:    "image/jpeg",
+				Extension:   "jpeg",
+				Size:        200,
+				CreatedAt:   1660380011999,
+				UpdatedAt:   1660380011999,
+				DbName:      "hippo_test",
+				StoredSize:  50,
+				Compression: "gzip",
+			})
+			if err != nil {
+				AbortSuite("failed prepare seed data: " + err.Error())
+			}
 		})
 
 		AfterEach(func() {
@@ -238,6 +584,8 @@ var _ = Describe("File Repository", func() {
 				res, err := repo.RetrieveFile(ctx, p)
 
 				Expect(res).ToNot(BeNil())
+				Expect(res.StoredSize).To(Equal(int64(50)))
+				Expect(res.Compression).To(Equal("gzip"))
 				Expect(err).To(BeNil())
 			})
 		})
@@ -280,9 +628,12 @@ var _ = Describe("File Repository", func() {
 				Mimetype:  "image/jpeg",
 				Extension: "jpeg",
 				Size:      200,
-				CreateFn: func(ctx context.Context, p repository.CreateFnParam) error {
-					return nil
+				CreateFn: func(ctx context.Context, p repository.CreateFnParam) (*repository.CreateFnResult, error) {
+					return &repository.CreateFnResult{
+						Size: 200,
+					}, nil
 				},
+				OwnerClientId: "client-id",
 			}
 		})
 
@@ -310,8 +661,8 @@ var _ = Describe("File Repository", func() {
 
 		When("failed proceed callback", func() {
 			It("shold return error", func() {
-				p.CreateFn = func(ctx context.Context, p repository.CreateFnParam) error {
-					return fmt.Errorf("failed proceed callback")
+				p.CreateFn = func(ctx context.Context, p repository.CreateFnParam) (*repository.CreateFnResult, error) {
+					return nil, fmt.Errorf("failed proceed callback")
 				}
 				res, err := repo.CreateFile(ctx, p)
 
@@ -326,6 +677,12 @@ var _ = Describe("File Repository", func() {
 
 				Expect(res).ToNot(BeNil())
 				Expect(err).To(BeNil())
+
+				retrieve, err := repo.RetrieveFile(ctx, repository.RetrieveFileParam{
+					UniqueId: p.UniqueId,
+				})
+				Expect(err).To(BeNil())
+				Expect(retrieve.OwnerClientId).To(Equal("client-id"))
 			})
 		})
 
@@ -379,5 +736,484 @@ var _ = Describe("File Repository", func() {
 				Expect(err).ToNot(BeNil())
 			})
 		})
+
+		When("failed create deduplicated file", func() {
+			It("should release the blob reference", func() {
+				currentTimestamp := time.Now()
+				_, err := client.
+					Database("hippo_test").
+					Collection("file").
+					InsertOne(ctx, bson.D{
+						{
+							Key:   "_id",
+							Value: p.UniqueId,
+						},
+						{
+							Key:   "path",
+							Value: "/file/2021",
+						},
+						{
+							Key:   "created_at",
+							Value: currentTimestamp,
+						},
+					})
+				if err != nil {
+					AbortSuite("failed prepare dummy data: " + err.Error())
+				}
+
+				p.Deduplicate = true
+				p.CreateFn = func(ctx context.Context, p repository.CreateFnParam) (*repository.CreateFnResult, error) {
+					return &repository.CreateFnResult{
+						Size:           200,
+						ChecksumSha256: "mock-failed-checksum",
+					}, nil
+				}
+				res, err := repo.CreateFile(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).ToNot(BeNil())
+
+				total, err := client.
+					Database("hippo_test").
+					Collection("file_blob").
+					CountDocuments(ctx, bson.D{
+						{
+							Key:   "_id",
+							Value: "mock-failed-checksum",
+						},
+					})
+				Expect(err).To(BeNil())
+				Expect(total).To(Equal(int64(0)))
+			})
+		})
+
+		When("content is already stored", func() {
+			It("should return the stored content path", func() {
+				currentTimestamp := time.Now()
+				_, err := client.
+					Database("hippo_test").
+					Collection("file_blob").
+					InsertOne(ctx, bson.M{
+						"_id":        "mock-stored-checksum",
+						"path":       "/file/2021",
+						"ref_count":  int64(1),
+						"created_at": currentTimestamp,
+						"updated_at": currentTimestamp,
+					})
+				if err != nil {
+					AbortSuite("failed prepare dummy data: " + err.Error())
+				}
+
+				p.Deduplicate = true
+				p.CreateFn = func(ctx context.Context, p repository.CreateFnParam) (*repository.CreateFnResult, error) {
+					return &repository.CreateFnResult{
+						Size:           200,
+						ChecksumSha256: "mock-stored-checksum",
+					}, nil
+				}
+				p.DuplicateFn = func(ctx context.Context, p repository.DuplicateFnParam) error {
+					return nil
+				}
+				res, err := repo.CreateFile(ctx, p)
+
+				Expect(err).To(BeNil())
+				Expect(res.Path).To(Equal("/file/2021"))
+
+				_, err = client.
+					Database("hippo_test").
+					Collection("file_blob").
+					DeleteOne(ctx, bson.D{
+						{
+							Key:   "_id",
+							Value: "mock-stored-checksum",
+						},
+					})
+				if err != nil {
+					AbortSuite("failed cleaning seed data: " + err.Error())
+				}
+			})
+		})
+	})
+
+	Context("SearchFile function", Label("integration"), Ordered, func() {
+		var (
+			ctx    context.Context
+			client *mongo.Client
+			repo   repository.File
+			p      repository.SearchFileParam
+		)
+
+		BeforeAll(func() {
+			dbClient, err := OpenDb("")
+			if err != nil {
+				AbortSuite("failed open test db: " + err.Error())
+			}
+			client = dbClient
+
+			err = RunDbMigration(dbClient, RunDbMigrationParam{
+				DbName: "hippo_test",
+			})
+			if err != nil {
+				AbortSuite("failed prepare db migration: " + err.Error())
+			}
+			ctx = context.Background()
+			dbCfgOpt := repository_mongo.WithDbConfig(&repository_mongo.DbConfig{
+				DbName: "hippo_test",
+			})
+			dbClientOpt := repository_mongo.WithDbClient(client)
+			repo = repository_mongo.NewFile(dbClientOpt, dbCfgOpt)
+		})
+
+		BeforeEach(func() {
+			p = repository.SearchFileParam{
+				Limit:     24,
+				Keyword:   "search",
+				Mimetypes: []string{"image/jpeg"},
+				Statuses:  []string{"available"},
+				SortBy:    "size",
+				SortOrder: "desc",
+			}
+			seeds := []InsertFileParam{
+				{Id: "search-1", Name: "search-1", Mimetype: "image/jpeg", Size: 100},
+				{Id: "search-2", Name: "search-2", Mimetype: "image/jpeg", Size: 200},
+				{Id: "search-3", Name: "search-3", Mimetype: "image/png", Size: 300},
+				{Id: "search-4", Name: "search-4", Mimetype: "image/jpeg", Size: 400, DeletedAt: 1660380011999},
+			}
+			for _, seed := range seeds {
+				seed.Path = "/file/2022"
+				seed.Extension = "jpeg"
+				seed.CreatedAt = 1660380011999
+				seed.UpdatedAt = 1660380011999
+				seed.DbName = "hippo_test"
+				err := InsertFile(client, seed)
+				if err != nil {
+					AbortSuite("failed prepare seed data: " + err.Error())
+				}
+			}
+		})
+
+		AfterEach(func() {
+			_, err := client.
+				Database("hippo_test").
+				Collection("file").
+				DeleteMany(ctx, bson.D{})
+			if err != nil {
+				AbortSuite("failed cleaning seed data: " + err.Error())
+			}
+		})
+
+		AfterAll(func() {
+			err := client.Disconnect(ctx)
+			if err != nil {
+				AbortSuite("failed close test db: " + err.Error())
+			}
+		})
+
+		When("there is no matching file", func() {
+			It("should return empty result", func() {
+				p.Keyword = "unknown"
+				res, err := repo.SearchFile(ctx, p)
+
+				Expect(res.Summary.TotalItems).To(Equal(int64(0)))
+				Expect(res.Items).To(BeEmpty())
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("there are matching files", func() {
+			It("should return sorted result", func() {
+				res, err := repo.SearchFile(ctx, p)
+
+				Expect(err).To(BeNil())
+				Expect(res.Summary.TotalItems).To(Equal(int64(2)))
+				Expect(res.Items).To(HaveLen(2))
+				Expect(res.Items[0].UniqueId).To(Equal("search-2"))
+				Expect(res.Items[1].UniqueId).To(Equal("search-1"))
+			})
+		})
+
+		When("searching deleted file", func() {
+			It("should return deleted file", func() {
+				p.Statuses = []string{"deleted"}
+				res, err := repo.SearchFile(ctx, p)
+
+				Expect(err).To(BeNil())
+				Expect(res.Summary.TotalItems).To(Equal(int64(1)))
+				Expect(res.Items[0].UniqueId).To(Equal("search-4"))
+				Expect(res.Items[0].DeletedAt).ToNot(BeNil())
+			})
+		})
+
+		When("searching by path after the id", func() {
+			It("should return files after the id", func() {
+				p.Keyword = ""
+				p.Mimetypes = nil
+				p.Paths = []string{"/file/2022"}
+				p.AfterId = "search-1"
+				p.SortBy = repository.FILE_SORT_ID
+				p.SortOrder = repository.SORT_ASC
+				res, err := repo.SearchFile(ctx, p)
+
+				Expect(err).To(BeNil())
+				Expect(res.Summary.TotalItems).To(Equal(int64(2)))
+				Expect(res.Items).To(HaveLen(2))
+				Expect(res.Items[0].UniqueId).To(Equal("search-2"))
+				Expect(res.Items[1].UniqueId).To(Equal("search-3"))
+			})
+		})
+
+		When("searching by unknown path", func() {
+			It("should return empty result", func() {
+				p.Paths = []string{"/file/unknown"}
+				res, err := repo.SearchFile(ctx, p)
+
+				Expect(err).To(BeNil())
+				Expect(res.Summary.TotalItems).To(Equal(int64(0)))
+				Expect(res.Items).To(BeEmpty())
+			})
+		})
+	})
+
+	Context("PurgeFile function", Label("integration"), Ordered, func() {
+		var (
+			ctx          context.Context
+			client       *mongo.Client
+			repo         repository.File
+			p            repository.PurgeFileParam
+			purgeFnParam []repository.PurgeFnParam
+		)
+
+		BeforeAll(func() {
+			dbClient, err := OpenDb("")
+			if err != nil {
+				AbortSuite("failed open test db: " + err.Error())
+			}
+			client = dbClient
+
+			err = RunDbMigration(dbClient, RunDbMigrationParam{
+				DbName: "hippo_test",
+			})
+			if err != nil {
+				AbortSuite("failed prepare db migration: " + err.Error())
+			}
+			ctx = context.Background()
+			dbCfgOpt := repository_mongo.WithDbConfig(&repository_mongo.DbConfig{
+				DbName: "hippo_test",
+			})
+			dbClientOpt := repository_mongo.WithDbClient(client)
+			repo = repository_mongo.NewFile(dbClientOpt, dbCfgOpt)
+		})
+
+		BeforeEach(func() {
+			purgeFnParam = []repository.PurgeFnParam{}
+			p = repository.PurgeFileParam{
+				DeletedBefore: time.UnixMilli(1660380012000).UTC(),
+				Limit:         2,
+				PurgeFn: func(ctx context.Context, p repository.PurgeFnParam) error {
+					purgeFnParam = append(purgeFnParam, p)
+					return nil
+				},
+			}
+			seeds := []InsertFileParam{
+				{Id: "purge-1", DeletedAt: 1660380011000},
+				{Id: "purge-2", DeletedAt: 1660380011500},
+				{Id: "purge-3", DeletedAt: 1660380011999},
+				{Id: "purge-4", DeletedAt: 1660380013000},
+				{Id: "purge-5"},
+			}
+			for _, seed := range seeds {
+				seed.Name = seed.Id
+				seed.Path = "/file/2022"
+				seed.Mimetype = "image/jpeg"
+				seed.Extension = "jpeg"
+				seed.Size = 100
+				seed.CreatedAt = 1660380010000
+				seed.UpdatedAt = 1660380010000
+				seed.DbName = "hippo_test"
+				err := InsertFile(client, seed)
+				if err != nil {
+					AbortSuite("failed prepare seed data: " + err.Error())
+				}
+			}
+		})
+
+		AfterEach(func() {
+			_, err := client.
+				Database("hippo_test").
+				Collection("file").
+				DeleteMany(ctx, bson.D{})
+			if err != nil {
+				AbortSuite("failed cleaning seed data: " + err.Error())
+			}
+		})
+
+		AfterAll(func() {
+			err := client.Disconnect(ctx)
+			if err != nil {
+				AbortSuite("failed close test db: " + err.Error())
+			}
+		})
+
+		When("there are expired deleted files", func() {
+			It("should purge them in batches", func() {
+				res, err := repo.PurgeFile(ctx, p)
+
+				Expect(err).To(BeNil())
+				Expect(res.TotalItems).To(Equal(int64(2)))
+
+				res, err = repo.PurgeFile(ctx, p)
+
+				Expect(err).To(BeNil())
+				Expect(res.TotalItems).To(Equal(int64(1)))
+
+				res, err = repo.PurgeFile(ctx, p)
+
+				Expect(err).To(BeNil())
+				Expect(res.TotalItems).To(Equal(int64(0)))
+				Expect(purgeFnParam).To(Equal([]repository.PurgeFnParam{
+					{FilePath: "/file/2022", References: 3},
+					{FilePath: "/file/2022", References: 2},
+				}))
+
+				total, err := client.
+					Database("hippo_test").
+					Collection("file").
+					CountDocuments(ctx, bson.D{})
+				Expect(err).To(BeNil())
+				Expect(total).To(Equal(int64(2)))
+			})
+		})
+	})
+
+	Context("SearchFileKey and UpdateFileKey function", Label("integration"), Ordered, func() {
+		var (
+			ctx    context.Context
+			client *mongo.Client
+			repo   repository.File
+		)
+
+		BeforeAll(func() {
+			dbClient, err := OpenDb("")
+			if err != nil {
+				AbortSuite("failed open test db: " + err.Error())
+			}
+			client = dbClient
+
+			err = RunDbMigration(dbClient, RunDbMigrationParam{
+				DbName: "hippo_test",
+			})
+			if err != nil {
+				AbortSuite("failed prepare db migration: " + err.Error())
+			}
+			ctx = context.Background()
+			dbCfgOpt := repository_mongo.WithDbConfig(&repository_mongo.DbConfig{
+				DbName: "hippo_test",
+			})
+			dbClientOpt := repository_mongo.WithDbClient(client)
+			repo = repository_mongo.NewFile(dbClientOpt, dbCfgOpt)
+		})
+
+		BeforeEach(func() {
+			seeds := []InsertFileParam{
+				{Id: "key-1", EncryptionKeyId: "old", EncryptionDataKey: "data-1"},
+				{Id: "key-2", EncryptionKeyId: "new", EncryptionDataKey: "data-2"},
+				{Id: "key-3", EncryptionKeyId: "old", EncryptionDataKey: "data-3", DeletedAt: 1660380011000},
+				{Id: "key-4"},
+			}
+			for _, seed := range seeds {
+				seed.Name = seed.Id
+				seed.Path = "/file/" + seed.Id
+				seed.Mimetype = "image/jpeg"
+				seed.Extension = "jpeg"
+				seed.Size = 100
+				seed.CreatedAt = 1660380010000
+				seed.UpdatedAt = 1660380010000
+				seed.DbName = "hippo_test"
+				err := InsertFile(client, seed)
+				if err != nil {
+					AbortSuite("failed prepare seed data: " + err.Error())
+				}
+			}
+		})
+
+		AfterEach(func() {
+			_, err := client.
+				Database("hippo_test").
+				Collection("file").
+				DeleteMany(ctx, bson.D{})
+			if err != nil {
+				AbortSuite("failed cleaning seed data: " + err.Error())
+			}
+		})
+
+		AfterAll(func() {
+			err := client.Disconnect(ctx)
+			if err != nil {
+				AbortSuite("failed close test db: " + err.Error())
+			}
+		})
+
+		When("files are wrapped by another key", func() {
+			It("should return them in pages", func() {
+				res, err := repo.SearchFileKey(ctx, repository.SearchFileKeyParam{
+					ExcludeKeyId: "new",
+					Limit:        1,
+				})
+
+				Expect(err).To(BeNil())
+				Expect(res.Items).To(Equal([]repository.SearchFileKeyItem{
+					{UniqueId: "key-1", EncryptionKeyId: "old", EncryptionDataKey: "data-1"},
+				}))
+
+				res, err = repo.SearchFileKey(ctx, repository.SearchFileKeyParam{
+					ExcludeKeyId: "new",
+					AfterId:      "key-1",
+					Limit:        1,
+				})
+
+				Expect(err).To(BeNil())
+				Expect(res.Items).To(Equal([]repository.SearchFileKeyItem{
+					{UniqueId: "key-3", EncryptionKeyId: "old", EncryptionDataKey: "data-3"},
+				}))
+			})
+		})
+
+		When("data key is unchanged", func() {
+			It("should update the key", func() {
+				res, err := repo.UpdateFileKey(ctx, repository.UpdateFileKeyParam{
+					UniqueId:          "key-1",
+					CurrentDataKey:    "data-1",
+					EncryptionKeyId:   "new",
+					EncryptionDataKey: "data-1-new",
+					UpdatedAt:         time.UnixMilli(1660380012000).UTC(),
+				})
+
+				Expect(err).To(BeNil())
+				Expect(res).ToNot(BeNil())
+
+				file, err := repo.RetrieveFile(ctx, repository.RetrieveFileParam{
+					UniqueId: "key-1",
+				})
+
+				Expect(err).To(BeNil())
+				Expect(file.EncryptionKeyId).To(Equal("new"))
+				Expect(file.EncryptionDataKey).To(Equal("data-1-new"))
+			})
+		})
+
+		When("data key is already changed", func() {
+			It("should return error", func() {
+				res, err := repo.UpdateFileKey(ctx, repository.UpdateFileKeyParam{
+					UniqueId:          "key-1",
+					CurrentDataKey:    "data-0",
+					EncryptionKeyId:   "new",
+					EncryptionDataKey: "data-1-new",
+					UpdatedAt:         time.UnixMilli(1660380012000).UTC(),
+				})
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(repository.ErrNotFound))
+			})
+		})
 	})
 })
@@ -0,0 +1,445 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-seidon/hippo/internal/repository"
+	db_mongo "github.com/go-seidon/provider/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type multipart struct {
+	dbConfig *DbConfig
+	dbClient db_mongo.Client
+}
+
+func (r *multipart) CreateMultipart(ctx context.Context, p repository.CreateMultipartParam) (*repository.CreateMultipartResult, error) {
+	cl := r.dbClient.Database(r.dbConfig.DbName).Collection("multipart")
+	data := bson.D{
+		{
+			Key:   "_id",
+			Value: p.UniqueId,
+		},
+		{
+			Key:   "name",
+			Value: p.Name,
+		},
+		{
+			Key:   "mimetype",
+			Value: p.Mimetype,
+		},
+		{
+			Key:   "extension",
+			Value: p.Extension,
+		},
+		{
+			Key:   "file_id",
+			Value: "",
+		},
+		{
+			Key:   "owner_client_id",
+			Value: p.OwnerClientId,
+		},
+		{
+			Key:   "created_at",
+			Value: p.CreatedAt,
+		},
+		{
+			Key:   "updated_at",
+			Value: p.CreatedAt,
+		},
+	}
+	_, err := cl.InsertOne(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &repository.CreateMultipartResult{
+		UniqueId:  p.UniqueId,
+		Name:      p.Name,
+		Mimetype:  p.Mimetype,
+		Extension: p.Extension,
+		CreatedAt: p.CreatedAt,
+	}
+	return res, nil
+}
+
+func (r *multipart) RetrieveMultipart(ctx context.Context, p repository.RetrieveMultipartParam) (*repository.RetrieveMultipartResult, error) {
+	cl := r.dbClient.Database(r.dbConfig.DbName).Collection("multipart")
+	findFilter := bson.D{
+		{
+			Key:   "_id",
+			Value: p.UniqueId,
+		},
+	}
+	multipart := struct {
+		Id            string     `bson:"_id"`
+		Name          string     `bson:"name"`
+		Mimetype      string     `bson:"mimetype"`
+		Extension     string     `bson:"extension"`
+		FileId        string     `bson:"file_id"`
+		OwnerClientId string     `bson:"owner_client_id"`
+		CreatedAt     time.Time  `bson:"created_at"`
+		UpdatedAt     time.Time  `bson:"updated_at"`
+		CompletedAt   *time.Time `bson:"completed_at"`
+	}{}
+	err := cl.FindOne(ctx, findFilter).Decode(&multipart)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, repository.ErrNotFound
+		}
+		return nil, err
+	}
+
+	res := &repository.RetrieveMultipartResult{
+		UniqueId:      multipart.Id,
+		Name:          multipart.Name,
+		Mimetype:      multipart.Mimetype,
+		Extension:     multipart.Extension,
+		FileId:        multipart.FileId,
+		OwnerClientId: multipart.OwnerClientId,
+		CreatedAt:     multipart.CreatedAt,
+		UpdatedAt:     multipart.UpdatedAt,
+		CompletedAt:   multipart.CompletedAt,
+	}
+	return res, nil
+}
+
+func (r *multipart) SearchMultipart(ctx context.Context, p repository.SearchMultipartParam) (*repository.SearchMultipartResult, error) {
+	cl := r.dbClient.Database(r.dbConfig.DbName).Collection("multipart")
+	filter := bson.D{
+		{
+			Key: "updated_at",
+			Value: bson.M{
+				"$lt": p.UpdatedBefore,
+			},
+		},
+	}
+	opts := options.
+		Find().
+		SetSort(bson.D{
+			{
+				Key:   "updated_at",
+				Value: 1,
+			},
+		})
+	if p.Limit > 0 {
+		opts.SetLimit(int64(p.Limit))
+	}
+
+	findRes, err := cl.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	multiparts := []struct {
+		Id          string     `bson:"_id"`
+		CreatedAt   time.Time  `bson:"created_at"`
+		UpdatedAt   time.Time  `bson:"updated_at"`
+		CompletedAt *time.Time `bson:"completed_at"`
+	}{}
+	err = findRes.All(ctx, &multiparts)
+	if err != nil {
+		return nil, err
+	}
+
+	items := []repository.SearchMultipartItem{}
+	for _, multipart := range multiparts {
+		items = append(items, repository.SearchMultipartItem{
+			UniqueId:    multipart.Id,
+			CreatedAt:   multipart.CreatedAt,
+			UpdatedAt:   multipart.UpdatedAt,
+			CompletedAt: multipart.CompletedAt,
+		})
+	}
+
+	res := &repository.SearchMultipartResult{
+		Items: items,
+	}
+	return res, nil
+}
+
+// @note: part record is upserted by the multipart id and part number (unique index)
+// so the same part number uploaded in parallel is resolved as the last write,
+// the multipart last activity is recorded before the part is stored
+func (r *multipart) UploadPart(ctx context.Context, p repository.UploadPartParam) (*repository.UploadPartResult, error) {
+	db := r.dbClient.Database(r.dbConfig.DbName)
+	findFilter := bson.D{
+		{
+			Key:   "_id",
+			Value: p.MultipartId,
+		},
+	}
+	multipart := struct {
+		Id          string     `bson:"_id"`
+		CompletedAt *time.Time `bson:"completed_at"`
+	}{}
+	err := db.Collection("multipart").FindOne(ctx, findFilter).Decode(&multipart)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, repository.ErrNotFound
+		}
+		return nil, err
+	}
+
+	if multipart.CompletedAt != nil {
+		return nil, repository.ErrConflict
+	}
+
+	_, err = db.Collection("multipart").UpdateOne(ctx, findFilter, bson.M{
+		"$max": bson.M{
+			"updated_at": p.UploadedAt,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	partFilter := bson.D{
+		{
+			Key:   "multipart_id",
+			Value: p.MultipartId,
+		},
+		{
+			Key:   "part_number",
+			Value: p.PartNumber,
+		},
+	}
+	data := bson.M{
+		"$set": bson.M{
+			"path":            p.Path,
+			"size":            p.Size,
+			"checksum_sha256": p.ChecksumSha256,
+			"updated_at":      p.UploadedAt,
+		},
+		"$setOnInsert": bson.M{
+			"created_at": p.UploadedAt,
+		},
+	}
+	opts := options.
+		FindOneAndUpdate().
+		SetUpsert(true).
+		SetReturnDocument(options.Before)
+
+	part := struct {
+		Path string `bson:"path"`
+	}{}
+	err = db.Collection("multipart_part").FindOneAndUpdate(ctx, partFilter, data, opts).Decode(&part)
+	if err != nil && err != mongo.ErrNoDocuments {
+		return nil, err
+	}
+
+	res := &repository.UploadPartResult{
+		MultipartId:    p.MultipartId,
+		PartNumber:     p.PartNumber,
+		Path:           p.Path,
+		Size:           p.Size,
+		ChecksumSha256: p.ChecksumSha256,
+		UploadedAt:     p.UploadedAt,
+		ReplacedPath:   part.Path,
+	}
+	return res, nil
+}
+
+func (r *multipart) ListPart(ctx context.Context, p repository.ListPartParam) (*repository.ListPartResult, error) {
+	parts, err := r.findParts(ctx, p.MultipartId)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &repository.ListPartResult{
+		Items: parts,
+	}
+	return res, nil
+}
+
+// @note: the multipart is completed only when it's still not completed
+// so the concurrent completion is reported as conflict
+func (r *multipart) CompleteMultipart(ctx context.Context, p repository.CompleteMultipartParam) (*repository.CompleteMultipartResult, error) {
+	db := r.dbClient.Database(r.dbConfig.DbName)
+	findFilter := bson.D{
+		{
+			Key:   "_id",
+			Value: p.UniqueId,
+		},
+	}
+	multipart := struct {
+		Id          string     `bson:"_id"`
+		Name        string     `bson:"name"`
+		Mimetype    string     `bson:"mimetype"`
+		Extension   string     `bson:"extension"`
+		CompletedAt *time.Time `bson:"completed_at"`
+	}{}
+	err := db.Collection("multipart").FindOne(ctx, findFilter).Decode(&multipart)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, repository.ErrNotFound
+		}
+		return nil, err
+	}
+
+	if multipart.CompletedAt != nil {
+		return nil, repository.ErrConflict
+	}
+
+	parts, err := r.findParts(ctx, p.UniqueId)
+	if err != nil {
+		return nil, err
+	}
+
+	fn, err := p.CompleteFn(ctx, repository.CompleteFnParam{
+		Name:      multipart.Name,
+		Mimetype:  multipart.Mimetype,
+		Extension: multipart.Extension,
+		Parts:     parts,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	updateFilter := bson.D{
+		{
+			Key:   "_id",
+			Value: p.UniqueId,
+		},
+		{
+			Key:   "completed_at",
+			Value: nil,
+		},
+	}
+	data := bson.M{
+		"$set": bson.M{
+			"file_id":      fn.FileId,
+			"updated_at":   p.CompletedAt,
+			"completed_at": p.CompletedAt,
+		},
+	}
+	updateRes, err := db.Collection("multipart").UpdateOne(ctx, updateFilter, data)
+	if err != nil {
+		return nil, err
+	}
+	if updateRes.MatchedCount == 0 {
+		return nil, repository.ErrConflict
+	}
+
+	_, err = db.Collection("multipart_part").DeleteMany(ctx, bson.D{
+		{
+			Key:   "multipart_id",
+			Value: p.UniqueId,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := &repository.CompleteMultipartResult{
+		UniqueId:    multipart.Id,
+		FileId:      fn.FileId,
+		Parts:       parts,
+		CompletedAt: p.CompletedAt,
+	}
+	return res, nil
+}
+
+func (r *multipart) DeleteMultipart(ctx context.Context, p repository.DeleteMultipartParam) (*repository.DeleteMultipartResult, error) {
+	db := r.dbClient.Database(r.dbConfig.DbName)
+	filter := bson.D{
+		{
+			Key:   "_id",
+			Value: p.UniqueId,
+		},
+	}
+	multipart := struct {
+		Id string `bson:"_id"`
+	}{}
+	err := db.Collection("multipart").FindOneAndDelete(ctx, filter).Decode(&multipart)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, repository.ErrNotFound
+		}
+		return nil, err
+	}
+
+	parts, err := r.findParts(ctx, p.UniqueId)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = db.Collection("multipart_part").DeleteMany(ctx, bson.D{
+		{
+			Key:   "multipart_id",
+			Value: p.UniqueId,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := &repository.DeleteMultipartResult{
+		Parts:     parts,
+		DeletedAt: p.DeletedAt,
+	}
+	return res, nil
+}
+
+func (r *multipart) findParts(ctx context.Context, multipartId string) ([]repository.PartItem, error) {
+	cl := r.dbClient.Database(r.dbConfig.DbName).Collection("multipart_part")
+	filter := bson.D{
+		{
+			Key:   "multipart_id",
+			Value: multipartId,
+		},
+	}
+	opts := options.
+		Find().
+		SetSort(bson.D{
+			{
+				Key:   "part_number",
+				Value: 1,
+			},
+		})
+
+	findRes, err := cl.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := []struct {
+		PartNumber     int32     `bson:"part_number"`
+		Path           string    `bson:"path"`
+		Size           int64     `bson:"size"`
+		ChecksumSha256 string    `bson:"checksum_sha256"`
+		UpdatedAt      time.Time `bson:"updated_at"`
+	}{}
+	err = findRes.All(ctx, &parts)
+	if err != nil {
+		return nil, err
+	}
+
+	items := []repository.PartItem{}
+	for _, part := range parts {
+		items = append(items, repository.PartItem{
+			PartNumber:     part.PartNumber,
+			Path:           part.Path,
+			Size:           part.Size,
+			ChecksumSha256: part.ChecksumSha256,
+			UploadedAt:     part.UpdatedAt.UTC(),
+		})
+	}
+	return items, nil
+}
+
+func NewMultipart(opts ...RepoOption) *multipart {
+	p := RepositoryParam{}
+	for _, opt := range opts {
+		opt(&p)
+	}
+
+	return &multipart{
+		dbClient: p.dbClient,
+		dbConfig: p.dbConfig,
+	}
+}
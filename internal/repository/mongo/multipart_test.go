@@ -0,0 +1,267 @@
+package mongo_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-seidon/hippo/internal/repository"
+	repository_mongo "github.com/go-seidon/hippo/internal/repository/mongo"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var _ = Describe("Multipart Repository", func() {
+	Context("Multipart session", Label("integration"), Ordered, func() {
+		var (
+			ctx       context.Context
+			client    *mongo.Client
+			repo      repository.Multipart
+			currentTs time.Time
+		)
+
+		BeforeAll(func() {
+			dbClient, err := OpenDb("")
+			if err != nil {
+				AbortSuite("failed open test db: " + err.Error())
+			}
+			client = dbClient
+
+			err = RunDbMigration(dbClient, RunDbMigrationParam{
+				DbName: "hippo_test",
+			})
+			if err != nil {
+				AbortSuite("failed prepare db migration: " + err.Error())
+			}
+			ctx = context.Background()
+			currentTs = time.UnixMilli(time.Now().UnixMilli()).UTC()
+			dbCfgOpt := repository_mongo.WithDbConfig(&repository_mongo.DbConfig{
+				DbName: "hippo_test",
+			})
+			dbClientOpt := repository_mongo.WithDbClient(client)
+			repo = repository_mongo.NewMultipart(dbClientOpt, dbCfgOpt)
+		})
+
+		AfterAll(func() {
+			db := client.Database("hippo_test")
+			_, err := db.
+				Collection("multipart").
+				DeleteMany(ctx, bson.D{
+					{
+						Key: "_id",
+						Value: bson.M{
+							"$in": bson.A{"mock-multipart-id", "mock-aborted-id"},
+						},
+					},
+				})
+			if err != nil {
+				AbortSuite("failed cleaning seed data: " + err.Error())
+			}
+
+			_, err = db.
+				Collection("multipart_part").
+				DeleteMany(ctx, bson.D{
+					{
+						Key: "multipart_id",
+						Value: bson.M{
+							"$in": bson.A{"mock-multipart-id", "mock-aborted-id"},
+						},
+					},
+				})
+			if err != nil {
+				AbortSuite("failed cleaning seed data: " + err.Error())
+			}
+
+			err = client.Disconnect(ctx)
+			if err != nil {
+				AbortSuite("failed close test db: " + err.Error())
+			}
+		})
+
+		When("success create multipart", func() {
+			It("should return result", func() {
+				res, err := repo.CreateMultipart(ctx, repository.CreateMultipartParam{
+					UniqueId:      "mock-multipart-id",
+					Name:          "image",
+					Mimetype:      "image/jpeg",
+					Extension:     "jpeg",
+					CreatedAt:     currentTs,
+					OwnerClientId: "client-record-id",
+				})
+
+				Expect(err).To(BeNil())
+				Expect(res.UniqueId).To(Equal("mock-multipart-id"))
+
+				retrieve, err := repo.RetrieveMultipart(ctx, repository.RetrieveMultipartParam{
+					UniqueId: "mock-multipart-id",
+				})
+				Expect(err).To(BeNil())
+				Expect(retrieve.OwnerClientId).To(Equal("client-record-id"))
+
+				_, err = repo.CreateMultipart(ctx, repository.CreateMultipartParam{
+					UniqueId:  "mock-aborted-id",
+					CreatedAt: currentTs,
+				})
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("multipart is not available", func() {
+			It("should return error", func() {
+				res, err := repo.UploadPart(ctx, repository.UploadPartParam{
+					MultipartId: "invalid-multipart-id",
+					PartNumber:  1,
+				})
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(repository.ErrNotFound))
+			})
+		})
+
+		When("part is uploaded", func() {
+			It("should return result", func() {
+				res, err := repo.UploadPart(ctx, repository.UploadPartParam{
+					MultipartId:    "mock-multipart-id",
+					PartNumber:     2,
+					Path:           "/partial/mock-multipart-id.2.a",
+					Size:           100,
+					ChecksumSha256: "sha256-2",
+					UploadedAt:     currentTs,
+				})
+
+				Expect(err).To(BeNil())
+				Expect(res.ReplacedPath).To(Equal(""))
+
+				res, err = repo.UploadPart(ctx, repository.UploadPartParam{
+					MultipartId:    "mock-multipart-id",
+					PartNumber:     1,
+					Path:           "/partial/mock-multipart-id.1.a",
+					Size:           200,
+					ChecksumSha256: "sha256-1",
+					UploadedAt:     currentTs,
+				})
+
+				Expect(err).To(BeNil())
+				Expect(res.ReplacedPath).To(Equal(""))
+			})
+		})
+
+		When("part is re-uploaded", func() {
+			It("should return replaced path", func() {
+				res, err := repo.UploadPart(ctx, repository.UploadPartParam{
+					MultipartId:    "mock-multipart-id",
+					PartNumber:     2,
+					Path:           "/partial/mock-multipart-id.2.b",
+					Size:           150,
+					ChecksumSha256: "sha256-2b",
+					UploadedAt:     currentTs.Add(time.Minute),
+				})
+
+				Expect(err).To(BeNil())
+				Expect(res.ReplacedPath).To(Equal("/partial/mock-multipart-id.2.a"))
+
+				retrieve, err := repo.RetrieveMultipart(ctx, repository.RetrieveMultipartParam{
+					UniqueId: "mock-multipart-id",
+				})
+				Expect(err).To(BeNil())
+				Expect(retrieve.UpdatedAt).To(Equal(currentTs.Add(time.Minute)))
+			})
+		})
+
+		When("success list part", func() {
+			It("should return ordered result", func() {
+				res, err := repo.ListPart(ctx, repository.ListPartParam{
+					MultipartId: "mock-multipart-id",
+				})
+
+				Expect(err).To(BeNil())
+				Expect(len(res.Items)).To(Equal(2))
+				Expect(res.Items[0].PartNumber).To(Equal(int32(1)))
+				Expect(res.Items[1].Path).To(Equal("/partial/mock-multipart-id.2.b"))
+			})
+		})
+
+		When("failed proceed complete callback", func() {
+			It("should return error", func() {
+				res, err := repo.CompleteMultipart(ctx, repository.CompleteMultipartParam{
+					UniqueId:    "mock-multipart-id",
+					CompletedAt: currentTs,
+					CompleteFn: func(ctx context.Context, p repository.CompleteFnParam) (*repository.CompleteFnResult, error) {
+						return nil, fmt.Errorf("failed proceed callback")
+					},
+				})
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("failed proceed callback")))
+			})
+		})
+
+		When("success complete multipart", func() {
+			It("should return result", func() {
+				res, err := repo.CompleteMultipart(ctx, repository.CompleteMultipartParam{
+					UniqueId:    "mock-multipart-id",
+					CompletedAt: currentTs,
+					CompleteFn: func(ctx context.Context, p repository.CompleteFnParam) (*repository.CompleteFnResult, error) {
+						return &repository.CompleteFnResult{FileId: "mock-file-id"}, nil
+					},
+				})
+
+				Expect(err).To(BeNil())
+				Expect(res.FileId).To(Equal("mock-file-id"))
+				Expect(len(res.Parts)).To(Equal(2))
+			})
+		})
+
+		When("multipart is already completed", func() {
+			It("should return error", func() {
+				res, err := repo.UploadPart(ctx, repository.UploadPartParam{
+					MultipartId: "mock-multipart-id",
+					PartNumber:  3,
+				})
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(repository.ErrConflict))
+			})
+		})
+
+		When("success search multipart", func() {
+			It("should return result", func() {
+				res, err := repo.SearchMultipart(ctx, repository.SearchMultipartParam{
+					UpdatedBefore: currentTs.Add(time.Second),
+					Limit:         100,
+				})
+
+				Expect(err).To(BeNil())
+				ids := []string{}
+				for _, item := range res.Items {
+					ids = append(ids, item.UniqueId)
+				}
+				Expect(ids).To(ContainElement("mock-aborted-id"))
+				Expect(ids).NotTo(ContainElement("mock-multipart-id"))
+			})
+		})
+
+		When("success delete multipart", func() {
+			It("should return result", func() {
+				_, err := repo.UploadPart(ctx, repository.UploadPartParam{
+					MultipartId: "mock-aborted-id",
+					PartNumber:  1,
+					Path:        "/partial/mock-aborted-id.1.a",
+					UploadedAt:  currentTs,
+				})
+				Expect(err).To(BeNil())
+
+				res, err := repo.DeleteMultipart(ctx, repository.DeleteMultipartParam{
+					UniqueId:  "mock-aborted-id",
+					DeletedAt: currentTs,
+				})
+
+				Expect(err).To(BeNil())
+				Expect(len(res.Parts)).To(Equal(1))
+				Expect(res.Parts[0].Path).To(Equal("/partial/mock-aborted-id.1.a"))
+			})
+		})
+	})
+})
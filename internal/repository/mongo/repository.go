@@ -12,9 +12,11 @@ import (
 )
 
 type mongoRepository struct {
-	dbClient db_mongo.Client
-	authRepo *auth
-	fileRepo *file
+	dbClient      db_mongo.Client
+	authRepo      *auth
+	fileRepo      *file
+	uploadRepo    *upload
+	multipartRepo *multipart
 }
 
 func (p *mongoRepository) Init(ctx context.Context) error {
@@ -40,6 +42,14 @@ func (p *mongoRepository) GetFile() repository.File {
 	return p.fileRepo
 }
 
+func (p *mongoRepository) GetUpload() repository.Upload {
+	return p.uploadRepo
+}
+
+func (p *mongoRepository) GetMultipart() repository.Multipart {
+	return p.multipartRepo
+}
+
 func NewRepository(opts ...RepoOption) (*mongoRepository, error) {
 	p := RepositoryParam{}
 	for _, opt := range opts {
@@ -61,11 +71,21 @@ func NewRepository(opts ...RepoOption) (*mongoRepository, error) {
 		dbConfig: p.dbConfig,
 		dbClient: p.dbClient,
 	}
+	uploadRepo := &upload{
+		dbConfig: p.dbConfig,
+		dbClient: p.dbClient,
+	}
+	multipartRepo := &multipart{
+		dbConfig: p.dbConfig,
+		dbClient: p.dbClient,
+	}
 
 	repo := &mongoRepository{
-		dbClient: p.dbClient,
-		authRepo: authRepo,
-		fileRepo: fileRepo,
+		dbClient:      p.dbClient,
+		authRepo:      authRepo,
+		fileRepo:      fileRepo,
+		uploadRepo:    uploadRepo,
+		multipartRepo: multipartRepo,
 	}
 	return repo, nil
 }
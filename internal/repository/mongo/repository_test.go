@@ -100,6 +100,50 @@ var _ = Describe("Repository Provider", func() {
 		})
 	})
 
+	Context("GetUpload function", Label("unit"), func() {
+		var (
+			provider repository.Repository
+		)
+
+		BeforeEach(func() {
+			mOpt := repository_mongo.WithDbClient(&mongo.Client{})
+			dbCfgOpt := repository_mongo.WithDbConfig(&repository_mongo.DbConfig{
+				DbName: "db_name",
+			})
+			provider, _ = repository_mongo.NewRepository(mOpt, dbCfgOpt)
+		})
+
+		When("function is called", func() {
+			It("should return result", func() {
+				res := provider.GetUpload()
+
+				Expect(res).ToNot(BeNil())
+			})
+		})
+	})
+
+	Context("GetMultipart function", Label("unit"), func() {
+		var (
+			provider repository.Repository
+		)
+
+		BeforeEach(func() {
+			mOpt := repository_mongo.WithDbClient(&mongo.Client{})
+			dbCfgOpt := repository_mongo.WithDbConfig(&repository_mongo.DbConfig{
+				DbName: "db_name",
+			})
+			provider, _ = repository_mongo.NewRepository(mOpt, dbCfgOpt)
+		})
+
+		When("function is called", func() {
+			It("should return result", func() {
+				res := provider.GetMultipart()
+
+				Expect(res).ToNot(BeNil())
+			})
+		})
+	})
+
 	Context("Init function", Label("unit"), func() {
 		var (
 			provider repository.Repository
@@ -0,0 +1,274 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-seidon/hippo/internal/repository"
+	db_mongo "github.com/go-seidon/provider/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type upload struct {
+	dbConfig *DbConfig
+	dbClient db_mongo.Client
+}
+
+func (r *upload) CreateUpload(ctx context.Context, p repository.CreateUploadParam) (*repository.CreateUploadResult, error) {
+	cl := r.dbClient.Database(r.dbConfig.DbName).Collection("upload")
+	data := bson.D{
+		{
+			Key:   "_id",
+			Value: p.UniqueId,
+		},
+		{
+			Key:   "name",
+			Value: p.Name,
+		},
+		{
+			Key:   "extension",
+			Value: p.Extension,
+		},
+		{
+			Key:   "path",
+			Value: p.Path,
+		},
+		{
+			Key:   "size",
+			Value: p.Size,
+		},
+		{
+			Key:   "offset",
+			Value: int64(0),
+		},
+		{
+			Key:   "file_id",
+			Value: "",
+		},
+		{
+			Key:   "owner_client_id",
+			Value: p.OwnerClientId,
+		},
+		{
+			Key:   "created_at",
+			Value: p.CreatedAt,
+		},
+		{
+			Key:   "updated_at",
+			Value: p.CreatedAt,
+		},
+	}
+	_, err := cl.InsertOne(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &repository.CreateUploadResult{
+		UniqueId:  p.UniqueId,
+		Name:      p.Name,
+		Extension: p.Extension,
+		Path:      p.Path,
+		Size:      p.Size,
+		Offset:    0,
+		CreatedAt: p.CreatedAt,
+	}
+	return res, nil
+}
+
+func (r *upload) RetrieveUpload(ctx context.Context, p repository.RetrieveUploadParam) (*repository.RetrieveUploadResult, error) {
+	cl := r.dbClient.Database(r.dbConfig.DbName).Collection("upload")
+	findFilter := bson.D{
+		{
+			Key:   "_id",
+			Value: p.UniqueId,
+		},
+	}
+	upload := struct {
+		Id            string     `bson:"_id"`
+		Name          string     `bson:"name"`
+		Extension     string     `bson:"extension"`
+		Path          string     `bson:"path"`
+		Size          int64      `bson:"size"`
+		Offset        int64      `bson:"offset"`
+		FileId        string     `bson:"file_id"`
+		OwnerClientId string     `bson:"owner_client_id"`
+		CreatedAt     time.Time  `bson:"created_at"`
+		UpdatedAt     time.Time  `bson:"updated_at"`
+		CompletedAt   *time.Time `bson:"completed_at"`
+	}{}
+	err := cl.FindOne(ctx, findFilter).Decode(&upload)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, repository.ErrNotFound
+		}
+		return nil, err
+	}
+
+	res := &repository.RetrieveUploadResult{
+		UniqueId:      upload.Id,
+		Name:          upload.Name,
+		Extension:     upload.Extension,
+		Path:          upload.Path,
+		Size:          upload.Size,
+		Offset:        upload.Offset,
+		FileId:        upload.FileId,
+		OwnerClientId: upload.OwnerClientId,
+		CreatedAt:     upload.CreatedAt,
+		UpdatedAt:     upload.UpdatedAt,
+		CompletedAt:   upload.CompletedAt,
+	}
+	return res, nil
+}
+
+// @note: the offset is moved only when it's still equal to the param offset
+// so a concurrent request of the same upload is reported as conflict
+func (r *upload) AppendUpload(ctx context.Context, p repository.AppendUploadParam) (*repository.AppendUploadResult, error) {
+	cl := r.dbClient.Database(r.dbConfig.DbName).Collection("upload")
+	findFilter := bson.D{
+		{
+			Key:   "_id",
+			Value: p.UniqueId,
+		},
+	}
+	upload := struct {
+		Id          string     `bson:"_id"`
+		Path        string     `bson:"path"`
+		Size        int64      `bson:"size"`
+		Offset      int64      `bson:"offset"`
+		CompletedAt *time.Time `bson:"completed_at"`
+	}{}
+	err := cl.FindOne(ctx, findFilter).Decode(&upload)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, repository.ErrNotFound
+		}
+		return nil, err
+	}
+
+	if upload.CompletedAt != nil || upload.Offset != p.Offset {
+		return nil, repository.ErrConflict
+	}
+
+	fn, err := p.AppendFn(ctx, repository.AppendFnParam{
+		FilePath: upload.Path,
+		Offset:   upload.Offset,
+		Size:     upload.Size,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	updateFilter := bson.D{
+		{
+			Key:   "_id",
+			Value: p.UniqueId,
+		},
+		{
+			Key:   "offset",
+			Value: p.Offset,
+		},
+	}
+	data := bson.M{
+		"$set": bson.M{
+			"offset":     fn.Size,
+			"updated_at": p.UpdatedAt,
+		},
+	}
+	updateRes, err := cl.UpdateOne(ctx, updateFilter, data)
+	if err != nil {
+		return nil, err
+	}
+	if updateRes.MatchedCount == 0 {
+		return nil, repository.ErrConflict
+	}
+
+	res := &repository.AppendUploadResult{
+		UniqueId:  upload.Id,
+		Size:      upload.Size,
+		Offset:    fn.Size,
+		UpdatedAt: p.UpdatedAt,
+	}
+	return res, nil
+}
+
+// @note: return `ErrNotFound` if the upload is not available or already completed
+func (r *upload) CompleteUpload(ctx context.Context, p repository.CompleteUploadParam) (*repository.CompleteUploadResult, error) {
+	cl := r.dbClient.Database(r.dbConfig.DbName).Collection("upload")
+	updateFilter := bson.D{
+		{
+			Key:   "_id",
+			Value: p.UniqueId,
+		},
+		{
+			Key:   "completed_at",
+			Value: nil,
+		},
+	}
+	data := bson.M{
+		"$set": bson.M{
+			"file_id":      p.FileId,
+			"updated_at":   p.CompletedAt,
+			"completed_at": p.CompletedAt,
+		},
+	}
+	updateRes, err := cl.UpdateOne(ctx, updateFilter, data)
+	if err != nil {
+		return nil, err
+	}
+	if updateRes.MatchedCount == 0 {
+		return nil, repository.ErrNotFound
+	}
+
+	res := &repository.CompleteUploadResult{
+		UniqueId:    p.UniqueId,
+		FileId:      p.FileId,
+		CompletedAt: p.CompletedAt,
+	}
+	return res, nil
+}
+
+func (r *upload) DeleteUpload(ctx context.Context, p repository.DeleteUploadParam) (*repository.DeleteUploadResult, error) {
+	cl := r.dbClient.Database(r.dbConfig.DbName).Collection("upload")
+	filter := bson.D{
+		{
+			Key:   "_id",
+			Value: p.UniqueId,
+		},
+	}
+	upload := struct {
+		Id   string `bson:"_id"`
+		Path string `bson:"path"`
+	}{}
+	err := cl.FindOneAndDelete(ctx, filter).Decode(&upload)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, repository.ErrNotFound
+		}
+		return nil, err
+	}
+
+	err = p.DeleteFn(ctx, repository.DeleteFnParam{
+		FilePath: upload.Path,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := &repository.DeleteUploadResult{
+		DeletedAt: p.DeletedAt,
+	}
+	return res, nil
+}
+
+func NewUpload(opts ...RepoOption) *upload {
+	p := RepositoryParam{}
+	for _, opt := range opts {
+		opt(&p)
+	}
+
+	return &upload{
+		dbClient: p.dbClient,
+		dbConfig: p.dbConfig,
+	}
+}
@@ -0,0 +1,195 @@
+package mongo_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-seidon/hippo/internal/repository"
+	repository_mongo "github.com/go-seidon/hippo/internal/repository/mongo"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var _ = Describe("Upload Repository", func() {
+	Context("Upload session", Label("integration"), Ordered, func() {
+		var (
+			ctx       context.Context
+			client    *mongo.Client
+			repo      repository.Upload
+			currentTs time.Time
+		)
+
+		BeforeAll(func() {
+			dbClient, err := OpenDb("")
+			if err != nil {
+				AbortSuite("failed open test db: " + err.Error())
+			}
+			client = dbClient
+
+			err = RunDbMigration(dbClient, RunDbMigrationParam{
+				DbName: "hippo_test",
+			})
+			if err != nil {
+				AbortSuite("failed prepare db migration: " + err.Error())
+			}
+			ctx = context.Background()
+			currentTs = time.UnixMilli(time.Now().UnixMilli()).UTC()
+			dbCfgOpt := repository_mongo.WithDbConfig(&repository_mongo.DbConfig{
+				DbName: "hippo_test",
+			})
+			dbClientOpt := repository_mongo.WithDbClient(client)
+			repo = repository_mongo.NewUpload(dbClientOpt, dbCfgOpt)
+		})
+
+		AfterAll(func() {
+			_, err := client.
+				Database("hippo_test").
+				Collection("upload").
+				DeleteMany(ctx, bson.D{
+					{
+						Key:   "_id",
+						Value: "mock-upload-id",
+					},
+				})
+			if err != nil {
+				AbortSuite("failed cleaning seed data: " + err.Error())
+			}
+
+			err = client.Disconnect(ctx)
+			if err != nil {
+				AbortSuite("failed close test db: " + err.Error())
+			}
+		})
+
+		When("success create upload", func() {
+			It("should return result", func() {
+				res, err := repo.CreateUpload(ctx, repository.CreateUploadParam{
+					UniqueId:      "mock-upload-id",
+					Name:          "image",
+					Extension:     "jpeg",
+					Path:          "/partial/mock-upload-id",
+					Size:          200,
+					CreatedAt:     currentTs,
+					OwnerClientId: "owner-id",
+				})
+
+				Expect(err).To(BeNil())
+				Expect(res.Offset).To(Equal(int64(0)))
+			})
+		})
+
+		When("upload is not available", func() {
+			It("should return error", func() {
+				res, err := repo.RetrieveUpload(ctx, repository.RetrieveUploadParam{
+					UniqueId: "invalid-upload-id",
+				})
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(repository.ErrNotFound))
+			})
+		})
+
+		When("offset is not matched", func() {
+			It("should return error", func() {
+				res, err := repo.AppendUpload(ctx, repository.AppendUploadParam{
+					UniqueId:  "mock-upload-id",
+					Offset:    100,
+					UpdatedAt: currentTs,
+				})
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(repository.ErrConflict))
+			})
+		})
+
+		When("failed proceed append callback", func() {
+			It("should return error", func() {
+				res, err := repo.AppendUpload(ctx, repository.AppendUploadParam{
+					UniqueId:  "mock-upload-id",
+					Offset:    0,
+					UpdatedAt: currentTs,
+					AppendFn: func(ctx context.Context, p repository.AppendFnParam) (*repository.AppendFnResult, error) {
+						return nil, fmt.Errorf("failed proceed callback")
+					},
+				})
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("failed proceed callback")))
+			})
+		})
+
+		When("success append upload", func() {
+			It("should return result", func() {
+				res, err := repo.AppendUpload(ctx, repository.AppendUploadParam{
+					UniqueId:  "mock-upload-id",
+					Offset:    0,
+					UpdatedAt: currentTs,
+					AppendFn: func(ctx context.Context, p repository.AppendFnParam) (*repository.AppendFnResult, error) {
+						return &repository.AppendFnResult{Size: 200}, nil
+					},
+				})
+
+				Expect(err).To(BeNil())
+				Expect(res.Offset).To(Equal(int64(200)))
+			})
+		})
+
+		When("success complete upload", func() {
+			It("should return result", func() {
+				res, err := repo.CompleteUpload(ctx, repository.CompleteUploadParam{
+					UniqueId:    "mock-upload-id",
+					FileId:      "mock-file-id",
+					CompletedAt: currentTs,
+				})
+
+				Expect(err).To(BeNil())
+				Expect(res.FileId).To(Equal("mock-file-id"))
+			})
+		})
+
+		When("upload is already completed", func() {
+			It("should return error", func() {
+				res, err := repo.CompleteUpload(ctx, repository.CompleteUploadParam{
+					UniqueId:    "mock-upload-id",
+					FileId:      "mock-file-id",
+					CompletedAt: currentTs,
+				})
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(repository.ErrNotFound))
+			})
+		})
+
+		When("success retrieve upload", func() {
+			It("should return result", func() {
+				res, err := repo.RetrieveUpload(ctx, repository.RetrieveUploadParam{
+					UniqueId: "mock-upload-id",
+				})
+
+				Expect(err).To(BeNil())
+				Expect(res.Offset).To(Equal(int64(200)))
+				Expect(res.FileId).To(Equal("mock-file-id"))
+				Expect(res.OwnerClientId).To(Equal("owner-id"))
+				Expect(res.CompletedAt).ToNot(BeNil())
+			})
+		})
+
+		When("success delete upload", func() {
+			It("should return result", func() {
+				res, err := repo.DeleteUpload(ctx, repository.DeleteUploadParam{
+					UniqueId:  "mock-upload-id",
+					DeletedAt: currentTs,
+					DeleteFn: func(ctx context.Context, p repository.DeleteFnParam) error {
+						return nil
+					},
+				})
+
+				Expect(err).To(BeNil())
+				Expect(res.DeletedAt).To(Equal(currentTs))
+			})
+		})
+	})
+})
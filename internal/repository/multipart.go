@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+type (
+	CompleteFn func(ctx context.Context, p CompleteFnParam) (*CompleteFnResult, error)
+)
+
+type Multipart interface {
+	CreateMultipart(ctx context.Context, p CreateMultipartParam) (*CreateMultipartResult, error)
+	RetrieveMultipart(ctx context.Context, p RetrieveMultipartParam) (*RetrieveMultipartResult, error)
+	SearchMultipart(ctx context.Context, p SearchMultipartParam) (*SearchMultipartResult, error)
+	UploadPart(ctx context.Context, p UploadPartParam) (*UploadPartResult, error)
+	ListPart(ctx context.Context, p ListPartParam) (*ListPartResult, error)
+	CompleteMultipart(ctx context.Context, p CompleteMultipartParam) (*CompleteMultipartResult, error)
+	DeleteMultipart(ctx context.Context, p DeleteMultipartParam) (*DeleteMultipartResult, error)
+}
+
+type CreateMultipartParam struct {
+	UniqueId  string
+	Name      string
+	Mimetype  string
+	Extension string
+	CreatedAt time.Time
+	// @note: id of the auth client record which initiated the multipart,
+	// empty when it's not initiated by a client
+	OwnerClientId string
+}
+
+type CreateMultipartResult struct {
+	UniqueId  string
+	Name      string
+	Mimetype  string
+	Extension string
+	CreatedAt time.Time
+}
+
+type RetrieveMultipartParam struct {
+	UniqueId string
+}
+
+type RetrieveMultipartResult struct {
+	UniqueId      string
+	Name          string
+	Mimetype      string
+	Extension     string
+	FileId        string
+	OwnerClientId string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	CompletedAt   *time.Time
+}
+
+type SearchMultipartParam struct {
+	UpdatedBefore time.Time
+	Limit         int32
+}
+
+type SearchMultipartResult struct {
+	Items []SearchMultipartItem
+}
+
+type SearchMultipartItem struct {
+	UniqueId    string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	CompletedAt *time.Time
+}
+
+// @note: return `ErrConflict` if the multipart is already completed,
+// the replaced path is the previous part path when the part number is re-uploaded
+type UploadPartParam struct {
+	MultipartId    string
+	PartNumber     int32
+	Path           string
+	Size           int64
+	ChecksumSha256 string
+	UploadedAt     time.Time
+}
+
+type UploadPartResult struct {
+	MultipartId    string
+	PartNumber     int32
+	Path           string
+	Size           int64
+	ChecksumSha256 string
+	UploadedAt     time.Time
+	ReplacedPath   string
+}
+
+type ListPartParam struct {
+	MultipartId string
+}
+
+type ListPartResult struct {
+	Items []PartItem
+}
+
+type PartItem struct {
+	PartNumber     int32
+	Path           string
+	Size           int64
+	ChecksumSha256 string
+	UploadedAt     time.Time
+}
+
+// @note: return `ErrConflict` if the multipart is already completed,
+// the parts are removed once the multipart is completed
+type CompleteMultipartParam struct {
+	UniqueId    string
+	CompletedAt time.Time
+	CompleteFn  CompleteFn
+}
+
+type CompleteFnParam struct {
+	Name      string
+	Mimetype  string
+	Extension string
+	Parts     []PartItem
+}
+
+type CompleteFnResult struct {
+	FileId string
+}
+
+type CompleteMultipartResult struct {
+	UniqueId    string
+	FileId      string
+	Parts       []PartItem
+	CompletedAt time.Time
+}
+
+type DeleteMultipartParam struct {
+	UniqueId  string
+	DeletedAt time.Time
+}
+
+type DeleteMultipartResult struct {
+	Parts     []PartItem
+	DeletedAt time.Time
+}
@@ -3,11 +3,13 @@ package mysql
 import (
 	"context"
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/go-seidon/hippo/internal/repository"
 	"github.com/go-seidon/provider/typeconv"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"gorm.io/plugin/dbresolver"
 )
 
@@ -28,7 +30,7 @@ func (r *auth) CreateClient(ctx context.Context, p repository.CreateClientParam)
 	currentClient := &AuthClient{}
 	checkRes := tx.
 		Select("id, client_id").
-		First(currentClient, "client_id = ?", p.ClientId)
+		First(currentClient, "client_id = ? AND deleted_at = 0", p.ClientId)
 	if !errors.Is(checkRes.Error, gorm.ErrRecordNotFound) {
 		txRes := tx.Rollback()
 		if txRes.Error != nil {
@@ -41,14 +43,19 @@ func (r *auth) CreateClient(ctx context.Context, p repository.CreateClientParam)
 	}
 
 	createParam := &AuthClient{
-		Id:           p.Id,
-		ClientId:     p.ClientId,
-		ClientSecret: p.ClientSecret,
-		Name:         p.Name,
-		Type:         p.Type,
-		Status:       p.Status,
-		CreatedAt:    p.CreatedAt.UnixMilli(),
-		UpdatedAt:    p.CreatedAt.UnixMilli(),
+		Id:                   p.Id,
+		ClientId:             p.ClientId,
+		ClientSecret:         p.ClientSecret,
+		Name:                 p.Name,
+		Type:                 p.Type,
+		Status:               p.Status,
+		Scopes:               strings.Join(p.Scopes, " "),
+		QuotaMaxStoredSize:   p.Quota.MaxStoredSize,
+		QuotaMaxTotalFiles:   p.Quota.MaxTotalFiles,
+		QuotaMaxFileSize:     p.Quota.MaxFileSize,
+		QuotaMaxDailyUploads: p.Quota.MaxDailyUploads,
+		CreatedAt:            p.CreatedAt.UnixMilli(),
+		UpdatedAt:            p.CreatedAt.UnixMilli(),
 	}
 	createRes := tx.Create(createParam)
 	if createRes.Error != nil {
@@ -95,11 +102,14 @@ func (r *auth) FindClient(ctx context.Context, p repository.FindClientParam) (*r
 		WithContext(ctx).
 		Clauses(dbresolver.Read)
 
-	findRes := query.Select(`id, client_id, client_secret, name, type, status, created_at, updated_at`)
+	findRes := query.Select(`id, client_id, client_secret, name, type, status, scopes, ` +
+		`quota_max_stored_size, quota_max_total_files, quota_max_file_size, quota_max_daily_uploads, ` +
+		`usage_stored_size, usage_total_files, usage_daily_uploads, usage_daily_at, ` +
+		`previous_client_secret, previous_secret_expires_at, secret_rotated_at, created_at, updated_at`)
 	if p.ClientId != "" {
-		findRes = findRes.First(authClient, "client_id = ?", p.ClientId)
+		findRes = findRes.First(authClient, "client_id = ? AND deleted_at = 0", p.ClientId)
 	} else {
-		findRes = findRes.First(authClient, "id = ?", p.Id)
+		findRes = findRes.First(authClient, "id = ? AND deleted_at = 0", p.Id)
 	}
 
 	if findRes.Error != nil {
@@ -116,9 +126,19 @@ func (r *auth) FindClient(ctx context.Context, p repository.FindClientParam) (*r
 		Name:         authClient.Name,
 		Type:         authClient.Type,
 		Status:       authClient.Status,
+		Scopes:       authClient.scopes(),
+		Quota:        authClient.quota(),
+		Usage:        authClient.usage(),
 		CreatedAt:    time.UnixMilli(authClient.CreatedAt).UTC(),
 		UpdatedAt:    typeconv.Time(time.UnixMilli(authClient.UpdatedAt).UTC()),
 	}
+	if authClient.PreviousClientSecret != "" {
+		res.PreviousClientSecret = authClient.PreviousClientSecret
+		res.PreviousSecretExpiresAt = typeconv.Time(time.UnixMilli(authClient.PreviousSecretExpiresAt).UTC())
+	}
+	if authClient.SecretRotatedAt > 0 {
+		res.SecretRotatedAt = typeconv.Time(time.UnixMilli(authClient.SecretRotatedAt).UTC())
+	}
 	return res, nil
 }
 
@@ -133,7 +153,7 @@ func (r *auth) UpdateClient(ctx context.Context, p repository.UpdateClientParam)
 
 	findRes := tx.
 		Select(`id, client_id, name, type, status`).
-		First(&AuthClient{}, "id = ?", p.Id)
+		First(&AuthClient{}, "id = ? AND deleted_at = 0", p.Id)
 	if findRes.Error != nil {
 		txRes := tx.Rollback()
 		if txRes.Error != nil {
@@ -145,16 +165,27 @@ func (r *auth) UpdateClient(ctx context.Context, p repository.UpdateClientParam)
 		return nil, findRes.Error
 	}
 
+	data := map[string]interface{}{
+		"client_id":  p.ClientId,
+		"name":       p.Name,
+		"type":       p.Type,
+		"status":     p.Status,
+		"updated_at": p.UpdatedAt.UnixMilli(),
+	}
+	if p.Scopes != nil {
+		data["scopes"] = strings.Join(p.Scopes, " ")
+	}
+	if p.Quota != nil {
+		data["quota_max_stored_size"] = p.Quota.MaxStoredSize
+		data["quota_max_total_files"] = p.Quota.MaxTotalFiles
+		data["quota_max_file_size"] = p.Quota.MaxFileSize
+		data["quota_max_daily_uploads"] = p.Quota.MaxDailyUploads
+	}
+
 	updateRes := tx.
 		Model(&AuthClient{}).
 		Where("id = ?", p.Id).
-		Updates(map[string]interface{}{
-			"client_id":  p.ClientId,
-			"name":       p.Name,
-			"type":       p.Type,
-			"status":     p.Status,
-			"updated_at": p.UpdatedAt.UnixMilli(),
-		})
+		Updates(data)
 	if updateRes.Error != nil {
 		txRes := tx.Rollback()
 		if txRes.Error != nil {
@@ -196,7 +227,8 @@ func (r *auth) UpdateClient(ctx context.Context, p repository.UpdateClientParam)
 func (r *auth) SearchClient(ctx context.Context, p repository.SearchClientParam) (*repository.SearchClientResult, error) {
 	query := r.gormClient.
 		WithContext(ctx).
-		Clauses(dbresolver.Read)
+		Clauses(dbresolver.Read).
+		Where("deleted_at = 0")
 
 	if len(p.Statuses) > 0 {
 		query.Where("status IN ?", p.Statuses)
@@ -258,6 +290,238 @@ func (r *auth) SearchClient(ctx context.Context, p repository.SearchClientParam)
 	return res, nil
 }
 
+func (r *auth) UpdateClientUsage(ctx context.Context, p repository.UpdateClientUsageParam) (*repository.UpdateClientUsageResult, error) {
+	tx := r.gormClient.
+		WithContext(ctx).
+		Clauses(dbresolver.Write).
+		Begin()
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	res, err := updateClientUsage(tx, p)
+	if err != nil {
+		txRes := tx.Rollback()
+		if txRes.Error != nil {
+			return nil, txRes.Error
+		}
+		return nil, err
+	}
+
+	txRes := tx.Commit()
+	if txRes.Error != nil {
+		return nil, txRes.Error
+	}
+	return res, nil
+}
+
+func (r *auth) RotateClientSecret(ctx context.Context, p repository.RotateClientSecretParam) (*repository.RotateClientSecretResult, error) {
+	tx := r.gormClient.
+		WithContext(ctx).
+		Clauses(dbresolver.Write).
+		Begin()
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	authClient := &AuthClient{}
+	findRes := tx.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select(`id, client_id, client_secret`).
+		First(authClient, "id = ? AND deleted_at = 0", p.Id)
+	if findRes.Error != nil {
+		txRes := tx.Rollback()
+		if txRes.Error != nil {
+			return nil, txRes.Error
+		}
+		if errors.Is(findRes.Error, gorm.ErrRecordNotFound) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, findRes.Error
+	}
+
+	updateRes := tx.
+		Model(&AuthClient{}).
+		Where("id = ?", p.Id).
+		Updates(map[string]interface{}{
+			"client_secret":              p.ClientSecret,
+			"previous_client_secret":     authClient.ClientSecret,
+			"previous_secret_expires_at": p.PreviousSecretExpiresAt.UnixMilli(),
+			"secret_rotated_at":          p.RotatedAt.UnixMilli(),
+			"updated_at":                 p.RotatedAt.UnixMilli(),
+		})
+	if updateRes.Error != nil {
+		txRes := tx.Rollback()
+		if txRes.Error != nil {
+			return nil, txRes.Error
+		}
+		return nil, updateRes.Error
+	}
+
+	txRes := tx.Commit()
+	if txRes.Error != nil {
+		return nil, txRes.Error
+	}
+
+	res := &repository.RotateClientSecretResult{
+		Id:                      authClient.Id,
+		ClientId:                authClient.ClientId,
+		PreviousSecretExpiresAt: p.PreviousSecretExpiresAt,
+		SecretRotatedAt:         p.RotatedAt,
+	}
+	return res, nil
+}
+
+func (r *auth) RevokeClientSecret(ctx context.Context, p repository.RevokeClientSecretParam) (*repository.RevokeClientSecretResult, error) {
+	tx := r.gormClient.
+		WithContext(ctx).
+		Clauses(dbresolver.Write).
+		Begin()
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	authClient := &AuthClient{}
+	findRes := tx.
+		Select(`id, client_id`).
+		First(authClient, "id = ? AND deleted_at = 0", p.Id)
+	if findRes.Error != nil {
+		txRes := tx.Rollback()
+		if txRes.Error != nil {
+			return nil, txRes.Error
+		}
+		if errors.Is(findRes.Error, gorm.ErrRecordNotFound) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, findRes.Error
+	}
+
+	updateRes := tx.
+		Model(&AuthClient{}).
+		Where("id = ?", p.Id).
+		Updates(map[string]interface{}{
+			"previous_client_secret":     "",
+			"previous_secret_expires_at": 0,
+			"updated_at":                 p.RevokedAt.UnixMilli(),
+		})
+	if updateRes.Error != nil {
+		txRes := tx.Rollback()
+		if txRes.Error != nil {
+			return nil, txRes.Error
+		}
+		return nil, updateRes.Error
+	}
+
+	txRes := tx.Commit()
+	if txRes.Error != nil {
+		return nil, txRes.Error
+	}
+
+	res := &repository.RevokeClientSecretResult{
+		Id:        authClient.Id,
+		ClientId:  authClient.ClientId,
+		RevokedAt: p.RevokedAt,
+	}
+	return res, nil
+}
+
+func (r *auth) DeleteClient(ctx context.Context, p repository.DeleteClientParam) (*repository.DeleteClientResult, error) {
+	tx := r.gormClient.
+		WithContext(ctx).
+		Clauses(dbresolver.Write).
+		Begin()
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	authClient := &AuthClient{}
+	findRes := tx.
+		Select(`id, client_id`).
+		First(authClient, "id = ? AND deleted_at = 0", p.Id)
+	if findRes.Error != nil {
+		txRes := tx.Rollback()
+		if txRes.Error != nil {
+			return nil, txRes.Error
+		}
+		if errors.Is(findRes.Error, gorm.ErrRecordNotFound) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, findRes.Error
+	}
+
+	updateRes := tx.
+		Model(&AuthClient{}).
+		Where("id = ?", p.Id).
+		Updates(map[string]interface{}{
+			"updated_at": p.DeletedAt.UnixMilli(),
+			"deleted_at": p.DeletedAt.UnixMilli(),
+		})
+	if updateRes.Error != nil {
+		txRes := tx.Rollback()
+		if txRes.Error != nil {
+			return nil, txRes.Error
+		}
+		return nil, updateRes.Error
+	}
+
+	txRes := tx.Commit()
+	if txRes.Error != nil {
+		return nil, txRes.Error
+	}
+
+	res := &repository.DeleteClientResult{
+		Id:        authClient.Id,
+		ClientId:  authClient.ClientId,
+		DeletedAt: p.DeletedAt,
+	}
+	return res, nil
+}
+
+// @note: the client row is locked so the concurrent usage updates are applied in order,
+// the usage is updated in the given transaction so it's applied along with the file changes
+func updateClientUsage(tx *gorm.DB, p repository.UpdateClientUsageParam) (*repository.UpdateClientUsageResult, error) {
+	authClient := &AuthClient{}
+	findRes := tx.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select(`id, quota_max_stored_size, quota_max_total_files, quota_max_file_size, quota_max_daily_uploads, `+
+			`usage_stored_size, usage_total_files, usage_daily_uploads, usage_daily_at`).
+		First(authClient, "id = ? AND deleted_at = 0", p.Id)
+	if findRes.Error != nil {
+		if errors.Is(findRes.Error, gorm.ErrRecordNotFound) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, findRes.Error
+	}
+
+	quota := authClient.quota()
+	usage := authClient.usage().Add(p)
+	if p.CheckQuota {
+		err := quota.Check(usage)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	updateRes := tx.
+		Model(&AuthClient{}).
+		Where("id = ?", authClient.Id).
+		UpdateColumns(map[string]interface{}{
+			"usage_stored_size":   usage.StoredSize,
+			"usage_total_files":   usage.TotalFiles,
+			"usage_daily_uploads": usage.DailyUploads,
+			"usage_daily_at":      usage.DailyAt.UnixMilli(),
+		})
+	if updateRes.Error != nil {
+		return nil, updateRes.Error
+	}
+
+	res := &repository.UpdateClientUsageResult{
+		Quota: quota,
+		Usage: usage,
+	}
+	return res, nil
+}
+
 type AuthParam struct {
 	GormClient *gorm.DB
 }
@@ -269,14 +533,54 @@ func NewAuth(p AuthParam) *auth {
 }
 
 type AuthClient struct {
-	Id           string `gorm:"column:id;primaryKey"`
-	ClientId     string `gorm:"column:client_id"`
-	ClientSecret string `gorm:"column:client_secret"`
-	Name         string `gorm:"column:name"`
-	Type         string `gorm:"column:type"`
-	Status       string `gorm:"column:status"`
-	CreatedAt    int64  `gorm:"column:created_at"`
-	UpdatedAt    int64  `gorm:"column:updated_at;autoUpdateTime:milli"`
+	Id                      string `gorm:"column:id;primaryKey"`
+	ClientId                string `gorm:"column:client_id"`
+	ClientSecret            string `gorm:"column:client_secret"`
+	Name                    string `gorm:"column:name"`
+	Type                    string `gorm:"column:type"`
+	Status                  string `gorm:"column:status"`
+	Scopes                  string `gorm:"column:scopes"`
+	QuotaMaxStoredSize      int64  `gorm:"column:quota_max_stored_size"`
+	QuotaMaxTotalFiles      int64  `gorm:"column:quota_max_total_files"`
+	QuotaMaxFileSize        int64  `gorm:"column:quota_max_file_size"`
+	QuotaMaxDailyUploads    int64  `gorm:"column:quota_max_daily_uploads"`
+	UsageStoredSize         int64  `gorm:"column:usage_stored_size;<-:update"`
+	UsageTotalFiles         int64  `gorm:"column:usage_total_files;<-:update"`
+	UsageDailyUploads       int64  `gorm:"column:usage_daily_uploads;<-:update"`
+	UsageDailyAt            int64  `gorm:"column:usage_daily_at;<-:update"`
+	PreviousClientSecret    string `gorm:"column:previous_client_secret;<-:update"`
+	PreviousSecretExpiresAt int64  `gorm:"column:previous_secret_expires_at;<-:update"`
+	SecretRotatedAt         int64  `gorm:"column:secret_rotated_at;<-:update"`
+	CreatedAt               int64  `gorm:"column:created_at"`
+	UpdatedAt               int64  `gorm:"column:updated_at;autoUpdateTime:milli"`
+	DeletedAt               int64  `gorm:"column:deleted_at;<-:update"`
+}
+
+// @note: scopes are stored as a space delimited list
+func (c *AuthClient) scopes() []string {
+	return strings.Fields(c.Scopes)
+}
+
+func (c *AuthClient) quota() repository.ClientQuota {
+	return repository.ClientQuota{
+		MaxStoredSize:   c.QuotaMaxStoredSize,
+		MaxTotalFiles:   c.QuotaMaxTotalFiles,
+		MaxFileSize:     c.QuotaMaxFileSize,
+		MaxDailyUploads: c.QuotaMaxDailyUploads,
+	}
+}
+
+// @note: daily at is not set until the client is used for the first time
+func (c *AuthClient) usage() repository.ClientUsage {
+	usage := repository.ClientUsage{
+		StoredSize:   c.UsageStoredSize,
+		TotalFiles:   c.UsageTotalFiles,
+		DailyUploads: c.UsageDailyUploads,
+	}
+	if c.UsageDailyAt > 0 {
+		usage.DailyAt = time.UnixMilli(c.UsageDailyAt).UTC()
+	}
+	return usage
 }
 
 func (AuthClient) TableName() string {
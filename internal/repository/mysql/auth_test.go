@@ -3,6 +3,7 @@ package mysql_test
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"regexp"
 	"strings"
@@ -66,10 +67,17 @@ var _ = Describe("Auth Repository", func() {
 				Name:         "name",
 				Type:         "basic",
 				Status:       "active",
-				CreatedAt:    currentTs,
+				Scopes:       []string{"file:read", "file:write"},
+				Quota: repository.ClientQuota{
+					MaxStoredSize:   1024,
+					MaxTotalFiles:   10,
+					MaxFileSize:     512,
+					MaxDailyUploads: 5,
+				},
+				CreatedAt: currentTs,
 			}
-			checkStmt = regexp.QuoteMeta("SELECT id, client_id FROM `auth_client` WHERE client_id = ? ORDER BY `auth_client`.`id` LIMIT 1")
-			insertStmt = regexp.QuoteMeta("INSERT INTO `auth_client` (`id`,`client_id`,`client_secret`,`name`,`type`,`status`,`created_at`,`updated_at`) VALUES (?,?,?,?,?,?,?,?)")
+			checkStmt = regexp.QuoteMeta("SELECT id, client_id FROM `auth_client` WHERE client_id = ? AND deleted_at = 0 ORDER BY `auth_client`.`id` LIMIT 1")
+			insertStmt = regexp.QuoteMeta("INSERT INTO `auth_client` (`id`,`client_id`,`client_secret`,`name`,`type`,`status`,`scopes`,`quota_max_stored_size`,`quota_max_total_files`,`quota_max_file_size`,`quota_max_daily_uploads`,`created_at`,`updated_at`) VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?)")
 			findStmt = regexp.QuoteMeta("SELECT id, client_id, client_secret, name, type, status, created_at FROM `auth_client` WHERE id = ? ORDER BY `auth_client`.`id` LIMIT 1")
 		})
 
@@ -175,6 +183,9 @@ var _ = Describe("Auth Repository", func() {
 					WithArgs(
 						p.Id, p.ClientId, p.ClientSecret,
 						p.Name, p.Type, p.Status,
+						"file:read file:write",
+						p.Quota.MaxStoredSize, p.Quota.MaxTotalFiles,
+						p.Quota.MaxFileSize, p.Quota.MaxDailyUploads,
 						p.CreatedAt.UnixMilli(),
 						p.CreatedAt.UnixMilli(),
 					).
@@ -206,6 +217,9 @@ var _ = Describe("Auth Repository", func() {
 					WithArgs(
 						p.Id, p.ClientId, p.ClientSecret,
 						p.Name, p.Type, p.Status,
+						"file:read file:write",
+						p.Quota.MaxStoredSize, p.Quota.MaxTotalFiles,
+						p.Quota.MaxFileSize, p.Quota.MaxDailyUploads,
 						p.CreatedAt.UnixMilli(),
 						p.CreatedAt.UnixMilli(),
 					).
@@ -236,6 +250,9 @@ var _ = Describe("Auth Repository", func() {
 					WithArgs(
 						p.Id, p.ClientId, p.ClientSecret,
 						p.Name, p.Type, p.Status,
+						"file:read file:write",
+						p.Quota.MaxStoredSize, p.Quota.MaxTotalFiles,
+						p.Quota.MaxFileSize, p.Quota.MaxDailyUploads,
 						p.CreatedAt.UnixMilli(),
 						p.CreatedAt.UnixMilli(),
 					).
@@ -272,6 +289,9 @@ var _ = Describe("Auth Repository", func() {
 					WithArgs(
 						p.Id, p.ClientId, p.ClientSecret,
 						p.Name, p.Type, p.Status,
+						"file:read file:write",
+						p.Quota.MaxStoredSize, p.Quota.MaxTotalFiles,
+						p.Quota.MaxFileSize, p.Quota.MaxDailyUploads,
 						p.CreatedAt.UnixMilli(),
 						p.CreatedAt.UnixMilli(),
 					).
@@ -307,6 +327,9 @@ var _ = Describe("Auth Repository", func() {
 					WithArgs(
 						p.Id, p.ClientId, p.ClientSecret,
 						p.Name, p.Type, p.Status,
+						"file:read file:write",
+						p.Quota.MaxStoredSize, p.Quota.MaxTotalFiles,
+						p.Quota.MaxFileSize, p.Quota.MaxDailyUploads,
 						p.CreatedAt.UnixMilli(),
 						p.CreatedAt.UnixMilli(),
 					).
@@ -350,6 +373,9 @@ var _ = Describe("Auth Repository", func() {
 					WithArgs(
 						p.Id, p.ClientId, p.ClientSecret,
 						p.Name, p.Type, p.Status,
+						"file:read file:write",
+						p.Quota.MaxStoredSize, p.Quota.MaxTotalFiles,
+						p.Quota.MaxFileSize, p.Quota.MaxDailyUploads,
 						p.CreatedAt.UnixMilli(),
 						p.CreatedAt.UnixMilli(),
 					).
@@ -392,6 +418,7 @@ var _ = Describe("Auth Repository", func() {
 		var (
 			ctx       context.Context
 			currentTs time.Time
+			dailyAt   time.Time
 			dbClient  sqlmock.Sqlmock
 			authRepo  repository.Auth
 			p         repository.FindClientParam
@@ -408,6 +435,7 @@ var _ = Describe("Auth Repository", func() {
 
 			ctx = context.Background()
 			currentTs = time.Now()
+			dailyAt = currentTs.UTC().Truncate(24 * time.Hour)
 			db, dbClient, err = sqlmock.New()
 			if err != nil {
 				AbortSuite("failed create db mock: " + err.Error())
@@ -436,17 +464,41 @@ var _ = Describe("Auth Repository", func() {
 				Name:         "name",
 				Type:         "basic",
 				Status:       "active",
-				CreatedAt:    time.UnixMilli(currentTs.UnixMilli()).UTC(),
-				UpdatedAt:    typeconv.Time(time.UnixMilli(currentTs.UnixMilli()).UTC()),
+				Scopes:       []string{"file:read", "file:write"},
+				Quota: repository.ClientQuota{
+					MaxStoredSize:   1024,
+					MaxTotalFiles:   10,
+					MaxFileSize:     512,
+					MaxDailyUploads: 5,
+				},
+				Usage: repository.ClientUsage{
+					StoredSize:   256,
+					TotalFiles:   2,
+					DailyUploads: 1,
+					DailyAt:      time.UnixMilli(dailyAt.UnixMilli()).UTC(),
+				},
+				PreviousClientSecret:    "previous-secret",
+				PreviousSecretExpiresAt: typeconv.Time(time.UnixMilli(currentTs.Add(time.Hour).UnixMilli()).UTC()),
+				SecretRotatedAt:         typeconv.Time(time.UnixMilli(currentTs.UnixMilli()).UTC()),
+				CreatedAt:               time.UnixMilli(currentTs.UnixMilli()).UTC(),
+				UpdatedAt:               typeconv.Time(time.UnixMilli(currentTs.UnixMilli()).UTC()),
 			}
-			findStmt = regexp.QuoteMeta("SELECT id, client_id, client_secret, name, type, status, created_at, updated_at FROM `auth_client` WHERE id = ? ORDER BY `auth_client`.`id` LIMIT 1")
+			findStmt = regexp.QuoteMeta("SELECT id, client_id, client_secret, name, type, status, scopes, quota_max_stored_size, quota_max_total_files, quota_max_file_size, quota_max_daily_uploads, usage_stored_size, usage_total_files, usage_daily_uploads, usage_daily_at, previous_client_secret, previous_secret_expires_at, secret_rotated_at, created_at, updated_at FROM `auth_client` WHERE id = ? AND deleted_at = 0 ORDER BY `auth_client`.`id` LIMIT 1")
 			findRows = sqlmock.NewRows([]string{
 				"id", "client_id", "client_secret",
-				"name", "type", "status",
+				"name", "type", "status", "scopes",
+				"quota_max_stored_size", "quota_max_total_files",
+				"quota_max_file_size", "quota_max_daily_uploads",
+				"usage_stored_size", "usage_total_files",
+				"usage_daily_uploads", "usage_daily_at",
+				"previous_client_secret", "previous_secret_expires_at", "secret_rotated_at",
 				"created_at", "updated_at",
 			}).AddRow(
 				r.Id, r.ClientId, r.ClientSecret,
-				r.Name, r.Type, r.Status,
+				r.Name, r.Type, r.Status, "file:read file:write",
+				1024, 10, 512, 5,
+				256, 2, 1, dailyAt.UnixMilli(),
+				"previous-secret", currentTs.Add(time.Hour).UnixMilli(), currentTs.UnixMilli(),
 				currentTs.UnixMilli(), currentTs.UnixMilli(),
 			)
 		})
@@ -505,7 +557,7 @@ var _ = Describe("Auth Repository", func() {
 				p := repository.FindClientParam{
 					ClientId: "client-id",
 				}
-				findStmt := regexp.QuoteMeta("SELECT id, client_id, client_secret, name, type, status, created_at, updated_at FROM `auth_client` WHERE client_id = ? ORDER BY `auth_client`.`id` LIMIT 1")
+				findStmt := regexp.QuoteMeta("SELECT id, client_id, client_secret, name, type, status, scopes, quota_max_stored_size, quota_max_total_files, quota_max_file_size, quota_max_daily_uploads, usage_stored_size, usage_total_files, usage_daily_uploads, usage_daily_at, previous_client_secret, previous_secret_expires_at, secret_rotated_at, created_at, updated_at FROM `auth_client` WHERE client_id = ? AND deleted_at = 0 ORDER BY `auth_client`.`id` LIMIT 1")
 				dbClient.
 					ExpectQuery(findStmt).
 					WithArgs(p.ClientId).
@@ -579,7 +631,7 @@ var _ = Describe("Auth Repository", func() {
 				CreatedAt:    time.UnixMilli(currentTs.UnixMilli()).UTC(),
 				UpdatedAt:    time.UnixMilli(currentTs.UnixMilli()).UTC(),
 			}
-			findStmt = regexp.QuoteMeta("SELECT id, client_id, name, type, status FROM `auth_client` WHERE id = ? ORDER BY `auth_client`.`id` LIMIT 1")
+			findStmt = regexp.QuoteMeta("SELECT id, client_id, name, type, status FROM `auth_client` WHERE id = ? AND deleted_at = 0 ORDER BY `auth_client`.`id` LIMIT 1")
 			updateStmt = regexp.QuoteMeta("UPDATE `auth_client` SET `client_id`=?,`name`=?,`status`=?,`type`=?,`updated_at`=? WHERE id = ?")
 			checkStmt = regexp.QuoteMeta("SELECT id, client_id, client_secret, name, type, status, created_at, updated_at FROM `auth_client` WHERE id = ? ORDER BY `auth_client`.`id` LIMIT 1")
 			findRows = sqlmock.NewRows([]string{
@@ -895,6 +947,96 @@ var _ = Describe("Auth Repository", func() {
 				Expect(err).To(BeNil())
 			})
 		})
+
+		When("quota is specified", func() {
+			It("should update the quota", func() {
+				p.Quota = &repository.ClientQuota{
+					MaxStoredSize:   1024,
+					MaxTotalFiles:   10,
+					MaxFileSize:     512,
+					MaxDailyUploads: 5,
+				}
+				updateStmt := regexp.QuoteMeta("UPDATE `auth_client` SET `client_id`=?,`name`=?,`quota_max_daily_uploads`=?,`quota_max_file_size`=?,`quota_max_stored_size`=?,`quota_max_total_files`=?,`status`=?,`type`=?,`updated_at`=? WHERE id = ?")
+
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.Id).
+					WillReturnRows(findRows)
+
+				dbClient.
+					ExpectExec(updateStmt).
+					WithArgs(
+						p.ClientId,
+						p.Name,
+						p.Quota.MaxDailyUploads,
+						p.Quota.MaxFileSize,
+						p.Quota.MaxStoredSize,
+						p.Quota.MaxTotalFiles,
+						p.Status,
+						p.Type,
+						p.UpdatedAt.UnixMilli(),
+						p.Id,
+					).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				dbClient.
+					ExpectQuery(checkStmt).
+					WithArgs(p.Id).
+					WillReturnRows(checkRows)
+
+				dbClient.
+					ExpectCommit()
+
+				res, err := authRepo.UpdateClient(ctx, p)
+
+				Expect(res).To(Equal(r))
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("scopes is specified", func() {
+			It("should update the scopes", func() {
+				p.Scopes = []string{"file:read", "client:admin"}
+				updateStmt := regexp.QuoteMeta("UPDATE `auth_client` SET `client_id`=?,`name`=?,`scopes`=?,`status`=?,`type`=?,`updated_at`=? WHERE id = ?")
+
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.Id).
+					WillReturnRows(findRows)
+
+				dbClient.
+					ExpectExec(updateStmt).
+					WithArgs(
+						p.ClientId,
+						p.Name,
+						"file:read client:admin",
+						p.Status,
+						p.Type,
+						p.UpdatedAt.UnixMilli(),
+						p.Id,
+					).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				dbClient.
+					ExpectQuery(checkStmt).
+					WithArgs(p.Id).
+					WillReturnRows(checkRows)
+
+				dbClient.
+					ExpectCommit()
+
+				res, err := authRepo.UpdateClient(ctx, p)
+
+				Expect(res).To(Equal(r))
+				Expect(err).To(BeNil())
+			})
+		})
 	})
 
 	Context("SearchClient function", Label("unit"), func() {
@@ -974,7 +1116,8 @@ var _ = Describe("Auth Repository", func() {
 			searchStmt = regexp.QuoteMeta(strings.TrimSpace(`
 				SELECT id, client_id, client_secret, name, type, status, created_at, updated_at
 				FROM ` + "`auth_client`" + `
-				WHERE status IN (?)
+				WHERE deleted_at = 0
+				AND status IN (?)
 				AND (name LIKE ? OR client_id LIKE ?)
 				LIMIT 24
 				OFFSET 48
@@ -982,7 +1125,8 @@ var _ = Describe("Auth Repository", func() {
 			countStmt = regexp.QuoteMeta(strings.TrimSpace(`
 				SELECT count(*)
 				FROM ` + "`auth_client`" + ` 
-				WHERE status IN (?)
+				WHERE deleted_at = 0
+				AND status IN (?)
 				AND (name LIKE ? OR client_id LIKE ?)
 			`))
 			searchRows = sqlmock.NewRows([]string{
@@ -1175,4 +1319,774 @@ var _ = Describe("Auth Repository", func() {
 		})
 	})
 
+	Context("UpdateClientUsage function", Label("unit"), func() {
+		var (
+			ctx        context.Context
+			currentTs  time.Time
+			dailyAt    time.Time
+			dbClient   sqlmock.Sqlmock
+			authRepo   repository.Auth
+			p          repository.UpdateClientUsageParam
+			findStmt   string
+			updateStmt string
+			findRows   *sqlmock.Rows
+		)
+
+		BeforeEach(func() {
+			var (
+				db  *sql.DB
+				err error
+			)
+
+			ctx = context.Background()
+			currentTs = time.Now()
+			dailyAt = currentTs.UTC().Truncate(24 * time.Hour)
+			db, dbClient, err = sqlmock.New()
+			if err != nil {
+				AbortSuite("failed create db mock: " + err.Error())
+			}
+
+			gormClient, err := gorm.Open(gorm_mysql.New(gorm_mysql.Config{
+				Conn:                      db,
+				SkipInitializeWithVersion: true,
+			}), &gorm.Config{
+				DisableAutomaticPing: true,
+			})
+			if err != nil {
+				AbortSuite("failed create gorm client: " + err.Error())
+			}
+			authRepo = repository_mysql.NewAuth(repository_mysql.AuthParam{
+				GormClient: gormClient,
+			})
+
+			p = repository.UpdateClientUsageParam{
+				Id:           "id",
+				StoredSize:   100,
+				TotalFiles:   1,
+				DailyUploads: 1,
+				UsedAt:       currentTs,
+				CheckQuota:   true,
+			}
+			findStmt = regexp.QuoteMeta("SELECT id, quota_max_stored_size, quota_max_total_files, quota_max_file_size, quota_max_daily_uploads, usage_stored_size, usage_total_files, usage_daily_uploads, usage_daily_at FROM `auth_client` WHERE id = ? AND deleted_at = 0 ORDER BY `auth_client`.`id` LIMIT 1 FOR UPDATE")
+			updateStmt = regexp.QuoteMeta("UPDATE `auth_client` SET `usage_daily_at`=?,`usage_daily_uploads`=?,`usage_stored_size`=?,`usage_total_files`=? WHERE id = ?")
+			findRows = sqlmock.NewRows([]string{
+				"id",
+				"quota_max_stored_size", "quota_max_total_files",
+				"quota_max_file_size", "quota_max_daily_uploads",
+				"usage_stored_size", "usage_total_files",
+				"usage_daily_uploads", "usage_daily_at",
+			}).AddRow(
+				"id",
+				1024, 10, 512, 5,
+				256, 2, 1, dailyAt.UnixMilli(),
+			)
+		})
+
+		AfterEach(func() {
+			err := dbClient.ExpectationsWereMet()
+			if err != nil {
+				AbortSuite("some expectations were not met " + err.Error())
+			}
+		})
+
+		When("failed begin trx", func() {
+			It("should return error", func() {
+				dbClient.
+					ExpectBegin().
+					WillReturnError(fmt.Errorf("begin error"))
+
+				res, err := authRepo.UpdateClientUsage(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("begin error")))
+			})
+		})
+
+		When("failed find client", func() {
+			It("should return error", func() {
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.Id).
+					WillReturnError(fmt.Errorf("network error"))
+
+				dbClient.
+					ExpectRollback()
+
+				res, err := authRepo.UpdateClientUsage(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("network error")))
+			})
+		})
+
+		When("client is not available", func() {
+			It("should return error", func() {
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.Id).
+					WillReturnError(gorm.ErrRecordNotFound)
+
+				dbClient.
+					ExpectRollback()
+
+				res, err := authRepo.UpdateClientUsage(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(repository.ErrNotFound))
+			})
+		})
+
+		When("quota is exceeded", func() {
+			It("should return error", func() {
+				p.StoredSize = 1000
+
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.Id).
+					WillReturnRows(findRows)
+
+				dbClient.
+					ExpectRollback()
+
+				res, err := authRepo.UpdateClientUsage(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(errors.Is(err, repository.ErrExceeded)).To(BeTrue())
+				Expect(err.Error()).To(Equal("quota exceeded: max stored size"))
+			})
+		})
+
+		When("failed update usage", func() {
+			It("should return error", func() {
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.Id).
+					WillReturnRows(findRows)
+
+				dbClient.
+					ExpectExec(updateStmt).
+					WithArgs(dailyAt.UnixMilli(), int64(2), int64(356), int64(3), "id").
+					WillReturnError(fmt.Errorf("network error"))
+
+				dbClient.
+					ExpectRollback()
+
+				res, err := authRepo.UpdateClientUsage(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("network error")))
+			})
+		})
+
+		When("failed commit trx", func() {
+			It("should return error", func() {
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.Id).
+					WillReturnRows(findRows)
+
+				dbClient.
+					ExpectExec(updateStmt).
+					WithArgs(dailyAt.UnixMilli(), int64(2), int64(356), int64(3), "id").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				dbClient.
+					ExpectCommit().
+					WillReturnError(fmt.Errorf("commit error"))
+
+				res, err := authRepo.UpdateClientUsage(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("commit error")))
+			})
+		})
+
+		When("usage is on the other day", func() {
+			It("should restart the daily uploads", func() {
+				p.UsedAt = currentTs.Add(24 * time.Hour)
+				nextDay := dailyAt.Add(24 * time.Hour)
+
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.Id).
+					WillReturnRows(findRows)
+
+				dbClient.
+					ExpectExec(updateStmt).
+					WithArgs(nextDay.UnixMilli(), int64(1), int64(356), int64(3), "id").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				dbClient.
+					ExpectCommit()
+
+				res, err := authRepo.UpdateClientUsage(ctx, p)
+
+				Expect(res.Usage).To(Equal(repository.ClientUsage{
+					StoredSize:   356,
+					TotalFiles:   3,
+					DailyUploads: 1,
+					DailyAt:      nextDay,
+				}))
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("success update usage", func() {
+			It("should return result", func() {
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.Id).
+					WillReturnRows(findRows)
+
+				dbClient.
+					ExpectExec(updateStmt).
+					WithArgs(dailyAt.UnixMilli(), int64(2), int64(356), int64(3), "id").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				dbClient.
+					ExpectCommit()
+
+				res, err := authRepo.UpdateClientUsage(ctx, p)
+
+				Expect(res).To(Equal(&repository.UpdateClientUsageResult{
+					Quota: repository.ClientQuota{
+						MaxStoredSize:   1024,
+						MaxTotalFiles:   10,
+						MaxFileSize:     512,
+						MaxDailyUploads: 5,
+					},
+					Usage: repository.ClientUsage{
+						StoredSize:   356,
+						TotalFiles:   3,
+						DailyUploads: 2,
+						DailyAt:      dailyAt,
+					},
+				}))
+				Expect(err).To(BeNil())
+			})
+		})
+	})
+
+	Context("RotateClientSecret function", Label("unit"), func() {
+		var (
+			ctx        context.Context
+			currentTs  time.Time
+			dbClient   sqlmock.Sqlmock
+			authRepo   repository.Auth
+			p          repository.RotateClientSecretParam
+			findStmt   string
+			updateStmt string
+			findRows   *sqlmock.Rows
+		)
+
+		BeforeEach(func() {
+			var (
+				db  *sql.DB
+				err error
+			)
+
+			ctx = context.Background()
+			currentTs = time.Now()
+			db, dbClient, err = sqlmock.New()
+			if err != nil {
+				AbortSuite("failed create db mock: " + err.Error())
+			}
+
+			gormClient, err := gorm.Open(gorm_mysql.New(gorm_mysql.Config{
+				Conn:                      db,
+				SkipInitializeWithVersion: true,
+			}), &gorm.Config{
+				DisableAutomaticPing: true,
+			})
+			if err != nil {
+				AbortSuite("failed create gorm client: " + err.Error())
+			}
+			authRepo = repository_mysql.NewAuth(repository_mysql.AuthParam{
+				GormClient: gormClient,
+			})
+
+			p = repository.RotateClientSecretParam{
+				Id:                      "id",
+				ClientSecret:            "new-secret",
+				PreviousSecretExpiresAt: currentTs.Add(time.Hour),
+				RotatedAt:               currentTs,
+			}
+			findStmt = regexp.QuoteMeta("SELECT id, client_id, client_secret FROM `auth_client` WHERE id = ? AND deleted_at = 0 ORDER BY `auth_client`.`id` LIMIT 1 FOR UPDATE")
+			updateStmt = regexp.QuoteMeta("UPDATE `auth_client` SET `client_secret`=?,`previous_client_secret`=?,`previous_secret_expires_at`=?,`secret_rotated_at`=?,`updated_at`=? WHERE id = ?")
+			findRows = sqlmock.NewRows([]string{
+				"id", "client_id", "client_secret",
+			}).AddRow(
+				"id", "client-id", "old-secret",
+			)
+		})
+
+		AfterEach(func() {
+			err := dbClient.ExpectationsWereMet()
+			if err != nil {
+				AbortSuite("some expectations were not met " + err.Error())
+			}
+		})
+
+		When("failed begin trx", func() {
+			It("should return error", func() {
+				dbClient.
+					ExpectBegin().
+					WillReturnError(fmt.Errorf("begin error"))
+
+				res, err := authRepo.RotateClientSecret(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("begin error")))
+			})
+		})
+
+		When("client is not available", func() {
+			It("should return error", func() {
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.Id).
+					WillReturnError(gorm.ErrRecordNotFound)
+
+				dbClient.
+					ExpectRollback()
+
+				res, err := authRepo.RotateClientSecret(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(repository.ErrNotFound))
+			})
+		})
+
+		When("failed find client", func() {
+			It("should return error", func() {
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.Id).
+					WillReturnError(fmt.Errorf("network error"))
+
+				dbClient.
+					ExpectRollback()
+
+				res, err := authRepo.RotateClientSecret(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("network error")))
+			})
+		})
+
+		When("failed update secret", func() {
+			It("should return error", func() {
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.Id).
+					WillReturnRows(findRows)
+
+				dbClient.
+					ExpectExec(updateStmt).
+					WithArgs(
+						"new-secret", "old-secret",
+						p.PreviousSecretExpiresAt.UnixMilli(),
+						p.RotatedAt.UnixMilli(), p.RotatedAt.UnixMilli(),
+						p.Id,
+					).
+					WillReturnError(fmt.Errorf("network error"))
+
+				dbClient.
+					ExpectRollback()
+
+				res, err := authRepo.RotateClientSecret(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("network error")))
+			})
+		})
+
+		When("failed commit trx", func() {
+			It("should return error", func() {
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.Id).
+					WillReturnRows(findRows)
+
+				dbClient.
+					ExpectExec(updateStmt).
+					WithArgs(
+						"new-secret", "old-secret",
+						p.PreviousSecretExpiresAt.UnixMilli(),
+						p.RotatedAt.UnixMilli(), p.RotatedAt.UnixMilli(),
+						p.Id,
+					).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				dbClient.
+					ExpectCommit().
+					WillReturnError(fmt.Errorf("commit error"))
+
+				res, err := authRepo.RotateClientSecret(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("commit error")))
+			})
+		})
+
+		When("success rotate secret", func() {
+			It("should return result", func() {
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.Id).
+					WillReturnRows(findRows)
+
+				dbClient.
+					ExpectExec(updateStmt).
+					WithArgs(
+						"new-secret", "old-secret",
+						p.PreviousSecretExpiresAt.UnixMilli(),
+						p.RotatedAt.UnixMilli(), p.RotatedAt.UnixMilli(),
+						p.Id,
+					).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				dbClient.
+					ExpectCommit()
+
+				res, err := authRepo.RotateClientSecret(ctx, p)
+
+				Expect(res).To(Equal(&repository.RotateClientSecretResult{
+					Id:                      "id",
+					ClientId:                "client-id",
+					PreviousSecretExpiresAt: p.PreviousSecretExpiresAt,
+					SecretRotatedAt:         p.RotatedAt,
+				}))
+				Expect(err).To(BeNil())
+			})
+		})
+	})
+
+	Context("RevokeClientSecret function", Label("unit"), func() {
+		var (
+			ctx        context.Context
+			currentTs  time.Time
+			dbClient   sqlmock.Sqlmock
+			authRepo   repository.Auth
+			p          repository.RevokeClientSecretParam
+			findStmt   string
+			updateStmt string
+			findRows   *sqlmock.Rows
+		)
+
+		BeforeEach(func() {
+			var (
+				db  *sql.DB
+				err error
+			)
+
+			ctx = context.Background()
+			currentTs = time.Now()
+			db, dbClient, err = sqlmock.New()
+			if err != nil {
+				AbortSuite("failed create db mock: " + err.Error())
+			}
+
+			gormClient, err := gorm.Open(gorm_mysql.New(gorm_mysql.Config{
+				Conn:                      db,
+				SkipInitializeWithVersion: true,
+			}), &gorm.Config{
+				DisableAutomaticPing: true,
+			})
+			if err != nil {
+				AbortSuite("failed create gorm client: " + err.Error())
+			}
+			authRepo = repository_mysql.NewAuth(repository_mysql.AuthParam{
+				GormClient: gormClient,
+			})
+
+			p = repository.RevokeClientSecretParam{
+				Id:        "id",
+				RevokedAt: currentTs,
+			}
+			findStmt = regexp.QuoteMeta("SELECT id, client_id FROM `auth_client` WHERE id = ? AND deleted_at = 0 ORDER BY `auth_client`.`id` LIMIT 1")
+			updateStmt = regexp.QuoteMeta("UPDATE `auth_client` SET `previous_client_secret`=?,`previous_secret_expires_at`=?,`updated_at`=? WHERE id = ?")
+			findRows = sqlmock.NewRows([]string{
+				"id", "client_id",
+			}).AddRow(
+				"id", "client-id",
+			)
+		})
+
+		AfterEach(func() {
+			err := dbClient.ExpectationsWereMet()
+			if err != nil {
+				AbortSuite("some expectations were not met " + err.Error())
+			}
+		})
+
+		When("failed begin trx", func() {
+			It("should return error", func() {
+				dbClient.
+					ExpectBegin().
+					WillReturnError(fmt.Errorf("begin error"))
+
+				res, err := authRepo.RevokeClientSecret(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("begin error")))
+			})
+		})
+
+		When("client is not available", func() {
+			It("should return error", func() {
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.Id).
+					WillReturnError(gorm.ErrRecordNotFound)
+
+				dbClient.
+					ExpectRollback()
+
+				res, err := authRepo.RevokeClientSecret(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(repository.ErrNotFound))
+			})
+		})
+
+		When("failed update secret", func() {
+			It("should return error", func() {
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.Id).
+					WillReturnRows(findRows)
+
+				dbClient.
+					ExpectExec(updateStmt).
+					WithArgs("", 0, p.RevokedAt.UnixMilli(), p.Id).
+					WillReturnError(fmt.Errorf("network error"))
+
+				dbClient.
+					ExpectRollback()
+
+				res, err := authRepo.RevokeClientSecret(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("network error")))
+			})
+		})
+
+		When("success revoke secret", func() {
+			It("should return result", func() {
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.Id).
+					WillReturnRows(findRows)
+
+				dbClient.
+					ExpectExec(updateStmt).
+					WithArgs("", 0, p.RevokedAt.UnixMilli(), p.Id).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				dbClient.
+					ExpectCommit()
+
+				res, err := authRepo.RevokeClientSecret(ctx, p)
+
+				Expect(res).To(Equal(&repository.RevokeClientSecretResult{
+					Id:        "id",
+					ClientId:  "client-id",
+					RevokedAt: p.RevokedAt,
+				}))
+				Expect(err).To(BeNil())
+			})
+		})
+	})
+
+	Context("DeleteClient function", Label("unit"), func() {
+		var (
+			ctx        context.Context
+			currentTs  time.Time
+			dbClient   sqlmock.Sqlmock
+			authRepo   repository.Auth
+			p          repository.DeleteClientParam
+			findStmt   string
+			updateStmt string
+			findRows   *sqlmock.Rows
+		)
+
+		BeforeEach(func() {
+			var (
+				db  *sql.DB
+				err error
+			)
+
+			ctx = context.Background()
+			currentTs = time.Now()
+			db, dbClient, err = sqlmock.New()
+			if err != nil {
+				AbortSuite("failed create db mock: " + err.Error())
+			}
+
+			gormClient, err := gorm.Open(gorm_mysql.New(gorm_mysql.Config{
+				Conn:                      db,
+				SkipInitializeWithVersion: true,
+			}), &gorm.Config{
+				DisableAutomaticPing: true,
+			})
+			if err != nil {
+				AbortSuite("failed create gorm client: " + err.Error())
+			}
+			authRepo = repository_mysql.NewAuth(repository_mysql.AuthParam{
+				GormClient: gormClient,
+			})
+
+			p = repository.DeleteClientParam{
+				Id:        "id",
+				DeletedAt: currentTs,
+			}
+			findStmt = regexp.QuoteMeta("SELECT id, client_id FROM `auth_client` WHERE id = ? AND deleted_at = 0 ORDER BY `auth_client`.`id` LIMIT 1")
+			updateStmt = regexp.QuoteMeta("UPDATE `auth_client` SET `deleted_at`=?,`updated_at`=? WHERE id = ?")
+			findRows = sqlmock.NewRows([]string{
+				"id", "client_id",
+			}).AddRow(
+				"id", "client-id",
+			)
+		})
+
+		AfterEach(func() {
+			err := dbClient.ExpectationsWereMet()
+			if err != nil {
+				AbortSuite("some expectations were not met " + err.Error())
+			}
+		})
+
+		When("failed begin trx", func() {
+			It("should return error", func() {
+				dbClient.
+					ExpectBegin().
+					WillReturnError(fmt.Errorf("begin error"))
+
+				res, err := authRepo.DeleteClient(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("begin error")))
+			})
+		})
+
+		When("client is not available", func() {
+			It("should return error", func() {
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.Id).
+					WillReturnError(gorm.ErrRecordNotFound)
+
+				dbClient.
+					ExpectRollback()
+
+				res, err := authRepo.DeleteClient(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(repository.ErrNotFound))
+			})
+		})
+
+		When("failed delete client", func() {
+			It("should return error", func() {
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.Id).
+					WillReturnRows(findRows)
+
+				dbClient.
+					ExpectExec(updateStmt).
+					WithArgs(p.DeletedAt.UnixMilli(), p.DeletedAt.UnixMilli(), p.Id).
+					WillReturnError(fmt.Errorf("network error"))
+
+				dbClient.
+					ExpectRollback()
+
+				res, err := authRepo.DeleteClient(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("network error")))
+			})
+		})
+
+		When("success delete client", func() {
+			It("should return result", func() {
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.Id).
+					WillReturnRows(findRows)
+
+				dbClient.
+					ExpectExec(updateStmt).
+					WithArgs(p.DeletedAt.UnixMilli(), p.DeletedAt.UnixMilli(), p.Id).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				dbClient.
+					ExpectCommit()
+
+				res, err := authRepo.DeleteClient(ctx, p)
+
+				Expect(res).To(Equal(&repository.DeleteClientResult{
+					Id:        "id",
+					ClientId:  "client-id",
+					DeletedAt: p.DeletedAt,
+				}))
+				Expect(err).To(BeNil())
+			})
+		})
+	})
 })
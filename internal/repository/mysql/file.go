@@ -9,6 +9,7 @@ import (
 	"github.com/go-seidon/hippo/internal/repository"
 	"github.com/go-seidon/provider/typeconv"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"gorm.io/plugin/dbresolver"
 )
 
@@ -41,14 +42,15 @@ func (r *file) CreateFile(ctx context.Context, p repository.CreateFileParam) (*r
 	}
 
 	createParam := &File{
-		Id:        p.UniqueId,
-		Name:      p.Name,
-		Path:      p.Path,
-		Mimetype:  p.Mimetype,
-		Extension: p.Extension,
-		Size:      p.Size,
-		CreatedAt: p.CreatedAt.UnixMilli(),
-		UpdatedAt: p.CreatedAt.UnixMilli(),
+		Id:            p.UniqueId,
+		Name:          p.Name,
+		Path:          p.Path,
+		Mimetype:      p.Mimetype,
+		Extension:     p.Extension,
+		Size:          p.Size,
+		OwnerClientId: p.OwnerClientId,
+		CreatedAt:     p.CreatedAt.UnixMilli(),
+		UpdatedAt:     p.CreatedAt.UnixMilli(),
 	}
 	createRes := tx.Create(createParam)
 	if createRes.Error != nil {
@@ -59,27 +61,107 @@ func (r *file) CreateFile(ctx context.Context, p repository.CreateFileParam) (*r
 		return nil, createRes.Error
 	}
 
-	file := &File{}
-	findRes := tx.
-		Select("id, name, path, mimetype, extension, size, created_at").
-		First(file, "id = ?", p.UniqueId)
-	if findRes.Error != nil {
+	// @note: file is written after the record is created so a failed write is rolled back
+	// and the stored size and checksum are taken from the written data
+	fn, err := p.CreateFn(ctx, repository.CreateFnParam{
+		FilePath: p.Path,
+	})
+	if err != nil {
 		txRes := tx.Rollback()
 		if txRes.Error != nil {
 			return nil, txRes.Error
 		}
-		return nil, findRes.Error
+		return nil, err
 	}
 
-	err := p.CreateFn(ctx, repository.CreateFnParam{
-		FilePath: p.Path,
-	})
-	if err != nil {
+	path := p.Path
+	keyId := fn.EncryptionKeyId
+	dataKey := fn.EncryptionDataKey
+	storedSize := fn.StoredSize
+	compression := fn.Compression
+	if p.Deduplicate {
+		blob, err := r.referenceBlob(tx, FileBlob{
+			ChecksumSha256: fn.ChecksumSha256,
+			Path:           p.Path,
+			RefCount:       1,
+			CreatedAt:      p.CreatedAt.UnixMilli(),
+			UpdatedAt:      p.CreatedAt.UnixMilli(),
+		})
+		if err != nil {
+			txRes := tx.Rollback()
+			if txRes.Error != nil {
+				return nil, txRes.Error
+			}
+			return nil, err
+		}
+
+		if blob.Path != p.Path {
+			err = p.DuplicateFn(ctx, repository.DuplicateFnParam{
+				FilePath: p.Path,
+				BlobPath: blob.Path,
+			})
+			if err != nil {
+				txRes := tx.Rollback()
+				if txRes.Error != nil {
+					return nil, txRes.Error
+				}
+				return nil, err
+			}
+			path = blob.Path
+
+			// @note: stored content is read using the encryption key and compression of the file which stored it
+			blobFile := &File{}
+			blobRes := tx.
+				Select("encryption_key_id, encryption_data_key, stored_size, compression").
+				Where("path = ? AND id <> ?", blob.Path, p.UniqueId).
+				Limit(1).
+				Find(blobFile)
+			if blobRes.Error != nil {
+				txRes := tx.Rollback()
+				if txRes.Error != nil {
+					return nil, txRes.Error
+				}
+				return nil, blobRes.Error
+			}
+			keyId = blobFile.EncryptionKeyId
+			dataKey = blobFile.EncryptionDataKey
+			storedSize = blobFile.StoredSize
+			compression = blobFile.Compression
+		}
+	}
+
+	updateRes := tx.
+		Model(&File{}).
+		Where("id = ?", p.UniqueId).
+		Updates(map[string]interface{}{
+			"path":                path,
+			"size":                fn.Size,
+			"checksum_sha256":     fn.ChecksumSha256,
+			"checksum_md5":        fn.ChecksumMd5,
+			"encryption_key_id":   keyId,
+			"encryption_data_key": dataKey,
+			"stored_size":         storedSize,
+			"compression":         compression,
+			"updated_at":          p.CreatedAt.UnixMilli(),
+		})
+	if updateRes.Error != nil {
 		txRes := tx.Rollback()
 		if txRes.Error != nil {
 			return nil, txRes.Error
 		}
-		return nil, err
+		return nil, updateRes.Error
+	}
+
+	file := &File{}
+	findRes := tx.
+		Select("id, name, path, mimetype, extension, size, checksum_sha256, checksum_md5, created_at").
+		First(file, "id = ?", p.UniqueId)
+	if findRes.Error != nil {
+		txRes := tx.Rollback()
+		if txRes.Error != nil {
+			return nil, txRes.Error
+		}
+		return nil, findRes.Error
 	}
 
 	txRes := tx.Commit()
@@ -88,13 +170,15 @@ func (r *file) CreateFile(ctx context.Context, p repository.CreateFileParam) (*r
 	}
 
 	res := &repository.CreateFileResult{
-		UniqueId:  file.Id,
-		Path:      file.Path,
-		Name:      file.Name,
-		Mimetype:  file.Mimetype,
-		Extension: file.Extension,
-		Size:      file.Size,
-		CreatedAt: time.UnixMilli(file.CreatedAt).UTC(),
+		UniqueId:       file.Id,
+		Path:           file.Path,
+		Name:           file.Name,
+		Mimetype:       file.Mimetype,
+		Extension:      file.Extension,
+		Size:           file.Size,
+		CreatedAt:      time.UnixMilli(file.CreatedAt).UTC(),
+		ChecksumSha256: file.ChecksumSha256,
+		ChecksumMd5:    file.ChecksumMd5,
 	}
 	return res, nil
 }
@@ -106,7 +190,7 @@ func (r *file) RetrieveFile(ctx context.Context, p repository.RetrieveFileParam)
 
 	file := &File{}
 	findRes := query.
-		Select("id, name, path, mimetype, extension, size, created_at, deleted_at").
+		Select("id, name, path, mimetype, extension, size, checksum_sha256, checksum_md5, encryption_key_id, encryption_data_key, stored_size, compression, owner_client_id, created_at, deleted_at").
 		First(file, "id = ?", p.UniqueId)
 	if findRes.Error != nil {
 		if errors.Is(findRes.Error, gorm.ErrRecordNotFound) {
@@ -121,14 +205,21 @@ func (r *file) RetrieveFile(ctx context.Context, p repository.RetrieveFileParam)
 	}
 
 	res := &repository.RetrieveFileResult{
-		UniqueId:  file.Id,
-		Path:      file.Path,
-		Name:      file.Name,
-		Mimetype:  file.Mimetype,
-		Extension: file.Extension,
-		Size:      file.Size,
-		CreatedAt: time.UnixMilli(file.CreatedAt).UTC(),
-		DeletedAt: deletedAt,
+		UniqueId:          file.Id,
+		Path:              file.Path,
+		Name:              file.Name,
+		Mimetype:          file.Mimetype,
+		Extension:         file.Extension,
+		Size:              file.Size,
+		CreatedAt:         time.UnixMilli(file.CreatedAt).UTC(),
+		DeletedAt:         deletedAt,
+		ChecksumSha256:    file.ChecksumSha256,
+		ChecksumMd5:       file.ChecksumMd5,
+		EncryptionKeyId:   file.EncryptionKeyId,
+		EncryptionDataKey: file.EncryptionDataKey,
+		StoredSize:        file.StoredSize,
+		Compression:       file.Compression,
+		OwnerClientId:     file.OwnerClientId,
 	}
 	return res, nil
 }
@@ -142,8 +233,10 @@ func (r *file) DeleteFile(ctx context.Context, p repository.DeleteFileParam) (*r
 		return nil, tx.Error
 	}
 
+	// @note: file is locked so the concurrent delete doesn't release the usage and the blob twice
 	currentFile := &File{}
 	findRes := tx.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
 		Select("id, deleted_at").
 		First(currentFile, "id = ?", p.UniqueId)
 	if findRes.Error != nil {
@@ -182,7 +275,7 @@ func (r *file) DeleteFile(ctx context.Context, p repository.DeleteFileParam) (*r
 
 	file := &File{}
 	checkRes := tx.
-		Select(`id, path, deleted_at`).
+		Select(`id, path, size, checksum_sha256, owner_client_id, deleted_at`).
 		First(file, "id = ?", p.UniqueId)
 	if checkRes.Error != nil {
 		txRes := tx.Rollback()
@@ -192,8 +285,27 @@ func (r *file) DeleteFile(ctx context.Context, p repository.DeleteFileParam) (*r
 		return nil, checkRes.Error
 	}
 
-	err := p.DeleteFn(ctx, repository.DeleteFnParam{
-		FilePath: file.Path,
+	err := r.updateOwnerUsage(tx, file, -1, p.DeletedAt)
+	if err != nil {
+		txRes := tx.Rollback()
+		if txRes.Error != nil {
+			return nil, txRes.Error
+		}
+		return nil, err
+	}
+
+	references, err := r.dereferenceBlob(tx, file, p.DeletedAt.UnixMilli())
+	if err != nil {
+		txRes := tx.Rollback()
+		if txRes.Error != nil {
+			return nil, txRes.Error
+		}
+		return nil, err
+	}
+
+	err = p.DeleteFn(ctx, repository.DeleteFnParam{
+		FilePath:   file.Path,
+		References: references,
 	})
 	if err != nil {
 		txRes := tx.Rollback()
@@ -214,6 +326,529 @@ func (r *file) DeleteFile(ctx context.Context, p repository.DeleteFileParam) (*r
 	return res, nil
 }
 
+func (r *file) RestoreFile(ctx context.Context, p repository.RestoreFileParam) (*repository.RestoreFileResult, error) {
+	tx := r.gormClient.
+		WithContext(ctx).
+		Clauses(dbresolver.Write).
+		Begin()
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	file := &File{}
+	findRes := tx.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id, path, size, checksum_sha256, owner_client_id, deleted_at").
+		First(file, "id = ?", p.UniqueId)
+	if findRes.Error != nil {
+		txRes := tx.Rollback()
+		if txRes.Error != nil {
+			return nil, txRes.Error
+		}
+		if errors.Is(findRes.Error, gorm.ErrRecordNotFound) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, findRes.Error
+	}
+
+	if !file.DeletedAt.Valid {
+		txRes := tx.Rollback()
+		if txRes.Error != nil {
+			return nil, txRes.Error
+		}
+		return nil, repository.ErrNotDeleted
+	}
+
+	updateRes := tx.
+		Model(&File{}).
+		Where("id = ?", p.UniqueId).
+		Updates(map[string]interface{}{
+			"updated_at": p.RestoredAt.UnixMilli(),
+			"deleted_at": nil,
+		})
+	if updateRes.Error != nil {
+		txRes := tx.Rollback()
+		if txRes.Error != nil {
+			return nil, txRes.Error
+		}
+		return nil, updateRes.Error
+	}
+
+	err := r.updateOwnerUsage(tx, file, 1, p.RestoredAt)
+	if err != nil {
+		txRes := tx.Rollback()
+		if txRes.Error != nil {
+			return nil, txRes.Error
+		}
+		return nil, err
+	}
+
+	references, err := r.restoreBlob(tx, file, p.Deduplicate, p.RestoredAt.UnixMilli())
+	if err != nil {
+		txRes := tx.Rollback()
+		if txRes.Error != nil {
+			return nil, txRes.Error
+		}
+		return nil, err
+	}
+
+	err = p.RestoreFn(ctx, repository.RestoreFnParam{
+		FilePath:   file.Path,
+		References: references,
+	})
+	if err != nil {
+		txRes := tx.Rollback()
+		if txRes.Error != nil {
+			return nil, txRes.Error
+		}
+		return nil, err
+	}
+
+	txRes := tx.Commit()
+	if txRes.Error != nil {
+		return nil, txRes.Error
+	}
+
+	res := &repository.RestoreFileResult{
+		RestoredAt: p.RestoredAt,
+	}
+	return res, nil
+}
+
+func (r *file) SearchFile(ctx context.Context, p repository.SearchFileParam) (*repository.SearchFileResult, error) {
+	query := r.gormClient.
+		WithContext(ctx).
+		Clauses(dbresolver.Read)
+
+	if p.Keyword != "" {
+		query.Where("name LIKE ?", "%"+p.Keyword+"%")
+	}
+
+	if len(p.Mimetypes) > 0 {
+		query.Where("mimetype IN ?", p.Mimetypes)
+	}
+
+	if len(p.Extensions) > 0 {
+		query.Where("extension IN ?", p.Extensions)
+	}
+
+	if p.MinSize != nil {
+		query.Where("size >= ?", *p.MinSize)
+	}
+
+	if p.MaxSize != nil {
+		query.Where("size <= ?", *p.MaxSize)
+	}
+
+	if p.UploadedFrom != nil {
+		query.Where("created_at >= ?", p.UploadedFrom.UnixMilli())
+	}
+
+	if p.UploadedTo != nil {
+		query.Where("created_at <= ?", p.UploadedTo.UnixMilli())
+	}
+
+	available, deleted := searchFileStatus(p.Statuses)
+	if available && !deleted {
+		query.Where("deleted_at IS NULL")
+	} else if deleted && !available {
+		query.Where("deleted_at IS NOT NULL")
+	}
+
+	if len(p.OwnerClientIds) > 0 {
+		query.Where("owner_client_id IN ?", p.OwnerClientIds)
+	}
+
+	if len(p.Paths) > 0 {
+		query.Where("path IN ?", p.Paths)
+	}
+
+	if p.AfterId != "" {
+		query.Where("id > ?", p.AfterId)
+	}
+
+	res := &repository.SearchFileResult{
+		Summary: repository.SearchFileSummary{},
+		Items:   []repository.SearchFileItem{},
+	}
+	countRes := query.
+		Table("file").
+		Count(&res.Summary.TotalItems)
+	if countRes.Error != nil {
+		return nil, countRes.Error
+	}
+
+	if column, ok := fileSortColumns[p.SortBy]; ok {
+		query.Order(clause.OrderByColumn{
+			Column: clause.Column{Name: column},
+			Desc:   p.SortOrder == repository.SORT_DESC,
+		})
+	}
+
+	if p.Limit > 0 {
+		query.Limit(int(p.Limit))
+	}
+
+	if p.Offset > 0 {
+		query.Offset(int(p.Offset))
+	}
+
+	files := []File{}
+	searchRes := query.
+		Select(`id, name, path, mimetype, extension, size, checksum_sha256, checksum_md5, encryption_key_id, encryption_data_key, stored_size, compression, created_at, deleted_at`).
+		Find(&files)
+
+	if searchRes.Error != nil {
+		if errors.Is(searchRes.Error, gorm.ErrRecordNotFound) {
+			return res, nil
+		}
+		return nil, searchRes.Error
+	}
+
+	for _, file := range files {
+		var deletedAt *time.Time
+		if file.DeletedAt.Valid {
+			deletedAt = typeconv.Time(time.UnixMilli(file.DeletedAt.Int64).UTC())
+		}
+
+		res.Items = append(res.Items, repository.SearchFileItem{
+			UniqueId:          file.Id,
+			Name:              file.Name,
+			Path:              file.Path,
+			Mimetype:          file.Mimetype,
+			Extension:         file.Extension,
+			Size:              file.Size,
+			CreatedAt:         time.UnixMilli(file.CreatedAt).UTC(),
+			DeletedAt:         deletedAt,
+			ChecksumSha256:    file.ChecksumSha256,
+			ChecksumMd5:       file.ChecksumMd5,
+			EncryptionKeyId:   file.EncryptionKeyId,
+			EncryptionDataKey: file.EncryptionDataKey,
+			StoredSize:        file.StoredSize,
+			Compression:       file.Compression,
+		})
+	}
+
+	return res, nil
+}
+
+func (r *file) PurgeFile(ctx context.Context, p repository.PurgeFileParam) (*repository.PurgeFileResult, error) {
+	tx := r.gormClient.
+		WithContext(ctx).
+		Clauses(dbresolver.Write).
+		Begin()
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	files := []File{}
+	findRes := tx.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id, path").
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", p.DeletedBefore.UnixMilli()).
+		Order("deleted_at").
+		Limit(int(p.Limit)).
+		Find(&files)
+	if findRes.Error != nil {
+		txRes := tx.Rollback()
+		if txRes.Error != nil {
+			return nil, txRes.Error
+		}
+		return nil, findRes.Error
+	}
+
+	res := &repository.PurgeFileResult{}
+	if len(files) == 0 {
+		txRes := tx.Commit()
+		if txRes.Error != nil {
+			return nil, txRes.Error
+		}
+		return res, nil
+	}
+
+	ids := []string{}
+	paths := []string{}
+	purgedPaths := map[string]bool{}
+	for _, file := range files {
+		ids = append(ids, file.Id)
+		if !purgedPaths[file.Path] {
+			purgedPaths[file.Path] = true
+			paths = append(paths, file.Path)
+		}
+	}
+
+	deleteRes := tx.
+		Where("id IN ?", ids).
+		Delete(&File{})
+	if deleteRes.Error != nil {
+		txRes := tx.Rollback()
+		if txRes.Error != nil {
+			return nil, txRes.Error
+		}
+		return nil, deleteRes.Error
+	}
+
+	for _, path := range paths {
+		references := int64(0)
+		countRes := tx.
+			Model(&File{}).
+			Where("path = ?", path).
+			Count(&references)
+		if countRes.Error != nil {
+			txRes := tx.Rollback()
+			if txRes.Error != nil {
+				return nil, txRes.Error
+			}
+			return nil, countRes.Error
+		}
+
+		err := p.PurgeFn(ctx, repository.PurgeFnParam{
+			FilePath:   path,
+			References: references,
+		})
+		if err != nil {
+			txRes := tx.Rollback()
+			if txRes.Error != nil {
+				return nil, txRes.Error
+			}
+			return nil, err
+		}
+	}
+
+	txRes := tx.Commit()
+	if txRes.Error != nil {
+		return nil, txRes.Error
+	}
+
+	res.TotalItems = deleteRes.RowsAffected
+	return res, nil
+}
+
+func (r *file) SearchFileKey(ctx context.Context, p repository.SearchFileKeyParam) (*repository.SearchFileKeyResult, error) {
+	query := r.gormClient.
+		WithContext(ctx).
+		Clauses(dbresolver.Read).
+		Where("encryption_key_id <> '' AND encryption_key_id <> ?", p.ExcludeKeyId)
+
+	if p.AfterId != "" {
+		query.Where("id > ?", p.AfterId)
+	}
+
+	if p.Limit > 0 {
+		query.Limit(int(p.Limit))
+	}
+
+	files := []File{}
+	searchRes := query.
+		Select("id, encryption_key_id, encryption_data_key").
+		Order("id").
+		Find(&files)
+	if searchRes.Error != nil {
+		return nil, searchRes.Error
+	}
+
+	res := &repository.SearchFileKeyResult{
+		Items: []repository.SearchFileKeyItem{},
+	}
+	for _, file := range files {
+		res.Items = append(res.Items, repository.SearchFileKeyItem{
+			UniqueId:          file.Id,
+			EncryptionKeyId:   file.EncryptionKeyId,
+			EncryptionDataKey: file.EncryptionDataKey,
+		})
+	}
+	return res, nil
+}
+
+func (r *file) UpdateFileKey(ctx context.Context, p repository.UpdateFileKeyParam) (*repository.UpdateFileKeyResult, error) {
+	updateRes := r.gormClient.
+		WithContext(ctx).
+		Clauses(dbresolver.Write).
+		Model(&File{}).
+		Where("id = ? AND encryption_data_key = ?", p.UniqueId, p.CurrentDataKey).
+		Updates(map[string]interface{}{
+			"encryption_key_id":   p.EncryptionKeyId,
+			"encryption_data_key": p.EncryptionDataKey,
+			"updated_at":          p.UpdatedAt.UnixMilli(),
+		})
+	if updateRes.Error != nil {
+		return nil, updateRes.Error
+	}
+
+	if updateRes.RowsAffected == 0 {
+		return nil, repository.ErrNotFound
+	}
+
+	res := &repository.UpdateFileKeyResult{
+		UpdatedAt: p.UpdatedAt,
+	}
+	return res, nil
+}
+
+var fileSortColumns = map[string]string{
+	repository.FILE_SORT_NAME:        "name",
+	repository.FILE_SORT_SIZE:        "size",
+	repository.FILE_SORT_UPLOADED_AT: "created_at",
+	repository.FILE_SORT_ID:          "id",
+}
+
+// @note: status filter is only applied when exactly one of the status is requested
+func searchFileStatus(statuses []string) (available bool, deleted bool) {
+	for _, status := range statuses {
+		switch status {
+		case repository.FILE_STATUS_AVAILABLE:
+			available = true
+		case repository.FILE_STATUS_DELETED:
+			deleted = true
+		}
+	}
+	return available, deleted
+}
+
+// @note: register the content or add reference to the already stored content
+// the returned blob path is the path of the stored content
+func (r *file) referenceBlob(tx *gorm.DB, b FileBlob) (*FileBlob, error) {
+	createRes := tx.
+		Clauses(clause.OnConflict{
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"ref_count":  gorm.Expr("ref_count + 1"),
+				"updated_at": b.UpdatedAt,
+			}),
+		}).
+		Create(&b)
+	if createRes.Error != nil {
+		return nil, createRes.Error
+	}
+
+	blob := &FileBlob{}
+	findRes := tx.
+		Select("checksum_sha256, path, ref_count").
+		First(blob, "checksum_sha256 = ?", b.ChecksumSha256)
+	if findRes.Error != nil {
+		return nil, findRes.Error
+	}
+	return blob, nil
+}
+
+// @note: remove file reference from the stored content (if it's deduplicated)
+// and return the remaining references, blob record is removed when its last reference goes
+func (r *file) dereferenceBlob(tx *gorm.DB, f *File, updatedAt int64) (int64, error) {
+	if f.ChecksumSha256 == "" {
+		return 0, nil
+	}
+
+	blob := &FileBlob{}
+	findRes := tx.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("checksum_sha256, path, ref_count").
+		Where("checksum_sha256 = ? AND path = ?", f.ChecksumSha256, f.Path).
+		Limit(1).
+		Find(blob)
+	if findRes.Error != nil {
+		return 0, findRes.Error
+	}
+	if findRes.RowsAffected == 0 {
+		return 0, nil
+	}
+
+	if blob.RefCount <= 1 {
+		deleteRes := tx.
+			Where("checksum_sha256 = ?", blob.ChecksumSha256).
+			Delete(&FileBlob{})
+		if deleteRes.Error != nil {
+			return 0, deleteRes.Error
+		}
+		return 0, nil
+	}
+
+	updateRes := tx.
+		Model(&FileBlob{}).
+		Where("checksum_sha256 = ?", blob.ChecksumSha256).
+		Updates(map[string]interface{}{
+			"ref_count":  gorm.Expr("ref_count - 1"),
+			"updated_at": updatedAt,
+		})
+	if updateRes.Error != nil {
+		return 0, updateRes.Error
+	}
+	return blob.RefCount - 1, nil
+}
+
+// @note: add file reference back to the stored content and return the other references,
+// the content is registered again when it's no longer referenced and deduplication is enabled
+// file is not linked when the same content has been stored in another path meanwhile
+func (r *file) restoreBlob(tx *gorm.DB, f *File, deduplicate bool, updatedAt int64) (int64, error) {
+	if f.ChecksumSha256 == "" {
+		return 0, nil
+	}
+
+	blob := &FileBlob{}
+	findRes := tx.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("checksum_sha256, path, ref_count").
+		Where("checksum_sha256 = ?", f.ChecksumSha256).
+		Limit(1).
+		Find(blob)
+	if findRes.Error != nil {
+		return 0, findRes.Error
+	}
+
+	if findRes.RowsAffected == 0 {
+		if !deduplicate {
+			return 0, nil
+		}
+
+		createRes := tx.Create(&FileBlob{
+			ChecksumSha256: f.ChecksumSha256,
+			Path:           f.Path,
+			RefCount:       1,
+			CreatedAt:      updatedAt,
+			UpdatedAt:      updatedAt,
+		})
+		if createRes.Error != nil {
+			return 0, createRes.Error
+		}
+		return 0, nil
+	}
+
+	if blob.Path != f.Path {
+		return 0, nil
+	}
+
+	updateRes := tx.
+		Model(&FileBlob{}).
+		Where("checksum_sha256 = ?", blob.ChecksumSha256).
+		Updates(map[string]interface{}{
+			"ref_count":  gorm.Expr("ref_count + 1"),
+			"updated_at": updatedAt,
+		})
+	if updateRes.Error != nil {
+		return 0, updateRes.Error
+	}
+	return blob.RefCount, nil
+}
+
+// @note: usage of the owner is released when the file is deleted (direction -1)
+// and it's added back when the file is restored (direction 1) as long as it's within the quota,
+// the usage of unowned file or the file of deleted client is not tracked
+func (r *file) updateOwnerUsage(tx *gorm.DB, f *File, direction int64, usedAt time.Time) error {
+	if f.OwnerClientId == "" {
+		return nil
+	}
+
+	_, err := updateClientUsage(tx, repository.UpdateClientUsageParam{
+		Id:         f.OwnerClientId,
+		StoredSize: direction * f.Size,
+		TotalFiles: direction,
+		UsedAt:     usedAt,
+		CheckQuota: direction > 0,
+	})
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil
+	}
+	return err
+}
+
 type FileParam struct {
 	GormClient *gorm.DB
 }
@@ -225,17 +860,37 @@ func NewFile(p FileParam) *file {
 }
 
 type File struct {
-	Id        string        `gorm:"column:id;primaryKey"`
-	Path      string        `gorm:"column:path"`
-	Name      string        `gorm:"column:name"`
-	Mimetype  string        `gorm:"column:mimetype"`
-	Extension string        `gorm:"column:extension"`
-	Size      int64         `gorm:"column:size"`
-	CreatedAt int64         `gorm:"column:created_at"`
-	UpdatedAt int64         `gorm:"column:updated_at;autoUpdateTime:milli"`
-	DeletedAt sql.NullInt64 `gorm:"column:deleted_at;<-:update"`
+	Id             string        `gorm:"column:id;primaryKey"`
+	Path           string        `gorm:"column:path"`
+	Name           string        `gorm:"column:name"`
+	Mimetype       string        `gorm:"column:mimetype"`
+	Extension      string        `gorm:"column:extension"`
+	Size           int64         `gorm:"column:size"`
+	ChecksumSha256 string        `gorm:"column:checksum_sha256"`
+	ChecksumMd5    string        `gorm:"column:checksum_md5"`
+	OwnerClientId  string        `gorm:"column:owner_client_id"`
+	CreatedAt      int64         `gorm:"column:created_at"`
+	UpdatedAt      int64         `gorm:"column:updated_at;autoUpdateTime:milli"`
+	DeletedAt      sql.NullInt64 `gorm:"column:deleted_at;<-:update"`
+	// @note: key and compression are set after the content is written
+	EncryptionKeyId   string `gorm:"column:encryption_key_id;<-:update"`
+	EncryptionDataKey string `gorm:"column:encryption_data_key;<-:update"`
+	StoredSize        int64  `gorm:"column:stored_size;<-:update"`
+	Compression       string `gorm:"column:compression;<-:update"`
 }
 
 func (File) TableName() string {
 	return "file"
 }
+
+type FileBlob struct {
+	ChecksumSha256 string `gorm:"column:checksum_sha256;primaryKey"`
+	Path           string `gorm:"column:path"`
+	RefCount       int64  `gorm:"column:ref_count"`
+	CreatedAt      int64  `gorm:"column:created_at"`
+	UpdatedAt      int64  `gorm:"column:updated_at"`
+}
+
+func (FileBlob) TableName() string {
+	return "file_blob"
+}
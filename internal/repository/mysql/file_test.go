@@ -3,8 +3,10 @@ package mysql_test
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"fmt"
 	"regexp"
+	"strings"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
@@ -20,14 +22,18 @@ import (
 var _ = Describe("File Repository", func() {
 	Context("CreateFile function", Label("unit"), func() {
 		var (
-			ctx        context.Context
-			currentTs  time.Time
-			dbClient   sqlmock.Sqlmock
-			fileRepo   repository.File
-			p          repository.CreateFileParam
-			checkStmt  string
-			insertStmt string
-			findStmt   string
+			ctx             context.Context
+			currentTs       time.Time
+			dbClient        sqlmock.Sqlmock
+			fileRepo        repository.File
+			p               repository.CreateFileParam
+			checkStmt       string
+			insertStmt      string
+			updateStmt      string
+			findStmt        string
+			insertBlobStmt  string
+			findBlobStmt    string
+			findBlobKeyStmt string
 		)
 
 		BeforeEach(func() {
@@ -63,14 +69,23 @@ var _ = Describe("File Repository", func() {
 				Mimetype:  "image/jpeg",
 				Extension: "jpg",
 				Size:      2334,
-				CreateFn: func(ctx context.Context, p repository.CreateFnParam) error {
-					return nil
+				CreateFn: func(ctx context.Context, p repository.CreateFnParam) (*repository.CreateFnResult, error) {
+					return &repository.CreateFnResult{
+						Size:           2048,
+						ChecksumSha256: "mock-sha256",
+						ChecksumMd5:    "mock-md5",
+					}, nil
 				},
-				CreatedAt: currentTs,
+				CreatedAt:     currentTs,
+				OwnerClientId: "client-id",
 			}
 			checkStmt = regexp.QuoteMeta("SELECT `id` FROM `file` WHERE id = ? ORDER BY `file`.`id` LIMIT 1")
-			insertStmt = regexp.QuoteMeta("INSERT INTO `file` (`id`,`path`,`name`,`mimetype`,`extension`,`size`,`created_at`,`updated_at`) VALUES (?,?,?,?,?,?,?,?)")
-			findStmt = regexp.QuoteMeta("SELECT id, name, path, mimetype, extension, size, created_at FROM `file` WHERE id = ? ORDER BY `file`.`id` LIMIT 1")
+			insertStmt = regexp.QuoteMeta("INSERT INTO `file` (`id`,`path`,`name`,`mimetype`,`extension`,`size`,`checksum_sha256`,`checksum_md5`,`owner_client_id`,`created_at`,`updated_at`) VALUES (?,?,?,?,?,?,?,?,?,?,?)")
+			updateStmt = regexp.QuoteMeta("UPDATE `file` SET `checksum_md5`=?,`checksum_sha256`=?,`compression`=?,`encryption_data_key`=?,`encryption_key_id`=?,`path`=?,`size`=?,`stored_size`=?,`updated_at`=? WHERE id = ?")
+			findBlobKeyStmt = regexp.QuoteMeta("SELECT encryption_key_id, encryption_data_key, stored_size, compression FROM `file` WHERE path = ? AND id <> ? LIMIT 1")
+			findStmt = regexp.QuoteMeta("SELECT id, name, path, mimetype, extension, size, checksum_sha256, checksum_md5, created_at FROM `file` WHERE id = ? ORDER BY `file`.`id` LIMIT 1")
+			insertBlobStmt = regexp.QuoteMeta("INSERT INTO `file_blob` (`checksum_sha256`,`path`,`ref_count`,`created_at`,`updated_at`) VALUES (?,?,?,?,?) ON DUPLICATE KEY UPDATE `ref_count`=ref_count + 1,`updated_at`=?")
+			findBlobStmt = regexp.QuoteMeta("SELECT checksum_sha256, path, ref_count FROM `file_blob` WHERE checksum_sha256 = ? ORDER BY `file_blob`.`checksum_sha256` LIMIT 1")
 		})
 
 		AfterEach(func() {
@@ -184,6 +199,9 @@ var _ = Describe("File Repository", func() {
 						p.Mimetype,
 						p.Extension,
 						p.Size,
+						"",
+						"",
+						p.OwnerClientId,
 						p.CreatedAt.UnixMilli(),
 						p.CreatedAt.UnixMilli(),
 					).
@@ -221,6 +239,9 @@ var _ = Describe("File Repository", func() {
 						p.Mimetype,
 						p.Extension,
 						p.Size,
+						"",
+						"",
+						p.OwnerClientId,
 						p.CreatedAt.UnixMilli(),
 						p.CreatedAt.UnixMilli(),
 					).
@@ -236,8 +257,12 @@ var _ = Describe("File Repository", func() {
 			})
 		})
 
-		When("failed rollback during check inserted file", func() {
+		When("failed rollback during failure execute callback", func() {
 			It("should return error", func() {
+				p.CreateFn = func(ctx context.Context, p repository.CreateFnParam) (*repository.CreateFnResult, error) {
+					return nil, fmt.Errorf("callback error")
+				}
+
 				dbClient.
 					ExpectBegin()
 
@@ -257,18 +282,14 @@ var _ = Describe("File Repository", func() {
 						p.Mimetype,
 						p.Extension,
 						p.Size,
+						"",
+						"",
+						p.OwnerClientId,
 						p.CreatedAt.UnixMilli(),
 						p.CreatedAt.UnixMilli(),
 					).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 
-				dbClient.
-					ExpectQuery(findStmt).
-					WithArgs(
-						p.UniqueId,
-					).
-					WillReturnError(fmt.Errorf("network error"))
-
 				dbClient.
 					ExpectRollback().
 					WillReturnError(fmt.Errorf("rollback error"))
@@ -280,8 +301,12 @@ var _ = Describe("File Repository", func() {
 			})
 		})
 
-		When("failed check inserted file", func() {
+		When("failed execute callback", func() {
 			It("should return error", func() {
+				p.CreateFn = func(ctx context.Context, p repository.CreateFnParam) (*repository.CreateFnResult, error) {
+					return nil, fmt.Errorf("callback error")
+				}
+
 				dbClient.
 					ExpectBegin()
 
@@ -301,43 +326,26 @@ var _ = Describe("File Repository", func() {
 						p.Mimetype,
 						p.Extension,
 						p.Size,
+						"",
+						"",
+						p.OwnerClientId,
 						p.CreatedAt.UnixMilli(),
 						p.CreatedAt.UnixMilli(),
 					).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 
-				dbClient.
-					ExpectQuery(findStmt).
-					WithArgs(
-						p.UniqueId,
-					).
-					WillReturnError(fmt.Errorf("network error"))
-
 				dbClient.
 					ExpectRollback()
 
 				res, err := fileRepo.CreateFile(ctx, p)
 
 				Expect(res).To(BeNil())
-				Expect(err).To(Equal(fmt.Errorf("network error")))
+				Expect(err).To(Equal(fmt.Errorf("callback error")))
 			})
 		})
 
-		When("failed rollback during failure execute callback", func() {
+		When("failed rollback during update file size", func() {
 			It("should return error", func() {
-				p := repository.CreateFileParam{
-					UniqueId:  "id",
-					Path:      "storage/id",
-					Name:      "dolphin",
-					Mimetype:  "image/jpeg",
-					Extension: "jpg",
-					Size:      2334,
-					CreateFn: func(ctx context.Context, p repository.CreateFnParam) error {
-						return fmt.Errorf("callback error")
-					},
-					CreatedAt: currentTs,
-				}
-
 				dbClient.
 					ExpectBegin()
 
@@ -357,32 +365,29 @@ var _ = Describe("File Repository", func() {
 						p.Mimetype,
 						p.Extension,
 						p.Size,
+						"",
+						"",
+						p.OwnerClientId,
 						p.CreatedAt.UnixMilli(),
 						p.CreatedAt.UnixMilli(),
 					).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 
-				findRows := sqlmock.
-					NewRows([]string{
-						"id", "name", "path", "mimetype",
-						"extension", "size", "created_at",
-					}).
-					AddRow(
-						p.UniqueId,
-						p.Name,
-						p.Path,
-						p.Mimetype,
-						p.Extension,
-						p.Size,
-						p.CreatedAt.UnixMilli(),
-					)
-
 				dbClient.
-					ExpectQuery(findStmt).
+					ExpectExec(updateStmt).
 					WithArgs(
+						"mock-md5",
+						"mock-sha256",
+						"",
+						"",
+						"",
+						p.Path,
+						int64(2048),
+						int64(0),
+						p.CreatedAt.UnixMilli(),
 						p.UniqueId,
 					).
-					WillReturnRows(findRows)
+					WillReturnError(fmt.Errorf("network error"))
 
 				dbClient.
 					ExpectRollback().
@@ -395,21 +400,8 @@ var _ = Describe("File Repository", func() {
 			})
 		})
 
-		When("failed execute callback", func() {
+		When("failed update file size", func() {
 			It("should return error", func() {
-				p := repository.CreateFileParam{
-					UniqueId:  "id",
-					Path:      "storage/id",
-					Name:      "dolphin",
-					Mimetype:  "image/jpeg",
-					Extension: "jpg",
-					Size:      2334,
-					CreateFn: func(ctx context.Context, p repository.CreateFnParam) error {
-						return fmt.Errorf("callback error")
-					},
-					CreatedAt: currentTs,
-				}
-
 				dbClient.
 					ExpectBegin()
 
@@ -429,58 +421,105 @@ var _ = Describe("File Repository", func() {
 						p.Mimetype,
 						p.Extension,
 						p.Size,
+						"",
+						"",
+						p.OwnerClientId,
 						p.CreatedAt.UnixMilli(),
 						p.CreatedAt.UnixMilli(),
 					).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 
-				findRows := sqlmock.
-					NewRows([]string{
-						"id", "name", "path", "mimetype",
-						"extension", "size", "created_at",
-					}).
-					AddRow(
+				dbClient.
+					ExpectExec(updateStmt).
+					WithArgs(
+						"mock-md5",
+						"mock-sha256",
+						"",
+						"",
+						"",
+						p.Path,
+						int64(2048),
+						int64(0),
+						p.CreatedAt.UnixMilli(),
+						p.UniqueId,
+					).
+					WillReturnError(fmt.Errorf("network error"))
+
+				dbClient.
+					ExpectRollback()
+
+				res, err := fileRepo.CreateFile(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("network error")))
+			})
+		})
+
+		When("failed rollback during check inserted file", func() {
+			It("should return error", func() {
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectQuery(checkStmt).
+					WithArgs(
+						p.UniqueId,
+					).
+					WillReturnError(gorm.ErrRecordNotFound)
+
+				dbClient.
+					ExpectExec(insertStmt).
+					WithArgs(
 						p.UniqueId,
-						p.Name,
 						p.Path,
+						p.Name,
 						p.Mimetype,
 						p.Extension,
 						p.Size,
+						"",
+						"",
+						p.OwnerClientId,
 						p.CreatedAt.UnixMilli(),
-					)
+						p.CreatedAt.UnixMilli(),
+					).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				dbClient.
+					ExpectExec(updateStmt).
+					WithArgs(
+						"mock-md5",
+						"mock-sha256",
+						"",
+						"",
+						"",
+						p.Path,
+						int64(2048),
+						int64(0),
+						p.CreatedAt.UnixMilli(),
+						p.UniqueId,
+					).
+					WillReturnResult(sqlmock.NewResult(1, 1))
 
 				dbClient.
 					ExpectQuery(findStmt).
 					WithArgs(
 						p.UniqueId,
 					).
-					WillReturnRows(findRows)
+					WillReturnError(fmt.Errorf("network error"))
 
 				dbClient.
-					ExpectRollback()
+					ExpectRollback().
+					WillReturnError(fmt.Errorf("rollback error"))
 
 				res, err := fileRepo.CreateFile(ctx, p)
 
 				Expect(res).To(BeNil())
-				Expect(err).To(Equal(fmt.Errorf("callback error")))
+				Expect(err).To(Equal(fmt.Errorf("rollback error")))
 			})
 		})
 
-		When("failed execute callback", func() {
+		When("failed check inserted file", func() {
 			It("should return error", func() {
-				p := repository.CreateFileParam{
-					UniqueId:  "id",
-					Path:      "storage/id",
-					Name:      "dolphin",
-					Mimetype:  "image/jpeg",
-					Extension: "jpg",
-					Size:      2334,
-					CreateFn: func(ctx context.Context, p repository.CreateFnParam) error {
-						return fmt.Errorf("callback error")
-					},
-					CreatedAt: currentTs,
-				}
-
 				dbClient.
 					ExpectBegin()
 
@@ -500,32 +539,36 @@ var _ = Describe("File Repository", func() {
 						p.Mimetype,
 						p.Extension,
 						p.Size,
+						"",
+						"",
+						p.OwnerClientId,
 						p.CreatedAt.UnixMilli(),
 						p.CreatedAt.UnixMilli(),
 					).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 
-				findRows := sqlmock.
-					NewRows([]string{
-						"id", "name", "path", "mimetype",
-						"extension", "size", "created_at",
-					}).
-					AddRow(
-						p.UniqueId,
-						p.Name,
+				dbClient.
+					ExpectExec(updateStmt).
+					WithArgs(
+						"mock-md5",
+						"mock-sha256",
+						"",
+						"",
+						"",
 						p.Path,
-						p.Mimetype,
-						p.Extension,
-						p.Size,
+						int64(2048),
+						int64(0),
 						p.CreatedAt.UnixMilli(),
-					)
+						p.UniqueId,
+					).
+					WillReturnResult(sqlmock.NewResult(1, 1))
 
 				dbClient.
 					ExpectQuery(findStmt).
 					WithArgs(
 						p.UniqueId,
 					).
-					WillReturnRows(findRows)
+					WillReturnError(fmt.Errorf("network error"))
 
 				dbClient.
 					ExpectRollback()
@@ -533,7 +576,7 @@ var _ = Describe("File Repository", func() {
 				res, err := fileRepo.CreateFile(ctx, p)
 
 				Expect(res).To(BeNil())
-				Expect(err).To(Equal(fmt.Errorf("callback error")))
+				Expect(err).To(Equal(fmt.Errorf("network error")))
 			})
 		})
 
@@ -558,15 +601,35 @@ var _ = Describe("File Repository", func() {
 						p.Mimetype,
 						p.Extension,
 						p.Size,
+						"",
+						"",
+						p.OwnerClientId,
 						p.CreatedAt.UnixMilli(),
 						p.CreatedAt.UnixMilli(),
 					).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 
+				dbClient.
+					ExpectExec(updateStmt).
+					WithArgs(
+						"mock-md5",
+						"mock-sha256",
+						"",
+						"",
+						"",
+						p.Path,
+						int64(2048),
+						int64(0),
+						p.CreatedAt.UnixMilli(),
+						p.UniqueId,
+					).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
 				findRows := sqlmock.
 					NewRows([]string{
 						"id", "name", "path", "mimetype",
-						"extension", "size", "created_at",
+						"extension", "size", "checksum_sha256",
+						"checksum_md5", "created_at",
 					}).
 					AddRow(
 						p.UniqueId,
@@ -574,7 +637,9 @@ var _ = Describe("File Repository", func() {
 						p.Path,
 						p.Mimetype,
 						p.Extension,
-						p.Size,
+						2048,
+						"mock-sha256",
+						"mock-md5",
 						p.CreatedAt.UnixMilli(),
 					)
 
@@ -617,15 +682,35 @@ var _ = Describe("File Repository", func() {
 						p.Mimetype,
 						p.Extension,
 						p.Size,
+						"",
+						"",
+						p.OwnerClientId,
+						p.CreatedAt.UnixMilli(),
 						p.CreatedAt.UnixMilli(),
+					).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				dbClient.
+					ExpectExec(updateStmt).
+					WithArgs(
+						"mock-md5",
+						"mock-sha256",
+						"",
+						"",
+						"",
+						p.Path,
+						int64(2048),
+						int64(0),
 						p.CreatedAt.UnixMilli(),
+						p.UniqueId,
 					).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 
 				findRows := sqlmock.
 					NewRows([]string{
 						"id", "name", "path", "mimetype",
-						"extension", "size", "created_at",
+						"extension", "size", "checksum_sha256",
+						"checksum_md5", "created_at",
 					}).
 					AddRow(
 						p.UniqueId,
@@ -633,7 +718,9 @@ var _ = Describe("File Repository", func() {
 						p.Path,
 						p.Mimetype,
 						p.Extension,
-						p.Size,
+						2048,
+						"mock-sha256",
+						"mock-md5",
 						p.CreatedAt.UnixMilli(),
 					)
 
@@ -651,93 +738,342 @@ var _ = Describe("File Repository", func() {
 
 				Expect(err).To(BeNil())
 				Expect(res).To(Equal(&repository.CreateFileResult{
-					UniqueId:  p.UniqueId,
-					Name:      p.Name,
-					Path:      p.Path,
-					Mimetype:  p.Mimetype,
-					Extension: p.Extension,
-					Size:      p.Size,
-					CreatedAt: time.UnixMilli(p.CreatedAt.UnixMilli()).UTC(),
+					UniqueId:       p.UniqueId,
+					Name:           p.Name,
+					Path:           p.Path,
+					Mimetype:       p.Mimetype,
+					Extension:      p.Extension,
+					Size:           2048,
+					CreatedAt:      time.UnixMilli(p.CreatedAt.UnixMilli()).UTC(),
+					ChecksumSha256: "mock-sha256",
+					ChecksumMd5:    "mock-md5",
 				}))
 			})
 		})
-	})
-
-	Context("RetrieveFile function", Label("unit"), func() {
-		var (
-			ctx       context.Context
-			currentTs time.Time
-			dbClient  sqlmock.Sqlmock
-			fileRepo  repository.File
-			p         repository.RetrieveFileParam
-			r         *repository.RetrieveFileResult
-			findStmt  string
-		)
 
-		BeforeEach(func() {
-			var (
-				db  *sql.DB
-				err error
-			)
-
-			ctx = context.Background()
-			currentTs = time.Now().UTC()
-			db, dbClient, err = sqlmock.New()
-			if err != nil {
-				AbortSuite("failed create db mock: " + err.Error())
-			}
-
-			gormClient, err := gorm.Open(gorm_mysql.New(gorm_mysql.Config{
-				Conn:                      db,
-				SkipInitializeWithVersion: true,
-			}), &gorm.Config{
-				DisableAutomaticPing: true,
-			})
-			if err != nil {
-				AbortSuite("failed create gorm client: " + err.Error())
-			}
-			fileRepo = repository_mysql.NewFile(repository_mysql.FileParam{
-				GormClient: gormClient,
-			})
+		When("failed reference file blob", func() {
+			It("should return error", func() {
+				p.Deduplicate = true
 
-			p = repository.RetrieveFileParam{
-				UniqueId: "id",
-			}
-			r = &repository.RetrieveFileResult{
-				UniqueId:  "id",
-				CreatedAt: time.UnixMilli(currentTs.UnixMilli()).UTC(),
-				DeletedAt: typeconv.Time(time.UnixMilli(currentTs.UnixMilli()).UTC()),
-			}
-			findStmt = regexp.QuoteMeta("SELECT id, name, path, mimetype, extension, size, created_at, deleted_at FROM `file` WHERE id = ? ORDER BY `file`.`id` LIMIT 1")
-		})
+				dbClient.
+					ExpectBegin()
 
-		AfterEach(func() {
-			err := dbClient.ExpectationsWereMet()
-			if err != nil {
-				AbortSuite("some expectations were not met " + err.Error())
-			}
-		})
+				dbClient.
+					ExpectQuery(checkStmt).
+					WithArgs(
+						p.UniqueId,
+					).
+					WillReturnError(gorm.ErrRecordNotFound)
 
-		When("failed find file", func() {
-			It("should return error", func() {
 				dbClient.
-					ExpectQuery(findStmt).
+					ExpectExec(insertStmt).
 					WithArgs(
 						p.UniqueId,
+						p.Path,
+						p.Name,
+						p.Mimetype,
+						p.Extension,
+						p.Size,
+						"",
+						"",
+						p.OwnerClientId,
+						p.CreatedAt.UnixMilli(),
+						p.CreatedAt.UnixMilli(),
+					).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				dbClient.
+					ExpectExec(insertBlobStmt).
+					WithArgs(
+						"mock-sha256",
+						p.Path,
+						int64(1),
+						p.CreatedAt.UnixMilli(),
+						p.CreatedAt.UnixMilli(),
+						p.CreatedAt.UnixMilli(),
 					).
 					WillReturnError(fmt.Errorf("network error"))
 
-				res, err := fileRepo.RetrieveFile(ctx, p)
+				dbClient.
+					ExpectRollback()
+
+				res, err := fileRepo.CreateFile(ctx, p)
 
 				Expect(res).To(BeNil())
 				Expect(err).To(Equal(fmt.Errorf("network error")))
 			})
 		})
 
-		When("file is not available", func() {
+		When("failed execute duplicate callback", func() {
 			It("should return error", func() {
+				p.Deduplicate = true
+				p.DuplicateFn = func(ctx context.Context, p repository.DuplicateFnParam) error {
+					return fmt.Errorf("callback error")
+				}
+
 				dbClient.
-					ExpectQuery(findStmt).
+					ExpectBegin()
+
+				dbClient.
+					ExpectQuery(checkStmt).
+					WithArgs(
+						p.UniqueId,
+					).
+					WillReturnError(gorm.ErrRecordNotFound)
+
+				dbClient.
+					ExpectExec(insertStmt).
+					WithArgs(
+						p.UniqueId,
+						p.Path,
+						p.Name,
+						p.Mimetype,
+						p.Extension,
+						p.Size,
+						"",
+						"",
+						p.OwnerClientId,
+						p.CreatedAt.UnixMilli(),
+						p.CreatedAt.UnixMilli(),
+					).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				dbClient.
+					ExpectExec(insertBlobStmt).
+					WithArgs(
+						"mock-sha256",
+						p.Path,
+						int64(1),
+						p.CreatedAt.UnixMilli(),
+						p.CreatedAt.UnixMilli(),
+						p.CreatedAt.UnixMilli(),
+					).
+					WillReturnResult(sqlmock.NewResult(1, 2))
+
+				blobRows := sqlmock.
+					NewRows([]string{"checksum_sha256", "path", "ref_count"}).
+					AddRow("mock-sha256", "storage/blob", 2)
+				dbClient.
+					ExpectQuery(findBlobStmt).
+					WithArgs(
+						"mock-sha256",
+					).
+					WillReturnRows(blobRows)
+
+				dbClient.
+					ExpectRollback()
+
+				res, err := fileRepo.CreateFile(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("callback error")))
+			})
+		})
+
+		When("success create duplicated file", func() {
+			It("should return result", func() {
+				duplicatePath := ""
+				p.Deduplicate = true
+				p.DuplicateFn = func(ctx context.Context, p repository.DuplicateFnParam) error {
+					duplicatePath = p.FilePath
+					return nil
+				}
+
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectQuery(checkStmt).
+					WithArgs(
+						p.UniqueId,
+					).
+					WillReturnError(gorm.ErrRecordNotFound)
+
+				dbClient.
+					ExpectExec(insertStmt).
+					WithArgs(
+						p.UniqueId,
+						p.Path,
+						p.Name,
+						p.Mimetype,
+						p.Extension,
+						p.Size,
+						"",
+						"",
+						p.OwnerClientId,
+						p.CreatedAt.UnixMilli(),
+						p.CreatedAt.UnixMilli(),
+					).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				dbClient.
+					ExpectExec(insertBlobStmt).
+					WithArgs(
+						"mock-sha256",
+						p.Path,
+						int64(1),
+						p.CreatedAt.UnixMilli(),
+						p.CreatedAt.UnixMilli(),
+						p.CreatedAt.UnixMilli(),
+					).
+					WillReturnResult(sqlmock.NewResult(1, 2))
+
+				blobRows := sqlmock.
+					NewRows([]string{"checksum_sha256", "path", "ref_count"}).
+					AddRow("mock-sha256", "storage/blob", 2)
+				dbClient.
+					ExpectQuery(findBlobStmt).
+					WithArgs(
+						"mock-sha256",
+					).
+					WillReturnRows(blobRows)
+
+				keyRows := sqlmock.
+					NewRows([]string{"encryption_key_id", "encryption_data_key", "stored_size", "compression"}).
+					AddRow("key-1", "blob-data-key", 512, "gzip")
+				dbClient.
+					ExpectQuery(findBlobKeyStmt).
+					WithArgs(
+						"storage/blob",
+						p.UniqueId,
+					).
+					WillReturnRows(keyRows)
+
+				dbClient.
+					ExpectExec(updateStmt).
+					WithArgs(
+						"mock-md5",
+						"mock-sha256",
+						"gzip",
+						"blob-data-key",
+						"key-1",
+						"storage/blob",
+						int64(2048),
+						int64(512),
+						p.CreatedAt.UnixMilli(),
+						p.UniqueId,
+					).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				findRows := sqlmock.
+					NewRows([]string{
+						"id", "name", "path", "mimetype",
+						"extension", "size", "checksum_sha256",
+						"checksum_md5", "created_at",
+					}).
+					AddRow(
+						p.UniqueId,
+						p.Name,
+						"storage/blob",
+						p.Mimetype,
+						p.Extension,
+						2048,
+						"mock-sha256",
+						"mock-md5",
+						p.CreatedAt.UnixMilli(),
+					)
+
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(
+						p.UniqueId,
+					).
+					WillReturnRows(findRows)
+
+				dbClient.
+					ExpectCommit()
+
+				res, err := fileRepo.CreateFile(ctx, p)
+
+				Expect(err).To(BeNil())
+				Expect(duplicatePath).To(Equal(p.Path))
+				Expect(res.Path).To(Equal("storage/blob"))
+			})
+		})
+	})
+
+	Context("RetrieveFile function", Label("unit"), func() {
+		var (
+			ctx       context.Context
+			currentTs time.Time
+			dbClient  sqlmock.Sqlmock
+			fileRepo  repository.File
+			p         repository.RetrieveFileParam
+			r         *repository.RetrieveFileResult
+			findStmt  string
+		)
+
+		BeforeEach(func() {
+			var (
+				db  *sql.DB
+				err error
+			)
+
+			ctx = context.Background()
+			currentTs = time.Now().UTC()
+			db, dbClient, err = sqlmock.New()
+			if err != nil {
+				AbortSuite("failed create db mock: " + err.Error())
+			}
+
+			gormClient, err := gorm.Open(gorm_mysql.New(gorm_mysql.Config{
+				Conn:                      db,
+				SkipInitializeWithVersion: true,
+			}), &gorm.Config{
+				DisableAutomaticPing: true,
+			})
+			if err != nil {
+				AbortSuite("failed create gorm client: " + err.Error())
+			}
+			fileRepo = repository_mysql.NewFile(repository_mysql.FileParam{
+				GormClient: gormClient,
+			})
+
+			p = repository.RetrieveFileParam{
+				UniqueId: "id",
+			}
+			r = &repository.RetrieveFileResult{
+				UniqueId:          "id",
+				CreatedAt:         time.UnixMilli(currentTs.UnixMilli()).UTC(),
+				DeletedAt:         typeconv.Time(time.UnixMilli(currentTs.UnixMilli()).UTC()),
+				ChecksumSha256:    "mock-sha256",
+				ChecksumMd5:       "mock-md5",
+				EncryptionKeyId:   "key-1",
+				EncryptionDataKey: "mock-data-key",
+				StoredSize:        512,
+				Compression:       "gzip",
+				OwnerClientId:     "client-id",
+			}
+			findStmt = regexp.QuoteMeta("SELECT id, name, path, mimetype, extension, size, checksum_sha256, checksum_md5, encryption_key_id, encryption_data_key, stored_size, compression, owner_client_id, created_at, deleted_at FROM `file` WHERE id = ? ORDER BY `file`.`id` LIMIT 1")
+		})
+
+		AfterEach(func() {
+			err := dbClient.ExpectationsWereMet()
+			if err != nil {
+				AbortSuite("some expectations were not met " + err.Error())
+			}
+		})
+
+		When("failed find file", func() {
+			It("should return error", func() {
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(
+						p.UniqueId,
+					).
+					WillReturnError(fmt.Errorf("network error"))
+
+				res, err := fileRepo.RetrieveFile(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("network error")))
+			})
+		})
+
+		When("file is not available", func() {
+			It("should return error", func() {
+				dbClient.
+					ExpectQuery(findStmt).
 					WithArgs(
 						p.UniqueId,
 					).
@@ -755,7 +1091,9 @@ var _ = Describe("File Repository", func() {
 				findRows := sqlmock.
 					NewRows([]string{
 						"id", "name", "path", "mimetype",
-						"extension", "size",
+						"extension", "size", "checksum_sha256", "checksum_md5",
+						"encryption_key_id", "encryption_data_key",
+						"stored_size", "compression", "owner_client_id",
 						"created_at", "deleted_at",
 					}).
 					AddRow(
@@ -765,6 +1103,13 @@ var _ = Describe("File Repository", func() {
 						r.Mimetype,
 						r.Extension,
 						r.Size,
+						r.ChecksumSha256,
+						r.ChecksumMd5,
+						r.EncryptionKeyId,
+						r.EncryptionDataKey,
+						r.StoredSize,
+						r.Compression,
+						r.OwnerClientId,
 						currentTs.UnixMilli(),
 						currentTs.UnixMilli(),
 					)
@@ -786,16 +1131,19 @@ var _ = Describe("File Repository", func() {
 
 	Context("DeleteFile function", Label("unit"), func() {
 		var (
-			ctx        context.Context
-			currentTs  time.Time
-			dbClient   sqlmock.Sqlmock
-			fileRepo   repository.File
-			p          repository.DeleteFileParam
-			findStmt   string
-			deleteStmt string
-			checkStmt  string
-			findRows   *sqlmock.Rows
-			checkRows  *sqlmock.Rows
+			ctx            context.Context
+			currentTs      time.Time
+			dbClient       sqlmock.Sqlmock
+			fileRepo       repository.File
+			p              repository.DeleteFileParam
+			findStmt       string
+			deleteStmt     string
+			checkStmt      string
+			findRows       *sqlmock.Rows
+			checkRows      *sqlmock.Rows
+			findBlobStmt   string
+			updateBlobStmt string
+			deleteBlobStmt string
 		)
 
 		BeforeEach(func() {
@@ -831,9 +1179,12 @@ var _ = Describe("File Repository", func() {
 					return nil
 				},
 			}
-			findStmt = regexp.QuoteMeta("SELECT id, deleted_at FROM `file` WHERE id = ? ORDER BY `file`.`id` LIMIT 1")
+			findStmt = regexp.QuoteMeta("SELECT id, deleted_at FROM `file` WHERE id = ? ORDER BY `file`.`id` LIMIT 1 FOR UPDATE")
 			deleteStmt = regexp.QuoteMeta("UPDATE `file` SET `deleted_at`=?,`updated_at`=? WHERE id = ?")
-			checkStmt = regexp.QuoteMeta("SELECT id, path, deleted_at FROM `file` WHERE id = ? ORDER BY `file`.`id")
+			checkStmt = regexp.QuoteMeta("SELECT id, path, size, checksum_sha256, owner_client_id, deleted_at FROM `file` WHERE id = ? ORDER BY `file`.`id")
+			findBlobStmt = regexp.QuoteMeta("SELECT checksum_sha256, path, ref_count FROM `file_blob` WHERE checksum_sha256 = ? AND path = ? LIMIT 1 FOR UPDATE")
+			updateBlobStmt = regexp.QuoteMeta("UPDATE `file_blob` SET `ref_count`=ref_count - 1,`updated_at`=? WHERE checksum_sha256 = ?")
+			deleteBlobStmt = regexp.QuoteMeta("DELETE FROM `file_blob` WHERE checksum_sha256 = ?")
 			findRows = sqlmock.
 				NewRows([]string{"id", "deleted_at"}).
 				AddRow("id", nil)
@@ -1218,8 +1569,60 @@ var _ = Describe("File Repository", func() {
 			})
 		})
 
-		When("success delete file", func() {
+		When("failed dereference file blob", func() {
+			It("should return error", func() {
+				checkRows = sqlmock.
+					NewRows([]string{"id", "path", "checksum_sha256", "deleted_at"}).
+					AddRow("id", "path", "sha256", currentTs.UnixMilli())
+
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.UniqueId).
+					WillReturnRows(findRows)
+
+				dbClient.
+					ExpectExec(deleteStmt).
+					WithArgs(
+						p.DeletedAt.UnixMilli(),
+						p.DeletedAt.UnixMilli(),
+						p.UniqueId,
+					).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				dbClient.
+					ExpectQuery(checkStmt).
+					WithArgs(p.UniqueId).
+					WillReturnRows(checkRows)
+
+				dbClient.
+					ExpectQuery(findBlobStmt).
+					WithArgs("sha256", "path").
+					WillReturnError(fmt.Errorf("network error"))
+
+				dbClient.
+					ExpectRollback()
+
+				res, err := fileRepo.DeleteFile(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("network error")))
+			})
+		})
+
+		When("success delete last reference of deduplicated file", func() {
 			It("should return result", func() {
+				references := int64(-1)
+				p.DeleteFn = func(ctx context.Context, p repository.DeleteFnParam) error {
+					references = p.References
+					return nil
+				}
+				checkRows = sqlmock.
+					NewRows([]string{"id", "path", "checksum_sha256", "deleted_at"}).
+					AddRow("id", "path", "sha256", currentTs.UnixMilli())
+
 				dbClient.
 					ExpectBegin()
 
@@ -1242,16 +1645,1612 @@ var _ = Describe("File Repository", func() {
 					WithArgs(p.UniqueId).
 					WillReturnRows(checkRows)
 
+				blobRows := sqlmock.
+					NewRows([]string{"checksum_sha256", "path", "ref_count"}).
+					AddRow("sha256", "path", 1)
+				dbClient.
+					ExpectQuery(findBlobStmt).
+					WithArgs("sha256", "path").
+					WillReturnRows(blobRows)
+
+				dbClient.
+					ExpectExec(deleteBlobStmt).
+					WithArgs("sha256").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
 				dbClient.
 					ExpectCommit()
 
 				res, err := fileRepo.DeleteFile(ctx, p)
 
 				Expect(err).To(BeNil())
+				Expect(references).To(Equal(int64(0)))
 				Expect(res).To(Equal(&repository.DeleteFileResult{
 					DeletedAt: time.UnixMilli(currentTs.UnixMilli()).UTC(),
 				}))
 			})
 		})
+
+		When("success delete shared deduplicated file", func() {
+			It("should return result", func() {
+				references := int64(-1)
+				p.DeleteFn = func(ctx context.Context, p repository.DeleteFnParam) error {
+					references = p.References
+					return nil
+				}
+				checkRows = sqlmock.
+					NewRows([]string{"id", "path", "checksum_sha256", "deleted_at"}).
+					AddRow("id", "path", "sha256", currentTs.UnixMilli())
+
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.UniqueId).
+					WillReturnRows(findRows)
+
+				dbClient.
+					ExpectExec(deleteStmt).
+					WithArgs(
+						p.DeletedAt.UnixMilli(),
+						p.DeletedAt.UnixMilli(),
+						p.UniqueId,
+					).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				dbClient.
+					ExpectQuery(checkStmt).
+					WithArgs(p.UniqueId).
+					WillReturnRows(checkRows)
+
+				blobRows := sqlmock.
+					NewRows([]string{"checksum_sha256", "path", "ref_count"}).
+					AddRow("sha256", "path", 3)
+				dbClient.
+					ExpectQuery(findBlobStmt).
+					WithArgs("sha256", "path").
+					WillReturnRows(blobRows)
+
+				dbClient.
+					ExpectExec(updateBlobStmt).
+					WithArgs(p.DeletedAt.UnixMilli(), "sha256").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				dbClient.
+					ExpectCommit()
+
+				res, err := fileRepo.DeleteFile(ctx, p)
+
+				Expect(err).To(BeNil())
+				Expect(references).To(Equal(int64(2)))
+				Expect(res).To(Equal(&repository.DeleteFileResult{
+					DeletedAt: time.UnixMilli(currentTs.UnixMilli()).UTC(),
+				}))
+			})
+		})
+
+		When("owned file is deleted", func() {
+			It("should release the owner usage", func() {
+				dailyAt := currentTs.Truncate(24 * time.Hour)
+				checkRows = sqlmock.
+					NewRows([]string{"id", "path", "size", "owner_client_id", "deleted_at"}).
+					AddRow("id", "path", 100, "client-record-id", currentTs.UnixMilli())
+				findUsageStmt := regexp.QuoteMeta("SELECT id, quota_max_stored_size, quota_max_total_files, quota_max_file_size, quota_max_daily_uploads, usage_stored_size, usage_total_files, usage_daily_uploads, usage_daily_at FROM `auth_client` WHERE id = ? AND deleted_at = 0 ORDER BY `auth_client`.`id` LIMIT 1 FOR UPDATE")
+				updateUsageStmt := regexp.QuoteMeta("UPDATE `auth_client` SET `usage_daily_at`=?,`usage_daily_uploads`=?,`usage_stored_size`=?,`usage_total_files`=? WHERE id = ?")
+
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.UniqueId).
+					WillReturnRows(findRows)
+
+				dbClient.
+					ExpectExec(deleteStmt).
+					WithArgs(
+						p.DeletedAt.UnixMilli(),
+						p.DeletedAt.UnixMilli(),
+						p.UniqueId,
+					).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				dbClient.
+					ExpectQuery(checkStmt).
+					WithArgs(p.UniqueId).
+					WillReturnRows(checkRows)
+
+				dbClient.
+					ExpectQuery(findUsageStmt).
+					WithArgs("client-record-id").
+					WillReturnRows(sqlmock.NewRows([]string{
+						"id",
+						"quota_max_stored_size", "quota_max_total_files",
+						"quota_max_file_size", "quota_max_daily_uploads",
+						"usage_stored_size", "usage_total_files",
+						"usage_daily_uploads", "usage_daily_at",
+					}).AddRow(
+						"client-record-id",
+						1024, 10, 512, 5,
+						256, 2, 1, dailyAt.UnixMilli(),
+					))
+
+				dbClient.
+					ExpectExec(updateUsageStmt).
+					WithArgs(dailyAt.UnixMilli(), 1, 156, 1, "client-record-id").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				dbClient.
+					ExpectCommit()
+
+				res, err := fileRepo.DeleteFile(ctx, p)
+
+				Expect(err).To(BeNil())
+				Expect(res).To(Equal(&repository.DeleteFileResult{
+					DeletedAt: time.UnixMilli(currentTs.UnixMilli()).UTC(),
+				}))
+			})
+		})
+
+		When("failed release the owner usage", func() {
+			It("should return error", func() {
+				checkRows = sqlmock.
+					NewRows([]string{"id", "path", "size", "owner_client_id", "deleted_at"}).
+					AddRow("id", "path", 100, "client-record-id", currentTs.UnixMilli())
+				findUsageStmt := regexp.QuoteMeta("SELECT id, quota_max_stored_size, quota_max_total_files, quota_max_file_size, quota_max_daily_uploads, usage_stored_size, usage_total_files, usage_daily_uploads, usage_daily_at FROM `auth_client` WHERE id = ? AND deleted_at = 0 ORDER BY `auth_client`.`id` LIMIT 1 FOR UPDATE")
+
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.UniqueId).
+					WillReturnRows(findRows)
+
+				dbClient.
+					ExpectExec(deleteStmt).
+					WithArgs(
+						p.DeletedAt.UnixMilli(),
+						p.DeletedAt.UnixMilli(),
+						p.UniqueId,
+					).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				dbClient.
+					ExpectQuery(checkStmt).
+					WithArgs(p.UniqueId).
+					WillReturnRows(checkRows)
+
+				dbClient.
+					ExpectQuery(findUsageStmt).
+					WithArgs("client-record-id").
+					WillReturnError(fmt.Errorf("network error"))
+
+				dbClient.
+					ExpectRollback()
+
+				res, err := fileRepo.DeleteFile(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("network error")))
+			})
+		})
+
+		When("owner client is already deleted", func() {
+			It("should return result", func() {
+				checkRows = sqlmock.
+					NewRows([]string{"id", "path", "size", "owner_client_id", "deleted_at"}).
+					AddRow("id", "path", 100, "client-record-id", currentTs.UnixMilli())
+				findUsageStmt := regexp.QuoteMeta("SELECT id, quota_max_stored_size, quota_max_total_files, quota_max_file_size, quota_max_daily_uploads, usage_stored_size, usage_total_files, usage_daily_uploads, usage_daily_at FROM `auth_client` WHERE id = ? AND deleted_at = 0 ORDER BY `auth_client`.`id` LIMIT 1 FOR UPDATE")
+
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.UniqueId).
+					WillReturnRows(findRows)
+
+				dbClient.
+					ExpectExec(deleteStmt).
+					WithArgs(
+						p.DeletedAt.UnixMilli(),
+						p.DeletedAt.UnixMilli(),
+						p.UniqueId,
+					).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				dbClient.
+					ExpectQuery(checkStmt).
+					WithArgs(p.UniqueId).
+					WillReturnRows(checkRows)
+
+				dbClient.
+					ExpectQuery(findUsageStmt).
+					WithArgs("client-record-id").
+					WillReturnError(gorm.ErrRecordNotFound)
+
+				dbClient.
+					ExpectCommit()
+
+				res, err := fileRepo.DeleteFile(ctx, p)
+
+				Expect(err).To(BeNil())
+				Expect(res).To(Equal(&repository.DeleteFileResult{
+					DeletedAt: time.UnixMilli(currentTs.UnixMilli()).UTC(),
+				}))
+			})
+		})
+
+		When("success delete file", func() {
+			It("should return result", func() {
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.UniqueId).
+					WillReturnRows(findRows)
+
+				dbClient.
+					ExpectExec(deleteStmt).
+					WithArgs(
+						p.DeletedAt.UnixMilli(),
+						p.DeletedAt.UnixMilli(),
+						p.UniqueId,
+					).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				dbClient.
+					ExpectQuery(checkStmt).
+					WithArgs(p.UniqueId).
+					WillReturnRows(checkRows)
+
+				dbClient.
+					ExpectCommit()
+
+				res, err := fileRepo.DeleteFile(ctx, p)
+
+				Expect(err).To(BeNil())
+				Expect(res).To(Equal(&repository.DeleteFileResult{
+					DeletedAt: time.UnixMilli(currentTs.UnixMilli()).UTC(),
+				}))
+			})
+		})
+	})
+
+	Context("RestoreFile function", Label("unit"), func() {
+		var (
+			ctx            context.Context
+			currentTs      time.Time
+			dbClient       sqlmock.Sqlmock
+			fileRepo       repository.File
+			p              repository.RestoreFileParam
+			findStmt       string
+			restoreStmt    string
+			findBlobStmt   string
+			insertBlobStmt string
+			updateBlobStmt string
+			findRows       *sqlmock.Rows
+			restoreFnParam *repository.RestoreFnParam
+		)
+
+		BeforeEach(func() {
+			var (
+				db  *sql.DB
+				err error
+			)
+
+			ctx = context.Background()
+			currentTs = time.Now().UTC()
+			db, dbClient, err = sqlmock.New()
+			if err != nil {
+				AbortSuite("failed create db mock: " + err.Error())
+			}
+
+			gormClient, err := gorm.Open(gorm_mysql.New(gorm_mysql.Config{
+				Conn:                      db,
+				SkipInitializeWithVersion: true,
+			}), &gorm.Config{
+				DisableAutomaticPing: true,
+			})
+			if err != nil {
+				AbortSuite("failed create gorm client: " + err.Error())
+			}
+			fileRepo = repository_mysql.NewFile(repository_mysql.FileParam{
+				GormClient: gormClient,
+			})
+
+			restoreFnParam = nil
+			p = repository.RestoreFileParam{
+				UniqueId:    "id",
+				RestoredAt:  currentTs,
+				Deduplicate: true,
+				RestoreFn: func(ctx context.Context, p repository.RestoreFnParam) error {
+					restoreFnParam = &p
+					return nil
+				},
+			}
+			findStmt = regexp.QuoteMeta("SELECT id, path, size, checksum_sha256, owner_client_id, deleted_at FROM `file` WHERE id = ? ORDER BY `file`.`id` LIMIT 1 FOR UPDATE")
+			restoreStmt = regexp.QuoteMeta("UPDATE `file` SET `deleted_at`=?,`updated_at`=? WHERE id = ?")
+			findBlobStmt = regexp.QuoteMeta("SELECT checksum_sha256, path, ref_count FROM `file_blob` WHERE checksum_sha256 = ? LIMIT 1 FOR UPDATE")
+			insertBlobStmt = regexp.QuoteMeta("INSERT INTO `file_blob` (`checksum_sha256`,`path`,`ref_count`,`created_at`,`updated_at`) VALUES (?,?,?,?,?)")
+			updateBlobStmt = regexp.QuoteMeta("UPDATE `file_blob` SET `ref_count`=ref_count + 1,`updated_at`=? WHERE checksum_sha256 = ?")
+			findRows = sqlmock.
+				NewRows([]string{"id", "path", "checksum_sha256", "deleted_at"}).
+				AddRow("id", "path", "sha256", currentTs.UnixMilli())
+		})
+
+		AfterEach(func() {
+			err := dbClient.ExpectationsWereMet()
+			if err != nil {
+				AbortSuite("some expectations were not met " + err.Error())
+			}
+		})
+
+		When("failed begin trx", func() {
+			It("should return error", func() {
+				dbClient.
+					ExpectBegin().
+					WillReturnError(fmt.Errorf("begin error"))
+
+				res, err := fileRepo.RestoreFile(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("begin error")))
+			})
+		})
+
+		When("failed find file", func() {
+			It("should return error", func() {
+				dbClient.ExpectBegin()
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.UniqueId).
+					WillReturnError(fmt.Errorf("network error"))
+				dbClient.ExpectRollback()
+
+				res, err := fileRepo.RestoreFile(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("network error")))
+			})
+		})
+
+		When("failed rollback during find file", func() {
+			It("should return error", func() {
+				dbClient.ExpectBegin()
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.UniqueId).
+					WillReturnError(fmt.Errorf("network error"))
+				dbClient.
+					ExpectRollback().
+					WillReturnError(fmt.Errorf("rollback error"))
+
+				res, err := fileRepo.RestoreFile(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("rollback error")))
+			})
+		})
+
+		When("file is not found", func() {
+			It("should return error", func() {
+				dbClient.ExpectBegin()
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.UniqueId).
+					WillReturnError(gorm.ErrRecordNotFound)
+				dbClient.ExpectRollback()
+
+				res, err := fileRepo.RestoreFile(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(repository.ErrNotFound))
+			})
+		})
+
+		When("file is not deleted", func() {
+			It("should return error", func() {
+				rows := sqlmock.
+					NewRows([]string{"id", "path", "checksum_sha256", "deleted_at"}).
+					AddRow("id", "path", "sha256", nil)
+
+				dbClient.ExpectBegin()
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.UniqueId).
+					WillReturnRows(rows)
+				dbClient.ExpectRollback()
+
+				res, err := fileRepo.RestoreFile(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(repository.ErrNotDeleted))
+			})
+		})
+
+		When("failed restore file", func() {
+			It("should return error", func() {
+				dbClient.ExpectBegin()
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.UniqueId).
+					WillReturnRows(findRows)
+				dbClient.
+					ExpectExec(restoreStmt).
+					WithArgs(nil, currentTs.UnixMilli(), p.UniqueId).
+					WillReturnError(fmt.Errorf("network error"))
+				dbClient.ExpectRollback()
+
+				res, err := fileRepo.RestoreFile(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("network error")))
+			})
+		})
+
+		When("failed find blob", func() {
+			It("should return error", func() {
+				dbClient.ExpectBegin()
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.UniqueId).
+					WillReturnRows(findRows)
+				dbClient.
+					ExpectExec(restoreStmt).
+					WithArgs(nil, currentTs.UnixMilli(), p.UniqueId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				dbClient.
+					ExpectQuery(findBlobStmt).
+					WithArgs("sha256").
+					WillReturnError(fmt.Errorf("network error"))
+				dbClient.ExpectRollback()
+
+				res, err := fileRepo.RestoreFile(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("network error")))
+			})
+		})
+
+		When("content is no longer referenced", func() {
+			It("should register the content", func() {
+				dbClient.ExpectBegin()
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.UniqueId).
+					WillReturnRows(findRows)
+				dbClient.
+					ExpectExec(restoreStmt).
+					WithArgs(nil, currentTs.UnixMilli(), p.UniqueId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				dbClient.
+					ExpectQuery(findBlobStmt).
+					WithArgs("sha256").
+					WillReturnRows(sqlmock.NewRows([]string{"checksum_sha256", "path", "ref_count"}))
+				dbClient.
+					ExpectExec(insertBlobStmt).
+					WithArgs("sha256", "path", 1, currentTs.UnixMilli(), currentTs.UnixMilli()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				dbClient.ExpectCommit()
+
+				res, err := fileRepo.RestoreFile(ctx, p)
+
+				Expect(res).To(Equal(&repository.RestoreFileResult{
+					RestoredAt: currentTs,
+				}))
+				Expect(err).To(BeNil())
+				Expect(restoreFnParam).To(Equal(&repository.RestoreFnParam{
+					FilePath:   "path",
+					References: 0,
+				}))
+			})
+		})
+
+		When("content is no longer referenced and deduplication is disabled", func() {
+			It("should not register the content", func() {
+				p.Deduplicate = false
+				dbClient.ExpectBegin()
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.UniqueId).
+					WillReturnRows(findRows)
+				dbClient.
+					ExpectExec(restoreStmt).
+					WithArgs(nil, currentTs.UnixMilli(), p.UniqueId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				dbClient.
+					ExpectQuery(findBlobStmt).
+					WithArgs("sha256").
+					WillReturnRows(sqlmock.NewRows([]string{"checksum_sha256", "path", "ref_count"}))
+				dbClient.ExpectCommit()
+
+				res, err := fileRepo.RestoreFile(ctx, p)
+
+				Expect(res).ToNot(BeNil())
+				Expect(err).To(BeNil())
+				Expect(restoreFnParam.References).To(Equal(int64(0)))
+			})
+		})
+
+		When("content is stored in another path", func() {
+			It("should not link the file", func() {
+				dbClient.ExpectBegin()
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.UniqueId).
+					WillReturnRows(findRows)
+				dbClient.
+					ExpectExec(restoreStmt).
+					WithArgs(nil, currentTs.UnixMilli(), p.UniqueId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				dbClient.
+					ExpectQuery(findBlobStmt).
+					WithArgs("sha256").
+					WillReturnRows(sqlmock.
+						NewRows([]string{"checksum_sha256", "path", "ref_count"}).
+						AddRow("sha256", "other-path", 2))
+				dbClient.ExpectCommit()
+
+				res, err := fileRepo.RestoreFile(ctx, p)
+
+				Expect(res).ToNot(BeNil())
+				Expect(err).To(BeNil())
+				Expect(restoreFnParam.References).To(Equal(int64(0)))
+			})
+		})
+
+		When("failed restore content", func() {
+			It("should return error", func() {
+				p.RestoreFn = func(ctx context.Context, p repository.RestoreFnParam) error {
+					return fmt.Errorf("disk error")
+				}
+				dbClient.ExpectBegin()
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.UniqueId).
+					WillReturnRows(findRows)
+				dbClient.
+					ExpectExec(restoreStmt).
+					WithArgs(nil, currentTs.UnixMilli(), p.UniqueId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				dbClient.
+					ExpectQuery(findBlobStmt).
+					WithArgs("sha256").
+					WillReturnRows(sqlmock.
+						NewRows([]string{"checksum_sha256", "path", "ref_count"}).
+						AddRow("sha256", "path", 2))
+				dbClient.
+					ExpectExec(updateBlobStmt).
+					WithArgs(currentTs.UnixMilli(), "sha256").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				dbClient.ExpectRollback()
+
+				res, err := fileRepo.RestoreFile(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("disk error")))
+			})
+		})
+
+		When("failed commit trx", func() {
+			It("should return error", func() {
+				dbClient.ExpectBegin()
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.UniqueId).
+					WillReturnRows(findRows)
+				dbClient.
+					ExpectExec(restoreStmt).
+					WithArgs(nil, currentTs.UnixMilli(), p.UniqueId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				dbClient.
+					ExpectQuery(findBlobStmt).
+					WithArgs("sha256").
+					WillReturnRows(sqlmock.
+						NewRows([]string{"checksum_sha256", "path", "ref_count"}).
+						AddRow("sha256", "path", 2))
+				dbClient.
+					ExpectExec(updateBlobStmt).
+					WithArgs(currentTs.UnixMilli(), "sha256").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				dbClient.
+					ExpectCommit().
+					WillReturnError(fmt.Errorf("commit error"))
+
+				res, err := fileRepo.RestoreFile(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("commit error")))
+			})
+		})
+
+		When("restored file exceeds the owner quota", func() {
+			It("should return error", func() {
+				dailyAt := currentTs.Truncate(24 * time.Hour)
+				findRows = sqlmock.
+					NewRows([]string{"id", "path", "size", "checksum_sha256", "owner_client_id", "deleted_at"}).
+					AddRow("id", "path", 900, "sha256", "client-record-id", currentTs.UnixMilli())
+				findUsageStmt := regexp.QuoteMeta("SELECT id, quota_max_stored_size, quota_max_total_files, quota_max_file_size, quota_max_daily_uploads, usage_stored_size, usage_total_files, usage_daily_uploads, usage_daily_at FROM `auth_client` WHERE id = ? AND deleted_at = 0 ORDER BY `auth_client`.`id` LIMIT 1 FOR UPDATE")
+
+				dbClient.ExpectBegin()
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.UniqueId).
+					WillReturnRows(findRows)
+				dbClient.
+					ExpectExec(restoreStmt).
+					WithArgs(nil, currentTs.UnixMilli(), p.UniqueId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				dbClient.
+					ExpectQuery(findUsageStmt).
+					WithArgs("client-record-id").
+					WillReturnRows(sqlmock.NewRows([]string{
+						"id",
+						"quota_max_stored_size", "quota_max_total_files",
+						"quota_max_file_size", "quota_max_daily_uploads",
+						"usage_stored_size", "usage_total_files",
+						"usage_daily_uploads", "usage_daily_at",
+					}).AddRow(
+						"client-record-id",
+						1024, 10, 512, 5,
+						256, 2, 1, dailyAt.UnixMilli(),
+					))
+				dbClient.ExpectRollback()
+
+				res, err := fileRepo.RestoreFile(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(MatchError(repository.ErrExceeded))
+			})
+		})
+
+		When("owned file is restored", func() {
+			It("should add the owner usage", func() {
+				dailyAt := currentTs.Truncate(24 * time.Hour)
+				findRows = sqlmock.
+					NewRows([]string{"id", "path", "size", "checksum_sha256", "owner_client_id", "deleted_at"}).
+					AddRow("id", "path", 100, "sha256", "client-record-id", currentTs.UnixMilli())
+				findUsageStmt := regexp.QuoteMeta("SELECT id, quota_max_stored_size, quota_max_total_files, quota_max_file_size, quota_max_daily_uploads, usage_stored_size, usage_total_files, usage_daily_uploads, usage_daily_at FROM `auth_client` WHERE id = ? AND deleted_at = 0 ORDER BY `auth_client`.`id` LIMIT 1 FOR UPDATE")
+				updateUsageStmt := regexp.QuoteMeta("UPDATE `auth_client` SET `usage_daily_at`=?,`usage_daily_uploads`=?,`usage_stored_size`=?,`usage_total_files`=? WHERE id = ?")
+
+				dbClient.ExpectBegin()
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.UniqueId).
+					WillReturnRows(findRows)
+				dbClient.
+					ExpectExec(restoreStmt).
+					WithArgs(nil, currentTs.UnixMilli(), p.UniqueId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				dbClient.
+					ExpectQuery(findUsageStmt).
+					WithArgs("client-record-id").
+					WillReturnRows(sqlmock.NewRows([]string{
+						"id",
+						"quota_max_stored_size", "quota_max_total_files",
+						"quota_max_file_size", "quota_max_daily_uploads",
+						"usage_stored_size", "usage_total_files",
+						"usage_daily_uploads", "usage_daily_at",
+					}).AddRow(
+						"client-record-id",
+						1024, 10, 512, 5,
+						256, 2, 1, dailyAt.UnixMilli(),
+					))
+				dbClient.
+					ExpectExec(updateUsageStmt).
+					WithArgs(dailyAt.UnixMilli(), 1, 356, 3, "client-record-id").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				dbClient.
+					ExpectQuery(findBlobStmt).
+					WithArgs("sha256").
+					WillReturnRows(sqlmock.
+						NewRows([]string{"checksum_sha256", "path", "ref_count"}).
+						AddRow("sha256", "path", 2))
+				dbClient.
+					ExpectExec(updateBlobStmt).
+					WithArgs(currentTs.UnixMilli(), "sha256").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				dbClient.ExpectCommit()
+
+				res, err := fileRepo.RestoreFile(ctx, p)
+
+				Expect(res).To(Equal(&repository.RestoreFileResult{
+					RestoredAt: currentTs,
+				}))
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("content is shared with other files", func() {
+			It("should add the file reference", func() {
+				dbClient.ExpectBegin()
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.UniqueId).
+					WillReturnRows(findRows)
+				dbClient.
+					ExpectExec(restoreStmt).
+					WithArgs(nil, currentTs.UnixMilli(), p.UniqueId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				dbClient.
+					ExpectQuery(findBlobStmt).
+					WithArgs("sha256").
+					WillReturnRows(sqlmock.
+						NewRows([]string{"checksum_sha256", "path", "ref_count"}).
+						AddRow("sha256", "path", 2))
+				dbClient.
+					ExpectExec(updateBlobStmt).
+					WithArgs(currentTs.UnixMilli(), "sha256").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				dbClient.ExpectCommit()
+
+				res, err := fileRepo.RestoreFile(ctx, p)
+
+				Expect(res).To(Equal(&repository.RestoreFileResult{
+					RestoredAt: currentTs,
+				}))
+				Expect(err).To(BeNil())
+				Expect(restoreFnParam).To(Equal(&repository.RestoreFnParam{
+					FilePath:   "path",
+					References: 2,
+				}))
+			})
+		})
+	})
+
+	Context("SearchFile function", Label("unit"), func() {
+		var (
+			ctx        context.Context
+			currentTs  time.Time
+			dbClient   sqlmock.Sqlmock
+			fileRepo   repository.File
+			p          repository.SearchFileParam
+			searchStmt string
+			countStmt  string
+			searchRows *sqlmock.Rows
+			countRows  *sqlmock.Rows
+			args       []driver.Value
+		)
+
+		BeforeEach(func() {
+			var (
+				db  *sql.DB
+				err error
+			)
+
+			ctx = context.Background()
+			currentTs = time.Now().UTC()
+			db, dbClient, err = sqlmock.New()
+			if err != nil {
+				AbortSuite("failed create db mock: " + err.Error())
+			}
+
+			gormClient, err := gorm.Open(gorm_mysql.New(gorm_mysql.Config{
+				Conn:                      db,
+				SkipInitializeWithVersion: true,
+			}), &gorm.Config{
+				DisableAutomaticPing: true,
+			})
+			if err != nil {
+				AbortSuite("failed create gorm client: " + err.Error())
+			}
+			fileRepo = repository_mysql.NewFile(repository_mysql.FileParam{
+				GormClient: gormClient,
+			})
+
+			p = repository.SearchFileParam{
+				Limit:        24,
+				Offset:       48,
+				Keyword:      "dolpin",
+				Mimetypes:    []string{"image/jpeg", "image/png"},
+				Extensions:   []string{"jpg"},
+				MinSize:      typeconv.Int64(100),
+				MaxSize:      typeconv.Int64(200),
+				UploadedFrom: typeconv.Time(time.UnixMilli(1000).UTC()),
+				UploadedTo:   typeconv.Time(time.UnixMilli(2000).UTC()),
+				Statuses:     []string{"available"},
+				SortBy:       "uploaded_at",
+				SortOrder:    "desc",
+			}
+			searchStmt = regexp.QuoteMeta(strings.TrimSpace(`
+				SELECT id, name, path, mimetype, extension, size, checksum_sha256, checksum_md5, encryption_key_id, encryption_data_key, stored_size, compression, created_at, deleted_at
+				FROM ` + "`file`" + `
+				WHERE name LIKE ?
+				AND mimetype IN (?,?)
+				AND extension IN (?)
+				AND size >= ?
+				AND size <= ?
+				AND created_at >= ?
+				AND created_at <= ?
+				AND deleted_at IS NULL
+				ORDER BY ` + "`created_at`" + ` DESC
+				LIMIT 24
+				OFFSET 48
+			`))
+			countStmt = regexp.QuoteMeta(strings.TrimSpace(`
+				SELECT count(*)
+				FROM ` + "`file`" + `
+				WHERE name LIKE ?
+				AND mimetype IN (?,?)
+				AND extension IN (?)
+				AND size >= ?
+				AND size <= ?
+				AND created_at >= ?
+				AND created_at <= ?
+				AND deleted_at IS NULL
+			`))
+			searchRows = sqlmock.NewRows([]string{
+				"id", "name", "path",
+				"mimetype", "extension", "size",
+				"checksum_sha256", "checksum_md5",
+				"created_at", "deleted_at",
+			}).AddRow(
+				"id-1", "dolpin-1", "/storage/id-1.jpg",
+				"image/jpeg", "jpg", 100,
+				"sha256-1", "md5-1",
+				currentTs.UnixMilli(), nil,
+			).AddRow(
+				"id-2", "dolpin-2", "/storage/id-2.jpg",
+				"image/png", "jpg", 200,
+				"sha256-2", "",
+				currentTs.UnixMilli(), nil,
+			)
+			countRows = sqlmock.
+				NewRows([]string{"count(*)"}).
+				AddRow(2)
+			args = []driver.Value{
+				"%dolpin%", "image/jpeg", "image/png", "jpg",
+				int64(100), int64(200), int64(1000), int64(2000),
+			}
+		})
+
+		AfterEach(func() {
+			err := dbClient.ExpectationsWereMet()
+			if err != nil {
+				AbortSuite("some expectations were not met " + err.Error())
+			}
+		})
+
+		When("failed count search file", func() {
+			It("should return error", func() {
+				dbClient.
+					ExpectQuery(countStmt).
+					WithArgs(args...).
+					WillReturnError(fmt.Errorf("network error"))
+
+				res, err := fileRepo.SearchFile(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("network error")))
+			})
+		})
+
+		When("failed search file", func() {
+			It("should return error", func() {
+				dbClient.
+					ExpectQuery(countStmt).
+					WithArgs(args...).
+					WillReturnRows(countRows)
+
+				dbClient.
+					ExpectQuery(searchStmt).
+					WithArgs(args...).
+					WillReturnError(fmt.Errorf("network error"))
+
+				res, err := fileRepo.SearchFile(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("network error")))
+			})
+		})
+
+		When("there is no file", func() {
+			It("should return empty result", func() {
+				countRows := sqlmock.
+					NewRows([]string{"count(*)"}).
+					AddRow(0)
+				dbClient.
+					ExpectQuery(countStmt).
+					WithArgs(args...).
+					WillReturnRows(countRows)
+
+				dbClient.
+					ExpectQuery(searchStmt).
+					WithArgs(args...).
+					WillReturnError(gorm.ErrRecordNotFound)
+
+				res, err := fileRepo.SearchFile(ctx, p)
+
+				r := &repository.SearchFileResult{
+					Summary: repository.SearchFileSummary{
+						TotalItems: 0,
+					},
+					Items: []repository.SearchFileItem{},
+				}
+				Expect(res).To(Equal(r))
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("searching deleted file only", func() {
+			It("should return result", func() {
+				p = repository.SearchFileParam{
+					Statuses:  []string{"deleted"},
+					SortBy:    "name",
+					SortOrder: "asc",
+				}
+				countStmt := regexp.QuoteMeta(strings.TrimSpace(`
+					SELECT count(*)
+					FROM ` + "`file`" + `
+					WHERE deleted_at IS NOT NULL
+				`))
+				searchStmt := regexp.QuoteMeta(strings.TrimSpace(`
+					SELECT id, name, path, mimetype, extension, size, checksum_sha256, checksum_md5, encryption_key_id, encryption_data_key, stored_size, compression, created_at, deleted_at
+					FROM ` + "`file`" + `
+					WHERE deleted_at IS NOT NULL
+					ORDER BY ` + "`name`" + `
+				`))
+				countRows := sqlmock.
+					NewRows([]string{"count(*)"}).
+					AddRow(1)
+				searchRows := sqlmock.NewRows([]string{
+					"id", "name", "path",
+					"mimetype", "extension", "size",
+					"checksum_sha256", "checksum_md5",
+					"created_at", "deleted_at",
+				}).AddRow(
+					"id-1", "dolpin-1", "/storage/id-1.jpg",
+					"image/jpeg", "jpg", 100,
+					"sha256-1", "md5-1",
+					currentTs.UnixMilli(), currentTs.UnixMilli(),
+				)
+				dbClient.
+					ExpectQuery(countStmt).
+					WillReturnRows(countRows)
+
+				dbClient.
+					ExpectQuery(searchStmt).
+					WillReturnRows(searchRows)
+
+				res, err := fileRepo.SearchFile(ctx, p)
+
+				r := &repository.SearchFileResult{
+					Summary: repository.SearchFileSummary{
+						TotalItems: 1,
+					},
+					Items: []repository.SearchFileItem{
+						{
+							UniqueId:       "id-1",
+							Name:           "dolpin-1",
+							Path:           "/storage/id-1.jpg",
+							Mimetype:       "image/jpeg",
+							Extension:      "jpg",
+							Size:           100,
+							CreatedAt:      time.UnixMilli(currentTs.UnixMilli()).UTC(),
+							DeletedAt:      typeconv.Time(time.UnixMilli(currentTs.UnixMilli()).UTC()),
+							ChecksumSha256: "sha256-1",
+							ChecksumMd5:    "md5-1",
+						},
+					},
+				}
+				Expect(res).To(Equal(r))
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("searching owned file only", func() {
+			It("should filter by the owner", func() {
+				p = repository.SearchFileParam{
+					OwnerClientIds: []string{"id", ""},
+				}
+				countStmt := regexp.QuoteMeta(strings.TrimSpace(`
+					SELECT count(*)
+					FROM ` + "`file`" + `
+					WHERE owner_client_id IN (?,?)
+				`))
+				searchStmt := regexp.QuoteMeta(strings.TrimSpace(`
+					SELECT id, name, path, mimetype, extension, size, checksum_sha256, checksum_md5, encryption_key_id, encryption_data_key, stored_size, compression, created_at, deleted_at
+					FROM ` + "`file`" + `
+					WHERE owner_client_id IN (?,?)
+				`))
+				countRows := sqlmock.
+					NewRows([]string{"count(*)"}).
+					AddRow(0)
+				dbClient.
+					ExpectQuery(countStmt).
+					WithArgs("id", "").
+					WillReturnRows(countRows)
+
+				dbClient.
+					ExpectQuery(searchStmt).
+					WithArgs("id", "").
+					WillReturnError(gorm.ErrRecordNotFound)
+
+				res, err := fileRepo.SearchFile(ctx, p)
+
+				Expect(res.Summary.TotalItems).To(Equal(int64(0)))
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("searching by path after the id", func() {
+			It("should filter by the path and the id", func() {
+				p = repository.SearchFileParam{
+					Limit:     10,
+					Paths:     []string{"/storage/id-1.jpg"},
+					AfterId:   "id",
+					SortBy:    repository.FILE_SORT_ID,
+					SortOrder: repository.SORT_ASC,
+				}
+				countStmt := regexp.QuoteMeta(strings.TrimSpace(`
+					SELECT count(*)
+					FROM ` + "`file`" + `
+					WHERE path IN (?)
+					AND id > ?
+				`))
+				searchStmt := regexp.QuoteMeta(strings.TrimSpace(`
+					SELECT id, name, path, mimetype, extension, size, checksum_sha256, checksum_md5, encryption_key_id, encryption_data_key, stored_size, compression, created_at, deleted_at
+					FROM ` + "`file`" + `
+					WHERE path IN (?)
+					AND id > ?
+					ORDER BY ` + "`id`" + `
+					LIMIT 10
+				`))
+				countRows := sqlmock.
+					NewRows([]string{"count(*)"}).
+					AddRow(0)
+				dbClient.
+					ExpectQuery(countStmt).
+					WithArgs("/storage/id-1.jpg", "id").
+					WillReturnRows(countRows)
+
+				dbClient.
+					ExpectQuery(searchStmt).
+					WithArgs("/storage/id-1.jpg", "id").
+					WillReturnError(gorm.ErrRecordNotFound)
+
+				res, err := fileRepo.SearchFile(ctx, p)
+
+				Expect(res.Summary.TotalItems).To(Equal(int64(0)))
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("there are some files", func() {
+			It("should return result", func() {
+				dbClient.
+					ExpectQuery(countStmt).
+					WithArgs(args...).
+					WillReturnRows(countRows)
+
+				dbClient.
+					ExpectQuery(searchStmt).
+					WithArgs(args...).
+					WillReturnRows(searchRows)
+
+				res, err := fileRepo.SearchFile(ctx, p)
+
+				r := &repository.SearchFileResult{
+					Summary: repository.SearchFileSummary{
+						TotalItems: 2,
+					},
+					Items: []repository.SearchFileItem{
+						{
+							UniqueId:       "id-1",
+							Name:           "dolpin-1",
+							Path:           "/storage/id-1.jpg",
+							Mimetype:       "image/jpeg",
+							Extension:      "jpg",
+							Size:           100,
+							CreatedAt:      time.UnixMilli(currentTs.UnixMilli()).UTC(),
+							ChecksumSha256: "sha256-1",
+							ChecksumMd5:    "md5-1",
+						},
+						{
+							UniqueId:       "id-2",
+							Name:           "dolpin-2",
+							Path:           "/storage/id-2.jpg",
+							Mimetype:       "image/png",
+							Extension:      "jpg",
+							Size:           200,
+							CreatedAt:      time.UnixMilli(currentTs.UnixMilli()).UTC(),
+							ChecksumSha256: "sha256-2",
+						},
+					},
+				}
+				Expect(res).To(Equal(r))
+				Expect(err).To(BeNil())
+			})
+		})
+	})
+
+	Context("PurgeFile function", Label("unit"), func() {
+		var (
+			ctx          context.Context
+			currentTs    time.Time
+			dbClient     sqlmock.Sqlmock
+			fileRepo     repository.File
+			p            repository.PurgeFileParam
+			findStmt     string
+			deleteStmt   string
+			countStmt    string
+			findRows     *sqlmock.Rows
+			purgeFnParam []repository.PurgeFnParam
+		)
+
+		BeforeEach(func() {
+			var (
+				db  *sql.DB
+				err error
+			)
+
+			ctx = context.Background()
+			currentTs = time.Now().UTC()
+			db, dbClient, err = sqlmock.New()
+			if err != nil {
+				AbortSuite("failed create db mock: " + err.Error())
+			}
+
+			gormClient, err := gorm.Open(gorm_mysql.New(gorm_mysql.Config{
+				Conn:                      db,
+				SkipInitializeWithVersion: true,
+			}), &gorm.Config{
+				DisableAutomaticPing: true,
+			})
+			if err != nil {
+				AbortSuite("failed create gorm client: " + err.Error())
+			}
+			fileRepo = repository_mysql.NewFile(repository_mysql.FileParam{
+				GormClient: gormClient,
+			})
+
+			purgeFnParam = []repository.PurgeFnParam{}
+			p = repository.PurgeFileParam{
+				DeletedBefore: currentTs,
+				Limit:         100,
+				PurgeFn: func(ctx context.Context, p repository.PurgeFnParam) error {
+					purgeFnParam = append(purgeFnParam, p)
+					return nil
+				},
+			}
+			findStmt = regexp.QuoteMeta(strings.TrimSpace(`
+				SELECT id, path FROM ` + "`file`" + `
+				WHERE deleted_at IS NOT NULL AND deleted_at < ?
+				ORDER BY deleted_at
+				LIMIT 100 FOR UPDATE
+			`))
+			deleteStmt = regexp.QuoteMeta("DELETE FROM `file` WHERE id IN (?,?,?)")
+			countStmt = regexp.QuoteMeta("SELECT count(*) FROM `file` WHERE path = ?")
+			findRows = sqlmock.
+				NewRows([]string{"id", "path"}).
+				AddRow("id-1", "path-1").
+				AddRow("id-2", "path-2").
+				AddRow("id-3", "path-1")
+		})
+
+		AfterEach(func() {
+			err := dbClient.ExpectationsWereMet()
+			if err != nil {
+				AbortSuite("some expectations were not met " + err.Error())
+			}
+		})
+
+		When("failed begin trx", func() {
+			It("should return error", func() {
+				dbClient.
+					ExpectBegin().
+					WillReturnError(fmt.Errorf("begin error"))
+
+				res, err := fileRepo.PurgeFile(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("begin error")))
+			})
+		})
+
+		When("failed find deleted file", func() {
+			It("should return error", func() {
+				dbClient.ExpectBegin()
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(currentTs.UnixMilli()).
+					WillReturnError(fmt.Errorf("network error"))
+				dbClient.ExpectRollback()
+
+				res, err := fileRepo.PurgeFile(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("network error")))
+			})
+		})
+
+		When("there is no deleted file", func() {
+			It("should return empty result", func() {
+				dbClient.ExpectBegin()
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(currentTs.UnixMilli()).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "path"}))
+				dbClient.ExpectCommit()
+
+				res, err := fileRepo.PurgeFile(ctx, p)
+
+				Expect(res).To(Equal(&repository.PurgeFileResult{
+					TotalItems: 0,
+				}))
+				Expect(err).To(BeNil())
+				Expect(purgeFnParam).To(BeEmpty())
+			})
+		})
+
+		When("failed purge file", func() {
+			It("should return error", func() {
+				dbClient.ExpectBegin()
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(currentTs.UnixMilli()).
+					WillReturnRows(findRows)
+				dbClient.
+					ExpectExec(deleteStmt).
+					WithArgs("id-1", "id-2", "id-3").
+					WillReturnError(fmt.Errorf("network error"))
+				dbClient.ExpectRollback()
+
+				res, err := fileRepo.PurgeFile(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("network error")))
+			})
+		})
+
+		When("failed count path references", func() {
+			It("should return error", func() {
+				dbClient.ExpectBegin()
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(currentTs.UnixMilli()).
+					WillReturnRows(findRows)
+				dbClient.
+					ExpectExec(deleteStmt).
+					WithArgs("id-1", "id-2", "id-3").
+					WillReturnResult(sqlmock.NewResult(0, 3))
+				dbClient.
+					ExpectQuery(countStmt).
+					WithArgs("path-1").
+					WillReturnError(fmt.Errorf("network error"))
+				dbClient.ExpectRollback()
+
+				res, err := fileRepo.PurgeFile(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("network error")))
+			})
+		})
+
+		When("failed purge content", func() {
+			It("should return error", func() {
+				p.PurgeFn = func(ctx context.Context, p repository.PurgeFnParam) error {
+					return fmt.Errorf("disk error")
+				}
+				dbClient.ExpectBegin()
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(currentTs.UnixMilli()).
+					WillReturnRows(findRows)
+				dbClient.
+					ExpectExec(deleteStmt).
+					WithArgs("id-1", "id-2", "id-3").
+					WillReturnResult(sqlmock.NewResult(0, 3))
+				dbClient.
+					ExpectQuery(countStmt).
+					WithArgs("path-1").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+				dbClient.ExpectRollback()
+
+				res, err := fileRepo.PurgeFile(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("disk error")))
+			})
+		})
+
+		When("failed commit trx", func() {
+			It("should return error", func() {
+				dbClient.ExpectBegin()
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(currentTs.UnixMilli()).
+					WillReturnRows(findRows)
+				dbClient.
+					ExpectExec(deleteStmt).
+					WithArgs("id-1", "id-2", "id-3").
+					WillReturnResult(sqlmock.NewResult(0, 3))
+				dbClient.
+					ExpectQuery(countStmt).
+					WithArgs("path-1").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+				dbClient.
+					ExpectQuery(countStmt).
+					WithArgs("path-2").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				dbClient.
+					ExpectCommit().
+					WillReturnError(fmt.Errorf("commit error"))
+
+				res, err := fileRepo.PurgeFile(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("commit error")))
+			})
+		})
+
+		When("success purge file", func() {
+			It("should return result", func() {
+				dbClient.ExpectBegin()
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(currentTs.UnixMilli()).
+					WillReturnRows(findRows)
+				dbClient.
+					ExpectExec(deleteStmt).
+					WithArgs("id-1", "id-2", "id-3").
+					WillReturnResult(sqlmock.NewResult(0, 3))
+				dbClient.
+					ExpectQuery(countStmt).
+					WithArgs("path-1").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+				dbClient.
+					ExpectQuery(countStmt).
+					WithArgs("path-2").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				dbClient.ExpectCommit()
+
+				res, err := fileRepo.PurgeFile(ctx, p)
+
+				Expect(res).To(Equal(&repository.PurgeFileResult{
+					TotalItems: 3,
+				}))
+				Expect(err).To(BeNil())
+				Expect(purgeFnParam).To(Equal([]repository.PurgeFnParam{
+					{FilePath: "path-1", References: 0},
+					{FilePath: "path-2", References: 1},
+				}))
+			})
+		})
+	})
+
+	Context("SearchFileKey function", Label("unit"), func() {
+		var (
+			ctx        context.Context
+			dbClient   sqlmock.Sqlmock
+			fileRepo   repository.File
+			p          repository.SearchFileKeyParam
+			searchStmt string
+		)
+
+		BeforeEach(func() {
+			var (
+				db  *sql.DB
+				err error
+			)
+
+			ctx = context.Background()
+			db, dbClient, err = sqlmock.New()
+			if err != nil {
+				AbortSuite("failed create db mock: " + err.Error())
+			}
+
+			gormClient, err := gorm.Open(gorm_mysql.New(gorm_mysql.Config{
+				Conn:                      db,
+				SkipInitializeWithVersion: true,
+			}), &gorm.Config{
+				DisableAutomaticPing: true,
+			})
+			if err != nil {
+				AbortSuite("failed create gorm client: " + err.Error())
+			}
+			fileRepo = repository_mysql.NewFile(repository_mysql.FileParam{
+				GormClient: gormClient,
+			})
+
+			p = repository.SearchFileKeyParam{
+				ExcludeKeyId: "key-2",
+				AfterId:      "id-1",
+				Limit:        100,
+			}
+			searchStmt = regexp.QuoteMeta(strings.TrimSpace(`
+				SELECT id, encryption_key_id, encryption_data_key FROM ` + "`file`" + `
+				WHERE (encryption_key_id <> '' AND encryption_key_id <> ?)
+				AND id > ?
+				ORDER BY id
+				LIMIT 100
+			`))
+		})
+
+		AfterEach(func() {
+			err := dbClient.ExpectationsWereMet()
+			if err != nil {
+				AbortSuite("some expectations were not met " + err.Error())
+			}
+		})
+
+		When("failed search file", func() {
+			It("should return error", func() {
+				dbClient.
+					ExpectQuery(searchStmt).
+					WithArgs(p.ExcludeKeyId, p.AfterId).
+					WillReturnError(fmt.Errorf("network error"))
+
+				res, err := fileRepo.SearchFileKey(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("network error")))
+			})
+		})
+
+		When("success search file", func() {
+			It("should return result", func() {
+				rows := sqlmock.
+					NewRows([]string{"id", "encryption_key_id", "encryption_data_key"}).
+					AddRow("id-2", "key-1", "data-key-2").
+					AddRow("id-3", "key-1", "data-key-3")
+				dbClient.
+					ExpectQuery(searchStmt).
+					WithArgs(p.ExcludeKeyId, p.AfterId).
+					WillReturnRows(rows)
+
+				res, err := fileRepo.SearchFileKey(ctx, p)
+
+				Expect(err).To(BeNil())
+				Expect(res.Items).To(Equal([]repository.SearchFileKeyItem{
+					{
+						UniqueId:          "id-2",
+						EncryptionKeyId:   "key-1",
+						EncryptionDataKey: "data-key-2",
+					},
+					{
+						UniqueId:          "id-3",
+						EncryptionKeyId:   "key-1",
+						EncryptionDataKey: "data-key-3",
+					},
+				}))
+			})
+		})
+	})
+
+	Context("UpdateFileKey function", Label("unit"), func() {
+		var (
+			ctx        context.Context
+			currentTs  time.Time
+			dbClient   sqlmock.Sqlmock
+			fileRepo   repository.File
+			p          repository.UpdateFileKeyParam
+			updateStmt string
+		)
+
+		BeforeEach(func() {
+			var (
+				db  *sql.DB
+				err error
+			)
+
+			ctx = context.Background()
+			currentTs = time.Now().UTC()
+			db, dbClient, err = sqlmock.New()
+			if err != nil {
+				AbortSuite("failed create db mock: " + err.Error())
+			}
+
+			gormClient, err := gorm.Open(gorm_mysql.New(gorm_mysql.Config{
+				Conn:                      db,
+				SkipInitializeWithVersion: true,
+			}), &gorm.Config{
+				DisableAutomaticPing: true,
+			})
+			if err != nil {
+				AbortSuite("failed create gorm client: " + err.Error())
+			}
+			fileRepo = repository_mysql.NewFile(repository_mysql.FileParam{
+				GormClient: gormClient,
+			})
+
+			p = repository.UpdateFileKeyParam{
+				UniqueId:          "id-1",
+				CurrentDataKey:    "data-key-1",
+				EncryptionKeyId:   "key-2",
+				EncryptionDataKey: "data-key-2",
+				UpdatedAt:         currentTs,
+			}
+			updateStmt = regexp.QuoteMeta("UPDATE `file` SET `encryption_data_key`=?,`encryption_key_id`=?,`updated_at`=? WHERE id = ? AND encryption_data_key = ?")
+		})
+
+		AfterEach(func() {
+			err := dbClient.ExpectationsWereMet()
+			if err != nil {
+				AbortSuite("some expectations were not met " + err.Error())
+			}
+		})
+
+		When("failed update file", func() {
+			It("should return error", func() {
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectExec(updateStmt).
+					WithArgs(
+						p.EncryptionDataKey,
+						p.EncryptionKeyId,
+						p.UpdatedAt.UnixMilli(),
+						p.UniqueId,
+						p.CurrentDataKey,
+					).
+					WillReturnError(fmt.Errorf("network error"))
+
+				dbClient.
+					ExpectRollback()
+
+				res, err := fileRepo.UpdateFileKey(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("network error")))
+			})
+		})
+
+		When("data key is already changed", func() {
+			It("should return error", func() {
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectExec(updateStmt).
+					WithArgs(
+						p.EncryptionDataKey,
+						p.EncryptionKeyId,
+						p.UpdatedAt.UnixMilli(),
+						p.UniqueId,
+						p.CurrentDataKey,
+					).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+
+				dbClient.
+					ExpectCommit()
+
+				res, err := fileRepo.UpdateFileKey(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(repository.ErrNotFound))
+			})
+		})
+
+		When("success update file", func() {
+			It("should return result", func() {
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectExec(updateStmt).
+					WithArgs(
+						p.EncryptionDataKey,
+						p.EncryptionKeyId,
+						p.UpdatedAt.UnixMilli(),
+						p.UniqueId,
+						p.CurrentDataKey,
+					).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
+				dbClient.
+					ExpectCommit()
+
+				res, err := fileRepo.UpdateFileKey(ctx, p)
+
+				Expect(err).To(BeNil())
+				Expect(res.UpdatedAt).To(Equal(currentTs))
+			})
+		})
 	})
 })
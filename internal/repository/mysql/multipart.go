@@ -0,0 +1,484 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/go-seidon/hippo/internal/repository"
+	"github.com/go-seidon/provider/typeconv"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/plugin/dbresolver"
+)
+
+type multipart struct {
+	gormClient *gorm.DB
+}
+
+func (r *multipart) CreateMultipart(ctx context.Context, p repository.CreateMultipartParam) (*repository.CreateMultipartResult, error) {
+	createParam := &Multipart{
+		Id:            p.UniqueId,
+		Name:          p.Name,
+		Mimetype:      p.Mimetype,
+		Extension:     p.Extension,
+		OwnerClientId: p.OwnerClientId,
+		CreatedAt:     p.CreatedAt.UnixMilli(),
+		UpdatedAt:     p.CreatedAt.UnixMilli(),
+	}
+	createRes := r.gormClient.
+		WithContext(ctx).
+		Clauses(dbresolver.Write).
+		Create(createParam)
+	if createRes.Error != nil {
+		return nil, createRes.Error
+	}
+
+	res := &repository.CreateMultipartResult{
+		UniqueId:  createParam.Id,
+		Name:      createParam.Name,
+		Mimetype:  createParam.Mimetype,
+		Extension: createParam.Extension,
+		CreatedAt: time.UnixMilli(createParam.CreatedAt).UTC(),
+	}
+	return res, nil
+}
+
+func (r *multipart) RetrieveMultipart(ctx context.Context, p repository.RetrieveMultipartParam) (*repository.RetrieveMultipartResult, error) {
+	query := r.gormClient.
+		WithContext(ctx).
+		Clauses(dbresolver.Write)
+
+	multipart := &Multipart{}
+	findRes := query.
+		Select("id, name, mimetype, extension, file_id, owner_client_id, created_at, updated_at, completed_at").
+		First(multipart, "id = ?", p.UniqueId)
+	if findRes.Error != nil {
+		if errors.Is(findRes.Error, gorm.ErrRecordNotFound) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, findRes.Error
+	}
+
+	var completedAt *time.Time
+	if multipart.CompletedAt.Valid {
+		completedAt = typeconv.Time(time.UnixMilli(multipart.CompletedAt.Int64).UTC())
+	}
+
+	res := &repository.RetrieveMultipartResult{
+		UniqueId:      multipart.Id,
+		Name:          multipart.Name,
+		Mimetype:      multipart.Mimetype,
+		Extension:     multipart.Extension,
+		FileId:        multipart.FileId,
+		OwnerClientId: multipart.OwnerClientId,
+		CreatedAt:     time.UnixMilli(multipart.CreatedAt).UTC(),
+		UpdatedAt:     time.UnixMilli(multipart.UpdatedAt).UTC(),
+		CompletedAt:   completedAt,
+	}
+	return res, nil
+}
+
+func (r *multipart) SearchMultipart(ctx context.Context, p repository.SearchMultipartParam) (*repository.SearchMultipartResult, error) {
+	multiparts := []Multipart{}
+	searchRes := r.gormClient.
+		WithContext(ctx).
+		Clauses(dbresolver.Read).
+		Select("id, created_at, updated_at, completed_at").
+		Where("updated_at < ?", p.UpdatedBefore.UnixMilli()).
+		Order("updated_at ASC").
+		Limit(int(p.Limit)).
+		Find(&multiparts)
+	if searchRes.Error != nil {
+		return nil, searchRes.Error
+	}
+
+	items := []repository.SearchMultipartItem{}
+	for _, multipart := range multiparts {
+		var completedAt *time.Time
+		if multipart.CompletedAt.Valid {
+			completedAt = typeconv.Time(time.UnixMilli(multipart.CompletedAt.Int64).UTC())
+		}
+
+		items = append(items, repository.SearchMultipartItem{
+			UniqueId:    multipart.Id,
+			CreatedAt:   time.UnixMilli(multipart.CreatedAt).UTC(),
+			UpdatedAt:   time.UnixMilli(multipart.UpdatedAt).UTC(),
+			CompletedAt: completedAt,
+		})
+	}
+
+	res := &repository.SearchMultipartResult{
+		Items: items,
+	}
+	return res, nil
+}
+
+// @note: the multipart record is locked to record the last activity and so completion waits for the ongoing part uploads,
+// the lock is only held for the records update so the part files are still written in parallel
+func (r *multipart) UploadPart(ctx context.Context, p repository.UploadPartParam) (*repository.UploadPartResult, error) {
+	tx := r.gormClient.
+		WithContext(ctx).
+		Clauses(dbresolver.Write).
+		Begin()
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	multipart := &Multipart{}
+	findRes := tx.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id, completed_at").
+		First(multipart, "id = ?", p.MultipartId)
+	if findRes.Error != nil {
+		txRes := tx.Rollback()
+		if txRes.Error != nil {
+			return nil, txRes.Error
+		}
+		if errors.Is(findRes.Error, gorm.ErrRecordNotFound) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, findRes.Error
+	}
+
+	if multipart.CompletedAt.Valid {
+		txRes := tx.Rollback()
+		if txRes.Error != nil {
+			return nil, txRes.Error
+		}
+		return nil, repository.ErrConflict
+	}
+
+	touchRes := tx.
+		Model(&Multipart{}).
+		Where("id = ?", p.MultipartId).
+		Updates(map[string]interface{}{
+			"updated_at": p.UploadedAt.UnixMilli(),
+		})
+	if touchRes.Error != nil {
+		txRes := tx.Rollback()
+		if txRes.Error != nil {
+			return nil, txRes.Error
+		}
+		return nil, touchRes.Error
+	}
+
+	part := &MultipartPart{}
+	findRes = tx.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("multipart_id, part_number, path").
+		Where("multipart_id = ? AND part_number = ?", p.MultipartId, p.PartNumber).
+		Limit(1).
+		Find(part)
+	if findRes.Error != nil {
+		txRes := tx.Rollback()
+		if txRes.Error != nil {
+			return nil, txRes.Error
+		}
+		return nil, findRes.Error
+	}
+
+	replacedPath := ""
+	if findRes.RowsAffected == 0 {
+		createRes := tx.Create(&MultipartPart{
+			MultipartId:    p.MultipartId,
+			PartNumber:     p.PartNumber,
+			Path:           p.Path,
+			Size:           p.Size,
+			ChecksumSha256: p.ChecksumSha256,
+			CreatedAt:      p.UploadedAt.UnixMilli(),
+			UpdatedAt:      p.UploadedAt.UnixMilli(),
+		})
+		if createRes.Error != nil {
+			txRes := tx.Rollback()
+			if txRes.Error != nil {
+				return nil, txRes.Error
+			}
+			return nil, createRes.Error
+		}
+	} else {
+		replacedPath = part.Path
+		updateRes := tx.
+			Model(&MultipartPart{}).
+			Where("multipart_id = ? AND part_number = ?", p.MultipartId, p.PartNumber).
+			Updates(map[string]interface{}{
+				"path":            p.Path,
+				"size":            p.Size,
+				"checksum_sha256": p.ChecksumSha256,
+				"updated_at":      p.UploadedAt.UnixMilli(),
+			})
+		if updateRes.Error != nil {
+			txRes := tx.Rollback()
+			if txRes.Error != nil {
+				return nil, txRes.Error
+			}
+			return nil, updateRes.Error
+		}
+	}
+
+	txRes := tx.Commit()
+	if txRes.Error != nil {
+		return nil, txRes.Error
+	}
+
+	res := &repository.UploadPartResult{
+		MultipartId:    p.MultipartId,
+		PartNumber:     p.PartNumber,
+		Path:           p.Path,
+		Size:           p.Size,
+		ChecksumSha256: p.ChecksumSha256,
+		UploadedAt:     time.UnixMilli(p.UploadedAt.UnixMilli()).UTC(),
+		ReplacedPath:   replacedPath,
+	}
+	return res, nil
+}
+
+func (r *multipart) ListPart(ctx context.Context, p repository.ListPartParam) (*repository.ListPartResult, error) {
+	parts := []MultipartPart{}
+	findRes := r.gormClient.
+		WithContext(ctx).
+		Clauses(dbresolver.Write).
+		Select("multipart_id, part_number, path, size, checksum_sha256, updated_at").
+		Where("multipart_id = ?", p.MultipartId).
+		Order("part_number ASC").
+		Find(&parts)
+	if findRes.Error != nil {
+		return nil, findRes.Error
+	}
+
+	res := &repository.ListPartResult{
+		Items: newPartItems(parts),
+	}
+	return res, nil
+}
+
+func (r *multipart) CompleteMultipart(ctx context.Context, p repository.CompleteMultipartParam) (*repository.CompleteMultipartResult, error) {
+	tx := r.gormClient.
+		WithContext(ctx).
+		Clauses(dbresolver.Write).
+		Begin()
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	multipart := &Multipart{}
+	findRes := tx.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id, name, mimetype, extension, completed_at").
+		First(multipart, "id = ?", p.UniqueId)
+	if findRes.Error != nil {
+		txRes := tx.Rollback()
+		if txRes.Error != nil {
+			return nil, txRes.Error
+		}
+		if errors.Is(findRes.Error, gorm.ErrRecordNotFound) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, findRes.Error
+	}
+
+	if multipart.CompletedAt.Valid {
+		txRes := tx.Rollback()
+		if txRes.Error != nil {
+			return nil, txRes.Error
+		}
+		return nil, repository.ErrConflict
+	}
+
+	parts := []MultipartPart{}
+	findRes = tx.
+		Select("multipart_id, part_number, path, size, checksum_sha256, updated_at").
+		Where("multipart_id = ?", p.UniqueId).
+		Order("part_number ASC").
+		Find(&parts)
+	if findRes.Error != nil {
+		txRes := tx.Rollback()
+		if txRes.Error != nil {
+			return nil, txRes.Error
+		}
+		return nil, findRes.Error
+	}
+
+	partItems := newPartItems(parts)
+	fn, err := p.CompleteFn(ctx, repository.CompleteFnParam{
+		Name:      multipart.Name,
+		Mimetype:  multipart.Mimetype,
+		Extension: multipart.Extension,
+		Parts:     partItems,
+	})
+	if err != nil {
+		txRes := tx.Rollback()
+		if txRes.Error != nil {
+			return nil, txRes.Error
+		}
+		return nil, err
+	}
+
+	updateRes := tx.
+		Model(&Multipart{}).
+		Where("id = ?", p.UniqueId).
+		Updates(map[string]interface{}{
+			"file_id":      fn.FileId,
+			"updated_at":   p.CompletedAt.UnixMilli(),
+			"completed_at": p.CompletedAt.UnixMilli(),
+		})
+	if updateRes.Error != nil {
+		txRes := tx.Rollback()
+		if txRes.Error != nil {
+			return nil, txRes.Error
+		}
+		return nil, updateRes.Error
+	}
+
+	deleteRes := tx.
+		Where("multipart_id = ?", p.UniqueId).
+		Delete(&MultipartPart{})
+	if deleteRes.Error != nil {
+		txRes := tx.Rollback()
+		if txRes.Error != nil {
+			return nil, txRes.Error
+		}
+		return nil, deleteRes.Error
+	}
+
+	txRes := tx.Commit()
+	if txRes.Error != nil {
+		return nil, txRes.Error
+	}
+
+	res := &repository.CompleteMultipartResult{
+		UniqueId:    multipart.Id,
+		FileId:      fn.FileId,
+		Parts:       partItems,
+		CompletedAt: time.UnixMilli(p.CompletedAt.UnixMilli()).UTC(),
+	}
+	return res, nil
+}
+
+func (r *multipart) DeleteMultipart(ctx context.Context, p repository.DeleteMultipartParam) (*repository.DeleteMultipartResult, error) {
+	tx := r.gormClient.
+		WithContext(ctx).
+		Clauses(dbresolver.Write).
+		Begin()
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	multipart := &Multipart{}
+	findRes := tx.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		First(multipart, "id = ?", p.UniqueId)
+	if findRes.Error != nil {
+		txRes := tx.Rollback()
+		if txRes.Error != nil {
+			return nil, txRes.Error
+		}
+		if errors.Is(findRes.Error, gorm.ErrRecordNotFound) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, findRes.Error
+	}
+
+	parts := []MultipartPart{}
+	findRes = tx.
+		Select("multipart_id, part_number, path, size, checksum_sha256, updated_at").
+		Where("multipart_id = ?", p.UniqueId).
+		Order("part_number ASC").
+		Find(&parts)
+	if findRes.Error != nil {
+		txRes := tx.Rollback()
+		if txRes.Error != nil {
+			return nil, txRes.Error
+		}
+		return nil, findRes.Error
+	}
+
+	deleteRes := tx.
+		Where("multipart_id = ?", p.UniqueId).
+		Delete(&MultipartPart{})
+	if deleteRes.Error != nil {
+		txRes := tx.Rollback()
+		if txRes.Error != nil {
+			return nil, txRes.Error
+		}
+		return nil, deleteRes.Error
+	}
+
+	deleteRes = tx.
+		Where("id = ?", p.UniqueId).
+		Delete(&Multipart{})
+	if deleteRes.Error != nil {
+		txRes := tx.Rollback()
+		if txRes.Error != nil {
+			return nil, txRes.Error
+		}
+		return nil, deleteRes.Error
+	}
+
+	txRes := tx.Commit()
+	if txRes.Error != nil {
+		return nil, txRes.Error
+	}
+
+	res := &repository.DeleteMultipartResult{
+		Parts:     newPartItems(parts),
+		DeletedAt: time.UnixMilli(p.DeletedAt.UnixMilli()).UTC(),
+	}
+	return res, nil
+}
+
+func newPartItems(parts []MultipartPart) []repository.PartItem {
+	items := []repository.PartItem{}
+	for _, part := range parts {
+		items = append(items, repository.PartItem{
+			PartNumber:     part.PartNumber,
+			Path:           part.Path,
+			Size:           part.Size,
+			ChecksumSha256: part.ChecksumSha256,
+			UploadedAt:     time.UnixMilli(part.UpdatedAt).UTC(),
+		})
+	}
+	return items
+}
+
+type MultipartParam struct {
+	GormClient *gorm.DB
+}
+
+func NewMultipart(p MultipartParam) *multipart {
+	return &multipart{
+		gormClient: p.GormClient,
+	}
+}
+
+type Multipart struct {
+	Id            string        `gorm:"column:id;primaryKey"`
+	Name          string        `gorm:"column:name"`
+	Mimetype      string        `gorm:"column:mimetype"`
+	Extension     string        `gorm:"column:extension"`
+	FileId        string        `gorm:"column:file_id"`
+	OwnerClientId string        `gorm:"column:owner_client_id"`
+	CreatedAt     int64         `gorm:"column:created_at"`
+	UpdatedAt     int64         `gorm:"column:updated_at"`
+	CompletedAt   sql.NullInt64 `gorm:"column:completed_at;<-:update"`
+}
+
+func (Multipart) TableName() string {
+	return "multipart"
+}
+
+type MultipartPart struct {
+	MultipartId    string `gorm:"column:multipart_id;primaryKey"`
+	PartNumber     int32  `gorm:"column:part_number;primaryKey"`
+	Path           string `gorm:"column:path"`
+	Size           int64  `gorm:"column:size"`
+	ChecksumSha256 string `gorm:"column:checksum_sha256"`
+	CreatedAt      int64  `gorm:"column:created_at"`
+	UpdatedAt      int64  `gorm:"column:updated_at"`
+}
+
+func (MultipartPart) TableName() string {
+	return "multipart_part"
+}
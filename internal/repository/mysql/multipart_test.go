@@ -0,0 +1,1224 @@
+package mysql_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-seidon/hippo/internal/repository"
+	repository_mysql "github.com/go-seidon/hippo/internal/repository/mysql"
+	"github.com/go-seidon/provider/typeconv"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	gorm_mysql "gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("Multipart Repository", func() {
+	var (
+		ctx           context.Context
+		currentTs     time.Time
+		dbClient      sqlmock.Sqlmock
+		multipartRepo repository.Multipart
+	)
+
+	BeforeEach(func() {
+		var (
+			db  *sql.DB
+			err error
+		)
+
+		ctx = context.Background()
+		currentTs = time.Now().UTC()
+		db, dbClient, err = sqlmock.New()
+		if err != nil {
+			AbortSuite("failed create db mock: " + err.Error())
+		}
+
+		gormClient, err := gorm.Open(gorm_mysql.New(gorm_mysql.Config{
+			Conn:                      db,
+			SkipInitializeWithVersion: true,
+		}), &gorm.Config{
+			DisableAutomaticPing: true,
+		})
+		if err != nil {
+			AbortSuite("failed create gorm client: " + err.Error())
+		}
+		multipartRepo = repository_mysql.NewMultipart(repository_mysql.MultipartParam{
+			GormClient: gormClient,
+		})
+	})
+
+	AfterEach(func() {
+		err := dbClient.ExpectationsWereMet()
+		if err != nil {
+			AbortSuite("some expectations were not met " + err.Error())
+		}
+	})
+
+	Context("CreateMultipart function", Label("unit"), func() {
+		var (
+			p          repository.CreateMultipartParam
+			insertStmt string
+		)
+
+		BeforeEach(func() {
+			p = repository.CreateMultipartParam{
+				UniqueId:      "id",
+				Name:          "dolphin",
+				Mimetype:      "image/jpeg",
+				Extension:     "jpg",
+				CreatedAt:     currentTs,
+				OwnerClientId: "client-record-id",
+			}
+			insertStmt = regexp.QuoteMeta("INSERT INTO `multipart` (`id`,`name`,`mimetype`,`extension`,`file_id`,`owner_client_id`,`created_at`,`updated_at`) VALUES (?,?,?,?,?,?,?,?)")
+		})
+
+		When("failed create multipart", func() {
+			It("should return error", func() {
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectExec(insertStmt).
+					WithArgs(
+						p.UniqueId,
+						p.Name,
+						p.Mimetype,
+						p.Extension,
+						"",
+						p.OwnerClientId,
+						p.CreatedAt.UnixMilli(),
+						p.CreatedAt.UnixMilli(),
+					).
+					WillReturnError(fmt.Errorf("network error"))
+
+				dbClient.
+					ExpectRollback()
+
+				res, err := multipartRepo.CreateMultipart(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("network error")))
+			})
+		})
+
+		When("success create multipart", func() {
+			It("should return result", func() {
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectExec(insertStmt).
+					WithArgs(
+						p.UniqueId,
+						p.Name,
+						p.Mimetype,
+						p.Extension,
+						"",
+						p.OwnerClientId,
+						p.CreatedAt.UnixMilli(),
+						p.CreatedAt.UnixMilli(),
+					).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				dbClient.
+					ExpectCommit()
+
+				res, err := multipartRepo.CreateMultipart(ctx, p)
+
+				Expect(err).To(BeNil())
+				Expect(res).To(Equal(&repository.CreateMultipartResult{
+					UniqueId:  "id",
+					Name:      "dolphin",
+					Mimetype:  "image/jpeg",
+					Extension: "jpg",
+					CreatedAt: time.UnixMilli(currentTs.UnixMilli()).UTC(),
+				}))
+			})
+		})
+	})
+
+	Context("RetrieveMultipart function", Label("unit"), func() {
+		var (
+			p        repository.RetrieveMultipartParam
+			findStmt string
+		)
+
+		BeforeEach(func() {
+			p = repository.RetrieveMultipartParam{
+				UniqueId: "id",
+			}
+			findStmt = regexp.QuoteMeta("SELECT id, name, mimetype, extension, file_id, owner_client_id, created_at, updated_at, completed_at FROM `multipart` WHERE id = ? ORDER BY `multipart`.`id` LIMIT 1")
+		})
+
+		When("failed find multipart", func() {
+			It("should return error", func() {
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.UniqueId).
+					WillReturnError(fmt.Errorf("network error"))
+
+				res, err := multipartRepo.RetrieveMultipart(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("network error")))
+			})
+		})
+
+		When("multipart is not available", func() {
+			It("should return error", func() {
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.UniqueId).
+					WillReturnError(gorm.ErrRecordNotFound)
+
+				res, err := multipartRepo.RetrieveMultipart(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(repository.ErrNotFound))
+			})
+		})
+
+		When("success find multipart", func() {
+			It("should return result", func() {
+				rows := sqlmock.
+					NewRows([]string{"id", "name", "mimetype", "extension", "file_id", "owner_client_id", "created_at", "updated_at", "completed_at"}).
+					AddRow("id", "dolphin", "image/jpeg", "jpg", "file-id", "client-record-id", currentTs.UnixMilli(), currentTs.UnixMilli(), currentTs.UnixMilli())
+
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.UniqueId).
+					WillReturnRows(rows)
+
+				res, err := multipartRepo.RetrieveMultipart(ctx, p)
+
+				Expect(err).To(BeNil())
+				Expect(res).To(Equal(&repository.RetrieveMultipartResult{
+					UniqueId:      "id",
+					Name:          "dolphin",
+					Mimetype:      "image/jpeg",
+					Extension:     "jpg",
+					FileId:        "file-id",
+					OwnerClientId: "client-record-id",
+					CreatedAt:     time.UnixMilli(currentTs.UnixMilli()).UTC(),
+					UpdatedAt:     time.UnixMilli(currentTs.UnixMilli()).UTC(),
+					CompletedAt:   typeconv.Time(time.UnixMilli(currentTs.UnixMilli()).UTC()),
+				}))
+			})
+		})
+	})
+
+	Context("SearchMultipart function", Label("unit"), func() {
+		var (
+			p          repository.SearchMultipartParam
+			searchStmt string
+		)
+
+		BeforeEach(func() {
+			p = repository.SearchMultipartParam{
+				UpdatedBefore: currentTs,
+				Limit:         10,
+			}
+			searchStmt = regexp.QuoteMeta("SELECT id, created_at, updated_at, completed_at FROM `multipart` WHERE updated_at < ? ORDER BY updated_at ASC LIMIT 10")
+		})
+
+		When("failed search multipart", func() {
+			It("should return error", func() {
+				dbClient.
+					ExpectQuery(searchStmt).
+					WithArgs(p.UpdatedBefore.UnixMilli()).
+					WillReturnError(fmt.Errorf("network error"))
+
+				res, err := multipartRepo.SearchMultipart(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("network error")))
+			})
+		})
+
+		When("multipart is not available", func() {
+			It("should return empty result", func() {
+				rows := sqlmock.
+					NewRows([]string{"id", "created_at", "updated_at", "completed_at"})
+
+				dbClient.
+					ExpectQuery(searchStmt).
+					WithArgs(p.UpdatedBefore.UnixMilli()).
+					WillReturnRows(rows)
+
+				res, err := multipartRepo.SearchMultipart(ctx, p)
+
+				Expect(err).To(BeNil())
+				Expect(res).To(Equal(&repository.SearchMultipartResult{
+					Items: []repository.SearchMultipartItem{},
+				}))
+			})
+		})
+
+		When("multipart is available", func() {
+			It("should return result", func() {
+				rows := sqlmock.
+					NewRows([]string{"id", "created_at", "updated_at", "completed_at"}).
+					AddRow("id-1", currentTs.UnixMilli(), currentTs.UnixMilli(), nil).
+					AddRow("id-2", currentTs.UnixMilli(), currentTs.UnixMilli(), currentTs.UnixMilli())
+
+				dbClient.
+					ExpectQuery(searchStmt).
+					WithArgs(p.UpdatedBefore.UnixMilli()).
+					WillReturnRows(rows)
+
+				res, err := multipartRepo.SearchMultipart(ctx, p)
+
+				Expect(err).To(BeNil())
+				Expect(res).To(Equal(&repository.SearchMultipartResult{
+					Items: []repository.SearchMultipartItem{
+						{
+							UniqueId:  "id-1",
+							CreatedAt: time.UnixMilli(currentTs.UnixMilli()).UTC(),
+							UpdatedAt: time.UnixMilli(currentTs.UnixMilli()).UTC(),
+						},
+						{
+							UniqueId:    "id-2",
+							CreatedAt:   time.UnixMilli(currentTs.UnixMilli()).UTC(),
+							UpdatedAt:   time.UnixMilli(currentTs.UnixMilli()).UTC(),
+							CompletedAt: typeconv.Time(time.UnixMilli(currentTs.UnixMilli()).UTC()),
+						},
+					},
+				}))
+			})
+		})
+	})
+
+	Context("UploadPart function", Label("unit"), func() {
+		var (
+			p            repository.UploadPartParam
+			findStmt     string
+			findPartStmt string
+			insertStmt   string
+			updateStmt   string
+			touchStmt    string
+			findRows     *sqlmock.Rows
+		)
+
+		BeforeEach(func() {
+			p = repository.UploadPartParam{
+				MultipartId:    "id",
+				PartNumber:     2,
+				Path:           "storage/partial/id.2.part-id",
+				Size:           1024,
+				ChecksumSha256: "sha256",
+				UploadedAt:     currentTs,
+			}
+			findStmt = regexp.QuoteMeta("SELECT id, completed_at FROM `multipart` WHERE id = ? ORDER BY `multipart`.`id` LIMIT 1 FOR UPDATE")
+			findPartStmt = regexp.QuoteMeta("SELECT multipart_id, part_number, path FROM `multipart_part` WHERE multipart_id = ? AND part_number = ? LIMIT 1 FOR UPDATE")
+			insertStmt = regexp.QuoteMeta("INSERT INTO `multipart_part` (`multipart_id`,`part_number`,`path`,`size`,`checksum_sha256`,`created_at`,`updated_at`) VALUES (?,?,?,?,?,?,?)")
+			updateStmt = regexp.QuoteMeta("UPDATE `multipart_part` SET `checksum_sha256`=?,`path`=?,`size`=?,`updated_at`=? WHERE multipart_id = ? AND part_number = ?")
+			touchStmt = regexp.QuoteMeta("UPDATE `multipart` SET `updated_at`=? WHERE id = ?")
+			findRows = sqlmock.
+				NewRows([]string{"id", "completed_at"}).
+				AddRow("id", nil)
+		})
+
+		When("failed begin trx", func() {
+			It("should return error", func() {
+				dbClient.
+					ExpectBegin().
+					WillReturnError(fmt.Errorf("begin error"))
+
+				res, err := multipartRepo.UploadPart(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("begin error")))
+			})
+		})
+
+		When("multipart is not available", func() {
+			It("should return error", func() {
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.MultipartId).
+					WillReturnError(gorm.ErrRecordNotFound)
+
+				dbClient.
+					ExpectRollback()
+
+				res, err := multipartRepo.UploadPart(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(repository.ErrNotFound))
+			})
+		})
+
+		When("multipart is already completed", func() {
+			It("should return error", func() {
+				findRows = sqlmock.
+					NewRows([]string{"id", "completed_at"}).
+					AddRow("id", currentTs.UnixMilli())
+
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.MultipartId).
+					WillReturnRows(findRows)
+
+				dbClient.
+					ExpectRollback()
+
+				res, err := multipartRepo.UploadPart(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(repository.ErrConflict))
+			})
+		})
+
+		When("failed update multipart activity", func() {
+			It("should return error", func() {
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.MultipartId).
+					WillReturnRows(findRows)
+
+				dbClient.
+					ExpectExec(touchStmt).
+					WithArgs(p.UploadedAt.UnixMilli(), p.MultipartId).
+					WillReturnError(fmt.Errorf("network error"))
+
+				dbClient.
+					ExpectRollback()
+
+				res, err := multipartRepo.UploadPart(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("network error")))
+			})
+		})
+
+		When("failed find part", func() {
+			It("should return error", func() {
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.MultipartId).
+					WillReturnRows(findRows)
+
+				dbClient.
+					ExpectExec(touchStmt).
+					WithArgs(p.UploadedAt.UnixMilli(), p.MultipartId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
+				dbClient.
+					ExpectQuery(findPartStmt).
+					WithArgs(p.MultipartId, p.PartNumber).
+					WillReturnError(fmt.Errorf("network error"))
+
+				dbClient.
+					ExpectRollback()
+
+				res, err := multipartRepo.UploadPart(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("network error")))
+			})
+		})
+
+		When("failed create part", func() {
+			It("should return error", func() {
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.MultipartId).
+					WillReturnRows(findRows)
+
+				dbClient.
+					ExpectExec(touchStmt).
+					WithArgs(p.UploadedAt.UnixMilli(), p.MultipartId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
+				dbClient.
+					ExpectQuery(findPartStmt).
+					WithArgs(p.MultipartId, p.PartNumber).
+					WillReturnRows(sqlmock.NewRows([]string{"multipart_id", "part_number", "path"}))
+
+				dbClient.
+					ExpectExec(insertStmt).
+					WithArgs(
+						p.MultipartId,
+						p.PartNumber,
+						p.Path,
+						p.Size,
+						p.ChecksumSha256,
+						p.UploadedAt.UnixMilli(),
+						p.UploadedAt.UnixMilli(),
+					).
+					WillReturnError(fmt.Errorf("network error"))
+
+				dbClient.
+					ExpectRollback()
+
+				res, err := multipartRepo.UploadPart(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("network error")))
+			})
+		})
+
+		When("failed update part", func() {
+			It("should return error", func() {
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.MultipartId).
+					WillReturnRows(findRows)
+
+				dbClient.
+					ExpectExec(touchStmt).
+					WithArgs(p.UploadedAt.UnixMilli(), p.MultipartId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
+				dbClient.
+					ExpectQuery(findPartStmt).
+					WithArgs(p.MultipartId, p.PartNumber).
+					WillReturnRows(sqlmock.
+						NewRows([]string{"multipart_id", "part_number", "path"}).
+						AddRow("id", 2, "storage/partial/id.2.old-id"))
+
+				dbClient.
+					ExpectExec(updateStmt).
+					WithArgs(p.ChecksumSha256, p.Path, p.Size, p.UploadedAt.UnixMilli(), p.MultipartId, p.PartNumber).
+					WillReturnError(fmt.Errorf("network error"))
+
+				dbClient.
+					ExpectRollback()
+
+				res, err := multipartRepo.UploadPart(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("network error")))
+			})
+		})
+
+		When("failed commit trx", func() {
+			It("should return error", func() {
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.MultipartId).
+					WillReturnRows(findRows)
+
+				dbClient.
+					ExpectExec(touchStmt).
+					WithArgs(p.UploadedAt.UnixMilli(), p.MultipartId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
+				dbClient.
+					ExpectQuery(findPartStmt).
+					WithArgs(p.MultipartId, p.PartNumber).
+					WillReturnRows(sqlmock.NewRows([]string{"multipart_id", "part_number", "path"}))
+
+				dbClient.
+					ExpectExec(insertStmt).
+					WithArgs(
+						p.MultipartId,
+						p.PartNumber,
+						p.Path,
+						p.Size,
+						p.ChecksumSha256,
+						p.UploadedAt.UnixMilli(),
+						p.UploadedAt.UnixMilli(),
+					).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				dbClient.
+					ExpectCommit().
+					WillReturnError(fmt.Errorf("commit error"))
+
+				res, err := multipartRepo.UploadPart(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("commit error")))
+			})
+		})
+
+		When("part is uploaded for the first time", func() {
+			It("should return result", func() {
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.MultipartId).
+					WillReturnRows(findRows)
+
+				dbClient.
+					ExpectExec(touchStmt).
+					WithArgs(p.UploadedAt.UnixMilli(), p.MultipartId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
+				dbClient.
+					ExpectQuery(findPartStmt).
+					WithArgs(p.MultipartId, p.PartNumber).
+					WillReturnRows(sqlmock.NewRows([]string{"multipart_id", "part_number", "path"}))
+
+				dbClient.
+					ExpectExec(insertStmt).
+					WithArgs(
+						p.MultipartId,
+						p.PartNumber,
+						p.Path,
+						p.Size,
+						p.ChecksumSha256,
+						p.UploadedAt.UnixMilli(),
+						p.UploadedAt.UnixMilli(),
+					).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				dbClient.
+					ExpectCommit()
+
+				res, err := multipartRepo.UploadPart(ctx, p)
+
+				Expect(err).To(BeNil())
+				Expect(res).To(Equal(&repository.UploadPartResult{
+					MultipartId:    "id",
+					PartNumber:     2,
+					Path:           "storage/partial/id.2.part-id",
+					Size:           1024,
+					ChecksumSha256: "sha256",
+					UploadedAt:     time.UnixMilli(currentTs.UnixMilli()).UTC(),
+				}))
+			})
+		})
+
+		When("part is re-uploaded", func() {
+			It("should return result", func() {
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.MultipartId).
+					WillReturnRows(findRows)
+
+				dbClient.
+					ExpectExec(touchStmt).
+					WithArgs(p.UploadedAt.UnixMilli(), p.MultipartId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
+				dbClient.
+					ExpectQuery(findPartStmt).
+					WithArgs(p.MultipartId, p.PartNumber).
+					WillReturnRows(sqlmock.
+						NewRows([]string{"multipart_id", "part_number", "path"}).
+						AddRow("id", 2, "storage/partial/id.2.old-id"))
+
+				dbClient.
+					ExpectExec(updateStmt).
+					WithArgs(p.ChecksumSha256, p.Path, p.Size, p.UploadedAt.UnixMilli(), p.MultipartId, p.PartNumber).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				dbClient.
+					ExpectCommit()
+
+				res, err := multipartRepo.UploadPart(ctx, p)
+
+				Expect(err).To(BeNil())
+				Expect(res).To(Equal(&repository.UploadPartResult{
+					MultipartId:    "id",
+					PartNumber:     2,
+					Path:           "storage/partial/id.2.part-id",
+					Size:           1024,
+					ChecksumSha256: "sha256",
+					UploadedAt:     time.UnixMilli(currentTs.UnixMilli()).UTC(),
+					ReplacedPath:   "storage/partial/id.2.old-id",
+				}))
+			})
+		})
+	})
+
+	Context("ListPart function", Label("unit"), func() {
+		var (
+			p        repository.ListPartParam
+			listStmt string
+		)
+
+		BeforeEach(func() {
+			p = repository.ListPartParam{
+				MultipartId: "id",
+			}
+			listStmt = regexp.QuoteMeta("SELECT multipart_id, part_number, path, size, checksum_sha256, updated_at FROM `multipart_part` WHERE multipart_id = ? ORDER BY part_number ASC")
+		})
+
+		When("failed list part", func() {
+			It("should return error", func() {
+				dbClient.
+					ExpectQuery(listStmt).
+					WithArgs(p.MultipartId).
+					WillReturnError(fmt.Errorf("network error"))
+
+				res, err := multipartRepo.ListPart(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("network error")))
+			})
+		})
+
+		When("success list part", func() {
+			It("should return result", func() {
+				rows := sqlmock.
+					NewRows([]string{"multipart_id", "part_number", "path", "size", "checksum_sha256", "updated_at"}).
+					AddRow("id", 1, "storage/partial/id.1.a", 1024, "sha256-1", currentTs.UnixMilli()).
+					AddRow("id", 2, "storage/partial/id.2.b", 512, "sha256-2", currentTs.UnixMilli())
+
+				dbClient.
+					ExpectQuery(listStmt).
+					WithArgs(p.MultipartId).
+					WillReturnRows(rows)
+
+				res, err := multipartRepo.ListPart(ctx, p)
+
+				Expect(err).To(BeNil())
+				Expect(res).To(Equal(&repository.ListPartResult{
+					Items: []repository.PartItem{
+						{
+							PartNumber:     1,
+							Path:           "storage/partial/id.1.a",
+							Size:           1024,
+							ChecksumSha256: "sha256-1",
+							UploadedAt:     time.UnixMilli(currentTs.UnixMilli()).UTC(),
+						},
+						{
+							PartNumber:     2,
+							Path:           "storage/partial/id.2.b",
+							Size:           512,
+							ChecksumSha256: "sha256-2",
+							UploadedAt:     time.UnixMilli(currentTs.UnixMilli()).UTC(),
+						},
+					},
+				}))
+			})
+		})
+	})
+
+	Context("CompleteMultipart function", Label("unit"), func() {
+		var (
+			p          repository.CompleteMultipartParam
+			findStmt   string
+			listStmt   string
+			updateStmt string
+			deleteStmt string
+			findRows   *sqlmock.Rows
+			listRows   *sqlmock.Rows
+			fnParam    *repository.CompleteFnParam
+			parts      []repository.PartItem
+		)
+
+		BeforeEach(func() {
+			fnParam = nil
+			p = repository.CompleteMultipartParam{
+				UniqueId:    "id",
+				CompletedAt: currentTs,
+				CompleteFn: func(ctx context.Context, p repository.CompleteFnParam) (*repository.CompleteFnResult, error) {
+					fnParam = &p
+					return &repository.CompleteFnResult{
+						FileId: "file-id",
+					}, nil
+				},
+			}
+			findStmt = regexp.QuoteMeta("SELECT id, name, mimetype, extension, completed_at FROM `multipart` WHERE id = ? ORDER BY `multipart`.`id` LIMIT 1 FOR UPDATE")
+			listStmt = regexp.QuoteMeta("SELECT multipart_id, part_number, path, size, checksum_sha256, updated_at FROM `multipart_part` WHERE multipart_id = ? ORDER BY part_number ASC")
+			updateStmt = regexp.QuoteMeta("UPDATE `multipart` SET `completed_at`=?,`file_id`=?,`updated_at`=? WHERE id = ?")
+			deleteStmt = regexp.QuoteMeta("DELETE FROM `multipart_part` WHERE multipart_id = ?")
+			findRows = sqlmock.
+				NewRows([]string{"id", "name", "mimetype", "extension", "completed_at"}).
+				AddRow("id", "dolphin", "image/jpeg", "jpg", nil)
+			listRows = sqlmock.
+				NewRows([]string{"multipart_id", "part_number", "path", "size", "checksum_sha256", "updated_at"}).
+				AddRow("id", 1, "storage/partial/id.1.a", 1024, "sha256-1", currentTs.UnixMilli())
+			parts = []repository.PartItem{
+				{
+					PartNumber:     1,
+					Path:           "storage/partial/id.1.a",
+					Size:           1024,
+					ChecksumSha256: "sha256-1",
+					UploadedAt:     time.UnixMilli(currentTs.UnixMilli()).UTC(),
+				},
+			}
+		})
+
+		When("failed begin trx", func() {
+			It("should return error", func() {
+				dbClient.
+					ExpectBegin().
+					WillReturnError(fmt.Errorf("begin error"))
+
+				res, err := multipartRepo.CompleteMultipart(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("begin error")))
+			})
+		})
+
+		When("multipart is not available", func() {
+			It("should return error", func() {
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.UniqueId).
+					WillReturnError(gorm.ErrRecordNotFound)
+
+				dbClient.
+					ExpectRollback()
+
+				res, err := multipartRepo.CompleteMultipart(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(repository.ErrNotFound))
+			})
+		})
+
+		When("multipart is already completed", func() {
+			It("should return error", func() {
+				findRows = sqlmock.
+					NewRows([]string{"id", "name", "mimetype", "extension", "completed_at"}).
+					AddRow("id", "dolphin", "image/jpeg", "jpg", currentTs.UnixMilli())
+
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.UniqueId).
+					WillReturnRows(findRows)
+
+				dbClient.
+					ExpectRollback()
+
+				res, err := multipartRepo.CompleteMultipart(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(repository.ErrConflict))
+			})
+		})
+
+		When("failed list part", func() {
+			It("should return error", func() {
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.UniqueId).
+					WillReturnRows(findRows)
+
+				dbClient.
+					ExpectQuery(listStmt).
+					WithArgs(p.UniqueId).
+					WillReturnError(fmt.Errorf("network error"))
+
+				dbClient.
+					ExpectRollback()
+
+				res, err := multipartRepo.CompleteMultipart(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("network error")))
+			})
+		})
+
+		When("failed execute callback", func() {
+			It("should return error", func() {
+				p.CompleteFn = func(ctx context.Context, p repository.CompleteFnParam) (*repository.CompleteFnResult, error) {
+					return nil, fmt.Errorf("disk error")
+				}
+
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.UniqueId).
+					WillReturnRows(findRows)
+
+				dbClient.
+					ExpectQuery(listStmt).
+					WithArgs(p.UniqueId).
+					WillReturnRows(listRows)
+
+				dbClient.
+					ExpectRollback()
+
+				res, err := multipartRepo.CompleteMultipart(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("disk error")))
+			})
+		})
+
+		When("failed update multipart", func() {
+			It("should return error", func() {
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.UniqueId).
+					WillReturnRows(findRows)
+
+				dbClient.
+					ExpectQuery(listStmt).
+					WithArgs(p.UniqueId).
+					WillReturnRows(listRows)
+
+				dbClient.
+					ExpectExec(updateStmt).
+					WithArgs(p.CompletedAt.UnixMilli(), "file-id", p.CompletedAt.UnixMilli(), p.UniqueId).
+					WillReturnError(fmt.Errorf("network error"))
+
+				dbClient.
+					ExpectRollback()
+
+				res, err := multipartRepo.CompleteMultipart(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("network error")))
+			})
+		})
+
+		When("failed delete part", func() {
+			It("should return error", func() {
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.UniqueId).
+					WillReturnRows(findRows)
+
+				dbClient.
+					ExpectQuery(listStmt).
+					WithArgs(p.UniqueId).
+					WillReturnRows(listRows)
+
+				dbClient.
+					ExpectExec(updateStmt).
+					WithArgs(p.CompletedAt.UnixMilli(), "file-id", p.CompletedAt.UnixMilli(), p.UniqueId).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				dbClient.
+					ExpectExec(deleteStmt).
+					WithArgs(p.UniqueId).
+					WillReturnError(fmt.Errorf("network error"))
+
+				dbClient.
+					ExpectRollback()
+
+				res, err := multipartRepo.CompleteMultipart(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("network error")))
+			})
+		})
+
+		When("failed commit trx", func() {
+			It("should return error", func() {
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.UniqueId).
+					WillReturnRows(findRows)
+
+				dbClient.
+					ExpectQuery(listStmt).
+					WithArgs(p.UniqueId).
+					WillReturnRows(listRows)
+
+				dbClient.
+					ExpectExec(updateStmt).
+					WithArgs(p.CompletedAt.UnixMilli(), "file-id", p.CompletedAt.UnixMilli(), p.UniqueId).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				dbClient.
+					ExpectExec(deleteStmt).
+					WithArgs(p.UniqueId).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				dbClient.
+					ExpectCommit().
+					WillReturnError(fmt.Errorf("commit error"))
+
+				res, err := multipartRepo.CompleteMultipart(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("commit error")))
+			})
+		})
+
+		When("success complete multipart", func() {
+			It("should return result", func() {
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.UniqueId).
+					WillReturnRows(findRows)
+
+				dbClient.
+					ExpectQuery(listStmt).
+					WithArgs(p.UniqueId).
+					WillReturnRows(listRows)
+
+				dbClient.
+					ExpectExec(updateStmt).
+					WithArgs(p.CompletedAt.UnixMilli(), "file-id", p.CompletedAt.UnixMilli(), p.UniqueId).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				dbClient.
+					ExpectExec(deleteStmt).
+					WithArgs(p.UniqueId).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				dbClient.
+					ExpectCommit()
+
+				res, err := multipartRepo.CompleteMultipart(ctx, p)
+
+				Expect(err).To(BeNil())
+				Expect(res).To(Equal(&repository.CompleteMultipartResult{
+					UniqueId:    "id",
+					FileId:      "file-id",
+					Parts:       parts,
+					CompletedAt: time.UnixMilli(currentTs.UnixMilli()).UTC(),
+				}))
+				Expect(fnParam).To(Equal(&repository.CompleteFnParam{
+					Name:      "dolphin",
+					Mimetype:  "image/jpeg",
+					Extension: "jpg",
+					Parts:     parts,
+				}))
+			})
+		})
+	})
+
+	Context("DeleteMultipart function", Label("unit"), func() {
+		var (
+			p              repository.DeleteMultipartParam
+			findStmt       string
+			listStmt       string
+			deletePartStmt string
+			deleteStmt     string
+			findRows       *sqlmock.Rows
+			listRows       *sqlmock.Rows
+		)
+
+		BeforeEach(func() {
+			p = repository.DeleteMultipartParam{
+				UniqueId:  "id",
+				DeletedAt: currentTs,
+			}
+			findStmt = regexp.QuoteMeta("SELECT `id` FROM `multipart` WHERE id = ? ORDER BY `multipart`.`id` LIMIT 1 FOR UPDATE")
+			listStmt = regexp.QuoteMeta("SELECT multipart_id, part_number, path, size, checksum_sha256, updated_at FROM `multipart_part` WHERE multipart_id = ? ORDER BY part_number ASC")
+			deletePartStmt = regexp.QuoteMeta("DELETE FROM `multipart_part` WHERE multipart_id = ?")
+			deleteStmt = regexp.QuoteMeta("DELETE FROM `multipart` WHERE id = ?")
+			findRows = sqlmock.
+				NewRows([]string{"id"}).
+				AddRow("id")
+			listRows = sqlmock.
+				NewRows([]string{"multipart_id", "part_number", "path", "size", "checksum_sha256", "updated_at"}).
+				AddRow("id", 1, "storage/partial/id.1.a", 1024, "sha256-1", currentTs.UnixMilli())
+		})
+
+		When("failed begin trx", func() {
+			It("should return error", func() {
+				dbClient.
+					ExpectBegin().
+					WillReturnError(fmt.Errorf("begin error"))
+
+				res, err := multipartRepo.DeleteMultipart(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("begin error")))
+			})
+		})
+
+		When("multipart is not available", func() {
+			It("should return error", func() {
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.UniqueId).
+					WillReturnError(gorm.ErrRecordNotFound)
+
+				dbClient.
+					ExpectRollback()
+
+				res, err := multipartRepo.DeleteMultipart(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(repository.ErrNotFound))
+			})
+		})
+
+		When("failed list part", func() {
+			It("should return error", func() {
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.UniqueId).
+					WillReturnRows(findRows)
+
+				dbClient.
+					ExpectQuery(listStmt).
+					WithArgs(p.UniqueId).
+					WillReturnError(fmt.Errorf("network error"))
+
+				dbClient.
+					ExpectRollback()
+
+				res, err := multipartRepo.DeleteMultipart(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("network error")))
+			})
+		})
+
+		When("failed delete part", func() {
+			It("should return error", func() {
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.UniqueId).
+					WillReturnRows(findRows)
+
+				dbClient.
+					ExpectQuery(listStmt).
+					WithArgs(p.UniqueId).
+					WillReturnRows(listRows)
+
+				dbClient.
+					ExpectExec(deletePartStmt).
+					WithArgs(p.UniqueId).
+					WillReturnError(fmt.Errorf("network error"))
+
+				dbClient.
+					ExpectRollback()
+
+				res, err := multipartRepo.DeleteMultipart(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("network error")))
+			})
+		})
+
+		When("failed delete multipart", func() {
+			It("should return error", func() {
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.UniqueId).
+					WillReturnRows(findRows)
+
+				dbClient.
+					ExpectQuery(listStmt).
+					WithArgs(p.UniqueId).
+					WillReturnRows(listRows)
+
+				dbClient.
+					ExpectExec(deletePartStmt).
+					WithArgs(p.UniqueId).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				dbClient.
+					ExpectExec(deleteStmt).
+					WithArgs(p.UniqueId).
+					WillReturnError(fmt.Errorf("network error"))
+
+				dbClient.
+					ExpectRollback()
+
+				res, err := multipartRepo.DeleteMultipart(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("network error")))
+			})
+		})
+
+		When("success delete multipart", func() {
+			It("should return result", func() {
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.UniqueId).
+					WillReturnRows(findRows)
+
+				dbClient.
+					ExpectQuery(listStmt).
+					WithArgs(p.UniqueId).
+					WillReturnRows(listRows)
+
+				dbClient.
+					ExpectExec(deletePartStmt).
+					WithArgs(p.UniqueId).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				dbClient.
+					ExpectExec(deleteStmt).
+					WithArgs(p.UniqueId).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				dbClient.
+					ExpectCommit()
+
+				res, err := multipartRepo.DeleteMultipart(ctx, p)
+
+				Expect(err).To(BeNil())
+				Expect(res).To(Equal(&repository.DeleteMultipartResult{
+					Parts: []repository.PartItem{
+						{
+							PartNumber:     1,
+							Path:           "storage/partial/id.1.a",
+							Size:           1024,
+							ChecksumSha256: "sha256-1",
+							UploadedAt:     time.UnixMilli(currentTs.UnixMilli()).UTC(),
+						},
+					},
+					DeletedAt: time.UnixMilli(currentTs.UnixMilli()).UTC(),
+				}))
+			})
+		})
+	})
+})
@@ -9,9 +9,11 @@ import (
 )
 
 type mysqlRepository struct {
-	dbClient mysql.Pingable
-	authRepo *auth
-	fileRepo *file
+	dbClient      mysql.Pingable
+	authRepo      *auth
+	fileRepo      *file
+	uploadRepo    *upload
+	multipartRepo *multipart
 }
 
 func (p *mysqlRepository) Init(ctx context.Context) error {
@@ -30,6 +32,14 @@ func (p *mysqlRepository) GetFile() repository.File {
 	return p.fileRepo
 }
 
+func (p *mysqlRepository) GetUpload() repository.Upload {
+	return p.uploadRepo
+}
+
+func (p *mysqlRepository) GetMultipart() repository.Multipart {
+	return p.multipartRepo
+}
+
 func NewRepository(opts ...RepoOption) (*mysqlRepository, error) {
 	p := RepositoryParam{}
 	for _, opt := range opts {
@@ -55,11 +65,19 @@ func NewRepository(opts ...RepoOption) (*mysqlRepository, error) {
 	fileRepo := &file{
 		gormClient: p.gormClient,
 	}
+	uploadRepo := &upload{
+		gormClient: p.gormClient,
+	}
+	multipartRepo := &multipart{
+		gormClient: p.gormClient,
+	}
 
 	repo := &mysqlRepository{
-		dbClient: dbClient,
-		authRepo: authRepo,
-		fileRepo: fileRepo,
+		dbClient:      dbClient,
+		authRepo:      authRepo,
+		fileRepo:      fileRepo,
+		uploadRepo:    uploadRepo,
+		multipartRepo: multipartRepo,
 	}
 	return repo, nil
 }
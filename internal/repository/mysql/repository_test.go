@@ -80,6 +80,44 @@ var _ = Describe("Repository Provider", func() {
 		})
 	})
 
+	Context("GetUpload function", Label("unit"), func() {
+		var (
+			provider repository.Repository
+		)
+
+		BeforeEach(func() {
+			mOpt := repository_mysql.WithDbClient(&sql.DB{})
+			provider, _ = repository_mysql.NewRepository(mOpt)
+		})
+
+		When("function is called", func() {
+			It("should return result", func() {
+				res := provider.GetUpload()
+
+				Expect(res).ToNot(BeNil())
+			})
+		})
+	})
+
+	Context("GetMultipart function", Label("unit"), func() {
+		var (
+			provider repository.Repository
+		)
+
+		BeforeEach(func() {
+			mOpt := repository_mysql.WithDbClient(&sql.DB{})
+			provider, _ = repository_mysql.NewRepository(mOpt)
+		})
+
+		When("function is called", func() {
+			It("should return result", func() {
+				res := provider.GetMultipart()
+
+				Expect(res).ToNot(BeNil())
+			})
+		})
+	})
+
 	Context("Init function", Label("unit"), func() {
 		var (
 			provider repository.Repository
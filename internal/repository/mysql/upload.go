@@ -0,0 +1,278 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/go-seidon/hippo/internal/repository"
+	"github.com/go-seidon/provider/typeconv"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/plugin/dbresolver"
+)
+
+type upload struct {
+	gormClient *gorm.DB
+}
+
+func (r *upload) CreateUpload(ctx context.Context, p repository.CreateUploadParam) (*repository.CreateUploadResult, error) {
+	createParam := &Upload{
+		Id:            p.UniqueId,
+		Name:          p.Name,
+		Extension:     p.Extension,
+		Path:          p.Path,
+		Size:          p.Size,
+		Offset:        0,
+		OwnerClientId: p.OwnerClientId,
+		CreatedAt:     p.CreatedAt.UnixMilli(),
+		UpdatedAt:     p.CreatedAt.UnixMilli(),
+	}
+	createRes := r.gormClient.
+		WithContext(ctx).
+		Clauses(dbresolver.Write).
+		Create(createParam)
+	if createRes.Error != nil {
+		return nil, createRes.Error
+	}
+
+	res := &repository.CreateUploadResult{
+		UniqueId:  createParam.Id,
+		Name:      createParam.Name,
+		Extension: createParam.Extension,
+		Path:      createParam.Path,
+		Size:      createParam.Size,
+		Offset:    createParam.Offset,
+		CreatedAt: time.UnixMilli(createParam.CreatedAt).UTC(),
+	}
+	return res, nil
+}
+
+// @note: upload is read from the primary since the offset must be up to date for resuming
+func (r *upload) RetrieveUpload(ctx context.Context, p repository.RetrieveUploadParam) (*repository.RetrieveUploadResult, error) {
+	query := r.gormClient.
+		WithContext(ctx).
+		Clauses(dbresolver.Write)
+
+	upload := &Upload{}
+	findRes := query.
+		Select("id, name, extension, path, size, `offset`, file_id, owner_client_id, created_at, updated_at, completed_at").
+		First(upload, "id = ?", p.UniqueId)
+	if findRes.Error != nil {
+		if errors.Is(findRes.Error, gorm.ErrRecordNotFound) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, findRes.Error
+	}
+
+	var completedAt *time.Time
+	if upload.CompletedAt.Valid {
+		completedAt = typeconv.Time(time.UnixMilli(upload.CompletedAt.Int64).UTC())
+	}
+
+	res := &repository.RetrieveUploadResult{
+		UniqueId:      upload.Id,
+		Name:          upload.Name,
+		Extension:     upload.Extension,
+		Path:          upload.Path,
+		Size:          upload.Size,
+		Offset:        upload.Offset,
+		FileId:        upload.FileId,
+		OwnerClientId: upload.OwnerClientId,
+		CreatedAt:     time.UnixMilli(upload.CreatedAt).UTC(),
+		UpdatedAt:     time.UnixMilli(upload.UpdatedAt).UTC(),
+		CompletedAt:   completedAt,
+	}
+	return res, nil
+}
+
+// @note: the upload record is locked while the chunk is appended
+// so concurrent requests of the same upload can't write at the same offset
+func (r *upload) AppendUpload(ctx context.Context, p repository.AppendUploadParam) (*repository.AppendUploadResult, error) {
+	tx := r.gormClient.
+		WithContext(ctx).
+		Clauses(dbresolver.Write).
+		Begin()
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	upload := &Upload{}
+	findRes := tx.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id, path, size, `offset`, completed_at").
+		First(upload, "id = ?", p.UniqueId)
+	if findRes.Error != nil {
+		txRes := tx.Rollback()
+		if txRes.Error != nil {
+			return nil, txRes.Error
+		}
+		if errors.Is(findRes.Error, gorm.ErrRecordNotFound) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, findRes.Error
+	}
+
+	if upload.CompletedAt.Valid || upload.Offset != p.Offset {
+		txRes := tx.Rollback()
+		if txRes.Error != nil {
+			return nil, txRes.Error
+		}
+		return nil, repository.ErrConflict
+	}
+
+	fn, err := p.AppendFn(ctx, repository.AppendFnParam{
+		FilePath: upload.Path,
+		Offset:   upload.Offset,
+		Size:     upload.Size,
+	})
+	if err != nil {
+		txRes := tx.Rollback()
+		if txRes.Error != nil {
+			return nil, txRes.Error
+		}
+		return nil, err
+	}
+
+	updateRes := tx.
+		Model(&Upload{}).
+		Where("id = ?", p.UniqueId).
+		Updates(map[string]interface{}{
+			"offset":     fn.Size,
+			"updated_at": p.UpdatedAt.UnixMilli(),
+		})
+	if updateRes.Error != nil {
+		txRes := tx.Rollback()
+		if txRes.Error != nil {
+			return nil, txRes.Error
+		}
+		return nil, updateRes.Error
+	}
+
+	txRes := tx.Commit()
+	if txRes.Error != nil {
+		return nil, txRes.Error
+	}
+
+	res := &repository.AppendUploadResult{
+		UniqueId:  upload.Id,
+		Size:      upload.Size,
+		Offset:    fn.Size,
+		UpdatedAt: time.UnixMilli(p.UpdatedAt.UnixMilli()).UTC(),
+	}
+	return res, nil
+}
+
+// @note: return `ErrNotFound` if the upload is not available or already completed
+func (r *upload) CompleteUpload(ctx context.Context, p repository.CompleteUploadParam) (*repository.CompleteUploadResult, error) {
+	updateRes := r.gormClient.
+		WithContext(ctx).
+		Clauses(dbresolver.Write).
+		Model(&Upload{}).
+		Where("id = ? AND completed_at IS NULL", p.UniqueId).
+		Updates(map[string]interface{}{
+			"file_id":      p.FileId,
+			"updated_at":   p.CompletedAt.UnixMilli(),
+			"completed_at": p.CompletedAt.UnixMilli(),
+		})
+	if updateRes.Error != nil {
+		return nil, updateRes.Error
+	}
+	if updateRes.RowsAffected == 0 {
+		return nil, repository.ErrNotFound
+	}
+
+	res := &repository.CompleteUploadResult{
+		UniqueId:    p.UniqueId,
+		FileId:      p.FileId,
+		CompletedAt: time.UnixMilli(p.CompletedAt.UnixMilli()).UTC(),
+	}
+	return res, nil
+}
+
+func (r *upload) DeleteUpload(ctx context.Context, p repository.DeleteUploadParam) (*repository.DeleteUploadResult, error) {
+	tx := r.gormClient.
+		WithContext(ctx).
+		Clauses(dbresolver.Write).
+		Begin()
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	upload := &Upload{}
+	findRes := tx.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id, path").
+		First(upload, "id = ?", p.UniqueId)
+	if findRes.Error != nil {
+		txRes := tx.Rollback()
+		if txRes.Error != nil {
+			return nil, txRes.Error
+		}
+		if errors.Is(findRes.Error, gorm.ErrRecordNotFound) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, findRes.Error
+	}
+
+	deleteRes := tx.
+		Where("id = ?", p.UniqueId).
+		Delete(&Upload{})
+	if deleteRes.Error != nil {
+		txRes := tx.Rollback()
+		if txRes.Error != nil {
+			return nil, txRes.Error
+		}
+		return nil, deleteRes.Error
+	}
+
+	err := p.DeleteFn(ctx, repository.DeleteFnParam{
+		FilePath: upload.Path,
+	})
+	if err != nil {
+		txRes := tx.Rollback()
+		if txRes.Error != nil {
+			return nil, txRes.Error
+		}
+		return nil, err
+	}
+
+	txRes := tx.Commit()
+	if txRes.Error != nil {
+		return nil, txRes.Error
+	}
+
+	res := &repository.DeleteUploadResult{
+		DeletedAt: time.UnixMilli(p.DeletedAt.UnixMilli()).UTC(),
+	}
+	return res, nil
+}
+
+type UploadParam struct {
+	GormClient *gorm.DB
+}
+
+func NewUpload(p UploadParam) *upload {
+	return &upload{
+		gormClient: p.GormClient,
+	}
+}
+
+type Upload struct {
+	Id            string        `gorm:"column:id;primaryKey"`
+	Name          string        `gorm:"column:name"`
+	Extension     string        `gorm:"column:extension"`
+	Path          string        `gorm:"column:path"`
+	Size          int64         `gorm:"column:size"`
+	Offset        int64         `gorm:"column:offset"`
+	FileId        string        `gorm:"column:file_id"`
+	OwnerClientId string        `gorm:"column:owner_client_id"`
+	CreatedAt     int64         `gorm:"column:created_at"`
+	UpdatedAt     int64         `gorm:"column:updated_at"`
+	CompletedAt   sql.NullInt64 `gorm:"column:completed_at;<-:update"`
+}
+
+func (Upload) TableName() string {
+	return "upload"
+}
@@ -0,0 +1,658 @@
+package mysql_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-seidon/hippo/internal/repository"
+	repository_mysql "github.com/go-seidon/hippo/internal/repository/mysql"
+	"github.com/go-seidon/provider/typeconv"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	gorm_mysql "gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("Upload Repository", func() {
+	var (
+		ctx        context.Context
+		currentTs  time.Time
+		dbClient   sqlmock.Sqlmock
+		uploadRepo repository.Upload
+	)
+
+	BeforeEach(func() {
+		var (
+			db  *sql.DB
+			err error
+		)
+
+		ctx = context.Background()
+		currentTs = time.Now().UTC()
+		db, dbClient, err = sqlmock.New()
+		if err != nil {
+			AbortSuite("failed create db mock: " + err.Error())
+		}
+
+		gormClient, err := gorm.Open(gorm_mysql.New(gorm_mysql.Config{
+			Conn:                      db,
+			SkipInitializeWithVersion: true,
+		}), &gorm.Config{
+			DisableAutomaticPing: true,
+		})
+		if err != nil {
+			AbortSuite("failed create gorm client: " + err.Error())
+		}
+		uploadRepo = repository_mysql.NewUpload(repository_mysql.UploadParam{
+			GormClient: gormClient,
+		})
+	})
+
+	AfterEach(func() {
+		err := dbClient.ExpectationsWereMet()
+		if err != nil {
+			AbortSuite("some expectations were not met " + err.Error())
+		}
+	})
+
+	Context("CreateUpload function", Label("unit"), func() {
+		var (
+			p          repository.CreateUploadParam
+			insertStmt string
+		)
+
+		BeforeEach(func() {
+			p = repository.CreateUploadParam{
+				UniqueId:      "id",
+				Name:          "dolphin",
+				Extension:     "jpg",
+				Path:          "storage/partial/id",
+				Size:          2048,
+				CreatedAt:     currentTs,
+				OwnerClientId: "client-record-id",
+			}
+			insertStmt = regexp.QuoteMeta("INSERT INTO `upload` (`id`,`name`,`extension`,`path`,`size`,`offset`,`file_id`,`owner_client_id`,`created_at`,`updated_at`) VALUES (?,?,?,?,?,?,?,?,?,?)")
+		})
+
+		When("failed create upload", func() {
+			It("should return error", func() {
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectExec(insertStmt).
+					WithArgs(
+						p.UniqueId,
+						p.Name,
+						p.Extension,
+						p.Path,
+						p.Size,
+						int64(0),
+						"",
+						p.OwnerClientId,
+						p.CreatedAt.UnixMilli(),
+						p.CreatedAt.UnixMilli(),
+					).
+					WillReturnError(fmt.Errorf("network error"))
+
+				dbClient.
+					ExpectRollback()
+
+				res, err := uploadRepo.CreateUpload(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("network error")))
+			})
+		})
+
+		When("success create upload", func() {
+			It("should return result", func() {
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectExec(insertStmt).
+					WithArgs(
+						p.UniqueId,
+						p.Name,
+						p.Extension,
+						p.Path,
+						p.Size,
+						int64(0),
+						"",
+						p.OwnerClientId,
+						p.CreatedAt.UnixMilli(),
+						p.CreatedAt.UnixMilli(),
+					).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				dbClient.
+					ExpectCommit()
+
+				res, err := uploadRepo.CreateUpload(ctx, p)
+
+				Expect(err).To(BeNil())
+				Expect(res).To(Equal(&repository.CreateUploadResult{
+					UniqueId:  p.UniqueId,
+					Name:      p.Name,
+					Extension: p.Extension,
+					Path:      p.Path,
+					Size:      p.Size,
+					Offset:    0,
+					CreatedAt: time.UnixMilli(p.CreatedAt.UnixMilli()).UTC(),
+				}))
+			})
+		})
+	})
+
+	Context("RetrieveUpload function", Label("unit"), func() {
+		var (
+			p        repository.RetrieveUploadParam
+			findStmt string
+		)
+
+		BeforeEach(func() {
+			p = repository.RetrieveUploadParam{
+				UniqueId: "id",
+			}
+			findStmt = regexp.QuoteMeta("SELECT id, name, extension, path, size, `offset`, file_id, owner_client_id, created_at, updated_at, completed_at FROM `upload` WHERE id = ? ORDER BY `upload`.`id` LIMIT 1")
+		})
+
+		When("failed find upload", func() {
+			It("should return error", func() {
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.UniqueId).
+					WillReturnError(fmt.Errorf("network error"))
+
+				res, err := uploadRepo.RetrieveUpload(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("network error")))
+			})
+		})
+
+		When("upload is not available", func() {
+			It("should return error", func() {
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.UniqueId).
+					WillReturnError(gorm.ErrRecordNotFound)
+
+				res, err := uploadRepo.RetrieveUpload(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(repository.ErrNotFound))
+			})
+		})
+
+		When("success find upload", func() {
+			It("should return result", func() {
+				rows := sqlmock.
+					NewRows([]string{
+						"id", "name", "extension", "path", "size", "offset",
+						"file_id", "owner_client_id", "created_at", "updated_at", "completed_at",
+					}).
+					AddRow(
+						"id", "dolphin", "jpg", "storage/partial/id", 2048, 2048,
+						"file-id", "client-record-id", currentTs.UnixMilli(), currentTs.UnixMilli(), currentTs.UnixMilli(),
+					)
+
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.UniqueId).
+					WillReturnRows(rows)
+
+				res, err := uploadRepo.RetrieveUpload(ctx, p)
+
+				Expect(err).To(BeNil())
+				Expect(res).To(Equal(&repository.RetrieveUploadResult{
+					UniqueId:      "id",
+					Name:          "dolphin",
+					Extension:     "jpg",
+					Path:          "storage/partial/id",
+					Size:          2048,
+					Offset:        2048,
+					FileId:        "file-id",
+					OwnerClientId: "client-record-id",
+					CreatedAt:     time.UnixMilli(currentTs.UnixMilli()).UTC(),
+					UpdatedAt:     time.UnixMilli(currentTs.UnixMilli()).UTC(),
+					CompletedAt:   typeconv.Time(time.UnixMilli(currentTs.UnixMilli()).UTC()),
+				}))
+			})
+		})
+	})
+
+	Context("AppendUpload function", Label("unit"), func() {
+		var (
+			p          repository.AppendUploadParam
+			findStmt   string
+			updateStmt string
+			findRows   *sqlmock.Rows
+		)
+
+		BeforeEach(func() {
+			p = repository.AppendUploadParam{
+				UniqueId:  "id",
+				Offset:    1024,
+				UpdatedAt: currentTs,
+				AppendFn: func(ctx context.Context, p repository.AppendFnParam) (*repository.AppendFnResult, error) {
+					return &repository.AppendFnResult{
+						Size: 2048,
+					}, nil
+				},
+			}
+			findStmt = regexp.QuoteMeta("SELECT id, path, size, `offset`, completed_at FROM `upload` WHERE id = ? ORDER BY `upload`.`id` LIMIT 1 FOR UPDATE")
+			updateStmt = regexp.QuoteMeta("UPDATE `upload` SET `offset`=?,`updated_at`=? WHERE id = ?")
+			findRows = sqlmock.
+				NewRows([]string{"id", "path", "size", "offset", "completed_at"}).
+				AddRow("id", "storage/partial/id", 4096, 1024, nil)
+		})
+
+		When("failed begin trx", func() {
+			It("should return error", func() {
+				dbClient.
+					ExpectBegin().
+					WillReturnError(fmt.Errorf("begin error"))
+
+				res, err := uploadRepo.AppendUpload(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("begin error")))
+			})
+		})
+
+		When("upload is not available", func() {
+			It("should return error", func() {
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.UniqueId).
+					WillReturnError(gorm.ErrRecordNotFound)
+
+				dbClient.
+					ExpectRollback()
+
+				res, err := uploadRepo.AppendUpload(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(repository.ErrNotFound))
+			})
+		})
+
+		When("offset is not matched", func() {
+			It("should return error", func() {
+				p.Offset = 0
+
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.UniqueId).
+					WillReturnRows(findRows)
+
+				dbClient.
+					ExpectRollback()
+
+				res, err := uploadRepo.AppendUpload(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(repository.ErrConflict))
+			})
+		})
+
+		When("upload is already completed", func() {
+			It("should return error", func() {
+				findRows = sqlmock.
+					NewRows([]string{"id", "path", "size", "offset", "completed_at"}).
+					AddRow("id", "storage/partial/id", 1024, 1024, currentTs.UnixMilli())
+
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.UniqueId).
+					WillReturnRows(findRows)
+
+				dbClient.
+					ExpectRollback()
+
+				res, err := uploadRepo.AppendUpload(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(repository.ErrConflict))
+			})
+		})
+
+		When("failed execute callback", func() {
+			It("should return error", func() {
+				p.AppendFn = func(ctx context.Context, p repository.AppendFnParam) (*repository.AppendFnResult, error) {
+					return nil, fmt.Errorf("disk error")
+				}
+
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.UniqueId).
+					WillReturnRows(findRows)
+
+				dbClient.
+					ExpectRollback()
+
+				res, err := uploadRepo.AppendUpload(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("disk error")))
+			})
+		})
+
+		When("failed update offset", func() {
+			It("should return error", func() {
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.UniqueId).
+					WillReturnRows(findRows)
+
+				dbClient.
+					ExpectExec(updateStmt).
+					WithArgs(int64(2048), p.UpdatedAt.UnixMilli(), p.UniqueId).
+					WillReturnError(fmt.Errorf("network error"))
+
+				dbClient.
+					ExpectRollback()
+
+				res, err := uploadRepo.AppendUpload(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("network error")))
+			})
+		})
+
+		When("failed commit trx", func() {
+			It("should return error", func() {
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.UniqueId).
+					WillReturnRows(findRows)
+
+				dbClient.
+					ExpectExec(updateStmt).
+					WithArgs(int64(2048), p.UpdatedAt.UnixMilli(), p.UniqueId).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				dbClient.
+					ExpectCommit().
+					WillReturnError(fmt.Errorf("commit error"))
+
+				res, err := uploadRepo.AppendUpload(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("commit error")))
+			})
+		})
+
+		When("success append upload", func() {
+			It("should return result", func() {
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.UniqueId).
+					WillReturnRows(findRows)
+
+				dbClient.
+					ExpectExec(updateStmt).
+					WithArgs(int64(2048), p.UpdatedAt.UnixMilli(), p.UniqueId).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				dbClient.
+					ExpectCommit()
+
+				res, err := uploadRepo.AppendUpload(ctx, p)
+
+				Expect(err).To(BeNil())
+				Expect(res).To(Equal(&repository.AppendUploadResult{
+					UniqueId:  "id",
+					Size:      4096,
+					Offset:    2048,
+					UpdatedAt: time.UnixMilli(currentTs.UnixMilli()).UTC(),
+				}))
+			})
+		})
+	})
+
+	Context("CompleteUpload function", Label("unit"), func() {
+		var (
+			p          repository.CompleteUploadParam
+			updateStmt string
+		)
+
+		BeforeEach(func() {
+			p = repository.CompleteUploadParam{
+				UniqueId:    "id",
+				FileId:      "file-id",
+				CompletedAt: currentTs,
+			}
+			updateStmt = regexp.QuoteMeta("UPDATE `upload` SET `completed_at`=?,`file_id`=?,`updated_at`=? WHERE id = ? AND completed_at IS NULL")
+		})
+
+		When("failed update upload", func() {
+			It("should return error", func() {
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectExec(updateStmt).
+					WithArgs(p.CompletedAt.UnixMilli(), p.FileId, p.CompletedAt.UnixMilli(), p.UniqueId).
+					WillReturnError(fmt.Errorf("network error"))
+
+				dbClient.
+					ExpectRollback()
+
+				res, err := uploadRepo.CompleteUpload(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("network error")))
+			})
+		})
+
+		When("upload is not available", func() {
+			It("should return error", func() {
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectExec(updateStmt).
+					WithArgs(p.CompletedAt.UnixMilli(), p.FileId, p.CompletedAt.UnixMilli(), p.UniqueId).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+
+				dbClient.
+					ExpectCommit()
+
+				res, err := uploadRepo.CompleteUpload(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(repository.ErrNotFound))
+			})
+		})
+
+		When("success complete upload", func() {
+			It("should return result", func() {
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectExec(updateStmt).
+					WithArgs(p.CompletedAt.UnixMilli(), p.FileId, p.CompletedAt.UnixMilli(), p.UniqueId).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				dbClient.
+					ExpectCommit()
+
+				res, err := uploadRepo.CompleteUpload(ctx, p)
+
+				Expect(err).To(BeNil())
+				Expect(res).To(Equal(&repository.CompleteUploadResult{
+					UniqueId:    "id",
+					FileId:      "file-id",
+					CompletedAt: time.UnixMilli(currentTs.UnixMilli()).UTC(),
+				}))
+			})
+		})
+	})
+
+	Context("DeleteUpload function", Label("unit"), func() {
+		var (
+			p          repository.DeleteUploadParam
+			findStmt   string
+			deleteStmt string
+			findRows   *sqlmock.Rows
+		)
+
+		BeforeEach(func() {
+			p = repository.DeleteUploadParam{
+				UniqueId:  "id",
+				DeletedAt: currentTs,
+				DeleteFn: func(ctx context.Context, p repository.DeleteFnParam) error {
+					return nil
+				},
+			}
+			findStmt = regexp.QuoteMeta("SELECT id, path FROM `upload` WHERE id = ? ORDER BY `upload`.`id` LIMIT 1 FOR UPDATE")
+			deleteStmt = regexp.QuoteMeta("DELETE FROM `upload` WHERE id = ?")
+			findRows = sqlmock.
+				NewRows([]string{"id", "path"}).
+				AddRow("id", "storage/partial/id")
+		})
+
+		When("failed begin trx", func() {
+			It("should return error", func() {
+				dbClient.
+					ExpectBegin().
+					WillReturnError(fmt.Errorf("begin error"))
+
+				res, err := uploadRepo.DeleteUpload(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("begin error")))
+			})
+		})
+
+		When("upload is not available", func() {
+			It("should return error", func() {
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.UniqueId).
+					WillReturnError(gorm.ErrRecordNotFound)
+
+				dbClient.
+					ExpectRollback()
+
+				res, err := uploadRepo.DeleteUpload(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(repository.ErrNotFound))
+			})
+		})
+
+		When("failed delete upload", func() {
+			It("should return error", func() {
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.UniqueId).
+					WillReturnRows(findRows)
+
+				dbClient.
+					ExpectExec(deleteStmt).
+					WithArgs(p.UniqueId).
+					WillReturnError(fmt.Errorf("network error"))
+
+				dbClient.
+					ExpectRollback()
+
+				res, err := uploadRepo.DeleteUpload(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("network error")))
+			})
+		})
+
+		When("failed execute callback", func() {
+			It("should return error", func() {
+				p.DeleteFn = func(ctx context.Context, p repository.DeleteFnParam) error {
+					return fmt.Errorf("disk error")
+				}
+
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.UniqueId).
+					WillReturnRows(findRows)
+
+				dbClient.
+					ExpectExec(deleteStmt).
+					WithArgs(p.UniqueId).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				dbClient.
+					ExpectRollback()
+
+				res, err := uploadRepo.DeleteUpload(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("disk error")))
+			})
+		})
+
+		When("success delete upload", func() {
+			It("should return result", func() {
+				dbClient.
+					ExpectBegin()
+
+				dbClient.
+					ExpectQuery(findStmt).
+					WithArgs(p.UniqueId).
+					WillReturnRows(findRows)
+
+				dbClient.
+					ExpectExec(deleteStmt).
+					WithArgs(p.UniqueId).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				dbClient.
+					ExpectCommit()
+
+				res, err := uploadRepo.DeleteUpload(ctx, p)
+
+				Expect(err).To(BeNil())
+				Expect(res).To(Equal(&repository.DeleteUploadResult{
+					DeletedAt: time.UnixMilli(currentTs.UnixMilli()).UTC(),
+				}))
+			})
+		})
+	})
+})
@@ -12,4 +12,6 @@ type Repository interface {
 	Ping(ctx context.Context) error
 	GetAuth() Auth
 	GetFile() File
+	GetUpload() Upload
+	GetMultipart() Multipart
 }
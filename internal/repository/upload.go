@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+type (
+	AppendFn func(ctx context.Context, p AppendFnParam) (*AppendFnResult, error)
+)
+
+type Upload interface {
+	CreateUpload(ctx context.Context, p CreateUploadParam) (*CreateUploadResult, error)
+	RetrieveUpload(ctx context.Context, p RetrieveUploadParam) (*RetrieveUploadResult, error)
+	AppendUpload(ctx context.Context, p AppendUploadParam) (*AppendUploadResult, error)
+	CompleteUpload(ctx context.Context, p CompleteUploadParam) (*CompleteUploadResult, error)
+	DeleteUpload(ctx context.Context, p DeleteUploadParam) (*DeleteUploadResult, error)
+}
+
+type CreateUploadParam struct {
+	UniqueId  string
+	Name      string
+	Extension string
+	Path      string
+	Size      int64
+	CreatedAt time.Time
+	// @note: id of the auth client record which created the upload,
+	// empty when it's not created by a client
+	OwnerClientId string
+}
+
+type CreateUploadResult struct {
+	UniqueId  string
+	Name      string
+	Extension string
+	Path      string
+	Size      int64
+	Offset    int64
+	CreatedAt time.Time
+}
+
+type RetrieveUploadParam struct {
+	UniqueId string
+}
+
+type RetrieveUploadResult struct {
+	UniqueId      string
+	Name          string
+	Extension     string
+	Path          string
+	Size          int64
+	Offset        int64
+	FileId        string
+	OwnerClientId string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	CompletedAt   *time.Time
+}
+
+// @note: return `ErrConflict` if the current offset is not equal to the param offset
+// the offset is moved to the appended size returned by AppendFn
+type AppendUploadParam struct {
+	UniqueId  string
+	Offset    int64
+	UpdatedAt time.Time
+	AppendFn  AppendFn
+}
+
+type AppendFnParam struct {
+	FilePath string
+	Offset   int64
+	Size     int64
+}
+
+type AppendFnResult struct {
+	Size int64
+}
+
+type AppendUploadResult struct {
+	UniqueId  string
+	Size      int64
+	Offset    int64
+	UpdatedAt time.Time
+}
+
+type CompleteUploadParam struct {
+	UniqueId    string
+	FileId      string
+	CompletedAt time.Time
+}
+
+type CompleteUploadResult struct {
+	UniqueId    string
+	FileId      string
+	CompletedAt time.Time
+}
+
+type DeleteUploadParam struct {
+	UniqueId  string
+	DeletedAt time.Time
+	DeleteFn  DeleteFn
+}
+
+type DeleteUploadResult struct {
+	DeletedAt time.Time
+}
@@ -4,12 +4,13 @@ import (
 	"context"
 	"fmt"
 	net_http "net/http"
+	"time"
 
 	"github.com/go-seidon/hippo/internal/app"
 	"github.com/go-seidon/hippo/internal/auth"
-	"github.com/go-seidon/hippo/internal/file"
 	"github.com/go-seidon/hippo/internal/filesystem"
 	"github.com/go-seidon/hippo/internal/healthcheck"
+	"github.com/go-seidon/hippo/internal/job"
 	"github.com/go-seidon/hippo/internal/repository"
 	"github.com/go-seidon/hippo/internal/resthandler"
 	"github.com/go-seidon/hippo/internal/restmiddleware"
@@ -22,6 +23,7 @@ import (
 	"github.com/go-seidon/provider/health"
 	"github.com/go-seidon/provider/identity/ksuid"
 	"github.com/go-seidon/provider/logging"
+	"github.com/go-seidon/provider/random/crypto"
 	"github.com/go-seidon/provider/serialization/json"
 	"github.com/go-seidon/provider/validation/govalidator"
 	"github.com/labstack/echo/v4"
@@ -34,6 +36,7 @@ type restApp struct {
 	logger       logging.Logger
 	repository   repository.Repository
 	healthClient health.HealthCheck
+	jobScheduler job.Scheduler
 }
 
 func (a *restApp) Run(ctx context.Context) error {
@@ -49,6 +52,11 @@ func (a *restApp) Run(ctx context.Context) error {
 		return err
 	}
 
+	err = a.jobScheduler.Start(ctx)
+	if err != nil {
+		return err
+	}
+
 	a.logger.Infof("Listening on: %s", a.config.GetAddress())
 	err = a.server.Start(a.config.GetAddress())
 	if err != net_http.ErrServerClosed {
@@ -65,6 +73,11 @@ func (a *restApp) Stop(ctx context.Context) error {
 		a.logger.Errorf("Failed stopping healthcheck, err: %s", err.Error())
 	}
 
+	err = a.jobScheduler.Stop(ctx)
+	if err != nil {
+		a.logger.Errorf("Failed stopping job scheduler, err: %s", err.Error())
+	}
+
 	return a.server.Shutdown(ctx)
 }
 
@@ -111,8 +124,54 @@ func NewRestApp(opts ...RestAppOption) (*restApp, error) {
 		}
 	}
 
+	verificationCache := p.VerificationCache
+	if verificationCache == nil {
+		verificationCache, err = app.NewDefaultVerificationCache(p.Config)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	jobScheduler := p.JobScheduler
+	if jobScheduler == nil {
+		jobScheduler, err = app.NewDefaultJobScheduler(p.Config, logger, repo, verificationCache)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	server := p.Server
 	if p.Server == nil {
+		fileManager, err := app.NewDefaultFileManager(p.Config)
+		if err != nil {
+			return nil, err
+		}
+
+		dirManager, err := app.NewDefaultDirectoryManager(p.Config)
+		if err != nil {
+			return nil, err
+		}
+
+		locator, err := app.NewDefaultUploadLocation(p.Config)
+		if err != nil {
+			return nil, err
+		}
+
+		policy, err := app.NewDefaultUploadPolicy(p.Config)
+		if err != nil {
+			return nil, err
+		}
+
+		fileScanner, err := app.NewDefaultScanner(p.Config)
+		if err != nil {
+			return nil, err
+		}
+
+		tokenClient, err := app.NewDefaultTokenAuth(p.Config, repo.GetAuth(), verificationCache)
+		if err != nil {
+			return nil, err
+		}
+
 		e := echo.New()
 		e.Debug = p.Config.AppDebug
 		e.HTTPErrorHandler = echoapp.NewErrorHandler(echoapp.ErrorHandlerParam{
@@ -131,28 +190,56 @@ func NewRestApp(opts ...RestAppOption) (*restApp, error) {
 		bcryptHasher := bcrypt.NewHasher()
 		govalidator := govalidator.NewValidator()
 		ksuIdentifier := ksuid.NewIdentifier()
-		fileManager := filesystem.NewFileManager()
-		dirManager := filesystem.NewDirectoryManager()
 		clock := datetime.NewClock()
-		locator := file.NewDailyRotate(file.DailyRotateParam{})
+		secretRandomizer := crypto.NewRandomizer(
+			crypto.WithDictionary("0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"),
+		)
 
 		basicClient := auth.NewBasicAuth(auth.NewBasicAuthParam{
-			Encoder:  base64Encoder,
-			Hasher:   bcryptHasher,
-			AuthRepo: repo.GetAuth(),
+			Encoder:      base64Encoder,
+			Hasher:       bcryptHasher,
+			Clock:        clock,
+			AuthRepo:     repo.GetAuth(),
+			AdminClients: p.Config.AuthAdminClients,
+			Cache:        verificationCache,
 		})
 
 		fileClient := service.NewFile(service.FileParam{
 			FileRepo:    repo.GetFile(),
+			AuthRepo:    repo.GetAuth(),
 			FileManager: fileManager,
 			Logger:      logger,
 			Identifier:  ksuIdentifier,
 			Clock:       clock,
 			DirManager:  dirManager,
 			Locator:     locator,
+			Policy:      policy,
+			Scanner:     fileScanner,
 			Validator:   govalidator,
 			Config: &service.FileConfig{
-				UploadDir: p.Config.UploadDirectory,
+				UploadDir:         p.Config.UploadDirectory,
+				ChecksumMd5:       p.Config.UploadChecksumMd5,
+				Deduplicate:       p.Config.UploadDeduplicate,
+				TrashEnabled:      p.Config.FileTrashEnabled,
+				TrashDir:          p.Config.FileTrashDirectory,
+				ScanFailOpen:      p.Config.ScannerFailOpen,
+				ScanSkipOversized: p.Config.ScannerSkipOversized,
+				UnownedAccess:     p.Config.FileUnownedAccess,
+			},
+		})
+
+		uploadClient := service.NewUpload(service.UploadParam{
+			UploadRepo:     repo.GetUpload(),
+			FileClient:     fileClient,
+			PartialManager: filesystem.NewPartialManager(),
+			DirManager:     filesystem.NewDirectoryManager(),
+			Logger:         logger,
+			Identifier:     ksuIdentifier,
+			Clock:          clock,
+			Validator:      govalidator,
+			Config: &service.UploadConfig{
+				PartialDir: p.Config.UploadPartialDirectory,
+				MaxSize:    p.Config.UploadPartialSize,
 			},
 		})
 
@@ -174,7 +261,12 @@ func NewRestApp(opts ...RestAppOption) (*restApp, error) {
 			Hasher:     bcryptHasher,
 			Identifier: ksuIdentifier,
 			Clock:      clock,
+			Randomizer: secretRandomizer,
 			AuthRepo:   repo.GetAuth(),
+			Cache:      verificationCache,
+			Config: &service.AuthClientConfig{
+				SecretGracePeriod: time.Duration(p.Config.AuthSecretGracePeriod) * time.Second,
+			},
 		})
 		authHandler := resthandler.NewAuth(resthandler.AuthParam{
 			AuthClient: authClient,
@@ -185,24 +277,69 @@ func NewRestApp(opts ...RestAppOption) (*restApp, error) {
 			FileParser: multipart.FileParser,
 		})
 
+		uploadHandler := resthandler.NewUpload(resthandler.UploadParam{
+			UploadClient: uploadClient,
+			Config: &resthandler.UploadConfig{
+				MaxSize: p.Config.UploadPartialSize,
+			},
+		})
+
 		basicAuth := restmiddleware.NewBasicAuth(restmiddleware.BasicAuthParam{
 			Serializer:  jsonSerializer,
 			BasicClient: basicClient,
 		})
-		basicAuthMiddleware := echo.WrapMiddleware(basicAuth.Handle)
+		authMiddleware := echo.WrapMiddleware(basicAuth.Handle)
 
 		basicGroup := e.Group("")
 		basicGroup.GET("/info", basicHandler.GetAppInfo)
+		basicGroup.OPTIONS("/v1/upload", uploadHandler.GetUploadOptions)
+
+		// @note: bearer token is accepted along with the basic auth when it's enabled
+		if tokenClient != nil {
+			bearerAuth := restmiddleware.NewBearerAuth(restmiddleware.BearerAuthParam{
+				Serializer:  jsonSerializer,
+				TokenClient: tokenClient,
+				Fallback:    basicAuth.Handle,
+			})
+			authMiddleware = echo.WrapMiddleware(bearerAuth.Handle)
+
+			oauthHandler := resthandler.NewOAuth(resthandler.OAuthParam{
+				TokenClient: tokenClient,
+			})
+			basicGroup.POST("/oauth/token", oauthHandler.IssueToken)
+		}
+
+		requireScope := func(scope string) echo.MiddlewareFunc {
+			m := restmiddleware.NewScope(restmiddleware.ScopeParam{
+				Serializer: jsonSerializer,
+				Scope:      scope,
+			})
+			return echo.WrapMiddleware(m.Handle)
+		}
+		clientAdmin := requireScope(auth.SCOPE_CLIENT_ADMIN)
+		fileRead := requireScope(auth.SCOPE_FILE_READ)
+		fileWrite := requireScope(auth.SCOPE_FILE_WRITE)
+		fileDelete := requireScope(auth.SCOPE_FILE_DELETE)
 
-		basicAuthGroup := e.Group("", basicAuthMiddleware)
-		basicAuthGroup.GET("/health", healthHandler.CheckHealth)
-		basicAuthGroup.POST("/v1/auth-client", authHandler.CreateClient)
-		basicAuthGroup.POST("/v1/auth-client/search", authHandler.SearchClient)
-		basicAuthGroup.GET("/v1/auth-client/:id", authHandler.GetClientById)
-		basicAuthGroup.PUT("/v1/auth-client/:id", authHandler.UpdateClientById)
-		basicAuthGroup.POST("/v1/file", fileHandler.UploadFile)
-		basicAuthGroup.GET("/v1/file/:id", fileHandler.RetrieveFileById)
-		basicAuthGroup.DELETE("/v1/file/:id", fileHandler.DeleteFileById)
+		authGroup := e.Group("", authMiddleware)
+		authGroup.GET("/health", healthHandler.CheckHealth)
+		authGroup.POST("/v1/auth-client", authHandler.CreateClient, clientAdmin)
+		authGroup.POST("/v1/auth-client/search", authHandler.SearchClient, clientAdmin)
+		authGroup.GET("/v1/auth-client/:id", authHandler.GetClientById, clientAdmin)
+		authGroup.PUT("/v1/auth-client/:id", authHandler.UpdateClientById, clientAdmin)
+		authGroup.DELETE("/v1/auth-client/:id", authHandler.DeleteClientById, clientAdmin)
+		authGroup.POST("/v1/auth-client/:id/rotate-secret", authHandler.RotateClientSecret, clientAdmin)
+		authGroup.POST("/v1/auth-client/:id/revoke-secret", authHandler.RevokeClientSecret, clientAdmin)
+		authGroup.POST("/v1/file", fileHandler.UploadFile, fileWrite)
+		authGroup.POST("/v1/file/search", fileHandler.SearchFile, fileRead)
+		authGroup.GET("/v1/file/:id", fileHandler.RetrieveFileById, fileRead)
+		authGroup.HEAD("/v1/file/:id", fileHandler.RetrieveFileMetaById, fileRead)
+		authGroup.DELETE("/v1/file/:id", fileHandler.DeleteFileById, fileDelete)
+		authGroup.POST("/v1/file/:id/restore", fileHandler.RestoreFileById, fileDelete)
+		authGroup.POST("/v1/upload", uploadHandler.CreateUpload, fileWrite)
+		authGroup.HEAD("/v1/upload/:id", uploadHandler.RetrieveUploadById, fileWrite)
+		authGroup.PATCH("/v1/upload/:id", uploadHandler.AppendUploadById, fileWrite)
+		authGroup.DELETE("/v1/upload/:id", uploadHandler.DeleteUploadById, fileWrite)
 	}
 
 	app := &restApp{
@@ -210,6 +347,7 @@ func NewRestApp(opts ...RestAppOption) (*restApp, error) {
 		config:       config,
 		logger:       logger,
 		healthClient: healthClient,
+		jobScheduler: jobScheduler,
 		repository:   repo,
 	}
 	return app, nil
@@ -12,6 +12,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	"github.com/go-seidon/hippo/internal/app"
+	mock_job "github.com/go-seidon/hippo/internal/job/mock"
 	mock_restapp "github.com/go-seidon/hippo/internal/restapp/mock"
 	mock_healthcheck "github.com/go-seidon/provider/health/mock"
 	mock_logging "github.com/go-seidon/provider/logging/mock"
@@ -50,11 +51,27 @@ var _ = Describe("App Package", func() {
 			})
 		})
 
+		When("storage provider is invalid", func() {
+			It("should return error", func() {
+				res, err := restapp.NewRestApp(
+					restapp.WithConfig(&app.Config{
+						RepositoryProvider: repository.PROVIDER_MYSQL,
+						UploadStorage:      "invalid",
+					}),
+					restapp.WithLogger(log),
+				)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("invalid storage provider")))
+			})
+		})
+
 		When("logger is not specified", func() {
 			It("should return result", func() {
 				res, err := restapp.NewRestApp(
 					restapp.WithConfig(&app.Config{
 						RepositoryProvider: repository.PROVIDER_MYSQL,
+						UploadStorage:      "local",
 					}),
 				)
 
@@ -68,6 +85,7 @@ var _ = Describe("App Package", func() {
 				res, err := restapp.NewRestApp(
 					restapp.WithConfig(&app.Config{
 						RepositoryProvider: repository.PROVIDER_MYSQL,
+						UploadStorage:      "local",
 						AppDebug:           true,
 					}),
 				)
@@ -82,6 +100,7 @@ var _ = Describe("App Package", func() {
 				res, err := restapp.NewRestApp(
 					restapp.WithConfig(&app.Config{
 						RepositoryProvider: repository.PROVIDER_MYSQL,
+						UploadStorage:      "local",
 						AppDebug:           true,
 						AppEnv:             "local",
 					}),
@@ -92,12 +111,32 @@ var _ = Describe("App Package", func() {
 			})
 		})
 
+		When("verification cache config is invalid", func() {
+			It("should return error", func() {
+				res, err := restapp.NewRestApp(
+					restapp.WithLogger(log),
+					restapp.WithConfig(&app.Config{
+						RepositoryProvider: repository.PROVIDER_MONGO,
+						UploadStorage:      "local",
+						AppEnv:             "local",
+						MongoMode:          "standalone",
+						MongoAuthMode:      "basic",
+						AuthCacheTtl:       60,
+					}),
+				)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("invalid cache max entries")))
+			})
+		})
+
 		When("parameter is specified", func() {
 			It("should return result", func() {
 				res, err := restapp.NewRestApp(
 					restapp.WithLogger(log),
 					restapp.WithConfig(&app.Config{
 						RepositoryProvider: repository.PROVIDER_MONGO,
+						UploadStorage:      "local",
 						AppEnv:             "local",
 						MongoMode:          "standalone",
 						MongoAuthMode:      "basic",
@@ -155,6 +194,7 @@ var _ = Describe("App Package", func() {
 			logger        *mock_logging.MockLogger
 			server        *mock_restapp.MockServer
 			healthService *mock_healthcheck.MockHealthCheck
+			jobScheduler  *mock_job.MockScheduler
 			repo          *mock_repository.MockRepository
 			ctx           context.Context
 		)
@@ -164,6 +204,7 @@ var _ = Describe("App Package", func() {
 			ctrl := gomock.NewController(t)
 			logger = mock_logging.NewMockLogger(ctrl)
 			healthService = mock_healthcheck.NewMockHealthCheck(ctrl)
+			jobScheduler = mock_job.NewMockScheduler(ctrl)
 			server = mock_restapp.NewMockServer(ctrl)
 			repo = mock_repository.NewMockRepository(ctrl)
 			fileRepo := mock_repository.NewMockFile(ctrl)
@@ -178,10 +219,12 @@ var _ = Describe("App Package", func() {
 					RESTAppHost:        "localhost",
 					RESTAppPort:        4949,
 					RepositoryProvider: "mysql",
+					UploadStorage:      "local",
 				}),
 				restapp.WithLogger(logger),
 				restapp.WithServer(server),
 				restapp.WithService(healthService),
+				restapp.WithJobScheduler(jobScheduler),
 				restapp.WithRepository(repo),
 			)
 
@@ -232,6 +275,37 @@ var _ = Describe("App Package", func() {
 			})
 		})
 
+		When("failed start job scheduler", func() {
+			It("should return error", func() {
+				logger.
+					EXPECT().
+					Infof(gomock.Eq("Running %s:%s"), gomock.Eq("mock-name-rest"), gomock.Eq("mock-version")).
+					Times(1)
+
+				healthService.
+					EXPECT().
+					Start(gomock.Eq(ctx)).
+					Return(nil).
+					Times(1)
+
+				repo.
+					EXPECT().
+					Init(gomock.Eq(ctx)).
+					Return(nil).
+					Times(1)
+
+				jobScheduler.
+					EXPECT().
+					Start(gomock.Eq(ctx)).
+					Return(fmt.Errorf("scheduler error")).
+					Times(1)
+
+				err := ra.Run(ctx)
+
+				Expect(err).To(Equal(fmt.Errorf("scheduler error")))
+			})
+		})
+
 		When("failed listen and serve", func() {
 			It("should return error", func() {
 				logger.
@@ -251,6 +325,12 @@ var _ = Describe("App Package", func() {
 					Return(nil).
 					Times(1)
 
+				jobScheduler.
+					EXPECT().
+					Start(gomock.Eq(ctx)).
+					Return(nil).
+					Times(1)
+
 				logger.
 					EXPECT().
 					Infof(gomock.Eq("Listening on: %s"), gomock.Eq("localhost:4949")).
@@ -287,6 +367,12 @@ var _ = Describe("App Package", func() {
 					Return(nil).
 					Times(1)
 
+				jobScheduler.
+					EXPECT().
+					Start(gomock.Eq(ctx)).
+					Return(nil).
+					Times(1)
+
 				logger.
 					EXPECT().
 					Infof(gomock.Eq("Listening on: %s"), gomock.Eq("localhost:4949")).
@@ -311,6 +397,7 @@ var _ = Describe("App Package", func() {
 			logger        *mock_logging.MockLogger
 			server        *mock_restapp.MockServer
 			healthService *mock_healthcheck.MockHealthCheck
+			jobScheduler  *mock_job.MockScheduler
 			ctx           context.Context
 		)
 
@@ -319,6 +406,7 @@ var _ = Describe("App Package", func() {
 			ctrl := gomock.NewController(t)
 			logger = mock_logging.NewMockLogger(ctrl)
 			healthService = mock_healthcheck.NewMockHealthCheck(ctrl)
+			jobScheduler = mock_job.NewMockScheduler(ctrl)
 			server = mock_restapp.NewMockServer(ctrl)
 			ra, _ = restapp.NewRestApp(
 				restapp.WithConfig(&app.Config{
@@ -327,10 +415,12 @@ var _ = Describe("App Package", func() {
 					RESTAppHost:        "localhost",
 					RESTAppPort:        4949,
 					RepositoryProvider: "mysql",
+					UploadStorage:      "local",
 				}),
 				restapp.WithLogger(logger),
 				restapp.WithServer(server),
 				restapp.WithService(healthService),
+				restapp.WithJobScheduler(jobScheduler),
 			)
 			ctx = context.Background()
 		})
@@ -348,6 +438,12 @@ var _ = Describe("App Package", func() {
 					Return(nil).
 					Times(1)
 
+				jobScheduler.
+					EXPECT().
+					Stop(gomock.Eq(ctx)).
+					Return(nil).
+					Times(1)
+
 				server.
 					EXPECT().
 					Shutdown(gomock.Eq(context.Background())).
@@ -373,6 +469,12 @@ var _ = Describe("App Package", func() {
 					Return(nil).
 					Times(1)
 
+				jobScheduler.
+					EXPECT().
+					Stop(gomock.Eq(ctx)).
+					Return(nil).
+					Times(1)
+
 				server.
 					EXPECT().
 					Shutdown(gomock.Eq(context.Background())).
@@ -403,6 +505,12 @@ var _ = Describe("App Package", func() {
 					Errorf(gomock.Eq("Failed stopping healthcheck, err: %s"), gomock.Eq("routine error")).
 					Times(1)
 
+				jobScheduler.
+					EXPECT().
+					Stop(gomock.Eq(ctx)).
+					Return(nil).
+					Times(1)
+
 				server.
 					EXPECT().
 					Shutdown(gomock.Eq(context.Background())).
@@ -414,5 +522,41 @@ var _ = Describe("App Package", func() {
 				Expect(err).To(Equal(fmt.Errorf("cant stop app")))
 			})
 		})
+
+		When("failed stop job scheduler", func() {
+			It("should log the error", func() {
+				logger.
+					EXPECT().
+					Infof(gomock.Eq("Stopping %s on: %s"), gomock.Eq("mock-name-rest"), gomock.Eq("localhost:4949")).
+					Times(1)
+
+				healthService.
+					EXPECT().
+					Stop(gomock.Eq(ctx)).
+					Return(nil).
+					Times(1)
+
+				jobScheduler.
+					EXPECT().
+					Stop(gomock.Eq(ctx)).
+					Return(fmt.Errorf("context deadline exceeded")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					Errorf(gomock.Eq("Failed stopping job scheduler, err: %s"), gomock.Eq("context deadline exceeded")).
+					Times(1)
+
+				server.
+					EXPECT().
+					Shutdown(gomock.Eq(context.Background())).
+					Return(nil).
+					Times(1)
+
+				err := ra.Stop(ctx)
+
+				Expect(err).To(BeNil())
+			})
+		})
 	})
 })
@@ -4,6 +4,8 @@ import (
 	"fmt"
 
 	"github.com/go-seidon/hippo/internal/app"
+	"github.com/go-seidon/hippo/internal/auth"
+	"github.com/go-seidon/hippo/internal/job"
 	"github.com/go-seidon/hippo/internal/repository"
 	"github.com/go-seidon/provider/health"
 	"github.com/go-seidon/provider/logging"
@@ -35,6 +37,9 @@ type RestAppParam struct {
 	Server       Server
 	Repository   repository.Repository
 	HealthClient health.HealthCheck
+	JobScheduler job.Scheduler
+	// @note: specify the same cache to share it between apps in the same process
+	VerificationCache auth.VerificationCache
 }
 
 type RestAppOption func(*RestAppParam)
@@ -68,3 +73,15 @@ func WithRepository(repo repository.Repository) RestAppOption {
 		p.Repository = repo
 	}
 }
+
+func WithJobScheduler(scheduler job.Scheduler) RestAppOption {
+	return func(p *RestAppParam) {
+		p.JobScheduler = scheduler
+	}
+}
+
+func WithVerificationCache(cache auth.VerificationCache) RestAppOption {
+	return func(p *RestAppParam) {
+		p.VerificationCache = cache
+	}
+}
@@ -29,6 +29,8 @@ func (h *authHandler) CreateClient(ctx echo.Context) error {
 		Name:         req.Name,
 		Type:         string(req.Type),
 		Status:       string(req.Status),
+		Scopes:       clientScopes(req.Scopes),
+		Quota:        clientQuota(req.Quota),
 	})
 	if err != nil {
 		switch err.Code {
@@ -87,17 +89,46 @@ func (h *authHandler) GetClientById(ctx echo.Context) error {
 		updatedAt = typeconv.Int64(findRes.UpdatedAt.UnixMilli())
 	}
 
+	var secretRotatedAt *int64
+	if findRes.SecretRotatedAt != nil {
+		secretRotatedAt = typeconv.Int64(findRes.SecretRotatedAt.UnixMilli())
+	}
+
+	var previousSecretExpiresAt *int64
+	if findRes.PreviousSecretExpiresAt != nil {
+		previousSecretExpiresAt = typeconv.Int64(findRes.PreviousSecretExpiresAt.UnixMilli())
+	}
+
+	scopes := []restapp.AuthClientScope{}
+	for _, scope := range findRes.Scopes {
+		scopes = append(scopes, restapp.AuthClientScope(scope))
+	}
+
 	return ctx.JSON(http.StatusOK, &restapp.GetAuthClientByIdResponse{
 		Code:    findRes.Success.Code,
 		Message: findRes.Success.Message,
 		Data: restapp.GetAuthClientByIdData{
-			Id:        findRes.Id,
-			Name:      findRes.Name,
-			Type:      findRes.Type,
-			Status:    findRes.Status,
-			ClientId:  findRes.ClientId,
-			CreatedAt: findRes.CreatedAt.UnixMilli(),
-			UpdatedAt: updatedAt,
+			Id:       findRes.Id,
+			Name:     findRes.Name,
+			Type:     findRes.Type,
+			Status:   findRes.Status,
+			ClientId: findRes.ClientId,
+			Scopes:   scopes,
+			Quota: restapp.AuthClientQuota{
+				MaxStoredSize:   findRes.Quota.MaxStoredSize,
+				MaxTotalFiles:   findRes.Quota.MaxTotalFiles,
+				MaxFileSize:     findRes.Quota.MaxFileSize,
+				MaxDailyUploads: findRes.Quota.MaxDailyUploads,
+			},
+			Usage: restapp.AuthClientUsage{
+				StoredSize:   findRes.Usage.StoredSize,
+				TotalFiles:   findRes.Usage.TotalFiles,
+				DailyUploads: findRes.Usage.DailyUploads,
+			},
+			SecretRotatedAt:         secretRotatedAt,
+			PreviousSecretExpiresAt: previousSecretExpiresAt,
+			CreatedAt:               findRes.CreatedAt.UnixMilli(),
+			UpdatedAt:               updatedAt,
 		},
 	})
 }
@@ -117,6 +148,8 @@ func (h *authHandler) UpdateClientById(ctx echo.Context) error {
 		Name:     req.Name,
 		Type:     string(req.Type),
 		Status:   string(req.Status),
+		Scopes:   clientScopes(req.Scopes),
+		Quota:    clientQuota(req.Quota),
 	})
 	if err != nil {
 		switch err.Code {
@@ -152,6 +185,40 @@ func (h *authHandler) UpdateClientById(ctx echo.Context) error {
 	})
 }
 
+func (h *authHandler) DeleteClientById(ctx echo.Context) error {
+	deleteRes, err := h.authClient.DeleteClientById(ctx.Request().Context(), service.DeleteClientByIdParam{
+		Id: ctx.Param("id"),
+	})
+	if err != nil {
+		switch err.Code {
+		case status.INVALID_PARAM:
+			return echo.NewHTTPError(http.StatusBadRequest, &restapp.ResponseBodyInfo{
+				Code:    err.Code,
+				Message: err.Message,
+			})
+		case status.RESOURCE_NOTFOUND:
+			return echo.NewHTTPError(http.StatusNotFound, &restapp.ResponseBodyInfo{
+				Code:    err.Code,
+				Message: err.Message,
+			})
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, &restapp.ResponseBodyInfo{
+			Code:    err.Code,
+			Message: err.Message,
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, &restapp.DeleteAuthClientByIdResponse{
+		Code:    deleteRes.Success.Code,
+		Message: deleteRes.Success.Message,
+		Data: restapp.DeleteAuthClientByIdData{
+			Id:        deleteRes.Id,
+			ClientId:  deleteRes.ClientId,
+			DeletedAt: deleteRes.DeletedAt.UnixMilli(),
+		},
+	})
+}
+
 func (h *authHandler) SearchClient(ctx echo.Context) error {
 	req := &restapp.SearchAuthClientRequest{}
 	if err := ctx.Bind(req); err != nil {
@@ -229,6 +296,115 @@ func (h *authHandler) SearchClient(ctx echo.Context) error {
 	})
 }
 
+// @note: nil quota is returned when it's not specified
+func (h *authHandler) RotateClientSecret(ctx echo.Context) error {
+	req := &restapp.RotateAuthClientSecretRequest{}
+	if err := ctx.Bind(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, &restapp.ResponseBodyInfo{
+			Code:    status.INVALID_PARAM,
+			Message: "invalid request",
+		})
+	}
+
+	clientSecret := ""
+	if req.ClientSecret != nil {
+		clientSecret = *req.ClientSecret
+	}
+
+	rotateRes, err := h.authClient.RotateClientSecret(ctx.Request().Context(), service.RotateClientSecretParam{
+		Id:           ctx.Param("id"),
+		ClientSecret: clientSecret,
+	})
+	if err != nil {
+		switch err.Code {
+		case status.INVALID_PARAM:
+			return echo.NewHTTPError(http.StatusBadRequest, &restapp.ResponseBodyInfo{
+				Code:    err.Code,
+				Message: err.Message,
+			})
+		case status.RESOURCE_NOTFOUND:
+			return echo.NewHTTPError(http.StatusNotFound, &restapp.ResponseBodyInfo{
+				Code:    err.Code,
+				Message: err.Message,
+			})
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, &restapp.ResponseBodyInfo{
+			Code:    err.Code,
+			Message: err.Message,
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, &restapp.RotateAuthClientSecretResponse{
+		Code:    rotateRes.Success.Code,
+		Message: rotateRes.Success.Message,
+		Data: restapp.RotateAuthClientSecretData{
+			Id:                      rotateRes.Id,
+			ClientId:                rotateRes.ClientId,
+			ClientSecret:            rotateRes.ClientSecret,
+			SecretRotatedAt:         rotateRes.SecretRotatedAt.UnixMilli(),
+			PreviousSecretExpiresAt: rotateRes.PreviousSecretExpiresAt.UnixMilli(),
+		},
+	})
+}
+
+func (h *authHandler) RevokeClientSecret(ctx echo.Context) error {
+	revokeRes, err := h.authClient.RevokeClientSecret(ctx.Request().Context(), service.RevokeClientSecretParam{
+		Id: ctx.Param("id"),
+	})
+	if err != nil {
+		switch err.Code {
+		case status.INVALID_PARAM:
+			return echo.NewHTTPError(http.StatusBadRequest, &restapp.ResponseBodyInfo{
+				Code:    err.Code,
+				Message: err.Message,
+			})
+		case status.RESOURCE_NOTFOUND:
+			return echo.NewHTTPError(http.StatusNotFound, &restapp.ResponseBodyInfo{
+				Code:    err.Code,
+				Message: err.Message,
+			})
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, &restapp.ResponseBodyInfo{
+			Code:    err.Code,
+			Message: err.Message,
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, &restapp.RevokeAuthClientSecretResponse{
+		Code:    revokeRes.Success.Code,
+		Message: revokeRes.Success.Message,
+		Data: restapp.RevokeAuthClientSecretData{
+			Id:        revokeRes.Id,
+			ClientId:  revokeRes.ClientId,
+			RevokedAt: revokeRes.RevokedAt.UnixMilli(),
+		},
+	})
+}
+
+func clientQuota(q *restapp.AuthClientQuota) *service.ClientQuota {
+	if q == nil {
+		return nil
+	}
+	return &service.ClientQuota{
+		MaxStoredSize:   q.MaxStoredSize,
+		MaxTotalFiles:   q.MaxTotalFiles,
+		MaxFileSize:     q.MaxFileSize,
+		MaxDailyUploads: q.MaxDailyUploads,
+	}
+}
+
+// @note: nil scopes is returned when it's not specified
+func clientScopes(s *[]restapp.AuthClientScope) []string {
+	if s == nil {
+		return nil
+	}
+	scopes := []string{}
+	for _, scope := range *s {
+		scopes = append(scopes, string(scope))
+	}
+	return scopes
+}
+
 type AuthParam struct {
 	AuthClient service.AuthClient
 }
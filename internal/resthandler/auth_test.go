@@ -217,11 +217,23 @@ var _ = Describe("Auth Handler", func() {
 					Code:    1000,
 					Message: "success find auth client",
 				},
-				Id:        "id",
-				ClientId:  "client-id",
-				Name:      "name",
-				Type:      "basic",
-				Status:    "active",
+				Id:       "id",
+				ClientId: "client-id",
+				Name:     "name",
+				Type:     "basic",
+				Status:   "active",
+				Scopes:   []string{"file:read", "file:write"},
+				Quota: service.ClientQuota{
+					MaxStoredSize:   1000,
+					MaxTotalFiles:   10,
+					MaxFileSize:     500,
+					MaxDailyUploads: 5,
+				},
+				Usage: service.ClientUsage{
+					StoredSize:   300,
+					TotalFiles:   3,
+					DailyUploads: 2,
+				},
 				CreatedAt: currentTs,
 				UpdatedAt: &currentTs,
 			}
@@ -316,11 +328,23 @@ var _ = Describe("Auth Handler", func() {
 				Expect(res.Code).To(Equal(int32(1000)))
 				Expect(res.Message).To(Equal("success find auth client"))
 				Expect(res.Data).To(Equal(restapp.GetAuthClientByIdData{
-					Id:        findRes.Id,
-					Name:      findRes.Name,
-					Status:    findRes.Status,
-					Type:      findRes.Type,
-					ClientId:  findRes.ClientId,
+					Id:       findRes.Id,
+					Name:     findRes.Name,
+					Status:   findRes.Status,
+					Type:     findRes.Type,
+					ClientId: findRes.ClientId,
+					Scopes:   []restapp.AuthClientScope{restapp.FileRead, restapp.FileWrite},
+					Quota: restapp.AuthClientQuota{
+						MaxStoredSize:   1000,
+						MaxTotalFiles:   10,
+						MaxFileSize:     500,
+						MaxDailyUploads: 5,
+					},
+					Usage: restapp.AuthClientUsage{
+						StoredSize:   300,
+						TotalFiles:   3,
+						DailyUploads: 2,
+					},
 					CreatedAt: findRes.CreatedAt.UnixMilli(),
 					UpdatedAt: &updatedAt,
 				}))
@@ -346,6 +370,11 @@ var _ = Describe("Auth Handler", func() {
 				Name:     "name",
 				Type:     "basic",
 				Status:   "active",
+				Scopes:   &[]restapp.AuthClientScope{restapp.FileRead, restapp.ClientAdmin},
+				Quota: &restapp.AuthClientQuota{
+					MaxStoredSize: 1000,
+					MaxFileSize:   500,
+				},
 			}
 			body, _ := json.Marshal(reqBody)
 			buffer := bytes.NewBuffer(body)
@@ -371,6 +400,11 @@ var _ = Describe("Auth Handler", func() {
 				Name:     reqBody.Name,
 				Type:     string(reqBody.Type),
 				Status:   string(reqBody.Status),
+				Scopes:   []string{"file:read", "client:admin"},
+				Quota: &service.ClientQuota{
+					MaxStoredSize: 1000,
+					MaxFileSize:   500,
+				},
 			}
 			updateRes = &service.UpdateClientByIdResult{
 				Success: system.Success{
@@ -803,4 +837,483 @@ var _ = Describe("Auth Handler", func() {
 			})
 		})
 	})
+	Context("RotateClientSecret function", Label("unit"), func() {
+		var (
+			currentTs   time.Time
+			ctx         echo.Context
+			h           func(ctx echo.Context) error
+			rec         *httptest.ResponseRecorder
+			authClient  *mock_service.MockAuthClient
+			rotateParam service.RotateClientSecretParam
+			rotateRes   *service.RotateClientSecretResult
+		)
+
+		BeforeEach(func() {
+			currentTs = time.Now().UTC()
+			clientSecret := "new-secret"
+			reqBody := &restapp.RotateAuthClientSecretRequest{
+				ClientSecret: &clientSecret,
+			}
+			body, _ := json.Marshal(reqBody)
+			buffer := bytes.NewBuffer(body)
+			req := httptest.NewRequest(http.MethodPost, "/", buffer)
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec = httptest.NewRecorder()
+
+			e := echo.New()
+			ctx = e.NewContext(req, rec)
+			ctx.SetParamNames("id")
+			ctx.SetParamValues("mock-id")
+
+			t := GinkgoT()
+			ctrl := gomock.NewController(t)
+			authClient = mock_service.NewMockAuthClient(ctrl)
+			authHandler := resthandler.NewAuth(resthandler.AuthParam{
+				AuthClient: authClient,
+			})
+			h = authHandler.RotateClientSecret
+			rotateParam = service.RotateClientSecretParam{
+				Id:           "mock-id",
+				ClientSecret: "new-secret",
+			}
+			rotateRes = &service.RotateClientSecretResult{
+				Success: system.Success{
+					Code:    1000,
+					Message: "success rotate auth client secret",
+				},
+				Id:                      "mock-id",
+				ClientId:                "client-id",
+				ClientSecret:            "new-secret",
+				SecretRotatedAt:         currentTs,
+				PreviousSecretExpiresAt: currentTs.Add(24 * time.Hour),
+			}
+		})
+
+		When("failed binding request body", func() {
+			It("should return error", func() {
+				body, _ := json.Marshal(struct {
+					ClientSecret int `json:"client_secret"`
+				}{
+					ClientSecret: 1,
+				})
+				buffer := bytes.NewBuffer(body)
+
+				req := httptest.NewRequest(http.MethodPost, "/", buffer)
+				req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+				rec := httptest.NewRecorder()
+
+				e := echo.New()
+				ctx := e.NewContext(req, rec)
+
+				err := h(ctx)
+
+				Expect(err).To(Equal(&echo.HTTPError{
+					Code: 400,
+					Message: &restapp.ResponseBodyInfo{
+						Code:    1002,
+						Message: "invalid request",
+					},
+				}))
+			})
+		})
+
+		When("there is invalid data", func() {
+			It("should return error", func() {
+				authClient.
+					EXPECT().
+					RotateClientSecret(gomock.Eq(ctx.Request().Context()), gomock.Eq(rotateParam)).
+					Return(nil, &system.Error{
+						Code:    1002,
+						Message: "invalid data",
+					}).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(Equal(&echo.HTTPError{
+					Code: 400,
+					Message: &restapp.ResponseBodyInfo{
+						Code:    1002,
+						Message: "invalid data",
+					},
+				}))
+			})
+		})
+
+		When("auth client is not available", func() {
+			It("should return error", func() {
+				authClient.
+					EXPECT().
+					RotateClientSecret(gomock.Eq(ctx.Request().Context()), gomock.Eq(rotateParam)).
+					Return(nil, &system.Error{
+						Code:    1004,
+						Message: "auth client is not available",
+					}).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(Equal(&echo.HTTPError{
+					Code: 404,
+					Message: &restapp.ResponseBodyInfo{
+						Code:    1004,
+						Message: "auth client is not available",
+					},
+				}))
+			})
+		})
+
+		When("failed rotate auth client secret", func() {
+			It("should return error", func() {
+				authClient.
+					EXPECT().
+					RotateClientSecret(gomock.Eq(ctx.Request().Context()), gomock.Eq(rotateParam)).
+					Return(nil, &system.Error{
+						Code:    1001,
+						Message: "network error",
+					}).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(Equal(&echo.HTTPError{
+					Code: 500,
+					Message: &restapp.ResponseBodyInfo{
+						Code:    1001,
+						Message: "network error",
+					},
+				}))
+			})
+		})
+
+		When("success rotate auth client secret", func() {
+			It("should return result", func() {
+				authClient.
+					EXPECT().
+					RotateClientSecret(gomock.Eq(ctx.Request().Context()), gomock.Eq(rotateParam)).
+					Return(rotateRes, nil).
+					Times(1)
+
+				err := h(ctx)
+
+				res := &restapp.RotateAuthClientSecretResponse{}
+				json.Unmarshal(rec.Body.Bytes(), res)
+
+				Expect(err).To(BeNil())
+				Expect(rec.Code).To(Equal(http.StatusOK))
+				Expect(res.Code).To(Equal(int32(1000)))
+				Expect(res.Message).To(Equal("success rotate auth client secret"))
+				Expect(res.Data).To(Equal(restapp.RotateAuthClientSecretData{
+					Id:                      rotateRes.Id,
+					ClientId:                rotateRes.ClientId,
+					ClientSecret:            rotateRes.ClientSecret,
+					SecretRotatedAt:         rotateRes.SecretRotatedAt.UnixMilli(),
+					PreviousSecretExpiresAt: rotateRes.PreviousSecretExpiresAt.UnixMilli(),
+				}))
+			})
+		})
+
+		When("request body is empty", func() {
+			It("should generate client secret", func() {
+				req := httptest.NewRequest(http.MethodPost, "/", nil)
+				rec := httptest.NewRecorder()
+
+				e := echo.New()
+				ctx := e.NewContext(req, rec)
+				ctx.SetParamNames("id")
+				ctx.SetParamValues("mock-id")
+
+				rotateParam.ClientSecret = ""
+				rotateRes.ClientSecret = "generated-secret"
+				authClient.
+					EXPECT().
+					RotateClientSecret(gomock.Eq(ctx.Request().Context()), gomock.Eq(rotateParam)).
+					Return(rotateRes, nil).
+					Times(1)
+
+				err := h(ctx)
+
+				res := &restapp.RotateAuthClientSecretResponse{}
+				json.Unmarshal(rec.Body.Bytes(), res)
+
+				Expect(err).To(BeNil())
+				Expect(rec.Code).To(Equal(http.StatusOK))
+				Expect(res.Data.ClientSecret).To(Equal("generated-secret"))
+			})
+		})
+	})
+
+	Context("RevokeClientSecret function", Label("unit"), func() {
+		var (
+			currentTs   time.Time
+			ctx         echo.Context
+			h           func(ctx echo.Context) error
+			rec         *httptest.ResponseRecorder
+			authClient  *mock_service.MockAuthClient
+			revokeParam service.RevokeClientSecretParam
+			revokeRes   *service.RevokeClientSecretResult
+		)
+
+		BeforeEach(func() {
+			currentTs = time.Now().UTC()
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			rec = httptest.NewRecorder()
+
+			e := echo.New()
+			ctx = e.NewContext(req, rec)
+			ctx.SetParamNames("id")
+			ctx.SetParamValues("mock-id")
+
+			t := GinkgoT()
+			ctrl := gomock.NewController(t)
+			authClient = mock_service.NewMockAuthClient(ctrl)
+			authHandler := resthandler.NewAuth(resthandler.AuthParam{
+				AuthClient: authClient,
+			})
+			h = authHandler.RevokeClientSecret
+			revokeParam = service.RevokeClientSecretParam{
+				Id: "mock-id",
+			}
+			revokeRes = &service.RevokeClientSecretResult{
+				Success: system.Success{
+					Code:    1000,
+					Message: "success revoke auth client secret",
+				},
+				Id:        "mock-id",
+				ClientId:  "client-id",
+				RevokedAt: currentTs,
+			}
+		})
+
+		When("there is invalid data", func() {
+			It("should return error", func() {
+				authClient.
+					EXPECT().
+					RevokeClientSecret(gomock.Eq(ctx.Request().Context()), gomock.Eq(revokeParam)).
+					Return(nil, &system.Error{
+						Code:    1002,
+						Message: "invalid data",
+					}).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(Equal(&echo.HTTPError{
+					Code: 400,
+					Message: &restapp.ResponseBodyInfo{
+						Code:    1002,
+						Message: "invalid data",
+					},
+				}))
+			})
+		})
+
+		When("auth client is not available", func() {
+			It("should return error", func() {
+				authClient.
+					EXPECT().
+					RevokeClientSecret(gomock.Eq(ctx.Request().Context()), gomock.Eq(revokeParam)).
+					Return(nil, &system.Error{
+						Code:    1004,
+						Message: "auth client is not available",
+					}).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(Equal(&echo.HTTPError{
+					Code: 404,
+					Message: &restapp.ResponseBodyInfo{
+						Code:    1004,
+						Message: "auth client is not available",
+					},
+				}))
+			})
+		})
+
+		When("failed revoke auth client secret", func() {
+			It("should return error", func() {
+				authClient.
+					EXPECT().
+					RevokeClientSecret(gomock.Eq(ctx.Request().Context()), gomock.Eq(revokeParam)).
+					Return(nil, &system.Error{
+						Code:    1001,
+						Message: "network error",
+					}).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(Equal(&echo.HTTPError{
+					Code: 500,
+					Message: &restapp.ResponseBodyInfo{
+						Code:    1001,
+						Message: "network error",
+					},
+				}))
+			})
+		})
+
+		When("success revoke auth client secret", func() {
+			It("should return result", func() {
+				authClient.
+					EXPECT().
+					RevokeClientSecret(gomock.Eq(ctx.Request().Context()), gomock.Eq(revokeParam)).
+					Return(revokeRes, nil).
+					Times(1)
+
+				err := h(ctx)
+
+				res := &restapp.RevokeAuthClientSecretResponse{}
+				json.Unmarshal(rec.Body.Bytes(), res)
+
+				Expect(err).To(BeNil())
+				Expect(rec.Code).To(Equal(http.StatusOK))
+				Expect(res.Code).To(Equal(int32(1000)))
+				Expect(res.Message).To(Equal("success revoke auth client secret"))
+				Expect(res.Data).To(Equal(restapp.RevokeAuthClientSecretData{
+					Id:        revokeRes.Id,
+					ClientId:  revokeRes.ClientId,
+					RevokedAt: revokeRes.RevokedAt.UnixMilli(),
+				}))
+			})
+		})
+	})
+
+	Context("DeleteClientById function", Label("unit"), func() {
+		var (
+			currentTs   time.Time
+			ctx         echo.Context
+			h           func(ctx echo.Context) error
+			rec         *httptest.ResponseRecorder
+			authClient  *mock_service.MockAuthClient
+			deleteParam service.DeleteClientByIdParam
+			deleteRes   *service.DeleteClientByIdResult
+		)
+
+		BeforeEach(func() {
+			currentTs = time.Now().UTC()
+			req := httptest.NewRequest(http.MethodDelete, "/", nil)
+			rec = httptest.NewRecorder()
+
+			e := echo.New()
+			ctx = e.NewContext(req, rec)
+			ctx.SetParamNames("id")
+			ctx.SetParamValues("mock-id")
+
+			t := GinkgoT()
+			ctrl := gomock.NewController(t)
+			authClient = mock_service.NewMockAuthClient(ctrl)
+			authHandler := resthandler.NewAuth(resthandler.AuthParam{
+				AuthClient: authClient,
+			})
+			h = authHandler.DeleteClientById
+			deleteParam = service.DeleteClientByIdParam{
+				Id: "mock-id",
+			}
+			deleteRes = &service.DeleteClientByIdResult{
+				Success: system.Success{
+					Code:    1000,
+					Message: "success delete auth client",
+				},
+				Id:        "mock-id",
+				ClientId:  "client-id",
+				DeletedAt: currentTs,
+			}
+		})
+
+		When("there is invalid data", func() {
+			It("should return error", func() {
+				authClient.
+					EXPECT().
+					DeleteClientById(gomock.Eq(ctx.Request().Context()), gomock.Eq(deleteParam)).
+					Return(nil, &system.Error{
+						Code:    1002,
+						Message: "invalid data",
+					}).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(Equal(&echo.HTTPError{
+					Code: 400,
+					Message: &restapp.ResponseBodyInfo{
+						Code:    1002,
+						Message: "invalid data",
+					},
+				}))
+			})
+		})
+
+		When("auth client is not available", func() {
+			It("should return error", func() {
+				authClient.
+					EXPECT().
+					DeleteClientById(gomock.Eq(ctx.Request().Context()), gomock.Eq(deleteParam)).
+					Return(nil, &system.Error{
+						Code:    1004,
+						Message: "auth client is not available",
+					}).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(Equal(&echo.HTTPError{
+					Code: 404,
+					Message: &restapp.ResponseBodyInfo{
+						Code:    1004,
+						Message: "auth client is not available",
+					},
+				}))
+			})
+		})
+
+		When("failed delete auth client", func() {
+			It("should return error", func() {
+				authClient.
+					EXPECT().
+					DeleteClientById(gomock.Eq(ctx.Request().Context()), gomock.Eq(deleteParam)).
+					Return(nil, &system.Error{
+						Code:    1001,
+						Message: "network error",
+					}).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(Equal(&echo.HTTPError{
+					Code: 500,
+					Message: &restapp.ResponseBodyInfo{
+						Code:    1001,
+						Message: "network error",
+					},
+				}))
+			})
+		})
+
+		When("success delete auth client", func() {
+			It("should return result", func() {
+				authClient.
+					EXPECT().
+					DeleteClientById(gomock.Eq(ctx.Request().Context()), gomock.Eq(deleteParam)).
+					Return(deleteRes, nil).
+					Times(1)
+
+				err := h(ctx)
+
+				res := &restapp.DeleteAuthClientByIdResponse{}
+				json.Unmarshal(rec.Body.Bytes(), res)
+
+				Expect(err).To(BeNil())
+				Expect(rec.Code).To(Equal(http.StatusOK))
+				Expect(res.Code).To(Equal(int32(1000)))
+				Expect(res.Message).To(Equal("success delete auth client"))
+				Expect(res.Data).To(Equal(restapp.DeleteAuthClientByIdData{
+					Id:        deleteRes.Id,
+					ClientId:  deleteRes.ClientId,
+					DeletedAt: deleteRes.DeletedAt.UnixMilli(),
+				}))
+			})
+		})
+	})
 })
@@ -1,23 +1,39 @@
 package resthandler
 
 import (
+	"encoding/base64"
+	"encoding/hex"
 	"fmt"
+	"io"
+	mime_multipart "mime/multipart"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-seidon/hippo/api/restapp"
 	"github.com/go-seidon/hippo/internal/service"
 	"github.com/go-seidon/hippo/internal/storage/multipart"
 	"github.com/go-seidon/provider/status"
+	"github.com/go-seidon/provider/system"
+	"github.com/go-seidon/provider/typeconv"
 	"github.com/labstack/echo/v4"
 )
 
+const (
+	// @note: maximum size of the form field sent along with the file
+	MAX_FIELD_SIZE = 1024
+)
+
 type fileHandler struct {
 	fileClient service.File
 	fileParser multipart.Parser
 }
 
+// @note: the form is streamed instead of being buffered,
+// so the fields are only read when they're sent before the file part
 func (h *fileHandler) UploadFile(ctx echo.Context) error {
-	fileHeader, ferr := ctx.FormFile("file")
+	form, ferr := ctx.Request().MultipartReader()
 	if ferr != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, &restapp.ResponseBodyInfo{
 			Code:    status.INVALID_PARAM,
@@ -25,7 +41,45 @@ func (h *fileHandler) UploadFile(ctx echo.Context) error {
 		})
 	}
 
-	fileInfo, ferr := h.fileParser(fileHeader)
+	bucket := ""
+	var filePart *mime_multipart.Part
+	for filePart == nil {
+		part, err := form.NextPart()
+		if err == io.EOF {
+			return echo.NewHTTPError(http.StatusBadRequest, &restapp.ResponseBodyInfo{
+				Code:    status.INVALID_PARAM,
+				Message: http.ErrMissingFile.Error(),
+			})
+		}
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, &restapp.ResponseBodyInfo{
+				Code:    status.INVALID_PARAM,
+				Message: err.Error(),
+			})
+		}
+
+		switch part.FormName() {
+		case "file":
+			filePart = part
+		case "bucket":
+			value, err := io.ReadAll(io.LimitReader(part, MAX_FIELD_SIZE+1))
+			if err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, &restapp.ResponseBodyInfo{
+					Code:    status.INVALID_PARAM,
+					Message: err.Error(),
+				})
+			}
+			if len(value) > MAX_FIELD_SIZE {
+				return echo.NewHTTPError(http.StatusBadRequest, &restapp.ResponseBodyInfo{
+					Code:    status.INVALID_PARAM,
+					Message: "bucket is too long",
+				})
+			}
+			bucket = string(value)
+		}
+	}
+
+	fileInfo, ferr := h.fileParser(filePart)
 	if ferr != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, &restapp.ResponseBodyInfo{
 			Code:    status.INVALID_PARAM,
@@ -34,6 +88,7 @@ func (h *fileHandler) UploadFile(ctx echo.Context) error {
 	}
 	defer fileInfo.Data.Close()
 
+	clientId, _, _ := ctx.Request().BasicAuth()
 	uploadFile, err := h.fileClient.UploadFile(
 		ctx.Request().Context(),
 		service.WithReader(fileInfo.Data),
@@ -43,12 +98,25 @@ func (h *fileHandler) UploadFile(ctx echo.Context) error {
 			fileInfo.Extension,
 			fileInfo.Size,
 		),
+		service.WithLocation(clientId, bucket),
 	)
 	if err != nil {
 		httpCode := http.StatusInternalServerError
 		switch err.Code {
 		case status.INVALID_PARAM:
 			httpCode = http.StatusBadRequest
+		case service.FILE_NOT_ALLOWED:
+			httpCode = http.StatusUnsupportedMediaType
+		case service.FILE_INFECTED:
+			httpCode = http.StatusUnprocessableEntity
+		case service.FILE_NOT_SCANNED:
+			httpCode = http.StatusServiceUnavailable
+		case service.FILE_NOT_SCANNABLE:
+			httpCode = http.StatusRequestEntityTooLarge
+		case service.QUOTA_EXCEEDED:
+			httpCode = http.StatusTooManyRequests
+		case service.QUOTA_SIZE_EXCEEDED:
+			httpCode = http.StatusRequestEntityTooLarge
 		}
 		return echo.NewHTTPError(httpCode, &restapp.ResponseBodyInfo{
 			Code:    err.Code,
@@ -56,22 +124,134 @@ func (h *fileHandler) UploadFile(ctx echo.Context) error {
 		})
 	}
 
+	var checksumMd5 *string
+	if uploadFile.Checksum.Md5 != "" {
+		checksumMd5 = &uploadFile.Checksum.Md5
+	}
+
 	return ctx.JSON(http.StatusOK, &restapp.UploadFileResponse{
 		Code:    uploadFile.Success.Code,
 		Message: uploadFile.Success.Message,
 		Data: restapp.UploadFileData{
-			Id:         uploadFile.UniqueId,
-			Name:       uploadFile.Name,
-			Mimetype:   uploadFile.Mimetype,
-			Extension:  uploadFile.Extension,
-			Size:       uploadFile.Size,
-			UploadedAt: uploadFile.UploadedAt.UnixMilli(),
+			Id:             uploadFile.UniqueId,
+			Name:           uploadFile.Name,
+			Mimetype:       uploadFile.Mimetype,
+			Extension:      uploadFile.Extension,
+			Size:           uploadFile.Size,
+			UploadedAt:     uploadFile.UploadedAt.UnixMilli(),
+			ChecksumSha256: uploadFile.Checksum.Sha256,
+			ChecksumMd5:    checksumMd5,
 		},
 	})
 }
 
+// @note: precondition and range are evaluated against the stored file metadata
+// before the data is opened, the file is only looked up once
 func (h *fileHandler) RetrieveFileById(ctx echo.Context) error {
-	findFile, err := h.fileClient.RetrieveFile(ctx.Request().Context(), service.RetrieveFileParam{
+	verifyChecksum := false
+	if ctx.QueryParam("verify_checksum") != "" {
+		verify, perr := strconv.ParseBool(ctx.QueryParam("verify_checksum"))
+		if perr != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, &restapp.ResponseBodyInfo{
+				Code:    status.INVALID_PARAM,
+				Message: "invalid verify_checksum parameter",
+			})
+		}
+		verifyChecksum = verify
+	}
+
+	req := ctx.Request()
+	header := ctx.Response().Header()
+	rangeHeader := req.Header.Get("Range")
+	notModified := false
+	ranged := false
+	findFile, err := h.fileClient.RetrieveFile(req.Context(), service.RetrieveFileParam{
+		FileId:          ctx.Param("id"),
+		VerifyChecksum:  verifyChecksum,
+		AcceptEncodings: parseAcceptEncoding(req.Header.Values("Accept-Encoding")),
+		Select: func(meta *service.RetrieveFileResult) (*service.RetrieveFileSelection, *system.Error) {
+			if isNotModified(req, meta) {
+				notModified = true
+				return &service.RetrieveFileSelection{Skip: true}, nil
+			}
+			if rangeHeader == "" || !isRangeApplied(req, meta) {
+				return nil, nil
+			}
+
+			offset, length, valid, satisfiable := parseRange(rangeHeader, meta.Size)
+			if !satisfiable {
+				header.Set("Content-Range", fmt.Sprintf("bytes */%d", meta.Size))
+				return nil, &system.Error{
+					Code:    service.FILE_RANGE_INVALID,
+					Message: "file range is not satisfiable",
+				}
+			}
+			if !valid {
+				return nil, nil
+			}
+			ranged = true
+			return &service.RetrieveFileSelection{
+				Offset: offset,
+				Length: length,
+			}, nil
+		},
+	})
+	if err != nil {
+		return newRetrieveFileError(err)
+	}
+
+	setFileHeader(header, findFile)
+	if findFile.Compression != "" {
+		header.Add("Vary", "Accept-Encoding")
+	}
+	if notModified {
+		return ctx.NoContent(http.StatusNotModified)
+	}
+	defer findFile.Data.Close()
+
+	if findFile.ContentEncoding != "" {
+		// @note: length and digest of the compressed data are unknown
+		header.Del("Digest")
+		header.Set("Content-Encoding", findFile.ContentEncoding)
+		return ctx.Stream(http.StatusOK, findFile.MimeType, findFile.Data)
+	}
+
+	header.Set("Content-Length", fmt.Sprintf("%d", findFile.Length))
+	if ranged {
+		header.Set("Content-Range", fmt.Sprintf(
+			"bytes %d-%d/%d",
+			findFile.Offset,
+			findFile.Offset+findFile.Length-1,
+			findFile.Size,
+		))
+		return ctx.Stream(http.StatusPartialContent, findFile.MimeType, findFile.Data)
+	}
+	return ctx.Stream(http.StatusOK, findFile.MimeType, findFile.Data)
+}
+
+func (h *fileHandler) RetrieveFileMetaById(ctx echo.Context) error {
+	req := ctx.Request()
+	findMeta, err := h.fileClient.RetrieveFile(req.Context(), service.RetrieveFileParam{
+		FileId:       ctx.Param("id"),
+		MetadataOnly: true,
+	})
+	if err != nil {
+		return newRetrieveFileError(err)
+	}
+
+	header := ctx.Response().Header()
+	setFileHeader(header, findMeta)
+	if isNotModified(req, findMeta) {
+		return ctx.NoContent(http.StatusNotModified)
+	}
+
+	header.Set("Content-Type", findMeta.MimeType)
+	header.Set("Content-Length", fmt.Sprintf("%d", findMeta.Size))
+	return ctx.NoContent(http.StatusOK)
+}
+
+func (h *fileHandler) DeleteFileById(ctx echo.Context) error {
+	deleteFile, err := h.fileClient.DeleteFile(ctx.Request().Context(), service.DeleteFileParam{
 		FileId: ctx.Param("id"),
 	})
 	if err != nil {
@@ -79,6 +259,8 @@ func (h *fileHandler) RetrieveFileById(ctx echo.Context) error {
 		switch err.Code {
 		case status.INVALID_PARAM:
 			httpCode = http.StatusBadRequest
+		case status.ACTION_FORBIDDEN:
+			httpCode = http.StatusForbidden
 		case status.RESOURCE_NOTFOUND:
 			httpCode = http.StatusNotFound
 		}
@@ -87,18 +269,18 @@ func (h *fileHandler) RetrieveFileById(ctx echo.Context) error {
 			Message: err.Message,
 		})
 	}
-	defer findFile.Data.Close()
 
-	header := ctx.Response().Header()
-	header.Set("X-File-Name", findFile.Name)
-	header.Set("X-File-Mimetype", findFile.MimeType)
-	header.Set("X-File-Extension", findFile.Extension)
-	header.Set("X-File-Size", fmt.Sprintf("%d", findFile.Size))
-	return ctx.Stream(http.StatusOK, findFile.MimeType, findFile.Data)
+	return ctx.JSON(http.StatusOK, &restapp.DeleteFileByIdResponse{
+		Code:    deleteFile.Success.Code,
+		Message: deleteFile.Success.Message,
+		Data: restapp.DeleteFileByIdData{
+			DeletedAt: deleteFile.DeletedAt.UnixMilli(),
+		},
+	})
 }
 
-func (h *fileHandler) DeleteFileById(ctx echo.Context) error {
-	deleteFile, err := h.fileClient.DeleteFile(ctx.Request().Context(), service.DeleteFileParam{
+func (h *fileHandler) RestoreFileById(ctx echo.Context) error {
+	restoreFile, err := h.fileClient.RestoreFile(ctx.Request().Context(), service.RestoreFileParam{
 		FileId: ctx.Param("id"),
 	})
 	if err != nil {
@@ -106,8 +288,14 @@ func (h *fileHandler) DeleteFileById(ctx echo.Context) error {
 		switch err.Code {
 		case status.INVALID_PARAM:
 			httpCode = http.StatusBadRequest
+		case status.ACTION_FORBIDDEN:
+			httpCode = http.StatusForbidden
 		case status.RESOURCE_NOTFOUND:
 			httpCode = http.StatusNotFound
+		case service.FILE_NOT_DELETED:
+			httpCode = http.StatusConflict
+		case service.QUOTA_EXCEEDED:
+			httpCode = http.StatusTooManyRequests
 		}
 		return echo.NewHTTPError(httpCode, &restapp.ResponseBodyInfo{
 			Code:    err.Code,
@@ -115,11 +303,111 @@ func (h *fileHandler) DeleteFileById(ctx echo.Context) error {
 		})
 	}
 
-	return ctx.JSON(http.StatusOK, &restapp.DeleteFileByIdResponse{
-		Code:    deleteFile.Success.Code,
-		Message: deleteFile.Success.Message,
-		Data: restapp.DeleteFileByIdData{
-			DeletedAt: deleteFile.DeletedAt.UnixMilli(),
+	return ctx.JSON(http.StatusOK, &restapp.RestoreFileByIdResponse{
+		Code:    restoreFile.Success.Code,
+		Message: restoreFile.Success.Message,
+		Data: restapp.RestoreFileByIdData{
+			RestoredAt: restoreFile.RestoredAt.UnixMilli(),
+		},
+	})
+}
+
+func (h *fileHandler) SearchFile(ctx echo.Context) error {
+	req := &restapp.SearchFileRequest{}
+	if err := ctx.Bind(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, &restapp.ResponseBodyInfo{
+			Code:    status.INVALID_PARAM,
+			Message: "invalid request",
+		})
+	}
+
+	param := service.SearchFileParam{
+		Keyword: typeconv.StringVal(req.Keyword),
+	}
+	if req.Filter != nil {
+		if req.Filter.MimetypeIn != nil {
+			param.Mimetypes = *req.Filter.MimetypeIn
+		}
+		if req.Filter.ExtensionIn != nil {
+			param.Extensions = *req.Filter.ExtensionIn
+		}
+		if req.Filter.StatusIn != nil {
+			for _, status := range *req.Filter.StatusIn {
+				param.Statuses = append(param.Statuses, string(status))
+			}
+		}
+		param.MinSize = req.Filter.SizeGte
+		param.MaxSize = req.Filter.SizeLte
+		if req.Filter.UploadedAtGte != nil {
+			param.UploadedFrom = typeconv.Time(time.UnixMilli(*req.Filter.UploadedAtGte).UTC())
+		}
+		if req.Filter.UploadedAtLte != nil {
+			param.UploadedTo = typeconv.Time(time.UnixMilli(*req.Filter.UploadedAtLte).UTC())
+		}
+	}
+
+	if req.Pagination != nil {
+		param.TotalItems = req.Pagination.TotalItems
+		param.Page = req.Pagination.Page
+	}
+
+	if req.Sort != nil {
+		param.SortBy = string(req.Sort.Field)
+		if req.Sort.Order != nil {
+			param.SortOrder = string(*req.Sort.Order)
+		}
+	}
+
+	searchRes, err := h.fileClient.SearchFile(ctx.Request().Context(), param)
+	if err != nil {
+		switch err.Code {
+		case status.INVALID_PARAM:
+			return echo.NewHTTPError(http.StatusBadRequest, &restapp.ResponseBodyInfo{
+				Code:    err.Code,
+				Message: err.Message,
+			})
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, &restapp.ResponseBodyInfo{
+			Code:    err.Code,
+			Message: err.Message,
+		})
+	}
+
+	items := []restapp.SearchFileItem{}
+	for _, searchItem := range searchRes.Items {
+		var deletedAt *int64
+		if searchItem.DeletedAt != nil {
+			deleted := searchItem.DeletedAt.UnixMilli()
+			deletedAt = &deleted
+		}
+
+		var checksumMd5 *string
+		if searchItem.Checksum.Md5 != "" {
+			checksumMd5 = typeconv.String(searchItem.Checksum.Md5)
+		}
+
+		items = append(items, restapp.SearchFileItem{
+			Id:             searchItem.UniqueId,
+			Name:           searchItem.Name,
+			Mimetype:       searchItem.MimeType,
+			Extension:      searchItem.Extension,
+			Size:           searchItem.Size,
+			UploadedAt:     searchItem.UploadedAt.UnixMilli(),
+			DeletedAt:      deletedAt,
+			ChecksumSha256: searchItem.Checksum.Sha256,
+			ChecksumMd5:    checksumMd5,
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, &restapp.SearchFileResponse{
+		Code:    searchRes.Success.Code,
+		Message: searchRes.Success.Message,
+		Data: restapp.SearchFileData{
+			Items: items,
+			Summary: restapp.SearchFileSummary{
+				Page:       searchRes.Summary.Page,
+				TotalItems: searchRes.Summary.TotalItems,
+			},
 		},
 	})
 }
@@ -135,3 +423,168 @@ func NewFile(p FileParam) *fileHandler {
 		fileParser: p.FileParser,
 	}
 }
+
+func newRetrieveFileError(err *system.Error) error {
+	httpCode := http.StatusInternalServerError
+	switch err.Code {
+	case status.INVALID_PARAM:
+		httpCode = http.StatusBadRequest
+	case status.ACTION_FORBIDDEN:
+		httpCode = http.StatusForbidden
+	case status.RESOURCE_NOTFOUND:
+		httpCode = http.StatusNotFound
+	case service.FILE_RANGE_INVALID:
+		httpCode = http.StatusRequestedRangeNotSatisfiable
+	}
+	return echo.NewHTTPError(httpCode, &restapp.ResponseBodyInfo{
+		Code:    err.Code,
+		Message: err.Message,
+	})
+}
+
+func setFileHeader(header http.Header, f *service.RetrieveFileResult) {
+	header.Set("Accept-Ranges", "bytes")
+	header.Set("X-File-Name", f.Name)
+	header.Set("X-File-Mimetype", f.MimeType)
+	header.Set("X-File-Extension", f.Extension)
+	header.Set("X-File-Size", fmt.Sprintf("%d", f.Size))
+	if !f.UploadedAt.IsZero() {
+		header.Set("Last-Modified", f.UploadedAt.UTC().Format(http.TimeFormat))
+	}
+	if f.Checksum.Sha256 != "" {
+		header.Set("ETag", fileETag(f))
+
+		digest, derr := hex.DecodeString(f.Checksum.Sha256)
+		if derr == nil {
+			header.Set("Digest", "sha-256="+base64.StdEncoding.EncodeToString(digest))
+		}
+	}
+}
+
+// @note: entity tag is suffixed by the content encoding
+// since the encoded data is a different representation of the file
+func fileETag(f *service.RetrieveFileResult) string {
+	if f.ContentEncoding != "" {
+		return fmt.Sprintf(`"%s-%s"`, f.Checksum.Sha256, f.ContentEncoding)
+	}
+	return fmt.Sprintf(`"%s"`, f.Checksum.Sha256)
+}
+
+// @note: If-None-Match takes precedence over If-Modified-Since (rfc 7232)
+func isNotModified(req *http.Request, f *service.RetrieveFileResult) bool {
+	etag := ""
+	if f.Checksum.Sha256 != "" {
+		etag = fileETag(f)
+	}
+
+	noneMatch := req.Header.Get("If-None-Match")
+	if noneMatch != "" {
+		if etag == "" {
+			return false
+		}
+		for _, tag := range strings.Split(noneMatch, ",") {
+			tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+			if tag == "*" || tag == etag {
+				return true
+			}
+		}
+		return false
+	}
+
+	modifiedSince := req.Header.Get("If-Modified-Since")
+	if modifiedSince == "" || f.UploadedAt.IsZero() {
+		return false
+	}
+	since, err := http.ParseTime(modifiedSince)
+	if err != nil {
+		return false
+	}
+	return !f.UploadedAt.Truncate(time.Second).After(since)
+}
+
+// @note: range is ignored when If-Range validator does not match,
+// weak entity tag is never matched since it requires strong comparison
+func isRangeApplied(req *http.Request, f *service.RetrieveFileResult) bool {
+	ifRange := strings.TrimSpace(req.Header.Get("If-Range"))
+	if ifRange == "" {
+		return true
+	}
+
+	if strings.HasPrefix(ifRange, `"`) {
+		return f.Checksum.Sha256 != "" && ifRange == fmt.Sprintf(`"%s"`, f.Checksum.Sha256)
+	}
+	if strings.HasPrefix(ifRange, "W/") {
+		return false
+	}
+
+	date, err := http.ParseTime(ifRange)
+	if err != nil || f.UploadedAt.IsZero() {
+		return false
+	}
+	return f.UploadedAt.Truncate(time.Second).Equal(date)
+}
+
+func parseAcceptEncoding(values []string) []string {
+	var encodings []string
+	for _, value := range values {
+		for _, encoding := range strings.Split(value, ",") {
+			encoding = strings.TrimSpace(encoding)
+			if encoding != "" {
+				encodings = append(encodings, encoding)
+			}
+		}
+	}
+	return encodings
+}
+
+// @note: only single byte range is supported, invalid or multiple ranges are ignored
+// so the whole file is returned as allowed by rfc 7233
+func parseRange(header string, size int64) (offset, length int64, valid, satisfiable bool) {
+	if !strings.HasPrefix(header, "bytes=") {
+		return 0, 0, false, true
+	}
+	spec := strings.TrimSpace(strings.TrimPrefix(header, "bytes="))
+	if strings.Contains(spec, ",") {
+		return 0, 0, false, true
+	}
+
+	bounds := strings.SplitN(spec, "-", 2)
+	if len(bounds) != 2 {
+		return 0, 0, false, true
+	}
+	startSpec := strings.TrimSpace(bounds[0])
+	endSpec := strings.TrimSpace(bounds[1])
+
+	if startSpec == "" {
+		suffix, err := strconv.ParseInt(endSpec, 10, 64)
+		if err != nil || suffix < 0 {
+			return 0, 0, false, true
+		}
+		if suffix == 0 || size == 0 {
+			return 0, 0, false, false
+		}
+		if suffix > size {
+			suffix = size
+		}
+		return size - suffix, suffix, true, true
+	}
+
+	start, err := strconv.ParseInt(startSpec, 10, 64)
+	if err != nil || start < 0 {
+		return 0, 0, false, true
+	}
+	end := size - 1
+	if endSpec != "" {
+		end, err = strconv.ParseInt(endSpec, 10, 64)
+		if err != nil || end < start {
+			return 0, 0, false, true
+		}
+	}
+	if start >= size {
+		return 0, 0, false, false
+	}
+	if end >= size {
+		end = size - 1
+	}
+	return start, end - start + 1, true, true
+}
@@ -2,21 +2,25 @@ package resthandler_test
 
 import (
 	"bytes"
+	"context"
 	encoding_json "encoding/json"
 	"fmt"
 	"io"
 	mime_multipart "mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"time"
 
 	"github.com/go-seidon/hippo/api/restapp"
+	"github.com/go-seidon/hippo/internal/file"
 	"github.com/go-seidon/hippo/internal/resthandler"
 	"github.com/go-seidon/hippo/internal/service"
 	mock_service "github.com/go-seidon/hippo/internal/service/mock"
 	"github.com/go-seidon/hippo/internal/storage/multipart"
 	mock_io "github.com/go-seidon/provider/io/mock"
 	"github.com/go-seidon/provider/system"
+	"github.com/go-seidon/provider/typeconv"
 	"github.com/golang/mock/gomock"
 	"github.com/labstack/echo/v4"
 	. "github.com/onsi/ginkgo/v2"
@@ -90,7 +94,7 @@ var _ = Describe("File Handler", func() {
 			fileData = mock_io.NewMockReadAtSeekCloser(ctrl)
 			fileHandler := resthandler.NewFile(resthandler.FileParam{
 				FileClient: fileClient,
-				FileParser: func(h *mime_multipart.FileHeader) (*multipart.FileInfo, error) {
+				FileParser: func(p *mime_multipart.Part) (*multipart.FileInfo, error) {
 					return &multipart.FileInfo{
 						Data:      fileData,
 						Name:      "dolphin 22",
@@ -112,6 +116,10 @@ var _ = Describe("File Handler", func() {
 				Extension:  "jpg",
 				Size:       23342,
 				UploadedAt: time.Now().UTC(),
+				Checksum: file.Checksum{
+					Sha256: "mock-sha256",
+					Md5:    "mock-md5",
+				},
 			}
 		})
 
@@ -130,72 +138,1235 @@ var _ = Describe("File Handler", func() {
 					Code: 400,
 					Message: &restapp.ResponseBodyInfo{
 						Code:    1002,
-						Message: "no multipart boundary param in Content-Type",
+						Message: "no multipart boundary param in Content-Type",
+					},
+				}))
+			})
+		})
+
+		When("file is not specified", func() {
+			It("should return error", func() {
+				body := new(bytes.Buffer)
+				writer := mime_multipart.NewWriter(body)
+				writer.WriteField("bucket", "avatar")
+				writer.Close()
+
+				req := httptest.NewRequest(http.MethodPost, "/", body)
+				req.Header.Set(echo.HeaderContentType, writer.FormDataContentType())
+				ctx := echo.New().NewContext(req, httptest.NewRecorder())
+
+				err := h(ctx)
+
+				Expect(err).To(Equal(&echo.HTTPError{
+					Code: 400,
+					Message: &restapp.ResponseBodyInfo{
+						Code:    1002,
+						Message: "http: no such file",
+					},
+				}))
+			})
+		})
+
+		When("bucket is too long", func() {
+			It("should return error", func() {
+				body := new(bytes.Buffer)
+				writer := mime_multipart.NewWriter(body)
+				writer.WriteField("bucket", strings.Repeat("a", 1025))
+				writer.CreateFormFile("file", "file.go")
+				writer.Close()
+
+				req := httptest.NewRequest(http.MethodPost, "/", body)
+				req.Header.Set(echo.HeaderContentType, writer.FormDataContentType())
+				ctx := echo.New().NewContext(req, httptest.NewRecorder())
+
+				err := h(ctx)
+
+				Expect(err).To(Equal(&echo.HTTPError{
+					Code: 400,
+					Message: &restapp.ResponseBodyInfo{
+						Code:    1002,
+						Message: "bucket is too long",
+					},
+				}))
+			})
+		})
+
+		When("bucket is sent before the file", func() {
+			It("should pass the file part", func() {
+				body := new(bytes.Buffer)
+				writer := mime_multipart.NewWriter(body)
+				writer.WriteField("bucket", "avatar")
+				fw, _ := writer.CreateFormFile("file", "dolphin.jpg")
+				fw.Write([]byte("content"))
+				writer.Close()
+
+				req := httptest.NewRequest(http.MethodPost, "/", body)
+				req.Header.Set(echo.HeaderContentType, writer.FormDataContentType())
+				rec := httptest.NewRecorder()
+				ctx := echo.New().NewContext(req, rec)
+
+				fileHandler := resthandler.NewFile(resthandler.FileParam{
+					FileClient: fileClient,
+					FileParser: func(p *mime_multipart.Part) (*multipart.FileInfo, error) {
+						Expect(p.FormName()).To(Equal("file"))
+						Expect(p.FileName()).To(Equal("dolphin.jpg"))
+						return &multipart.FileInfo{
+							Data:      fileData,
+							Name:      "dolphin",
+							Extension: "jpg",
+							Mimetype:  "image/jpeg",
+						}, nil
+					},
+				})
+
+				fileData.
+					EXPECT().
+					Close().
+					Return(nil).
+					Times(1)
+
+				fileClient.
+					EXPECT().
+					UploadFile(gomock.Eq(ctx.Request().Context()), gomock.Any()).
+					Return(uploadRes, nil).
+					Times(1)
+
+				err := fileHandler.UploadFile(ctx)
+
+				Expect(err).To(BeNil())
+				Expect(rec.Code).To(Equal(http.StatusOK))
+			})
+		})
+
+		When("failed parse file", func() {
+			It("should return error", func() {
+				fileHandler := resthandler.NewFile(resthandler.FileParam{
+					FileClient: fileClient,
+					FileParser: func(p *mime_multipart.Part) (*multipart.FileInfo, error) {
+						return nil, fmt.Errorf("disk error")
+					},
+				})
+				err := fileHandler.UploadFile(ctx)
+
+				Expect(err).To(Equal(&echo.HTTPError{
+					Code: 400,
+					Message: &restapp.ResponseBodyInfo{
+						Code:    1002,
+						Message: "disk error",
+					},
+				}))
+			})
+		})
+
+		When("there is invalid data", func() {
+			It("should return error", func() {
+				fileData.
+					EXPECT().
+					Close().
+					Return(nil).
+					Times(1)
+
+				fileClient.
+					EXPECT().
+					UploadFile(gomock.Eq(ctx.Request().Context()), gomock.Any()).
+					Return(nil, &system.Error{
+						Code:    1002,
+						Message: "invalid param",
+					}).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(Equal(&echo.HTTPError{
+					Code: 400,
+					Message: &restapp.ResponseBodyInfo{
+						Code:    1002,
+						Message: "invalid param",
+					},
+				}))
+			})
+		})
+
+		When("failed upload file", func() {
+			It("should return error", func() {
+				fileData.
+					EXPECT().
+					Close().
+					Return(nil).
+					Times(1)
+
+				fileClient.
+					EXPECT().
+					UploadFile(gomock.Eq(ctx.Request().Context()), gomock.Any()).
+					Return(nil, &system.Error{
+						Code:    1001,
+						Message: "network error",
+					}).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(Equal(&echo.HTTPError{
+					Code: 500,
+					Message: &restapp.ResponseBodyInfo{
+						Code:    1001,
+						Message: "network error",
+					},
+				}))
+			})
+		})
+
+		When("file is not allowed", func() {
+			It("should return error", func() {
+				fileData.
+					EXPECT().
+					Close().
+					Return(nil).
+					Times(1)
+
+				fileClient.
+					EXPECT().
+					UploadFile(gomock.Eq(ctx.Request().Context()), gomock.Any()).
+					Return(nil, &system.Error{
+						Code:    2007,
+						Message: "mimetype is not allowed",
+					}).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(Equal(&echo.HTTPError{
+					Code: 415,
+					Message: &restapp.ResponseBodyInfo{
+						Code:    2007,
+						Message: "mimetype is not allowed",
+					},
+				}))
+			})
+		})
+
+		When("file is infected", func() {
+			It("should return error", func() {
+				fileData.
+					EXPECT().
+					Close().
+					Return(nil).
+					Times(1)
+
+				fileClient.
+					EXPECT().
+					UploadFile(gomock.Eq(ctx.Request().Context()), gomock.Any()).
+					Return(nil, &system.Error{
+						Code:    2008,
+						Message: "file is infected: Eicar-Signature",
+					}).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(Equal(&echo.HTTPError{
+					Code: 422,
+					Message: &restapp.ResponseBodyInfo{
+						Code:    2008,
+						Message: "file is infected: Eicar-Signature",
+					},
+				}))
+			})
+		})
+
+		When("file is not scanned", func() {
+			It("should return error", func() {
+				fileData.
+					EXPECT().
+					Close().
+					Return(nil).
+					Times(1)
+
+				fileClient.
+					EXPECT().
+					UploadFile(gomock.Eq(ctx.Request().Context()), gomock.Any()).
+					Return(nil, &system.Error{
+						Code:    2009,
+						Message: "scanner is unavailable",
+					}).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(Equal(&echo.HTTPError{
+					Code: 503,
+					Message: &restapp.ResponseBodyInfo{
+						Code:    2009,
+						Message: "scanner is unavailable",
+					},
+				}))
+			})
+		})
+
+		When("file exceeds the scanner limit", func() {
+			It("should return error", func() {
+				fileData.
+					EXPECT().
+					Close().
+					Return(nil).
+					Times(1)
+
+				fileClient.
+					EXPECT().
+					UploadFile(gomock.Eq(ctx.Request().Context()), gomock.Any()).
+					Return(nil, &system.Error{
+						Code:    2012,
+						Message: "scanner limit is exceeded: INSTREAM size limit exceeded.",
+					}).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(Equal(&echo.HTTPError{
+					Code: 413,
+					Message: &restapp.ResponseBodyInfo{
+						Code:    2012,
+						Message: "scanner limit is exceeded: INSTREAM size limit exceeded.",
+					},
+				}))
+			})
+		})
+
+		When("client quota is exceeded", func() {
+			It("should return error", func() {
+				fileData.
+					EXPECT().
+					Close().
+					Return(nil).
+					Times(1)
+
+				fileClient.
+					EXPECT().
+					UploadFile(gomock.Eq(ctx.Request().Context()), gomock.Any()).
+					Return(nil, &system.Error{
+						Code:    2010,
+						Message: "quota exceeded: max total files",
+					}).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(Equal(&echo.HTTPError{
+					Code: 429,
+					Message: &restapp.ResponseBodyInfo{
+						Code:    2010,
+						Message: "quota exceeded: max total files",
+					},
+				}))
+			})
+		})
+
+		When("file size quota is exceeded", func() {
+			It("should return error", func() {
+				fileData.
+					EXPECT().
+					Close().
+					Return(nil).
+					Times(1)
+
+				fileClient.
+					EXPECT().
+					UploadFile(gomock.Eq(ctx.Request().Context()), gomock.Any()).
+					Return(nil, &system.Error{
+						Code:    2011,
+						Message: "file size quota is exceeded",
+					}).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(Equal(&echo.HTTPError{
+					Code: 413,
+					Message: &restapp.ResponseBodyInfo{
+						Code:    2011,
+						Message: "file size quota is exceeded",
+					},
+				}))
+			})
+		})
+
+		When("success upload file", func() {
+			It("should return result", func() {
+				fileData.
+					EXPECT().
+					Close().
+					Return(nil).
+					Times(1)
+
+				fileClient.
+					EXPECT().
+					UploadFile(gomock.Eq(ctx.Request().Context()), gomock.Any()).
+					Return(uploadRes, nil).
+					Times(1)
+
+				err := h(ctx)
+
+				res := &restapp.UploadFileResponse{}
+				encoding_json.Unmarshal(rec.Body.Bytes(), res)
+
+				Expect(err).To(BeNil())
+				Expect(res.Code).To(Equal(uploadRes.Success.Code))
+				Expect(res.Message).To(Equal(uploadRes.Success.Message))
+				Expect(res.Data).To(Equal(restapp.UploadFileData{
+					Id:             uploadRes.UniqueId,
+					Name:           uploadRes.Name,
+					Extension:      uploadRes.Extension,
+					Mimetype:       uploadRes.Mimetype,
+					Size:           uploadRes.Size,
+					UploadedAt:     uploadRes.UploadedAt.Local().UnixMilli(),
+					ChecksumSha256: uploadRes.Checksum.Sha256,
+					ChecksumMd5:    typeconv.String(uploadRes.Checksum.Md5),
+				}))
+			})
+		})
+	})
+
+	Context("RetrieveFileById function", Label("unit"), func() {
+		var (
+			ctx          echo.Context
+			h            func(ctx echo.Context) error
+			rec          *httptest.ResponseRecorder
+			fileClient   *mock_service.MockFile
+			findParam    service.RetrieveFileParam
+			findRes      *service.RetrieveFileResult
+			fileData     *mock_io.MockReadCloser
+			retrieved    service.RetrieveFileParam
+			selected     *service.RetrieveFileSelection
+			retrieveFile func(res *service.RetrieveFileResult) func(context.Context, service.RetrieveFileParam) (*service.RetrieveFileResult, *system.Error)
+		)
+
+		BeforeEach(func() {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec = httptest.NewRecorder()
+
+			e := echo.New()
+			ctx = e.NewContext(req, rec)
+			ctx.SetParamNames("id")
+			ctx.SetParamValues("id")
+
+			t := GinkgoT()
+			ctrl := gomock.NewController(t)
+			fileClient = mock_service.NewMockFile(ctrl)
+			fileHandler := resthandler.NewFile(resthandler.FileParam{
+				FileClient: fileClient,
+			})
+			h = fileHandler.RetrieveFileById
+			findParam = service.RetrieveFileParam{
+				FileId: "id",
+			}
+			fileData = mock_io.NewMockReadCloser(ctrl)
+			findRes = &service.RetrieveFileResult{
+				Success: system.Success{
+					Code:    1000,
+					Message: "success retrieve file",
+				},
+				Data:      fileData,
+				UniqueId:  "id",
+				Path:      "path",
+				MimeType:  "image/jpeg",
+				Name:      "dolhpin",
+				Extension: "jpg",
+				Size:      2334,
+				Length:    2334,
+				Checksum: file.Checksum{
+					Sha256: "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+				},
+			}
+			retrieved = service.RetrieveFileParam{}
+			selected = nil
+
+			// @note: emulate the service by evaluating the selection against the file metadata
+			retrieveFile = func(res *service.RetrieveFileResult) func(context.Context, service.RetrieveFileParam) (*service.RetrieveFileResult, *system.Error) {
+				return func(_ context.Context, p service.RetrieveFileParam) (*service.RetrieveFileResult, *system.Error) {
+					Expect(p.Select).ToNot(BeNil())
+					meta := *res
+					meta.Data = nil
+					selection, err := p.Select(&meta)
+					if err != nil {
+						return nil, err
+					}
+					selected = selection
+					p.Select = nil
+					retrieved = p
+					if selection != nil && selection.Skip {
+						return &meta, nil
+					}
+					return res, nil
+				}
+			}
+		})
+
+		When("verify_checksum parameter is invalid", func() {
+			It("should return error", func() {
+				req := httptest.NewRequest(http.MethodGet, "/?verify_checksum=maybe", nil)
+				ctx := echo.New().NewContext(req, rec)
+				ctx.SetParamNames("id")
+				ctx.SetParamValues("id")
+
+				err := h(ctx)
+
+				Expect(err).To(Equal(&echo.HTTPError{
+					Code: 400,
+					Message: &restapp.ResponseBodyInfo{
+						Code:    1002,
+						Message: "invalid verify_checksum parameter",
+					},
+				}))
+			})
+		})
+
+		When("there is invalid data", func() {
+			It("should return error", func() {
+				fileClient.
+					EXPECT().
+					RetrieveFile(gomock.Eq(ctx.Request().Context()), gomock.Any()).
+					Return(nil, &system.Error{
+						Code:    1002,
+						Message: "invalid data",
+					}).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(Equal(&echo.HTTPError{
+					Code: 400,
+					Message: &restapp.ResponseBodyInfo{
+						Code:    1002,
+						Message: "invalid data",
+					},
+				}))
+			})
+		})
+
+		When("file is not accessible", func() {
+			It("should return error", func() {
+				fileClient.
+					EXPECT().
+					RetrieveFile(gomock.Eq(ctx.Request().Context()), gomock.Any()).
+					Return(nil, &system.Error{
+						Code:    1003,
+						Message: "file is not accessible",
+					}).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(Equal(&echo.HTTPError{
+					Code: 403,
+					Message: &restapp.ResponseBodyInfo{
+						Code:    1003,
+						Message: "file is not accessible",
+					},
+				}))
+			})
+		})
+
+		When("file is not available", func() {
+			It("should return error", func() {
+				fileClient.
+					EXPECT().
+					RetrieveFile(gomock.Eq(ctx.Request().Context()), gomock.Any()).
+					Return(nil, &system.Error{
+						Code:    1004,
+						Message: "file is not available",
+					}).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(Equal(&echo.HTTPError{
+					Code: 404,
+					Message: &restapp.ResponseBodyInfo{
+						Code:    1004,
+						Message: "file is not available",
+					},
+				}))
+			})
+		})
+
+		When("failed find file", func() {
+			It("should return error", func() {
+				fileClient.
+					EXPECT().
+					RetrieveFile(gomock.Eq(ctx.Request().Context()), gomock.Any()).
+					Return(nil, &system.Error{
+						Code:    1001,
+						Message: "network error",
+					}).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(Equal(&echo.HTTPError{
+					Code: 500,
+					Message: &restapp.ResponseBodyInfo{
+						Code:    1001,
+						Message: "network error",
+					},
+				}))
+			})
+		})
+
+		When("success retrieve file", func() {
+			It("should return result", func() {
+				fileData.
+					EXPECT().
+					Close().
+					Return(nil).
+					Times(1)
+
+				fileData.
+					EXPECT().
+					Read(gomock.Any()).
+					Return(0, io.EOF).
+					Times(1)
+
+				fileClient.
+					EXPECT().
+					RetrieveFile(gomock.Eq(ctx.Request().Context()), gomock.Any()).
+					DoAndReturn(retrieveFile(findRes)).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(BeNil())
+				Expect(retrieved).To(Equal(findParam))
+				Expect(selected).To(BeNil())
+				Expect(rec.Code).To(Equal(http.StatusOK))
+				Expect(rec.Header().Get("ETag")).To(Equal(`"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`))
+				Expect(rec.Header().Get("Digest")).To(Equal("sha-256=n4bQgYhMfWWaL+qgxVrQFaO/TxsrC4Is0V1sFbDwCgg="))
+				Expect(rec.Header().Get("Content-Length")).To(Equal("2334"))
+			})
+		})
+
+		When("compressed encoding is accepted", func() {
+			It("should return compressed data", func() {
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+				req.Header.Add("Accept-Encoding", "br, gzip")
+				req.Header.Add("Accept-Encoding", "zstd;q=0")
+				ctx := echo.New().NewContext(req, rec)
+				ctx.SetParamNames("id")
+				ctx.SetParamValues("id")
+
+				fileData.
+					EXPECT().
+					Close().
+					Return(nil).
+					Times(1)
+
+				fileData.
+					EXPECT().
+					Read(gomock.Any()).
+					Return(0, io.EOF).
+					Times(1)
+
+				findParam.AcceptEncodings = []string{"br", "gzip", "zstd;q=0"}
+				findRes.Compression = "gzip"
+				findRes.ContentEncoding = "gzip"
+				fileClient.
+					EXPECT().
+					RetrieveFile(gomock.Eq(ctx.Request().Context()), gomock.Any()).
+					DoAndReturn(retrieveFile(findRes)).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(BeNil())
+				Expect(retrieved).To(Equal(findParam))
+				Expect(rec.Header().Get("Content-Encoding")).To(Equal("gzip"))
+				Expect(rec.Header().Get("ETag")).To(Equal(`"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08-gzip"`))
+				Expect(rec.Header().Get("Vary")).To(Equal("Accept-Encoding"))
+				Expect(rec.Header().Get("Content-Length")).To(BeEmpty())
+				Expect(rec.Header().Get("Digest")).To(BeEmpty())
+			})
+		})
+
+		When("compressed file is served decompressed", func() {
+			It("should return identity entity tag", func() {
+				fileData.
+					EXPECT().
+					Close().
+					Return(nil).
+					Times(1)
+
+				fileData.
+					EXPECT().
+					Read(gomock.Any()).
+					Return(0, io.EOF).
+					Times(1)
+
+				findRes.Compression = "gzip"
+				fileClient.
+					EXPECT().
+					RetrieveFile(gomock.Eq(ctx.Request().Context()), gomock.Any()).
+					DoAndReturn(retrieveFile(findRes)).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(BeNil())
+				Expect(rec.Header().Get("Content-Encoding")).To(BeEmpty())
+				Expect(rec.Header().Get("ETag")).To(Equal(`"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`))
+				Expect(rec.Header().Get("Vary")).To(Equal("Accept-Encoding"))
+				Expect(rec.Header().Get("Content-Length")).To(Equal("2334"))
+			})
+		})
+
+		When("checksum verification is requested", func() {
+			It("should return result", func() {
+				req := httptest.NewRequest(http.MethodGet, "/?verify_checksum=true", nil)
+				ctx := echo.New().NewContext(req, rec)
+				ctx.SetParamNames("id")
+				ctx.SetParamValues("id")
+
+				fileData.
+					EXPECT().
+					Close().
+					Return(nil).
+					Times(1)
+
+				fileData.
+					EXPECT().
+					Read(gomock.Any()).
+					Return(0, io.EOF).
+					Times(1)
+
+				findParam.VerifyChecksum = true
+				fileClient.
+					EXPECT().
+					RetrieveFile(gomock.Eq(ctx.Request().Context()), gomock.Any()).
+					DoAndReturn(retrieveFile(findRes)).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(BeNil())
+				Expect(retrieved).To(Equal(findParam))
+			})
+		})
+
+		When("file has no checksum", func() {
+			It("should not set checksum header", func() {
+				fileData.
+					EXPECT().
+					Close().
+					Return(nil).
+					Times(1)
+
+				fileData.
+					EXPECT().
+					Read(gomock.Any()).
+					Return(0, io.EOF).
+					Times(1)
+
+				findRes.Checksum = file.Checksum{}
+				fileClient.
+					EXPECT().
+					RetrieveFile(gomock.Eq(ctx.Request().Context()), gomock.Any()).
+					DoAndReturn(retrieveFile(findRes)).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(BeNil())
+				Expect(rec.Header().Get("ETag")).To(BeEmpty())
+				Expect(rec.Header().Get("Digest")).To(BeEmpty())
+			})
+		})
+
+		When("range is requested", func() {
+			It("should return partial content", func() {
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+				req.Header.Set("Range", "bytes=2-4")
+				ctx := echo.New().NewContext(req, rec)
+				ctx.SetParamNames("id")
+				ctx.SetParamValues("id")
+
+				findRes.Data = io.NopCloser(strings.NewReader("lph"))
+				findRes.Offset = 2
+				findRes.Length = 3
+				fileClient.
+					EXPECT().
+					RetrieveFile(gomock.Eq(ctx.Request().Context()), gomock.Any()).
+					DoAndReturn(retrieveFile(findRes)).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(BeNil())
+				Expect(selected).To(Equal(&service.RetrieveFileSelection{
+					Offset: 2,
+					Length: 3,
+				}))
+				Expect(rec.Code).To(Equal(http.StatusPartialContent))
+				Expect(rec.Header().Get("Content-Range")).To(Equal("bytes 2-4/2334"))
+				Expect(rec.Header().Get("Content-Length")).To(Equal("3"))
+				Expect(rec.Header().Get("Accept-Ranges")).To(Equal("bytes"))
+				Expect(rec.Body.String()).To(Equal("lph"))
+			})
+		})
+
+		When("suffix range is requested", func() {
+			It("should return the last bytes", func() {
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+				req.Header.Set("Range", "bytes=-4")
+				ctx := echo.New().NewContext(req, rec)
+				ctx.SetParamNames("id")
+				ctx.SetParamValues("id")
+
+				findRes.Data = io.NopCloser(strings.NewReader("data"))
+				findRes.Offset = 2330
+				findRes.Length = 4
+				fileClient.
+					EXPECT().
+					RetrieveFile(gomock.Eq(ctx.Request().Context()), gomock.Any()).
+					DoAndReturn(retrieveFile(findRes)).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(BeNil())
+				Expect(selected).To(Equal(&service.RetrieveFileSelection{
+					Offset: 2330,
+					Length: 4,
+				}))
+				Expect(rec.Code).To(Equal(http.StatusPartialContent))
+				Expect(rec.Header().Get("Content-Range")).To(Equal("bytes 2330-2333/2334"))
+			})
+		})
+
+		When("range is not satisfiable", func() {
+			It("should return error", func() {
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+				req.Header.Set("Range", "bytes=2334-")
+				ctx := echo.New().NewContext(req, rec)
+				ctx.SetParamNames("id")
+				ctx.SetParamValues("id")
+
+				fileClient.
+					EXPECT().
+					RetrieveFile(gomock.Eq(ctx.Request().Context()), gomock.Any()).
+					DoAndReturn(retrieveFile(findRes)).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(Equal(&echo.HTTPError{
+					Code: 416,
+					Message: &restapp.ResponseBodyInfo{
+						Code:    2005,
+						Message: "file range is not satisfiable",
+					},
+				}))
+				Expect(rec.Header().Get("Content-Range")).To(Equal("bytes */2334"))
+			})
+		})
+
+		When("multiple ranges are requested", func() {
+			It("should return whole file", func() {
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+				req.Header.Set("Range", "bytes=0-1,4-5")
+				ctx := echo.New().NewContext(req, rec)
+				ctx.SetParamNames("id")
+				ctx.SetParamValues("id")
+
+				findRes.Data = io.NopCloser(strings.NewReader("content"))
+				findRes.Length = 7
+				fileClient.
+					EXPECT().
+					RetrieveFile(gomock.Eq(ctx.Request().Context()), gomock.Any()).
+					DoAndReturn(retrieveFile(findRes)).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(BeNil())
+				Expect(selected).To(BeNil())
+				Expect(rec.Code).To(Equal(http.StatusOK))
+				Expect(rec.Header().Get("Content-Range")).To(BeEmpty())
+			})
+		})
+
+		When("If-Range is not matched", func() {
+			It("should return whole file", func() {
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+				req.Header.Set("Range", "bytes=2-4")
+				req.Header.Set("If-Range", `"outdated-etag"`)
+				ctx := echo.New().NewContext(req, rec)
+				ctx.SetParamNames("id")
+				ctx.SetParamValues("id")
+
+				findRes.Data = io.NopCloser(strings.NewReader("content"))
+				findRes.Length = 7
+				fileClient.
+					EXPECT().
+					RetrieveFile(gomock.Eq(ctx.Request().Context()), gomock.Any()).
+					DoAndReturn(retrieveFile(findRes)).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(BeNil())
+				Expect(selected).To(BeNil())
+				Expect(rec.Code).To(Equal(http.StatusOK))
+				Expect(rec.Body.String()).To(Equal("content"))
+			})
+		})
+
+		When("If-None-Match is matched", func() {
+			It("should return not modified", func() {
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+				req.Header.Set("If-None-Match", `W/"other", "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`)
+				ctx := echo.New().NewContext(req, rec)
+				ctx.SetParamNames("id")
+				ctx.SetParamValues("id")
+
+				fileClient.
+					EXPECT().
+					RetrieveFile(gomock.Eq(ctx.Request().Context()), gomock.Any()).
+					DoAndReturn(retrieveFile(findRes)).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(BeNil())
+				Expect(selected).To(Equal(&service.RetrieveFileSelection{Skip: true}))
+				Expect(rec.Code).To(Equal(http.StatusNotModified))
+				Expect(rec.Body.Len()).To(Equal(0))
+			})
+		})
+
+		When("If-None-Match is matched with the encoded entity tag", func() {
+			It("should return not modified", func() {
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+				req.Header.Set("Accept-Encoding", "gzip")
+				req.Header.Set("If-None-Match", `"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08-gzip"`)
+				ctx := echo.New().NewContext(req, rec)
+				ctx.SetParamNames("id")
+				ctx.SetParamValues("id")
+
+				findRes.Compression = "gzip"
+				findRes.ContentEncoding = "gzip"
+				fileClient.
+					EXPECT().
+					RetrieveFile(gomock.Eq(ctx.Request().Context()), gomock.Any()).
+					DoAndReturn(retrieveFile(findRes)).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(BeNil())
+				Expect(selected).To(Equal(&service.RetrieveFileSelection{Skip: true}))
+				Expect(rec.Code).To(Equal(http.StatusNotModified))
+				Expect(rec.Header().Get("ETag")).To(Equal(`"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08-gzip"`))
+				Expect(rec.Header().Get("Vary")).To(Equal("Accept-Encoding"))
+			})
+		})
+
+		When("If-None-Match is matched with other encoding entity tag", func() {
+			It("should return whole file", func() {
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+				req.Header.Set("If-None-Match", `"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08-gzip"`)
+				ctx := echo.New().NewContext(req, rec)
+				ctx.SetParamNames("id")
+				ctx.SetParamValues("id")
+
+				findRes.Data = io.NopCloser(strings.NewReader("content"))
+				findRes.Length = 7
+				findRes.Compression = "gzip"
+				fileClient.
+					EXPECT().
+					RetrieveFile(gomock.Eq(ctx.Request().Context()), gomock.Any()).
+					DoAndReturn(retrieveFile(findRes)).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(BeNil())
+				Expect(selected).To(BeNil())
+				Expect(rec.Code).To(Equal(http.StatusOK))
+				Expect(rec.Body.String()).To(Equal("content"))
+			})
+		})
+
+		When("file is not modified since the requested time", func() {
+			It("should return not modified", func() {
+				uploadedAt := time.Date(2022, 10, 1, 8, 0, 0, 0, time.UTC)
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+				req.Header.Set("If-Modified-Since", uploadedAt.Format(http.TimeFormat))
+				ctx := echo.New().NewContext(req, rec)
+				ctx.SetParamNames("id")
+				ctx.SetParamValues("id")
+
+				findRes.UploadedAt = uploadedAt.Add(500 * time.Millisecond)
+				fileClient.
+					EXPECT().
+					RetrieveFile(gomock.Eq(ctx.Request().Context()), gomock.Any()).
+					DoAndReturn(retrieveFile(findRes)).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(BeNil())
+				Expect(rec.Code).To(Equal(http.StatusNotModified))
+				Expect(rec.Header().Get("Last-Modified")).To(Equal("Sat, 01 Oct 2022 08:00:00 GMT"))
+			})
+		})
+	})
+
+	Context("RetrieveFileMetaById function", Label("unit"), func() {
+		var (
+			ctx        echo.Context
+			h          func(ctx echo.Context) error
+			rec        *httptest.ResponseRecorder
+			fileClient *mock_service.MockFile
+			findParam  service.RetrieveFileParam
+			findRes    *service.RetrieveFileResult
+		)
+
+		BeforeEach(func() {
+			req := httptest.NewRequest(http.MethodHead, "/", nil)
+			rec = httptest.NewRecorder()
+
+			e := echo.New()
+			ctx = e.NewContext(req, rec)
+			ctx.SetParamNames("id")
+			ctx.SetParamValues("id")
+
+			t := GinkgoT()
+			ctrl := gomock.NewController(t)
+			fileClient = mock_service.NewMockFile(ctrl)
+			fileHandler := resthandler.NewFile(resthandler.FileParam{
+				FileClient: fileClient,
+			})
+			h = fileHandler.RetrieveFileMetaById
+			findParam = service.RetrieveFileParam{
+				FileId:       "id",
+				MetadataOnly: true,
+			}
+			findRes = &service.RetrieveFileResult{
+				Success: system.Success{
+					Code:    1000,
+					Message: "success retrieve file",
+				},
+				UniqueId:  "id",
+				Path:      "path",
+				MimeType:  "image/jpeg",
+				Name:      "dolhpin",
+				Extension: "jpg",
+				Size:      2334,
+				Checksum: file.Checksum{
+					Sha256: "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+				},
+				Length: 2334,
+			}
+		})
+
+		When("file is not accessible", func() {
+			It("should return error", func() {
+				fileClient.
+					EXPECT().
+					RetrieveFile(gomock.Eq(ctx.Request().Context()), gomock.Eq(findParam)).
+					Return(nil, &system.Error{
+						Code:    1003,
+						Message: "file is not accessible",
+					}).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(Equal(&echo.HTTPError{
+					Code: 403,
+					Message: &restapp.ResponseBodyInfo{
+						Code:    1003,
+						Message: "file is not accessible",
+					},
+				}))
+			})
+		})
+
+		When("file is not available", func() {
+			It("should return error", func() {
+				fileClient.
+					EXPECT().
+					RetrieveFile(gomock.Eq(ctx.Request().Context()), gomock.Eq(findParam)).
+					Return(nil, &system.Error{
+						Code:    1004,
+						Message: "file is not found",
+					}).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(Equal(&echo.HTTPError{
+					Code: 404,
+					Message: &restapp.ResponseBodyInfo{
+						Code:    1004,
+						Message: "file is not found",
+					},
+				}))
+			})
+		})
+
+		When("If-None-Match is matched", func() {
+			It("should return not modified", func() {
+				ctx.Request().Header.Set("If-None-Match", "*")
+				fileClient.
+					EXPECT().
+					RetrieveFile(gomock.Eq(ctx.Request().Context()), gomock.Eq(findParam)).
+					Return(findRes, nil).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(BeNil())
+				Expect(rec.Code).To(Equal(http.StatusNotModified))
+			})
+		})
+
+		When("success retrieve file metadata", func() {
+			It("should return headers without body", func() {
+				fileClient.
+					EXPECT().
+					RetrieveFile(gomock.Eq(ctx.Request().Context()), gomock.Eq(findParam)).
+					Return(findRes, nil).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(BeNil())
+				Expect(rec.Code).To(Equal(http.StatusOK))
+				Expect(rec.Header().Get("Content-Length")).To(Equal("2334"))
+				Expect(rec.Header().Get("Content-Type")).To(Equal("image/jpeg"))
+				Expect(rec.Header().Get("X-File-Name")).To(Equal("dolhpin"))
+				Expect(rec.Header().Get("Accept-Ranges")).To(Equal("bytes"))
+				Expect(rec.Body.Len()).To(Equal(0))
+			})
+		})
+	})
+
+	Context("DeleteFileById function", Label("unit"), func() {
+		var (
+			currentTs   time.Time
+			ctx         echo.Context
+			h           func(ctx echo.Context) error
+			rec         *httptest.ResponseRecorder
+			fileClient  *mock_service.MockFile
+			deleteParam service.DeleteFileParam
+			deleteRes   *service.DeleteFileResult
+		)
+
+		BeforeEach(func() {
+			currentTs = time.Now().UTC()
+
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec = httptest.NewRecorder()
+
+			e := echo.New()
+			ctx = e.NewContext(req, rec)
+			ctx.SetParamNames("id")
+			ctx.SetParamValues("id")
+
+			t := GinkgoT()
+			ctrl := gomock.NewController(t)
+			fileClient = mock_service.NewMockFile(ctrl)
+			fileHandler := resthandler.NewFile(resthandler.FileParam{
+				FileClient: fileClient,
+			})
+			h = fileHandler.DeleteFileById
+			deleteParam = service.DeleteFileParam{
+				FileId: "id",
+			}
+			deleteRes = &service.DeleteFileResult{
+				Success: system.Success{
+					Code:    1000,
+					Message: "success delete file",
+				},
+				DeletedAt: currentTs,
+			}
+		})
+
+		When("there is invalid data", func() {
+			It("should return error", func() {
+				fileClient.
+					EXPECT().
+					DeleteFile(gomock.Eq(ctx.Request().Context()), gomock.Eq(deleteParam)).
+					Return(nil, &system.Error{
+						Code:    1002,
+						Message: "invalid data",
+					}).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(Equal(&echo.HTTPError{
+					Code: 400,
+					Message: &restapp.ResponseBodyInfo{
+						Code:    1002,
+						Message: "invalid data",
 					},
 				}))
 			})
 		})
 
-		When("failed parse file", func() {
+		When("file is not accessible", func() {
 			It("should return error", func() {
-				fileHandler := resthandler.NewFile(resthandler.FileParam{
-					FileClient: fileClient,
-					FileParser: func(h *mime_multipart.FileHeader) (*multipart.FileInfo, error) {
-						return nil, fmt.Errorf("disk error")
-					},
-				})
-				err := fileHandler.UploadFile(ctx)
+				fileClient.
+					EXPECT().
+					DeleteFile(gomock.Eq(ctx.Request().Context()), gomock.Eq(deleteParam)).
+					Return(nil, &system.Error{
+						Code:    1003,
+						Message: "file is not accessible",
+					}).
+					Times(1)
+
+				err := h(ctx)
 
 				Expect(err).To(Equal(&echo.HTTPError{
-					Code: 400,
+					Code: 403,
 					Message: &restapp.ResponseBodyInfo{
-						Code:    1002,
-						Message: "disk error",
+						Code:    1003,
+						Message: "file is not accessible",
 					},
 				}))
 			})
 		})
 
-		When("there is invalid data", func() {
+		When("file is not available", func() {
 			It("should return error", func() {
-				fileData.
-					EXPECT().
-					Close().
-					Return(nil).
-					Times(1)
-
 				fileClient.
 					EXPECT().
-					UploadFile(gomock.Eq(ctx.Request().Context()), gomock.Any()).
+					DeleteFile(gomock.Eq(ctx.Request().Context()), gomock.Eq(deleteParam)).
 					Return(nil, &system.Error{
-						Code:    1002,
-						Message: "invalid param",
+						Code:    1004,
+						Message: "file is not available",
 					}).
 					Times(1)
 
 				err := h(ctx)
 
 				Expect(err).To(Equal(&echo.HTTPError{
-					Code: 400,
+					Code: 404,
 					Message: &restapp.ResponseBodyInfo{
-						Code:    1002,
-						Message: "invalid param",
+						Code:    1004,
+						Message: "file is not available",
 					},
 				}))
 			})
 		})
 
-		When("failed upload file", func() {
+		When("failed delete file", func() {
 			It("should return error", func() {
-				fileData.
-					EXPECT().
-					Close().
-					Return(nil).
-					Times(1)
-
 				fileClient.
 					EXPECT().
-					UploadFile(gomock.Eq(ctx.Request().Context()), gomock.Any()).
+					DeleteFile(gomock.Eq(ctx.Request().Context()), gomock.Eq(deleteParam)).
 					Return(nil, &system.Error{
 						Code:    1001,
 						Message: "network error",
@@ -214,53 +1385,45 @@ var _ = Describe("File Handler", func() {
 			})
 		})
 
-		When("success upload file", func() {
-			It("should return result", func() {
-				fileData.
-					EXPECT().
-					Close().
-					Return(nil).
-					Times(1)
-
+		When("success delete file", func() {
+			It("should return error", func() {
 				fileClient.
 					EXPECT().
-					UploadFile(gomock.Eq(ctx.Request().Context()), gomock.Any()).
-					Return(uploadRes, nil).
+					DeleteFile(gomock.Eq(ctx.Request().Context()), gomock.Eq(deleteParam)).
+					Return(deleteRes, nil).
 					Times(1)
 
 				err := h(ctx)
 
-				res := &restapp.UploadFileResponse{}
+				res := &restapp.DeleteFileByIdResponse{}
 				encoding_json.Unmarshal(rec.Body.Bytes(), res)
 
 				Expect(err).To(BeNil())
-				Expect(res.Code).To(Equal(uploadRes.Success.Code))
-				Expect(res.Message).To(Equal(uploadRes.Success.Message))
-				Expect(res.Data).To(Equal(restapp.UploadFileData{
-					Id:         uploadRes.UniqueId,
-					Name:       uploadRes.Name,
-					Extension:  uploadRes.Extension,
-					Mimetype:   uploadRes.Mimetype,
-					Size:       uploadRes.Size,
-					UploadedAt: uploadRes.UploadedAt.Local().UnixMilli(),
+				Expect(rec.Code).To(Equal(http.StatusOK))
+				Expect(res.Code).To(Equal(int32(1000)))
+				Expect(res.Message).To(Equal("success delete file"))
+				Expect(res.Data).To(Equal(restapp.DeleteFileByIdData{
+					DeletedAt: currentTs.UnixMilli(),
 				}))
 			})
 		})
 	})
 
-	Context("RetrieveFileById function", Label("unit"), func() {
+	Context("RestoreFileById function", Label("unit"), func() {
 		var (
-			ctx        echo.Context
-			h          func(ctx echo.Context) error
-			rec        *httptest.ResponseRecorder
-			fileClient *mock_service.MockFile
-			findParam  service.RetrieveFileParam
-			findRes    *service.RetrieveFileResult
-			fileData   *mock_io.MockReadCloser
+			currentTs    time.Time
+			ctx          echo.Context
+			h            func(ctx echo.Context) error
+			rec          *httptest.ResponseRecorder
+			fileClient   *mock_service.MockFile
+			restoreParam service.RestoreFileParam
+			restoreRes   *service.RestoreFileResult
 		)
 
 		BeforeEach(func() {
-			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			currentTs = time.Now().UTC()
+
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
 			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 			rec = httptest.NewRecorder()
 
@@ -275,23 +1438,16 @@ var _ = Describe("File Handler", func() {
 			fileHandler := resthandler.NewFile(resthandler.FileParam{
 				FileClient: fileClient,
 			})
-			h = fileHandler.RetrieveFileById
-			findParam = service.RetrieveFileParam{
+			h = fileHandler.RestoreFileById
+			restoreParam = service.RestoreFileParam{
 				FileId: "id",
 			}
-			fileData = mock_io.NewMockReadCloser(ctrl)
-			findRes = &service.RetrieveFileResult{
+			restoreRes = &service.RestoreFileResult{
 				Success: system.Success{
 					Code:    1000,
-					Message: "success retrieve file",
+					Message: "success restore file",
 				},
-				Data:      fileData,
-				UniqueId:  "id",
-				Path:      "path",
-				MimeType:  "image/jpeg",
-				Name:      "dolhpin",
-				Extension: "jpg",
-				Size:      2334,
+				RestoredAt: currentTs,
 			}
 		})
 
@@ -299,7 +1455,7 @@ var _ = Describe("File Handler", func() {
 			It("should return error", func() {
 				fileClient.
 					EXPECT().
-					RetrieveFile(gomock.Eq(ctx.Request().Context()), gomock.Eq(findParam)).
+					RestoreFile(gomock.Eq(ctx.Request().Context()), gomock.Eq(restoreParam)).
 					Return(nil, &system.Error{
 						Code:    1002,
 						Message: "invalid data",
@@ -322,7 +1478,7 @@ var _ = Describe("File Handler", func() {
 			It("should return error", func() {
 				fileClient.
 					EXPECT().
-					RetrieveFile(gomock.Eq(ctx.Request().Context()), gomock.Eq(findParam)).
+					RestoreFile(gomock.Eq(ctx.Request().Context()), gomock.Eq(restoreParam)).
 					Return(nil, &system.Error{
 						Code:    1004,
 						Message: "file is not available",
@@ -341,78 +1497,164 @@ var _ = Describe("File Handler", func() {
 			})
 		})
 
-		When("failed find file", func() {
+		When("file is owned by other client", func() {
 			It("should return error", func() {
 				fileClient.
 					EXPECT().
-					RetrieveFile(gomock.Eq(ctx.Request().Context()), gomock.Eq(findParam)).
+					RestoreFile(gomock.Eq(ctx.Request().Context()), gomock.Eq(restoreParam)).
 					Return(nil, &system.Error{
-						Code:    1001,
-						Message: "network error",
+						Code:    1003,
+						Message: "file is not accessible",
 					}).
 					Times(1)
 
 				err := h(ctx)
 
 				Expect(err).To(Equal(&echo.HTTPError{
-					Code: 500,
+					Code: 403,
 					Message: &restapp.ResponseBodyInfo{
-						Code:    1001,
-						Message: "network error",
+						Code:    1003,
+						Message: "file is not accessible",
 					},
 				}))
 			})
 		})
 
-		When("success retrieve file", func() {
+		When("file is not deleted", func() {
 			It("should return error", func() {
-				fileData.
+				fileClient.
 					EXPECT().
-					Close().
-					Return(nil).
+					RestoreFile(gomock.Eq(ctx.Request().Context()), gomock.Eq(restoreParam)).
+					Return(nil, &system.Error{
+						Code:    service.FILE_NOT_DELETED,
+						Message: "file is not deleted",
+					}).
 					Times(1)
 
-				fileData.
+				err := h(ctx)
+
+				Expect(err).To(Equal(&echo.HTTPError{
+					Code: 409,
+					Message: &restapp.ResponseBodyInfo{
+						Code:    service.FILE_NOT_DELETED,
+						Message: "file is not deleted",
+					},
+				}))
+			})
+		})
+
+		When("quota is exceeded", func() {
+			It("should return error", func() {
+				fileClient.
 					EXPECT().
-					Read(gomock.Any()).
-					Return(0, io.EOF).
+					RestoreFile(gomock.Eq(ctx.Request().Context()), gomock.Eq(restoreParam)).
+					Return(nil, &system.Error{
+						Code:    service.QUOTA_EXCEEDED,
+						Message: "quota is exceeded",
+					}).
 					Times(1)
 
+				err := h(ctx)
+
+				Expect(err).To(Equal(&echo.HTTPError{
+					Code: 429,
+					Message: &restapp.ResponseBodyInfo{
+						Code:    service.QUOTA_EXCEEDED,
+						Message: "quota is exceeded",
+					},
+				}))
+			})
+		})
+
+		When("failed restore file", func() {
+			It("should return error", func() {
 				fileClient.
 					EXPECT().
-					RetrieveFile(gomock.Eq(ctx.Request().Context()), gomock.Eq(findParam)).
-					Return(findRes, nil).
+					RestoreFile(gomock.Eq(ctx.Request().Context()), gomock.Eq(restoreParam)).
+					Return(nil, &system.Error{
+						Code:    1001,
+						Message: "network error",
+					}).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(Equal(&echo.HTTPError{
+					Code: 500,
+					Message: &restapp.ResponseBodyInfo{
+						Code:    1001,
+						Message: "network error",
+					},
+				}))
+			})
+		})
+
+		When("success restore file", func() {
+			It("should return result", func() {
+				fileClient.
+					EXPECT().
+					RestoreFile(gomock.Eq(ctx.Request().Context()), gomock.Eq(restoreParam)).
+					Return(restoreRes, nil).
 					Times(1)
 
 				err := h(ctx)
 
+				res := &restapp.RestoreFileByIdResponse{}
+				encoding_json.Unmarshal(rec.Body.Bytes(), res)
+
 				Expect(err).To(BeNil())
+				Expect(rec.Code).To(Equal(http.StatusOK))
+				Expect(res.Code).To(Equal(int32(1000)))
+				Expect(res.Message).To(Equal("success restore file"))
+				Expect(res.Data).To(Equal(restapp.RestoreFileByIdData{
+					RestoredAt: currentTs.UnixMilli(),
+				}))
 			})
 		})
 	})
 
-	Context("DeleteFileById function", Label("unit"), func() {
+	Context("SearchFile function", Label("unit"), func() {
 		var (
 			currentTs   time.Time
 			ctx         echo.Context
 			h           func(ctx echo.Context) error
 			rec         *httptest.ResponseRecorder
 			fileClient  *mock_service.MockFile
-			deleteParam service.DeleteFileParam
-			deleteRes   *service.DeleteFileResult
+			searchParam service.SearchFileParam
+			searchRes   *service.SearchFileResult
 		)
 
 		BeforeEach(func() {
 			currentTs = time.Now().UTC()
-
-			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			order := restapp.SearchFileSortOrderAsc
+			reqBody := &restapp.SearchFileRequest{
+				Keyword: typeconv.String("dolpin"),
+				Filter: &restapp.SearchFileFilter{
+					MimetypeIn:    &[]string{"image/jpeg"},
+					ExtensionIn:   &[]string{"jpg"},
+					StatusIn:      &[]restapp.SearchFileFilterStatusIn{"available"},
+					SizeGte:       typeconv.Int64(100),
+					SizeLte:       typeconv.Int64(200),
+					UploadedAtGte: typeconv.Int64(1000),
+					UploadedAtLte: typeconv.Int64(2000),
+				},
+				Pagination: &restapp.RequestPagination{
+					Page:       2,
+					TotalItems: 24,
+				},
+				Sort: &restapp.SearchFileSort{
+					Field: restapp.SearchFileSortFieldSize,
+					Order: &order,
+				},
+			}
+			body, _ := encoding_json.Marshal(reqBody)
+			buffer := bytes.NewBuffer(body)
+			req := httptest.NewRequest(http.MethodPost, "/", buffer)
 			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 			rec = httptest.NewRecorder()
 
 			e := echo.New()
 			ctx = e.NewContext(req, rec)
-			ctx.SetParamNames("id")
-			ctx.SetParamValues("id")
 
 			t := GinkgoT()
 			ctrl := gomock.NewController(t)
@@ -420,29 +1662,76 @@ var _ = Describe("File Handler", func() {
 			fileHandler := resthandler.NewFile(resthandler.FileParam{
 				FileClient: fileClient,
 			})
-			h = fileHandler.DeleteFileById
-			deleteParam = service.DeleteFileParam{
-				FileId: "id",
+			h = fileHandler.SearchFile
+			searchParam = service.SearchFileParam{
+				Keyword:      "dolpin",
+				TotalItems:   24,
+				Page:         2,
+				Mimetypes:    []string{"image/jpeg"},
+				Extensions:   []string{"jpg"},
+				MinSize:      typeconv.Int64(100),
+				MaxSize:      typeconv.Int64(200),
+				UploadedFrom: typeconv.Time(time.UnixMilli(1000).UTC()),
+				UploadedTo:   typeconv.Time(time.UnixMilli(2000).UTC()),
+				Statuses:     []string{"available"},
+				SortBy:       "size",
+				SortOrder:    "asc",
 			}
-			deleteRes = &service.DeleteFileResult{
+			searchRes = &service.SearchFileResult{
 				Success: system.Success{
 					Code:    1000,
-					Message: "success delete file",
+					Message: "success search file",
+				},
+				Items: []service.SearchFileItem{
+					{
+						UniqueId:  "id-1",
+						Name:      "dolpin-1",
+						Path:      "/storage/id-1.jpg",
+						MimeType:  "image/jpeg",
+						Extension: "jpg",
+						Size:      100,
+						Checksum: file.Checksum{
+							Sha256: "sha256-1",
+							Md5:    "md5-1",
+						},
+						UploadedAt: currentTs,
+					},
+					{
+						UniqueId:  "id-2",
+						Name:      "dolpin-2",
+						Path:      "/storage/id-2.jpg",
+						MimeType:  "image/jpeg",
+						Extension: "jpg",
+						Size:      200,
+						Checksum: file.Checksum{
+							Sha256: "sha256-2",
+						},
+						UploadedAt: currentTs,
+						DeletedAt:  &currentTs,
+					},
+				},
+				Summary: service.SearchFileSummary{
+					TotalItems: 2,
+					Page:       2,
 				},
-				DeletedAt: currentTs,
 			}
 		})
 
-		When("there is invalid data", func() {
+		When("failed binding request body", func() {
 			It("should return error", func() {
-				fileClient.
-					EXPECT().
-					DeleteFile(gomock.Eq(ctx.Request().Context()), gomock.Eq(deleteParam)).
-					Return(nil, &system.Error{
-						Code:    1002,
-						Message: "invalid data",
-					}).
-					Times(1)
+				reqBody, _ := encoding_json.Marshal(struct {
+					Filter int `json:"filter"`
+				}{
+					Filter: 1,
+				})
+				buffer := bytes.NewBuffer(reqBody)
+
+				req := httptest.NewRequest(http.MethodPost, "/", buffer)
+				req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+				rec := httptest.NewRecorder()
+
+				e := echo.New()
+				ctx := e.NewContext(req, rec)
 
 				err := h(ctx)
 
@@ -450,40 +1739,40 @@ var _ = Describe("File Handler", func() {
 					Code: 400,
 					Message: &restapp.ResponseBodyInfo{
 						Code:    1002,
-						Message: "invalid data",
+						Message: "invalid request",
 					},
 				}))
 			})
 		})
 
-		When("file is not available", func() {
+		When("there is invalid data", func() {
 			It("should return error", func() {
 				fileClient.
 					EXPECT().
-					DeleteFile(gomock.Eq(ctx.Request().Context()), gomock.Eq(deleteParam)).
+					SearchFile(gomock.Eq(ctx.Request().Context()), gomock.Eq(searchParam)).
 					Return(nil, &system.Error{
-						Code:    1004,
-						Message: "file is not available",
+						Code:    1002,
+						Message: "invalid data",
 					}).
 					Times(1)
 
 				err := h(ctx)
 
 				Expect(err).To(Equal(&echo.HTTPError{
-					Code: 404,
+					Code: 400,
 					Message: &restapp.ResponseBodyInfo{
-						Code:    1004,
-						Message: "file is not available",
+						Code:    1002,
+						Message: "invalid data",
 					},
 				}))
 			})
 		})
 
-		When("failed delete file", func() {
+		When("failed search file", func() {
 			It("should return error", func() {
 				fileClient.
 					EXPECT().
-					DeleteFile(gomock.Eq(ctx.Request().Context()), gomock.Eq(deleteParam)).
+					SearchFile(gomock.Eq(ctx.Request().Context()), gomock.Eq(searchParam)).
 					Return(nil, &system.Error{
 						Code:    1001,
 						Message: "network error",
@@ -502,25 +1791,84 @@ var _ = Describe("File Handler", func() {
 			})
 		})
 
-		When("success delete file", func() {
-			It("should return error", func() {
+		When("there is no file", func() {
+			It("should return empty result", func() {
+				searchRes := &service.SearchFileResult{
+					Success: system.Success{
+						Code:    1000,
+						Message: "success search file",
+					},
+					Items: []service.SearchFileItem{},
+					Summary: service.SearchFileSummary{
+						TotalItems: 0,
+						Page:       2,
+					},
+				}
 				fileClient.
 					EXPECT().
-					DeleteFile(gomock.Eq(ctx.Request().Context()), gomock.Eq(deleteParam)).
-					Return(deleteRes, nil).
+					SearchFile(gomock.Eq(ctx.Request().Context()), gomock.Eq(searchParam)).
+					Return(searchRes, nil).
 					Times(1)
 
 				err := h(ctx)
 
-				res := &restapp.DeleteFileByIdResponse{}
+				res := &restapp.SearchFileResponse{}
 				encoding_json.Unmarshal(rec.Body.Bytes(), res)
 
 				Expect(err).To(BeNil())
 				Expect(rec.Code).To(Equal(http.StatusOK))
 				Expect(res.Code).To(Equal(int32(1000)))
-				Expect(res.Message).To(Equal("success delete file"))
-				Expect(res.Data).To(Equal(restapp.DeleteFileByIdData{
-					DeletedAt: currentTs.UnixMilli(),
+				Expect(res.Message).To(Equal("success search file"))
+				Expect(res.Data.Summary).To(Equal(restapp.SearchFileSummary{
+					Page:       2,
+					TotalItems: 0,
+				}))
+				Expect(res.Data.Items).To(Equal([]restapp.SearchFileItem{}))
+			})
+		})
+
+		When("there are some files", func() {
+			It("should return result", func() {
+				fileClient.
+					EXPECT().
+					SearchFile(gomock.Eq(ctx.Request().Context()), gomock.Eq(searchParam)).
+					Return(searchRes, nil).
+					Times(1)
+
+				err := h(ctx)
+
+				res := &restapp.SearchFileResponse{}
+				encoding_json.Unmarshal(rec.Body.Bytes(), res)
+
+				Expect(err).To(BeNil())
+				Expect(rec.Code).To(Equal(http.StatusOK))
+				Expect(res.Code).To(Equal(int32(1000)))
+				Expect(res.Message).To(Equal("success search file"))
+				Expect(res.Data.Summary).To(Equal(restapp.SearchFileSummary{
+					Page:       2,
+					TotalItems: 2,
+				}))
+				Expect(res.Data.Items).To(Equal([]restapp.SearchFileItem{
+					{
+						Id:             "id-1",
+						Name:           "dolpin-1",
+						Mimetype:       "image/jpeg",
+						Extension:      "jpg",
+						Size:           100,
+						UploadedAt:     currentTs.UnixMilli(),
+						ChecksumSha256: "sha256-1",
+						ChecksumMd5:    typeconv.String("md5-1"),
+					},
+					{
+						Id:             "id-2",
+						Name:           "dolpin-2",
+						Mimetype:       "image/jpeg",
+						Extension:      "jpg",
+						Size:           200,
+						UploadedAt:     currentTs.UnixMilli(),
+						DeletedAt:      typeconv.Int64(currentTs.UnixMilli()),
+						ChecksumSha256: "sha256-2",
+					},
 				}))
 			})
 		})
@@ -0,0 +1,77 @@
+package resthandler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/go-seidon/hippo/api/restapp"
+	"github.com/go-seidon/hippo/internal/auth"
+	"github.com/go-seidon/provider/status"
+	"github.com/go-seidon/provider/typeconv"
+	"github.com/labstack/echo/v4"
+)
+
+type oauthHandler struct {
+	tokenClient auth.TokenAuth
+}
+
+// @note: error is returned using the oauth2 error response (RFC 6749 section 5.2)
+func (h *oauthHandler) IssueToken(ctx echo.Context) error {
+	grantType := ctx.FormValue("grant_type")
+	if grantType == "" {
+		return ctx.JSON(http.StatusBadRequest, &restapp.OAuthError{
+			Error:            restapp.InvalidRequest,
+			ErrorDescription: typeconv.String("grant type is not specified"),
+		})
+	}
+	if grantType != string(restapp.ClientCredentials) {
+		return ctx.JSON(http.StatusBadRequest, &restapp.OAuthError{
+			Error:            restapp.UnsupportedGrantType,
+			ErrorDescription: typeconv.String("grant type is not supported"),
+		})
+	}
+
+	// @note: basic auth is preferred over the request body
+	clientId, clientSecret, ok := ctx.Request().BasicAuth()
+	if !ok {
+		clientId = ctx.FormValue("client_id")
+		clientSecret = ctx.FormValue("client_secret")
+	}
+
+	issueRes, err := h.tokenClient.IssueToken(ctx.Request().Context(), auth.IssueTokenParam{
+		ClientId:     clientId,
+		ClientSecret: clientSecret,
+	})
+	if err != nil {
+		if errors.Is(err, auth.ErrInvalidCredential) {
+			return ctx.JSON(http.StatusUnauthorized, &restapp.OAuthError{
+				Error:            restapp.InvalidClient,
+				ErrorDescription: typeconv.String("client credential is invalid"),
+			})
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, &restapp.ResponseBodyInfo{
+			Code:    status.ACTION_FAILED,
+			Message: err.Error(),
+		})
+	}
+
+	ctx.Response().Header().Set("Cache-Control", "no-store")
+	ctx.Response().Header().Set("Pragma", "no-cache")
+	return ctx.JSON(http.StatusOK, &restapp.IssueTokenResponse{
+		AccessToken: issueRes.AccessToken,
+		TokenType:   issueRes.TokenType,
+		ExpiresIn:   issueRes.ExpiresIn,
+		Scope:       typeconv.String(strings.Join(issueRes.Scopes, " ")),
+	})
+}
+
+type OAuthParam struct {
+	TokenClient auth.TokenAuth
+}
+
+func NewOAuth(p OAuthParam) *oauthHandler {
+	return &oauthHandler{
+		tokenClient: p.TokenClient,
+	}
+}
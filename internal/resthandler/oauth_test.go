@@ -0,0 +1,206 @@
+package resthandler_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-seidon/hippo/api/restapp"
+	"github.com/go-seidon/hippo/internal/auth"
+	mock_auth "github.com/go-seidon/hippo/internal/auth/mock"
+	"github.com/go-seidon/hippo/internal/resthandler"
+	"github.com/go-seidon/provider/typeconv"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("OAuth Handler", func() {
+	Context("IssueToken function", Label("unit"), func() {
+		var (
+			currentTs   time.Time
+			h           func(ctx echo.Context) error
+			tokenClient *mock_auth.MockTokenAuth
+			issueParam  auth.IssueTokenParam
+			issueRes    *auth.IssueTokenResult
+			newContext  func(form url.Values) (echo.Context, *httptest.ResponseRecorder)
+		)
+
+		BeforeEach(func() {
+			currentTs = time.Now()
+			t := GinkgoT()
+			ctrl := gomock.NewController(t)
+			tokenClient = mock_auth.NewMockTokenAuth(ctrl)
+			oauthHandler := resthandler.NewOAuth(resthandler.OAuthParam{
+				TokenClient: tokenClient,
+			})
+			h = oauthHandler.IssueToken
+			issueParam = auth.IssueTokenParam{
+				ClientId:     "client-id",
+				ClientSecret: "client-secret",
+			}
+			issueRes = &auth.IssueTokenResult{
+				AccessToken: "access-token",
+				TokenType:   "Bearer",
+				ExpiresIn:   3600,
+				ExpiresAt:   currentTs.Add(time.Hour),
+				Scopes:      []string{"file:read", "file:write"},
+			}
+			newContext = func(form url.Values) (echo.Context, *httptest.ResponseRecorder) {
+				req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(form.Encode()))
+				req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+				rec := httptest.NewRecorder()
+
+				e := echo.New()
+				return e.NewContext(req, rec), rec
+			}
+		})
+
+		When("grant type is not specified", func() {
+			It("should return error", func() {
+				ctx, rec := newContext(url.Values{})
+
+				err := h(ctx)
+
+				res := &restapp.OAuthError{}
+				json.Unmarshal(rec.Body.Bytes(), res)
+
+				Expect(err).To(BeNil())
+				Expect(rec.Code).To(Equal(http.StatusBadRequest))
+				Expect(res).To(Equal(&restapp.OAuthError{
+					Error:            restapp.InvalidRequest,
+					ErrorDescription: typeconv.String("grant type is not specified"),
+				}))
+			})
+		})
+
+		When("grant type is not supported", func() {
+			It("should return error", func() {
+				ctx, rec := newContext(url.Values{
+					"grant_type": []string{"password"},
+				})
+
+				err := h(ctx)
+
+				res := &restapp.OAuthError{}
+				json.Unmarshal(rec.Body.Bytes(), res)
+
+				Expect(err).To(BeNil())
+				Expect(rec.Code).To(Equal(http.StatusBadRequest))
+				Expect(res.Error).To(Equal(restapp.UnsupportedGrantType))
+			})
+		})
+
+		When("client credential is invalid", func() {
+			It("should return error", func() {
+				ctx, rec := newContext(url.Values{
+					"grant_type":    []string{"client_credentials"},
+					"client_id":     []string{"client-id"},
+					"client_secret": []string{"client-secret"},
+				})
+
+				tokenClient.
+					EXPECT().
+					IssueToken(gomock.Eq(ctx.Request().Context()), gomock.Eq(issueParam)).
+					Return(nil, auth.ErrInvalidCredential).
+					Times(1)
+
+				err := h(ctx)
+
+				res := &restapp.OAuthError{}
+				json.Unmarshal(rec.Body.Bytes(), res)
+
+				Expect(err).To(BeNil())
+				Expect(rec.Code).To(Equal(http.StatusUnauthorized))
+				Expect(res).To(Equal(&restapp.OAuthError{
+					Error:            restapp.InvalidClient,
+					ErrorDescription: typeconv.String("client credential is invalid"),
+				}))
+			})
+		})
+
+		When("failed issue token", func() {
+			It("should return error", func() {
+				ctx, _ := newContext(url.Values{
+					"grant_type":    []string{"client_credentials"},
+					"client_id":     []string{"client-id"},
+					"client_secret": []string{"client-secret"},
+				})
+
+				tokenClient.
+					EXPECT().
+					IssueToken(gomock.Eq(ctx.Request().Context()), gomock.Eq(issueParam)).
+					Return(nil, fmt.Errorf("network error")).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(Equal(&echo.HTTPError{
+					Code: 500,
+					Message: &restapp.ResponseBodyInfo{
+						Code:    1001,
+						Message: "network error",
+					},
+				}))
+			})
+		})
+
+		When("credential is specified using request body", func() {
+			It("should return result", func() {
+				ctx, rec := newContext(url.Values{
+					"grant_type":    []string{"client_credentials"},
+					"client_id":     []string{"client-id"},
+					"client_secret": []string{"client-secret"},
+				})
+
+				tokenClient.
+					EXPECT().
+					IssueToken(gomock.Eq(ctx.Request().Context()), gomock.Eq(issueParam)).
+					Return(issueRes, nil).
+					Times(1)
+
+				err := h(ctx)
+
+				res := &restapp.IssueTokenResponse{}
+				json.Unmarshal(rec.Body.Bytes(), res)
+
+				Expect(err).To(BeNil())
+				Expect(rec.Code).To(Equal(http.StatusOK))
+				Expect(rec.Header().Get("Cache-Control")).To(Equal("no-store"))
+				Expect(res).To(Equal(&restapp.IssueTokenResponse{
+					AccessToken: "access-token",
+					TokenType:   "Bearer",
+					ExpiresIn:   3600,
+					Scope:       typeconv.String("file:read file:write"),
+				}))
+			})
+		})
+
+		When("credential is specified using basic auth", func() {
+			It("should return result", func() {
+				ctx, rec := newContext(url.Values{
+					"grant_type":    []string{"client_credentials"},
+					"client_id":     []string{"other-client-id"},
+					"client_secret": []string{"other-client-secret"},
+				})
+				ctx.Request().SetBasicAuth("client-id", "client-secret")
+
+				tokenClient.
+					EXPECT().
+					IssueToken(gomock.Eq(ctx.Request().Context()), gomock.Eq(issueParam)).
+					Return(issueRes, nil).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(BeNil())
+				Expect(rec.Code).To(Equal(http.StatusOK))
+			})
+		})
+	})
+})
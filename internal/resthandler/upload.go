@@ -0,0 +1,273 @@
+package resthandler
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-seidon/hippo/api/restapp"
+	"github.com/go-seidon/hippo/internal/service"
+	"github.com/go-seidon/provider/status"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	TUS_VERSION   = "1.0.0"
+	TUS_EXTENSION = "creation,termination"
+
+	MIME_OFFSET_OCTET_STREAM = "application/offset+octet-stream"
+)
+
+type uploadHandler struct {
+	uploadClient service.Upload
+	config       *UploadConfig
+}
+
+func (h *uploadHandler) GetUploadOptions(ctx echo.Context) error {
+	header := ctx.Response().Header()
+	header.Set("Tus-Resumable", TUS_VERSION)
+	header.Set("Tus-Version", TUS_VERSION)
+	header.Set("Tus-Extension", TUS_EXTENSION)
+	if h.config.MaxSize > 0 {
+		header.Set("Tus-Max-Size", strconv.FormatInt(h.config.MaxSize, 10))
+	}
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func (h *uploadHandler) CreateUpload(ctx echo.Context) error {
+	err := h.checkVersion(ctx)
+	if err != nil {
+		return err
+	}
+
+	size, perr := strconv.ParseInt(ctx.Request().Header.Get("Upload-Length"), 10, 64)
+	if perr != nil || size < 0 {
+		return echo.NewHTTPError(http.StatusBadRequest, &restapp.ResponseBodyInfo{
+			Code:    status.INVALID_PARAM,
+			Message: "invalid Upload-Length header",
+		})
+	}
+
+	metadata, perr := parseUploadMetadata(ctx.Request().Header.Get("Upload-Metadata"))
+	if perr != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, &restapp.ResponseBodyInfo{
+			Code:    status.INVALID_PARAM,
+			Message: "invalid Upload-Metadata header",
+		})
+	}
+
+	createUpload, cerr := h.uploadClient.CreateUpload(ctx.Request().Context(), service.CreateUploadParam{
+		FileName: metadata["filename"],
+		Size:     size,
+	})
+	if cerr != nil {
+		httpCode := http.StatusInternalServerError
+		switch cerr.Code {
+		case status.INVALID_PARAM:
+			httpCode = http.StatusBadRequest
+		case service.UPLOAD_SIZE_EXCEEDED:
+			httpCode = http.StatusRequestEntityTooLarge
+		}
+		return echo.NewHTTPError(httpCode, &restapp.ResponseBodyInfo{
+			Code:    cerr.Code,
+			Message: cerr.Message,
+		})
+	}
+
+	header := ctx.Response().Header()
+	header.Set("Location", fmt.Sprintf("/v1/upload/%s", createUpload.UniqueId))
+	header.Set("Upload-Offset", strconv.FormatInt(createUpload.Offset, 10))
+	return ctx.NoContent(http.StatusCreated)
+}
+
+func (h *uploadHandler) RetrieveUploadById(ctx echo.Context) error {
+	err := h.checkVersion(ctx)
+	if err != nil {
+		return err
+	}
+
+	findUpload, rerr := h.uploadClient.RetrieveUpload(ctx.Request().Context(), service.RetrieveUploadParam{
+		UploadId: ctx.Param("id"),
+	})
+	if rerr != nil {
+		httpCode := http.StatusInternalServerError
+		switch rerr.Code {
+		case status.INVALID_PARAM:
+			httpCode = http.StatusBadRequest
+		case status.ACTION_FORBIDDEN:
+			httpCode = http.StatusForbidden
+		case status.RESOURCE_NOTFOUND:
+			httpCode = http.StatusNotFound
+		}
+		return echo.NewHTTPError(httpCode, &restapp.ResponseBodyInfo{
+			Code:    rerr.Code,
+			Message: rerr.Message,
+		})
+	}
+
+	header := ctx.Response().Header()
+	header.Set("Cache-Control", "no-store")
+	header.Set("Upload-Offset", strconv.FormatInt(findUpload.Offset, 10))
+	header.Set("Upload-Length", strconv.FormatInt(findUpload.Size, 10))
+	if findUpload.FileId != "" {
+		header.Set("X-File-Id", findUpload.FileId)
+	}
+	return ctx.NoContent(http.StatusOK)
+}
+
+func (h *uploadHandler) AppendUploadById(ctx echo.Context) error {
+	err := h.checkVersion(ctx)
+	if err != nil {
+		return err
+	}
+
+	req := ctx.Request()
+	if req.Header.Get(echo.HeaderContentType) != MIME_OFFSET_OCTET_STREAM {
+		return echo.NewHTTPError(http.StatusUnsupportedMediaType, &restapp.ResponseBodyInfo{
+			Code:    status.INVALID_PARAM,
+			Message: "invalid Content-Type header",
+		})
+	}
+
+	offset, perr := strconv.ParseInt(req.Header.Get("Upload-Offset"), 10, 64)
+	if perr != nil || offset < 0 {
+		return echo.NewHTTPError(http.StatusBadRequest, &restapp.ResponseBodyInfo{
+			Code:    status.INVALID_PARAM,
+			Message: "invalid Upload-Offset header",
+		})
+	}
+
+	appendUpload, aerr := h.uploadClient.AppendUpload(req.Context(), service.AppendUploadParam{
+		UploadId: ctx.Param("id"),
+		Offset:   offset,
+		Reader:   req.Body,
+	})
+	if aerr != nil {
+		httpCode := http.StatusInternalServerError
+		switch aerr.Code {
+		case status.INVALID_PARAM:
+			httpCode = http.StatusBadRequest
+		case status.ACTION_FORBIDDEN:
+			httpCode = http.StatusForbidden
+		case status.RESOURCE_NOTFOUND:
+			httpCode = http.StatusNotFound
+		case service.UPLOAD_OFFSET_CONFLICT:
+			httpCode = http.StatusConflict
+		case service.UPLOAD_SIZE_EXCEEDED:
+			httpCode = http.StatusRequestEntityTooLarge
+		case service.FILE_NOT_ALLOWED:
+			httpCode = http.StatusUnsupportedMediaType
+		case service.FILE_INFECTED:
+			httpCode = http.StatusUnprocessableEntity
+		case service.FILE_NOT_SCANNED:
+			httpCode = http.StatusServiceUnavailable
+		case service.FILE_NOT_SCANNABLE:
+			httpCode = http.StatusRequestEntityTooLarge
+		case service.QUOTA_EXCEEDED:
+			httpCode = http.StatusTooManyRequests
+		case service.QUOTA_SIZE_EXCEEDED:
+			httpCode = http.StatusRequestEntityTooLarge
+		}
+		return echo.NewHTTPError(httpCode, &restapp.ResponseBodyInfo{
+			Code:    aerr.Code,
+			Message: aerr.Message,
+		})
+	}
+
+	header := ctx.Response().Header()
+	header.Set("Upload-Offset", strconv.FormatInt(appendUpload.Offset, 10))
+	if appendUpload.FileId != "" {
+		header.Set("X-File-Id", appendUpload.FileId)
+	}
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func (h *uploadHandler) DeleteUploadById(ctx echo.Context) error {
+	err := h.checkVersion(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, derr := h.uploadClient.DeleteUpload(ctx.Request().Context(), service.DeleteUploadParam{
+		UploadId: ctx.Param("id"),
+	})
+	if derr != nil {
+		httpCode := http.StatusInternalServerError
+		switch derr.Code {
+		case status.INVALID_PARAM:
+			httpCode = http.StatusBadRequest
+		case status.ACTION_FORBIDDEN:
+			httpCode = http.StatusForbidden
+		case status.RESOURCE_NOTFOUND:
+			httpCode = http.StatusNotFound
+		}
+		return echo.NewHTTPError(httpCode, &restapp.ResponseBodyInfo{
+			Code:    derr.Code,
+			Message: derr.Message,
+		})
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+// @note: every tus response including the error one contains the protocol version
+func (h *uploadHandler) checkVersion(ctx echo.Context) error {
+	header := ctx.Response().Header()
+	header.Set("Tus-Resumable", TUS_VERSION)
+
+	if ctx.Request().Header.Get("Tus-Resumable") != TUS_VERSION {
+		header.Set("Tus-Version", TUS_VERSION)
+		return echo.NewHTTPError(http.StatusPreconditionFailed, &restapp.ResponseBodyInfo{
+			Code:    status.INVALID_PARAM,
+			Message: "tus version is not supported",
+		})
+	}
+	return nil
+}
+
+// @note: metadata format is `key base64value,key2 base64value2`, value is optional
+func parseUploadMetadata(metadata string) (map[string]string, error) {
+	res := map[string]string{}
+	if strings.TrimSpace(metadata) == "" {
+		return res, nil
+	}
+
+	for _, pair := range strings.Split(metadata, ",") {
+		fields := strings.Fields(pair)
+		if len(fields) == 0 || len(fields) > 2 {
+			return nil, fmt.Errorf("invalid metadata")
+		}
+		if _, ok := res[fields[0]]; ok {
+			return nil, fmt.Errorf("duplicate metadata")
+		}
+
+		value := ""
+		if len(fields) == 2 {
+			decoded, err := base64.StdEncoding.DecodeString(fields[1])
+			if err != nil {
+				return nil, err
+			}
+			value = string(decoded)
+		}
+		res[fields[0]] = value
+	}
+	return res, nil
+}
+
+type UploadConfig struct {
+	MaxSize int64
+}
+
+type UploadParam struct {
+	UploadClient service.Upload
+	Config       *UploadConfig
+}
+
+func NewUpload(p UploadParam) *uploadHandler {
+	return &uploadHandler{
+		uploadClient: p.UploadClient,
+		config:       p.Config,
+	}
+}
@@ -0,0 +1,1028 @@
+package resthandler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/go-seidon/hippo/api/restapp"
+	"github.com/go-seidon/hippo/internal/resthandler"
+	"github.com/go-seidon/hippo/internal/service"
+	mock_service "github.com/go-seidon/hippo/internal/service/mock"
+	"github.com/go-seidon/provider/system"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Upload Handler", func() {
+	Context("GetUploadOptions function", Label("unit"), func() {
+		var (
+			ctx echo.Context
+			rec *httptest.ResponseRecorder
+		)
+
+		BeforeEach(func() {
+			req := httptest.NewRequest(http.MethodOptions, "/", nil)
+			rec = httptest.NewRecorder()
+
+			e := echo.New()
+			ctx = e.NewContext(req, rec)
+		})
+
+		When("max size is specified", func() {
+			It("should return result", func() {
+				uploadHandler := resthandler.NewUpload(resthandler.UploadParam{
+					Config: &resthandler.UploadConfig{
+						MaxSize: 1024,
+					},
+				})
+
+				err := uploadHandler.GetUploadOptions(ctx)
+
+				Expect(err).To(BeNil())
+				Expect(rec.Code).To(Equal(http.StatusNoContent))
+				Expect(rec.Header().Get("Tus-Resumable")).To(Equal("1.0.0"))
+				Expect(rec.Header().Get("Tus-Version")).To(Equal("1.0.0"))
+				Expect(rec.Header().Get("Tus-Extension")).To(Equal("creation,termination"))
+				Expect(rec.Header().Get("Tus-Max-Size")).To(Equal("1024"))
+			})
+		})
+
+		When("max size is not specified", func() {
+			It("should return result", func() {
+				uploadHandler := resthandler.NewUpload(resthandler.UploadParam{
+					Config: &resthandler.UploadConfig{},
+				})
+
+				err := uploadHandler.GetUploadOptions(ctx)
+
+				Expect(err).To(BeNil())
+				Expect(rec.Code).To(Equal(http.StatusNoContent))
+				Expect(rec.Header().Values("Tus-Max-Size")).To(BeEmpty())
+			})
+		})
+	})
+
+	Context("CreateUpload function", Label("unit"), func() {
+		var (
+			currentTs    time.Time
+			req          *http.Request
+			rec          *httptest.ResponseRecorder
+			h            func(ctx echo.Context) error
+			uploadClient *mock_service.MockUpload
+			createParam  service.CreateUploadParam
+			createRes    *service.CreateUploadResult
+		)
+
+		BeforeEach(func() {
+			currentTs = time.Now().UTC()
+
+			req = httptest.NewRequest(http.MethodPost, "/", nil)
+			req.Header.Set("Tus-Resumable", "1.0.0")
+			req.Header.Set("Upload-Length", "2048")
+			req.Header.Set("Upload-Metadata", "filename ZG9scGhpbi5qcGc=,is_private")
+			rec = httptest.NewRecorder()
+
+			t := GinkgoT()
+			ctrl := gomock.NewController(t)
+			uploadClient = mock_service.NewMockUpload(ctrl)
+			uploadHandler := resthandler.NewUpload(resthandler.UploadParam{
+				UploadClient: uploadClient,
+				Config:       &resthandler.UploadConfig{},
+			})
+			h = uploadHandler.CreateUpload
+			createParam = service.CreateUploadParam{
+				FileName: "dolphin.jpg",
+				Size:     2048,
+			}
+			createRes = &service.CreateUploadResult{
+				Success: system.Success{
+					Code:    1000,
+					Message: "success create upload",
+				},
+				UniqueId:  "id",
+				Size:      2048,
+				Offset:    0,
+				CreatedAt: currentTs,
+			}
+		})
+
+		When("tus version is not supported", func() {
+			It("should return error", func() {
+				req.Header.Set("Tus-Resumable", "0.2.2")
+				ctx := echo.New().NewContext(req, rec)
+
+				err := h(ctx)
+
+				Expect(err).To(Equal(&echo.HTTPError{
+					Code: 412,
+					Message: &restapp.ResponseBodyInfo{
+						Code:    1002,
+						Message: "tus version is not supported",
+					},
+				}))
+				Expect(rec.Header().Get("Tus-Version")).To(Equal("1.0.0"))
+				Expect(rec.Header().Get("Tus-Resumable")).To(Equal("1.0.0"))
+			})
+		})
+
+		When("upload length is not specified", func() {
+			It("should return error", func() {
+				req.Header.Del("Upload-Length")
+				ctx := echo.New().NewContext(req, rec)
+
+				err := h(ctx)
+
+				Expect(err).To(Equal(&echo.HTTPError{
+					Code: 400,
+					Message: &restapp.ResponseBodyInfo{
+						Code:    1002,
+						Message: "invalid Upload-Length header",
+					},
+				}))
+			})
+		})
+
+		When("upload length is negative", func() {
+			It("should return error", func() {
+				req.Header.Set("Upload-Length", "-1")
+				ctx := echo.New().NewContext(req, rec)
+
+				err := h(ctx)
+
+				Expect(err).To(Equal(&echo.HTTPError{
+					Code: 400,
+					Message: &restapp.ResponseBodyInfo{
+						Code:    1002,
+						Message: "invalid Upload-Length header",
+					},
+				}))
+			})
+		})
+
+		When("upload metadata is invalid", func() {
+			It("should return error", func() {
+				req.Header.Set("Upload-Metadata", "filename invalid-base64")
+				ctx := echo.New().NewContext(req, rec)
+
+				err := h(ctx)
+
+				Expect(err).To(Equal(&echo.HTTPError{
+					Code: 400,
+					Message: &restapp.ResponseBodyInfo{
+						Code:    1002,
+						Message: "invalid Upload-Metadata header",
+					},
+				}))
+			})
+		})
+
+		When("upload metadata key is duplicated", func() {
+			It("should return error", func() {
+				req.Header.Set("Upload-Metadata", "filename ZG9scGhpbi5qcGc=,filename ZG9scGhpbi5wbmc=")
+				ctx := echo.New().NewContext(req, rec)
+
+				err := h(ctx)
+
+				Expect(err).To(Equal(&echo.HTTPError{
+					Code: 400,
+					Message: &restapp.ResponseBodyInfo{
+						Code:    1002,
+						Message: "invalid Upload-Metadata header",
+					},
+				}))
+			})
+		})
+
+		When("upload size is exceeded", func() {
+			It("should return error", func() {
+				ctx := echo.New().NewContext(req, rec)
+
+				uploadClient.
+					EXPECT().
+					CreateUpload(gomock.Eq(ctx.Request().Context()), gomock.Eq(createParam)).
+					Return(nil, &system.Error{
+						Code:    2002,
+						Message: "upload size is exceeded",
+					}).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(Equal(&echo.HTTPError{
+					Code: 413,
+					Message: &restapp.ResponseBodyInfo{
+						Code:    2002,
+						Message: "upload size is exceeded",
+					},
+				}))
+			})
+		})
+
+		When("failed create upload", func() {
+			It("should return error", func() {
+				ctx := echo.New().NewContext(req, rec)
+
+				uploadClient.
+					EXPECT().
+					CreateUpload(gomock.Eq(ctx.Request().Context()), gomock.Eq(createParam)).
+					Return(nil, &system.Error{
+						Code:    1001,
+						Message: "disk error",
+					}).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(Equal(&echo.HTTPError{
+					Code: 500,
+					Message: &restapp.ResponseBodyInfo{
+						Code:    1001,
+						Message: "disk error",
+					},
+				}))
+			})
+		})
+
+		When("metadata is not specified", func() {
+			It("should return result", func() {
+				req.Header.Del("Upload-Metadata")
+				ctx := echo.New().NewContext(req, rec)
+
+				uploadClient.
+					EXPECT().
+					CreateUpload(gomock.Eq(ctx.Request().Context()), gomock.Eq(service.CreateUploadParam{
+						Size: 2048,
+					})).
+					Return(createRes, nil).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(BeNil())
+				Expect(rec.Code).To(Equal(http.StatusCreated))
+			})
+		})
+
+		When("success create upload", func() {
+			It("should return result", func() {
+				ctx := echo.New().NewContext(req, rec)
+
+				uploadClient.
+					EXPECT().
+					CreateUpload(gomock.Eq(ctx.Request().Context()), gomock.Eq(createParam)).
+					Return(createRes, nil).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(BeNil())
+				Expect(rec.Code).To(Equal(http.StatusCreated))
+				Expect(rec.Header().Get("Location")).To(Equal("/v1/upload/id"))
+				Expect(rec.Header().Get("Upload-Offset")).To(Equal("0"))
+				Expect(rec.Header().Get("Tus-Resumable")).To(Equal("1.0.0"))
+			})
+		})
+	})
+
+	Context("RetrieveUploadById function", Label("unit"), func() {
+		var (
+			currentTs     time.Time
+			req           *http.Request
+			rec           *httptest.ResponseRecorder
+			h             func(ctx echo.Context) error
+			uploadClient  *mock_service.MockUpload
+			retrieveParam service.RetrieveUploadParam
+			retrieveRes   *service.RetrieveUploadResult
+		)
+
+		BeforeEach(func() {
+			currentTs = time.Now().UTC()
+
+			req = httptest.NewRequest(http.MethodHead, "/", nil)
+			req.Header.Set("Tus-Resumable", "1.0.0")
+			rec = httptest.NewRecorder()
+
+			t := GinkgoT()
+			ctrl := gomock.NewController(t)
+			uploadClient = mock_service.NewMockUpload(ctrl)
+			uploadHandler := resthandler.NewUpload(resthandler.UploadParam{
+				UploadClient: uploadClient,
+				Config:       &resthandler.UploadConfig{},
+			})
+			h = uploadHandler.RetrieveUploadById
+			retrieveParam = service.RetrieveUploadParam{
+				UploadId: "id",
+			}
+			retrieveRes = &service.RetrieveUploadResult{
+				Success: system.Success{
+					Code:    1000,
+					Message: "success retrieve upload",
+				},
+				UniqueId:  "id",
+				Name:      "dolphin",
+				Extension: "jpg",
+				Size:      2048,
+				Offset:    1024,
+				CreatedAt: currentTs,
+			}
+		})
+
+		newContext := func() echo.Context {
+			ctx := echo.New().NewContext(req, rec)
+			ctx.SetParamNames("id")
+			ctx.SetParamValues("id")
+			return ctx
+		}
+
+		When("tus version is not supported", func() {
+			It("should return error", func() {
+				req.Header.Del("Tus-Resumable")
+				ctx := newContext()
+
+				err := h(ctx)
+
+				Expect(err).To(Equal(&echo.HTTPError{
+					Code: 412,
+					Message: &restapp.ResponseBodyInfo{
+						Code:    1002,
+						Message: "tus version is not supported",
+					},
+				}))
+			})
+		})
+
+		When("upload is not available", func() {
+			It("should return error", func() {
+				ctx := newContext()
+
+				uploadClient.
+					EXPECT().
+					RetrieveUpload(gomock.Eq(ctx.Request().Context()), gomock.Eq(retrieveParam)).
+					Return(nil, &system.Error{
+						Code:    1004,
+						Message: "upload is not found",
+					}).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(Equal(&echo.HTTPError{
+					Code: 404,
+					Message: &restapp.ResponseBodyInfo{
+						Code:    1004,
+						Message: "upload is not found",
+					},
+				}))
+			})
+		})
+
+		When("upload is created by other client", func() {
+			It("should return error", func() {
+				ctx := newContext()
+
+				uploadClient.
+					EXPECT().
+					RetrieveUpload(gomock.Eq(ctx.Request().Context()), gomock.Eq(retrieveParam)).
+					Return(nil, &system.Error{
+						Code:    1003,
+						Message: "upload is not accessible",
+					}).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(Equal(&echo.HTTPError{
+					Code: 403,
+					Message: &restapp.ResponseBodyInfo{
+						Code:    1003,
+						Message: "upload is not accessible",
+					},
+				}))
+			})
+		})
+
+		When("failed retrieve upload", func() {
+			It("should return error", func() {
+				ctx := newContext()
+
+				uploadClient.
+					EXPECT().
+					RetrieveUpload(gomock.Eq(ctx.Request().Context()), gomock.Eq(retrieveParam)).
+					Return(nil, &system.Error{
+						Code:    1001,
+						Message: "db error",
+					}).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(Equal(&echo.HTTPError{
+					Code: 500,
+					Message: &restapp.ResponseBodyInfo{
+						Code:    1001,
+						Message: "db error",
+					},
+				}))
+			})
+		})
+
+		When("upload is not completed", func() {
+			It("should return result", func() {
+				ctx := newContext()
+
+				uploadClient.
+					EXPECT().
+					RetrieveUpload(gomock.Eq(ctx.Request().Context()), gomock.Eq(retrieveParam)).
+					Return(retrieveRes, nil).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(BeNil())
+				Expect(rec.Code).To(Equal(http.StatusOK))
+				Expect(rec.Header().Get("Upload-Offset")).To(Equal("1024"))
+				Expect(rec.Header().Get("Upload-Length")).To(Equal("2048"))
+				Expect(rec.Header().Get("Cache-Control")).To(Equal("no-store"))
+				Expect(rec.Header().Values("X-File-Id")).To(BeEmpty())
+			})
+		})
+
+		When("upload is completed", func() {
+			It("should return result", func() {
+				ctx := newContext()
+				retrieveRes.Offset = 2048
+				retrieveRes.FileId = "file-id"
+				retrieveRes.CompletedAt = &currentTs
+
+				uploadClient.
+					EXPECT().
+					RetrieveUpload(gomock.Eq(ctx.Request().Context()), gomock.Eq(retrieveParam)).
+					Return(retrieveRes, nil).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(BeNil())
+				Expect(rec.Code).To(Equal(http.StatusOK))
+				Expect(rec.Header().Get("Upload-Offset")).To(Equal("2048"))
+				Expect(rec.Header().Get("X-File-Id")).To(Equal("file-id"))
+			})
+		})
+	})
+
+	Context("AppendUploadById function", Label("unit"), func() {
+		var (
+			currentTs    time.Time
+			req          *http.Request
+			rec          *httptest.ResponseRecorder
+			h            func(ctx echo.Context) error
+			uploadClient *mock_service.MockUpload
+			appendRes    *service.AppendUploadResult
+		)
+
+		BeforeEach(func() {
+			currentTs = time.Now().UTC()
+
+			req = httptest.NewRequest(http.MethodPatch, "/", strings.NewReader("chunk"))
+			req.Header.Set("Tus-Resumable", "1.0.0")
+			req.Header.Set("Upload-Offset", "1024")
+			req.Header.Set(echo.HeaderContentType, "application/offset+octet-stream")
+			rec = httptest.NewRecorder()
+
+			t := GinkgoT()
+			ctrl := gomock.NewController(t)
+			uploadClient = mock_service.NewMockUpload(ctrl)
+			uploadHandler := resthandler.NewUpload(resthandler.UploadParam{
+				UploadClient: uploadClient,
+				Config:       &resthandler.UploadConfig{},
+			})
+			h = uploadHandler.AppendUploadById
+			appendRes = &service.AppendUploadResult{
+				Success: system.Success{
+					Code:    1000,
+					Message: "success append upload",
+				},
+				UniqueId: "id",
+				Size:     2048,
+				Offset:   1029,
+			}
+		})
+
+		newContext := func() echo.Context {
+			ctx := echo.New().NewContext(req, rec)
+			ctx.SetParamNames("id")
+			ctx.SetParamValues("id")
+			return ctx
+		}
+
+		appendParam := func() service.AppendUploadParam {
+			return service.AppendUploadParam{
+				UploadId: "id",
+				Offset:   1024,
+				Reader:   req.Body,
+			}
+		}
+
+		When("tus version is not supported", func() {
+			It("should return error", func() {
+				req.Header.Set("Tus-Resumable", "0.2.2")
+				ctx := newContext()
+
+				err := h(ctx)
+
+				Expect(err).To(Equal(&echo.HTTPError{
+					Code: 412,
+					Message: &restapp.ResponseBodyInfo{
+						Code:    1002,
+						Message: "tus version is not supported",
+					},
+				}))
+			})
+		})
+
+		When("content type is invalid", func() {
+			It("should return error", func() {
+				req.Header.Set(echo.HeaderContentType, echo.MIMEOctetStream)
+				ctx := newContext()
+
+				err := h(ctx)
+
+				Expect(err).To(Equal(&echo.HTTPError{
+					Code: 415,
+					Message: &restapp.ResponseBodyInfo{
+						Code:    1002,
+						Message: "invalid Content-Type header",
+					},
+				}))
+			})
+		})
+
+		When("upload offset is invalid", func() {
+			It("should return error", func() {
+				req.Header.Set("Upload-Offset", "invalid")
+				ctx := newContext()
+
+				err := h(ctx)
+
+				Expect(err).To(Equal(&echo.HTTPError{
+					Code: 400,
+					Message: &restapp.ResponseBodyInfo{
+						Code:    1002,
+						Message: "invalid Upload-Offset header",
+					},
+				}))
+			})
+		})
+
+		When("upload is not available", func() {
+			It("should return error", func() {
+				ctx := newContext()
+
+				uploadClient.
+					EXPECT().
+					AppendUpload(gomock.Eq(ctx.Request().Context()), gomock.Eq(appendParam())).
+					Return(nil, &system.Error{
+						Code:    1004,
+						Message: "upload is not found",
+					}).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(Equal(&echo.HTTPError{
+					Code: 404,
+					Message: &restapp.ResponseBodyInfo{
+						Code:    1004,
+						Message: "upload is not found",
+					},
+				}))
+			})
+		})
+
+		When("upload is created by other client", func() {
+			It("should return error", func() {
+				ctx := newContext()
+
+				uploadClient.
+					EXPECT().
+					AppendUpload(gomock.Eq(ctx.Request().Context()), gomock.Eq(appendParam())).
+					Return(nil, &system.Error{
+						Code:    1003,
+						Message: "upload is not accessible",
+					}).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(Equal(&echo.HTTPError{
+					Code: 403,
+					Message: &restapp.ResponseBodyInfo{
+						Code:    1003,
+						Message: "upload is not accessible",
+					},
+				}))
+			})
+		})
+
+		When("upload offset is not matched", func() {
+			It("should return error", func() {
+				ctx := newContext()
+
+				uploadClient.
+					EXPECT().
+					AppendUpload(gomock.Eq(ctx.Request().Context()), gomock.Eq(appendParam())).
+					Return(nil, &system.Error{
+						Code:    2001,
+						Message: "upload offset is not matched",
+					}).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(Equal(&echo.HTTPError{
+					Code: 409,
+					Message: &restapp.ResponseBodyInfo{
+						Code:    2001,
+						Message: "upload offset is not matched",
+					},
+				}))
+			})
+		})
+
+		When("upload size is exceeded", func() {
+			It("should return error", func() {
+				ctx := newContext()
+
+				uploadClient.
+					EXPECT().
+					AppendUpload(gomock.Eq(ctx.Request().Context()), gomock.Eq(appendParam())).
+					Return(nil, &system.Error{
+						Code:    2002,
+						Message: "upload size is exceeded",
+					}).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(Equal(&echo.HTTPError{
+					Code: 413,
+					Message: &restapp.ResponseBodyInfo{
+						Code:    2002,
+						Message: "upload size is exceeded",
+					},
+				}))
+			})
+		})
+
+		When("uploaded file is infected", func() {
+			It("should return error", func() {
+				ctx := newContext()
+
+				uploadClient.
+					EXPECT().
+					AppendUpload(gomock.Eq(ctx.Request().Context()), gomock.Eq(appendParam())).
+					Return(nil, &system.Error{
+						Code:    2008,
+						Message: "file is infected: Eicar-Signature",
+					}).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(Equal(&echo.HTTPError{
+					Code: 422,
+					Message: &restapp.ResponseBodyInfo{
+						Code:    2008,
+						Message: "file is infected: Eicar-Signature",
+					},
+				}))
+			})
+		})
+
+		When("uploaded file is not scanned", func() {
+			It("should return error", func() {
+				ctx := newContext()
+
+				uploadClient.
+					EXPECT().
+					AppendUpload(gomock.Eq(ctx.Request().Context()), gomock.Eq(appendParam())).
+					Return(nil, &system.Error{
+						Code:    2009,
+						Message: "scanner is unavailable",
+					}).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(Equal(&echo.HTTPError{
+					Code: 503,
+					Message: &restapp.ResponseBodyInfo{
+						Code:    2009,
+						Message: "scanner is unavailable",
+					},
+				}))
+			})
+		})
+
+		When("uploaded file exceeds the scanner limit", func() {
+			It("should return error", func() {
+				ctx := newContext()
+
+				uploadClient.
+					EXPECT().
+					AppendUpload(gomock.Eq(ctx.Request().Context()), gomock.Eq(appendParam())).
+					Return(nil, &system.Error{
+						Code:    2012,
+						Message: "scanner limit is exceeded: INSTREAM size limit exceeded.",
+					}).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(Equal(&echo.HTTPError{
+					Code: 413,
+					Message: &restapp.ResponseBodyInfo{
+						Code:    2012,
+						Message: "scanner limit is exceeded: INSTREAM size limit exceeded.",
+					},
+				}))
+			})
+		})
+
+		When("client quota is exceeded", func() {
+			It("should return error", func() {
+				ctx := newContext()
+
+				uploadClient.
+					EXPECT().
+					AppendUpload(gomock.Eq(ctx.Request().Context()), gomock.Eq(appendParam())).
+					Return(nil, &system.Error{
+						Code:    2010,
+						Message: "quota exceeded: max stored size",
+					}).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(Equal(&echo.HTTPError{
+					Code: 429,
+					Message: &restapp.ResponseBodyInfo{
+						Code:    2010,
+						Message: "quota exceeded: max stored size",
+					},
+				}))
+			})
+		})
+
+		When("file size quota is exceeded", func() {
+			It("should return error", func() {
+				ctx := newContext()
+
+				uploadClient.
+					EXPECT().
+					AppendUpload(gomock.Eq(ctx.Request().Context()), gomock.Eq(appendParam())).
+					Return(nil, &system.Error{
+						Code:    2011,
+						Message: "file size quota is exceeded",
+					}).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(Equal(&echo.HTTPError{
+					Code: 413,
+					Message: &restapp.ResponseBodyInfo{
+						Code:    2011,
+						Message: "file size quota is exceeded",
+					},
+				}))
+			})
+		})
+
+		When("failed append upload", func() {
+			It("should return error", func() {
+				ctx := newContext()
+
+				uploadClient.
+					EXPECT().
+					AppendUpload(gomock.Eq(ctx.Request().Context()), gomock.Eq(appendParam())).
+					Return(nil, &system.Error{
+						Code:    1001,
+						Message: "disk error",
+					}).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(Equal(&echo.HTTPError{
+					Code: 500,
+					Message: &restapp.ResponseBodyInfo{
+						Code:    1001,
+						Message: "disk error",
+					},
+				}))
+			})
+		})
+
+		When("upload is not completed", func() {
+			It("should return result", func() {
+				ctx := newContext()
+
+				uploadClient.
+					EXPECT().
+					AppendUpload(gomock.Eq(ctx.Request().Context()), gomock.Eq(appendParam())).
+					Return(appendRes, nil).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(BeNil())
+				Expect(rec.Code).To(Equal(http.StatusNoContent))
+				Expect(rec.Header().Get("Upload-Offset")).To(Equal("1029"))
+				Expect(rec.Header().Values("X-File-Id")).To(BeEmpty())
+			})
+		})
+
+		When("upload is completed", func() {
+			It("should return result", func() {
+				ctx := newContext()
+				appendRes.Offset = 2048
+				appendRes.FileId = "file-id"
+				appendRes.CompletedAt = &currentTs
+
+				uploadClient.
+					EXPECT().
+					AppendUpload(gomock.Eq(ctx.Request().Context()), gomock.Eq(appendParam())).
+					Return(appendRes, nil).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(BeNil())
+				Expect(rec.Code).To(Equal(http.StatusNoContent))
+				Expect(rec.Header().Get("Upload-Offset")).To(Equal("2048"))
+				Expect(rec.Header().Get("X-File-Id")).To(Equal("file-id"))
+			})
+		})
+	})
+
+	Context("DeleteUploadById function", Label("unit"), func() {
+		var (
+			currentTs    time.Time
+			req          *http.Request
+			rec          *httptest.ResponseRecorder
+			h            func(ctx echo.Context) error
+			uploadClient *mock_service.MockUpload
+			deleteParam  service.DeleteUploadParam
+			deleteRes    *service.DeleteUploadResult
+		)
+
+		BeforeEach(func() {
+			currentTs = time.Now().UTC()
+
+			req = httptest.NewRequest(http.MethodDelete, "/", nil)
+			req.Header.Set("Tus-Resumable", "1.0.0")
+			rec = httptest.NewRecorder()
+
+			t := GinkgoT()
+			ctrl := gomock.NewController(t)
+			uploadClient = mock_service.NewMockUpload(ctrl)
+			uploadHandler := resthandler.NewUpload(resthandler.UploadParam{
+				UploadClient: uploadClient,
+				Config:       &resthandler.UploadConfig{},
+			})
+			h = uploadHandler.DeleteUploadById
+			deleteParam = service.DeleteUploadParam{
+				UploadId: "id",
+			}
+			deleteRes = &service.DeleteUploadResult{
+				Success: system.Success{
+					Code:    1000,
+					Message: "success delete upload",
+				},
+				DeletedAt: currentTs,
+			}
+		})
+
+		newContext := func() echo.Context {
+			ctx := echo.New().NewContext(req, rec)
+			ctx.SetParamNames("id")
+			ctx.SetParamValues("id")
+			return ctx
+		}
+
+		When("tus version is not supported", func() {
+			It("should return error", func() {
+				req.Header.Del("Tus-Resumable")
+				ctx := newContext()
+
+				err := h(ctx)
+
+				Expect(err).To(Equal(&echo.HTTPError{
+					Code: 412,
+					Message: &restapp.ResponseBodyInfo{
+						Code:    1002,
+						Message: "tus version is not supported",
+					},
+				}))
+			})
+		})
+
+		When("upload is not available", func() {
+			It("should return error", func() {
+				ctx := newContext()
+
+				uploadClient.
+					EXPECT().
+					DeleteUpload(gomock.Eq(ctx.Request().Context()), gomock.Eq(deleteParam)).
+					Return(nil, &system.Error{
+						Code:    1004,
+						Message: "upload is not found",
+					}).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(Equal(&echo.HTTPError{
+					Code: 404,
+					Message: &restapp.ResponseBodyInfo{
+						Code:    1004,
+						Message: "upload is not found",
+					},
+				}))
+			})
+		})
+
+		When("upload is created by other client", func() {
+			It("should return error", func() {
+				ctx := newContext()
+
+				uploadClient.
+					EXPECT().
+					DeleteUpload(gomock.Eq(ctx.Request().Context()), gomock.Eq(deleteParam)).
+					Return(nil, &system.Error{
+						Code:    1003,
+						Message: "upload is not accessible",
+					}).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(Equal(&echo.HTTPError{
+					Code: 403,
+					Message: &restapp.ResponseBodyInfo{
+						Code:    1003,
+						Message: "upload is not accessible",
+					},
+				}))
+			})
+		})
+
+		When("failed delete upload", func() {
+			It("should return error", func() {
+				ctx := newContext()
+
+				uploadClient.
+					EXPECT().
+					DeleteUpload(gomock.Eq(ctx.Request().Context()), gomock.Eq(deleteParam)).
+					Return(nil, &system.Error{
+						Code:    1001,
+						Message: "db error",
+					}).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(Equal(&echo.HTTPError{
+					Code: 500,
+					Message: &restapp.ResponseBodyInfo{
+						Code:    1001,
+						Message: "db error",
+					},
+				}))
+			})
+		})
+
+		When("success delete upload", func() {
+			It("should return result", func() {
+				ctx := newContext()
+
+				uploadClient.
+					EXPECT().
+					DeleteUpload(gomock.Eq(ctx.Request().Context()), gomock.Eq(deleteParam)).
+					Return(deleteRes, nil).
+					Times(1)
+
+				err := h(ctx)
+
+				Expect(err).To(BeNil())
+				Expect(rec.Code).To(Equal(http.StatusNoContent))
+				Expect(rec.Header().Get("Tus-Resumable")).To(Equal("1.0.0"))
+			})
+		})
+	})
+})
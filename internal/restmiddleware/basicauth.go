@@ -57,7 +57,8 @@ func (m *basicAuth) Handle(h http.Handler) http.Handler {
 			return
 		}
 
-		h.ServeHTTP(w, r)
+		ctx := auth.WithIdentity(r.Context(), credential.Identity)
+		h.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
 
@@ -12,6 +12,7 @@ import (
 	mock_serialization "github.com/go-seidon/provider/serialization/mock"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
 )
 
 var _ = Describe("Basic Auth Middleware", func() {
@@ -52,6 +53,9 @@ var _ = Describe("Basic Auth Middleware", func() {
 			}
 			checkRes = &auth.CheckCredentialResult{
 				TokenValid: true,
+				Identity: &auth.Identity{
+					ClientId: "client-id",
+				},
 			}
 		})
 
@@ -169,7 +173,10 @@ var _ = Describe("Basic Auth Middleware", func() {
 
 				handler.
 					EXPECT().
-					ServeHTTP(gomock.Eq(rw), gomock.Eq(req)).
+					ServeHTTP(gomock.Eq(rw), gomock.Any()).
+					Do(func(w http.ResponseWriter, r *http.Request) {
+						Expect(auth.IdentityFromContext(r.Context())).To(Equal(checkRes.Identity))
+					}).
 					Times(1)
 
 				m.ServeHTTP(rw, req)
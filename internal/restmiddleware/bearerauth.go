@@ -0,0 +1,89 @@
+package restmiddleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/go-seidon/hippo/api/restapp"
+	"github.com/go-seidon/hippo/internal/auth"
+	"github.com/go-seidon/provider/serialization"
+	"github.com/go-seidon/provider/status"
+)
+
+type bearerAuth struct {
+	tokenClient auth.TokenAuth
+	serializer  serialization.Serializer
+	fallback    func(h http.Handler) http.Handler
+}
+
+func (m *bearerAuth) Handle(h http.Handler) http.Handler {
+	var next http.Handler
+	if m.fallback != nil {
+		next = m.fallback(h)
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auths := strings.Split(r.Header.Get("Authorization"), "Bearer ")
+		if len(auths) != 2 {
+			if next != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			response := &restapp.ResponseBodyInfo{
+				Code:    status.ACTION_FORBIDDEN,
+				Message: "credential is not specified",
+			}
+			info, _ := m.serializer.Marshal(response)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write(info)
+			return
+		}
+
+		credential, err := m.tokenClient.CheckToken(r.Context(), auth.CheckTokenParam{
+			AccessToken: auths[1],
+		})
+		if err != nil {
+			response := &restapp.ResponseBodyInfo{
+				Code:    status.ACTION_FORBIDDEN,
+				Message: "failed check credential",
+			}
+			info, _ := m.serializer.Marshal(response)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write(info)
+			return
+		}
+
+		if !credential.IsValid() {
+			response := &restapp.ResponseBodyInfo{
+				Code:    status.ACTION_FORBIDDEN,
+				Message: "credential is invalid",
+			}
+			info, _ := m.serializer.Marshal(response)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write(info)
+			return
+		}
+
+		ctx := auth.WithIdentity(r.Context(), credential.Identity)
+		h.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+type BearerAuthParam struct {
+	TokenClient auth.TokenAuth
+	Serializer  serialization.Serializer
+	// @note: request without bearer token is passed to the fallback (e.g: basic auth)
+	Fallback func(h http.Handler) http.Handler
+}
+
+func NewBearerAuth(p BearerAuthParam) *bearerAuth {
+	return &bearerAuth{
+		tokenClient: p.TokenClient,
+		serializer:  p.Serializer,
+		fallback:    p.Fallback,
+	}
+}
@@ -0,0 +1,210 @@
+package restmiddleware_test
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/go-seidon/hippo/api/restapp"
+	"github.com/go-seidon/hippo/internal/auth"
+	mock_auth "github.com/go-seidon/hippo/internal/auth/mock"
+	"github.com/go-seidon/hippo/internal/restmiddleware"
+	mock_http "github.com/go-seidon/provider/http/mock"
+	mock_serialization "github.com/go-seidon/provider/serialization/mock"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Bearer Auth Middleware", func() {
+	Context("Handle Function", Label("unit"), func() {
+		var (
+			a       *mock_auth.MockTokenAuth
+			s       *mock_serialization.MockSerializer
+			handler *mock_http.MockHandler
+			m       http.Handler
+
+			rw  *mock_http.MockResponseWriter
+			req *http.Request
+
+			checkParam auth.CheckTokenParam
+			checkRes   *auth.CheckCredentialResult
+		)
+
+		BeforeEach(func() {
+			t := GinkgoT()
+			ctrl := gomock.NewController(t)
+			a = mock_auth.NewMockTokenAuth(ctrl)
+			s = mock_serialization.NewMockSerializer(ctrl)
+			handler = mock_http.NewMockHandler(ctrl)
+			fn := restmiddleware.NewBearerAuth(restmiddleware.BearerAuthParam{
+				TokenClient: a,
+				Serializer:  s,
+			})
+			m = fn.Handle(handler)
+
+			rw = mock_http.NewMockResponseWriter(ctrl)
+			req = &http.Request{
+				Header: http.Header{},
+			}
+			req.Header.Set("Authorization", "Bearer access-token")
+
+			checkParam = auth.CheckTokenParam{
+				AccessToken: "access-token",
+			}
+			checkRes = &auth.CheckCredentialResult{
+				TokenValid: true,
+				Identity: &auth.Identity{
+					ClientId: "client-id",
+				},
+			}
+		})
+
+		When("authorization header is not specified", func() {
+			It("should return error", func() {
+				req.Header.Del("Authorization")
+
+				b := &restapp.ResponseBodyInfo{
+					Code:    1003,
+					Message: "credential is not specified",
+				}
+				s.
+					EXPECT().
+					Marshal(gomock.Eq(b)).
+					Return([]byte{}, nil).
+					Times(1)
+				rw.
+					EXPECT().
+					Header().
+					Return(map[string][]string{}).
+					Times(1)
+				rw.
+					EXPECT().
+					WriteHeader(401).
+					Times(1)
+				rw.
+					EXPECT().
+					Write(gomock.Eq([]byte{})).
+					Times(1)
+
+				m.ServeHTTP(rw, req)
+			})
+		})
+
+		When("bearer token is not specified and fallback is available", func() {
+			It("should pass the request to the fallback", func() {
+				fn := restmiddleware.NewBearerAuth(restmiddleware.BearerAuthParam{
+					TokenClient: a,
+					Serializer:  s,
+					Fallback: func(h http.Handler) http.Handler {
+						return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+							Expect(r.Header.Get("Authorization")).To(Equal("Basic basic-token"))
+							h.ServeHTTP(w, r)
+						})
+					},
+				})
+				m := fn.Handle(handler)
+				req.Header.Set("Authorization", "Basic basic-token")
+
+				handler.
+					EXPECT().
+					ServeHTTP(gomock.Eq(rw), gomock.Eq(req)).
+					Times(1)
+
+				m.ServeHTTP(rw, req)
+			})
+		})
+
+		When("failed check credential", func() {
+			It("should return error", func() {
+				a.
+					EXPECT().
+					CheckToken(gomock.Eq(req.Context()), gomock.Eq(checkParam)).
+					Return(nil, fmt.Errorf("network error")).
+					Times(1)
+
+				b := &restapp.ResponseBodyInfo{
+					Code:    1003,
+					Message: "failed check credential",
+				}
+				s.
+					EXPECT().
+					Marshal(gomock.Eq(b)).
+					Return([]byte{}, nil).
+					Times(1)
+				rw.
+					EXPECT().
+					Header().
+					Return(map[string][]string{}).
+					Times(1)
+				rw.
+					EXPECT().
+					WriteHeader(401).
+					Times(1)
+				rw.
+					EXPECT().
+					Write(gomock.Eq([]byte{})).
+					Times(1)
+
+				m.ServeHTTP(rw, req)
+			})
+		})
+
+		When("token is invalid", func() {
+			It("should return error", func() {
+				checkRes := &auth.CheckCredentialResult{
+					TokenValid: false,
+				}
+				a.
+					EXPECT().
+					CheckToken(gomock.Eq(req.Context()), gomock.Eq(checkParam)).
+					Return(checkRes, nil).
+					Times(1)
+
+				b := &restapp.ResponseBodyInfo{
+					Code:    1003,
+					Message: "credential is invalid",
+				}
+				s.
+					EXPECT().
+					Marshal(gomock.Eq(b)).
+					Return([]byte{}, nil).
+					Times(1)
+				rw.
+					EXPECT().
+					Header().
+					Return(map[string][]string{}).
+					Times(1)
+				rw.
+					EXPECT().
+					WriteHeader(401).
+					Times(1)
+				rw.
+					EXPECT().
+					Write(gomock.Eq([]byte{})).
+					Times(1)
+
+				m.ServeHTTP(rw, req)
+			})
+		})
+
+		When("token is valid", func() {
+			It("should return result", func() {
+				a.
+					EXPECT().
+					CheckToken(gomock.Eq(req.Context()), gomock.Eq(checkParam)).
+					Return(checkRes, nil).
+					Times(1)
+
+				handler.
+					EXPECT().
+					ServeHTTP(gomock.Eq(rw), gomock.Any()).
+					Do(func(w http.ResponseWriter, r *http.Request) {
+						Expect(auth.IdentityFromContext(r.Context())).To(Equal(checkRes.Identity))
+					}).
+					Times(1)
+
+				m.ServeHTTP(rw, req)
+			})
+		})
+	})
+})
@@ -0,0 +1,47 @@
+package restmiddleware
+
+import (
+	"net/http"
+
+	"github.com/go-seidon/hippo/api/restapp"
+	"github.com/go-seidon/hippo/internal/auth"
+	"github.com/go-seidon/provider/serialization"
+	"github.com/go-seidon/provider/status"
+)
+
+type scope struct {
+	serializer serialization.Serializer
+	scope      string
+}
+
+// @note: must be placed after the auth middleware which stores the identity
+func (m *scope) Handle(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		identity := auth.IdentityFromContext(r.Context())
+		if !identity.HasScope(m.scope) {
+			response := &restapp.ResponseBodyInfo{
+				Code:    status.ACTION_FORBIDDEN,
+				Message: "insufficient scope",
+			}
+			info, _ := m.serializer.Marshal(response)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusForbidden)
+			w.Write(info)
+			return
+		}
+
+		h.ServeHTTP(w, r)
+	})
+}
+
+type ScopeParam struct {
+	Serializer serialization.Serializer
+	Scope      string
+}
+
+func NewScope(p ScopeParam) *scope {
+	return &scope{
+		serializer: p.Serializer,
+		scope:      p.Scope,
+	}
+}
@@ -0,0 +1,125 @@
+package restmiddleware_test
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-seidon/hippo/api/restapp"
+	"github.com/go-seidon/hippo/internal/auth"
+	"github.com/go-seidon/hippo/internal/restmiddleware"
+	mock_http "github.com/go-seidon/provider/http/mock"
+	mock_serialization "github.com/go-seidon/provider/serialization/mock"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+)
+
+var _ = Describe("Scope Middleware", func() {
+	Context("Handle Function", Label("unit"), func() {
+		var (
+			s       *mock_serialization.MockSerializer
+			handler *mock_http.MockHandler
+			m       http.Handler
+
+			rw  *mock_http.MockResponseWriter
+			req *http.Request
+		)
+
+		BeforeEach(func() {
+			t := GinkgoT()
+			ctrl := gomock.NewController(t)
+			s = mock_serialization.NewMockSerializer(ctrl)
+			handler = mock_http.NewMockHandler(ctrl)
+			fn := restmiddleware.NewScope(restmiddleware.ScopeParam{
+				Serializer: s,
+				Scope:      auth.SCOPE_FILE_WRITE,
+			})
+			m = fn.Handle(handler)
+
+			rw = mock_http.NewMockResponseWriter(ctrl)
+			req = &http.Request{
+				Header: http.Header{},
+			}
+		})
+
+		When("identity is not available", func() {
+			It("should return error", func() {
+				b := &restapp.ResponseBodyInfo{
+					Code:    1003,
+					Message: "insufficient scope",
+				}
+				s.
+					EXPECT().
+					Marshal(gomock.Eq(b)).
+					Return([]byte{}, nil).
+					Times(1)
+				rw.
+					EXPECT().
+					Header().
+					Return(map[string][]string{}).
+					Times(1)
+				rw.
+					EXPECT().
+					WriteHeader(403).
+					Times(1)
+				rw.
+					EXPECT().
+					Write(gomock.Eq([]byte{})).
+					Times(1)
+
+				m.ServeHTTP(rw, req)
+			})
+		})
+
+		When("scope is not granted", func() {
+			It("should return error", func() {
+				ctx := auth.WithIdentity(context.Background(), &auth.Identity{
+					ClientId: "client-id",
+					Scopes:   []string{auth.SCOPE_FILE_READ},
+				})
+				req = req.WithContext(ctx)
+
+				b := &restapp.ResponseBodyInfo{
+					Code:    1003,
+					Message: "insufficient scope",
+				}
+				s.
+					EXPECT().
+					Marshal(gomock.Eq(b)).
+					Return([]byte{}, nil).
+					Times(1)
+				rw.
+					EXPECT().
+					Header().
+					Return(map[string][]string{}).
+					Times(1)
+				rw.
+					EXPECT().
+					WriteHeader(403).
+					Times(1)
+				rw.
+					EXPECT().
+					Write(gomock.Eq([]byte{})).
+					Times(1)
+
+				m.ServeHTTP(rw, req)
+			})
+		})
+
+		When("scope is granted", func() {
+			It("should pass the request", func() {
+				ctx := auth.WithIdentity(context.Background(), &auth.Identity{
+					ClientId: "client-id",
+					Scopes:   []string{auth.SCOPE_FILE_READ, auth.SCOPE_FILE_WRITE},
+				})
+				req = req.WithContext(ctx)
+
+				handler.
+					EXPECT().
+					ServeHTTP(gomock.Eq(rw), gomock.Eq(req)).
+					Times(1)
+
+				m.ServeHTTP(rw, req)
+			})
+		})
+	})
+})
@@ -0,0 +1,149 @@
+package scanner
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+const (
+	NETWORK_TCP  = "tcp"
+	NETWORK_UNIX = "unix"
+
+	DEFAULT_CHUNK_SIZE = 64 * 1024
+)
+
+// @note: content is streamed using the clamd INSTREAM command,
+// each chunk is prefixed by its length (4 bytes, network byte order)
+// and the stream is terminated by a zero length chunk
+type clamdScanner struct {
+	network   string
+	address   string
+	timeout   time.Duration
+	chunkSize int
+}
+
+func (s *clamdScanner) ScanFile(ctx context.Context, p ScanFileParam) (*ScanFileResult, error) {
+	dialer := net.Dialer{Timeout: s.timeout}
+	conn, err := dialer.DialContext(ctx, s.network, s.address)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrorUnavailable, err.Error())
+	}
+	defer conn.Close()
+
+	if s.timeout > 0 {
+		conn.SetDeadline(time.Now().Add(s.timeout))
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.SetDeadline(time.Now())
+		case <-done:
+		}
+	}()
+
+	err = s.stream(conn, p.Reader)
+	if err != nil {
+		// @note: clamd replies with an error and closes the connection
+		// when the stream is rejected (e.g: size limit exceeded)
+		reply, rErr := readReply(conn)
+		if rErr == nil {
+			return parseReply(reply)
+		}
+		return nil, fmt.Errorf("%w: %s", ErrorUnavailable, err.Error())
+	}
+
+	reply, err := readReply(conn)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrorUnavailable, err.Error())
+	}
+	return parseReply(reply)
+}
+
+func (s *clamdScanner) stream(w io.Writer, r io.Reader) error {
+	_, err := w.Write([]byte("zINSTREAM\x00"))
+	if err != nil {
+		return err
+	}
+
+	chunk := make([]byte, 4+s.chunkSize)
+	for {
+		n, rErr := io.ReadFull(r, chunk[4:])
+		if n > 0 {
+			binary.BigEndian.PutUint32(chunk[:4], uint32(n))
+			_, err := w.Write(chunk[:4+n])
+			if err != nil {
+				return err
+			}
+		}
+		if rErr == io.EOF || rErr == io.ErrUnexpectedEOF {
+			break
+		}
+		if rErr != nil {
+			return rErr
+		}
+	}
+
+	_, err = w.Write([]byte{0, 0, 0, 0})
+	return err
+}
+
+func readReply(r io.Reader) (string, error) {
+	reply, err := bufio.NewReader(r).ReadString(0)
+	if err != nil && (err != io.EOF || reply == "") {
+		return "", err
+	}
+	return strings.TrimRight(reply, "\x00\n"), nil
+}
+
+// @note: reply is either "stream: OK", "stream: <signature> FOUND" or "<reason> ERROR",
+// the stream exceeding StreamMaxLength is replied by "INSTREAM size limit exceeded. ERROR"
+func parseReply(reply string) (*ScanFileResult, error) {
+	reply = strings.TrimPrefix(reply, "stream: ")
+	switch {
+	case reply == "OK":
+		return &ScanFileResult{}, nil
+	case strings.HasSuffix(reply, " FOUND"):
+		res := &ScanFileResult{
+			Infected:  true,
+			Signature: strings.TrimSuffix(reply, " FOUND"),
+		}
+		return res, nil
+	case strings.HasSuffix(reply, " ERROR"):
+		reason := strings.TrimSuffix(reply, " ERROR")
+		if strings.Contains(strings.ToLower(reason), "size limit exceeded") {
+			return nil, fmt.Errorf("%w: %s", ErrorLimitExceeded, reason)
+		}
+		return nil, fmt.Errorf("%w: %s", ErrorUnavailable, reason)
+	}
+	return nil, fmt.Errorf("%w: unexpected reply %q", ErrorUnavailable, reply)
+}
+
+type ClamdParam struct {
+	// @note: either tcp (e.g: localhost:3310) or unix (e.g: /var/run/clamav/clamd.ctl)
+	Network   string
+	Address   string
+	Timeout   time.Duration
+	ChunkSize int
+}
+
+func NewClamd(p ClamdParam) *clamdScanner {
+	chunkSize := p.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = DEFAULT_CHUNK_SIZE
+	}
+	return &clamdScanner{
+		network:   p.Network,
+		address:   p.Address,
+		timeout:   p.Timeout,
+		chunkSize: chunkSize,
+	}
+}
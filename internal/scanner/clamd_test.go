@@ -0,0 +1,280 @@
+package scanner_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-seidon/hippo/internal/scanner"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// @note: fake clamd which reads the INSTREAM command and replies using the given function
+type fakeClamd struct {
+	listener net.Listener
+	command  chan string
+	content  chan []byte
+	reply    func(content []byte) string
+}
+
+func newFakeClamd(network, address string, reply func(content []byte) string) *fakeClamd {
+	listener, err := net.Listen(network, address)
+	Expect(err).To(BeNil())
+
+	c := &fakeClamd{
+		listener: listener,
+		command:  make(chan string, 1),
+		content:  make(chan []byte, 1),
+		reply:    reply,
+	}
+	go c.serve()
+	return c
+}
+
+func (c *fakeClamd) serve() {
+	conn, err := c.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	command := make([]byte, len("zINSTREAM\x00"))
+	_, err = io.ReadFull(conn, command)
+	if err != nil {
+		return
+	}
+	c.command <- string(command)
+
+	content := bytes.Buffer{}
+	size := make([]byte, 4)
+	for {
+		_, err := io.ReadFull(conn, size)
+		if err != nil {
+			return
+		}
+		n := binary.BigEndian.Uint32(size)
+		if n == 0 {
+			break
+		}
+		_, err = io.CopyN(&content, conn, int64(n))
+		if err != nil {
+			return
+		}
+	}
+	c.content <- content.Bytes()
+	conn.Write([]byte(c.reply(content.Bytes()) + "\x00"))
+}
+
+func (c *fakeClamd) Close() {
+	c.listener.Close()
+}
+
+var _ = Describe("Clamd Scanner", func() {
+
+	Context("ScanFile function", Label("unit"), func() {
+		var (
+			ctx     context.Context
+			content string
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			content = strings.Repeat("content-", 100)
+		})
+
+		When("file is clean", func() {
+			It("should stream the content in chunks", func() {
+				clamd := newFakeClamd("tcp", "127.0.0.1:0", func(content []byte) string {
+					return "stream: OK"
+				})
+				defer clamd.Close()
+
+				s := scanner.NewClamd(scanner.ClamdParam{
+					Network:   scanner.NETWORK_TCP,
+					Address:   clamd.listener.Addr().String(),
+					Timeout:   5 * time.Second,
+					ChunkSize: 64,
+				})
+				res, err := s.ScanFile(ctx, scanner.ScanFileParam{
+					Reader: strings.NewReader(content),
+				})
+
+				Expect(res).To(Equal(&scanner.ScanFileResult{}))
+				Expect(err).To(BeNil())
+				Expect(<-clamd.command).To(Equal("zINSTREAM\x00"))
+				Expect(string(<-clamd.content)).To(Equal(content))
+			})
+		})
+
+		When("file is infected", func() {
+			It("should return the signature", func() {
+				clamd := newFakeClamd("tcp", "127.0.0.1:0", func(content []byte) string {
+					return "stream: Eicar-Signature FOUND"
+				})
+				defer clamd.Close()
+
+				s := scanner.NewClamd(scanner.ClamdParam{
+					Network: scanner.NETWORK_TCP,
+					Address: clamd.listener.Addr().String(),
+					Timeout: 5 * time.Second,
+				})
+				res, err := s.ScanFile(ctx, scanner.ScanFileParam{
+					Reader: strings.NewReader(content),
+				})
+
+				Expect(res).To(Equal(&scanner.ScanFileResult{
+					Infected:  true,
+					Signature: "Eicar-Signature",
+				}))
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("scanner is listening on unix socket", func() {
+			It("should return result", func() {
+				dir, err := os.MkdirTemp("", "clamd")
+				Expect(err).To(BeNil())
+				defer os.RemoveAll(dir)
+
+				address := filepath.Join(dir, "clamd.sock")
+				clamd := newFakeClamd("unix", address, func(content []byte) string {
+					return "stream: OK"
+				})
+				defer clamd.Close()
+
+				s := scanner.NewClamd(scanner.ClamdParam{
+					Network: scanner.NETWORK_UNIX,
+					Address: address,
+					Timeout: 5 * time.Second,
+				})
+				res, err := s.ScanFile(ctx, scanner.ScanFileParam{
+					Reader: strings.NewReader(content),
+				})
+
+				Expect(res).To(Equal(&scanner.ScanFileResult{}))
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("scanner replies with error", func() {
+			It("should return error", func() {
+				clamd := newFakeClamd("tcp", "127.0.0.1:0", func(content []byte) string {
+					return "Can't allocate memory ERROR"
+				})
+				defer clamd.Close()
+
+				s := scanner.NewClamd(scanner.ClamdParam{
+					Network: scanner.NETWORK_TCP,
+					Address: clamd.listener.Addr().String(),
+					Timeout: 5 * time.Second,
+				})
+				res, err := s.ScanFile(ctx, scanner.ScanFileParam{
+					Reader: strings.NewReader(content),
+				})
+
+				Expect(res).To(BeNil())
+				Expect(errors.Is(err, scanner.ErrorUnavailable)).To(BeTrue())
+				Expect(err.Error()).To(Equal("scanner is unavailable: Can't allocate memory"))
+			})
+		})
+
+		When("stream size limit is exceeded", func() {
+			It("should return error", func() {
+				clamd := newFakeClamd("tcp", "127.0.0.1:0", func(content []byte) string {
+					return "INSTREAM size limit exceeded. ERROR"
+				})
+				defer clamd.Close()
+
+				s := scanner.NewClamd(scanner.ClamdParam{
+					Network: scanner.NETWORK_TCP,
+					Address: clamd.listener.Addr().String(),
+					Timeout: 5 * time.Second,
+				})
+				res, err := s.ScanFile(ctx, scanner.ScanFileParam{
+					Reader: strings.NewReader(content),
+				})
+
+				Expect(res).To(BeNil())
+				Expect(errors.Is(err, scanner.ErrorLimitExceeded)).To(BeTrue())
+				Expect(err.Error()).To(Equal("scanner limit is exceeded: INSTREAM size limit exceeded."))
+			})
+		})
+
+		When("scanner replies unexpectedly", func() {
+			It("should return error", func() {
+				clamd := newFakeClamd("tcp", "127.0.0.1:0", func(content []byte) string {
+					return "UNKNOWN COMMAND"
+				})
+				defer clamd.Close()
+
+				s := scanner.NewClamd(scanner.ClamdParam{
+					Network: scanner.NETWORK_TCP,
+					Address: clamd.listener.Addr().String(),
+					Timeout: 5 * time.Second,
+				})
+				res, err := s.ScanFile(ctx, scanner.ScanFileParam{
+					Reader: strings.NewReader(content),
+				})
+
+				Expect(res).To(BeNil())
+				Expect(errors.Is(err, scanner.ErrorUnavailable)).To(BeTrue())
+			})
+		})
+
+		When("scanner is not reachable", func() {
+			It("should return error", func() {
+				listener, err := net.Listen("tcp", "127.0.0.1:0")
+				Expect(err).To(BeNil())
+				address := listener.Addr().String()
+				listener.Close()
+
+				s := scanner.NewClamd(scanner.ClamdParam{
+					Network: scanner.NETWORK_TCP,
+					Address: address,
+					Timeout: 5 * time.Second,
+				})
+				res, err := s.ScanFile(ctx, scanner.ScanFileParam{
+					Reader: strings.NewReader(content),
+				})
+
+				Expect(res).To(BeNil())
+				Expect(errors.Is(err, scanner.ErrorUnavailable)).To(BeTrue())
+			})
+		})
+
+		When("scanner is not replying", func() {
+			It("should return error", func() {
+				listener, err := net.Listen("tcp", "127.0.0.1:0")
+				Expect(err).To(BeNil())
+				defer listener.Close()
+				go func() {
+					conn, err := listener.Accept()
+					if err == nil {
+						io.Copy(io.Discard, conn)
+						conn.Close()
+					}
+				}()
+
+				s := scanner.NewClamd(scanner.ClamdParam{
+					Network: scanner.NETWORK_TCP,
+					Address: listener.Addr().String(),
+					Timeout: 100 * time.Millisecond,
+				})
+				res, err := s.ScanFile(ctx, scanner.ScanFileParam{
+					Reader: strings.NewReader(content),
+				})
+
+				Expect(res).To(BeNil())
+				Expect(errors.Is(err, scanner.ErrorUnavailable)).To(BeTrue())
+			})
+		})
+	})
+})
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/scanner/scanner.go
+
+// Package mock_scanner is a generated GoMock package.
+package mock_scanner
+
+import (
+	context "context"
+	reflect "reflect"
+
+	scanner "github.com/go-seidon/hippo/internal/scanner"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockScanner is a mock of Scanner interface.
+type MockScanner struct {
+	ctrl     *gomock.Controller
+	recorder *MockScannerMockRecorder
+}
+
+// MockScannerMockRecorder is the mock recorder for MockScanner.
+type MockScannerMockRecorder struct {
+	mock *MockScanner
+}
+
+// NewMockScanner creates a new mock instance.
+func NewMockScanner(ctrl *gomock.Controller) *MockScanner {
+	mock := &MockScanner{ctrl: ctrl}
+	mock.recorder = &MockScannerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockScanner) EXPECT() *MockScannerMockRecorder {
+	return m.recorder
+}
+
+// ScanFile mocks base method.
+func (m *MockScanner) ScanFile(ctx context.Context, p scanner.ScanFileParam) (*scanner.ScanFileResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScanFile", ctx, p)
+	ret0, _ := ret[0].(*scanner.ScanFileResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ScanFile indicates an expected call of ScanFile.
+func (mr *MockScannerMockRecorder) ScanFile(ctx, p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScanFile", reflect.TypeOf((*MockScanner)(nil).ScanFile), ctx, p)
+}
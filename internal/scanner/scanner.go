@@ -0,0 +1,42 @@
+package scanner
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+const (
+	PROVIDER_NOOP  = "noop"
+	PROVIDER_CLAMD = "clamd"
+)
+
+var (
+	ErrorUnavailable   = errors.New("scanner is unavailable")
+	ErrorLimitExceeded = errors.New("scanner limit is exceeded")
+)
+
+type Scanner interface {
+	ScanFile(ctx context.Context, p ScanFileParam) (*ScanFileResult, error)
+}
+
+// @note: reader is consumed until the end or until the scanner stops reading it
+type ScanFileParam struct {
+	Reader io.Reader
+}
+
+type ScanFileResult struct {
+	Infected  bool
+	Signature string
+}
+
+// @note: default scanner when no scanner is configured, every file is reported as clean
+type noopScanner struct{}
+
+func (s *noopScanner) ScanFile(ctx context.Context, p ScanFileParam) (*ScanFileResult, error) {
+	return &ScanFileResult{}, nil
+}
+
+func NewNoop() *noopScanner {
+	return &noopScanner{}
+}
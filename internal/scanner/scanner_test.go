@@ -0,0 +1,33 @@
+package scanner_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/go-seidon/hippo/internal/scanner"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestScanner(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Scanner Package")
+}
+
+var _ = Describe("Noop Scanner", func() {
+
+	Context("ScanFile function", Label("unit"), func() {
+		When("file is scanned", func() {
+			It("should return clean result", func() {
+				s := scanner.NewNoop()
+				res, err := s.ScanFile(context.Background(), scanner.ScanFileParam{
+					Reader: strings.NewReader("content"),
+				})
+
+				Expect(res).To(Equal(&scanner.ScanFileResult{}))
+				Expect(err).To(BeNil())
+			})
+		})
+	})
+})
@@ -5,10 +5,12 @@ import (
 	"errors"
 	"time"
 
+	"github.com/go-seidon/hippo/internal/auth"
 	"github.com/go-seidon/hippo/internal/repository"
 	"github.com/go-seidon/provider/datetime"
 	"github.com/go-seidon/provider/hashing"
 	"github.com/go-seidon/provider/identity"
+	"github.com/go-seidon/provider/random"
 	"github.com/go-seidon/provider/status"
 	"github.com/go-seidon/provider/system"
 	"github.com/go-seidon/provider/validation"
@@ -19,6 +21,9 @@ type AuthClient interface {
 	FindClientById(ctx context.Context, p FindClientByIdParam) (*FindClientByIdResult, *system.Error)
 	UpdateClientById(ctx context.Context, p UpdateClientByIdParam) (*UpdateClientByIdResult, *system.Error)
 	SearchClient(ctx context.Context, p SearchClientParam) (*SearchClientResult, *system.Error)
+	RotateClientSecret(ctx context.Context, p RotateClientSecretParam) (*RotateClientSecretResult, *system.Error)
+	RevokeClientSecret(ctx context.Context, p RevokeClientSecretParam) (*RevokeClientSecretResult, *system.Error)
+	DeleteClientById(ctx context.Context, p DeleteClientByIdParam) (*DeleteClientByIdResult, *system.Error)
 }
 
 type CreateClientParam struct {
@@ -27,6 +32,23 @@ type CreateClientParam struct {
 	Name         string `validate:"required,printascii,min=3,max=64" label:"name"`
 	Type         string `validate:"required,oneof='basic'" label:"type"`
 	Status       string `validate:"required,oneof='active' 'inactive'" label:"status"`
+	// @note: file scopes are granted when it's not specified
+	Scopes []string `validate:"omitempty,unique,dive,oneof='file:read' 'file:write' 'file:delete' 'client:admin'" label:"scopes"`
+	Quota  *ClientQuota
+}
+
+// @note: zero limit means unlimited
+type ClientQuota struct {
+	MaxStoredSize   int64 `validate:"min=0" label:"max_stored_size"`
+	MaxTotalFiles   int64 `validate:"min=0" label:"max_total_files"`
+	MaxFileSize     int64 `validate:"min=0" label:"max_file_size"`
+	MaxDailyUploads int64 `validate:"min=0" label:"max_daily_uploads"`
+}
+
+type ClientUsage struct {
+	StoredSize   int64
+	TotalFiles   int64
+	DailyUploads int64
 }
 
 type CreateClientResult struct {
@@ -44,22 +66,31 @@ type FindClientByIdParam struct {
 }
 
 type FindClientByIdResult struct {
-	Success   system.Success
-	Id        string
-	ClientId  string
-	Name      string
-	Type      string
-	Status    string
-	CreatedAt time.Time
-	UpdatedAt *time.Time
+	Success  system.Success
+	Id       string
+	ClientId string
+	Name     string
+	Type     string
+	Status   string
+	Scopes   []string
+	Quota    ClientQuota
+	Usage    ClientUsage
+	// @note: previous secret expiry is only available during the rotation grace period
+	SecretRotatedAt         *time.Time
+	PreviousSecretExpiresAt *time.Time
+	CreatedAt               time.Time
+	UpdatedAt               *time.Time
 }
 
+// @note: current scopes and quota are kept when it's not specified
 type UpdateClientByIdParam struct {
-	Id       string `validate:"required,min=5,max=64" label:"id"`
-	ClientId string `validate:"required,lowercase,alphanum,min=6,max=128" label:"client_id"`
-	Name     string `validate:"required,printascii,min=3,max=64" label:"name"`
-	Type     string `validate:"required,oneof='basic'" label:"type"`
-	Status   string `validate:"required,oneof='active' 'inactive'" label:"status"`
+	Id       string   `validate:"required,min=5,max=64" label:"id"`
+	ClientId string   `validate:"required,lowercase,alphanum,min=6,max=128" label:"client_id"`
+	Name     string   `validate:"required,printascii,min=3,max=64" label:"name"`
+	Type     string   `validate:"required,oneof='basic'" label:"type"`
+	Status   string   `validate:"required,oneof='active' 'inactive'" label:"status"`
+	Scopes   []string `validate:"omitempty,unique,dive,oneof='file:read' 'file:write' 'file:delete' 'client:admin'" label:"scopes"`
+	Quota    *ClientQuota
 }
 
 type UpdateClientByIdResult struct {
@@ -101,6 +132,44 @@ type SearchClientSummary struct {
 	Page       int64
 }
 
+type RotateClientSecretParam struct {
+	Id string `validate:"required,min=5,max=64" label:"id"`
+	// @note: new secret is generated when it's not specified
+	ClientSecret string `validate:"omitempty,printascii,min=8,max=128" label:"client_secret"`
+}
+
+type RotateClientSecretResult struct {
+	Success  system.Success
+	Id       string
+	ClientId string
+	// @note: plain secret is only returned once
+	ClientSecret            string
+	SecretRotatedAt         time.Time
+	PreviousSecretExpiresAt time.Time
+}
+
+type RevokeClientSecretParam struct {
+	Id string `validate:"required,min=5,max=64" label:"id"`
+}
+
+type RevokeClientSecretResult struct {
+	Success   system.Success
+	Id        string
+	ClientId  string
+	RevokedAt time.Time
+}
+
+type DeleteClientByIdParam struct {
+	Id string `validate:"required,min=5,max=64" label:"id"`
+}
+
+type DeleteClientByIdResult struct {
+	Success   system.Success
+	Id        string
+	ClientId  string
+	DeletedAt time.Time
+}
+
 var _ AuthClient = (*authClient)(nil)
 
 type authClient struct {
@@ -108,7 +177,10 @@ type authClient struct {
 	hasher     hashing.Hasher
 	identifier identity.Identifier
 	clock      datetime.Clock
+	randomizer random.Randomizer
 	authRepo   repository.Auth
+	cache      auth.VerificationCache
+	config     *AuthClientConfig
 }
 
 func (c *authClient) CreateClient(ctx context.Context, p CreateClientParam) (*CreateClientResult, *system.Error) {
@@ -136,6 +208,16 @@ func (c *authClient) CreateClient(ctx context.Context, p CreateClientParam) (*Cr
 		}
 	}
 
+	quota := repository.ClientQuota{}
+	if p.Quota != nil {
+		quota = repository.ClientQuota(*p.Quota)
+	}
+
+	scopes := p.Scopes
+	if scopes == nil {
+		scopes = []string{auth.SCOPE_FILE_READ, auth.SCOPE_FILE_WRITE, auth.SCOPE_FILE_DELETE}
+	}
+
 	currentTs := c.clock.Now()
 	createRes, err := c.authRepo.CreateClient(ctx, repository.CreateClientParam{
 		Id:           id,
@@ -144,6 +226,8 @@ func (c *authClient) CreateClient(ctx context.Context, p CreateClientParam) (*Cr
 		Name:         p.Name,
 		Type:         p.Type,
 		Status:       p.Status,
+		Scopes:       scopes,
+		Quota:        quota,
 		CreatedAt:    currentTs,
 	})
 	if err != nil {
@@ -199,18 +283,35 @@ func (c *authClient) FindClientById(ctx context.Context, p FindClientByIdParam)
 		}
 	}
 
+	// @note: daily uploads of the previous day is no longer counted
+	currentTs := c.clock.Now()
+	usage := authClient.Usage.Add(repository.UpdateClientUsageParam{
+		UsedAt: currentTs,
+	})
+
 	res := &FindClientByIdResult{
 		Success: system.Success{
 			Code:    status.ACTION_SUCCESS,
 			Message: "success find auth client",
 		},
-		Id:        authClient.Id,
-		ClientId:  authClient.ClientId,
-		Name:      authClient.Name,
-		Type:      authClient.Type,
-		Status:    authClient.Status,
-		CreatedAt: authClient.CreatedAt,
-		UpdatedAt: authClient.UpdatedAt,
+		Id:       authClient.Id,
+		ClientId: authClient.ClientId,
+		Name:     authClient.Name,
+		Type:     authClient.Type,
+		Status:   authClient.Status,
+		Scopes:   authClient.Scopes,
+		Quota:    ClientQuota(authClient.Quota),
+		Usage: ClientUsage{
+			StoredSize:   usage.StoredSize,
+			TotalFiles:   usage.TotalFiles,
+			DailyUploads: usage.DailyUploads,
+		},
+		SecretRotatedAt: authClient.SecretRotatedAt,
+		CreatedAt:       authClient.CreatedAt,
+		UpdatedAt:       authClient.UpdatedAt,
+	}
+	if authClient.IsPreviousSecretValid(currentTs) {
+		res.PreviousSecretExpiresAt = authClient.PreviousSecretExpiresAt
 	}
 	return res, nil
 }
@@ -224,6 +325,12 @@ func (c *authClient) UpdateClientById(ctx context.Context, p UpdateClientByIdPar
 		}
 	}
 
+	var quota *repository.ClientQuota
+	if p.Quota != nil {
+		q := repository.ClientQuota(*p.Quota)
+		quota = &q
+	}
+
 	currentTs := c.clock.Now()
 	updateRes, err := c.authRepo.UpdateClient(ctx, repository.UpdateClientParam{
 		Id:        p.Id,
@@ -231,6 +338,8 @@ func (c *authClient) UpdateClientById(ctx context.Context, p UpdateClientByIdPar
 		Name:      p.Name,
 		Type:      p.Type,
 		Status:    p.Status,
+		Scopes:    p.Scopes,
+		Quota:     quota,
 		UpdatedAt: currentTs,
 	})
 	if err != nil {
@@ -246,6 +355,8 @@ func (c *authClient) UpdateClientById(ctx context.Context, p UpdateClientByIdPar
 		}
 	}
 
+	c.invalidateCredential(updateRes.Id)
+
 	res := &UpdateClientByIdResult{
 		Success: system.Success{
 			Code:    status.ACTION_SUCCESS,
@@ -316,12 +427,180 @@ func (c *authClient) SearchClient(ctx context.Context, p SearchClientParam) (*Se
 	return res, nil
 }
 
+func (c *authClient) RotateClientSecret(ctx context.Context, p RotateClientSecretParam) (*RotateClientSecretResult, *system.Error) {
+	err := c.validator.Validate(p)
+	if err != nil {
+		return nil, &system.Error{
+			Code:    status.INVALID_PARAM,
+			Message: err.Error(),
+		}
+	}
+
+	clientSecret := p.ClientSecret
+	if clientSecret == "" {
+		clientSecret, err = c.randomizer.String(CLIENT_SECRET_LENGTH)
+		if err != nil {
+			return nil, &system.Error{
+				Code:    status.ACTION_FAILED,
+				Message: err.Error(),
+			}
+		}
+	}
+
+	secret, err := c.hasher.Generate(clientSecret)
+	if err != nil {
+		return nil, &system.Error{
+			Code:    status.ACTION_FAILED,
+			Message: err.Error(),
+		}
+	}
+
+	currentTs := c.clock.Now()
+	rotateRes, err := c.authRepo.RotateClientSecret(ctx, repository.RotateClientSecretParam{
+		Id:                      p.Id,
+		ClientSecret:            string(secret),
+		PreviousSecretExpiresAt: currentTs.Add(c.config.SecretGracePeriod),
+		RotatedAt:               currentTs,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, &system.Error{
+				Code:    status.RESOURCE_NOTFOUND,
+				Message: "auth client is not available",
+			}
+		}
+		return nil, &system.Error{
+			Code:    status.ACTION_FAILED,
+			Message: err.Error(),
+		}
+	}
+
+	c.invalidateCredential(rotateRes.Id)
+
+	res := &RotateClientSecretResult{
+		Success: system.Success{
+			Code:    status.ACTION_SUCCESS,
+			Message: "success rotate auth client secret",
+		},
+		Id:                      rotateRes.Id,
+		ClientId:                rotateRes.ClientId,
+		ClientSecret:            clientSecret,
+		SecretRotatedAt:         rotateRes.SecretRotatedAt,
+		PreviousSecretExpiresAt: rotateRes.PreviousSecretExpiresAt,
+	}
+	return res, nil
+}
+
+func (c *authClient) RevokeClientSecret(ctx context.Context, p RevokeClientSecretParam) (*RevokeClientSecretResult, *system.Error) {
+	err := c.validator.Validate(p)
+	if err != nil {
+		return nil, &system.Error{
+			Code:    status.INVALID_PARAM,
+			Message: err.Error(),
+		}
+	}
+
+	currentTs := c.clock.Now()
+	revokeRes, err := c.authRepo.RevokeClientSecret(ctx, repository.RevokeClientSecretParam{
+		Id:        p.Id,
+		RevokedAt: currentTs,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, &system.Error{
+				Code:    status.RESOURCE_NOTFOUND,
+				Message: "auth client is not available",
+			}
+		}
+		return nil, &system.Error{
+			Code:    status.ACTION_FAILED,
+			Message: err.Error(),
+		}
+	}
+
+	c.invalidateCredential(revokeRes.Id)
+
+	res := &RevokeClientSecretResult{
+		Success: system.Success{
+			Code:    status.ACTION_SUCCESS,
+			Message: "success revoke auth client secret",
+		},
+		Id:        revokeRes.Id,
+		ClientId:  revokeRes.ClientId,
+		RevokedAt: revokeRes.RevokedAt,
+	}
+	return res, nil
+}
+
+// @note: deleted client is no longer accepted and its client_id can be reused by the new client
+func (c *authClient) DeleteClientById(ctx context.Context, p DeleteClientByIdParam) (*DeleteClientByIdResult, *system.Error) {
+	err := c.validator.Validate(p)
+	if err != nil {
+		return nil, &system.Error{
+			Code:    status.INVALID_PARAM,
+			Message: err.Error(),
+		}
+	}
+
+	currentTs := c.clock.Now()
+	deleteRes, err := c.authRepo.DeleteClient(ctx, repository.DeleteClientParam{
+		Id:        p.Id,
+		DeletedAt: currentTs,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, &system.Error{
+				Code:    status.RESOURCE_NOTFOUND,
+				Message: "auth client is not available",
+			}
+		}
+		return nil, &system.Error{
+			Code:    status.ACTION_FAILED,
+			Message: err.Error(),
+		}
+	}
+
+	c.invalidateCredential(deleteRes.Id)
+
+	res := &DeleteClientByIdResult{
+		Success: system.Success{
+			Code:    status.ACTION_SUCCESS,
+			Message: "success delete auth client",
+		},
+		Id:        deleteRes.Id,
+		ClientId:  deleteRes.ClientId,
+		DeletedAt: deleteRes.DeletedAt,
+	}
+	return res, nil
+}
+
+// @note: cached credential may carry the outdated status, scopes or secret of the client
+func (c *authClient) invalidateCredential(id string) {
+	if c.cache == nil {
+		return
+	}
+	c.cache.Invalidate(id)
+}
+
+const (
+	CLIENT_SECRET_LENGTH = 40
+)
+
+type AuthClientConfig struct {
+	// @note: previous secret is still accepted during the grace period after rotation
+	SecretGracePeriod time.Duration
+}
+
 type AuthClientParam struct {
 	Validator  validation.Validator
 	Hasher     hashing.Hasher
 	Identifier identity.Identifier
 	Clock      datetime.Clock
+	Randomizer random.Randomizer
 	AuthRepo   repository.Auth
+	// @note: verified credentials of the updated client are invalidated when it's specified
+	Cache  auth.VerificationCache
+	Config *AuthClientConfig
 }
 
 func NewAuthClient(p AuthClientParam) *authClient {
@@ -330,6 +609,9 @@ func NewAuthClient(p AuthClientParam) *authClient {
 		hasher:     p.Hasher,
 		identifier: p.Identifier,
 		clock:      p.Clock,
+		randomizer: p.Randomizer,
 		authRepo:   p.AuthRepo,
+		cache:      p.Cache,
+		config:     p.Config,
 	}
 }
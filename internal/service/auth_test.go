@@ -5,12 +5,14 @@ import (
 	"fmt"
 	"time"
 
+	mock_auth "github.com/go-seidon/hippo/internal/auth/mock"
 	"github.com/go-seidon/hippo/internal/repository"
 	mock_repository "github.com/go-seidon/hippo/internal/repository/mock"
 	"github.com/go-seidon/hippo/internal/service"
 	mock_datetime "github.com/go-seidon/provider/datetime/mock"
 	mock_hashing "github.com/go-seidon/provider/hashing/mock"
 	mock_identifier "github.com/go-seidon/provider/identity/mock"
+	mock_random "github.com/go-seidon/provider/random/mock"
 	"github.com/go-seidon/provider/system"
 	mock_validation "github.com/go-seidon/provider/validation/mock"
 	"github.com/golang/mock/gomock"
@@ -65,6 +67,7 @@ var _ = Describe("Auth Client Package", func() {
 				Name:         p.Name,
 				Type:         p.Type,
 				Status:       p.Status,
+				Scopes:       []string{"file:read", "file:write", "file:delete"},
 				CreatedAt:    currentTs,
 			}
 			createRes = &repository.CreateClientResult{
@@ -268,6 +271,48 @@ var _ = Describe("Auth Client Package", func() {
 				Expect(err).To(BeNil())
 			})
 		})
+
+		When("scopes is specified", func() {
+			It("should create client with the scopes", func() {
+				p.Scopes = []string{"client:admin"}
+				createParam.Scopes = []string{"client:admin"}
+
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				identifier.
+					EXPECT().
+					GenerateId().
+					Return("id", nil).
+					Times(1)
+
+				hasher.
+					EXPECT().
+					Generate(gomock.Eq(p.ClientSecret)).
+					Return([]byte("secret"), nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				authRepo.
+					EXPECT().
+					CreateClient(gomock.Eq(ctx), gomock.Eq(createParam)).
+					Return(createRes, nil).
+					Times(1)
+
+				res, err := authClient.CreateClient(ctx, p)
+
+				Expect(res.Success.Code).To(Equal(int32(1000)))
+				Expect(err).To(BeNil())
+			})
+		})
 	})
 
 	Context("FindClientById function", Label("unit"), func() {
@@ -318,6 +363,7 @@ var _ = Describe("Auth Client Package", func() {
 				Name:         "name",
 				Type:         "basic",
 				Status:       "active",
+				Scopes:       []string{"file:read"},
 				CreatedAt:    currentTs,
 			}
 			result = &service.FindClientByIdResult{
@@ -330,6 +376,7 @@ var _ = Describe("Auth Client Package", func() {
 				Name:      findRes.Name,
 				Type:      findRes.Type,
 				Status:    findRes.Status,
+				Scopes:    findRes.Scopes,
 				CreatedAt: findRes.CreatedAt,
 				UpdatedAt: findRes.UpdatedAt,
 			}
@@ -409,8 +456,132 @@ var _ = Describe("Auth Client Package", func() {
 					Return(findRes, nil).
 					Times(1)
 
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				res, err := authClient.FindClientById(ctx, param)
+
+				Expect(res).To(Equal(result))
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("client has quota", func() {
+			It("should return result", func() {
+				findRes.Quota = repository.ClientQuota{
+					MaxStoredSize:   1000,
+					MaxTotalFiles:   10,
+					MaxFileSize:     500,
+					MaxDailyUploads: 5,
+				}
+				findRes.Usage = repository.ClientUsage{
+					StoredSize:   300,
+					TotalFiles:   3,
+					DailyUploads: 2,
+					DailyAt:      currentTs.Add(-24 * time.Hour).Truncate(24 * time.Hour),
+				}
+
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(param)).
+					Return(nil).
+					Times(1)
+
+				authRepo.
+					EXPECT().
+					FindClient(gomock.Eq(ctx), gomock.Eq(findParam)).
+					Return(findRes, nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				res, err := authClient.FindClientById(ctx, param)
+
+				result.Quota = service.ClientQuota{
+					MaxStoredSize:   1000,
+					MaxTotalFiles:   10,
+					MaxFileSize:     500,
+					MaxDailyUploads: 5,
+				}
+				result.Usage = service.ClientUsage{
+					StoredSize: 300,
+					TotalFiles: 3,
+				}
+				Expect(res).To(Equal(result))
+				Expect(err).To(BeNil())
+			})
+		})
+		When("client secret is rotated", func() {
+			It("should return result", func() {
+				rotatedAt := currentTs.Add(-1 * time.Hour)
+				expiresAt := currentTs.Add(1 * time.Hour)
+				findRes.PreviousClientSecret = "previous-secret"
+				findRes.PreviousSecretExpiresAt = &expiresAt
+				findRes.SecretRotatedAt = &rotatedAt
+
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(param)).
+					Return(nil).
+					Times(1)
+
+				authRepo.
+					EXPECT().
+					FindClient(gomock.Eq(ctx), gomock.Eq(findParam)).
+					Return(findRes, nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				res, err := authClient.FindClientById(ctx, param)
+
+				result.SecretRotatedAt = &rotatedAt
+				result.PreviousSecretExpiresAt = &expiresAt
+				Expect(res).To(Equal(result))
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("previous client secret is expired", func() {
+			It("should not return previous secret expiry", func() {
+				rotatedAt := currentTs.Add(-2 * time.Hour)
+				expiresAt := currentTs.Add(-1 * time.Hour)
+				findRes.PreviousClientSecret = "previous-secret"
+				findRes.PreviousSecretExpiresAt = &expiresAt
+				findRes.SecretRotatedAt = &rotatedAt
+
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(param)).
+					Return(nil).
+					Times(1)
+
+				authRepo.
+					EXPECT().
+					FindClient(gomock.Eq(ctx), gomock.Eq(findParam)).
+					Return(findRes, nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
 				res, err := authClient.FindClientById(ctx, param)
 
+				result.SecretRotatedAt = &rotatedAt
 				Expect(res).To(Equal(result))
 				Expect(err).To(BeNil())
 			})
@@ -580,6 +751,112 @@ var _ = Describe("Auth Client Package", func() {
 				Expect(err).To(BeNil())
 			})
 		})
+
+		When("quota is specified", func() {
+			It("should update the quota", func() {
+				p.Quota = &service.ClientQuota{
+					MaxStoredSize: 1000,
+					MaxFileSize:   500,
+				}
+				updateParam.Quota = &repository.ClientQuota{
+					MaxStoredSize: 1000,
+					MaxFileSize:   500,
+				}
+
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				authRepo.
+					EXPECT().
+					UpdateClient(gomock.Eq(ctx), gomock.Eq(updateParam)).
+					Return(updateRes, nil).
+					Times(1)
+
+				res, err := authClient.UpdateClientById(ctx, p)
+
+				Expect(res.Success.Code).To(Equal(int32(1000)))
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("scopes is specified", func() {
+			It("should update the scopes", func() {
+				p.Scopes = []string{"file:read", "client:admin"}
+				updateParam.Scopes = []string{"file:read", "client:admin"}
+
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				authRepo.
+					EXPECT().
+					UpdateClient(gomock.Eq(ctx), gomock.Eq(updateParam)).
+					Return(updateRes, nil).
+					Times(1)
+
+				res, err := authClient.UpdateClientById(ctx, p)
+
+				Expect(res.Success.Code).To(Equal(int32(1000)))
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("verification cache is specified", func() {
+			It("should invalidate client credential", func() {
+				cache := mock_auth.NewMockVerificationCache(gomock.NewController(GinkgoT()))
+				authClient = service.NewAuthClient(service.AuthClientParam{
+					Validator: validator,
+					Clock:     clock,
+					AuthRepo:  authRepo,
+					Cache:     cache,
+				})
+
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				authRepo.
+					EXPECT().
+					UpdateClient(gomock.Eq(ctx), gomock.Eq(updateParam)).
+					Return(updateRes, nil).
+					Times(1)
+
+				cache.
+					EXPECT().
+					Invalidate(gomock.Eq("id")).
+					Times(1)
+
+				res, err := authClient.UpdateClientById(ctx, p)
+
+				Expect(res.Success.Code).To(Equal(int32(1000)))
+				Expect(err).To(BeNil())
+			})
+		})
 	})
 
 	Context("SearchClient function", Label("unit"), func() {
@@ -790,4 +1067,690 @@ var _ = Describe("Auth Client Package", func() {
 		})
 	})
 
+	Context("RotateClientSecret function", Label("unit"), func() {
+		var (
+			ctx         context.Context
+			currentTs   time.Time
+			authClient  service.AuthClient
+			p           service.RotateClientSecretParam
+			validator   *mock_validation.MockValidator
+			identifier  *mock_identifier.MockIdentifier
+			hasher      *mock_hashing.MockHasher
+			clock       *mock_datetime.MockClock
+			randomizer  *mock_random.MockRandomizer
+			authRepo    *mock_repository.MockAuth
+			rotateParam repository.RotateClientSecretParam
+			rotateRes   *repository.RotateClientSecretResult
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			currentTs = time.Now().UTC()
+			t := GinkgoT()
+			ctrl := gomock.NewController(t)
+			validator = mock_validation.NewMockValidator(ctrl)
+			identifier = mock_identifier.NewMockIdentifier(ctrl)
+			hasher = mock_hashing.NewMockHasher(ctrl)
+			clock = mock_datetime.NewMockClock(ctrl)
+			randomizer = mock_random.NewMockRandomizer(ctrl)
+			authRepo = mock_repository.NewMockAuth(ctrl)
+			authClient = service.NewAuthClient(service.AuthClientParam{
+				Validator:  validator,
+				Hasher:     hasher,
+				Identifier: identifier,
+				Clock:      clock,
+				Randomizer: randomizer,
+				AuthRepo:   authRepo,
+				Config: &service.AuthClientConfig{
+					SecretGracePeriod: 24 * time.Hour,
+				},
+			})
+			p = service.RotateClientSecretParam{
+				Id:           "id",
+				ClientSecret: "new-secret",
+			}
+			rotateParam = repository.RotateClientSecretParam{
+				Id:                      "id",
+				ClientSecret:            "hashed-secret",
+				PreviousSecretExpiresAt: currentTs.Add(24 * time.Hour),
+				RotatedAt:               currentTs,
+			}
+			rotateRes = &repository.RotateClientSecretResult{
+				Id:                      "id",
+				ClientId:                "client-id",
+				PreviousSecretExpiresAt: currentTs.Add(24 * time.Hour),
+				SecretRotatedAt:         currentTs,
+			}
+		})
+
+		When("there is invalid data", func() {
+			It("should return error", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(fmt.Errorf("invalid data")).
+					Times(1)
+
+				res, err := authClient.RotateClientSecret(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err.Code).To(Equal(int32(1002)))
+				Expect(err.Message).To(Equal("invalid data"))
+			})
+		})
+
+		When("failed generate secret", func() {
+			It("should return error", func() {
+				p.ClientSecret = ""
+
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				randomizer.
+					EXPECT().
+					String(gomock.Eq(40)).
+					Return("", fmt.Errorf("random error")).
+					Times(1)
+
+				res, err := authClient.RotateClientSecret(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err.Code).To(Equal(int32(1001)))
+				Expect(err.Message).To(Equal("random error"))
+			})
+		})
+
+		When("failed hash secret", func() {
+			It("should return error", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				hasher.
+					EXPECT().
+					Generate(gomock.Eq("new-secret")).
+					Return(nil, fmt.Errorf("hash error")).
+					Times(1)
+
+				res, err := authClient.RotateClientSecret(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err.Code).To(Equal(int32(1001)))
+				Expect(err.Message).To(Equal("hash error"))
+			})
+		})
+
+		When("failed rotate secret", func() {
+			It("should return error", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				hasher.
+					EXPECT().
+					Generate(gomock.Eq("new-secret")).
+					Return([]byte("hashed-secret"), nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				authRepo.
+					EXPECT().
+					RotateClientSecret(gomock.Eq(ctx), gomock.Eq(rotateParam)).
+					Return(nil, fmt.Errorf("network error")).
+					Times(1)
+
+				res, err := authClient.RotateClientSecret(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err.Code).To(Equal(int32(1001)))
+				Expect(err.Message).To(Equal("network error"))
+			})
+		})
+
+		When("client is not available", func() {
+			It("should return error", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				hasher.
+					EXPECT().
+					Generate(gomock.Eq("new-secret")).
+					Return([]byte("hashed-secret"), nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				authRepo.
+					EXPECT().
+					RotateClientSecret(gomock.Eq(ctx), gomock.Eq(rotateParam)).
+					Return(nil, repository.ErrNotFound).
+					Times(1)
+
+				res, err := authClient.RotateClientSecret(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err.Code).To(Equal(int32(1004)))
+				Expect(err.Message).To(Equal("auth client is not available"))
+			})
+		})
+
+		When("success rotate secret", func() {
+			It("should return result", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				hasher.
+					EXPECT().
+					Generate(gomock.Eq("new-secret")).
+					Return([]byte("hashed-secret"), nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				authRepo.
+					EXPECT().
+					RotateClientSecret(gomock.Eq(ctx), gomock.Eq(rotateParam)).
+					Return(rotateRes, nil).
+					Times(1)
+
+				res, err := authClient.RotateClientSecret(ctx, p)
+
+				Expect(res.Success.Code).To(Equal(int32(1000)))
+				Expect(res.Success.Message).To(Equal("success rotate auth client secret"))
+				Expect(res.Id).To(Equal("id"))
+				Expect(res.ClientId).To(Equal("client-id"))
+				Expect(res.ClientSecret).To(Equal("new-secret"))
+				Expect(res.SecretRotatedAt).To(Equal(currentTs))
+				Expect(res.PreviousSecretExpiresAt).To(Equal(currentTs.Add(24 * time.Hour)))
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("secret is generated", func() {
+			It("should return generated secret", func() {
+				p.ClientSecret = ""
+
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				randomizer.
+					EXPECT().
+					String(gomock.Eq(40)).
+					Return("generated-secret", nil).
+					Times(1)
+
+				hasher.
+					EXPECT().
+					Generate(gomock.Eq("generated-secret")).
+					Return([]byte("hashed-secret"), nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				authRepo.
+					EXPECT().
+					RotateClientSecret(gomock.Eq(ctx), gomock.Eq(rotateParam)).
+					Return(rotateRes, nil).
+					Times(1)
+
+				res, err := authClient.RotateClientSecret(ctx, p)
+
+				Expect(res.Success.Code).To(Equal(int32(1000)))
+				Expect(res.ClientSecret).To(Equal("generated-secret"))
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("verification cache is specified", func() {
+			It("should invalidate client credential", func() {
+				cache := mock_auth.NewMockVerificationCache(gomock.NewController(GinkgoT()))
+				authClient = service.NewAuthClient(service.AuthClientParam{
+					Validator: validator,
+					Hasher:    hasher,
+					Clock:     clock,
+					AuthRepo:  authRepo,
+					Cache:     cache,
+					Config: &service.AuthClientConfig{
+						SecretGracePeriod: 24 * time.Hour,
+					},
+				})
+
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				hasher.
+					EXPECT().
+					Generate(gomock.Eq("new-secret")).
+					Return([]byte("hashed-secret"), nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				authRepo.
+					EXPECT().
+					RotateClientSecret(gomock.Eq(ctx), gomock.Eq(rotateParam)).
+					Return(rotateRes, nil).
+					Times(1)
+
+				cache.
+					EXPECT().
+					Invalidate(gomock.Eq("id")).
+					Times(1)
+
+				res, err := authClient.RotateClientSecret(ctx, p)
+
+				Expect(res.Success.Code).To(Equal(int32(1000)))
+				Expect(err).To(BeNil())
+			})
+		})
+	})
+
+	Context("RevokeClientSecret function", Label("unit"), func() {
+		var (
+			ctx         context.Context
+			currentTs   time.Time
+			authClient  service.AuthClient
+			p           service.RevokeClientSecretParam
+			validator   *mock_validation.MockValidator
+			clock       *mock_datetime.MockClock
+			authRepo    *mock_repository.MockAuth
+			revokeParam repository.RevokeClientSecretParam
+			revokeRes   *repository.RevokeClientSecretResult
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			currentTs = time.Now().UTC()
+			t := GinkgoT()
+			ctrl := gomock.NewController(t)
+			validator = mock_validation.NewMockValidator(ctrl)
+			clock = mock_datetime.NewMockClock(ctrl)
+			authRepo = mock_repository.NewMockAuth(ctrl)
+			authClient = service.NewAuthClient(service.AuthClientParam{
+				Validator: validator,
+				Clock:     clock,
+				AuthRepo:  authRepo,
+			})
+			p = service.RevokeClientSecretParam{
+				Id: "id",
+			}
+			revokeParam = repository.RevokeClientSecretParam{
+				Id:        "id",
+				RevokedAt: currentTs,
+			}
+			revokeRes = &repository.RevokeClientSecretResult{
+				Id:        "id",
+				ClientId:  "client-id",
+				RevokedAt: currentTs,
+			}
+		})
+
+		When("there is invalid data", func() {
+			It("should return error", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(fmt.Errorf("invalid data")).
+					Times(1)
+
+				res, err := authClient.RevokeClientSecret(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err.Code).To(Equal(int32(1002)))
+				Expect(err.Message).To(Equal("invalid data"))
+			})
+		})
+
+		When("failed revoke secret", func() {
+			It("should return error", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				authRepo.
+					EXPECT().
+					RevokeClientSecret(gomock.Eq(ctx), gomock.Eq(revokeParam)).
+					Return(nil, fmt.Errorf("network error")).
+					Times(1)
+
+				res, err := authClient.RevokeClientSecret(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err.Code).To(Equal(int32(1001)))
+				Expect(err.Message).To(Equal("network error"))
+			})
+		})
+
+		When("client is not available", func() {
+			It("should return error", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				authRepo.
+					EXPECT().
+					RevokeClientSecret(gomock.Eq(ctx), gomock.Eq(revokeParam)).
+					Return(nil, repository.ErrNotFound).
+					Times(1)
+
+				res, err := authClient.RevokeClientSecret(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err.Code).To(Equal(int32(1004)))
+				Expect(err.Message).To(Equal("auth client is not available"))
+			})
+		})
+
+		When("success revoke secret", func() {
+			It("should return result", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				authRepo.
+					EXPECT().
+					RevokeClientSecret(gomock.Eq(ctx), gomock.Eq(revokeParam)).
+					Return(revokeRes, nil).
+					Times(1)
+
+				res, err := authClient.RevokeClientSecret(ctx, p)
+
+				Expect(res.Success.Code).To(Equal(int32(1000)))
+				Expect(res.Success.Message).To(Equal("success revoke auth client secret"))
+				Expect(res.Id).To(Equal("id"))
+				Expect(res.ClientId).To(Equal("client-id"))
+				Expect(res.RevokedAt).To(Equal(currentTs))
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("verification cache is specified", func() {
+			It("should invalidate client credential", func() {
+				cache := mock_auth.NewMockVerificationCache(gomock.NewController(GinkgoT()))
+				authClient = service.NewAuthClient(service.AuthClientParam{
+					Validator: validator,
+					Clock:     clock,
+					AuthRepo:  authRepo,
+					Cache:     cache,
+				})
+
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				authRepo.
+					EXPECT().
+					RevokeClientSecret(gomock.Eq(ctx), gomock.Eq(revokeParam)).
+					Return(revokeRes, nil).
+					Times(1)
+
+				cache.
+					EXPECT().
+					Invalidate(gomock.Eq("id")).
+					Times(1)
+
+				res, err := authClient.RevokeClientSecret(ctx, p)
+
+				Expect(res.Success.Code).To(Equal(int32(1000)))
+				Expect(err).To(BeNil())
+			})
+		})
+	})
+
+	Context("DeleteClientById function", Label("unit"), func() {
+		var (
+			ctx         context.Context
+			currentTs   time.Time
+			authClient  service.AuthClient
+			p           service.DeleteClientByIdParam
+			validator   *mock_validation.MockValidator
+			clock       *mock_datetime.MockClock
+			authRepo    *mock_repository.MockAuth
+			deleteParam repository.DeleteClientParam
+			deleteRes   *repository.DeleteClientResult
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			currentTs = time.Now().UTC()
+			t := GinkgoT()
+			ctrl := gomock.NewController(t)
+			validator = mock_validation.NewMockValidator(ctrl)
+			clock = mock_datetime.NewMockClock(ctrl)
+			authRepo = mock_repository.NewMockAuth(ctrl)
+			authClient = service.NewAuthClient(service.AuthClientParam{
+				Validator: validator,
+				Clock:     clock,
+				AuthRepo:  authRepo,
+			})
+			p = service.DeleteClientByIdParam{
+				Id: "id",
+			}
+			deleteParam = repository.DeleteClientParam{
+				Id:        "id",
+				DeletedAt: currentTs,
+			}
+			deleteRes = &repository.DeleteClientResult{
+				Id:        "id",
+				ClientId:  "client-id",
+				DeletedAt: currentTs,
+			}
+		})
+
+		When("there is invalid data", func() {
+			It("should return error", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(fmt.Errorf("invalid data")).
+					Times(1)
+
+				res, err := authClient.DeleteClientById(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err.Code).To(Equal(int32(1002)))
+				Expect(err.Message).To(Equal("invalid data"))
+			})
+		})
+
+		When("failed delete client", func() {
+			It("should return error", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				authRepo.
+					EXPECT().
+					DeleteClient(gomock.Eq(ctx), gomock.Eq(deleteParam)).
+					Return(nil, fmt.Errorf("network error")).
+					Times(1)
+
+				res, err := authClient.DeleteClientById(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err.Code).To(Equal(int32(1001)))
+				Expect(err.Message).To(Equal("network error"))
+			})
+		})
+
+		When("client is not available", func() {
+			It("should return error", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				authRepo.
+					EXPECT().
+					DeleteClient(gomock.Eq(ctx), gomock.Eq(deleteParam)).
+					Return(nil, repository.ErrNotFound).
+					Times(1)
+
+				res, err := authClient.DeleteClientById(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err.Code).To(Equal(int32(1004)))
+				Expect(err.Message).To(Equal("auth client is not available"))
+			})
+		})
+
+		When("success delete client", func() {
+			It("should return result", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				authRepo.
+					EXPECT().
+					DeleteClient(gomock.Eq(ctx), gomock.Eq(deleteParam)).
+					Return(deleteRes, nil).
+					Times(1)
+
+				res, err := authClient.DeleteClientById(ctx, p)
+
+				Expect(res.Success.Code).To(Equal(int32(1000)))
+				Expect(res.Success.Message).To(Equal("success delete auth client"))
+				Expect(res.Id).To(Equal("id"))
+				Expect(res.ClientId).To(Equal("client-id"))
+				Expect(res.DeletedAt).To(Equal(currentTs))
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("verification cache is specified", func() {
+			It("should invalidate client credential", func() {
+				cache := mock_auth.NewMockVerificationCache(gomock.NewController(GinkgoT()))
+				authClient = service.NewAuthClient(service.AuthClientParam{
+					Validator: validator,
+					Clock:     clock,
+					AuthRepo:  authRepo,
+					Cache:     cache,
+				})
+
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				authRepo.
+					EXPECT().
+					DeleteClient(gomock.Eq(ctx), gomock.Eq(deleteParam)).
+					Return(deleteRes, nil).
+					Times(1)
+
+				cache.
+					EXPECT().
+					Invalidate(gomock.Eq("id")).
+					Times(1)
+
+				res, err := authClient.DeleteClientById(ctx, p)
+
+				Expect(res.Success.Code).To(Equal(int32(1000)))
+				Expect(err).To(BeNil())
+			})
+		})
+	})
+
 })
@@ -1,17 +1,21 @@
 package service
 
 import (
-	"bytes"
+	"bufio"
 	"context"
 	"errors"
 	"fmt"
 	"io"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/go-seidon/hippo/internal/auth"
 	"github.com/go-seidon/hippo/internal/file"
 	"github.com/go-seidon/hippo/internal/filesystem"
 	"github.com/go-seidon/hippo/internal/repository"
+	"github.com/go-seidon/hippo/internal/scanner"
 	"github.com/go-seidon/provider/datetime"
 	"github.com/go-seidon/provider/identity"
 	"github.com/go-seidon/provider/logging"
@@ -24,6 +28,8 @@ type File interface {
 	UploadFile(ctx context.Context, opts ...UploadFileOption) (*UploadFileResult, *system.Error)
 	RetrieveFile(ctx context.Context, p RetrieveFileParam) (*RetrieveFileResult, *system.Error)
 	DeleteFile(ctx context.Context, p DeleteFileParam) (*DeleteFileResult, *system.Error)
+	RestoreFile(ctx context.Context, p RestoreFileParam) (*RestoreFileResult, *system.Error)
+	SearchFile(ctx context.Context, p SearchFileParam) (*SearchFileResult, *system.Error)
 }
 
 type UploadFileOption = func(*UploadFileParam)
@@ -43,12 +49,22 @@ func WithFileInfo(name, mimetype, extension string, size int64) UploadFileOption
 	}
 }
 
+// @note: client and bucket are only used to determine the upload location
+func WithLocation(clientId, bucket string) UploadFileOption {
+	return func(p *UploadFileParam) {
+		p.clientId = clientId
+		p.bucket = bucket
+	}
+}
+
 type UploadFileParam struct {
 	fileReader    io.Reader
 	fileName      string `validate:"max=4096" label:"name"`
 	fileMimetype  string `validate:"max=256" label:"mimetype"`
 	fileExtension string `validate:"max=128" label:"extension"`
 	fileSize      int64  `validate:"min=0" label:"size"`
+	clientId      string `validate:"max=128" label:"client_id"`
+	bucket        string `validate:"max=64" label:"bucket"`
 }
 
 type UploadFileResult struct {
@@ -60,21 +76,48 @@ type UploadFileResult struct {
 	Extension  string
 	Size       int64
 	UploadedAt time.Time
+	Checksum   file.Checksum
 }
 
+// @note: file data is read from the offset until the end when length is not specified,
+// data is not opened when only the metadata is requested
+// and compressed data is served as it is when its encoding is accepted (except for the ranged data)
 type RetrieveFileParam struct {
-	FileId string `validate:"required,min=5,max=64" label:"file_id"`
+	FileId          string `validate:"required,min=5,max=64" label:"file_id"`
+	VerifyChecksum  bool
+	Offset          int64 `validate:"min=0" label:"offset"`
+	Length          int64 `validate:"min=0" label:"length"`
+	MetadataOnly    bool
+	AcceptEncodings []string
+	// @note: evaluated using the file metadata before the data is opened
+	// so the request can be resolved without retrieving the file twice
+	Select func(meta *RetrieveFileResult) (*RetrieveFileSelection, *system.Error)
+}
+
+type RetrieveFileSelection struct {
+	// @note: data is not opened when skipped (e.g: file is not modified)
+	Skip   bool
+	Offset int64
+	Length int64
 }
 
 type RetrieveFileResult struct {
-	Success   system.Success
-	Data      io.ReadCloser
-	UniqueId  string
-	Name      string
-	Path      string
-	MimeType  string
-	Extension string
-	Size      int64
+	Success    system.Success
+	Data       io.ReadCloser
+	UniqueId   string
+	Name       string
+	Path       string
+	MimeType   string
+	Extension  string
+	Size       int64
+	Checksum   file.Checksum
+	UploadedAt time.Time
+	Offset     int64
+	Length     int64
+	// @note: compression of the stored data, content encoding is only set
+	// when the data is served compressed
+	Compression     string
+	ContentEncoding string
 }
 
 type DeleteFileParam struct {
@@ -86,16 +129,66 @@ type DeleteFileResult struct {
 	DeletedAt time.Time
 }
 
+type RestoreFileParam struct {
+	FileId string `validate:"required,min=5,max=64" label:"file_id"`
+}
+
+type RestoreFileResult struct {
+	Success    system.Success
+	RestoredAt time.Time
+}
+
+type SearchFileParam struct {
+	Keyword      string   `validate:"omitempty,printascii,min=2,max=64" label:"keyword"`
+	TotalItems   int32    `validate:"numeric,min=1,max=100" label:"total_items"`
+	Page         int64    `validate:"numeric,min=1" label:"page"`
+	Mimetypes    []string `validate:"unique,max=20,dive,printascii,min=3,max=128" label:"mimetypes"`
+	Extensions   []string `validate:"unique,max=20,dive,printascii,min=1,max=32" label:"extensions"`
+	MinSize      *int64   `validate:"omitempty,min=0" label:"min_size"`
+	MaxSize      *int64   `validate:"omitempty,min=0" label:"max_size"`
+	UploadedFrom *time.Time
+	UploadedTo   *time.Time
+	Statuses     []string `validate:"unique,min=0,max=2,dive,oneof='available' 'deleted'" label:"statuses"`
+	SortBy       string   `validate:"omitempty,oneof='name' 'size' 'uploaded_at'" label:"sort_by"`
+	SortOrder    string   `validate:"omitempty,oneof='asc' 'desc'" label:"sort_order"`
+}
+
+type SearchFileResult struct {
+	Success system.Success
+	Items   []SearchFileItem
+	Summary SearchFileSummary
+}
+
+type SearchFileItem struct {
+	UniqueId   string
+	Name       string
+	Path       string
+	MimeType   string
+	Extension  string
+	Size       int64
+	Checksum   file.Checksum
+	UploadedAt time.Time
+	DeletedAt  *time.Time
+}
+
+type SearchFileSummary struct {
+	TotalItems int64
+	Page       int64
+}
+
 var _ File = (*fileService)(nil)
 
 type fileService struct {
 	fileRepo    repository.File
+	authRepo    repository.Auth
 	fileManager filesystem.FileManager
 	dirManager  filesystem.DirectoryManager
 	identifier  identity.Identifier
 	clock       datetime.Clock
 	log         logging.Logger
 	locator     file.UploadLocation
+	policy      file.UploadPolicy
+	scanner     scanner.Scanner
 	validator   validation.Validator
 	config      *FileConfig
 }
@@ -128,6 +221,13 @@ func (s *fileService) RetrieveFile(ctx context.Context, p RetrieveFileParam) (*R
 		}
 	}
 
+	if !s.canAccess(ctx, retrieve.OwnerClientId) {
+		return nil, &system.Error{
+			Code:    status.ACTION_FORBIDDEN,
+			Message: "file is not accessible",
+		}
+	}
+
 	if retrieve.DeletedAt != nil {
 		return nil, &system.Error{
 			Code:    status.RESOURCE_NOTFOUND,
@@ -135,8 +235,83 @@ func (s *fileService) RetrieveFile(ctx context.Context, p RetrieveFileParam) (*R
 		}
 	}
 
+	res := &RetrieveFileResult{
+		Success: system.Success{
+			Code:    status.ACTION_SUCCESS,
+			Message: "success retrieve file",
+		},
+		UniqueId:  retrieve.UniqueId,
+		Name:      retrieve.Name,
+		Path:      retrieve.Path,
+		MimeType:  retrieve.Mimetype,
+		Extension: retrieve.Extension,
+		Size:      retrieve.Size,
+		Checksum: file.Checksum{
+			Sha256: retrieve.ChecksumSha256,
+			Md5:    retrieve.ChecksumMd5,
+		},
+		UploadedAt:  retrieve.CreatedAt,
+		Length:      retrieve.Size,
+		Compression: retrieve.Compression,
+	}
+	if retrieve.Compression != "" && acceptEncoding(p.AcceptEncodings, retrieve.Compression) {
+		res.ContentEncoding = retrieve.Compression
+	}
+
+	offset, length := p.Offset, p.Length
+	if p.Select != nil {
+		selection, serr := p.Select(res)
+		if serr != nil {
+			return nil, serr
+		}
+		if selection != nil {
+			if selection.Skip {
+				return res, nil
+			}
+			offset, length = selection.Offset, selection.Length
+		}
+	}
+
+	if offset > 0 || length > 0 {
+		if length == 0 {
+			length = retrieve.Size - offset
+		}
+		if offset >= retrieve.Size || offset+length > retrieve.Size {
+			return nil, &system.Error{
+				Code:    FILE_RANGE_INVALID,
+				Message: "file range is not satisfiable",
+			}
+		}
+		// @note: ranged data is always served as is
+		res.Offset = offset
+		res.Length = length
+		res.ContentEncoding = ""
+	}
+	if p.MetadataOnly {
+		return res, nil
+	}
+
+	key := encryptionKey(retrieve.EncryptionKeyId, retrieve.EncryptionDataKey)
+
+	// @note: file uploaded before checksum is introduced has no stored checksum to verify against
+	if p.VerifyChecksum && retrieve.ChecksumSha256 != "" {
+		serr := s.verifyChecksum(ctx, retrieve.Path, retrieve.ChecksumSha256, key, retrieve.Compression)
+		if serr != nil {
+			return nil, serr
+		}
+	}
+
+	compression := retrieve.Compression
+	if res.ContentEncoding != "" {
+		compression = ""
+	}
+
 	open, err := s.fileManager.OpenFile(ctx, filesystem.OpenFileParam{
-		Path: retrieve.Path,
+		Path:          retrieve.Path,
+		Offset:        offset,
+		Length:        length,
+		EncryptionKey: key,
+		Compression:   compression,
 	})
 	if err != nil {
 		if errors.Is(err, filesystem.ErrorFileNotFound) {
@@ -151,22 +326,47 @@ func (s *fileService) RetrieveFile(ctx context.Context, p RetrieveFileParam) (*R
 		}
 	}
 
-	res := &RetrieveFileResult{
-		Success: system.Success{
-			Code:    status.ACTION_SUCCESS,
-			Message: "success retrieve file",
-		},
-		Data:      open.File,
-		UniqueId:  retrieve.UniqueId,
-		Name:      retrieve.Name,
-		Path:      retrieve.Path,
-		MimeType:  retrieve.Mimetype,
-		Extension: retrieve.Extension,
-		Size:      retrieve.Size,
-	}
+	res.Data = open.File
 	return res, nil
 }
 
+func (s *fileService) verifyChecksum(ctx context.Context, path, checksum string, key *filesystem.EncryptionKey, compression string) *system.Error {
+	open, err := s.fileManager.OpenFile(ctx, filesystem.OpenFileParam{
+		Path:          path,
+		EncryptionKey: key,
+		Compression:   compression,
+	})
+	if err != nil {
+		if errors.Is(err, filesystem.ErrorFileNotFound) {
+			return &system.Error{
+				Code:    status.RESOURCE_NOTFOUND,
+				Message: "file is not found",
+			}
+		}
+		return &system.Error{
+			Code:    status.ACTION_FAILED,
+			Message: err.Error(),
+		}
+	}
+	defer open.File.Close()
+
+	current, err := file.ComputeChecksum(open.File)
+	if err != nil {
+		return &system.Error{
+			Code:    status.ACTION_FAILED,
+			Message: err.Error(),
+		}
+	}
+
+	if current.Sha256 != checksum {
+		return &system.Error{
+			Code:    status.ACTION_FAILED,
+			Message: "file checksum mismatch",
+		}
+	}
+	return nil
+}
+
 func (s *fileService) UploadFile(ctx context.Context, opts ...UploadFileOption) (*UploadFileResult, *system.Error) {
 	s.log.Debug("In function: UploadFile")
 	defer s.log.Debug("Returning function: UploadFile")
@@ -191,7 +391,71 @@ func (s *fileService) UploadFile(ctx context.Context, opts ...UploadFileOption)
 		}
 	}
 
-	uploadDir := fmt.Sprintf("%s/%s", s.config.UploadDir, s.locator.GetLocation())
+	// @note: the mimetype detected from the content is only used to check the policy,
+	// the declared one is kept and stored, policy is not checked when it's not specified
+	if s.policy != nil {
+		reader := bufio.NewReaderSize(p.fileReader, file.SNIFF_SIZE)
+		header, err := reader.Peek(file.SNIFF_SIZE)
+		if err != nil && err != io.EOF {
+			return nil, &system.Error{
+				Code:    status.ACTION_FAILED,
+				Message: err.Error(),
+			}
+		}
+		p.fileReader = reader
+
+		_, err = s.policy.CheckFile(file.CheckFileParam{
+			Header:    header,
+			Mimetype:  p.fileMimetype,
+			Extension: p.fileExtension,
+		})
+		if err != nil {
+			return nil, &system.Error{
+				Code:    FILE_NOT_ALLOWED,
+				Message: err.Error(),
+			}
+		}
+	}
+
+	// @note: file is owned by the authenticated client (recorded using its record id),
+	// the client id is also used for the location when it's not specified
+	ownerClientId := ""
+	identity := auth.IdentityFromContext(ctx)
+	if identity != nil {
+		ownerClientId = identity.Id
+		if p.clientId == "" {
+			p.clientId = identity.ClientId
+		}
+	}
+
+	// @note: quota is only checked for the known client,
+	// the declared size is checked upfront while the actual size is checked when the usage is reserved
+	quota, qErr := s.checkQuota(ctx, ownerClientId, p)
+	if qErr != nil {
+		return nil, qErr
+	}
+	if quota != nil && quota.MaxFileSize > 0 {
+		p.fileReader = &sizeLimitReader{
+			reader:    p.fileReader,
+			remaining: quota.MaxFileSize,
+		}
+	}
+
+	uniqueId, err := s.identifier.GenerateId()
+	if err != nil {
+		return nil, &system.Error{
+			Code:    status.ACTION_FAILED,
+			Message: err.Error(),
+		}
+	}
+
+	location := s.locator.GetLocation(file.GetLocationParam{
+		UniqueId: uniqueId,
+		ClientId: p.clientId,
+		Bucket:   p.bucket,
+		Mimetype: p.fileMimetype,
+	})
+	uploadDir := fmt.Sprintf("%s/%s", s.config.UploadDir, location)
 
 	exists, err := s.dirManager.IsDirectoryExists(ctx, filesystem.IsDirectoryExistsParam{
 		Path: uploadDir,
@@ -216,44 +480,69 @@ func (s *fileService) UploadFile(ctx context.Context, opts ...UploadFileOption)
 		}
 	}
 
-	data := bytes.NewBuffer([]byte{})
-	_, err = io.Copy(data, p.fileReader)
-	if err != nil {
-		return nil, &system.Error{
-			Code:    status.ACTION_FAILED,
-			Message: err.Error(),
-		}
+	path := fmt.Sprintf("%s/%s", uploadDir, uniqueId)
+	if p.fileExtension != "" {
+		path = fmt.Sprintf("%s.%s", path, p.fileExtension)
 	}
 
-	uniqueId, err := s.identifier.GenerateId()
-	if err != nil {
-		return nil, &system.Error{
-			Code:    status.ACTION_FAILED,
-			Message: err.Error(),
+	// @note: file is saved and scanned before the record transaction is started
+	// so the transaction is not held while the content is scanned
+	createFn := NewCreateFn(p.fileReader, p.fileMimetype, s.fileManager, s.config.ChecksumMd5)
+	if s.scanner != nil {
+		saved, err := NewScanFn(ScanFnParam{
+			Reader:        p.fileReader,
+			Scanner:       s.scanner,
+			FileManager:   s.fileManager,
+			Logger:        s.log,
+			FailOpen:      s.config.ScanFailOpen,
+			SkipOversized: s.config.ScanSkipOversized,
+			CreateFn: func(reader io.Reader) repository.CreateFn {
+				return NewCreateFn(reader, p.fileMimetype, s.fileManager, s.config.ChecksumMd5)
+			},
+		})(ctx, repository.CreateFnParam{
+			FilePath: path,
+		})
+		if err != nil {
+			return nil, newUploadFileError(err)
 		}
+		createFn = NewSavedFn(saved)
 	}
 
-	path := fmt.Sprintf("%s/%s", uploadDir, uniqueId)
-	if p.fileExtension != "" {
-		path = fmt.Sprintf("%s.%s", path, p.fileExtension)
+	currentTs := s.clock.Now()
+	reservation := &QuotaReservation{}
+	if quota != nil {
+		createFn = NewQuotaFn(QuotaFnParam{
+			AuthRepo:      s.authRepo,
+			OwnerClientId: ownerClientId,
+			UsedAt:        currentTs,
+			FileManager:   s.fileManager,
+			Logger:        s.log,
+			CreateFn:      createFn,
+			Reservation:   reservation,
+		})
 	}
 
-	currentTs := s.clock.Now()
 	cRes, err := s.fileRepo.CreateFile(ctx, repository.CreateFileParam{
-		UniqueId:  uniqueId,
-		Path:      path,
-		Name:      p.fileName,
-		Mimetype:  p.fileMimetype,
-		Extension: p.fileExtension,
-		Size:      p.fileSize,
-		CreatedAt: currentTs,
-		CreateFn:  NewCreateFn(data.Bytes(), s.fileManager),
+		UniqueId:      uniqueId,
+		Path:          path,
+		Name:          p.fileName,
+		Mimetype:      p.fileMimetype,
+		Extension:     p.fileExtension,
+		Size:          p.fileSize,
+		CreatedAt:     currentTs,
+		CreateFn:      createFn,
+		Deduplicate:   s.config.Deduplicate,
+		DuplicateFn:   NewDuplicateFn(s.fileManager),
+		OwnerClientId: ownerClientId,
 	})
+	if err != nil && reservation.Reserved {
+		s.releaseQuota(ctx, ownerClientId, reservation.Size, currentTs)
+	}
+	if err != nil && s.scanner != nil {
+		s.removeSavedFile(ctx, path)
+	}
 	if err != nil {
-		return nil, &system.Error{
-			Code:    status.ACTION_FAILED,
-			Message: err.Error(),
-		}
+		return nil, newUploadFileError(err)
 	}
 
 	res := &UploadFileResult{
@@ -268,10 +557,121 @@ func (s *fileService) UploadFile(ctx context.Context, opts ...UploadFileOption)
 		Extension:  cRes.Extension,
 		Size:       cRes.Size,
 		UploadedAt: cRes.CreatedAt,
+		Checksum: file.Checksum{
+			Sha256: cRes.ChecksumSha256,
+			Md5:    cRes.ChecksumMd5,
+		},
 	}
 	return res, nil
 }
 
+// @note: file saved before its record is created is removed when the record is not created,
+// it may be already removed (e.g: rejected by the quota)
+func (s *fileService) removeSavedFile(ctx context.Context, path string) {
+	_, err := s.fileManager.RemoveFile(ctx, filesystem.RemoveFileParam{
+		Path: path,
+	})
+	if err != nil && !errors.Is(err, filesystem.ErrorFileNotFound) {
+		s.log.Warnf("Failed removing saved file %s, err: %s", path, err.Error())
+	}
+}
+
+func newUploadFileError(err error) *system.Error {
+	if errors.Is(err, repository.ErrExceeded) {
+		return &system.Error{
+			Code:    QUOTA_EXCEEDED,
+			Message: err.Error(),
+		}
+	}
+	if errors.Is(err, file.ErrExceeded) {
+		return &system.Error{
+			Code:    QUOTA_SIZE_EXCEEDED,
+			Message: "file size quota is exceeded",
+		}
+	}
+	if errors.Is(err, file.ErrInfected) {
+		return &system.Error{
+			Code:    FILE_INFECTED,
+			Message: err.Error(),
+		}
+	}
+	if errors.Is(err, scanner.ErrorLimitExceeded) {
+		return &system.Error{
+			Code:    FILE_NOT_SCANNABLE,
+			Message: err.Error(),
+		}
+	}
+	if errors.Is(err, scanner.ErrorUnavailable) {
+		return &system.Error{
+			Code:    FILE_NOT_SCANNED,
+			Message: err.Error(),
+		}
+	}
+	return &system.Error{
+		Code:    status.ACTION_FAILED,
+		Message: err.Error(),
+	}
+}
+
+// @note: nil quota is returned when the client has no quota (or the auth repo is not specified)
+func (s *fileService) checkQuota(ctx context.Context, ownerClientId string, p UploadFileParam) (*repository.ClientQuota, *system.Error) {
+	if s.authRepo == nil || ownerClientId == "" {
+		return nil, nil
+	}
+
+	client, err := s.authRepo.FindClient(ctx, repository.FindClientParam{
+		Id: ownerClientId,
+	})
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, &system.Error{
+			Code:    status.ACTION_FAILED,
+			Message: err.Error(),
+		}
+	}
+
+	if client.Quota == (repository.ClientQuota{}) {
+		return nil, nil
+	}
+
+	if client.Quota.MaxFileSize > 0 && p.fileSize > client.Quota.MaxFileSize {
+		return nil, &system.Error{
+			Code:    QUOTA_SIZE_EXCEEDED,
+			Message: "file size quota is exceeded",
+		}
+	}
+
+	usage := client.Usage.Add(repository.UpdateClientUsageParam{
+		StoredSize:   p.fileSize,
+		TotalFiles:   1,
+		DailyUploads: 1,
+		UsedAt:       s.clock.Now(),
+	})
+	err = client.Quota.Check(usage)
+	if err != nil {
+		return nil, &system.Error{
+			Code:    QUOTA_EXCEEDED,
+			Message: err.Error(),
+		}
+	}
+	return &client.Quota, nil
+}
+
+// @note: the daily upload is not released since the upload is already attempted
+func (s *fileService) releaseQuota(ctx context.Context, ownerClientId string, size int64, usedAt time.Time) {
+	_, err := s.authRepo.UpdateClientUsage(ctx, repository.UpdateClientUsageParam{
+		Id:         ownerClientId,
+		StoredSize: -size,
+		TotalFiles: -1,
+		UsedAt:     usedAt,
+	})
+	if err != nil {
+		s.log.Warnf("Failed releasing quota of client %s, err: %s", ownerClientId, err.Error())
+	}
+}
+
 func (s *fileService) DeleteFile(ctx context.Context, p DeleteFileParam) (*DeleteFileResult, *system.Error) {
 	s.log.Debug("In function: DeleteFile")
 	defer s.log.Debug("Returning function: DeleteFile")
@@ -284,11 +684,39 @@ func (s *fileService) DeleteFile(ctx context.Context, p DeleteFileParam) (*Delet
 		}
 	}
 
+	retrieve, err := s.fileRepo.RetrieveFile(ctx, repository.RetrieveFileParam{
+		UniqueId: p.FileId,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, &system.Error{
+				Code:    status.RESOURCE_NOTFOUND,
+				Message: "file is not found",
+			}
+		}
+		return nil, &system.Error{
+			Code:    status.ACTION_FAILED,
+			Message: err.Error(),
+		}
+	}
+
+	if !s.canAccess(ctx, retrieve.OwnerClientId) {
+		return nil, &system.Error{
+			Code:    status.ACTION_FORBIDDEN,
+			Message: "file is not accessible",
+		}
+	}
+
+	deleteFn := NewDeleteFn(s.fileManager)
+	if s.config.TrashEnabled {
+		deleteFn = NewTrashFn(s.fileManager, s.dirManager, s.config.TrashDir)
+	}
+
 	currentTs := s.clock.Now()
 	deletion, err := s.fileRepo.DeleteFile(ctx, repository.DeleteFileParam{
 		UniqueId:  p.FileId,
 		DeletedAt: currentTs,
-		DeleteFn:  NewDeleteFn(s.fileManager),
+		DeleteFn:  deleteFn,
 	})
 	if err != nil {
 		if errors.Is(err, repository.ErrDeleted) {
@@ -318,33 +746,406 @@ func (s *fileService) DeleteFile(ctx context.Context, p DeleteFileParam) (*Delet
 	return res, nil
 }
 
-func NewCreateFn(data []byte, fileManager filesystem.FileManager) repository.CreateFn {
-	return func(ctx context.Context, cp repository.CreateFnParam) error {
-		exists, err := fileManager.IsFileExists(ctx, filesystem.IsFileExistsParam{
+// @note: the content is moved back from the trash when it's no longer stored,
+// file deleted while the trash is disabled can only be restored when the content is still shared
+func (s *fileService) RestoreFile(ctx context.Context, p RestoreFileParam) (*RestoreFileResult, *system.Error) {
+	s.log.Debug("In function: RestoreFile")
+	defer s.log.Debug("Returning function: RestoreFile")
+
+	err := s.validator.Validate(p)
+	if err != nil {
+		return nil, &system.Error{
+			Code:    status.INVALID_PARAM,
+			Message: err.Error(),
+		}
+	}
+
+	retrieve, err := s.fileRepo.RetrieveFile(ctx, repository.RetrieveFileParam{
+		UniqueId: p.FileId,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, &system.Error{
+				Code:    status.RESOURCE_NOTFOUND,
+				Message: "file is not found",
+			}
+		}
+		return nil, &system.Error{
+			Code:    status.ACTION_FAILED,
+			Message: err.Error(),
+		}
+	}
+
+	if !s.canAccess(ctx, retrieve.OwnerClientId) {
+		return nil, &system.Error{
+			Code:    status.ACTION_FORBIDDEN,
+			Message: "file is not accessible",
+		}
+	}
+
+	currentTs := s.clock.Now()
+	restoration, err := s.fileRepo.RestoreFile(ctx, repository.RestoreFileParam{
+		UniqueId:    p.FileId,
+		RestoredAt:  currentTs,
+		Deduplicate: s.config.Deduplicate,
+		RestoreFn:   NewRestoreFn(s.fileManager, s.config.TrashDir),
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrNotDeleted) {
+			return nil, &system.Error{
+				Code:    FILE_NOT_DELETED,
+				Message: "file is not deleted",
+			}
+		} else if errors.Is(err, repository.ErrNotFound) {
+			return nil, &system.Error{
+				Code:    status.RESOURCE_NOTFOUND,
+				Message: "file is not found",
+			}
+		} else if errors.Is(err, file.ErrNotFound) {
+			return nil, &system.Error{
+				Code:    status.RESOURCE_NOTFOUND,
+				Message: "file is not found in trash",
+			}
+		} else if errors.Is(err, repository.ErrExceeded) {
+			return nil, &system.Error{
+				Code:    QUOTA_EXCEEDED,
+				Message: err.Error(),
+			}
+		}
+		return nil, &system.Error{
+			Code:    status.ACTION_FAILED,
+			Message: err.Error(),
+		}
+	}
+
+	res := &RestoreFileResult{
+		Success: system.Success{
+			Code:    status.ACTION_SUCCESS,
+			Message: "success restore file",
+		},
+		RestoredAt: restoration.RestoredAt,
+	}
+	return res, nil
+}
+
+func (s *fileService) SearchFile(ctx context.Context, p SearchFileParam) (*SearchFileResult, *system.Error) {
+	s.log.Debug("In function: SearchFile")
+	defer s.log.Debug("Returning function: SearchFile")
+
+	err := s.validator.Validate(p)
+	if err != nil {
+		return nil, &system.Error{
+			Code:    status.INVALID_PARAM,
+			Message: err.Error(),
+		}
+	}
+
+	if p.MinSize != nil && p.MaxSize != nil && *p.MinSize > *p.MaxSize {
+		return nil, &system.Error{
+			Code:    status.INVALID_PARAM,
+			Message: "min_size must be less than or equal to max_size",
+		}
+	}
+
+	if p.UploadedFrom != nil && p.UploadedTo != nil && p.UploadedFrom.After(*p.UploadedTo) {
+		return nil, &system.Error{
+			Code:    status.INVALID_PARAM,
+			Message: "uploaded_from must be less than or equal to uploaded_to",
+		}
+	}
+
+	offset := int64(0)
+	if p.Page > 1 {
+		offset = (p.Page - 1) * int64(p.TotalItems)
+	}
+
+	sortBy := p.SortBy
+	if sortBy == "" {
+		sortBy = repository.FILE_SORT_UPLOADED_AT
+	}
+	sortOrder := p.SortOrder
+	if sortOrder == "" {
+		sortOrder = repository.SORT_DESC
+	}
+
+	searchRes, err := s.fileRepo.SearchFile(ctx, repository.SearchFileParam{
+		Limit:          p.TotalItems,
+		Offset:         offset,
+		Keyword:        p.Keyword,
+		Mimetypes:      p.Mimetypes,
+		Extensions:     p.Extensions,
+		MinSize:        p.MinSize,
+		MaxSize:        p.MaxSize,
+		UploadedFrom:   p.UploadedFrom,
+		UploadedTo:     p.UploadedTo,
+		Statuses:       p.Statuses,
+		SortBy:         sortBy,
+		SortOrder:      sortOrder,
+		OwnerClientIds: s.accessibleOwners(ctx),
+	})
+	if err != nil {
+		return nil, &system.Error{
+			Code:    status.ACTION_FAILED,
+			Message: err.Error(),
+		}
+	}
+
+	items := []SearchFileItem{}
+	for _, searchItem := range searchRes.Items {
+		items = append(items, SearchFileItem{
+			UniqueId:  searchItem.UniqueId,
+			Name:      searchItem.Name,
+			Path:      searchItem.Path,
+			MimeType:  searchItem.Mimetype,
+			Extension: searchItem.Extension,
+			Size:      searchItem.Size,
+			Checksum: file.Checksum{
+				Sha256: searchItem.ChecksumSha256,
+				Md5:    searchItem.ChecksumMd5,
+			},
+			UploadedAt: searchItem.CreatedAt,
+			DeletedAt:  searchItem.DeletedAt,
+		})
+	}
+
+	res := &SearchFileResult{
+		Success: system.Success{
+			Code:    status.ACTION_SUCCESS,
+			Message: "success search file",
+		},
+		Items: items,
+		Summary: SearchFileSummary{
+			TotalItems: searchRes.Summary.TotalItems,
+			Page:       p.Page,
+		},
+	}
+	return res, nil
+}
+
+// @note: data is streamed from reader into the file, the size is the number of bytes read
+// and the checksum is computed from the read bytes (md5 is computed only when it's enabled)
+// while the stored size is the number of bytes written after it's compressed and encrypted
+func NewCreateFn(reader io.Reader, mimetype string, fileManager filesystem.FileManager, checksumMd5 bool) repository.CreateFn {
+	return func(ctx context.Context, cp repository.CreateFnParam) (*repository.CreateFnResult, error) {
+		checksumReader := file.NewChecksumReader(file.ChecksumReaderParam{
+			Reader: reader,
+			Md5:    checksumMd5,
+		})
+		save, err := fileManager.SaveFile(ctx, filesystem.SaveFileParam{
+			Name:       cp.FilePath,
+			Reader:     checksumReader,
+			Permission: 0644,
+			Exclusive:  true,
+			Mimetype:   mimetype,
+		})
+		if errors.Is(err, filesystem.ErrorFileExists) {
+			return nil, file.ErrExists
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		checksum := checksumReader.Checksum()
+		res := &repository.CreateFnResult{
+			Size:           save.Size,
+			StoredSize:     save.StoredSize,
+			ChecksumSha256: checksum.Sha256,
+			ChecksumMd5:    checksum.Md5,
+			Compression:    save.Compression,
+		}
+		if save.EncryptionKey != nil {
+			res.EncryptionKeyId = save.EncryptionKey.KeyId
+			res.EncryptionDataKey = save.EncryptionKey.DataKey
+		}
+		return res, nil
+	}
+}
+
+type ScanFnParam struct {
+	Reader      io.Reader
+	Scanner     scanner.Scanner
+	FileManager filesystem.FileManager
+	Logger      logging.Logger
+	FailOpen    bool
+	// @note: file exceeding the scanner limit is accepted without being scanned
+	SkipOversized bool
+	// @note: creates the file from the reader which is also streamed to the scanner
+	CreateFn func(reader io.Reader) repository.CreateFn
+}
+
+type scanFnResult struct {
+	scan *scanner.ScanFileResult
+	err  error
+}
+
+// @note: content is scanned while it's saved so the file is rejected before its record is created,
+// rejected file is removed while the scanner failure is ignored when it fails open
+func NewScanFn(p ScanFnParam) repository.CreateFn {
+	return func(ctx context.Context, cp repository.CreateFnParam) (*repository.CreateFnResult, error) {
+		pr, pw := io.Pipe()
+		scanRes := make(chan scanFnResult, 1)
+		go func() {
+			scan, err := p.Scanner.ScanFile(ctx, scanner.ScanFileParam{
+				Reader: pr,
+			})
+			// @note: the rest of the content is drained so saving is not blocked when the scanner stops reading
+			io.Copy(io.Discard, pr)
+			scanRes <- scanFnResult{scan: scan, err: err}
+		}()
+
+		res, err := p.CreateFn(io.TeeReader(p.Reader, pw))(ctx, cp)
+		if err != nil {
+			pw.CloseWithError(err)
+			<-scanRes
+			return nil, err
+		}
+		pw.Close()
+
+		result := <-scanRes
+		oversized := errors.Is(result.err, scanner.ErrorLimitExceeded)
+		if oversized && p.SkipOversized {
+			p.Logger.Warnf("Skipped scanning file %s, err: %s", cp.FilePath, result.err.Error())
+			return res, nil
+		}
+		if result.err != nil && !oversized && p.FailOpen {
+			p.Logger.Warnf("Failed scanning file %s, err: %s", cp.FilePath, result.err.Error())
+			return res, nil
+		}
+
+		err = result.err
+		if err == nil && result.scan.Infected {
+			err = fmt.Errorf("%w: %s", file.ErrInfected, result.scan.Signature)
+		}
+		if err == nil {
+			return res, nil
+		}
+
+		_, rErr := p.FileManager.RemoveFile(ctx, filesystem.RemoveFileParam{
 			Path: cp.FilePath,
 		})
+		if rErr != nil {
+			p.Logger.Warnf("Failed removing rejected file %s, err: %s", cp.FilePath, rErr.Error())
+		}
+		return nil, err
+	}
+}
+
+// @note: file is already saved (e.g: saved and scanned before the record is created)
+func NewSavedFn(res *repository.CreateFnResult) repository.CreateFn {
+	return func(ctx context.Context, cp repository.CreateFnParam) (*repository.CreateFnResult, error) {
+		return res, nil
+	}
+}
+
+type QuotaFnParam struct {
+	AuthRepo      repository.Auth
+	OwnerClientId string
+	UsedAt        time.Time
+	FileManager   filesystem.FileManager
+	Logger        logging.Logger
+	CreateFn      repository.CreateFn
+	// @note: reservation is set once the usage is reserved
+	// so it can be released when the file record is not created
+	Reservation *QuotaReservation
+}
+
+type QuotaReservation struct {
+	Reserved bool
+	Size     int64
+}
+
+// @note: usage is reserved using the actual size after the file is saved,
+// file exceeding the quota is removed before its record is committed
+func NewQuotaFn(p QuotaFnParam) repository.CreateFn {
+	return func(ctx context.Context, cp repository.CreateFnParam) (*repository.CreateFnResult, error) {
+		res, err := p.CreateFn(ctx, cp)
 		if err != nil {
-			return err
+			return nil, err
 		}
-		if exists {
-			return file.ErrExists
+
+		_, err = p.AuthRepo.UpdateClientUsage(ctx, repository.UpdateClientUsageParam{
+			Id:           p.OwnerClientId,
+			StoredSize:   res.Size,
+			TotalFiles:   1,
+			DailyUploads: 1,
+			UsedAt:       p.UsedAt,
+			CheckQuota:   true,
+		})
+		if err == nil {
+			p.Reservation.Reserved = true
+			p.Reservation.Size = res.Size
+			return res, nil
 		}
 
-		_, err = fileManager.SaveFile(ctx, filesystem.SaveFileParam{
-			Name:       cp.FilePath,
-			Data:       data,
-			Permission: 0644,
+		_, rErr := p.FileManager.RemoveFile(ctx, filesystem.RemoveFileParam{
+			Path: cp.FilePath,
+		})
+		if rErr != nil {
+			p.Logger.Warnf("Failed removing rejected file %s, err: %s", cp.FilePath, rErr.Error())
+		}
+		return nil, err
+	}
+}
+
+// @note: file stored without encryption has no key
+func encryptionKey(keyId, dataKey string) *filesystem.EncryptionKey {
+	if keyId == "" {
+		return nil
+	}
+	return &filesystem.EncryptionKey{
+		KeyId:   keyId,
+		DataKey: dataKey,
+	}
+}
+
+// @note: encoding is accepted when it's listed (or matched by wildcard)
+// unless it's explicitly refused (e.g: gzip;q=0)
+func acceptEncoding(encodings []string, encoding string) bool {
+	accepted := map[string]bool{}
+	for _, accept := range encodings {
+		params := strings.Split(accept, ";")
+		name := strings.ToLower(strings.TrimSpace(params[0]))
+
+		q := 1.0
+		for _, param := range params[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				value, err := strconv.ParseFloat(strings.TrimPrefix(param, "q="), 64)
+				if err != nil {
+					value = 0
+				}
+				q = value
+			}
+		}
+		accepted[name] = q > 0
+	}
+
+	if ok, found := accepted[encoding]; found {
+		return ok
+	}
+	return accepted["*"]
+}
+
+// @note: the duplicated content is removed since the file will refer to the already stored content
+func NewDuplicateFn(fileManager filesystem.FileManager) repository.DuplicateFn {
+	return func(ctx context.Context, r repository.DuplicateFnParam) error {
+		_, err := fileManager.RemoveFile(ctx, filesystem.RemoveFileParam{
+			Path: r.FilePath,
 		})
 		if err != nil {
 			return err
 		}
-
 		return nil
 	}
 }
 
+// @note: the content is kept while it's still referenced by other files
 func NewDeleteFn(fileManager filesystem.FileManager) repository.DeleteFn {
 	return func(ctx context.Context, r repository.DeleteFnParam) error {
+		if r.References > 0 {
+			return nil
+		}
+
 		exists, err := fileManager.IsFileExists(ctx, filesystem.IsFileExistsParam{
 			Path: r.FilePath,
 		})
@@ -367,18 +1168,154 @@ func NewDeleteFn(fileManager filesystem.FileManager) repository.DeleteFn {
 	}
 }
 
+// @note: the content is moved into the trash instead of removed while it's still referenced by other files
+func NewTrashFn(fileManager filesystem.FileManager, dirManager filesystem.DirectoryManager, trashDir string) repository.DeleteFn {
+	return func(ctx context.Context, r repository.DeleteFnParam) error {
+		if r.References > 0 {
+			return nil
+		}
+
+		exists, err := fileManager.IsFileExists(ctx, filesystem.IsFileExistsParam{
+			Path: r.FilePath,
+		})
+		if err != nil {
+			return err
+		}
+
+		if !exists {
+			return file.ErrNotFound
+		}
+
+		exists, err = dirManager.IsDirectoryExists(ctx, filesystem.IsDirectoryExistsParam{
+			Path: trashDir,
+		})
+		if err != nil {
+			return err
+		}
+
+		if !exists {
+			_, err = dirManager.CreateDir(ctx, filesystem.CreateDirParam{
+				Path:       trashDir,
+				Permission: 0644,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err = fileManager.MoveFile(ctx, filesystem.MoveFileParam{
+			SourcePath:      r.FilePath,
+			DestinationPath: trashPath(trashDir, r.FilePath),
+		})
+		if err != nil {
+			return err
+		}
+
+		return nil
+	}
+}
+
+// @note: the content is still stored when it's shared with other files
+func NewRestoreFn(fileManager filesystem.FileManager, trashDir string) repository.RestoreFn {
+	return func(ctx context.Context, r repository.RestoreFnParam) error {
+		if r.References > 0 {
+			return nil
+		}
+
+		_, err := fileManager.MoveFile(ctx, filesystem.MoveFileParam{
+			SourcePath:      trashPath(trashDir, r.FilePath),
+			DestinationPath: r.FilePath,
+		})
+		if err != nil {
+			if errors.Is(err, filesystem.ErrorFileNotFound) {
+				return file.ErrNotFound
+			}
+			return err
+		}
+
+		return nil
+	}
+}
+
+// @note: the trashed content is kept while the path is still used by the remaining files,
+// content which is not in the trash has been removed when the file is deleted
+func NewPurgeFn(fileManager filesystem.FileManager, trashDir string) repository.PurgeFn {
+	return func(ctx context.Context, r repository.PurgeFnParam) error {
+		if r.References > 0 {
+			return nil
+		}
+
+		_, err := fileManager.RemoveFile(ctx, filesystem.RemoveFileParam{
+			Path: trashPath(trashDir, r.FilePath),
+		})
+		if err != nil && !errors.Is(err, filesystem.ErrorFileNotFound) {
+			return err
+		}
+
+		return nil
+	}
+}
+
+// @note: unowned file is the one uploaded before the ownership is recorded
+func (s *fileService) canAccess(ctx context.Context, ownerClientId string) bool {
+	return canAccess(ctx, ownerClientId, s.config.UnownedAccess)
+}
+
+// @note: unauthenticated context is an internal caller which can access any resource,
+// otherwise only the owner (or admin) can access it
+func canAccess(ctx context.Context, ownerClientId string, unownedAccess bool) bool {
+	identity := auth.IdentityFromContext(ctx)
+	if identity == nil || identity.Admin {
+		return true
+	}
+	if ownerClientId == "" {
+		return unownedAccess
+	}
+	return ownerClientId == identity.Id
+}
+
+// @note: owners of the files which can be accessed, empty when any file can be accessed
+func (s *fileService) accessibleOwners(ctx context.Context) []string {
+	identity := auth.IdentityFromContext(ctx)
+	if identity == nil || identity.Admin {
+		return nil
+	}
+	if s.config.UnownedAccess {
+		return []string{identity.Id, ""}
+	}
+	return []string{identity.Id}
+}
+
+// @note: file name is unique since it's generated from the file id
+func trashPath(trashDir, filePath string) string {
+	return fmt.Sprintf("%s/%s", trashDir, filepath.Base(filePath))
+}
+
 type FileConfig struct {
-	UploadDir string
+	UploadDir    string
+	ChecksumMd5  bool
+	Deduplicate  bool
+	TrashEnabled bool
+	TrashDir     string
+	// @note: file is accepted when the scanner fails (e.g: it's unreachable)
+	ScanFailOpen bool
+	// @note: file exceeding the scanner limit (e.g: clamd StreamMaxLength) is accepted unscanned
+	ScanSkipOversized bool
+	// @note: file without owner is accessible by any client
+	UnownedAccess bool
 }
 
 type FileParam struct {
 	FileRepo    repository.File
+	AuthRepo    repository.Auth
 	FileManager filesystem.FileManager
 	DirManager  filesystem.DirectoryManager
 	Logger      logging.Logger
 	Identifier  identity.Identifier
 	Clock       datetime.Clock
 	Locator     file.UploadLocation
+	Policy      file.UploadPolicy
+	Scanner     scanner.Scanner
 	Validator   validation.Validator
 	Config      *FileConfig
 }
@@ -386,12 +1323,15 @@ type FileParam struct {
 func NewFile(p FileParam) *fileService {
 	return &fileService{
 		fileRepo:    p.FileRepo,
+		authRepo:    p.AuthRepo,
 		fileManager: p.FileManager,
 		dirManager:  p.DirManager,
 		identifier:  p.Identifier,
 		clock:       p.Clock,
 		log:         p.Logger,
 		locator:     p.Locator,
+		policy:      p.Policy,
+		scanner:     p.Scanner,
 		config:      p.Config,
 		validator:   p.Validator,
 	}
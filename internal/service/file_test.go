@@ -2,17 +2,23 @@ package service_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"strings"
+	"testing/iotest"
 	"time"
 
+	"github.com/go-seidon/hippo/internal/auth"
 	"github.com/go-seidon/hippo/internal/file"
 	mock_file "github.com/go-seidon/hippo/internal/file/mock"
 	"github.com/go-seidon/hippo/internal/filesystem"
 	mock_filesystem "github.com/go-seidon/hippo/internal/filesystem/mock"
 	"github.com/go-seidon/hippo/internal/repository"
 	mock_repository "github.com/go-seidon/hippo/internal/repository/mock"
+	"github.com/go-seidon/hippo/internal/scanner"
+	mock_scanner "github.com/go-seidon/hippo/internal/scanner/mock"
 	"github.com/go-seidon/hippo/internal/service"
 	mock_datetime "github.com/go-seidon/provider/datetime/mock"
 	mock_identifier "github.com/go-seidon/provider/identity/mock"
@@ -75,11 +81,12 @@ var _ = Describe("File Service", func() {
 				UniqueId: p.FileId,
 			}
 			retrieveRes = &repository.RetrieveFileResult{
-				UniqueId:  p.FileId,
-				Name:      "mock-name",
-				Path:      "mock-path",
-				Mimetype:  "mock-mimetype",
-				Extension: "mock-extension",
+				UniqueId:       p.FileId,
+				Name:           "mock-name",
+				Path:           "mock-path",
+				Mimetype:       "mock-mimetype",
+				Extension:      "mock-extension",
+				ChecksumSha256: "ed7002b439e9ac845f22357d822bac1444730fbdb6016d3ec9432297b9ec9f73",
 			}
 			openParam = filesystem.OpenFileParam{
 				Path: retrieveRes.Path,
@@ -99,6 +106,9 @@ var _ = Describe("File Service", func() {
 				Path:      retrieveRes.Path,
 				MimeType:  retrieveRes.Mimetype,
 				Extension: retrieveRes.Extension,
+				Checksum: file.Checksum{
+					Sha256: retrieveRes.ChecksumSha256,
+				},
 			}
 
 			log.
@@ -179,8 +189,14 @@ var _ = Describe("File Service", func() {
 			})
 		})
 
-		When("failed find file record", func() {
+		When("file is owned by other client", func() {
 			It("should return error", func() {
+				ctx := auth.WithIdentity(ctx, &auth.Identity{
+					Id:       "other-id",
+					ClientId: "other-client-id",
+				})
+				retrieveRes.OwnerClientId = "id"
+
 				validator.
 					EXPECT().
 					Validate(gomock.Eq(p)).
@@ -190,19 +206,24 @@ var _ = Describe("File Service", func() {
 				fileRepo.
 					EXPECT().
 					RetrieveFile(gomock.Eq(ctx), gomock.Eq(retrieveParam)).
-					Return(nil, fmt.Errorf("db error")).
+					Return(retrieveRes, nil).
 					Times(1)
 
 				res, err := s.RetrieveFile(ctx, p)
 
 				Expect(res).To(BeNil())
-				Expect(err.Code).To(Equal(int32(1001)))
-				Expect(err.Message).To(Equal("db error"))
+				Expect(err.Code).To(Equal(int32(1003)))
+				Expect(err.Message).To(Equal("file is not accessible"))
 			})
 		})
 
-		When("file is not available in disk", func() {
+		When("file is not owned and unowned access is disabled", func() {
 			It("should return error", func() {
+				ctx := auth.WithIdentity(ctx, &auth.Identity{
+					Id:       "id",
+					ClientId: "client-id",
+				})
+
 				validator.
 					EXPECT().
 					Validate(gomock.Eq(p)).
@@ -215,22 +236,32 @@ var _ = Describe("File Service", func() {
 					Return(retrieveRes, nil).
 					Times(1)
 
-				fileManager.
-					EXPECT().
-					OpenFile(gomock.Eq(ctx), gomock.Eq(openParam)).
-					Return(nil, filesystem.ErrorFileNotFound).
-					Times(1)
-
 				res, err := s.RetrieveFile(ctx, p)
 
 				Expect(res).To(BeNil())
-				Expect(err.Code).To(Equal(int32(1004)))
-				Expect(err.Message).To(Equal("file is not found"))
+				Expect(err.Code).To(Equal(int32(1003)))
+				Expect(err.Message).To(Equal("file is not accessible"))
 			})
 		})
 
-		When("failed open file in disk", func() {
-			It("should return error", func() {
+		When("file is not owned and unowned access is enabled", func() {
+			It("should return result", func() {
+				s = service.NewFile(service.FileParam{
+					FileRepo:    fileRepo,
+					FileManager: fileManager,
+					Logger:      log,
+					Validator:   validator,
+					Config: &service.FileConfig{
+						UploadDir:     "temp",
+						UnownedAccess: true,
+					},
+				})
+				ctx := auth.WithIdentity(ctx, &auth.Identity{
+					Id:       "id",
+					ClientId: "client-id",
+				})
+				p.MetadataOnly = true
+
 				validator.
 					EXPECT().
 					Validate(gomock.Eq(p)).
@@ -243,22 +274,24 @@ var _ = Describe("File Service", func() {
 					Return(retrieveRes, nil).
 					Times(1)
 
-				fileManager.
-					EXPECT().
-					OpenFile(gomock.Eq(ctx), gomock.Eq(openParam)).
-					Return(nil, fmt.Errorf("disk error")).
-					Times(1)
-
 				res, err := s.RetrieveFile(ctx, p)
 
-				Expect(res).To(BeNil())
-				Expect(err.Code).To(Equal(int32(1001)))
-				Expect(err.Message).To(Equal("disk error"))
+				r.Data = nil
+				Expect(res).To(Equal(r))
+				Expect(err).To(BeNil())
 			})
 		})
 
-		When("success retrieve file", func() {
+		When("file is owned by other client and client is admin", func() {
 			It("should return result", func() {
+				ctx := auth.WithIdentity(ctx, &auth.Identity{
+					Id:       "admin-id",
+					ClientId: "admin-client-id",
+					Admin:    true,
+				})
+				retrieveRes.OwnerClientId = "id"
+				p.MetadataOnly = true
+
 				validator.
 					EXPECT().
 					Validate(gomock.Eq(p)).
@@ -271,537 +304,3600 @@ var _ = Describe("File Service", func() {
 					Return(retrieveRes, nil).
 					Times(1)
 
-				fileManager.
-					EXPECT().
-					OpenFile(gomock.Eq(ctx), gomock.Eq(openParam)).
-					Return(openRes, nil).
-					Times(1)
-
 				res, err := s.RetrieveFile(ctx, p)
 
+				r.Data = nil
 				Expect(res).To(Equal(r))
 				Expect(err).To(BeNil())
 			})
 		})
-	})
-
-	Context("UploadFile function", Label("unit"), func() {
-		var (
-			ctx            context.Context
-			currentTs      time.Time
-			fileRepo       *mock_repository.MockFile
-			fileManager    *mock_filesystem.MockFileManager
-			dirManager     *mock_filesystem.MockDirectoryManager
-			logger         *mock_logging.MockLogger
-			reader         *mock_io.MockReader
-			identifier     *mock_identifier.MockIdentifier
-			clock          *mock_datetime.MockClock
-			locator        *mock_file.MockUploadLocation
-			validator      *mock_validation.MockValidator
-			s              service.File
-			dirExistsParam filesystem.IsDirectoryExistsParam
-			createDirParam filesystem.CreateDirParam
-			createFileRes  *repository.CreateFileResult
-			opts           []service.UploadFileOption
-			r              *service.UploadFileResult
-		)
-
-		BeforeEach(func() {
-			currentTs = time.Now().UTC()
-			ctx = context.Background()
-			t := GinkgoT()
-			ctrl := gomock.NewController(t)
-			fileRepo = mock_repository.NewMockFile(ctrl)
-			fileManager = mock_filesystem.NewMockFileManager(ctrl)
-			dirManager = mock_filesystem.NewMockDirectoryManager(ctrl)
-			logger = mock_logging.NewMockLogger(ctrl)
-			identifier = mock_identifier.NewMockIdentifier(ctrl)
-			clock = mock_datetime.NewMockClock(ctrl)
-			locator = mock_file.NewMockUploadLocation(ctrl)
-			validator = mock_validation.NewMockValidator(ctrl)
-			reader = mock_io.NewMockReader(ctrl)
-			s = service.NewFile(service.FileParam{
-				FileRepo:    fileRepo,
-				FileManager: fileManager,
-				DirManager:  dirManager,
-				Logger:      logger,
-				Identifier:  identifier,
-				Clock:       clock,
-				Locator:     locator,
-				Validator:   validator,
-				Config: &service.FileConfig{
-					UploadDir: "temp",
-				},
-			})
-			dirExistsParam = filesystem.IsDirectoryExistsParam{
-				Path: "temp/2022/08/22",
-			}
-			createDirParam = filesystem.CreateDirParam{
-				Path:       "temp/2022/08/22",
-				Permission: 0644,
-			}
-			createFileRes = &repository.CreateFileResult{
-				UniqueId:  "mock-unique-id",
-				Name:      "mock-name",
-				Path:      "mock-path",
-				Mimetype:  "mock-mimetype",
-				Extension: "mock-extension",
-				Size:      200,
-				CreatedAt: currentTs,
-			}
-			dataOpt := service.WithReader(reader)
-			infoOpt := service.WithFileInfo("mock-name", "image/jpeg", "jpg", 100)
-			opts = append(opts, dataOpt)
-			opts = append(opts, infoOpt)
-			r = &service.UploadFileResult{
-				Success: system.Success{
-					Code:    1000,
-					Message: "success upload file",
-				},
-				UniqueId:   "mock-unique-id",
-				Name:       "mock-name",
-				Path:       "mock-path",
-				Mimetype:   "mock-mimetype",
-				Extension:  "mock-extension",
-				Size:       200,
-				UploadedAt: currentTs,
-			}
 
-			logger.
-				EXPECT().
-				Debug("In function: UploadFile").
-				Times(1)
-			logger.
-				EXPECT().
-				Debug("Returning function: UploadFile").
-				Times(1)
-		})
+		When("file is owned by the client", func() {
+			It("should return result", func() {
+				ctx := auth.WithIdentity(ctx, &auth.Identity{
+					Id:       "id",
+					ClientId: "client-id",
+				})
+				retrieveRes.OwnerClientId = "id"
+				p.MetadataOnly = true
 
-		When("there is invalid data", func() {
-			It("should return error", func() {
 				validator.
 					EXPECT().
-					Validate(gomock.Any()).
-					Return(fmt.Errorf("invalid data")).
+					Validate(gomock.Eq(p)).
+					Return(nil).
 					Times(1)
 
-				res, err := s.UploadFile(ctx)
+				fileRepo.
+					EXPECT().
+					RetrieveFile(gomock.Eq(ctx), gomock.Eq(retrieveParam)).
+					Return(retrieveRes, nil).
+					Times(1)
 
-				Expect(res).To(BeNil())
-				Expect(err.Code).To(Equal(int32(1002)))
-				Expect(err.Message).To(Equal("invalid data"))
+				res, err := s.RetrieveFile(ctx, p)
+
+				r.Data = nil
+				Expect(res).To(Equal(r))
+				Expect(err).To(BeNil())
 			})
 		})
 
-		When("file is not specified", func() {
+		When("failed find file record", func() {
 			It("should return error", func() {
 				validator.
 					EXPECT().
-					Validate(gomock.Any()).
+					Validate(gomock.Eq(p)).
 					Return(nil).
 					Times(1)
 
-				res, err := s.UploadFile(ctx)
+				fileRepo.
+					EXPECT().
+					RetrieveFile(gomock.Eq(ctx), gomock.Eq(retrieveParam)).
+					Return(nil, fmt.Errorf("db error")).
+					Times(1)
+
+				res, err := s.RetrieveFile(ctx, p)
 
 				Expect(res).To(BeNil())
-				Expect(err.Code).To(Equal(int32(1002)))
-				Expect(err.Message).To(Equal("file is not specified"))
+				Expect(err.Code).To(Equal(int32(1001)))
+				Expect(err.Message).To(Equal("db error"))
 			})
 		})
 
-		When("failed check directory existance", func() {
+		When("file is not available in disk", func() {
 			It("should return error", func() {
 				validator.
 					EXPECT().
-					Validate(gomock.Any()).
+					Validate(gomock.Eq(p)).
 					Return(nil).
 					Times(1)
 
-				locator.
+				fileRepo.
 					EXPECT().
-					GetLocation().
-					Return("2022/08/22").
+					RetrieveFile(gomock.Eq(ctx), gomock.Eq(retrieveParam)).
+					Return(retrieveRes, nil).
 					Times(1)
 
-				dirManager.
+				fileManager.
 					EXPECT().
-					IsDirectoryExists(gomock.Eq(ctx), gomock.Eq(dirExistsParam)).
-					Return(false, fmt.Errorf("disk error")).
+					OpenFile(gomock.Eq(ctx), gomock.Eq(openParam)).
+					Return(nil, filesystem.ErrorFileNotFound).
 					Times(1)
 
-				res, err := s.UploadFile(ctx, opts...)
+				res, err := s.RetrieveFile(ctx, p)
 
 				Expect(res).To(BeNil())
-				Expect(err.Code).To(Equal(int32(1001)))
-				Expect(err.Message).To(Equal("disk error"))
+				Expect(err.Code).To(Equal(int32(1004)))
+				Expect(err.Message).To(Equal("file is not found"))
 			})
 		})
 
-		When("failed create upload directory", func() {
+		When("failed open file in disk", func() {
 			It("should return error", func() {
 				validator.
 					EXPECT().
-					Validate(gomock.Any()).
+					Validate(gomock.Eq(p)).
 					Return(nil).
 					Times(1)
 
-				locator.
+				fileRepo.
 					EXPECT().
-					GetLocation().
-					Return("2022/08/22").
+					RetrieveFile(gomock.Eq(ctx), gomock.Eq(retrieveParam)).
+					Return(retrieveRes, nil).
 					Times(1)
 
-				dirManager.
-					EXPECT().
-					IsDirectoryExists(gomock.Eq(ctx), gomock.Eq(dirExistsParam)).
-					Return(false, nil).
-					Times(1)
-				dirManager.
+				fileManager.
 					EXPECT().
-					CreateDir(gomock.Eq(ctx), gomock.Eq(createDirParam)).
-					Return(nil, fmt.Errorf("i/o error")).
+					OpenFile(gomock.Eq(ctx), gomock.Eq(openParam)).
+					Return(nil, fmt.Errorf("disk error")).
 					Times(1)
 
-				res, err := s.UploadFile(ctx, opts...)
+				res, err := s.RetrieveFile(ctx, p)
 
 				Expect(res).To(BeNil())
 				Expect(err.Code).To(Equal(int32(1001)))
-				Expect(err.Message).To(Equal("i/o error"))
+				Expect(err.Message).To(Equal("disk error"))
 			})
 		})
 
-		When("failed read from file reader", func() {
-			It("should return error", func() {
+		When("success retrieve file", func() {
+			It("should return result", func() {
 				validator.
 					EXPECT().
-					Validate(gomock.Any()).
+					Validate(gomock.Eq(p)).
 					Return(nil).
 					Times(1)
 
-				locator.
+				fileRepo.
 					EXPECT().
-					GetLocation().
-					Return("2022/08/22").
+					RetrieveFile(gomock.Eq(ctx), gomock.Eq(retrieveParam)).
+					Return(retrieveRes, nil).
 					Times(1)
 
-				dirManager.
+				fileManager.
 					EXPECT().
-					IsDirectoryExists(gomock.Eq(ctx), gomock.Eq(dirExistsParam)).
-					Return(true, nil).
+					OpenFile(gomock.Eq(ctx), gomock.Eq(openParam)).
+					Return(openRes, nil).
 					Times(1)
 
-				dirManager.
-					EXPECT().
-					CreateDir(gomock.Eq(ctx), gomock.Eq(createDirParam)).
-					Times(0)
+				res, err := s.RetrieveFile(ctx, p)
+
+				Expect(res).To(Equal(r))
+				Expect(err).To(BeNil())
+			})
+		})
 
-				reader.
+		When("range is not satisfiable", func() {
+			It("should return error", func() {
+				p.Offset = 7
+				retrieveRes.Size = 7
+				validator.
 					EXPECT().
-					Read(gomock.Any()).
-					Return(0, fmt.Errorf("disk error")).
+					Validate(gomock.Eq(p)).
+					Return(nil).
 					Times(1)
 
-				fwOpt := service.WithReader(reader)
-				copts := opts
-				copts = append(copts, fwOpt)
+				fileRepo.
+					EXPECT().
+					RetrieveFile(gomock.Eq(ctx), gomock.Eq(retrieveParam)).
+					Return(retrieveRes, nil).
+					Times(1)
 
-				res, err := s.UploadFile(ctx, copts...)
+				res, err := s.RetrieveFile(ctx, p)
 
 				Expect(res).To(BeNil())
-				Expect(err.Code).To(Equal(int32(1001)))
-				Expect(err.Message).To(Equal("disk error"))
+				Expect(err).To(Equal(&system.Error{
+					Code:    2005,
+					Message: "file range is not satisfiable",
+				}))
 			})
 		})
 
-		When("failed generate file id", func() {
-			It("should return error", func() {
+		When("metadata only is requested", func() {
+			It("should return result without data", func() {
+				p.MetadataOnly = true
+				retrieveRes.Size = 7
+				retrieveRes.CreatedAt = currentTs
 				validator.
 					EXPECT().
-					Validate(gomock.Any()).
+					Validate(gomock.Eq(p)).
 					Return(nil).
 					Times(1)
 
-				locator.
+				fileRepo.
 					EXPECT().
-					GetLocation().
-					Return("2022/08/22").
+					RetrieveFile(gomock.Eq(ctx), gomock.Eq(retrieveParam)).
+					Return(retrieveRes, nil).
 					Times(1)
 
-				dirManager.
-					EXPECT().
-					IsDirectoryExists(gomock.Eq(ctx), gomock.Eq(dirExistsParam)).
-					Return(true, nil).
-					Times(1)
-				dirManager.
-					EXPECT().
-					CreateDir(gomock.Eq(ctx), gomock.Eq(createDirParam)).
-					Times(0)
-				reader.
+				res, err := s.RetrieveFile(ctx, p)
+
+				r.Data = nil
+				r.Size = 7
+				r.Length = 7
+				r.UploadedAt = currentTs
+				Expect(res).To(Equal(r))
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("success retrieve ranged file", func() {
+			It("should return result", func() {
+				p.Offset = 2
+				p.Length = 3
+				retrieveRes.Size = 7
+				validator.
 					EXPECT().
-					Read(gomock.Any()).
-					Return(0, io.EOF).
+					Validate(gomock.Eq(p)).
+					Return(nil).
 					Times(1)
-				identifier.
+
+				fileRepo.
 					EXPECT().
-					GenerateId().
-					Return("", fmt.Errorf("generate error")).
+					RetrieveFile(gomock.Eq(ctx), gomock.Eq(retrieveParam)).
+					Return(retrieveRes, nil).
 					Times(1)
 
-				fwOpt := service.WithReader(reader)
-				copts := opts
-				copts = append(copts, fwOpt)
+				openParam.Offset = 2
+				openParam.Length = 3
+				fileManager.
+					EXPECT().
+					OpenFile(gomock.Eq(ctx), gomock.Eq(openParam)).
+					Return(openRes, nil).
+					Times(1)
 
-				res, err := s.UploadFile(ctx, copts...)
+				res, err := s.RetrieveFile(ctx, p)
 
-				Expect(res).To(BeNil())
-				Expect(err.Code).To(Equal(int32(1001)))
-				Expect(err.Message).To(Equal("generate error"))
+				r.Size = 7
+				r.Offset = 2
+				r.Length = 3
+				Expect(res).To(Equal(r))
+				Expect(err).To(BeNil())
 			})
 		})
 
-		When("failed write file record", func() {
+		When("failed open file during checksum verification", func() {
 			It("should return error", func() {
+				p.VerifyChecksum = true
 				validator.
 					EXPECT().
-					Validate(gomock.Any()).
+					Validate(gomock.Eq(p)).
 					Return(nil).
 					Times(1)
 
-				locator.
+				fileRepo.
 					EXPECT().
-					GetLocation().
-					Return("2022/08/22").
+					RetrieveFile(gomock.Eq(ctx), gomock.Eq(retrieveParam)).
+					Return(retrieveRes, nil).
 					Times(1)
 
-				dirManager.
+				fileManager.
 					EXPECT().
-					IsDirectoryExists(gomock.Eq(ctx), gomock.Eq(dirExistsParam)).
-					Return(true, nil).
+					OpenFile(gomock.Eq(ctx), gomock.Eq(openParam)).
+					Return(nil, fmt.Errorf("disk error")).
 					Times(1)
 
-				dirManager.
+				res, err := s.RetrieveFile(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err.Code).To(Equal(int32(1001)))
+				Expect(err.Message).To(Equal("disk error"))
+			})
+		})
+
+		When("failed read file during checksum verification", func() {
+			It("should return error", func() {
+				p.VerifyChecksum = true
+				validator.
 					EXPECT().
-					CreateDir(gomock.Eq(ctx), gomock.Eq(createDirParam)).
-					Times(0)
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
 
-				reader.
+				fileRepo.
 					EXPECT().
-					Read(gomock.Any()).
-					Return(0, io.EOF).
+					RetrieveFile(gomock.Eq(ctx), gomock.Eq(retrieveParam)).
+					Return(retrieveRes, nil).
 					Times(1)
 
-				identifier.
+				fileManager.
 					EXPECT().
-					GenerateId().
-					Return("mock-unique-id", nil).
+					OpenFile(gomock.Eq(ctx), gomock.Eq(openParam)).
+					Return(&filesystem.OpenFileResult{
+						File: io.NopCloser(iotest.ErrReader(fmt.Errorf("disk error"))),
+					}, nil).
 					Times(1)
 
-				clock.
+				res, err := s.RetrieveFile(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err.Code).To(Equal(int32(1001)))
+				Expect(err.Message).To(Equal("disk error"))
+			})
+		})
+
+		When("stored file checksum is mismatch", func() {
+			It("should return error", func() {
+				p.VerifyChecksum = true
+				validator.
 					EXPECT().
-					Now().
-					Return(currentTs).
+					Validate(gomock.Eq(p)).
+					Return(nil).
 					Times(1)
 
 				fileRepo.
 					EXPECT().
-					CreateFile(gomock.Eq(ctx), gomock.Any()).
-					Return(nil, fmt.Errorf("db error")).
+					RetrieveFile(gomock.Eq(ctx), gomock.Eq(retrieveParam)).
+					Return(retrieveRes, nil).
 					Times(1)
 
-				res, err := s.UploadFile(ctx, opts...)
+				fileManager.
+					EXPECT().
+					OpenFile(gomock.Eq(ctx), gomock.Eq(openParam)).
+					Return(&filesystem.OpenFileResult{
+						File: io.NopCloser(strings.NewReader("corrupted")),
+					}, nil).
+					Times(1)
+
+				res, err := s.RetrieveFile(ctx, p)
 
 				Expect(res).To(BeNil())
 				Expect(err.Code).To(Equal(int32(1001)))
-				Expect(err.Message).To(Equal("db error"))
+				Expect(err.Message).To(Equal("file checksum mismatch"))
 			})
 		})
 
-		When("success upload file", func() {
+		When("stored file checksum is match", func() {
 			It("should return result", func() {
+				p.VerifyChecksum = true
 				validator.
 					EXPECT().
-					Validate(gomock.Any()).
+					Validate(gomock.Eq(p)).
 					Return(nil).
 					Times(1)
 
-				locator.
+				fileRepo.
 					EXPECT().
-					GetLocation().
-					Return("2022/08/22").
+					RetrieveFile(gomock.Eq(ctx), gomock.Eq(retrieveParam)).
+					Return(retrieveRes, nil).
 					Times(1)
 
-				dirManager.
+				gomock.InOrder(
+					fileManager.
+						EXPECT().
+						OpenFile(gomock.Eq(ctx), gomock.Eq(openParam)).
+						Return(&filesystem.OpenFileResult{
+							File: io.NopCloser(strings.NewReader("content")),
+						}, nil).
+						Times(1),
+					fileManager.
+						EXPECT().
+						OpenFile(gomock.Eq(ctx), gomock.Eq(openParam)).
+						Return(openRes, nil).
+						Times(1),
+				)
+
+				res, err := s.RetrieveFile(ctx, p)
+
+				Expect(res).To(Equal(r))
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("file has no stored checksum", func() {
+			It("should skip checksum verification", func() {
+				p.VerifyChecksum = true
+				retrieveRes.ChecksumSha256 = ""
+				r.Checksum.Sha256 = ""
+				validator.
 					EXPECT().
-					IsDirectoryExists(gomock.Eq(ctx), gomock.Eq(dirExistsParam)).
-					Return(true, nil).
+					Validate(gomock.Eq(p)).
+					Return(nil).
 					Times(1)
 
-				dirManager.
+				fileRepo.
 					EXPECT().
-					CreateDir(gomock.Eq(ctx), gomock.Eq(createDirParam)).
-					Times(0)
+					RetrieveFile(gomock.Eq(ctx), gomock.Eq(retrieveParam)).
+					Return(retrieveRes, nil).
+					Times(1)
+
+				fileManager.
+					EXPECT().
+					OpenFile(gomock.Eq(ctx), gomock.Eq(openParam)).
+					Return(openRes, nil).
+					Times(1)
+
+				res, err := s.RetrieveFile(ctx, p)
+
+				Expect(res).To(Equal(r))
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("file is encrypted", func() {
+			It("should open file using the encryption key", func() {
+				retrieveRes.EncryptionKeyId = "key-1"
+				retrieveRes.EncryptionDataKey = "mock-data-key"
+				openParam.EncryptionKey = &filesystem.EncryptionKey{
+					KeyId:   "key-1",
+					DataKey: "mock-data-key",
+				}
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
 
-				reader.
+				fileRepo.
 					EXPECT().
-					Read(gomock.Any()).
-					Return(0, io.EOF).
+					RetrieveFile(gomock.Eq(ctx), gomock.Eq(retrieveParam)).
+					Return(retrieveRes, nil).
 					Times(1)
 
-				identifier.
+				fileManager.
 					EXPECT().
-					GenerateId().
-					Return("mock-unique-id", nil).
+					OpenFile(gomock.Eq(ctx), gomock.Eq(openParam)).
+					Return(openRes, nil).
 					Times(1)
 
-				clock.
+				res, err := s.RetrieveFile(ctx, p)
+
+				Expect(res).To(Equal(r))
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("file is compressed", func() {
+			It("should open the decompressed file", func() {
+				p.AcceptEncodings = []string{"deflate", "gzip;q=0"}
+				retrieveRes.Compression = "gzip"
+				openParam.Compression = "gzip"
+				r.Compression = "gzip"
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				fileRepo.
+					EXPECT().
+					RetrieveFile(gomock.Eq(ctx), gomock.Eq(retrieveParam)).
+					Return(retrieveRes, nil).
+					Times(1)
+
+				fileManager.
+					EXPECT().
+					OpenFile(gomock.Eq(ctx), gomock.Eq(openParam)).
+					Return(openRes, nil).
+					Times(1)
+
+				res, err := s.RetrieveFile(ctx, p)
+
+				Expect(res).To(Equal(r))
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("compressed encoding is accepted", func() {
+			It("should open the compressed file", func() {
+				p.AcceptEncodings = []string{"br", "GZIP ; q=0.8"}
+				retrieveRes.Compression = "gzip"
+				r.Compression = "gzip"
+				r.ContentEncoding = "gzip"
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				fileRepo.
+					EXPECT().
+					RetrieveFile(gomock.Eq(ctx), gomock.Eq(retrieveParam)).
+					Return(retrieveRes, nil).
+					Times(1)
+
+				fileManager.
+					EXPECT().
+					OpenFile(gomock.Eq(ctx), gomock.Eq(openParam)).
+					Return(openRes, nil).
+					Times(1)
+
+				res, err := s.RetrieveFile(ctx, p)
+
+				Expect(res).To(Equal(r))
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("selection is failed", func() {
+			It("should return error", func() {
+				p.Select = func(meta *service.RetrieveFileResult) (*service.RetrieveFileSelection, *system.Error) {
+					return nil, &system.Error{
+						Code:    2005,
+						Message: "file range is not satisfiable",
+					}
+				}
+				validator.
+					EXPECT().
+					Validate(gomock.Any()).
+					Return(nil).
+					Times(1)
+
+				fileRepo.
+					EXPECT().
+					RetrieveFile(gomock.Eq(ctx), gomock.Eq(retrieveParam)).
+					Return(retrieveRes, nil).
+					Times(1)
+
+				res, err := s.RetrieveFile(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(&system.Error{
+					Code:    2005,
+					Message: "file range is not satisfiable",
+				}))
+			})
+		})
+
+		When("selection is skipped", func() {
+			It("should return result without data", func() {
+				var selectMeta *service.RetrieveFileResult
+				p.AcceptEncodings = []string{"gzip"}
+				p.Select = func(meta *service.RetrieveFileResult) (*service.RetrieveFileSelection, *system.Error) {
+					selectMeta = meta
+					return &service.RetrieveFileSelection{Skip: true}, nil
+				}
+				retrieveRes.Size = 7
+				retrieveRes.Compression = "gzip"
+				validator.
+					EXPECT().
+					Validate(gomock.Any()).
+					Return(nil).
+					Times(1)
+
+				fileRepo.
+					EXPECT().
+					RetrieveFile(gomock.Eq(ctx), gomock.Eq(retrieveParam)).
+					Return(retrieveRes, nil).
+					Times(1)
+
+				res, err := s.RetrieveFile(ctx, p)
+
+				r.Data = nil
+				r.Size = 7
+				r.Length = 7
+				r.Compression = "gzip"
+				r.ContentEncoding = "gzip"
+				Expect(res).To(Equal(r))
+				Expect(res).To(BeIdenticalTo(selectMeta))
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("selection is ranged", func() {
+			It("should open the ranged file as is", func() {
+				p.AcceptEncodings = []string{"gzip"}
+				p.Select = func(meta *service.RetrieveFileResult) (*service.RetrieveFileSelection, *system.Error) {
+					return &service.RetrieveFileSelection{Offset: 2, Length: 3}, nil
+				}
+				retrieveRes.Size = 7
+				retrieveRes.Compression = "gzip"
+				validator.
+					EXPECT().
+					Validate(gomock.Any()).
+					Return(nil).
+					Times(1)
+
+				fileRepo.
+					EXPECT().
+					RetrieveFile(gomock.Eq(ctx), gomock.Eq(retrieveParam)).
+					Return(retrieveRes, nil).
+					Times(1)
+
+				openParam.Offset = 2
+				openParam.Length = 3
+				openParam.Compression = "gzip"
+				fileManager.
+					EXPECT().
+					OpenFile(gomock.Eq(ctx), gomock.Eq(openParam)).
+					Return(openRes, nil).
+					Times(1)
+
+				res, err := s.RetrieveFile(ctx, p)
+
+				r.Size = 7
+				r.Offset = 2
+				r.Length = 3
+				r.Compression = "gzip"
+				Expect(res).To(Equal(r))
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("selected range is not satisfiable", func() {
+			It("should return error", func() {
+				p.Select = func(meta *service.RetrieveFileResult) (*service.RetrieveFileSelection, *system.Error) {
+					return &service.RetrieveFileSelection{Offset: 7}, nil
+				}
+				retrieveRes.Size = 7
+				validator.
+					EXPECT().
+					Validate(gomock.Any()).
+					Return(nil).
+					Times(1)
+
+				fileRepo.
+					EXPECT().
+					RetrieveFile(gomock.Eq(ctx), gomock.Eq(retrieveParam)).
+					Return(retrieveRes, nil).
+					Times(1)
+
+				res, err := s.RetrieveFile(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(&system.Error{
+					Code:    2005,
+					Message: "file range is not satisfiable",
+				}))
+			})
+		})
+	})
+
+	Context("UploadFile function", Label("unit"), func() {
+		var (
+			ctx            context.Context
+			currentTs      time.Time
+			fileRepo       *mock_repository.MockFile
+			fileManager    *mock_filesystem.MockFileManager
+			dirManager     *mock_filesystem.MockDirectoryManager
+			logger         *mock_logging.MockLogger
+			reader         *mock_io.MockReader
+			identifier     *mock_identifier.MockIdentifier
+			clock          *mock_datetime.MockClock
+			locator        *mock_file.MockUploadLocation
+			validator      *mock_validation.MockValidator
+			s              service.File
+			dirExistsParam filesystem.IsDirectoryExistsParam
+			createDirParam filesystem.CreateDirParam
+			createFileRes  *repository.CreateFileResult
+			locationParam  file.GetLocationParam
+			opts           []service.UploadFileOption
+			r              *service.UploadFileResult
+			authRepo       *mock_repository.MockAuth
+			quotaService   service.File
+			findClientRes  *repository.FindClientResult
+		)
+
+		BeforeEach(func() {
+			currentTs = time.Now().UTC()
+			ctx = context.Background()
+			t := GinkgoT()
+			ctrl := gomock.NewController(t)
+			fileRepo = mock_repository.NewMockFile(ctrl)
+			fileManager = mock_filesystem.NewMockFileManager(ctrl)
+			dirManager = mock_filesystem.NewMockDirectoryManager(ctrl)
+			logger = mock_logging.NewMockLogger(ctrl)
+			identifier = mock_identifier.NewMockIdentifier(ctrl)
+			clock = mock_datetime.NewMockClock(ctrl)
+			locator = mock_file.NewMockUploadLocation(ctrl)
+			validator = mock_validation.NewMockValidator(ctrl)
+			reader = mock_io.NewMockReader(ctrl)
+			s = service.NewFile(service.FileParam{
+				FileRepo:    fileRepo,
+				FileManager: fileManager,
+				DirManager:  dirManager,
+				Logger:      logger,
+				Identifier:  identifier,
+				Clock:       clock,
+				Locator:     locator,
+				Validator:   validator,
+				Config: &service.FileConfig{
+					UploadDir: "temp",
+				},
+			})
+			dirExistsParam = filesystem.IsDirectoryExistsParam{
+				Path: "temp/2022/08/22",
+			}
+			createDirParam = filesystem.CreateDirParam{
+				Path:       "temp/2022/08/22",
+				Permission: 0644,
+			}
+			createFileRes = &repository.CreateFileResult{
+				UniqueId:       "mock-unique-id",
+				Name:           "mock-name",
+				Path:           "mock-path",
+				Mimetype:       "mock-mimetype",
+				Extension:      "mock-extension",
+				Size:           200,
+				CreatedAt:      currentTs,
+				ChecksumSha256: "mock-sha256",
+				ChecksumMd5:    "mock-md5",
+			}
+			dataOpt := service.WithReader(reader)
+			infoOpt := service.WithFileInfo("mock-name", "image/jpeg", "jpg", 100)
+			locationOpt := service.WithLocation("mock-client-id", "mock-bucket")
+			opts = []service.UploadFileOption{dataOpt, infoOpt, locationOpt}
+			locationParam = file.GetLocationParam{
+				UniqueId: "mock-unique-id",
+				ClientId: "mock-client-id",
+				Bucket:   "mock-bucket",
+				Mimetype: "image/jpeg",
+			}
+			authRepo = mock_repository.NewMockAuth(ctrl)
+			quotaService = service.NewFile(service.FileParam{
+				FileRepo:    fileRepo,
+				AuthRepo:    authRepo,
+				FileManager: fileManager,
+				DirManager:  dirManager,
+				Logger:      logger,
+				Identifier:  identifier,
+				Clock:       clock,
+				Locator:     locator,
+				Validator:   validator,
+				Config: &service.FileConfig{
+					UploadDir: "temp",
+				},
+			})
+			findClientRes = &repository.FindClientResult{
+				ClientId: "mock-client-id",
+				Quota: repository.ClientQuota{
+					MaxStoredSize:   1000,
+					MaxTotalFiles:   10,
+					MaxFileSize:     500,
+					MaxDailyUploads: 5,
+				},
+				Usage: repository.ClientUsage{
+					StoredSize:   300,
+					TotalFiles:   3,
+					DailyUploads: 3,
+					DailyAt:      currentTs.Truncate(24 * time.Hour),
+				},
+			}
+			r = &service.UploadFileResult{
+				Success: system.Success{
+					Code:    1000,
+					Message: "success upload file",
+				},
+				UniqueId:   "mock-unique-id",
+				Name:       "mock-name",
+				Path:       "mock-path",
+				Mimetype:   "mock-mimetype",
+				Extension:  "mock-extension",
+				Size:       200,
+				UploadedAt: currentTs,
+				Checksum: file.Checksum{
+					Sha256: "mock-sha256",
+					Md5:    "mock-md5",
+				},
+			}
+
+			logger.
+				EXPECT().
+				Debug("In function: UploadFile").
+				Times(1)
+			logger.
+				EXPECT().
+				Debug("Returning function: UploadFile").
+				Times(1)
+		})
+
+		When("there is invalid data", func() {
+			It("should return error", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Any()).
+					Return(fmt.Errorf("invalid data")).
+					Times(1)
+
+				res, err := s.UploadFile(ctx)
+
+				Expect(res).To(BeNil())
+				Expect(err.Code).To(Equal(int32(1002)))
+				Expect(err.Message).To(Equal("invalid data"))
+			})
+		})
+
+		When("file is not specified", func() {
+			It("should return error", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Any()).
+					Return(nil).
+					Times(1)
+
+				res, err := s.UploadFile(ctx)
+
+				Expect(res).To(BeNil())
+				Expect(err.Code).To(Equal(int32(1002)))
+				Expect(err.Message).To(Equal("file is not specified"))
+			})
+		})
+
+		When("file is not allowed", func() {
+			It("should return error", func() {
+				policy := mock_file.NewMockUploadPolicy(gomock.NewController(GinkgoT()))
+				s = service.NewFile(service.FileParam{
+					Logger:    logger,
+					Policy:    policy,
+					Validator: validator,
+				})
+				validator.
+					EXPECT().
+					Validate(gomock.Any()).
+					Return(nil).
+					Times(1)
+
+				policy.
+					EXPECT().
+					CheckFile(gomock.Eq(file.CheckFileParam{
+						Header:    []byte("MZ\x90\x00"),
+						Mimetype:  "image/jpeg",
+						Extension: "jpg",
+					})).
+					Return(nil, file.ErrExtensionMismatch).
+					Times(1)
+
+				res, err := s.UploadFile(
+					ctx,
+					service.WithReader(strings.NewReader("MZ\x90\x00")),
+					service.WithFileInfo("mock-name", "image/jpeg", "jpg", 4),
+				)
+
+				Expect(res).To(BeNil())
+				Expect(err.Code).To(Equal(int32(2007)))
+				Expect(err.Message).To(Equal("extension does not match the content"))
+			})
+		})
+
+		When("file is allowed", func() {
+			It("should keep the declared mimetype", func() {
+				policy := mock_file.NewMockUploadPolicy(gomock.NewController(GinkgoT()))
+				s = service.NewFile(service.FileParam{
+					FileRepo:    fileRepo,
+					FileManager: fileManager,
+					DirManager:  dirManager,
+					Logger:      logger,
+					Identifier:  identifier,
+					Clock:       clock,
+					Locator:     locator,
+					Policy:      policy,
+					Validator:   validator,
+					Config: &service.FileConfig{
+						UploadDir: "temp",
+					},
+				})
+				validator.
+					EXPECT().
+					Validate(gomock.Any()).
+					Return(nil).
+					Times(1)
+
+				policy.
+					EXPECT().
+					CheckFile(gomock.Any()).
+					Return(&file.CheckFileResult{
+						Mimetype: "text/plain; charset=utf-8",
+					}, nil).
+					Times(1)
+
+				locator.
+					EXPECT().
+					GetLocation(gomock.Eq(locationParam)).
+					Return("2022/08/22").
+					Times(1)
+
+				dirManager.
+					EXPECT().
+					IsDirectoryExists(gomock.Eq(ctx), gomock.Eq(dirExistsParam)).
+					Return(true, nil).
+					Times(1)
+
+				identifier.
+					EXPECT().
+					GenerateId().
+					Return("mock-unique-id", nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				fileRepo.
+					EXPECT().
+					CreateFile(gomock.Eq(ctx), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, p repository.CreateFileParam) (*repository.CreateFileResult, error) {
+						Expect(p.Mimetype).To(Equal("image/jpeg"))
+						return createFileRes, nil
+					}).
+					Times(1)
+
+				res, err := s.UploadFile(
+					ctx,
+					service.WithReader(strings.NewReader("id,name")),
+					service.WithFileInfo("mock-name", "image/jpeg", "jpg", 7),
+					service.WithLocation("mock-client-id", "mock-bucket"),
+				)
+
+				Expect(err).To(BeNil())
+				Expect(res).To(Equal(r))
+			})
+		})
+
+		When("failed check directory existance", func() {
+			It("should return error", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Any()).
+					Return(nil).
+					Times(1)
+
+				identifier.
+					EXPECT().
+					GenerateId().
+					Return("mock-unique-id", nil).
+					Times(1)
+
+				locator.
+					EXPECT().
+					GetLocation(gomock.Eq(locationParam)).
+					Return("2022/08/22").
+					Times(1)
+
+				dirManager.
+					EXPECT().
+					IsDirectoryExists(gomock.Eq(ctx), gomock.Eq(dirExistsParam)).
+					Return(false, fmt.Errorf("disk error")).
+					Times(1)
+
+				res, err := s.UploadFile(ctx, opts...)
+
+				Expect(res).To(BeNil())
+				Expect(err.Code).To(Equal(int32(1001)))
+				Expect(err.Message).To(Equal("disk error"))
+			})
+		})
+
+		When("failed create upload directory", func() {
+			It("should return error", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Any()).
+					Return(nil).
+					Times(1)
+
+				identifier.
+					EXPECT().
+					GenerateId().
+					Return("mock-unique-id", nil).
+					Times(1)
+
+				locator.
+					EXPECT().
+					GetLocation(gomock.Eq(locationParam)).
+					Return("2022/08/22").
+					Times(1)
+
+				dirManager.
+					EXPECT().
+					IsDirectoryExists(gomock.Eq(ctx), gomock.Eq(dirExistsParam)).
+					Return(false, nil).
+					Times(1)
+				dirManager.
+					EXPECT().
+					CreateDir(gomock.Eq(ctx), gomock.Eq(createDirParam)).
+					Return(nil, fmt.Errorf("i/o error")).
+					Times(1)
+
+				res, err := s.UploadFile(ctx, opts...)
+
+				Expect(res).To(BeNil())
+				Expect(err.Code).To(Equal(int32(1001)))
+				Expect(err.Message).To(Equal("i/o error"))
+			})
+		})
+
+		When("failed generate file id", func() {
+			It("should return error", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Any()).
+					Return(nil).
+					Times(1)
+
+				identifier.
+					EXPECT().
+					GenerateId().
+					Return("", fmt.Errorf("generate error")).
+					Times(1)
+
+				res, err := s.UploadFile(ctx, opts...)
+
+				Expect(res).To(BeNil())
+				Expect(err.Code).To(Equal(int32(1001)))
+				Expect(err.Message).To(Equal("generate error"))
+			})
+		})
+
+		When("failed write file record", func() {
+			It("should return error", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Any()).
+					Return(nil).
+					Times(1)
+
+				locator.
+					EXPECT().
+					GetLocation(gomock.Eq(locationParam)).
+					Return("2022/08/22").
+					Times(1)
+
+				dirManager.
+					EXPECT().
+					IsDirectoryExists(gomock.Eq(ctx), gomock.Eq(dirExistsParam)).
+					Return(true, nil).
+					Times(1)
+
+				dirManager.
+					EXPECT().
+					CreateDir(gomock.Eq(ctx), gomock.Eq(createDirParam)).
+					Times(0)
+
+				identifier.
+					EXPECT().
+					GenerateId().
+					Return("mock-unique-id", nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				fileRepo.
+					EXPECT().
+					CreateFile(gomock.Eq(ctx), gomock.Any()).
+					Return(nil, fmt.Errorf("db error")).
+					Times(1)
+
+				res, err := s.UploadFile(ctx, opts...)
+
+				Expect(res).To(BeNil())
+				Expect(err.Code).To(Equal(int32(1001)))
+				Expect(err.Message).To(Equal("db error"))
+			})
+		})
+
+		When("file is infected", func() {
+			It("should return error", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Any()).
+					Return(nil).
+					Times(1)
+
+				locator.
+					EXPECT().
+					GetLocation(gomock.Eq(locationParam)).
+					Return("2022/08/22").
+					Times(1)
+
+				dirManager.
+					EXPECT().
+					IsDirectoryExists(gomock.Eq(ctx), gomock.Eq(dirExistsParam)).
+					Return(true, nil).
+					Times(1)
+
+				identifier.
+					EXPECT().
+					GenerateId().
+					Return("mock-unique-id", nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				fileRepo.
+					EXPECT().
+					CreateFile(gomock.Eq(ctx), gomock.Any()).
+					Return(nil, fmt.Errorf("%w: Eicar-Signature", file.ErrInfected)).
+					Times(1)
+
+				res, err := s.UploadFile(ctx, opts...)
+
+				Expect(res).To(BeNil())
+				Expect(err.Code).To(Equal(int32(2008)))
+				Expect(err.Message).To(Equal("file is infected: Eicar-Signature"))
+			})
+		})
+
+		When("file is not scanned", func() {
+			It("should return error", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Any()).
+					Return(nil).
+					Times(1)
+
+				locator.
+					EXPECT().
+					GetLocation(gomock.Eq(locationParam)).
+					Return("2022/08/22").
+					Times(1)
+
+				dirManager.
+					EXPECT().
+					IsDirectoryExists(gomock.Eq(ctx), gomock.Eq(dirExistsParam)).
+					Return(true, nil).
+					Times(1)
+
+				identifier.
+					EXPECT().
+					GenerateId().
+					Return("mock-unique-id", nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				fileRepo.
+					EXPECT().
+					CreateFile(gomock.Eq(ctx), gomock.Any()).
+					Return(nil, fmt.Errorf("%w: connection refused", scanner.ErrorUnavailable)).
+					Times(1)
+
+				res, err := s.UploadFile(ctx, opts...)
+
+				Expect(res).To(BeNil())
+				Expect(err.Code).To(Equal(int32(2009)))
+				Expect(err.Message).To(Equal("scanner is unavailable: connection refused"))
+			})
+		})
+
+		When("file exceeds the scanner limit", func() {
+			It("should return error", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Any()).
+					Return(nil).
+					Times(1)
+
+				locator.
+					EXPECT().
+					GetLocation(gomock.Eq(locationParam)).
+					Return("2022/08/22").
+					Times(1)
+
+				dirManager.
+					EXPECT().
+					IsDirectoryExists(gomock.Eq(ctx), gomock.Eq(dirExistsParam)).
+					Return(true, nil).
+					Times(1)
+
+				identifier.
+					EXPECT().
+					GenerateId().
+					Return("mock-unique-id", nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				fileRepo.
+					EXPECT().
+					CreateFile(gomock.Eq(ctx), gomock.Any()).
+					Return(nil, fmt.Errorf("%w: INSTREAM size limit exceeded.", scanner.ErrorLimitExceeded)).
+					Times(1)
+
+				res, err := s.UploadFile(ctx, opts...)
+
+				Expect(res).To(BeNil())
+				Expect(err.Code).To(Equal(int32(2012)))
+				Expect(err.Message).To(Equal("scanner limit is exceeded: INSTREAM size limit exceeded."))
+			})
+		})
+
+		When("failed find client", func() {
+			It("should return error", func() {
+				ctx := auth.WithIdentity(ctx, &auth.Identity{
+					Id:       "mock-id",
+					ClientId: "mock-client-id",
+				})
+				validator.
+					EXPECT().
+					Validate(gomock.Any()).
+					Return(nil).
+					Times(1)
+
+				authRepo.
+					EXPECT().
+					FindClient(gomock.Eq(ctx), gomock.Eq(repository.FindClientParam{
+						Id: "mock-id",
+					})).
+					Return(nil, fmt.Errorf("db error")).
+					Times(1)
+
+				res, err := quotaService.UploadFile(ctx, opts...)
+
+				Expect(res).To(BeNil())
+				Expect(err.Code).To(Equal(int32(1001)))
+				Expect(err.Message).To(Equal("db error"))
+			})
+		})
+
+		When("file size quota is exceeded", func() {
+			It("should return error", func() {
+				ctx := auth.WithIdentity(ctx, &auth.Identity{
+					Id:       "mock-id",
+					ClientId: "mock-client-id",
+				})
+				findClientRes.Quota.MaxFileSize = 50
+
+				validator.
+					EXPECT().
+					Validate(gomock.Any()).
+					Return(nil).
+					Times(1)
+
+				authRepo.
+					EXPECT().
+					FindClient(gomock.Eq(ctx), gomock.Any()).
+					Return(findClientRes, nil).
+					Times(1)
+
+				res, err := quotaService.UploadFile(ctx, opts...)
+
+				Expect(res).To(BeNil())
+				Expect(err.Code).To(Equal(int32(2011)))
+				Expect(err.Message).To(Equal("file size quota is exceeded"))
+			})
+		})
+
+		When("client quota is exceeded", func() {
+			It("should return error", func() {
+				ctx := auth.WithIdentity(ctx, &auth.Identity{
+					Id:       "mock-id",
+					ClientId: "mock-client-id",
+				})
+				findClientRes.Usage.TotalFiles = 10
+
+				validator.
+					EXPECT().
+					Validate(gomock.Any()).
+					Return(nil).
+					Times(1)
+
+				authRepo.
+					EXPECT().
+					FindClient(gomock.Eq(ctx), gomock.Any()).
+					Return(findClientRes, nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				res, err := quotaService.UploadFile(ctx, opts...)
+
+				Expect(res).To(BeNil())
+				Expect(err.Code).To(Equal(int32(2010)))
+				Expect(err.Message).To(Equal("quota exceeded: max total files"))
+			})
+		})
+
+		When("client quota is exceeded while reserving usage", func() {
+			It("should return error", func() {
+				ctx := auth.WithIdentity(ctx, &auth.Identity{
+					Id:       "mock-id",
+					ClientId: "mock-client-id",
+				})
+				validator.
+					EXPECT().
+					Validate(gomock.Any()).
+					Return(nil).
+					Times(1)
+
+				authRepo.
+					EXPECT().
+					FindClient(gomock.Eq(ctx), gomock.Any()).
+					Return(findClientRes, nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(2)
+
+				locator.
+					EXPECT().
+					GetLocation(gomock.Eq(locationParam)).
+					Return("2022/08/22").
+					Times(1)
+
+				dirManager.
+					EXPECT().
+					IsDirectoryExists(gomock.Eq(ctx), gomock.Eq(dirExistsParam)).
+					Return(true, nil).
+					Times(1)
+
+				identifier.
+					EXPECT().
+					GenerateId().
+					Return("mock-unique-id", nil).
+					Times(1)
+
+				fileRepo.
+					EXPECT().
+					CreateFile(gomock.Eq(ctx), gomock.Any()).
+					Return(nil, fmt.Errorf("%w: max stored size", repository.ErrExceeded)).
+					Times(1)
+
+				res, err := quotaService.UploadFile(ctx, opts...)
+
+				Expect(res).To(BeNil())
+				Expect(err.Code).To(Equal(int32(2010)))
+				Expect(err.Message).To(Equal("quota exceeded: max stored size"))
+			})
+		})
+
+		When("failed create file after usage is reserved", func() {
+			It("should release the usage", func() {
+				ctx := auth.WithIdentity(ctx, &auth.Identity{
+					Id:       "mock-id",
+					ClientId: "mock-client-id",
+				})
+				validator.
+					EXPECT().
+					Validate(gomock.Any()).
+					Return(nil).
+					Times(1)
+
+				authRepo.
+					EXPECT().
+					FindClient(gomock.Eq(ctx), gomock.Any()).
+					Return(findClientRes, nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(2)
+
+				locator.
+					EXPECT().
+					GetLocation(gomock.Eq(locationParam)).
+					Return("2022/08/22").
+					Times(1)
+
+				dirManager.
+					EXPECT().
+					IsDirectoryExists(gomock.Eq(ctx), gomock.Eq(dirExistsParam)).
+					Return(true, nil).
+					Times(1)
+
+				identifier.
+					EXPECT().
+					GenerateId().
+					Return("mock-unique-id", nil).
+					Times(1)
+
+				fileManager.
+					EXPECT().
+					SaveFile(gomock.Eq(ctx), gomock.Any()).
+					Return(&filesystem.SaveFileResult{Size: 120}, nil).
+					Times(1)
+
+				authRepo.
+					EXPECT().
+					UpdateClientUsage(gomock.Eq(ctx), gomock.Eq(repository.UpdateClientUsageParam{
+						Id:           "mock-id",
+						StoredSize:   120,
+						TotalFiles:   1,
+						DailyUploads: 1,
+						UsedAt:       currentTs,
+						CheckQuota:   true,
+					})).
+					Return(&repository.UpdateClientUsageResult{}, nil).
+					Times(1)
+
+				fileRepo.
+					EXPECT().
+					CreateFile(gomock.Eq(ctx), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, p repository.CreateFileParam) (*repository.CreateFileResult, error) {
+						_, err := p.CreateFn(ctx, repository.CreateFnParam{
+							FilePath: p.Path,
+						})
+						if err != nil {
+							return nil, err
+						}
+						return nil, fmt.Errorf("db error")
+					}).
+					Times(1)
+
+				authRepo.
+					EXPECT().
+					UpdateClientUsage(gomock.Eq(ctx), gomock.Eq(repository.UpdateClientUsageParam{
+						Id:         "mock-id",
+						StoredSize: -120,
+						TotalFiles: -1,
+						UsedAt:     currentTs,
+					})).
+					Return(&repository.UpdateClientUsageResult{}, nil).
+					Times(1)
+
+				res, err := quotaService.UploadFile(ctx, opts...)
+
+				Expect(res).To(BeNil())
+				Expect(err.Code).To(Equal(int32(1001)))
+				Expect(err.Message).To(Equal("db error"))
+			})
+		})
+
+		When("success upload file", func() {
+			It("should return result", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Any()).
+					Return(nil).
+					Times(1)
+
+				locator.
+					EXPECT().
+					GetLocation(gomock.Eq(locationParam)).
+					Return("2022/08/22").
+					Times(1)
+
+				dirManager.
+					EXPECT().
+					IsDirectoryExists(gomock.Eq(ctx), gomock.Eq(dirExistsParam)).
+					Return(true, nil).
+					Times(1)
+
+				dirManager.
+					EXPECT().
+					CreateDir(gomock.Eq(ctx), gomock.Eq(createDirParam)).
+					Times(0)
+
+				identifier.
+					EXPECT().
+					GenerateId().
+					Return("mock-unique-id", nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				fileRepo.
+					EXPECT().
+					CreateFile(gomock.Eq(ctx), gomock.Any()).
+					Return(createFileRes, nil).
+					Times(1)
+
+				res, err := s.UploadFile(ctx, opts...)
+
+				Expect(res).To(Equal(r))
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("client is authenticated", func() {
+			It("should record the client as the owner", func() {
+				ctx := auth.WithIdentity(ctx, &auth.Identity{
+					Id:       "owner-id",
+					ClientId: "owner-client-id",
+				})
+				opts := []service.UploadFileOption{
+					service.WithReader(reader),
+					service.WithFileInfo("mock-name", "image/jpeg", "jpg", 100),
+				}
+				locationParam.ClientId = "owner-client-id"
+				locationParam.Bucket = ""
+
+				validator.
+					EXPECT().
+					Validate(gomock.Any()).
+					Return(nil).
+					Times(1)
+
+				locator.
+					EXPECT().
+					GetLocation(gomock.Eq(locationParam)).
+					Return("2022/08/22").
+					Times(1)
+
+				dirManager.
+					EXPECT().
+					IsDirectoryExists(gomock.Eq(ctx), gomock.Eq(dirExistsParam)).
+					Return(true, nil).
+					Times(1)
+
+				identifier.
+					EXPECT().
+					GenerateId().
+					Return("mock-unique-id", nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				fileRepo.
+					EXPECT().
+					CreateFile(gomock.Eq(ctx), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, p repository.CreateFileParam) (*repository.CreateFileResult, error) {
+						Expect(p.OwnerClientId).To(Equal("owner-id"))
+						return createFileRes, nil
+					}).
+					Times(1)
+
+				res, err := s.UploadFile(ctx, opts...)
+
+				Expect(res).To(Equal(r))
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("file is infected before the record is created", func() {
+			It("should return error", func() {
+				fileScanner := mock_scanner.NewMockScanner(gomock.NewController(GinkgoT()))
+				scanService := service.NewFile(service.FileParam{
+					FileRepo:    fileRepo,
+					FileManager: fileManager,
+					DirManager:  dirManager,
+					Logger:      logger,
+					Identifier:  identifier,
+					Clock:       clock,
+					Locator:     locator,
+					Scanner:     fileScanner,
+					Validator:   validator,
+					Config: &service.FileConfig{
+						UploadDir: "temp",
+					},
+				})
+				opts[0] = service.WithReader(strings.NewReader("content"))
+				validator.
+					EXPECT().
+					Validate(gomock.Any()).
+					Return(nil).
+					Times(1)
+
+				locator.
+					EXPECT().
+					GetLocation(gomock.Eq(locationParam)).
+					Return("2022/08/22").
+					Times(1)
+
+				dirManager.
+					EXPECT().
+					IsDirectoryExists(gomock.Eq(ctx), gomock.Eq(dirExistsParam)).
+					Return(true, nil).
+					Times(1)
+
+				identifier.
+					EXPECT().
+					GenerateId().
+					Return("mock-unique-id", nil).
+					Times(1)
+
+				fileManager.
+					EXPECT().
+					SaveFile(gomock.Eq(ctx), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, p filesystem.SaveFileParam) (*filesystem.SaveFileResult, error) {
+						data, err := io.ReadAll(p.Reader)
+						return &filesystem.SaveFileResult{Size: int64(len(data))}, err
+					}).
+					Times(1)
+
+				fileScanner.
+					EXPECT().
+					ScanFile(gomock.Eq(ctx), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, p scanner.ScanFileParam) (*scanner.ScanFileResult, error) {
+						io.ReadAll(p.Reader)
+						return &scanner.ScanFileResult{Infected: true, Signature: "Eicar-Signature"}, nil
+					}).
+					Times(1)
+
+				fileManager.
+					EXPECT().
+					RemoveFile(gomock.Eq(ctx), gomock.Eq(filesystem.RemoveFileParam{
+						Path: "temp/2022/08/22/mock-unique-id.jpg",
+					})).
+					Return(&filesystem.RemoveFileResult{}, nil).
+					Times(1)
+
+				res, err := scanService.UploadFile(ctx, opts...)
+
+				Expect(res).To(BeNil())
+				Expect(err.Code).To(Equal(int32(2008)))
+				Expect(err.Message).To(Equal("file is infected: Eicar-Signature"))
+			})
+		})
+
+		When("failed create the scanned file record", func() {
+			It("should remove the saved file", func() {
+				fileScanner := mock_scanner.NewMockScanner(gomock.NewController(GinkgoT()))
+				scanService := service.NewFile(service.FileParam{
+					FileRepo:    fileRepo,
+					FileManager: fileManager,
+					DirManager:  dirManager,
+					Logger:      logger,
+					Identifier:  identifier,
+					Clock:       clock,
+					Locator:     locator,
+					Scanner:     fileScanner,
+					Validator:   validator,
+					Config: &service.FileConfig{
+						UploadDir: "temp",
+					},
+				})
+				opts[0] = service.WithReader(strings.NewReader("content"))
+				validator.
+					EXPECT().
+					Validate(gomock.Any()).
+					Return(nil).
+					Times(1)
+
+				locator.
+					EXPECT().
+					GetLocation(gomock.Eq(locationParam)).
+					Return("2022/08/22").
+					Times(1)
+
+				dirManager.
+					EXPECT().
+					IsDirectoryExists(gomock.Eq(ctx), gomock.Eq(dirExistsParam)).
+					Return(true, nil).
+					Times(1)
+
+				identifier.
+					EXPECT().
+					GenerateId().
+					Return("mock-unique-id", nil).
+					Times(1)
+
+				fileManager.
+					EXPECT().
+					SaveFile(gomock.Eq(ctx), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, p filesystem.SaveFileParam) (*filesystem.SaveFileResult, error) {
+						data, err := io.ReadAll(p.Reader)
+						return &filesystem.SaveFileResult{Size: int64(len(data))}, err
+					}).
+					Times(1)
+
+				fileScanner.
+					EXPECT().
+					ScanFile(gomock.Eq(ctx), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, p scanner.ScanFileParam) (*scanner.ScanFileResult, error) {
+						io.ReadAll(p.Reader)
+						return &scanner.ScanFileResult{}, nil
+					}).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				fileRepo.
+					EXPECT().
+					CreateFile(gomock.Eq(ctx), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, p repository.CreateFileParam) (*repository.CreateFileResult, error) {
+						res, err := p.CreateFn(ctx, repository.CreateFnParam{
+							FilePath: p.Path,
+						})
+						Expect(err).To(BeNil())
+						Expect(res.Size).To(Equal(int64(7)))
+						return nil, fmt.Errorf("db error")
+					}).
+					Times(1)
+
+				fileManager.
+					EXPECT().
+					RemoveFile(gomock.Eq(ctx), gomock.Eq(filesystem.RemoveFileParam{
+						Path: "temp/2022/08/22/mock-unique-id.jpg",
+					})).
+					Return(nil, fmt.Errorf("disk error")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					Warnf("Failed removing saved file %s, err: %s", "temp/2022/08/22/mock-unique-id.jpg", "disk error").
+					Times(1)
+
+				res, err := scanService.UploadFile(ctx, opts...)
+
+				Expect(res).To(BeNil())
+				Expect(err.Code).To(Equal(int32(1001)))
+				Expect(err.Message).To(Equal("db error"))
+			})
+		})
+	})
+
+	Context("NewCreateFn function", Label("unit"), func() {
+		var (
+			ctx           context.Context
+			reader        io.Reader
+			fileManager   *mock_filesystem.MockFileManager
+			fn            repository.CreateFn
+			createFnParam repository.CreateFnParam
+			saveFile      func(ctx context.Context, p filesystem.SaveFileParam) (*filesystem.SaveFileResult, error)
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			t := GinkgoT()
+			ctrl := gomock.NewController(t)
+			reader = strings.NewReader("content")
+			fileManager = mock_filesystem.NewMockFileManager(ctrl)
+			fn = service.NewCreateFn(reader, "text/plain", fileManager, false)
+			createFnParam = repository.CreateFnParam{
+				FilePath: "mock/path/name.jpg",
+			}
+			saveFile = func(ctx context.Context, p filesystem.SaveFileParam) (*filesystem.SaveFileResult, error) {
+				if p.Name != createFnParam.FilePath || p.Permission != 0644 || !p.Exclusive || p.Mimetype != "text/plain" {
+					return nil, fmt.Errorf("invalid save param")
+				}
+				data, err := io.ReadAll(p.Reader)
+				if err != nil {
+					return nil, err
+				}
+				return &filesystem.SaveFileResult{
+					Size: int64(len(data)),
+				}, nil
+			}
+		})
+
+		When("file already exists", func() {
+			It("should return error", func() {
+				fileManager.
+					EXPECT().
+					SaveFile(gomock.Eq(ctx), gomock.Any()).
+					Return(nil, filesystem.ErrorFileExists).
+					Times(1)
+
+				res, err := fn(ctx, createFnParam)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(file.ErrExists))
+			})
+		})
+
+		When("failed save file", func() {
+			It("should return error", func() {
+				fileManager.
+					EXPECT().
+					SaveFile(gomock.Eq(ctx), gomock.Any()).
+					Return(nil, fmt.Errorf("disk error")).
+					Times(1)
+
+				res, err := fn(ctx, createFnParam)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("disk error")))
+			})
+		})
+
+		When("success save file", func() {
+			It("should return result", func() {
+				fileManager.
+					EXPECT().
+					SaveFile(gomock.Eq(ctx), gomock.Any()).
+					DoAndReturn(saveFile).
+					Times(1)
+
+				res, err := fn(ctx, createFnParam)
+
+				Expect(res).To(Equal(&repository.CreateFnResult{
+					Size:           7,
+					ChecksumSha256: "ed7002b439e9ac845f22357d822bac1444730fbdb6016d3ec9432297b9ec9f73",
+				}))
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("md5 checksum is enabled", func() {
+			It("should return result", func() {
+				fn := service.NewCreateFn(reader, "text/plain", fileManager, true)
+
+				fileManager.
+					EXPECT().
+					SaveFile(gomock.Eq(ctx), gomock.Any()).
+					DoAndReturn(saveFile).
+					Times(1)
+
+				res, err := fn(ctx, createFnParam)
+
+				Expect(res).To(Equal(&repository.CreateFnResult{
+					Size:           7,
+					ChecksumSha256: "ed7002b439e9ac845f22357d822bac1444730fbdb6016d3ec9432297b9ec9f73",
+					ChecksumMd5:    "9a0364b9e99bb480dd25e1f0284c8555",
+				}))
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("file is encrypted", func() {
+			It("should return the encryption key", func() {
+				fileManager.
+					EXPECT().
+					SaveFile(gomock.Eq(ctx), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, p filesystem.SaveFileParam) (*filesystem.SaveFileResult, error) {
+						res, err := saveFile(ctx, p)
+						if err != nil {
+							return nil, err
+						}
+						res.EncryptionKey = &filesystem.EncryptionKey{
+							KeyId:   "key-1",
+							DataKey: "mock-data-key",
+						}
+						return res, nil
+					}).
+					Times(1)
+
+				res, err := fn(ctx, createFnParam)
+
+				Expect(res).To(Equal(&repository.CreateFnResult{
+					Size:              7,
+					ChecksumSha256:    "ed7002b439e9ac845f22357d822bac1444730fbdb6016d3ec9432297b9ec9f73",
+					EncryptionKeyId:   "key-1",
+					EncryptionDataKey: "mock-data-key",
+				}))
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("file is compressed", func() {
+			It("should return the compression", func() {
+				fileManager.
+					EXPECT().
+					SaveFile(gomock.Eq(ctx), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, p filesystem.SaveFileParam) (*filesystem.SaveFileResult, error) {
+						res, err := saveFile(ctx, p)
+						if err != nil {
+							return nil, err
+						}
+						res.StoredSize = 5
+						res.Compression = "gzip"
+						return res, nil
+					}).
+					Times(1)
+
+				res, err := fn(ctx, createFnParam)
+
+				Expect(res).To(Equal(&repository.CreateFnResult{
+					Size:           7,
+					StoredSize:     5,
+					ChecksumSha256: "ed7002b439e9ac845f22357d822bac1444730fbdb6016d3ec9432297b9ec9f73",
+					Compression:    "gzip",
+				}))
+				Expect(err).To(BeNil())
+			})
+		})
+	})
+
+	Context("NewQuotaFn function", Label("unit"), func() {
+		var (
+			ctx           context.Context
+			currentTs     time.Time
+			authRepo      *mock_repository.MockAuth
+			fileManager   *mock_filesystem.MockFileManager
+			logger        *mock_logging.MockLogger
+			reservation   *service.QuotaReservation
+			fnParam       service.QuotaFnParam
+			createFnParam repository.CreateFnParam
+			createFnRes   *repository.CreateFnResult
+			usageParam    repository.UpdateClientUsageParam
+			removeParam   filesystem.RemoveFileParam
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			currentTs = time.Now().UTC()
+			t := GinkgoT()
+			ctrl := gomock.NewController(t)
+			authRepo = mock_repository.NewMockAuth(ctrl)
+			fileManager = mock_filesystem.NewMockFileManager(ctrl)
+			logger = mock_logging.NewMockLogger(ctrl)
+			reservation = &service.QuotaReservation{}
+			createFnParam = repository.CreateFnParam{
+				FilePath: "mock/path/name.jpg",
+			}
+			createFnRes = &repository.CreateFnResult{
+				Size:           7,
+				ChecksumSha256: "mock-sha256",
+			}
+			fnParam = service.QuotaFnParam{
+				AuthRepo:      authRepo,
+				OwnerClientId: "mock-id",
+				UsedAt:        currentTs,
+				FileManager:   fileManager,
+				Logger:        logger,
+				CreateFn: func(ctx context.Context, p repository.CreateFnParam) (*repository.CreateFnResult, error) {
+					return createFnRes, nil
+				},
+				Reservation: reservation,
+			}
+			usageParam = repository.UpdateClientUsageParam{
+				Id:           "mock-id",
+				StoredSize:   7,
+				TotalFiles:   1,
+				DailyUploads: 1,
+				UsedAt:       currentTs,
+				CheckQuota:   true,
+			}
+			removeParam = filesystem.RemoveFileParam{
+				Path: "mock/path/name.jpg",
+			}
+		})
+
+		When("failed create file", func() {
+			It("should return error", func() {
+				fnParam.CreateFn = func(ctx context.Context, p repository.CreateFnParam) (*repository.CreateFnResult, error) {
+					return nil, fmt.Errorf("disk error")
+				}
+				fn := service.NewQuotaFn(fnParam)
+
+				res, err := fn(ctx, createFnParam)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("disk error")))
+				Expect(reservation.Reserved).To(BeFalse())
+			})
+		})
+
+		When("quota is exceeded", func() {
+			It("should remove the file", func() {
+				fn := service.NewQuotaFn(fnParam)
+
+				authRepo.
+					EXPECT().
+					UpdateClientUsage(gomock.Eq(ctx), gomock.Eq(usageParam)).
+					Return(nil, fmt.Errorf("%w: max stored size", repository.ErrExceeded)).
+					Times(1)
+
+				fileManager.
+					EXPECT().
+					RemoveFile(gomock.Eq(ctx), gomock.Eq(removeParam)).
+					Return(&filesystem.RemoveFileResult{}, nil).
+					Times(1)
+
+				res, err := fn(ctx, createFnParam)
+
+				Expect(res).To(BeNil())
+				Expect(errors.Is(err, repository.ErrExceeded)).To(BeTrue())
+				Expect(reservation.Reserved).To(BeFalse())
+			})
+		})
+
+		When("failed remove rejected file", func() {
+			It("should return error", func() {
+				fn := service.NewQuotaFn(fnParam)
+
+				authRepo.
+					EXPECT().
+					UpdateClientUsage(gomock.Eq(ctx), gomock.Eq(usageParam)).
+					Return(nil, fmt.Errorf("db error")).
+					Times(1)
+
+				fileManager.
+					EXPECT().
+					RemoveFile(gomock.Eq(ctx), gomock.Eq(removeParam)).
+					Return(nil, fmt.Errorf("disk error")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					Warnf("Failed removing rejected file %s, err: %s", "mock/path/name.jpg", "disk error").
+					Times(1)
+
+				res, err := fn(ctx, createFnParam)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("db error")))
+			})
+		})
+
+		When("usage is reserved", func() {
+			It("should return result", func() {
+				fn := service.NewQuotaFn(fnParam)
+
+				authRepo.
+					EXPECT().
+					UpdateClientUsage(gomock.Eq(ctx), gomock.Eq(usageParam)).
+					Return(&repository.UpdateClientUsageResult{}, nil).
+					Times(1)
+
+				res, err := fn(ctx, createFnParam)
+
+				Expect(res).To(Equal(createFnRes))
+				Expect(err).To(BeNil())
+				Expect(reservation).To(Equal(&service.QuotaReservation{
+					Reserved: true,
+					Size:     7,
+				}))
+			})
+		})
+	})
+
+	Context("NewScanFn function", Label("unit"), func() {
+		var (
+			ctx           context.Context
+			fileManager   *mock_filesystem.MockFileManager
+			fileScanner   *mock_scanner.MockScanner
+			logger        *mock_logging.MockLogger
+			fnParam       service.ScanFnParam
+			createFnParam repository.CreateFnParam
+			createFnRes   *repository.CreateFnResult
+			removeParam   filesystem.RemoveFileParam
+			scanFile      func(res *scanner.ScanFileResult, err error) func(ctx context.Context, p scanner.ScanFileParam) (*scanner.ScanFileResult, error)
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			t := GinkgoT()
+			ctrl := gomock.NewController(t)
+			fileManager = mock_filesystem.NewMockFileManager(ctrl)
+			fileScanner = mock_scanner.NewMockScanner(ctrl)
+			logger = mock_logging.NewMockLogger(ctrl)
+			createFnParam = repository.CreateFnParam{
+				FilePath: "mock/path/name.jpg",
+			}
+			createFnRes = &repository.CreateFnResult{
+				Size:           7,
+				ChecksumSha256: "mock-sha256",
+			}
+			removeParam = filesystem.RemoveFileParam{
+				Path: "mock/path/name.jpg",
+			}
+			fnParam = service.ScanFnParam{
+				Reader:      strings.NewReader("content"),
+				Scanner:     fileScanner,
+				FileManager: fileManager,
+				Logger:      logger,
+				CreateFn: func(reader io.Reader) repository.CreateFn {
+					return func(ctx context.Context, p repository.CreateFnParam) (*repository.CreateFnResult, error) {
+						data, err := io.ReadAll(reader)
+						if err != nil {
+							return nil, err
+						}
+						if string(data) != "content" {
+							return nil, fmt.Errorf("invalid content")
+						}
+						return createFnRes, nil
+					}
+				},
+			}
+			scanFile = func(res *scanner.ScanFileResult, err error) func(ctx context.Context, p scanner.ScanFileParam) (*scanner.ScanFileResult, error) {
+				return func(ctx context.Context, p scanner.ScanFileParam) (*scanner.ScanFileResult, error) {
+					data, rErr := io.ReadAll(p.Reader)
+					if rErr != nil {
+						return nil, rErr
+					}
+					if string(data) != "content" {
+						return nil, fmt.Errorf("invalid scanned content")
+					}
+					return res, err
+				}
+			}
+		})
+
+		When("failed create file", func() {
+			It("should return error", func() {
+				fnParam.CreateFn = func(reader io.Reader) repository.CreateFn {
+					return func(ctx context.Context, p repository.CreateFnParam) (*repository.CreateFnResult, error) {
+						return nil, fmt.Errorf("disk error")
+					}
+				}
+				fn := service.NewScanFn(fnParam)
+
+				fileScanner.
+					EXPECT().
+					ScanFile(gomock.Eq(ctx), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, p scanner.ScanFileParam) (*scanner.ScanFileResult, error) {
+						_, err := io.ReadAll(p.Reader)
+						return nil, err
+					}).
+					Times(1)
+
+				res, err := fn(ctx, createFnParam)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(fmt.Errorf("disk error")))
+			})
+		})
+
+		When("file is infected", func() {
+			It("should remove the file", func() {
+				fn := service.NewScanFn(fnParam)
+
+				fileScanner.
+					EXPECT().
+					ScanFile(gomock.Eq(ctx), gomock.Any()).
+					DoAndReturn(scanFile(&scanner.ScanFileResult{
+						Infected:  true,
+						Signature: "Eicar-Signature",
+					}, nil)).
+					Times(1)
+
+				fileManager.
+					EXPECT().
+					RemoveFile(gomock.Eq(ctx), gomock.Eq(removeParam)).
+					Return(&filesystem.RemoveFileResult{}, nil).
+					Times(1)
+
+				res, err := fn(ctx, createFnParam)
+
+				Expect(res).To(BeNil())
+				Expect(errors.Is(err, file.ErrInfected)).To(BeTrue())
+				Expect(err.Error()).To(Equal("file is infected: Eicar-Signature"))
+			})
+		})
+
+		When("failed remove infected file", func() {
+			It("should return error", func() {
+				fn := service.NewScanFn(fnParam)
+
+				fileScanner.
+					EXPECT().
+					ScanFile(gomock.Eq(ctx), gomock.Any()).
+					DoAndReturn(scanFile(&scanner.ScanFileResult{
+						Infected:  true,
+						Signature: "Eicar-Signature",
+					}, nil)).
+					Times(1)
+
+				fileManager.
+					EXPECT().
+					RemoveFile(gomock.Eq(ctx), gomock.Eq(removeParam)).
+					Return(nil, fmt.Errorf("disk error")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					Warnf("Failed removing rejected file %s, err: %s", "mock/path/name.jpg", "disk error").
+					Times(1)
+
+				res, err := fn(ctx, createFnParam)
+
+				Expect(res).To(BeNil())
+				Expect(errors.Is(err, file.ErrInfected)).To(BeTrue())
+			})
+		})
+
+		When("scanner is unavailable and fails closed", func() {
+			It("should remove the file", func() {
+				fn := service.NewScanFn(fnParam)
+
+				fileScanner.
+					EXPECT().
+					ScanFile(gomock.Eq(ctx), gomock.Any()).
+					Return(nil, scanner.ErrorUnavailable).
+					Times(1)
+
+				fileManager.
+					EXPECT().
+					RemoveFile(gomock.Eq(ctx), gomock.Eq(removeParam)).
+					Return(&filesystem.RemoveFileResult{}, nil).
+					Times(1)
+
+				res, err := fn(ctx, createFnParam)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(scanner.ErrorUnavailable))
+			})
+		})
+
+		When("scanner is unavailable and fails open", func() {
+			It("should return result", func() {
+				fnParam.FailOpen = true
+				fn := service.NewScanFn(fnParam)
+
+				fileScanner.
+					EXPECT().
+					ScanFile(gomock.Eq(ctx), gomock.Any()).
+					Return(nil, scanner.ErrorUnavailable).
+					Times(1)
+
+				logger.
+					EXPECT().
+					Warnf("Failed scanning file %s, err: %s", "mock/path/name.jpg", "scanner is unavailable").
+					Times(1)
+
+				res, err := fn(ctx, createFnParam)
+
+				Expect(res).To(Equal(createFnRes))
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("file exceeds the scanner limit and it's skipped", func() {
+			It("should return result", func() {
+				fnParam.SkipOversized = true
+				fn := service.NewScanFn(fnParam)
+
+				fileScanner.
+					EXPECT().
+					ScanFile(gomock.Eq(ctx), gomock.Any()).
+					Return(nil, fmt.Errorf("%w: INSTREAM size limit exceeded.", scanner.ErrorLimitExceeded)).
+					Times(1)
+
+				logger.
+					EXPECT().
+					Warnf("Skipped scanning file %s, err: %s", "mock/path/name.jpg", "scanner limit is exceeded: INSTREAM size limit exceeded.").
+					Times(1)
+
+				res, err := fn(ctx, createFnParam)
+
+				Expect(res).To(Equal(createFnRes))
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("file exceeds the scanner limit and it's not skipped", func() {
+			It("should remove the file even when it fails open", func() {
+				fnParam.FailOpen = true
+				fn := service.NewScanFn(fnParam)
+
+				fileScanner.
+					EXPECT().
+					ScanFile(gomock.Eq(ctx), gomock.Any()).
+					Return(nil, scanner.ErrorLimitExceeded).
+					Times(1)
+
+				fileManager.
+					EXPECT().
+					RemoveFile(gomock.Eq(ctx), gomock.Eq(removeParam)).
+					Return(&filesystem.RemoveFileResult{}, nil).
+					Times(1)
+
+				res, err := fn(ctx, createFnParam)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(scanner.ErrorLimitExceeded))
+			})
+		})
+
+		When("file is clean", func() {
+			It("should return result", func() {
+				fn := service.NewScanFn(fnParam)
+
+				fileScanner.
+					EXPECT().
+					ScanFile(gomock.Eq(ctx), gomock.Any()).
+					DoAndReturn(scanFile(&scanner.ScanFileResult{}, nil)).
+					Times(1)
+
+				res, err := fn(ctx, createFnParam)
+
+				Expect(res).To(Equal(createFnRes))
+				Expect(err).To(BeNil())
+			})
+		})
+	})
+
+	Context("DeleteFile function", Label("unit"), func() {
+		var (
+			ctx           context.Context
+			currentTs     time.Time
+			p             service.DeleteFileParam
+			fileRepo      *mock_repository.MockFile
+			fileManager   *mock_filesystem.MockFileManager
+			dirManager    *mock_filesystem.MockDirectoryManager
+			clock         *mock_datetime.MockClock
+			log           *mock_logging.MockLogger
+			validator     *mock_validation.MockValidator
+			s             service.File
+			retrieveParam repository.RetrieveFileParam
+			retrieveRes   *repository.RetrieveFileResult
+			deleteRes     *repository.DeleteFileResult
+			r             *service.DeleteFileResult
+		)
+
+		BeforeEach(func() {
+			currentTs = time.Now().UTC()
+			ctx = context.Background()
+			p = service.DeleteFileParam{
+				FileId: "mock-file-id",
+			}
+			t := GinkgoT()
+			ctrl := gomock.NewController(t)
+			fileRepo = mock_repository.NewMockFile(ctrl)
+			fileManager = mock_filesystem.NewMockFileManager(ctrl)
+			dirManager = mock_filesystem.NewMockDirectoryManager(ctrl)
+			identifier := mock_identifier.NewMockIdentifier(ctrl)
+			clock = mock_datetime.NewMockClock(ctrl)
+			locator := mock_file.NewMockUploadLocation(ctrl)
+			log = mock_logging.NewMockLogger(ctrl)
+			validator = mock_validation.NewMockValidator(ctrl)
+			s = service.NewFile(service.FileParam{
+				FileRepo:    fileRepo,
+				FileManager: fileManager,
+				DirManager:  dirManager,
+				Logger:      log,
+				Identifier:  identifier,
+				Clock:       clock,
+				Locator:     locator,
+				Validator:   validator,
+				Config: &service.FileConfig{
+					UploadDir: "temp",
+				},
+			})
+			retrieveParam = repository.RetrieveFileParam{
+				UniqueId: "mock-file-id",
+			}
+			retrieveRes = &repository.RetrieveFileResult{
+				UniqueId:      "mock-file-id",
+				OwnerClientId: "id",
+			}
+			deleteRes = &repository.DeleteFileResult{
+				DeletedAt: currentTs,
+			}
+			r = &service.DeleteFileResult{
+				Success: system.Success{
+					Code:    1000,
+					Message: "success delete file",
+				},
+				DeletedAt: currentTs,
+			}
+
+			log.
+				EXPECT().
+				Debug("In function: DeleteFile").
+				Times(1)
+			log.
+				EXPECT().
+				Debug("Returning function: DeleteFile").
+				Times(1)
+		})
+
+		When("parameter is not valid", func() {
+			It("should return error", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(fmt.Errorf("invalid data")).
+					Times(1)
+
+				res, err := s.DeleteFile(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err.Code).To(Equal(int32(1002)))
+				Expect(err.Message).To(Equal("invalid data"))
+			})
+		})
+
+		When("failed retrieve file", func() {
+			It("should return error", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				fileRepo.
+					EXPECT().
+					RetrieveFile(gomock.Eq(ctx), gomock.Eq(retrieveParam)).
+					Return(nil, fmt.Errorf("network error")).
+					Times(1)
+
+				res, err := s.DeleteFile(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err.Code).To(Equal(int32(1001)))
+				Expect(err.Message).To(Equal("network error"))
+			})
+		})
+
+		When("file is not found", func() {
+			It("should return error", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				fileRepo.
+					EXPECT().
+					RetrieveFile(gomock.Eq(ctx), gomock.Eq(retrieveParam)).
+					Return(nil, repository.ErrNotFound).
+					Times(1)
+
+				res, err := s.DeleteFile(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err.Code).To(Equal(int32(1004)))
+				Expect(err.Message).To(Equal("file is not found"))
+			})
+		})
+
+		When("file is owned by other client", func() {
+			It("should return error", func() {
+				ctx := auth.WithIdentity(ctx, &auth.Identity{
+					Id:       "other-id",
+					ClientId: "other-client-id",
+				})
+
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				fileRepo.
+					EXPECT().
+					RetrieveFile(gomock.Eq(ctx), gomock.Eq(retrieveParam)).
+					Return(retrieveRes, nil).
+					Times(1)
+
+				res, err := s.DeleteFile(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err.Code).To(Equal(int32(1003)))
+				Expect(err.Message).To(Equal("file is not accessible"))
+			})
+		})
+
+		When("file is owned by other client and client is admin", func() {
+			It("should return result", func() {
+				ctx := auth.WithIdentity(ctx, &auth.Identity{
+					Id:       "other-id",
+					ClientId: "other-client-id",
+					Admin:    true,
+				})
+
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				fileRepo.
+					EXPECT().
+					RetrieveFile(gomock.Eq(ctx), gomock.Eq(retrieveParam)).
+					Return(retrieveRes, nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				fileRepo.
+					EXPECT().
+					DeleteFile(gomock.Eq(ctx), gomock.Any()).
+					Return(deleteRes, nil).
+					Times(1)
+
+				res, err := s.DeleteFile(ctx, p)
+
+				Expect(res).To(Equal(r))
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("file is owned by the client", func() {
+			It("should return result", func() {
+				ctx := auth.WithIdentity(ctx, &auth.Identity{
+					Id:       "id",
+					ClientId: "client-id",
+				})
+
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				fileRepo.
+					EXPECT().
+					RetrieveFile(gomock.Eq(ctx), gomock.Eq(retrieveParam)).
+					Return(retrieveRes, nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				fileRepo.
+					EXPECT().
+					DeleteFile(gomock.Eq(ctx), gomock.Any()).
+					Return(deleteRes, nil).
+					Times(1)
+
+				res, err := s.DeleteFile(ctx, p)
+
+				Expect(res).To(Equal(r))
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("failed delete file", func() {
+			It("should return error", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				fileRepo.
+					EXPECT().
+					RetrieveFile(gomock.Eq(ctx), gomock.Eq(retrieveParam)).
+					Return(retrieveRes, nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				fileRepo.
+					EXPECT().
+					DeleteFile(gomock.Eq(ctx), gomock.Any()).
+					Return(nil, fmt.Errorf("network error")).
+					Times(1)
+
+				res, err := s.DeleteFile(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err.Code).To(Equal(int32(1001)))
+				Expect(err.Message).To(Equal("network error"))
+			})
+		})
+
+		When("file is not available", func() {
+			It("should return error", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				fileRepo.
+					EXPECT().
+					RetrieveFile(gomock.Eq(ctx), gomock.Eq(retrieveParam)).
+					Return(retrieveRes, nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				fileRepo.
+					EXPECT().
+					DeleteFile(gomock.Eq(ctx), gomock.Any()).
+					Return(nil, repository.ErrNotFound).
+					Times(1)
+
+				res, err := s.DeleteFile(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err.Code).To(Equal(int32(1004)))
+				Expect(err.Message).To(Equal("file is not found"))
+			})
+		})
+
+		When("file is deleted", func() {
+			It("should return error", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				fileRepo.
+					EXPECT().
+					RetrieveFile(gomock.Eq(ctx), gomock.Eq(retrieveParam)).
+					Return(retrieveRes, nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				fileRepo.
+					EXPECT().
+					DeleteFile(gomock.Eq(ctx), gomock.Any()).
+					Return(nil, repository.ErrDeleted).
+					Times(1)
+
+				res, err := s.DeleteFile(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err.Code).To(Equal(int32(1004)))
+				Expect(err.Message).To(Equal("file is deleted"))
+			})
+		})
+
+		When("success delete file", func() {
+			It("should return result", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				fileRepo.
+					EXPECT().
+					RetrieveFile(gomock.Eq(ctx), gomock.Eq(retrieveParam)).
+					Return(retrieveRes, nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				fileRepo.
+					EXPECT().
+					DeleteFile(gomock.Eq(ctx), gomock.Any()).
+					Return(deleteRes, nil).
+					Times(1)
+
+				res, err := s.DeleteFile(ctx, p)
+
+				Expect(res).To(Equal(r))
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("trash is enabled", func() {
+			It("should move the file into trash", func() {
+				s = service.NewFile(service.FileParam{
+					FileRepo:    fileRepo,
+					FileManager: fileManager,
+					DirManager:  dirManager,
+					Logger:      log,
+					Clock:       clock,
+					Validator:   validator,
+					Config: &service.FileConfig{
+						UploadDir:    "temp",
+						TrashEnabled: true,
+						TrashDir:     "temp/trash",
+					},
+				})
+
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				fileRepo.
+					EXPECT().
+					RetrieveFile(gomock.Eq(ctx), gomock.Eq(retrieveParam)).
+					Return(retrieveRes, nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				fileRepo.
+					EXPECT().
+					DeleteFile(gomock.Eq(ctx), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, p repository.DeleteFileParam) (*repository.DeleteFileResult, error) {
+						err := p.DeleteFn(ctx, repository.DeleteFnParam{
+							FilePath: "temp/2022/mock-file-id.jpg",
+						})
+						return deleteRes, err
+					}).
+					Times(1)
+
+				fileManager.
+					EXPECT().
+					IsFileExists(gomock.Eq(ctx), gomock.Any()).
+					Return(true, nil).
+					Times(1)
+
+				dirManager.
+					EXPECT().
+					IsDirectoryExists(gomock.Eq(ctx), gomock.Any()).
+					Return(true, nil).
+					Times(1)
+
+				fileManager.
+					EXPECT().
+					MoveFile(gomock.Eq(ctx), gomock.Eq(filesystem.MoveFileParam{
+						SourcePath:      "temp/2022/mock-file-id.jpg",
+						DestinationPath: "temp/trash/mock-file-id.jpg",
+					})).
+					Return(&filesystem.MoveFileResult{}, nil).
+					Times(1)
+
+				res, err := s.DeleteFile(ctx, p)
+
+				Expect(res).To(Equal(r))
+				Expect(err).To(BeNil())
+			})
+		})
+	})
+
+	Context("RestoreFile function", Label("unit"), func() {
+		var (
+			ctx         context.Context
+			currentTs   time.Time
+			p           service.RestoreFileParam
+			fileRepo    *mock_repository.MockFile
+			clock       *mock_datetime.MockClock
+			log         *mock_logging.MockLogger
+			validator   *mock_validation.MockValidator
+			s           service.File
+			restoreRes  *repository.RestoreFileResult
+			retrieveRes *repository.RetrieveFileResult
+			r           *service.RestoreFileResult
+		)
+
+		BeforeEach(func() {
+			currentTs = time.Now().UTC()
+			ctx = context.Background()
+			p = service.RestoreFileParam{
+				FileId: "mock-file-id",
+			}
+			t := GinkgoT()
+			ctrl := gomock.NewController(t)
+			fileRepo = mock_repository.NewMockFile(ctrl)
+			fileManager := mock_filesystem.NewMockFileManager(ctrl)
+			dirManager := mock_filesystem.NewMockDirectoryManager(ctrl)
+			identifier := mock_identifier.NewMockIdentifier(ctrl)
+			clock = mock_datetime.NewMockClock(ctrl)
+			locator := mock_file.NewMockUploadLocation(ctrl)
+			log = mock_logging.NewMockLogger(ctrl)
+			validator = mock_validation.NewMockValidator(ctrl)
+			s = service.NewFile(service.FileParam{
+				FileRepo:    fileRepo,
+				FileManager: fileManager,
+				DirManager:  dirManager,
+				Logger:      log,
+				Identifier:  identifier,
+				Clock:       clock,
+				Locator:     locator,
+				Validator:   validator,
+				Config: &service.FileConfig{
+					UploadDir:    "temp",
+					Deduplicate:  true,
+					TrashEnabled: true,
+					TrashDir:     "temp/trash",
+				},
+			})
+			retrieveRes = &repository.RetrieveFileResult{
+				UniqueId:      p.FileId,
+				OwnerClientId: "id",
+			}
+			restoreRes = &repository.RestoreFileResult{
+				RestoredAt: currentTs,
+			}
+			r = &service.RestoreFileResult{
+				Success: system.Success{
+					Code:    1000,
+					Message: "success restore file",
+				},
+				RestoredAt: currentTs,
+			}
+
+			log.
+				EXPECT().
+				Debug("In function: RestoreFile").
+				Times(1)
+			log.
+				EXPECT().
+				Debug("Returning function: RestoreFile").
+				Times(1)
+		})
+
+		When("parameter is not valid", func() {
+			It("should return error", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(fmt.Errorf("invalid data")).
+					Times(1)
+
+				res, err := s.RestoreFile(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err.Code).To(Equal(int32(1002)))
+				Expect(err.Message).To(Equal("invalid data"))
+			})
+		})
+
+		When("failed retrieve file", func() {
+			It("should return error", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				fileRepo.
+					EXPECT().
+					RetrieveFile(gomock.Eq(ctx), gomock.Any()).
+					Return(nil, fmt.Errorf("network error")).
+					Times(1)
+
+				res, err := s.RestoreFile(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err.Code).To(Equal(int32(1001)))
+				Expect(err.Message).To(Equal("network error"))
+			})
+		})
+
+		When("file is not found", func() {
+			It("should return error", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				fileRepo.
+					EXPECT().
+					RetrieveFile(gomock.Eq(ctx), gomock.Any()).
+					Return(nil, repository.ErrNotFound).
+					Times(1)
+
+				res, err := s.RestoreFile(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err.Code).To(Equal(int32(1004)))
+				Expect(err.Message).To(Equal("file is not found"))
+			})
+		})
+
+		When("file is owned by other client", func() {
+			It("should return error", func() {
+				ctx := auth.WithIdentity(ctx, &auth.Identity{
+					Id:       "other-id",
+					ClientId: "other-client",
+				})
+
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				fileRepo.
+					EXPECT().
+					RetrieveFile(gomock.Eq(ctx), gomock.Any()).
+					Return(retrieveRes, nil).
+					Times(1)
+
+				res, err := s.RestoreFile(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err.Code).To(Equal(int32(1003)))
+				Expect(err.Message).To(Equal("file is not accessible"))
+			})
+		})
+
+		When("failed restore file", func() {
+			It("should return error", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				fileRepo.
+					EXPECT().
+					RetrieveFile(gomock.Eq(ctx), gomock.Eq(repository.RetrieveFileParam{
+						UniqueId: p.FileId,
+					})).
+					Return(retrieveRes, nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				fileRepo.
+					EXPECT().
+					RestoreFile(gomock.Eq(ctx), gomock.Any()).
+					Return(nil, fmt.Errorf("network error")).
+					Times(1)
+
+				res, err := s.RestoreFile(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err.Code).To(Equal(int32(1001)))
+				Expect(err.Message).To(Equal("network error"))
+			})
+		})
+
+		When("file is not available", func() {
+			It("should return error", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				fileRepo.
+					EXPECT().
+					RetrieveFile(gomock.Eq(ctx), gomock.Eq(repository.RetrieveFileParam{
+						UniqueId: p.FileId,
+					})).
+					Return(retrieveRes, nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				fileRepo.
+					EXPECT().
+					RestoreFile(gomock.Eq(ctx), gomock.Any()).
+					Return(nil, repository.ErrNotFound).
+					Times(1)
+
+				res, err := s.RestoreFile(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err.Code).To(Equal(int32(1004)))
+				Expect(err.Message).To(Equal("file is not found"))
+			})
+		})
+
+		When("file is not deleted", func() {
+			It("should return error", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				fileRepo.
+					EXPECT().
+					RetrieveFile(gomock.Eq(ctx), gomock.Eq(repository.RetrieveFileParam{
+						UniqueId: p.FileId,
+					})).
+					Return(retrieveRes, nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				fileRepo.
+					EXPECT().
+					RestoreFile(gomock.Eq(ctx), gomock.Any()).
+					Return(nil, repository.ErrNotDeleted).
+					Times(1)
+
+				res, err := s.RestoreFile(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err.Code).To(Equal(service.FILE_NOT_DELETED))
+				Expect(err.Message).To(Equal("file is not deleted"))
+			})
+		})
+
+		When("file is not available in trash", func() {
+			It("should return error", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				fileRepo.
+					EXPECT().
+					RetrieveFile(gomock.Eq(ctx), gomock.Eq(repository.RetrieveFileParam{
+						UniqueId: p.FileId,
+					})).
+					Return(retrieveRes, nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				fileRepo.
+					EXPECT().
+					RestoreFile(gomock.Eq(ctx), gomock.Any()).
+					Return(nil, file.ErrNotFound).
+					Times(1)
+
+				res, err := s.RestoreFile(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err.Code).To(Equal(int32(1004)))
+				Expect(err.Message).To(Equal("file is not found in trash"))
+			})
+		})
+
+		When("restored file exceeds the owner quota", func() {
+			It("should return error", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				fileRepo.
+					EXPECT().
+					RetrieveFile(gomock.Eq(ctx), gomock.Any()).
+					Return(retrieveRes, nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				fileRepo.
+					EXPECT().
+					RestoreFile(gomock.Eq(ctx), gomock.Any()).
+					Return(nil, fmt.Errorf("%w: max stored size", repository.ErrExceeded)).
+					Times(1)
+
+				res, err := s.RestoreFile(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err.Code).To(Equal(service.QUOTA_EXCEEDED))
+				Expect(err.Message).To(Equal("quota exceeded: max stored size"))
+			})
+		})
+
+		When("success restore file", func() {
+			It("should return result", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				fileRepo.
+					EXPECT().
+					RetrieveFile(gomock.Eq(ctx), gomock.Eq(repository.RetrieveFileParam{
+						UniqueId: p.FileId,
+					})).
+					Return(retrieveRes, nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				fileRepo.
+					EXPECT().
+					RestoreFile(gomock.Eq(ctx), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, rp repository.RestoreFileParam) (*repository.RestoreFileResult, error) {
+						Expect(rp.UniqueId).To(Equal(p.FileId))
+						Expect(rp.RestoredAt).To(Equal(currentTs))
+						Expect(rp.Deduplicate).To(BeTrue())
+						return restoreRes, nil
+					}).
+					Times(1)
+
+				res, err := s.RestoreFile(ctx, p)
+
+				Expect(res).To(Equal(r))
+				Expect(err).To(BeNil())
+			})
+		})
+	})
+
+	Context("NewDeleteFn function", Label("unit"), func() {
+		var (
+			ctx               context.Context
+			fileManager       *mock_filesystem.MockFileManager
+			fn                repository.DeleteFn
+			deleteFnParam     repository.DeleteFnParam
+			isFileExistsParam filesystem.IsFileExistsParam
+			removeParam       filesystem.RemoveFileParam
+			removeRes         *filesystem.RemoveFileResult
+		)
+
+		BeforeEach(func() {
+			currentTimestamp := time.Now()
+			ctx = context.Background()
+			t := GinkgoT()
+			ctrl := gomock.NewController(t)
+			fileManager = mock_filesystem.NewMockFileManager(ctrl)
+			fn = service.NewDeleteFn(fileManager)
+			deleteFnParam = repository.DeleteFnParam{
+				FilePath: "mock/path",
+			}
+			isFileExistsParam = filesystem.IsFileExistsParam{
+				Path: deleteFnParam.FilePath,
+			}
+			removeParam = filesystem.RemoveFileParam{
+				Path: deleteFnParam.FilePath,
+			}
+			removeRes = &filesystem.RemoveFileResult{
+				RemovedAt: currentTimestamp,
+			}
+		})
+
+		When("failed check file existstance", func() {
+			It("should return error", func() {
+				fileManager.
+					EXPECT().
+					IsFileExists(gomock.Eq(ctx), gomock.Eq(isFileExistsParam)).
+					Return(false, fmt.Errorf("failed read disk")).
+					Times(1)
+
+				err := fn(ctx, deleteFnParam)
+
+				Expect(err).To(Equal(fmt.Errorf("failed read disk")))
+			})
+		})
+
+		When("file is not available in disk", func() {
+			It("should return error", func() {
+				fileManager.
+					EXPECT().
+					IsFileExists(gomock.Eq(ctx), gomock.Eq(isFileExistsParam)).
+					Return(false, nil).
+					Times(1)
+
+				err := fn(ctx, deleteFnParam)
+
+				Expect(err).To(Equal(file.ErrNotFound))
+			})
+		})
+
+		When("failed remove file from disk", func() {
+			It("should return error", func() {
+				fileManager.
+					EXPECT().
+					IsFileExists(gomock.Eq(ctx), gomock.Eq(isFileExistsParam)).
+					Return(true, nil).
+					Times(1)
+
+				fileManager.
+					EXPECT().
+					RemoveFile(gomock.Eq(ctx), gomock.Eq(removeParam)).
+					Return(nil, fmt.Errorf("disk error")).
+					Times(1)
+
+				err := fn(ctx, deleteFnParam)
+
+				Expect(err).To(Equal(fmt.Errorf("disk error")))
+			})
+		})
+
+		When("success remove file from disk", func() {
+			It("should return result", func() {
+				fileManager.
+					EXPECT().
+					IsFileExists(gomock.Eq(ctx), gomock.Eq(isFileExistsParam)).
+					Return(true, nil).
+					Times(1)
+
+				fileManager.
+					EXPECT().
+					RemoveFile(gomock.Eq(ctx), gomock.Eq(removeParam)).
+					Return(removeRes, nil).
+					Times(1)
+
+				err := fn(ctx, deleteFnParam)
+
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("file content is still referenced", func() {
+			It("should keep the file", func() {
+				deleteFnParam.References = 2
+
+				err := fn(ctx, deleteFnParam)
+
+				Expect(err).To(BeNil())
+			})
+		})
+	})
+
+	Context("NewDuplicateFn function", Label("unit"), func() {
+		var (
+			ctx            context.Context
+			fileManager    *mock_filesystem.MockFileManager
+			fn             repository.DuplicateFn
+			duplicateParam repository.DuplicateFnParam
+			removeParam    filesystem.RemoveFileParam
+			removeRes      *filesystem.RemoveFileResult
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			t := GinkgoT()
+			ctrl := gomock.NewController(t)
+			fileManager = mock_filesystem.NewMockFileManager(ctrl)
+			fn = service.NewDuplicateFn(fileManager)
+			duplicateParam = repository.DuplicateFnParam{
+				FilePath: "mock/path",
+				BlobPath: "mock/blob",
+			}
+			removeParam = filesystem.RemoveFileParam{
+				Path: duplicateParam.FilePath,
+			}
+			removeRes = &filesystem.RemoveFileResult{
+				RemovedAt: time.Now(),
+			}
+		})
+
+		When("failed remove duplicated file", func() {
+			It("should return error", func() {
+				fileManager.
+					EXPECT().
+					RemoveFile(gomock.Eq(ctx), gomock.Eq(removeParam)).
+					Return(nil, fmt.Errorf("disk error")).
+					Times(1)
+
+				err := fn(ctx, duplicateParam)
+
+				Expect(err).To(Equal(fmt.Errorf("disk error")))
+			})
+		})
+
+		When("success remove duplicated file", func() {
+			It("should return result", func() {
+				fileManager.
+					EXPECT().
+					RemoveFile(gomock.Eq(ctx), gomock.Eq(removeParam)).
+					Return(removeRes, nil).
+					Times(1)
+
+				err := fn(ctx, duplicateParam)
+
+				Expect(err).To(BeNil())
+			})
+		})
+	})
+
+	Context("NewTrashFn function", Label("unit"), func() {
+		var (
+			ctx         context.Context
+			fileManager *mock_filesystem.MockFileManager
+			dirManager  *mock_filesystem.MockDirectoryManager
+			fn          repository.DeleteFn
+			fnParam     repository.DeleteFnParam
+			moveParam   filesystem.MoveFileParam
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			t := GinkgoT()
+			ctrl := gomock.NewController(t)
+			fileManager = mock_filesystem.NewMockFileManager(ctrl)
+			dirManager = mock_filesystem.NewMockDirectoryManager(ctrl)
+			fn = service.NewTrashFn(fileManager, dirManager, "mock/trash")
+			fnParam = repository.DeleteFnParam{
+				FilePath: "mock/2022/id.jpg",
+			}
+			moveParam = filesystem.MoveFileParam{
+				SourcePath:      "mock/2022/id.jpg",
+				DestinationPath: "mock/trash/id.jpg",
+			}
+		})
+
+		When("file content is still referenced", func() {
+			It("should keep the file", func() {
+				fnParam.References = 1
+
+				err := fn(ctx, fnParam)
+
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("file is not available in disk", func() {
+			It("should return error", func() {
+				fileManager.
+					EXPECT().
+					IsFileExists(gomock.Eq(ctx), gomock.Eq(filesystem.IsFileExistsParam{
+						Path: fnParam.FilePath,
+					})).
+					Return(false, nil).
+					Times(1)
+
+				err := fn(ctx, fnParam)
+
+				Expect(err).To(Equal(file.ErrNotFound))
+			})
+		})
+
+		When("failed create trash directory", func() {
+			It("should return error", func() {
+				fileManager.
+					EXPECT().
+					IsFileExists(gomock.Eq(ctx), gomock.Any()).
+					Return(true, nil).
+					Times(1)
+
+				dirManager.
+					EXPECT().
+					IsDirectoryExists(gomock.Eq(ctx), gomock.Eq(filesystem.IsDirectoryExistsParam{
+						Path: "mock/trash",
+					})).
+					Return(false, nil).
+					Times(1)
+
+				dirManager.
+					EXPECT().
+					CreateDir(gomock.Eq(ctx), gomock.Eq(filesystem.CreateDirParam{
+						Path:       "mock/trash",
+						Permission: 0644,
+					})).
+					Return(nil, fmt.Errorf("disk error")).
+					Times(1)
+
+				err := fn(ctx, fnParam)
+
+				Expect(err).To(Equal(fmt.Errorf("disk error")))
+			})
+		})
+
+		When("failed move file into trash", func() {
+			It("should return error", func() {
+				fileManager.
+					EXPECT().
+					IsFileExists(gomock.Eq(ctx), gomock.Any()).
+					Return(true, nil).
+					Times(1)
+
+				dirManager.
+					EXPECT().
+					IsDirectoryExists(gomock.Eq(ctx), gomock.Any()).
+					Return(true, nil).
+					Times(1)
+
+				fileManager.
+					EXPECT().
+					MoveFile(gomock.Eq(ctx), gomock.Eq(moveParam)).
+					Return(nil, fmt.Errorf("disk error")).
+					Times(1)
+
+				err := fn(ctx, fnParam)
+
+				Expect(err).To(Equal(fmt.Errorf("disk error")))
+			})
+		})
+
+		When("success move file into trash", func() {
+			It("should return result", func() {
+				fileManager.
+					EXPECT().
+					IsFileExists(gomock.Eq(ctx), gomock.Any()).
+					Return(true, nil).
+					Times(1)
+
+				dirManager.
+					EXPECT().
+					IsDirectoryExists(gomock.Eq(ctx), gomock.Any()).
+					Return(false, nil).
+					Times(1)
+
+				dirManager.
 					EXPECT().
-					Now().
-					Return(currentTs).
+					CreateDir(gomock.Eq(ctx), gomock.Any()).
+					Return(&filesystem.CreateDirResult{}, nil).
 					Times(1)
 
-				fileRepo.
+				fileManager.
 					EXPECT().
-					CreateFile(gomock.Eq(ctx), gomock.Any()).
-					Return(createFileRes, nil).
+					MoveFile(gomock.Eq(ctx), gomock.Eq(moveParam)).
+					Return(&filesystem.MoveFileResult{}, nil).
 					Times(1)
 
-				res, err := s.UploadFile(ctx, opts...)
+				err := fn(ctx, fnParam)
 
-				Expect(res).To(Equal(r))
 				Expect(err).To(BeNil())
 			})
 		})
 	})
 
-	Context("NewCreateFn function", Label("unit"), func() {
+	Context("NewRestoreFn function", Label("unit"), func() {
 		var (
-			ctx           context.Context
-			data          []byte
-			fileManager   *mock_filesystem.MockFileManager
-			fn            repository.CreateFn
-			createFnParam repository.CreateFnParam
-			existsParam   filesystem.IsFileExistsParam
-			saveParam     filesystem.SaveFileParam
+			ctx         context.Context
+			fileManager *mock_filesystem.MockFileManager
+			fn          repository.RestoreFn
+			fnParam     repository.RestoreFnParam
+			moveParam   filesystem.MoveFileParam
 		)
 
 		BeforeEach(func() {
 			ctx = context.Background()
 			t := GinkgoT()
 			ctrl := gomock.NewController(t)
-			data = []byte{}
 			fileManager = mock_filesystem.NewMockFileManager(ctrl)
-			fn = service.NewCreateFn(data, fileManager)
-			createFnParam = repository.CreateFnParam{
-				FilePath: "mock/path/name.jpg",
+			fn = service.NewRestoreFn(fileManager, "mock/trash")
+			fnParam = repository.RestoreFnParam{
+				FilePath: "mock/2022/id.jpg",
 			}
-			existsParam = filesystem.IsFileExistsParam{
-				Path: createFnParam.FilePath,
-			}
-			saveParam = filesystem.SaveFileParam{
-				Name:       createFnParam.FilePath,
-				Data:       data,
-				Permission: 0644,
+			moveParam = filesystem.MoveFileParam{
+				SourcePath:      "mock/trash/id.jpg",
+				DestinationPath: "mock/2022/id.jpg",
 			}
 		})
 
-		When("failed check file existance", func() {
+		When("file content is shared with other files", func() {
+			It("should keep the file", func() {
+				fnParam.References = 1
+
+				err := fn(ctx, fnParam)
+
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("file is not available in trash", func() {
 			It("should return error", func() {
 				fileManager.
 					EXPECT().
-					IsFileExists(gomock.Eq(ctx), gomock.Eq(existsParam)).
-					Return(false, fmt.Errorf("disk error")).
+					MoveFile(gomock.Eq(ctx), gomock.Eq(moveParam)).
+					Return(nil, filesystem.ErrorFileNotFound).
 					Times(1)
 
-				err := fn(ctx, createFnParam)
+				err := fn(ctx, fnParam)
 
-				Expect(err).To(Equal(fmt.Errorf("disk error")))
+				Expect(err).To(Equal(file.ErrNotFound))
 			})
 		})
 
-		When("file already exists", func() {
+		When("failed move file from trash", func() {
 			It("should return error", func() {
 				fileManager.
 					EXPECT().
-					IsFileExists(gomock.Eq(ctx), gomock.Eq(existsParam)).
-					Return(true, nil).
+					MoveFile(gomock.Eq(ctx), gomock.Eq(moveParam)).
+					Return(nil, fmt.Errorf("disk error")).
 					Times(1)
 
-				err := fn(ctx, createFnParam)
+				err := fn(ctx, fnParam)
 
-				Expect(err).To(Equal(file.ErrExists))
+				Expect(err).To(Equal(fmt.Errorf("disk error")))
 			})
 		})
 
-		When("failed save file", func() {
-			It("should return error", func() {
+		When("success move file from trash", func() {
+			It("should return result", func() {
 				fileManager.
 					EXPECT().
-					IsFileExists(gomock.Eq(ctx), gomock.Eq(existsParam)).
-					Return(false, nil).
+					MoveFile(gomock.Eq(ctx), gomock.Eq(moveParam)).
+					Return(&filesystem.MoveFileResult{}, nil).
 					Times(1)
 
+				err := fn(ctx, fnParam)
+
+				Expect(err).To(BeNil())
+			})
+		})
+	})
+
+	Context("NewPurgeFn function", Label("unit"), func() {
+		var (
+			ctx         context.Context
+			fileManager *mock_filesystem.MockFileManager
+			fn          repository.PurgeFn
+			fnParam     repository.PurgeFnParam
+			removeParam filesystem.RemoveFileParam
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			t := GinkgoT()
+			ctrl := gomock.NewController(t)
+			fileManager = mock_filesystem.NewMockFileManager(ctrl)
+			fn = service.NewPurgeFn(fileManager, "mock/trash")
+			fnParam = repository.PurgeFnParam{
+				FilePath: "mock/2022/id.jpg",
+			}
+			removeParam = filesystem.RemoveFileParam{
+				Path: "mock/trash/id.jpg",
+			}
+		})
+
+		When("path is still used by other files", func() {
+			It("should keep the file", func() {
+				fnParam.References = 1
+
+				err := fn(ctx, fnParam)
+
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("file is not available in trash", func() {
+			It("should return result", func() {
 				fileManager.
 					EXPECT().
-					SaveFile(gomock.Eq(ctx), gomock.Eq(saveParam)).
-					Return(nil, fmt.Errorf("disk error")).
+					RemoveFile(gomock.Eq(ctx), gomock.Eq(removeParam)).
+					Return(nil, filesystem.ErrorFileNotFound).
 					Times(1)
 
-				err := fn(ctx, createFnParam)
+				err := fn(ctx, fnParam)
 
-				Expect(err).To(Equal(fmt.Errorf("disk error")))
+				Expect(err).To(BeNil())
 			})
 		})
 
-		When("success save file", func() {
-			It("should return nil", func() {
+		When("failed remove file from trash", func() {
+			It("should return error", func() {
 				fileManager.
 					EXPECT().
-					IsFileExists(gomock.Eq(ctx), gomock.Eq(existsParam)).
-					Return(false, nil).
+					RemoveFile(gomock.Eq(ctx), gomock.Eq(removeParam)).
+					Return(nil, fmt.Errorf("disk error")).
 					Times(1)
 
-				saveRes := filesystem.SaveFileResult{}
+				err := fn(ctx, fnParam)
+
+				Expect(err).To(Equal(fmt.Errorf("disk error")))
+			})
+		})
+
+		When("success remove file from trash", func() {
+			It("should return result", func() {
 				fileManager.
 					EXPECT().
-					SaveFile(gomock.Eq(ctx), gomock.Eq(saveParam)).
-					Return(&saveRes, nil).
+					RemoveFile(gomock.Eq(ctx), gomock.Eq(removeParam)).
+					Return(&filesystem.RemoveFileResult{}, nil).
 					Times(1)
 
-				err := fn(ctx, createFnParam)
+				err := fn(ctx, fnParam)
 
 				Expect(err).To(BeNil())
 			})
 		})
 	})
 
-	Context("DeleteFile function", Label("unit"), func() {
+	Context("SearchFile function", Label("unit"), func() {
 		var (
 			ctx         context.Context
 			currentTs   time.Time
-			p           service.DeleteFileParam
+			p           service.SearchFileParam
 			fileRepo    *mock_repository.MockFile
-			fileManager *mock_filesystem.MockFileManager
-			clock       *mock_datetime.MockClock
 			log         *mock_logging.MockLogger
 			validator   *mock_validation.MockValidator
 			s           service.File
-			deleteRes   *repository.DeleteFileResult
-			r           *service.DeleteFileResult
+			searchParam repository.SearchFileParam
+			searchRes   *repository.SearchFileResult
 		)
 
 		BeforeEach(func() {
 			currentTs = time.Now().UTC()
 			ctx = context.Background()
-			p = service.DeleteFileParam{
-				FileId: "mock-file-id",
+			p = service.SearchFileParam{
+				Keyword:    "dolpin",
+				TotalItems: 24,
+				Page:       2,
+				Mimetypes:  []string{"image/jpeg"},
+				Extensions: []string{"jpg"},
+				MinSize:    typeconv.Int64(100),
+				MaxSize:    typeconv.Int64(200),
+				Statuses:   []string{"available"},
 			}
 			t := GinkgoT()
 			ctrl := gomock.NewController(t)
 			fileRepo = mock_repository.NewMockFile(ctrl)
-			fileManager = mock_filesystem.NewMockFileManager(ctrl)
+			fileManager := mock_filesystem.NewMockFileManager(ctrl)
 			dirManager := mock_filesystem.NewMockDirectoryManager(ctrl)
 			identifier := mock_identifier.NewMockIdentifier(ctrl)
-			clock = mock_datetime.NewMockClock(ctrl)
+			clock := mock_datetime.NewMockClock(ctrl)
 			locator := mock_file.NewMockUploadLocation(ctrl)
 			log = mock_logging.NewMockLogger(ctrl)
 			validator = mock_validation.NewMockValidator(ctrl)
@@ -818,24 +3914,44 @@ var _ = Describe("File Service", func() {
 					UploadDir: "temp",
 				},
 			})
-			deleteRes = &repository.DeleteFileResult{
-				DeletedAt: currentTs,
+			searchParam = repository.SearchFileParam{
+				Limit:      24,
+				Offset:     24,
+				Keyword:    "dolpin",
+				Mimetypes:  []string{"image/jpeg"},
+				Extensions: []string{"jpg"},
+				MinSize:    typeconv.Int64(100),
+				MaxSize:    typeconv.Int64(200),
+				Statuses:   []string{"available"},
+				SortBy:     "uploaded_at",
+				SortOrder:  "desc",
 			}
-			r = &service.DeleteFileResult{
-				Success: system.Success{
-					Code:    1000,
-					Message: "success delete file",
+			searchRes = &repository.SearchFileResult{
+				Summary: repository.SearchFileSummary{
+					TotalItems: 25,
+				},
+				Items: []repository.SearchFileItem{
+					{
+						UniqueId:       "id-1",
+						Name:           "dolpin",
+						Path:           "/storage/id-1.jpg",
+						Mimetype:       "image/jpeg",
+						Extension:      "jpg",
+						Size:           150,
+						CreatedAt:      currentTs,
+						ChecksumSha256: "sha256",
+						ChecksumMd5:    "md5",
+					},
 				},
-				DeletedAt: currentTs,
 			}
 
 			log.
 				EXPECT().
-				Debug("In function: DeleteFile").
+				Debug("In function: SearchFile").
 				Times(1)
 			log.
 				EXPECT().
-				Debug("Returning function: DeleteFile").
+				Debug("Returning function: SearchFile").
 				Times(1)
 		})
 
@@ -847,7 +3963,7 @@ var _ = Describe("File Service", func() {
 					Return(fmt.Errorf("invalid data")).
 					Times(1)
 
-				res, err := s.DeleteFile(ctx, p)
+				res, err := s.SearchFile(ctx, p)
 
 				Expect(res).To(BeNil())
 				Expect(err.Code).To(Equal(int32(1002)))
@@ -855,63 +3971,42 @@ var _ = Describe("File Service", func() {
 			})
 		})
 
-		When("failed delete file", func() {
+		When("size range is not valid", func() {
 			It("should return error", func() {
+				p.MinSize = typeconv.Int64(300)
 				validator.
 					EXPECT().
 					Validate(gomock.Eq(p)).
 					Return(nil).
 					Times(1)
 
-				clock.
-					EXPECT().
-					Now().
-					Return(currentTs).
-					Times(1)
-
-				fileRepo.
-					EXPECT().
-					DeleteFile(gomock.Eq(ctx), gomock.Any()).
-					Return(nil, fmt.Errorf("network error")).
-					Times(1)
-
-				res, err := s.DeleteFile(ctx, p)
+				res, err := s.SearchFile(ctx, p)
 
 				Expect(res).To(BeNil())
-				Expect(err.Code).To(Equal(int32(1001)))
-				Expect(err.Message).To(Equal("network error"))
+				Expect(err.Code).To(Equal(int32(1002)))
+				Expect(err.Message).To(Equal("min_size must be less than or equal to max_size"))
 			})
 		})
 
-		When("file is not available", func() {
+		When("upload date range is not valid", func() {
 			It("should return error", func() {
+				p.UploadedFrom = typeconv.Time(currentTs)
+				p.UploadedTo = typeconv.Time(currentTs.Add(-time.Second))
 				validator.
 					EXPECT().
 					Validate(gomock.Eq(p)).
 					Return(nil).
 					Times(1)
 
-				clock.
-					EXPECT().
-					Now().
-					Return(currentTs).
-					Times(1)
-
-				fileRepo.
-					EXPECT().
-					DeleteFile(gomock.Eq(ctx), gomock.Any()).
-					Return(nil, repository.ErrNotFound).
-					Times(1)
-
-				res, err := s.DeleteFile(ctx, p)
+				res, err := s.SearchFile(ctx, p)
 
 				Expect(res).To(BeNil())
-				Expect(err.Code).To(Equal(int32(1004)))
-				Expect(err.Message).To(Equal("file is not found"))
+				Expect(err.Code).To(Equal(int32(1002)))
+				Expect(err.Message).To(Equal("uploaded_from must be less than or equal to uploaded_to"))
 			})
 		})
 
-		When("file is deleted", func() {
+		When("failed search file", func() {
 			It("should return error", func() {
 				validator.
 					EXPECT().
@@ -919,150 +4014,175 @@ var _ = Describe("File Service", func() {
 					Return(nil).
 					Times(1)
 
-				clock.
-					EXPECT().
-					Now().
-					Return(currentTs).
-					Times(1)
-
 				fileRepo.
 					EXPECT().
-					DeleteFile(gomock.Eq(ctx), gomock.Any()).
-					Return(nil, repository.ErrDeleted).
+					SearchFile(gomock.Eq(ctx), gomock.Eq(searchParam)).
+					Return(nil, fmt.Errorf("db error")).
 					Times(1)
 
-				res, err := s.DeleteFile(ctx, p)
+				res, err := s.SearchFile(ctx, p)
 
 				Expect(res).To(BeNil())
-				Expect(err.Code).To(Equal(int32(1004)))
-				Expect(err.Message).To(Equal("file is deleted"))
+				Expect(err.Code).To(Equal(int32(1001)))
+				Expect(err.Message).To(Equal("db error"))
 			})
 		})
 
-		When("success delete file", func() {
-			It("should return result", func() {
+		When("there is no file", func() {
+			It("should return empty result", func() {
 				validator.
 					EXPECT().
 					Validate(gomock.Eq(p)).
 					Return(nil).
 					Times(1)
 
-				clock.
-					EXPECT().
-					Now().
-					Return(currentTs).
-					Times(1)
-
+				searchRes := &repository.SearchFileResult{
+					Summary: repository.SearchFileSummary{},
+					Items:   []repository.SearchFileItem{},
+				}
 				fileRepo.
 					EXPECT().
-					DeleteFile(gomock.Eq(ctx), gomock.Any()).
-					Return(deleteRes, nil).
+					SearchFile(gomock.Eq(ctx), gomock.Eq(searchParam)).
+					Return(searchRes, nil).
 					Times(1)
 
-				res, err := s.DeleteFile(ctx, p)
-
+				res, err := s.SearchFile(ctx, p)
+
+				r := &service.SearchFileResult{
+					Success: system.Success{
+						Code:    1000,
+						Message: "success search file",
+					},
+					Items: []service.SearchFileItem{},
+					Summary: service.SearchFileSummary{
+						TotalItems: 0,
+						Page:       2,
+					},
+				}
 				Expect(res).To(Equal(r))
 				Expect(err).To(BeNil())
 			})
 		})
-	})
-
-	Context("NewDeleteFn function", Label("unit"), func() {
-		var (
-			ctx               context.Context
-			fileManager       *mock_filesystem.MockFileManager
-			fn                repository.DeleteFn
-			deleteFnParam     repository.DeleteFnParam
-			isFileExistsParam filesystem.IsFileExistsParam
-			removeParam       filesystem.RemoveFileParam
-			removeRes         *filesystem.RemoveFileResult
-		)
 
-		BeforeEach(func() {
-			currentTimestamp := time.Now()
-			ctx = context.Background()
-			t := GinkgoT()
-			ctrl := gomock.NewController(t)
-			fileManager = mock_filesystem.NewMockFileManager(ctrl)
-			fn = service.NewDeleteFn(fileManager)
-			deleteFnParam = repository.DeleteFnParam{
-				FilePath: "mock/path",
-			}
-			isFileExistsParam = filesystem.IsFileExistsParam{
-				Path: deleteFnParam.FilePath,
-			}
-			removeParam = filesystem.RemoveFileParam{
-				Path: deleteFnParam.FilePath,
-			}
-			removeRes = &filesystem.RemoveFileResult{
-				RemovedAt: currentTimestamp,
-			}
-		})
+		When("searched by non admin client", func() {
+			It("should only search owned file", func() {
+				ctx := auth.WithIdentity(ctx, &auth.Identity{
+					Id:       "id",
+					ClientId: "client-id",
+				})
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
 
-		When("failed check file existstance", func() {
-			It("should return error", func() {
-				fileManager.
+				searchParam.OwnerClientIds = []string{"id"}
+				fileRepo.
 					EXPECT().
-					IsFileExists(gomock.Eq(ctx), gomock.Eq(isFileExistsParam)).
-					Return(false, fmt.Errorf("failed read disk")).
+					SearchFile(gomock.Eq(ctx), gomock.Eq(searchParam)).
+					Return(searchRes, nil).
 					Times(1)
 
-				err := fn(ctx, deleteFnParam)
+				res, err := s.SearchFile(ctx, p)
 
-				Expect(err).To(Equal(fmt.Errorf("failed read disk")))
+				Expect(res).ToNot(BeNil())
+				Expect(err).To(BeNil())
 			})
 		})
 
-		When("file is not available in disk", func() {
-			It("should return error", func() {
-				fileManager.
+		When("searched by admin client", func() {
+			It("should search any file", func() {
+				ctx := auth.WithIdentity(ctx, &auth.Identity{
+					Id:       "admin-id",
+					ClientId: "admin-client",
+					Admin:    true,
+				})
+				validator.
 					EXPECT().
-					IsFileExists(gomock.Eq(ctx), gomock.Eq(isFileExistsParam)).
-					Return(false, nil).
+					Validate(gomock.Eq(p)).
+					Return(nil).
 					Times(1)
 
-				err := fn(ctx, deleteFnParam)
+				fileRepo.
+					EXPECT().
+					SearchFile(gomock.Eq(ctx), gomock.Eq(searchParam)).
+					Return(searchRes, nil).
+					Times(1)
 
-				Expect(err).To(Equal(file.ErrNotFound))
+				res, err := s.SearchFile(ctx, p)
+
+				Expect(res).ToNot(BeNil())
+				Expect(err).To(BeNil())
 			})
 		})
 
-		When("failed remove file from disk", func() {
-			It("should return error", func() {
-				fileManager.
+		When("sorting is specified", func() {
+			It("should use the given sorting", func() {
+				p.SortBy = "size"
+				p.SortOrder = "asc"
+				validator.
 					EXPECT().
-					IsFileExists(gomock.Eq(ctx), gomock.Eq(isFileExistsParam)).
-					Return(true, nil).
+					Validate(gomock.Eq(p)).
+					Return(nil).
 					Times(1)
 
-				fileManager.
+				searchParam.SortBy = "size"
+				searchParam.SortOrder = "asc"
+				fileRepo.
 					EXPECT().
-					RemoveFile(gomock.Eq(ctx), gomock.Eq(removeParam)).
-					Return(nil, fmt.Errorf("disk error")).
+					SearchFile(gomock.Eq(ctx), gomock.Eq(searchParam)).
+					Return(searchRes, nil).
 					Times(1)
 
-				err := fn(ctx, deleteFnParam)
+				res, err := s.SearchFile(ctx, p)
 
-				Expect(err).To(Equal(fmt.Errorf("disk error")))
+				Expect(res).ToNot(BeNil())
+				Expect(err).To(BeNil())
 			})
 		})
 
-		When("success remove file from disk", func() {
+		When("there are some files", func() {
 			It("should return result", func() {
-				fileManager.
+				validator.
 					EXPECT().
-					IsFileExists(gomock.Eq(ctx), gomock.Eq(isFileExistsParam)).
-					Return(true, nil).
+					Validate(gomock.Eq(p)).
+					Return(nil).
 					Times(1)
 
-				fileManager.
+				fileRepo.
 					EXPECT().
-					RemoveFile(gomock.Eq(ctx), gomock.Eq(removeParam)).
-					Return(removeRes, nil).
+					SearchFile(gomock.Eq(ctx), gomock.Eq(searchParam)).
+					Return(searchRes, nil).
 					Times(1)
 
-				err := fn(ctx, deleteFnParam)
-
+				res, err := s.SearchFile(ctx, p)
+
+				r := &service.SearchFileResult{
+					Success: system.Success{
+						Code:    1000,
+						Message: "success search file",
+					},
+					Items: []service.SearchFileItem{
+						{
+							UniqueId:  "id-1",
+							Name:      "dolpin",
+							Path:      "/storage/id-1.jpg",
+							MimeType:  "image/jpeg",
+							Extension: "jpg",
+							Size:      150,
+							Checksum: file.Checksum{
+								Sha256: "sha256",
+								Md5:    "md5",
+							},
+							UploadedAt: currentTs,
+						},
+					},
+					Summary: service.SearchFileSummary{
+						TotalItems: 25,
+						Page:       2,
+					},
+				}
+				Expect(res).To(Equal(r))
 				Expect(err).To(BeNil())
 			})
 		})
@@ -51,6 +51,21 @@ func (mr *MockAuthClientMockRecorder) CreateClient(ctx, p interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateClient", reflect.TypeOf((*MockAuthClient)(nil).CreateClient), ctx, p)
 }
 
+// DeleteClientById mocks base method.
+func (m *MockAuthClient) DeleteClientById(ctx context.Context, p service.DeleteClientByIdParam) (*service.DeleteClientByIdResult, *system.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteClientById", ctx, p)
+	ret0, _ := ret[0].(*service.DeleteClientByIdResult)
+	ret1, _ := ret[1].(*system.Error)
+	return ret0, ret1
+}
+
+// DeleteClientById indicates an expected call of DeleteClientById.
+func (mr *MockAuthClientMockRecorder) DeleteClientById(ctx, p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteClientById", reflect.TypeOf((*MockAuthClient)(nil).DeleteClientById), ctx, p)
+}
+
 // FindClientById mocks base method.
 func (m *MockAuthClient) FindClientById(ctx context.Context, p service.FindClientByIdParam) (*service.FindClientByIdResult, *system.Error) {
 	m.ctrl.T.Helper()
@@ -66,6 +81,36 @@ func (mr *MockAuthClientMockRecorder) FindClientById(ctx, p interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindClientById", reflect.TypeOf((*MockAuthClient)(nil).FindClientById), ctx, p)
 }
 
+// RevokeClientSecret mocks base method.
+func (m *MockAuthClient) RevokeClientSecret(ctx context.Context, p service.RevokeClientSecretParam) (*service.RevokeClientSecretResult, *system.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeClientSecret", ctx, p)
+	ret0, _ := ret[0].(*service.RevokeClientSecretResult)
+	ret1, _ := ret[1].(*system.Error)
+	return ret0, ret1
+}
+
+// RevokeClientSecret indicates an expected call of RevokeClientSecret.
+func (mr *MockAuthClientMockRecorder) RevokeClientSecret(ctx, p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeClientSecret", reflect.TypeOf((*MockAuthClient)(nil).RevokeClientSecret), ctx, p)
+}
+
+// RotateClientSecret mocks base method.
+func (m *MockAuthClient) RotateClientSecret(ctx context.Context, p service.RotateClientSecretParam) (*service.RotateClientSecretResult, *system.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateClientSecret", ctx, p)
+	ret0, _ := ret[0].(*service.RotateClientSecretResult)
+	ret1, _ := ret[1].(*system.Error)
+	return ret0, ret1
+}
+
+// RotateClientSecret indicates an expected call of RotateClientSecret.
+func (mr *MockAuthClientMockRecorder) RotateClientSecret(ctx, p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateClientSecret", reflect.TypeOf((*MockAuthClient)(nil).RotateClientSecret), ctx, p)
+}
+
 // SearchClient mocks base method.
 func (m *MockAuthClient) SearchClient(ctx context.Context, p service.SearchClientParam) (*service.SearchClientResult, *system.Error) {
 	m.ctrl.T.Helper()
@@ -51,6 +51,21 @@ func (mr *MockFileMockRecorder) DeleteFile(ctx, p interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFile", reflect.TypeOf((*MockFile)(nil).DeleteFile), ctx, p)
 }
 
+// RestoreFile mocks base method.
+func (m *MockFile) RestoreFile(ctx context.Context, p service.RestoreFileParam) (*service.RestoreFileResult, *system.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreFile", ctx, p)
+	ret0, _ := ret[0].(*service.RestoreFileResult)
+	ret1, _ := ret[1].(*system.Error)
+	return ret0, ret1
+}
+
+// RestoreFile indicates an expected call of RestoreFile.
+func (mr *MockFileMockRecorder) RestoreFile(ctx, p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreFile", reflect.TypeOf((*MockFile)(nil).RestoreFile), ctx, p)
+}
+
 // RetrieveFile mocks base method.
 func (m *MockFile) RetrieveFile(ctx context.Context, p service.RetrieveFileParam) (*service.RetrieveFileResult, *system.Error) {
 	m.ctrl.T.Helper()
@@ -66,6 +81,21 @@ func (mr *MockFileMockRecorder) RetrieveFile(ctx, p interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetrieveFile", reflect.TypeOf((*MockFile)(nil).RetrieveFile), ctx, p)
 }
 
+// SearchFile mocks base method.
+func (m *MockFile) SearchFile(ctx context.Context, p service.SearchFileParam) (*service.SearchFileResult, *system.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchFile", ctx, p)
+	ret0, _ := ret[0].(*service.SearchFileResult)
+	ret1, _ := ret[1].(*system.Error)
+	return ret0, ret1
+}
+
+// SearchFile indicates an expected call of SearchFile.
+func (mr *MockFileMockRecorder) SearchFile(ctx, p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchFile", reflect.TypeOf((*MockFile)(nil).SearchFile), ctx, p)
+}
+
 // UploadFile mocks base method.
 func (m *MockFile) UploadFile(ctx context.Context, opts ...service.UploadFileOption) (*service.UploadFileResult, *system.Error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,127 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/multipart.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	service "github.com/go-seidon/hippo/internal/service"
+	system "github.com/go-seidon/provider/system"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockMultipart is a mock of Multipart interface.
+type MockMultipart struct {
+	ctrl     *gomock.Controller
+	recorder *MockMultipartMockRecorder
+}
+
+// MockMultipartMockRecorder is the mock recorder for MockMultipart.
+type MockMultipartMockRecorder struct {
+	mock *MockMultipart
+}
+
+// NewMockMultipart creates a new mock instance.
+func NewMockMultipart(ctrl *gomock.Controller) *MockMultipart {
+	mock := &MockMultipart{ctrl: ctrl}
+	mock.recorder = &MockMultipartMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMultipart) EXPECT() *MockMultipartMockRecorder {
+	return m.recorder
+}
+
+// AbortMultipart mocks base method.
+func (m *MockMultipart) AbortMultipart(ctx context.Context, p service.AbortMultipartParam) (*service.AbortMultipartResult, *system.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AbortMultipart", ctx, p)
+	ret0, _ := ret[0].(*service.AbortMultipartResult)
+	ret1, _ := ret[1].(*system.Error)
+	return ret0, ret1
+}
+
+// AbortMultipart indicates an expected call of AbortMultipart.
+func (mr *MockMultipartMockRecorder) AbortMultipart(ctx, p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AbortMultipart", reflect.TypeOf((*MockMultipart)(nil).AbortMultipart), ctx, p)
+}
+
+// CleanupMultipart mocks base method.
+func (m *MockMultipart) CleanupMultipart(ctx context.Context, p service.CleanupMultipartParam) (*service.CleanupMultipartResult, *system.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CleanupMultipart", ctx, p)
+	ret0, _ := ret[0].(*service.CleanupMultipartResult)
+	ret1, _ := ret[1].(*system.Error)
+	return ret0, ret1
+}
+
+// CleanupMultipart indicates an expected call of CleanupMultipart.
+func (mr *MockMultipartMockRecorder) CleanupMultipart(ctx, p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CleanupMultipart", reflect.TypeOf((*MockMultipart)(nil).CleanupMultipart), ctx, p)
+}
+
+// CompleteMultipart mocks base method.
+func (m *MockMultipart) CompleteMultipart(ctx context.Context, p service.CompleteMultipartParam) (*service.CompleteMultipartResult, *system.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteMultipart", ctx, p)
+	ret0, _ := ret[0].(*service.CompleteMultipartResult)
+	ret1, _ := ret[1].(*system.Error)
+	return ret0, ret1
+}
+
+// CompleteMultipart indicates an expected call of CompleteMultipart.
+func (mr *MockMultipartMockRecorder) CompleteMultipart(ctx, p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteMultipart", reflect.TypeOf((*MockMultipart)(nil).CompleteMultipart), ctx, p)
+}
+
+// InitiateMultipart mocks base method.
+func (m *MockMultipart) InitiateMultipart(ctx context.Context, p service.InitiateMultipartParam) (*service.InitiateMultipartResult, *system.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InitiateMultipart", ctx, p)
+	ret0, _ := ret[0].(*service.InitiateMultipartResult)
+	ret1, _ := ret[1].(*system.Error)
+	return ret0, ret1
+}
+
+// InitiateMultipart indicates an expected call of InitiateMultipart.
+func (mr *MockMultipartMockRecorder) InitiateMultipart(ctx, p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InitiateMultipart", reflect.TypeOf((*MockMultipart)(nil).InitiateMultipart), ctx, p)
+}
+
+// ListPart mocks base method.
+func (m *MockMultipart) ListPart(ctx context.Context, p service.ListPartParam) (*service.ListPartResult, *system.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPart", ctx, p)
+	ret0, _ := ret[0].(*service.ListPartResult)
+	ret1, _ := ret[1].(*system.Error)
+	return ret0, ret1
+}
+
+// ListPart indicates an expected call of ListPart.
+func (mr *MockMultipartMockRecorder) ListPart(ctx, p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPart", reflect.TypeOf((*MockMultipart)(nil).ListPart), ctx, p)
+}
+
+// UploadPart mocks base method.
+func (m *MockMultipart) UploadPart(ctx context.Context, p service.UploadPartParam) (*service.UploadPartResult, *system.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadPart", ctx, p)
+	ret0, _ := ret[0].(*service.UploadPartResult)
+	ret1, _ := ret[1].(*system.Error)
+	return ret0, ret1
+}
+
+// UploadPart indicates an expected call of UploadPart.
+func (mr *MockMultipartMockRecorder) UploadPart(ctx, p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadPart", reflect.TypeOf((*MockMultipart)(nil).UploadPart), ctx, p)
+}
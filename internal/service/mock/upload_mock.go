@@ -0,0 +1,97 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/upload.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	service "github.com/go-seidon/hippo/internal/service"
+	system "github.com/go-seidon/provider/system"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUpload is a mock of Upload interface.
+type MockUpload struct {
+	ctrl     *gomock.Controller
+	recorder *MockUploadMockRecorder
+}
+
+// MockUploadMockRecorder is the mock recorder for MockUpload.
+type MockUploadMockRecorder struct {
+	mock *MockUpload
+}
+
+// NewMockUpload creates a new mock instance.
+func NewMockUpload(ctrl *gomock.Controller) *MockUpload {
+	mock := &MockUpload{ctrl: ctrl}
+	mock.recorder = &MockUploadMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUpload) EXPECT() *MockUploadMockRecorder {
+	return m.recorder
+}
+
+// AppendUpload mocks base method.
+func (m *MockUpload) AppendUpload(ctx context.Context, p service.AppendUploadParam) (*service.AppendUploadResult, *system.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AppendUpload", ctx, p)
+	ret0, _ := ret[0].(*service.AppendUploadResult)
+	ret1, _ := ret[1].(*system.Error)
+	return ret0, ret1
+}
+
+// AppendUpload indicates an expected call of AppendUpload.
+func (mr *MockUploadMockRecorder) AppendUpload(ctx, p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendUpload", reflect.TypeOf((*MockUpload)(nil).AppendUpload), ctx, p)
+}
+
+// CreateUpload mocks base method.
+func (m *MockUpload) CreateUpload(ctx context.Context, p service.CreateUploadParam) (*service.CreateUploadResult, *system.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUpload", ctx, p)
+	ret0, _ := ret[0].(*service.CreateUploadResult)
+	ret1, _ := ret[1].(*system.Error)
+	return ret0, ret1
+}
+
+// CreateUpload indicates an expected call of CreateUpload.
+func (mr *MockUploadMockRecorder) CreateUpload(ctx, p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUpload", reflect.TypeOf((*MockUpload)(nil).CreateUpload), ctx, p)
+}
+
+// DeleteUpload mocks base method.
+func (m *MockUpload) DeleteUpload(ctx context.Context, p service.DeleteUploadParam) (*service.DeleteUploadResult, *system.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUpload", ctx, p)
+	ret0, _ := ret[0].(*service.DeleteUploadResult)
+	ret1, _ := ret[1].(*system.Error)
+	return ret0, ret1
+}
+
+// DeleteUpload indicates an expected call of DeleteUpload.
+func (mr *MockUploadMockRecorder) DeleteUpload(ctx, p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUpload", reflect.TypeOf((*MockUpload)(nil).DeleteUpload), ctx, p)
+}
+
+// RetrieveUpload mocks base method.
+func (m *MockUpload) RetrieveUpload(ctx context.Context, p service.RetrieveUploadParam) (*service.RetrieveUploadResult, *system.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetrieveUpload", ctx, p)
+	ret0, _ := ret[0].(*service.RetrieveUploadResult)
+	ret1, _ := ret[1].(*system.Error)
+	return ret0, ret1
+}
+
+// RetrieveUpload indicates an expected call of RetrieveUpload.
+func (mr *MockUploadMockRecorder) RetrieveUpload(ctx, p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetrieveUpload", reflect.TypeOf((*MockUpload)(nil).RetrieveUpload), ctx, p)
+}
@@ -0,0 +1,724 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/go-seidon/hippo/internal/auth"
+	"github.com/go-seidon/hippo/internal/file"
+	"github.com/go-seidon/hippo/internal/filesystem"
+	"github.com/go-seidon/hippo/internal/repository"
+	"github.com/go-seidon/provider/datetime"
+	"github.com/go-seidon/provider/identity"
+	"github.com/go-seidon/provider/logging"
+	"github.com/go-seidon/provider/status"
+	"github.com/go-seidon/provider/system"
+	"github.com/go-seidon/provider/validation"
+)
+
+var (
+	errPartMismatch = errors.New("part is not matched")
+)
+
+// @note: multipart is an upload session which content is uploaded as numbered parts,
+// the parts may be uploaded in parallel and they're joined in order once it's completed
+type Multipart interface {
+	InitiateMultipart(ctx context.Context, p InitiateMultipartParam) (*InitiateMultipartResult, *system.Error)
+	UploadPart(ctx context.Context, p UploadPartParam) (*UploadPartResult, *system.Error)
+	ListPart(ctx context.Context, p ListPartParam) (*ListPartResult, *system.Error)
+	CompleteMultipart(ctx context.Context, p CompleteMultipartParam) (*CompleteMultipartResult, *system.Error)
+	AbortMultipart(ctx context.Context, p AbortMultipartParam) (*AbortMultipartResult, *system.Error)
+	CleanupMultipart(ctx context.Context, p CleanupMultipartParam) (*CleanupMultipartResult, *system.Error)
+}
+
+type InitiateMultipartParam struct {
+	Name      string `validate:"max=4096" label:"name"`
+	Mimetype  string `validate:"max=256" label:"mimetype"`
+	Extension string `validate:"max=128" label:"extension"`
+}
+
+type InitiateMultipartResult struct {
+	Success   system.Success
+	UniqueId  string
+	CreatedAt time.Time
+}
+
+type UploadPartParam struct {
+	MultipartId string `validate:"required,min=5,max=64" label:"upload_id"`
+	PartNumber  int32  `validate:"min=1,max=10000" label:"part_number"`
+	Reader      io.Reader
+}
+
+type UploadPartResult struct {
+	Success        system.Success
+	PartNumber     int32
+	Size           int64
+	ChecksumSha256 string
+	UploadedAt     time.Time
+}
+
+type ListPartParam struct {
+	MultipartId string `validate:"required,min=5,max=64" label:"upload_id"`
+}
+
+type ListPartResult struct {
+	Success system.Success
+	Items   []ListPartItem
+}
+
+type ListPartItem struct {
+	PartNumber     int32
+	Size           int64
+	ChecksumSha256 string
+	UploadedAt     time.Time
+}
+
+// @note: parts must be ordered by the part number,
+// uploaded parts which are not listed are discarded
+type CompleteMultipartParam struct {
+	MultipartId string              `validate:"required,min=5,max=64" label:"upload_id"`
+	Parts       []CompletePartParam `validate:"required,min=1,max=10000,dive" label:"parts"`
+}
+
+type CompletePartParam struct {
+	PartNumber     int32  `validate:"min=1,max=10000" label:"part_number"`
+	ChecksumSha256 string `validate:"required" label:"checksum_sha256"`
+}
+
+type CompleteMultipartResult struct {
+	Success     system.Success
+	UniqueId    string
+	Name        string
+	Path        string
+	Mimetype    string
+	Extension   string
+	Size        int64
+	UploadedAt  time.Time
+	Checksum    file.Checksum
+	CompletedAt time.Time
+}
+
+type AbortMultipartParam struct {
+	MultipartId string `validate:"required,min=5,max=64" label:"upload_id"`
+}
+
+type AbortMultipartResult struct {
+	Success   system.Success
+	AbortedAt time.Time
+}
+
+type CleanupMultipartParam struct {
+	Limit int32 `validate:"min=1,max=1000" label:"limit"`
+}
+
+type CleanupMultipartResult struct {
+	Success system.Success
+	Total   int32
+}
+
+var _ Multipart = (*multipartService)(nil)
+
+type multipartService struct {
+	multipartRepo  repository.Multipart
+	fileClient     File
+	partialManager filesystem.PartialManager
+	dirManager     filesystem.DirectoryManager
+	identifier     identity.Identifier
+	clock          datetime.Clock
+	log            logging.Logger
+	validator      validation.Validator
+	config         *MultipartConfig
+}
+
+func (s *multipartService) InitiateMultipart(ctx context.Context, p InitiateMultipartParam) (*InitiateMultipartResult, *system.Error) {
+	s.log.Debug("In function: InitiateMultipart")
+	defer s.log.Debug("Returning function: InitiateMultipart")
+
+	err := s.validator.Validate(p)
+	if err != nil {
+		return nil, &system.Error{
+			Code:    status.INVALID_PARAM,
+			Message: err.Error(),
+		}
+	}
+
+	uniqueId, err := s.identifier.GenerateId()
+	if err != nil {
+		return nil, &system.Error{
+			Code:    status.ACTION_FAILED,
+			Message: err.Error(),
+		}
+	}
+
+	ownerClientId := ""
+	client := auth.IdentityFromContext(ctx)
+	if client != nil {
+		ownerClientId = client.Id
+	}
+
+	currentTs := s.clock.Now()
+	createRes, err := s.multipartRepo.CreateMultipart(ctx, repository.CreateMultipartParam{
+		UniqueId:      uniqueId,
+		Name:          p.Name,
+		Mimetype:      p.Mimetype,
+		Extension:     p.Extension,
+		CreatedAt:     currentTs,
+		OwnerClientId: ownerClientId,
+	})
+	if err != nil {
+		return nil, &system.Error{
+			Code:    status.ACTION_FAILED,
+			Message: err.Error(),
+		}
+	}
+
+	res := &InitiateMultipartResult{
+		Success: system.Success{
+			Code:    status.ACTION_SUCCESS,
+			Message: "success initiate multipart",
+		},
+		UniqueId:  createRes.UniqueId,
+		CreatedAt: createRes.CreatedAt,
+	}
+	return res, nil
+}
+
+// @note: part is written to its own partial file so the same part number
+// can be re-uploaded, the replaced part file is removed afterwards
+func (s *multipartService) UploadPart(ctx context.Context, p UploadPartParam) (*UploadPartResult, *system.Error) {
+	s.log.Debug("In function: UploadPart")
+	defer s.log.Debug("Returning function: UploadPart")
+
+	err := s.validator.Validate(p)
+	if err != nil {
+		return nil, &system.Error{
+			Code:    status.INVALID_PARAM,
+			Message: err.Error(),
+		}
+	}
+
+	if p.Reader == nil {
+		return nil, &system.Error{
+			Code:    status.INVALID_PARAM,
+			Message: "part is not specified",
+		}
+	}
+
+	multipart, rErr := s.retrieveMultipart(ctx, p.MultipartId)
+	if rErr != nil {
+		return nil, rErr
+	}
+	if multipart.CompletedAt != nil {
+		return nil, s.newRepoError(repository.ErrConflict)
+	}
+
+	exists, err := s.dirManager.IsDirectoryExists(ctx, filesystem.IsDirectoryExistsParam{
+		Path: s.config.PartialDir,
+	})
+	if err != nil {
+		return nil, &system.Error{
+			Code:    status.ACTION_FAILED,
+			Message: err.Error(),
+		}
+	}
+
+	if !exists {
+		_, err := s.dirManager.CreateDir(ctx, filesystem.CreateDirParam{
+			Path:       s.config.PartialDir,
+			Permission: 0644,
+		})
+		if err != nil {
+			return nil, &system.Error{
+				Code:    status.ACTION_FAILED,
+				Message: err.Error(),
+			}
+		}
+	}
+
+	partId, err := s.identifier.GenerateId()
+	if err != nil {
+		return nil, &system.Error{
+			Code:    status.ACTION_FAILED,
+			Message: err.Error(),
+		}
+	}
+
+	path := fmt.Sprintf("%s/%s.%d.%s", s.config.PartialDir, p.MultipartId, p.PartNumber, partId)
+	_, err = s.partialManager.CreatePartial(ctx, filesystem.CreatePartialParam{
+		Path:       path,
+		Permission: 0644,
+	})
+	if err != nil {
+		return nil, &system.Error{
+			Code:    status.ACTION_FAILED,
+			Message: err.Error(),
+		}
+	}
+
+	hasher := sha256.New()
+	save, err := s.partialManager.AppendPartial(ctx, filesystem.AppendPartialParam{
+		Path:   path,
+		Offset: 0,
+		Reader: io.TeeReader(p.Reader, hasher),
+	})
+	if err != nil {
+		s.removePart(ctx, path)
+		return nil, &system.Error{
+			Code:    status.ACTION_FAILED,
+			Message: err.Error(),
+		}
+	}
+
+	upload, err := s.multipartRepo.UploadPart(ctx, repository.UploadPartParam{
+		MultipartId:    p.MultipartId,
+		PartNumber:     p.PartNumber,
+		Path:           path,
+		Size:           save.Size,
+		ChecksumSha256: hex.EncodeToString(hasher.Sum(nil)),
+		UploadedAt:     s.clock.Now(),
+	})
+	if err != nil {
+		s.removePart(ctx, path)
+		return nil, s.newRepoError(err)
+	}
+
+	if upload.ReplacedPath != "" {
+		s.removePart(ctx, upload.ReplacedPath)
+	}
+
+	res := &UploadPartResult{
+		Success: system.Success{
+			Code:    status.ACTION_SUCCESS,
+			Message: "success upload part",
+		},
+		PartNumber:     upload.PartNumber,
+		Size:           upload.Size,
+		ChecksumSha256: upload.ChecksumSha256,
+		UploadedAt:     upload.UploadedAt,
+	}
+	return res, nil
+}
+
+func (s *multipartService) ListPart(ctx context.Context, p ListPartParam) (*ListPartResult, *system.Error) {
+	s.log.Debug("In function: ListPart")
+	defer s.log.Debug("Returning function: ListPart")
+
+	err := s.validator.Validate(p)
+	if err != nil {
+		return nil, &system.Error{
+			Code:    status.INVALID_PARAM,
+			Message: err.Error(),
+		}
+	}
+
+	_, rErr := s.retrieveMultipart(ctx, p.MultipartId)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	parts, err := s.multipartRepo.ListPart(ctx, repository.ListPartParam{
+		MultipartId: p.MultipartId,
+	})
+	if err != nil {
+		return nil, &system.Error{
+			Code:    status.ACTION_FAILED,
+			Message: err.Error(),
+		}
+	}
+
+	items := []ListPartItem{}
+	for _, part := range parts.Items {
+		items = append(items, ListPartItem{
+			PartNumber:     part.PartNumber,
+			Size:           part.Size,
+			ChecksumSha256: part.ChecksumSha256,
+			UploadedAt:     part.UploadedAt,
+		})
+	}
+
+	res := &ListPartResult{
+		Success: system.Success{
+			Code:    status.ACTION_SUCCESS,
+			Message: "success list part",
+		},
+		Items: items,
+	}
+	return res, nil
+}
+
+// @note: the parts are joined outside of the repository transaction and the total size is limited,
+// the completion only checks that the joined parts are still the uploaded ones,
+// otherwise the joined file is removed
+func (s *multipartService) CompleteMultipart(ctx context.Context, p CompleteMultipartParam) (*CompleteMultipartResult, *system.Error) {
+	s.log.Debug("In function: CompleteMultipart")
+	defer s.log.Debug("Returning function: CompleteMultipart")
+
+	err := s.validator.Validate(p)
+	if err != nil {
+		return nil, &system.Error{
+			Code:    status.INVALID_PARAM,
+			Message: err.Error(),
+		}
+	}
+
+	for i := 1; i < len(p.Parts); i++ {
+		if p.Parts[i].PartNumber <= p.Parts[i-1].PartNumber {
+			return nil, &system.Error{
+				Code:    status.INVALID_PARAM,
+				Message: "parts must be ordered by part number",
+			}
+		}
+	}
+
+	multipart, rErr := s.retrieveMultipart(ctx, p.MultipartId)
+	if rErr != nil {
+		return nil, rErr
+	}
+	if multipart.CompletedAt != nil {
+		return nil, s.newRepoError(repository.ErrConflict)
+	}
+
+	uploaded, err := s.multipartRepo.ListPart(ctx, repository.ListPartParam{
+		MultipartId: p.MultipartId,
+	})
+	if err != nil {
+		return nil, &system.Error{
+			Code:    status.ACTION_FAILED,
+			Message: err.Error(),
+		}
+	}
+
+	paths, size, err := joinParts(p.Parts, uploaded.Items)
+	if err != nil {
+		return nil, &system.Error{
+			Code:    MULTIPART_PART_MISMATCH,
+			Message: "multipart part is not matched",
+		}
+	}
+
+	if s.config.MaxSize > 0 && size > s.config.MaxSize {
+		return nil, &system.Error{
+			Code:    UPLOAD_SIZE_EXCEEDED,
+			Message: "multipart size is exceeded",
+		}
+	}
+
+	reader := &partReader{
+		ctx:            ctx,
+		partialManager: s.partialManager,
+		paths:          paths,
+	}
+	uploadFile, uErr := s.fileClient.UploadFile(
+		ctx,
+		WithReader(reader),
+		WithFileInfo(multipart.Name, multipart.Mimetype, multipart.Extension, size),
+	)
+	reader.Close()
+	if uErr != nil {
+		return nil, uErr
+	}
+
+	complete, err := s.multipartRepo.CompleteMultipart(ctx, repository.CompleteMultipartParam{
+		UniqueId:    p.MultipartId,
+		CompletedAt: s.clock.Now(),
+		CompleteFn: func(ctx context.Context, cp repository.CompleteFnParam) (*repository.CompleteFnResult, error) {
+			current, _, err := joinParts(p.Parts, cp.Parts)
+			if err != nil {
+				return nil, err
+			}
+			for i, path := range current {
+				if path != paths[i] {
+					return nil, errPartMismatch
+				}
+			}
+
+			res := &repository.CompleteFnResult{
+				FileId: uploadFile.UniqueId,
+			}
+			return res, nil
+		},
+	})
+	if err != nil {
+		_, dErr := s.fileClient.DeleteFile(ctx, DeleteFileParam{
+			FileId: uploadFile.UniqueId,
+		})
+		if dErr != nil {
+			s.log.Warnf("Failed removing joined file, err: %s", dErr.Message)
+		}
+
+		if errors.Is(err, errPartMismatch) {
+			return nil, &system.Error{
+				Code:    MULTIPART_PART_MISMATCH,
+				Message: "multipart part is not matched",
+			}
+		}
+		return nil, s.newRepoError(err)
+	}
+
+	for _, part := range complete.Parts {
+		s.removePart(ctx, part.Path)
+	}
+
+	res := &CompleteMultipartResult{
+		Success: system.Success{
+			Code:    status.ACTION_SUCCESS,
+			Message: "success complete multipart",
+		},
+		UniqueId:    uploadFile.UniqueId,
+		Name:        uploadFile.Name,
+		Path:        uploadFile.Path,
+		Mimetype:    uploadFile.Mimetype,
+		Extension:   uploadFile.Extension,
+		Size:        uploadFile.Size,
+		UploadedAt:  uploadFile.UploadedAt,
+		Checksum:    uploadFile.Checksum,
+		CompletedAt: complete.CompletedAt,
+	}
+	return res, nil
+}
+
+func (s *multipartService) AbortMultipart(ctx context.Context, p AbortMultipartParam) (*AbortMultipartResult, *system.Error) {
+	s.log.Debug("In function: AbortMultipart")
+	defer s.log.Debug("Returning function: AbortMultipart")
+
+	err := s.validator.Validate(p)
+	if err != nil {
+		return nil, &system.Error{
+			Code:    status.INVALID_PARAM,
+			Message: err.Error(),
+		}
+	}
+
+	multipart, rErr := s.retrieveMultipart(ctx, p.MultipartId)
+	if rErr != nil {
+		return nil, rErr
+	}
+	if multipart.CompletedAt != nil {
+		return nil, s.newRepoError(repository.ErrConflict)
+	}
+
+	deletion, err := s.multipartRepo.DeleteMultipart(ctx, repository.DeleteMultipartParam{
+		UniqueId:  p.MultipartId,
+		DeletedAt: s.clock.Now(),
+	})
+	if err != nil {
+		return nil, s.newRepoError(err)
+	}
+
+	for _, part := range deletion.Parts {
+		s.removePart(ctx, part.Path)
+	}
+
+	res := &AbortMultipartResult{
+		Success: system.Success{
+			Code:    status.ACTION_SUCCESS,
+			Message: "success abort multipart",
+		},
+		AbortedAt: deletion.DeletedAt,
+	}
+	return res, nil
+}
+
+// @note: remove multipart which has no activity (initiated or part uploaded) within the ttl (including the completed one),
+// failure of a single multipart is logged so the rest of the batch is still cleaned up
+func (s *multipartService) CleanupMultipart(ctx context.Context, p CleanupMultipartParam) (*CleanupMultipartResult, *system.Error) {
+	s.log.Debug("In function: CleanupMultipart")
+	defer s.log.Debug("Returning function: CleanupMultipart")
+
+	err := s.validator.Validate(p)
+	if err != nil {
+		return nil, &system.Error{
+			Code:    status.INVALID_PARAM,
+			Message: err.Error(),
+		}
+	}
+
+	currentTs := s.clock.Now()
+	search, err := s.multipartRepo.SearchMultipart(ctx, repository.SearchMultipartParam{
+		UpdatedBefore: currentTs.Add(-s.config.Ttl),
+		Limit:         p.Limit,
+	})
+	if err != nil {
+		return nil, &system.Error{
+			Code:    status.ACTION_FAILED,
+			Message: err.Error(),
+		}
+	}
+
+	total := int32(0)
+	for _, multipart := range search.Items {
+		deletion, err := s.multipartRepo.DeleteMultipart(ctx, repository.DeleteMultipartParam{
+			UniqueId:  multipart.UniqueId,
+			DeletedAt: currentTs,
+		})
+		if err != nil {
+			s.log.Warnf("Failed removing expired multipart %s, err: %s", multipart.UniqueId, err.Error())
+			continue
+		}
+
+		for _, part := range deletion.Parts {
+			s.removePart(ctx, part.Path)
+		}
+		total++
+	}
+
+	res := &CleanupMultipartResult{
+		Success: system.Success{
+			Code:    status.ACTION_SUCCESS,
+			Message: "success cleanup multipart",
+		},
+		Total: total,
+	}
+	return res, nil
+}
+
+func (s *multipartService) removePart(ctx context.Context, path string) {
+	_, err := s.partialManager.RemovePartial(ctx, filesystem.RemovePartialParam{
+		Path: path,
+	})
+	if err != nil && !errors.Is(err, filesystem.ErrorFileNotFound) {
+		s.log.Warnf("Failed removing part file, err: %s", err.Error())
+	}
+}
+
+func (s *multipartService) retrieveMultipart(ctx context.Context, multipartId string) (*repository.RetrieveMultipartResult, *system.Error) {
+	multipart, err := s.multipartRepo.RetrieveMultipart(ctx, repository.RetrieveMultipartParam{
+		UniqueId: multipartId,
+	})
+	if err != nil {
+		return nil, s.newRepoError(err)
+	}
+
+	if !canAccess(ctx, multipart.OwnerClientId, true) {
+		return nil, &system.Error{
+			Code:    status.ACTION_FORBIDDEN,
+			Message: "multipart is not accessible",
+		}
+	}
+	return multipart, nil
+}
+
+func (s *multipartService) newRepoError(err error) *system.Error {
+	if errors.Is(err, repository.ErrNotFound) {
+		return &system.Error{
+			Code:    status.RESOURCE_NOTFOUND,
+			Message: "multipart is not found",
+		}
+	} else if errors.Is(err, repository.ErrConflict) {
+		return &system.Error{
+			Code:    MULTIPART_COMPLETED,
+			Message: "multipart is already completed",
+		}
+	}
+	return &system.Error{
+		Code:    status.ACTION_FAILED,
+		Message: err.Error(),
+	}
+}
+
+// @note: return the paths of the requested parts in order and their total size
+func joinParts(requested []CompletePartParam, uploaded []repository.PartItem) ([]string, int64, error) {
+	parts := map[int32]repository.PartItem{}
+	for _, part := range uploaded {
+		parts[part.PartNumber] = part
+	}
+
+	paths := []string{}
+	size := int64(0)
+	for _, part := range requested {
+		item, ok := parts[part.PartNumber]
+		if !ok || item.ChecksumSha256 != part.ChecksumSha256 {
+			return nil, 0, errPartMismatch
+		}
+		paths = append(paths, item.Path)
+		size += item.Size
+	}
+	return paths, size, nil
+}
+
+// partReader reads the part files one after another,
+// the part file is opened only when the previous one is fully read
+type partReader struct {
+	ctx            context.Context
+	partialManager filesystem.PartialManager
+	paths          []string
+	current        io.ReadCloser
+}
+
+func (r *partReader) Read(p []byte) (int, error) {
+	for {
+		if r.current == nil {
+			if len(r.paths) == 0 {
+				return 0, io.EOF
+			}
+
+			open, err := r.partialManager.OpenPartial(r.ctx, filesystem.OpenPartialParam{
+				Path: r.paths[0],
+			})
+			if err != nil {
+				return 0, err
+			}
+			r.current = open.File
+			r.paths = r.paths[1:]
+		}
+
+		n, err := r.current.Read(p)
+		if err == io.EOF {
+			err = r.Close()
+			if err != nil {
+				return n, err
+			}
+			if n == 0 {
+				continue
+			}
+		}
+		return n, err
+	}
+}
+
+func (r *partReader) Close() error {
+	if r.current == nil {
+		return nil
+	}
+	err := r.current.Close()
+	r.current = nil
+	return err
+}
+
+type MultipartConfig struct {
+	PartialDir string
+	Ttl        time.Duration
+	// @note: maximum total size of the joined parts, unlimited when it's not specified
+	MaxSize int64
+}
+
+type MultipartParam struct {
+	MultipartRepo  repository.Multipart
+	FileClient     File
+	PartialManager filesystem.PartialManager
+	DirManager     filesystem.DirectoryManager
+	Logger         logging.Logger
+	Identifier     identity.Identifier
+	Clock          datetime.Clock
+	Validator      validation.Validator
+	Config         *MultipartConfig
+}
+
+func NewMultipart(p MultipartParam) *multipartService {
+	return &multipartService{
+		multipartRepo:  p.MultipartRepo,
+		fileClient:     p.FileClient,
+		partialManager: p.PartialManager,
+		dirManager:     p.DirManager,
+		identifier:     p.Identifier,
+		clock:          p.Clock,
+		log:            p.Logger,
+		validator:      p.Validator,
+		config:         p.Config,
+	}
+}
@@ -0,0 +1,1662 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/go-seidon/hippo/internal/auth"
+	"github.com/go-seidon/hippo/internal/file"
+	"github.com/go-seidon/hippo/internal/filesystem"
+	mock_filesystem "github.com/go-seidon/hippo/internal/filesystem/mock"
+	"github.com/go-seidon/hippo/internal/repository"
+	mock_repository "github.com/go-seidon/hippo/internal/repository/mock"
+	"github.com/go-seidon/hippo/internal/service"
+	mock_service "github.com/go-seidon/hippo/internal/service/mock"
+	mock_datetime "github.com/go-seidon/provider/datetime/mock"
+	mock_identifier "github.com/go-seidon/provider/identity/mock"
+	mock_logging "github.com/go-seidon/provider/logging/mock"
+	"github.com/go-seidon/provider/system"
+	mock_validation "github.com/go-seidon/provider/validation/mock"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Multipart Service", func() {
+	var (
+		ctx            context.Context
+		currentTs      time.Time
+		multipartRepo  *mock_repository.MockMultipart
+		fileClient     *mock_service.MockFile
+		partialManager *mock_filesystem.MockPartialManager
+		dirManager     *mock_filesystem.MockDirectoryManager
+		identifier     *mock_identifier.MockIdentifier
+		clock          *mock_datetime.MockClock
+		log            *mock_logging.MockLogger
+		validator      *mock_validation.MockValidator
+		s              service.Multipart
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		currentTs = time.Now().UTC()
+		t := GinkgoT()
+		ctrl := gomock.NewController(t)
+		multipartRepo = mock_repository.NewMockMultipart(ctrl)
+		fileClient = mock_service.NewMockFile(ctrl)
+		partialManager = mock_filesystem.NewMockPartialManager(ctrl)
+		dirManager = mock_filesystem.NewMockDirectoryManager(ctrl)
+		identifier = mock_identifier.NewMockIdentifier(ctrl)
+		clock = mock_datetime.NewMockClock(ctrl)
+		log = mock_logging.NewMockLogger(ctrl)
+		validator = mock_validation.NewMockValidator(ctrl)
+		s = service.NewMultipart(service.MultipartParam{
+			MultipartRepo:  multipartRepo,
+			FileClient:     fileClient,
+			PartialManager: partialManager,
+			DirManager:     dirManager,
+			Logger:         log,
+			Identifier:     identifier,
+			Clock:          clock,
+			Validator:      validator,
+			Config: &service.MultipartConfig{
+				PartialDir: "temp/partial",
+				Ttl:        24 * time.Hour,
+			},
+		})
+	})
+
+	Context("InitiateMultipart function", Label("unit"), func() {
+		var (
+			p           service.InitiateMultipartParam
+			createParam repository.CreateMultipartParam
+		)
+
+		BeforeEach(func() {
+			p = service.InitiateMultipartParam{
+				Name:      "dolphin",
+				Mimetype:  "image/jpeg",
+				Extension: "jpg",
+			}
+			createParam = repository.CreateMultipartParam{
+				UniqueId:  "mock-multipart-id",
+				Name:      "dolphin",
+				Mimetype:  "image/jpeg",
+				Extension: "jpg",
+				CreatedAt: currentTs,
+			}
+
+			log.
+				EXPECT().
+				Debug("In function: InitiateMultipart").
+				Times(1)
+			log.
+				EXPECT().
+				Debug("Returning function: InitiateMultipart").
+				Times(1)
+		})
+
+		When("parameter is not valid", func() {
+			It("should return error", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(fmt.Errorf("invalid data")).
+					Times(1)
+
+				res, err := s.InitiateMultipart(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(&system.Error{
+					Code:    1002,
+					Message: "invalid data",
+				}))
+			})
+		})
+
+		When("failed generate id", func() {
+			It("should return error", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				identifier.
+					EXPECT().
+					GenerateId().
+					Return("", fmt.Errorf("generate error")).
+					Times(1)
+
+				res, err := s.InitiateMultipart(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(&system.Error{
+					Code:    1001,
+					Message: "generate error",
+				}))
+			})
+		})
+
+		When("failed create multipart", func() {
+			It("should return error", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				identifier.
+					EXPECT().
+					GenerateId().
+					Return("mock-multipart-id", nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				multipartRepo.
+					EXPECT().
+					CreateMultipart(gomock.Eq(ctx), gomock.Eq(createParam)).
+					Return(nil, fmt.Errorf("db error")).
+					Times(1)
+
+				res, err := s.InitiateMultipart(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(&system.Error{
+					Code:    1001,
+					Message: "db error",
+				}))
+			})
+		})
+
+		When("multipart is initiated by a client", func() {
+			It("should record the client", func() {
+				ctx := auth.WithIdentity(ctx, &auth.Identity{
+					Id:       "client-record-id",
+					ClientId: "client-id",
+				})
+				createParam.OwnerClientId = "client-record-id"
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				identifier.
+					EXPECT().
+					GenerateId().
+					Return("mock-multipart-id", nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				multipartRepo.
+					EXPECT().
+					CreateMultipart(gomock.Eq(ctx), gomock.Eq(createParam)).
+					Return(&repository.CreateMultipartResult{
+						UniqueId:  "mock-multipart-id",
+						CreatedAt: currentTs,
+					}, nil).
+					Times(1)
+
+				res, err := s.InitiateMultipart(ctx, p)
+
+				Expect(err).To(BeNil())
+				Expect(res.UniqueId).To(Equal("mock-multipart-id"))
+			})
+		})
+
+		When("success initiate multipart", func() {
+			It("should return result", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				identifier.
+					EXPECT().
+					GenerateId().
+					Return("mock-multipart-id", nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				multipartRepo.
+					EXPECT().
+					CreateMultipart(gomock.Eq(ctx), gomock.Eq(createParam)).
+					Return(&repository.CreateMultipartResult{
+						UniqueId:  "mock-multipart-id",
+						Name:      "dolphin",
+						Mimetype:  "image/jpeg",
+						Extension: "jpg",
+						CreatedAt: currentTs,
+					}, nil).
+					Times(1)
+
+				res, err := s.InitiateMultipart(ctx, p)
+
+				Expect(err).To(BeNil())
+				Expect(res).To(Equal(&service.InitiateMultipartResult{
+					Success: system.Success{
+						Code:    1000,
+						Message: "success initiate multipart",
+					},
+					UniqueId:  "mock-multipart-id",
+					CreatedAt: currentTs,
+				}))
+			})
+		})
+	})
+
+	Context("UploadPart function", Label("unit"), func() {
+		var (
+			p             service.UploadPartParam
+			retrieveParam repository.RetrieveMultipartParam
+			retrieveRes   *repository.RetrieveMultipartResult
+			partPath      string
+		)
+
+		BeforeEach(func() {
+			p = service.UploadPartParam{
+				MultipartId: "mock-multipart-id",
+				PartNumber:  2,
+				Reader:      strings.NewReader("content"),
+			}
+			retrieveParam = repository.RetrieveMultipartParam{
+				UniqueId: "mock-multipart-id",
+			}
+			retrieveRes = &repository.RetrieveMultipartResult{
+				UniqueId:  "mock-multipart-id",
+				CreatedAt: currentTs,
+				UpdatedAt: currentTs,
+			}
+			partPath = "temp/partial/mock-multipart-id.2.mock-part-id"
+
+			log.
+				EXPECT().
+				Debug("In function: UploadPart").
+				Times(1)
+			log.
+				EXPECT().
+				Debug("Returning function: UploadPart").
+				Times(1)
+		})
+
+		When("parameter is not valid", func() {
+			It("should return error", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(fmt.Errorf("invalid data")).
+					Times(1)
+
+				res, err := s.UploadPart(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(&system.Error{
+					Code:    1002,
+					Message: "invalid data",
+				}))
+			})
+		})
+
+		When("part is not specified", func() {
+			It("should return error", func() {
+				p.Reader = nil
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				res, err := s.UploadPart(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(&system.Error{
+					Code:    1002,
+					Message: "part is not specified",
+				}))
+			})
+		})
+
+		When("multipart is not available", func() {
+			It("should return error", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				multipartRepo.
+					EXPECT().
+					RetrieveMultipart(gomock.Eq(ctx), gomock.Eq(retrieveParam)).
+					Return(nil, repository.ErrNotFound).
+					Times(1)
+
+				res, err := s.UploadPart(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(&system.Error{
+					Code:    1004,
+					Message: "multipart is not found",
+				}))
+			})
+		})
+
+		When("multipart is initiated by other client", func() {
+			It("should return error", func() {
+				ctx := auth.WithIdentity(ctx, &auth.Identity{
+					Id:       "other-id",
+					ClientId: "other-client",
+				})
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				multipartRepo.
+					EXPECT().
+					RetrieveMultipart(gomock.Eq(ctx), gomock.Eq(retrieveParam)).
+					Return(&repository.RetrieveMultipartResult{
+						OwnerClientId: "owner-id",
+					}, nil).
+					Times(1)
+
+				res, err := s.UploadPart(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(&system.Error{
+					Code:    1003,
+					Message: "multipart is not accessible",
+				}))
+			})
+		})
+
+		When("multipart is already completed", func() {
+			It("should return error", func() {
+				retrieveRes.CompletedAt = &currentTs
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				multipartRepo.
+					EXPECT().
+					RetrieveMultipart(gomock.Eq(ctx), gomock.Eq(retrieveParam)).
+					Return(retrieveRes, nil).
+					Times(1)
+
+				res, err := s.UploadPart(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(&system.Error{
+					Code:    2003,
+					Message: "multipart is already completed",
+				}))
+			})
+		})
+
+		When("failed create partial directory", func() {
+			It("should return error", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				multipartRepo.
+					EXPECT().
+					RetrieveMultipart(gomock.Eq(ctx), gomock.Eq(retrieveParam)).
+					Return(retrieveRes, nil).
+					Times(1)
+
+				dirManager.
+					EXPECT().
+					IsDirectoryExists(gomock.Eq(ctx), gomock.Any()).
+					Return(false, nil).
+					Times(1)
+
+				dirManager.
+					EXPECT().
+					CreateDir(gomock.Eq(ctx), gomock.Eq(filesystem.CreateDirParam{
+						Path:       "temp/partial",
+						Permission: 0644,
+					})).
+					Return(nil, fmt.Errorf("disk error")).
+					Times(1)
+
+				res, err := s.UploadPart(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(&system.Error{
+					Code:    1001,
+					Message: "disk error",
+				}))
+			})
+		})
+
+		When("failed write part", func() {
+			It("should return error", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				multipartRepo.
+					EXPECT().
+					RetrieveMultipart(gomock.Eq(ctx), gomock.Eq(retrieveParam)).
+					Return(retrieveRes, nil).
+					Times(1)
+
+				dirManager.
+					EXPECT().
+					IsDirectoryExists(gomock.Eq(ctx), gomock.Any()).
+					Return(true, nil).
+					Times(1)
+
+				identifier.
+					EXPECT().
+					GenerateId().
+					Return("mock-part-id", nil).
+					Times(1)
+
+				partialManager.
+					EXPECT().
+					CreatePartial(gomock.Eq(ctx), gomock.Eq(filesystem.CreatePartialParam{
+						Path:       partPath,
+						Permission: 0644,
+					})).
+					Return(&filesystem.CreatePartialResult{}, nil).
+					Times(1)
+
+				partialManager.
+					EXPECT().
+					AppendPartial(gomock.Eq(ctx), gomock.Any()).
+					Return(nil, fmt.Errorf("disk error")).
+					Times(1)
+
+				partialManager.
+					EXPECT().
+					RemovePartial(gomock.Eq(ctx), gomock.Eq(filesystem.RemovePartialParam{
+						Path: partPath,
+					})).
+					Return(&filesystem.RemovePartialResult{}, nil).
+					Times(1)
+
+				res, err := s.UploadPart(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(&system.Error{
+					Code:    1001,
+					Message: "disk error",
+				}))
+			})
+		})
+
+		When("multipart is completed while uploading part", func() {
+			It("should return error", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				multipartRepo.
+					EXPECT().
+					RetrieveMultipart(gomock.Eq(ctx), gomock.Eq(retrieveParam)).
+					Return(retrieveRes, nil).
+					Times(1)
+
+				dirManager.
+					EXPECT().
+					IsDirectoryExists(gomock.Eq(ctx), gomock.Any()).
+					Return(true, nil).
+					Times(1)
+
+				identifier.
+					EXPECT().
+					GenerateId().
+					Return("mock-part-id", nil).
+					Times(1)
+
+				partialManager.
+					EXPECT().
+					CreatePartial(gomock.Eq(ctx), gomock.Any()).
+					Return(&filesystem.CreatePartialResult{}, nil).
+					Times(1)
+
+				partialManager.
+					EXPECT().
+					AppendPartial(gomock.Eq(ctx), gomock.Any()).
+					Return(&filesystem.AppendPartialResult{Size: 7}, nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				multipartRepo.
+					EXPECT().
+					UploadPart(gomock.Eq(ctx), gomock.Any()).
+					Return(nil, repository.ErrConflict).
+					Times(1)
+
+				partialManager.
+					EXPECT().
+					RemovePartial(gomock.Eq(ctx), gomock.Eq(filesystem.RemovePartialParam{
+						Path: partPath,
+					})).
+					Return(nil, fmt.Errorf("disk error")).
+					Times(1)
+
+				log.
+					EXPECT().
+					Warnf(gomock.Eq("Failed removing part file, err: %s"), gomock.Eq("disk error")).
+					Times(1)
+
+				res, err := s.UploadPart(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(&system.Error{
+					Code:    2003,
+					Message: "multipart is already completed",
+				}))
+			})
+		})
+
+		When("part is re-uploaded", func() {
+			It("should replace the previous part", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				multipartRepo.
+					EXPECT().
+					RetrieveMultipart(gomock.Eq(ctx), gomock.Eq(retrieveParam)).
+					Return(retrieveRes, nil).
+					Times(1)
+
+				dirManager.
+					EXPECT().
+					IsDirectoryExists(gomock.Eq(ctx), gomock.Any()).
+					Return(true, nil).
+					Times(1)
+
+				identifier.
+					EXPECT().
+					GenerateId().
+					Return("mock-part-id", nil).
+					Times(1)
+
+				partialManager.
+					EXPECT().
+					CreatePartial(gomock.Eq(ctx), gomock.Any()).
+					Return(&filesystem.CreatePartialResult{}, nil).
+					Times(1)
+
+				partialManager.
+					EXPECT().
+					AppendPartial(gomock.Eq(ctx), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, p filesystem.AppendPartialParam) (*filesystem.AppendPartialResult, error) {
+						data, err := io.ReadAll(p.Reader)
+						if err != nil {
+							return nil, err
+						}
+						return &filesystem.AppendPartialResult{
+							Size: int64(len(data)),
+						}, nil
+					}).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				multipartRepo.
+					EXPECT().
+					UploadPart(gomock.Eq(ctx), gomock.Eq(repository.UploadPartParam{
+						MultipartId:    "mock-multipart-id",
+						PartNumber:     2,
+						Path:           partPath,
+						Size:           7,
+						ChecksumSha256: "ed7002b439e9ac845f22357d822bac1444730fbdb6016d3ec9432297b9ec9f73",
+						UploadedAt:     currentTs,
+					})).
+					Return(&repository.UploadPartResult{
+						MultipartId:    "mock-multipart-id",
+						PartNumber:     2,
+						Path:           partPath,
+						Size:           7,
+						ChecksumSha256: "ed7002b439e9ac845f22357d822bac1444730fbdb6016d3ec9432297b9ec9f73",
+						UploadedAt:     currentTs,
+						ReplacedPath:   "temp/partial/mock-multipart-id.2.old-part-id",
+					}, nil).
+					Times(1)
+
+				partialManager.
+					EXPECT().
+					RemovePartial(gomock.Eq(ctx), gomock.Eq(filesystem.RemovePartialParam{
+						Path: "temp/partial/mock-multipart-id.2.old-part-id",
+					})).
+					Return(&filesystem.RemovePartialResult{}, nil).
+					Times(1)
+
+				res, err := s.UploadPart(ctx, p)
+
+				Expect(err).To(BeNil())
+				Expect(res).To(Equal(&service.UploadPartResult{
+					Success: system.Success{
+						Code:    1000,
+						Message: "success upload part",
+					},
+					PartNumber:     2,
+					Size:           7,
+					ChecksumSha256: "ed7002b439e9ac845f22357d822bac1444730fbdb6016d3ec9432297b9ec9f73",
+					UploadedAt:     currentTs,
+				}))
+			})
+		})
+	})
+
+	Context("ListPart function", Label("unit"), func() {
+		var (
+			p service.ListPartParam
+		)
+
+		BeforeEach(func() {
+			p = service.ListPartParam{
+				MultipartId: "mock-multipart-id",
+			}
+
+			log.
+				EXPECT().
+				Debug("In function: ListPart").
+				Times(1)
+			log.
+				EXPECT().
+				Debug("Returning function: ListPart").
+				Times(1)
+		})
+
+		When("multipart is not available", func() {
+			It("should return error", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				multipartRepo.
+					EXPECT().
+					RetrieveMultipart(gomock.Eq(ctx), gomock.Any()).
+					Return(nil, repository.ErrNotFound).
+					Times(1)
+
+				res, err := s.ListPart(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(&system.Error{
+					Code:    1004,
+					Message: "multipart is not found",
+				}))
+			})
+		})
+
+		When("multipart is initiated by other client", func() {
+			It("should return error", func() {
+				ctx := auth.WithIdentity(ctx, &auth.Identity{
+					Id:       "other-id",
+					ClientId: "other-client",
+				})
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				multipartRepo.
+					EXPECT().
+					RetrieveMultipart(gomock.Eq(ctx), gomock.Any()).
+					Return(&repository.RetrieveMultipartResult{
+						OwnerClientId: "owner-id",
+					}, nil).
+					Times(1)
+
+				res, err := s.ListPart(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(&system.Error{
+					Code:    1003,
+					Message: "multipart is not accessible",
+				}))
+			})
+		})
+
+		When("failed list part", func() {
+			It("should return error", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				multipartRepo.
+					EXPECT().
+					RetrieveMultipart(gomock.Eq(ctx), gomock.Any()).
+					Return(&repository.RetrieveMultipartResult{}, nil).
+					Times(1)
+
+				multipartRepo.
+					EXPECT().
+					ListPart(gomock.Eq(ctx), gomock.Eq(repository.ListPartParam{
+						MultipartId: "mock-multipart-id",
+					})).
+					Return(nil, fmt.Errorf("db error")).
+					Times(1)
+
+				res, err := s.ListPart(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(&system.Error{
+					Code:    1001,
+					Message: "db error",
+				}))
+			})
+		})
+
+		When("success list part", func() {
+			It("should return result", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				multipartRepo.
+					EXPECT().
+					RetrieveMultipart(gomock.Eq(ctx), gomock.Any()).
+					Return(&repository.RetrieveMultipartResult{}, nil).
+					Times(1)
+
+				multipartRepo.
+					EXPECT().
+					ListPart(gomock.Eq(ctx), gomock.Any()).
+					Return(&repository.ListPartResult{
+						Items: []repository.PartItem{
+							{
+								PartNumber:     1,
+								Path:           "temp/partial/mock-multipart-id.1.a",
+								Size:           7,
+								ChecksumSha256: "sha256-1",
+								UploadedAt:     currentTs,
+							},
+						},
+					}, nil).
+					Times(1)
+
+				res, err := s.ListPart(ctx, p)
+
+				Expect(err).To(BeNil())
+				Expect(res).To(Equal(&service.ListPartResult{
+					Success: system.Success{
+						Code:    1000,
+						Message: "success list part",
+					},
+					Items: []service.ListPartItem{
+						{
+							PartNumber:     1,
+							Size:           7,
+							ChecksumSha256: "sha256-1",
+							UploadedAt:     currentTs,
+						},
+					},
+				}))
+			})
+		})
+	})
+
+	Context("CompleteMultipart function", Label("unit"), func() {
+		var (
+			p             service.CompleteMultipartParam
+			retrieveParam repository.RetrieveMultipartParam
+			listParam     repository.ListPartParam
+			parts         []repository.PartItem
+		)
+
+		BeforeEach(func() {
+			p = service.CompleteMultipartParam{
+				MultipartId: "mock-multipart-id",
+				Parts: []service.CompletePartParam{
+					{PartNumber: 1, ChecksumSha256: "sha256-1"},
+					{PartNumber: 3, ChecksumSha256: "sha256-3"},
+				},
+			}
+			retrieveParam = repository.RetrieveMultipartParam{
+				UniqueId: "mock-multipart-id",
+			}
+			listParam = repository.ListPartParam{
+				MultipartId: "mock-multipart-id",
+			}
+			parts = []repository.PartItem{
+				{PartNumber: 1, Path: "temp/partial/mock-multipart-id.1.a", Size: 4, ChecksumSha256: "sha256-1"},
+				{PartNumber: 2, Path: "temp/partial/mock-multipart-id.2.a", Size: 4, ChecksumSha256: "sha256-2"},
+				{PartNumber: 3, Path: "temp/partial/mock-multipart-id.3.a", Size: 3, ChecksumSha256: "sha256-3"},
+			}
+
+			log.
+				EXPECT().
+				Debug("In function: CompleteMultipart").
+				Times(1)
+			log.
+				EXPECT().
+				Debug("Returning function: CompleteMultipart").
+				Times(1)
+		})
+
+		When("parameter is not valid", func() {
+			It("should return error", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(fmt.Errorf("invalid data")).
+					Times(1)
+
+				res, err := s.CompleteMultipart(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(&system.Error{
+					Code:    1002,
+					Message: "invalid data",
+				}))
+			})
+		})
+
+		When("parts are not ordered", func() {
+			It("should return error", func() {
+				p.Parts[1].PartNumber = 1
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				res, err := s.CompleteMultipart(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(&system.Error{
+					Code:    1002,
+					Message: "parts must be ordered by part number",
+				}))
+			})
+		})
+
+		When("multipart is not available", func() {
+			It("should return error", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				multipartRepo.
+					EXPECT().
+					RetrieveMultipart(gomock.Eq(ctx), gomock.Eq(retrieveParam)).
+					Return(nil, repository.ErrNotFound).
+					Times(1)
+
+				res, err := s.CompleteMultipart(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(&system.Error{
+					Code:    1004,
+					Message: "multipart is not found",
+				}))
+			})
+		})
+
+		When("multipart is initiated by other client", func() {
+			It("should return error", func() {
+				ctx := auth.WithIdentity(ctx, &auth.Identity{
+					Id:       "other-id",
+					ClientId: "other-client",
+				})
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				multipartRepo.
+					EXPECT().
+					RetrieveMultipart(gomock.Eq(ctx), gomock.Eq(retrieveParam)).
+					Return(&repository.RetrieveMultipartResult{
+						OwnerClientId: "owner-id",
+					}, nil).
+					Times(1)
+
+				res, err := s.CompleteMultipart(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(&system.Error{
+					Code:    1003,
+					Message: "multipart is not accessible",
+				}))
+			})
+		})
+
+		When("multipart is already completed", func() {
+			It("should return error", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				multipartRepo.
+					EXPECT().
+					RetrieveMultipart(gomock.Eq(ctx), gomock.Eq(retrieveParam)).
+					Return(&repository.RetrieveMultipartResult{
+						CompletedAt: &currentTs,
+					}, nil).
+					Times(1)
+
+				res, err := s.CompleteMultipart(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(&system.Error{
+					Code:    2003,
+					Message: "multipart is already completed",
+				}))
+			})
+		})
+
+		When("failed list part", func() {
+			It("should return error", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				multipartRepo.
+					EXPECT().
+					RetrieveMultipart(gomock.Eq(ctx), gomock.Eq(retrieveParam)).
+					Return(&repository.RetrieveMultipartResult{}, nil).
+					Times(1)
+
+				multipartRepo.
+					EXPECT().
+					ListPart(gomock.Eq(ctx), gomock.Eq(listParam)).
+					Return(nil, fmt.Errorf("db error")).
+					Times(1)
+
+				res, err := s.CompleteMultipart(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(&system.Error{
+					Code:    1001,
+					Message: "db error",
+				}))
+			})
+		})
+
+		When("part is not matched", func() {
+			It("should return error", func() {
+				parts[2].ChecksumSha256 = "sha256-other"
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				multipartRepo.
+					EXPECT().
+					RetrieveMultipart(gomock.Eq(ctx), gomock.Eq(retrieveParam)).
+					Return(&repository.RetrieveMultipartResult{}, nil).
+					Times(1)
+
+				multipartRepo.
+					EXPECT().
+					ListPart(gomock.Eq(ctx), gomock.Eq(listParam)).
+					Return(&repository.ListPartResult{Items: parts}, nil).
+					Times(1)
+
+				res, err := s.CompleteMultipart(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(&system.Error{
+					Code:    2004,
+					Message: "multipart part is not matched",
+				}))
+			})
+		})
+
+		When("multipart size is exceeded", func() {
+			It("should return error", func() {
+				s = service.NewMultipart(service.MultipartParam{
+					MultipartRepo: multipartRepo,
+					FileClient:    fileClient,
+					Logger:        log,
+					Validator:     validator,
+					Config: &service.MultipartConfig{
+						PartialDir: "temp/partial",
+						MaxSize:    6,
+					},
+				})
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				multipartRepo.
+					EXPECT().
+					RetrieveMultipart(gomock.Eq(ctx), gomock.Eq(retrieveParam)).
+					Return(&repository.RetrieveMultipartResult{}, nil).
+					Times(1)
+
+				multipartRepo.
+					EXPECT().
+					ListPart(gomock.Eq(ctx), gomock.Eq(listParam)).
+					Return(&repository.ListPartResult{Items: parts}, nil).
+					Times(1)
+
+				res, err := s.CompleteMultipart(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(&system.Error{
+					Code:    2002,
+					Message: "multipart size is exceeded",
+				}))
+			})
+		})
+
+		When("failed upload file", func() {
+			It("should return error", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				multipartRepo.
+					EXPECT().
+					RetrieveMultipart(gomock.Eq(ctx), gomock.Eq(retrieveParam)).
+					Return(&repository.RetrieveMultipartResult{}, nil).
+					Times(1)
+
+				multipartRepo.
+					EXPECT().
+					ListPart(gomock.Eq(ctx), gomock.Eq(listParam)).
+					Return(&repository.ListPartResult{Items: parts}, nil).
+					Times(1)
+
+				fileClient.
+					EXPECT().
+					UploadFile(gomock.Eq(ctx), gomock.Any(), gomock.Any()).
+					Return(nil, &system.Error{
+						Code:    1001,
+						Message: "disk error",
+					}).
+					Times(1)
+
+				res, err := s.CompleteMultipart(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(&system.Error{
+					Code:    1001,
+					Message: "disk error",
+				}))
+			})
+		})
+
+		When("multipart is completed concurrently", func() {
+			It("should remove the joined file", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				multipartRepo.
+					EXPECT().
+					RetrieveMultipart(gomock.Eq(ctx), gomock.Eq(retrieveParam)).
+					Return(&repository.RetrieveMultipartResult{}, nil).
+					Times(1)
+
+				multipartRepo.
+					EXPECT().
+					ListPart(gomock.Eq(ctx), gomock.Eq(listParam)).
+					Return(&repository.ListPartResult{Items: parts}, nil).
+					Times(1)
+
+				fileClient.
+					EXPECT().
+					UploadFile(gomock.Eq(ctx), gomock.Any(), gomock.Any()).
+					Return(&service.UploadFileResult{
+						UniqueId: "mock-file-id",
+					}, nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				multipartRepo.
+					EXPECT().
+					CompleteMultipart(gomock.Eq(ctx), gomock.Any()).
+					Return(nil, repository.ErrConflict).
+					Times(1)
+
+				fileClient.
+					EXPECT().
+					DeleteFile(gomock.Eq(ctx), gomock.Eq(service.DeleteFileParam{
+						FileId: "mock-file-id",
+					})).
+					Return(nil, &system.Error{
+						Code:    1001,
+						Message: "db error",
+					}).
+					Times(1)
+
+				log.
+					EXPECT().
+					Warnf(gomock.Eq("Failed removing joined file, err: %s"), gomock.Eq("db error")).
+					Times(1)
+
+				res, err := s.CompleteMultipart(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(&system.Error{
+					Code:    2003,
+					Message: "multipart is already completed",
+				}))
+			})
+		})
+
+		When("part is replaced while it's joined", func() {
+			It("should remove the joined file", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				multipartRepo.
+					EXPECT().
+					RetrieveMultipart(gomock.Eq(ctx), gomock.Eq(retrieveParam)).
+					Return(&repository.RetrieveMultipartResult{}, nil).
+					Times(1)
+
+				multipartRepo.
+					EXPECT().
+					ListPart(gomock.Eq(ctx), gomock.Eq(listParam)).
+					Return(&repository.ListPartResult{Items: parts}, nil).
+					Times(1)
+
+				fileClient.
+					EXPECT().
+					UploadFile(gomock.Eq(ctx), gomock.Any(), gomock.Any()).
+					Return(&service.UploadFileResult{
+						UniqueId: "mock-file-id",
+					}, nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				multipartRepo.
+					EXPECT().
+					CompleteMultipart(gomock.Eq(ctx), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, cp repository.CompleteMultipartParam) (*repository.CompleteMultipartResult, error) {
+						replaced := []repository.PartItem{parts[0], parts[1], parts[2]}
+						replaced[2].Path = "temp/partial/mock-multipart-id.3.b"
+						_, err := cp.CompleteFn(ctx, repository.CompleteFnParam{
+							Parts: replaced,
+						})
+						return nil, err
+					}).
+					Times(1)
+
+				fileClient.
+					EXPECT().
+					DeleteFile(gomock.Eq(ctx), gomock.Eq(service.DeleteFileParam{
+						FileId: "mock-file-id",
+					})).
+					Return(&service.DeleteFileResult{}, nil).
+					Times(1)
+
+				res, err := s.CompleteMultipart(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(&system.Error{
+					Code:    2004,
+					Message: "multipart part is not matched",
+				}))
+			})
+		})
+
+		When("success complete multipart", func() {
+			It("should return result", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				multipartRepo.
+					EXPECT().
+					RetrieveMultipart(gomock.Eq(ctx), gomock.Eq(retrieveParam)).
+					Return(&repository.RetrieveMultipartResult{
+						Name:      "dolphin",
+						Mimetype:  "image/jpeg",
+						Extension: "jpg",
+					}, nil).
+					Times(1)
+
+				multipartRepo.
+					EXPECT().
+					ListPart(gomock.Eq(ctx), gomock.Eq(listParam)).
+					Return(&repository.ListPartResult{Items: parts}, nil).
+					Times(1)
+
+				fileClient.
+					EXPECT().
+					UploadFile(gomock.Eq(ctx), gomock.Any(), gomock.Any()).
+					Return(&service.UploadFileResult{
+						UniqueId:   "mock-file-id",
+						Name:       "dolphin",
+						Path:       "temp/mock-file-id.jpg",
+						Mimetype:   "image/jpeg",
+						Extension:  "jpg",
+						Size:       7,
+						UploadedAt: currentTs,
+						Checksum: file.Checksum{
+							Sha256: "mock-checksum",
+						},
+					}, nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				multipartRepo.
+					EXPECT().
+					CompleteMultipart(gomock.Eq(ctx), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, cp repository.CompleteMultipartParam) (*repository.CompleteMultipartResult, error) {
+						fn, err := cp.CompleteFn(ctx, repository.CompleteFnParam{
+							Name:      "dolphin",
+							Mimetype:  "image/jpeg",
+							Extension: "jpg",
+							Parts:     parts,
+						})
+						if err != nil {
+							return nil, err
+						}
+						return &repository.CompleteMultipartResult{
+							UniqueId:    "mock-multipart-id",
+							FileId:      fn.FileId,
+							Parts:       parts,
+							CompletedAt: cp.CompletedAt,
+						}, nil
+					}).
+					Times(1)
+
+				partialManager.
+					EXPECT().
+					RemovePartial(gomock.Eq(ctx), gomock.Any()).
+					Return(&filesystem.RemovePartialResult{}, nil).
+					Times(3)
+
+				res, err := s.CompleteMultipart(ctx, p)
+
+				Expect(err).To(BeNil())
+				Expect(res).To(Equal(&service.CompleteMultipartResult{
+					Success: system.Success{
+						Code:    1000,
+						Message: "success complete multipart",
+					},
+					UniqueId:   "mock-file-id",
+					Name:       "dolphin",
+					Path:       "temp/mock-file-id.jpg",
+					Mimetype:   "image/jpeg",
+					Extension:  "jpg",
+					Size:       7,
+					UploadedAt: currentTs,
+					Checksum: file.Checksum{
+						Sha256: "mock-checksum",
+					},
+					CompletedAt: currentTs,
+				}))
+			})
+		})
+	})
+
+	Context("AbortMultipart function", Label("unit"), func() {
+		var (
+			p service.AbortMultipartParam
+		)
+
+		BeforeEach(func() {
+			p = service.AbortMultipartParam{
+				MultipartId: "mock-multipart-id",
+			}
+
+			log.
+				EXPECT().
+				Debug("In function: AbortMultipart").
+				Times(1)
+			log.
+				EXPECT().
+				Debug("Returning function: AbortMultipart").
+				Times(1)
+		})
+
+		When("multipart is initiated by other client", func() {
+			It("should return error", func() {
+				ctx := auth.WithIdentity(ctx, &auth.Identity{
+					Id:       "other-id",
+					ClientId: "other-client",
+				})
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				multipartRepo.
+					EXPECT().
+					RetrieveMultipart(gomock.Eq(ctx), gomock.Any()).
+					Return(&repository.RetrieveMultipartResult{
+						OwnerClientId: "owner-id",
+					}, nil).
+					Times(1)
+
+				res, err := s.AbortMultipart(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(&system.Error{
+					Code:    1003,
+					Message: "multipart is not accessible",
+				}))
+			})
+		})
+
+		When("multipart is already completed", func() {
+			It("should return error", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				multipartRepo.
+					EXPECT().
+					RetrieveMultipart(gomock.Eq(ctx), gomock.Any()).
+					Return(&repository.RetrieveMultipartResult{
+						CompletedAt: &currentTs,
+					}, nil).
+					Times(1)
+
+				res, err := s.AbortMultipart(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(&system.Error{
+					Code:    2003,
+					Message: "multipart is already completed",
+				}))
+			})
+		})
+
+		When("failed delete multipart", func() {
+			It("should return error", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				multipartRepo.
+					EXPECT().
+					RetrieveMultipart(gomock.Eq(ctx), gomock.Any()).
+					Return(&repository.RetrieveMultipartResult{}, nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				multipartRepo.
+					EXPECT().
+					DeleteMultipart(gomock.Eq(ctx), gomock.Any()).
+					Return(nil, fmt.Errorf("db error")).
+					Times(1)
+
+				res, err := s.AbortMultipart(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(&system.Error{
+					Code:    1001,
+					Message: "db error",
+				}))
+			})
+		})
+
+		When("success abort multipart", func() {
+			It("should return result", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				multipartRepo.
+					EXPECT().
+					RetrieveMultipart(gomock.Eq(ctx), gomock.Any()).
+					Return(&repository.RetrieveMultipartResult{}, nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				multipartRepo.
+					EXPECT().
+					DeleteMultipart(gomock.Eq(ctx), gomock.Eq(repository.DeleteMultipartParam{
+						UniqueId:  "mock-multipart-id",
+						DeletedAt: currentTs,
+					})).
+					Return(&repository.DeleteMultipartResult{
+						Parts: []repository.PartItem{
+							{PartNumber: 1, Path: "temp/partial/mock-multipart-id.1.a"},
+						},
+						DeletedAt: currentTs,
+					}, nil).
+					Times(1)
+
+				partialManager.
+					EXPECT().
+					RemovePartial(gomock.Eq(ctx), gomock.Eq(filesystem.RemovePartialParam{
+						Path: "temp/partial/mock-multipart-id.1.a",
+					})).
+					Return(nil, filesystem.ErrorFileNotFound).
+					Times(1)
+
+				res, err := s.AbortMultipart(ctx, p)
+
+				Expect(err).To(BeNil())
+				Expect(res).To(Equal(&service.AbortMultipartResult{
+					Success: system.Success{
+						Code:    1000,
+						Message: "success abort multipart",
+					},
+					AbortedAt: currentTs,
+				}))
+			})
+		})
+	})
+
+	Context("CleanupMultipart function", Label("unit"), func() {
+		var (
+			p service.CleanupMultipartParam
+		)
+
+		BeforeEach(func() {
+			p = service.CleanupMultipartParam{
+				Limit: 100,
+			}
+
+			log.
+				EXPECT().
+				Debug("In function: CleanupMultipart").
+				Times(1)
+			log.
+				EXPECT().
+				Debug("Returning function: CleanupMultipart").
+				Times(1)
+		})
+
+		When("failed search multipart", func() {
+			It("should return error", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				multipartRepo.
+					EXPECT().
+					SearchMultipart(gomock.Eq(ctx), gomock.Eq(repository.SearchMultipartParam{
+						UpdatedBefore: currentTs.Add(-24 * time.Hour),
+						Limit:         100,
+					})).
+					Return(nil, fmt.Errorf("db error")).
+					Times(1)
+
+				res, err := s.CleanupMultipart(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(&system.Error{
+					Code:    1001,
+					Message: "db error",
+				}))
+			})
+		})
+
+		When("some multipart are failed to be removed", func() {
+			It("should return total removed", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				multipartRepo.
+					EXPECT().
+					SearchMultipart(gomock.Eq(ctx), gomock.Any()).
+					Return(&repository.SearchMultipartResult{
+						Items: []repository.SearchMultipartItem{
+							{UniqueId: "mock-multipart-id-1"},
+							{UniqueId: "mock-multipart-id-2"},
+						},
+					}, nil).
+					Times(1)
+
+				multipartRepo.
+					EXPECT().
+					DeleteMultipart(gomock.Eq(ctx), gomock.Eq(repository.DeleteMultipartParam{
+						UniqueId:  "mock-multipart-id-1",
+						DeletedAt: currentTs,
+					})).
+					Return(nil, fmt.Errorf("db error")).
+					Times(1)
+
+				log.
+					EXPECT().
+					Warnf(gomock.Eq("Failed removing expired multipart %s, err: %s"), gomock.Eq("mock-multipart-id-1"), gomock.Eq("db error")).
+					Times(1)
+
+				multipartRepo.
+					EXPECT().
+					DeleteMultipart(gomock.Eq(ctx), gomock.Eq(repository.DeleteMultipartParam{
+						UniqueId:  "mock-multipart-id-2",
+						DeletedAt: currentTs,
+					})).
+					Return(&repository.DeleteMultipartResult{
+						Parts: []repository.PartItem{
+							{PartNumber: 1, Path: "temp/partial/mock-multipart-id-2.1.a"},
+						},
+						DeletedAt: currentTs,
+					}, nil).
+					Times(1)
+
+				partialManager.
+					EXPECT().
+					RemovePartial(gomock.Eq(ctx), gomock.Any()).
+					Return(&filesystem.RemovePartialResult{}, nil).
+					Times(1)
+
+				res, err := s.CleanupMultipart(ctx, p)
+
+				Expect(err).To(BeNil())
+				Expect(res).To(Equal(&service.CleanupMultipartResult{
+					Success: system.Success{
+						Code:    1000,
+						Message: "success cleanup multipart",
+					},
+					Total: 1,
+				}))
+			})
+		})
+	})
+})
@@ -0,0 +1,17 @@
+package service
+
+// @note: business code (reserved from: 2000-2999)
+const (
+	UPLOAD_OFFSET_CONFLICT  int32 = 2001
+	UPLOAD_SIZE_EXCEEDED    int32 = 2002
+	MULTIPART_COMPLETED     int32 = 2003
+	MULTIPART_PART_MISMATCH int32 = 2004
+	FILE_RANGE_INVALID      int32 = 2005
+	FILE_NOT_DELETED        int32 = 2006
+	FILE_NOT_ALLOWED        int32 = 2007
+	FILE_INFECTED           int32 = 2008
+	FILE_NOT_SCANNED        int32 = 2009
+	QUOTA_EXCEEDED          int32 = 2010
+	QUOTA_SIZE_EXCEEDED     int32 = 2011
+	FILE_NOT_SCANNABLE      int32 = 2012
+)
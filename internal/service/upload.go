@@ -0,0 +1,574 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/go-seidon/hippo/internal/auth"
+	"github.com/go-seidon/hippo/internal/file"
+	"github.com/go-seidon/hippo/internal/filesystem"
+	"github.com/go-seidon/hippo/internal/repository"
+	"github.com/go-seidon/provider/datetime"
+	"github.com/go-seidon/provider/identity"
+	"github.com/go-seidon/provider/logging"
+	"github.com/go-seidon/provider/status"
+	"github.com/go-seidon/provider/system"
+	"github.com/go-seidon/provider/validation"
+)
+
+// @note: upload is a resumable upload session, the content is appended chunk by chunk
+// into a partial file and it's finished as a regular file once the last chunk is received
+type Upload interface {
+	CreateUpload(ctx context.Context, p CreateUploadParam) (*CreateUploadResult, *system.Error)
+	RetrieveUpload(ctx context.Context, p RetrieveUploadParam) (*RetrieveUploadResult, *system.Error)
+	AppendUpload(ctx context.Context, p AppendUploadParam) (*AppendUploadResult, *system.Error)
+	DeleteUpload(ctx context.Context, p DeleteUploadParam) (*DeleteUploadResult, *system.Error)
+}
+
+type CreateUploadParam struct {
+	FileName string `validate:"max=4096" label:"file_name"`
+	Size     int64  `validate:"min=0" label:"size"`
+}
+
+type CreateUploadResult struct {
+	Success   system.Success
+	UniqueId  string
+	Size      int64
+	Offset    int64
+	CreatedAt time.Time
+}
+
+type RetrieveUploadParam struct {
+	UploadId string `validate:"required,min=5,max=64" label:"upload_id"`
+}
+
+type RetrieveUploadResult struct {
+	Success     system.Success
+	UniqueId    string
+	Name        string
+	Extension   string
+	Size        int64
+	Offset      int64
+	FileId      string
+	CreatedAt   time.Time
+	CompletedAt *time.Time
+}
+
+type AppendUploadParam struct {
+	UploadId string `validate:"required,min=5,max=64" label:"upload_id"`
+	Offset   int64  `validate:"min=0" label:"offset"`
+	Reader   io.Reader
+}
+
+type AppendUploadResult struct {
+	Success     system.Success
+	UniqueId    string
+	Size        int64
+	Offset      int64
+	FileId      string
+	CompletedAt *time.Time
+}
+
+type DeleteUploadParam struct {
+	UploadId string `validate:"required,min=5,max=64" label:"upload_id"`
+}
+
+type DeleteUploadResult struct {
+	Success   system.Success
+	DeletedAt time.Time
+}
+
+var _ Upload = (*uploadService)(nil)
+
+type uploadService struct {
+	uploadRepo     repository.Upload
+	fileClient     File
+	partialManager filesystem.PartialManager
+	dirManager     filesystem.DirectoryManager
+	identifier     identity.Identifier
+	clock          datetime.Clock
+	log            logging.Logger
+	validator      validation.Validator
+	config         *UploadConfig
+}
+
+func (s *uploadService) CreateUpload(ctx context.Context, p CreateUploadParam) (*CreateUploadResult, *system.Error) {
+	s.log.Debug("In function: CreateUpload")
+	defer s.log.Debug("Returning function: CreateUpload")
+
+	err := s.validator.Validate(p)
+	if err != nil {
+		return nil, &system.Error{
+			Code:    status.INVALID_PARAM,
+			Message: err.Error(),
+		}
+	}
+
+	if s.config.MaxSize > 0 && p.Size > s.config.MaxSize {
+		return nil, &system.Error{
+			Code:    UPLOAD_SIZE_EXCEEDED,
+			Message: "upload size is exceeded",
+		}
+	}
+
+	exists, err := s.dirManager.IsDirectoryExists(ctx, filesystem.IsDirectoryExistsParam{
+		Path: s.config.PartialDir,
+	})
+	if err != nil {
+		return nil, &system.Error{
+			Code:    status.ACTION_FAILED,
+			Message: err.Error(),
+		}
+	}
+
+	if !exists {
+		_, err := s.dirManager.CreateDir(ctx, filesystem.CreateDirParam{
+			Path:       s.config.PartialDir,
+			Permission: 0644,
+		})
+		if err != nil {
+			return nil, &system.Error{
+				Code:    status.ACTION_FAILED,
+				Message: err.Error(),
+			}
+		}
+	}
+
+	uniqueId, err := s.identifier.GenerateId()
+	if err != nil {
+		return nil, &system.Error{
+			Code:    status.ACTION_FAILED,
+			Message: err.Error(),
+		}
+	}
+
+	path := fmt.Sprintf("%s/%s", s.config.PartialDir, uniqueId)
+	_, err = s.partialManager.CreatePartial(ctx, filesystem.CreatePartialParam{
+		Path:       path,
+		Permission: 0644,
+	})
+	if err != nil {
+		return nil, &system.Error{
+			Code:    status.ACTION_FAILED,
+			Message: err.Error(),
+		}
+	}
+
+	// @note: name is kept as it's sent, only the last segment is used as the extension
+	name := p.FileName
+	extension := ""
+	if idx := strings.LastIndex(p.FileName, "."); idx >= 0 {
+		name = p.FileName[:idx]
+		extension = p.FileName[idx+1:]
+	}
+
+	ownerClientId := ""
+	identity := auth.IdentityFromContext(ctx)
+	if identity != nil {
+		ownerClientId = identity.Id
+	}
+
+	currentTs := s.clock.Now()
+	cRes, err := s.uploadRepo.CreateUpload(ctx, repository.CreateUploadParam{
+		UniqueId:      uniqueId,
+		Name:          name,
+		Extension:     strings.ToLower(extension),
+		Path:          path,
+		Size:          p.Size,
+		CreatedAt:     currentTs,
+		OwnerClientId: ownerClientId,
+	})
+	if err != nil {
+		s.partialManager.RemovePartial(ctx, filesystem.RemovePartialParam{
+			Path: path,
+		})
+		return nil, &system.Error{
+			Code:    status.ACTION_FAILED,
+			Message: err.Error(),
+		}
+	}
+
+	res := &CreateUploadResult{
+		Success: system.Success{
+			Code:    status.ACTION_SUCCESS,
+			Message: "success create upload",
+		},
+		UniqueId:  cRes.UniqueId,
+		Size:      cRes.Size,
+		Offset:    cRes.Offset,
+		CreatedAt: cRes.CreatedAt,
+	}
+	return res, nil
+}
+
+// @note: upload is not finished when it's retrieved, upload which has received all of the chunks
+// but failed to be finished is finished by appending an empty chunk at the last offset
+func (s *uploadService) RetrieveUpload(ctx context.Context, p RetrieveUploadParam) (*RetrieveUploadResult, *system.Error) {
+	s.log.Debug("In function: RetrieveUpload")
+	defer s.log.Debug("Returning function: RetrieveUpload")
+
+	err := s.validator.Validate(p)
+	if err != nil {
+		return nil, &system.Error{
+			Code:    status.INVALID_PARAM,
+			Message: err.Error(),
+		}
+	}
+
+	upload, serr := s.retrieveUpload(ctx, p.UploadId)
+	if serr != nil {
+		return nil, serr
+	}
+
+	res := &RetrieveUploadResult{
+		Success: system.Success{
+			Code:    status.ACTION_SUCCESS,
+			Message: "success retrieve upload",
+		},
+		UniqueId:    upload.UniqueId,
+		Name:        upload.Name,
+		Extension:   upload.Extension,
+		Size:        upload.Size,
+		Offset:      upload.Offset,
+		FileId:      upload.FileId,
+		CreatedAt:   upload.CreatedAt,
+		CompletedAt: upload.CompletedAt,
+	}
+	return res, nil
+}
+
+func (s *uploadService) AppendUpload(ctx context.Context, p AppendUploadParam) (*AppendUploadResult, *system.Error) {
+	s.log.Debug("In function: AppendUpload")
+	defer s.log.Debug("Returning function: AppendUpload")
+
+	err := s.validator.Validate(p)
+	if err != nil {
+		return nil, &system.Error{
+			Code:    status.INVALID_PARAM,
+			Message: err.Error(),
+		}
+	}
+
+	if p.Reader == nil {
+		return nil, &system.Error{
+			Code:    status.INVALID_PARAM,
+			Message: "chunk is not specified",
+		}
+	}
+
+	_, serr := s.retrieveUpload(ctx, p.UploadId)
+	if serr != nil {
+		return nil, serr
+	}
+
+	currentTs := s.clock.Now()
+	appendRes, err := s.uploadRepo.AppendUpload(ctx, repository.AppendUploadParam{
+		UniqueId:  p.UploadId,
+		Offset:    p.Offset,
+		UpdatedAt: currentTs,
+		AppendFn:  NewAppendFn(p.Reader, s.partialManager),
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, &system.Error{
+				Code:    status.RESOURCE_NOTFOUND,
+				Message: "upload is not found",
+			}
+		} else if errors.Is(err, repository.ErrConflict) || errors.Is(err, filesystem.ErrorInvalidOffset) {
+			return nil, &system.Error{
+				Code:    UPLOAD_OFFSET_CONFLICT,
+				Message: "upload offset is not matched",
+			}
+		} else if errors.Is(err, file.ErrExceeded) {
+			return nil, &system.Error{
+				Code:    UPLOAD_SIZE_EXCEEDED,
+				Message: "upload size is exceeded",
+			}
+		}
+		return nil, &system.Error{
+			Code:    status.ACTION_FAILED,
+			Message: err.Error(),
+		}
+	}
+
+	res := &AppendUploadResult{
+		Success: system.Success{
+			Code:    status.ACTION_SUCCESS,
+			Message: "success append upload",
+		},
+		UniqueId: appendRes.UniqueId,
+		Size:     appendRes.Size,
+		Offset:   appendRes.Offset,
+	}
+	if appendRes.Offset < appendRes.Size {
+		return res, nil
+	}
+
+	upload, err := s.uploadRepo.RetrieveUpload(ctx, repository.RetrieveUploadParam{
+		UniqueId: p.UploadId,
+	})
+	if err != nil {
+		return nil, &system.Error{
+			Code:    status.ACTION_FAILED,
+			Message: err.Error(),
+		}
+	}
+
+	complete, cerr := s.completeUpload(ctx, upload)
+	if cerr != nil {
+		return nil, cerr
+	}
+	res.FileId = complete.FileId
+	res.CompletedAt = &complete.CompletedAt
+	return res, nil
+}
+
+func (s *uploadService) DeleteUpload(ctx context.Context, p DeleteUploadParam) (*DeleteUploadResult, *system.Error) {
+	s.log.Debug("In function: DeleteUpload")
+	defer s.log.Debug("Returning function: DeleteUpload")
+
+	err := s.validator.Validate(p)
+	if err != nil {
+		return nil, &system.Error{
+			Code:    status.INVALID_PARAM,
+			Message: err.Error(),
+		}
+	}
+
+	_, serr := s.retrieveUpload(ctx, p.UploadId)
+	if serr != nil {
+		return nil, serr
+	}
+
+	currentTs := s.clock.Now()
+	deletion, err := s.uploadRepo.DeleteUpload(ctx, repository.DeleteUploadParam{
+		UniqueId:  p.UploadId,
+		DeletedAt: currentTs,
+		DeleteFn:  NewDeletePartialFn(s.partialManager),
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, &system.Error{
+				Code:    status.RESOURCE_NOTFOUND,
+				Message: "upload is not found",
+			}
+		}
+		return nil, &system.Error{
+			Code:    status.ACTION_FAILED,
+			Message: err.Error(),
+		}
+	}
+
+	res := &DeleteUploadResult{
+		Success: system.Success{
+			Code:    status.ACTION_SUCCESS,
+			Message: "success delete upload",
+		},
+		DeletedAt: deletion.DeletedAt,
+	}
+	return res, nil
+}
+
+// @note: upload is only accessible by the client which created it (or admin),
+// upload created before the creator is recorded is accessible by any client
+func (s *uploadService) retrieveUpload(ctx context.Context, uploadId string) (*repository.RetrieveUploadResult, *system.Error) {
+	upload, err := s.uploadRepo.RetrieveUpload(ctx, repository.RetrieveUploadParam{
+		UniqueId: uploadId,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, &system.Error{
+				Code:    status.RESOURCE_NOTFOUND,
+				Message: "upload is not found",
+			}
+		}
+		return nil, &system.Error{
+			Code:    status.ACTION_FAILED,
+			Message: err.Error(),
+		}
+	}
+
+	if !canAccess(ctx, upload.OwnerClientId, true) {
+		return nil, &system.Error{
+			Code:    status.ACTION_FORBIDDEN,
+			Message: "upload is not accessible",
+		}
+	}
+	return upload, nil
+}
+
+// @note: the partial file is uploaded through the file service
+// so the finished upload is stored the same way as a regular upload,
+// the uploaded file is removed when the upload is finished by the concurrent request
+func (s *uploadService) completeUpload(ctx context.Context, upload *repository.RetrieveUploadResult) (*repository.CompleteUploadResult, *system.Error) {
+	open, err := s.partialManager.OpenPartial(ctx, filesystem.OpenPartialParam{
+		Path: upload.Path,
+	})
+	if err != nil {
+		return nil, &system.Error{
+			Code:    status.ACTION_FAILED,
+			Message: err.Error(),
+		}
+	}
+	defer open.File.Close()
+
+	reader := bufio.NewReader(open.File)
+	buff, err := reader.Peek(512)
+	if err != nil && err != io.EOF {
+		return nil, &system.Error{
+			Code:    status.ACTION_FAILED,
+			Message: err.Error(),
+		}
+	}
+
+	uploadFile, uerr := s.fileClient.UploadFile(
+		ctx,
+		WithReader(reader),
+		WithFileInfo(
+			upload.Name,
+			file.DetectMimetype(buff),
+			upload.Extension,
+			upload.Size,
+		),
+	)
+	if uerr != nil {
+		return nil, uerr
+	}
+
+	complete, err := s.uploadRepo.CompleteUpload(ctx, repository.CompleteUploadParam{
+		UniqueId:    upload.UniqueId,
+		FileId:      uploadFile.UniqueId,
+		CompletedAt: s.clock.Now(),
+	})
+	if err != nil {
+		_, derr := s.fileClient.DeleteFile(ctx, DeleteFileParam{
+			FileId: uploadFile.UniqueId,
+		})
+		if derr != nil {
+			s.log.Warnf("Failed removing uploaded file, err: %s", derr.Message)
+		}
+
+		if errors.Is(err, repository.ErrNotFound) {
+			current, rerr := s.uploadRepo.RetrieveUpload(ctx, repository.RetrieveUploadParam{
+				UniqueId: upload.UniqueId,
+			})
+			if rerr == nil && current.CompletedAt != nil {
+				complete := &repository.CompleteUploadResult{
+					UniqueId:    current.UniqueId,
+					FileId:      current.FileId,
+					CompletedAt: *current.CompletedAt,
+				}
+				return complete, nil
+			}
+		}
+		return nil, &system.Error{
+			Code:    status.ACTION_FAILED,
+			Message: err.Error(),
+		}
+	}
+
+	_, err = s.partialManager.RemovePartial(ctx, filesystem.RemovePartialParam{
+		Path: upload.Path,
+	})
+	if err != nil {
+		s.log.Warnf("Failed removing partial file, err: %s", err.Error())
+	}
+	return complete, nil
+}
+
+// @note: chunk is rejected when it's exceeding the upload size
+func NewAppendFn(reader io.Reader, partialManager filesystem.PartialManager) repository.AppendFn {
+	return func(ctx context.Context, ap repository.AppendFnParam) (*repository.AppendFnResult, error) {
+		save, err := partialManager.AppendPartial(ctx, filesystem.AppendPartialParam{
+			Path:   ap.FilePath,
+			Offset: ap.Offset,
+			Reader: &sizeLimitReader{
+				reader:    reader,
+				remaining: ap.Size - ap.Offset,
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		res := &repository.AppendFnResult{
+			Size: save.Size,
+		}
+		return res, nil
+	}
+}
+
+// @note: partial file of the completed upload is already removed
+func NewDeletePartialFn(partialManager filesystem.PartialManager) repository.DeleteFn {
+	return func(ctx context.Context, r repository.DeleteFnParam) error {
+		_, err := partialManager.RemovePartial(ctx, filesystem.RemovePartialParam{
+			Path: r.FilePath,
+		})
+		if err != nil && !errors.Is(err, filesystem.ErrorFileNotFound) {
+			return err
+		}
+		return nil
+	}
+}
+
+type sizeLimitReader struct {
+	reader    io.Reader
+	remaining int64
+}
+
+func (r *sizeLimitReader) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	if r.remaining <= 0 {
+		n, err := r.reader.Read(p[:1])
+		if n > 0 {
+			return 0, file.ErrExceeded
+		}
+		return 0, err
+	}
+
+	if int64(len(p)) > r.remaining {
+		p = p[:r.remaining]
+	}
+	n, err := r.reader.Read(p)
+	r.remaining -= int64(n)
+	return n, err
+}
+
+type UploadConfig struct {
+	PartialDir string
+	MaxSize    int64
+}
+
+type UploadParam struct {
+	UploadRepo     repository.Upload
+	FileClient     File
+	PartialManager filesystem.PartialManager
+	DirManager     filesystem.DirectoryManager
+	Logger         logging.Logger
+	Identifier     identity.Identifier
+	Clock          datetime.Clock
+	Validator      validation.Validator
+	Config         *UploadConfig
+}
+
+func NewUpload(p UploadParam) *uploadService {
+	return &uploadService{
+		uploadRepo:     p.UploadRepo,
+		fileClient:     p.FileClient,
+		partialManager: p.PartialManager,
+		dirManager:     p.DirManager,
+		identifier:     p.Identifier,
+		clock:          p.Clock,
+		log:            p.Logger,
+		validator:      p.Validator,
+		config:         p.Config,
+	}
+}
@@ -0,0 +1,1324 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/go-seidon/hippo/internal/auth"
+	"github.com/go-seidon/hippo/internal/file"
+	"github.com/go-seidon/hippo/internal/filesystem"
+	mock_filesystem "github.com/go-seidon/hippo/internal/filesystem/mock"
+	"github.com/go-seidon/hippo/internal/repository"
+	mock_repository "github.com/go-seidon/hippo/internal/repository/mock"
+	"github.com/go-seidon/hippo/internal/service"
+	mock_service "github.com/go-seidon/hippo/internal/service/mock"
+	mock_datetime "github.com/go-seidon/provider/datetime/mock"
+	mock_identifier "github.com/go-seidon/provider/identity/mock"
+	mock_logging "github.com/go-seidon/provider/logging/mock"
+	"github.com/go-seidon/provider/system"
+	mock_validation "github.com/go-seidon/provider/validation/mock"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Upload Service", func() {
+	var (
+		ctx            context.Context
+		currentTs      time.Time
+		uploadRepo     *mock_repository.MockUpload
+		fileClient     *mock_service.MockFile
+		partialManager *mock_filesystem.MockPartialManager
+		dirManager     *mock_filesystem.MockDirectoryManager
+		identifier     *mock_identifier.MockIdentifier
+		clock          *mock_datetime.MockClock
+		log            *mock_logging.MockLogger
+		validator      *mock_validation.MockValidator
+		s              service.Upload
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		currentTs = time.Now().UTC()
+		t := GinkgoT()
+		ctrl := gomock.NewController(t)
+		uploadRepo = mock_repository.NewMockUpload(ctrl)
+		fileClient = mock_service.NewMockFile(ctrl)
+		partialManager = mock_filesystem.NewMockPartialManager(ctrl)
+		dirManager = mock_filesystem.NewMockDirectoryManager(ctrl)
+		identifier = mock_identifier.NewMockIdentifier(ctrl)
+		clock = mock_datetime.NewMockClock(ctrl)
+		log = mock_logging.NewMockLogger(ctrl)
+		validator = mock_validation.NewMockValidator(ctrl)
+		s = service.NewUpload(service.UploadParam{
+			UploadRepo:     uploadRepo,
+			FileClient:     fileClient,
+			PartialManager: partialManager,
+			DirManager:     dirManager,
+			Logger:         log,
+			Identifier:     identifier,
+			Clock:          clock,
+			Validator:      validator,
+			Config: &service.UploadConfig{
+				PartialDir: "temp/partial",
+				MaxSize:    4096,
+			},
+		})
+	})
+
+	Context("CreateUpload function", Label("unit"), func() {
+		var (
+			p            service.CreateUploadParam
+			partialParam filesystem.CreatePartialParam
+			createParam  repository.CreateUploadParam
+			createRes    *repository.CreateUploadResult
+		)
+
+		BeforeEach(func() {
+			p = service.CreateUploadParam{
+				FileName: "Blue Dolphin.v2.JPG",
+				Size:     2048,
+			}
+			partialParam = filesystem.CreatePartialParam{
+				Path:       "temp/partial/mock-upload-id",
+				Permission: 0644,
+			}
+			createParam = repository.CreateUploadParam{
+				UniqueId:  "mock-upload-id",
+				Name:      "Blue Dolphin.v2",
+				Extension: "jpg",
+				Path:      "temp/partial/mock-upload-id",
+				Size:      2048,
+				CreatedAt: currentTs,
+			}
+			createRes = &repository.CreateUploadResult{
+				UniqueId:  "mock-upload-id",
+				Name:      "Blue Dolphin.v2",
+				Extension: "jpg",
+				Path:      "temp/partial/mock-upload-id",
+				Size:      2048,
+				CreatedAt: currentTs,
+			}
+
+			log.
+				EXPECT().
+				Debug("In function: CreateUpload").
+				Times(1)
+			log.
+				EXPECT().
+				Debug("Returning function: CreateUpload").
+				Times(1)
+		})
+
+		When("parameter is not valid", func() {
+			It("should return error", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(fmt.Errorf("invalid data")).
+					Times(1)
+
+				res, err := s.CreateUpload(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(&system.Error{
+					Code:    1002,
+					Message: "invalid data",
+				}))
+			})
+		})
+
+		When("size is exceeding the limit", func() {
+			It("should return error", func() {
+				p.Size = 4097
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				res, err := s.CreateUpload(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(&system.Error{
+					Code:    service.UPLOAD_SIZE_EXCEEDED,
+					Message: "upload size is exceeded",
+				}))
+			})
+		})
+
+		When("failed create partial directory", func() {
+			It("should return error", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				dirManager.
+					EXPECT().
+					IsDirectoryExists(gomock.Eq(ctx), gomock.Eq(filesystem.IsDirectoryExistsParam{
+						Path: "temp/partial",
+					})).
+					Return(false, nil).
+					Times(1)
+
+				dirManager.
+					EXPECT().
+					CreateDir(gomock.Eq(ctx), gomock.Eq(filesystem.CreateDirParam{
+						Path:       "temp/partial",
+						Permission: 0644,
+					})).
+					Return(nil, fmt.Errorf("disk error")).
+					Times(1)
+
+				res, err := s.CreateUpload(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(&system.Error{
+					Code:    1001,
+					Message: "disk error",
+				}))
+			})
+		})
+
+		When("failed generate id", func() {
+			It("should return error", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				dirManager.
+					EXPECT().
+					IsDirectoryExists(gomock.Eq(ctx), gomock.Any()).
+					Return(true, nil).
+					Times(1)
+
+				identifier.
+					EXPECT().
+					GenerateId().
+					Return("", fmt.Errorf("generate error")).
+					Times(1)
+
+				res, err := s.CreateUpload(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(&system.Error{
+					Code:    1001,
+					Message: "generate error",
+				}))
+			})
+		})
+
+		When("failed create partial file", func() {
+			It("should return error", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				dirManager.
+					EXPECT().
+					IsDirectoryExists(gomock.Eq(ctx), gomock.Any()).
+					Return(true, nil).
+					Times(1)
+
+				identifier.
+					EXPECT().
+					GenerateId().
+					Return("mock-upload-id", nil).
+					Times(1)
+
+				partialManager.
+					EXPECT().
+					CreatePartial(gomock.Eq(ctx), gomock.Eq(partialParam)).
+					Return(nil, fmt.Errorf("disk error")).
+					Times(1)
+
+				res, err := s.CreateUpload(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(&system.Error{
+					Code:    1001,
+					Message: "disk error",
+				}))
+			})
+		})
+
+		When("failed create upload", func() {
+			It("should remove partial file", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				dirManager.
+					EXPECT().
+					IsDirectoryExists(gomock.Eq(ctx), gomock.Any()).
+					Return(true, nil).
+					Times(1)
+
+				identifier.
+					EXPECT().
+					GenerateId().
+					Return("mock-upload-id", nil).
+					Times(1)
+
+				partialManager.
+					EXPECT().
+					CreatePartial(gomock.Eq(ctx), gomock.Eq(partialParam)).
+					Return(&filesystem.CreatePartialResult{}, nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				uploadRepo.
+					EXPECT().
+					CreateUpload(gomock.Eq(ctx), gomock.Eq(createParam)).
+					Return(nil, fmt.Errorf("db error")).
+					Times(1)
+
+				partialManager.
+					EXPECT().
+					RemovePartial(gomock.Eq(ctx), gomock.Eq(filesystem.RemovePartialParam{
+						Path: partialParam.Path,
+					})).
+					Return(&filesystem.RemovePartialResult{}, nil).
+					Times(1)
+
+				res, err := s.CreateUpload(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(&system.Error{
+					Code:    1001,
+					Message: "db error",
+				}))
+			})
+		})
+
+		When("success create upload", func() {
+			It("should return result", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				dirManager.
+					EXPECT().
+					IsDirectoryExists(gomock.Eq(ctx), gomock.Any()).
+					Return(true, nil).
+					Times(1)
+
+				identifier.
+					EXPECT().
+					GenerateId().
+					Return("mock-upload-id", nil).
+					Times(1)
+
+				partialManager.
+					EXPECT().
+					CreatePartial(gomock.Eq(ctx), gomock.Eq(partialParam)).
+					Return(&filesystem.CreatePartialResult{}, nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				uploadRepo.
+					EXPECT().
+					CreateUpload(gomock.Eq(ctx), gomock.Eq(createParam)).
+					Return(createRes, nil).
+					Times(1)
+
+				res, err := s.CreateUpload(ctx, p)
+
+				Expect(err).To(BeNil())
+				Expect(res).To(Equal(&service.CreateUploadResult{
+					Success: system.Success{
+						Code:    1000,
+						Message: "success create upload",
+					},
+					UniqueId:  "mock-upload-id",
+					Size:      2048,
+					Offset:    0,
+					CreatedAt: currentTs,
+				}))
+			})
+		})
+
+		When("upload is created by a client", func() {
+			It("should record the client", func() {
+				ctx := auth.WithIdentity(ctx, &auth.Identity{
+					Id:       "owner-id",
+					ClientId: "owner-client",
+				})
+				createParam.OwnerClientId = "owner-id"
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				dirManager.
+					EXPECT().
+					IsDirectoryExists(gomock.Eq(ctx), gomock.Any()).
+					Return(true, nil).
+					Times(1)
+
+				identifier.
+					EXPECT().
+					GenerateId().
+					Return("mock-upload-id", nil).
+					Times(1)
+
+				partialManager.
+					EXPECT().
+					CreatePartial(gomock.Eq(ctx), gomock.Eq(partialParam)).
+					Return(&filesystem.CreatePartialResult{}, nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				uploadRepo.
+					EXPECT().
+					CreateUpload(gomock.Eq(ctx), gomock.Eq(createParam)).
+					Return(createRes, nil).
+					Times(1)
+
+				res, err := s.CreateUpload(ctx, p)
+
+				Expect(err).To(BeNil())
+				Expect(res).To(Equal(&service.CreateUploadResult{
+					Success: system.Success{
+						Code:    1000,
+						Message: "success create upload",
+					},
+					UniqueId:  "mock-upload-id",
+					Size:      2048,
+					Offset:    0,
+					CreatedAt: currentTs,
+				}))
+			})
+		})
+	})
+
+	Context("RetrieveUpload function", Label("unit"), func() {
+		var (
+			p             service.RetrieveUploadParam
+			retrieveParam repository.RetrieveUploadParam
+			retrieveRes   *repository.RetrieveUploadResult
+		)
+
+		BeforeEach(func() {
+			p = service.RetrieveUploadParam{
+				UploadId: "mock-upload-id",
+			}
+			retrieveParam = repository.RetrieveUploadParam{
+				UniqueId: p.UploadId,
+			}
+			retrieveRes = &repository.RetrieveUploadResult{
+				UniqueId:  "mock-upload-id",
+				Name:      "dolphin",
+				Extension: "jpg",
+				Path:      "temp/partial/mock-upload-id",
+				Size:      2048,
+				Offset:    1024,
+				CreatedAt: currentTs,
+				UpdatedAt: currentTs,
+			}
+
+			log.
+				EXPECT().
+				Debug("In function: RetrieveUpload").
+				Times(1)
+			log.
+				EXPECT().
+				Debug("Returning function: RetrieveUpload").
+				Times(1)
+			validator.
+				EXPECT().
+				Validate(gomock.Eq(p)).
+				Return(nil).
+				Times(1)
+		})
+
+		When("upload is not available", func() {
+			It("should return error", func() {
+				uploadRepo.
+					EXPECT().
+					RetrieveUpload(gomock.Eq(ctx), gomock.Eq(retrieveParam)).
+					Return(nil, repository.ErrNotFound).
+					Times(1)
+
+				res, err := s.RetrieveUpload(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(&system.Error{
+					Code:    1004,
+					Message: "upload is not found",
+				}))
+			})
+		})
+
+		When("failed retrieve upload", func() {
+			It("should return error", func() {
+				uploadRepo.
+					EXPECT().
+					RetrieveUpload(gomock.Eq(ctx), gomock.Eq(retrieveParam)).
+					Return(nil, fmt.Errorf("db error")).
+					Times(1)
+
+				res, err := s.RetrieveUpload(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(&system.Error{
+					Code:    1001,
+					Message: "db error",
+				}))
+			})
+		})
+
+		When("upload is created by other client", func() {
+			It("should return error", func() {
+				ctx := auth.WithIdentity(ctx, &auth.Identity{
+					Id:       "other-id",
+					ClientId: "other-client",
+				})
+				retrieveRes.OwnerClientId = "owner-id"
+				uploadRepo.
+					EXPECT().
+					RetrieveUpload(gomock.Eq(ctx), gomock.Eq(retrieveParam)).
+					Return(retrieveRes, nil).
+					Times(1)
+
+				res, err := s.RetrieveUpload(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(&system.Error{
+					Code:    1003,
+					Message: "upload is not accessible",
+				}))
+			})
+		})
+
+		When("upload is in progress", func() {
+			It("should return result", func() {
+				uploadRepo.
+					EXPECT().
+					RetrieveUpload(gomock.Eq(ctx), gomock.Eq(retrieveParam)).
+					Return(retrieveRes, nil).
+					Times(1)
+
+				res, err := s.RetrieveUpload(ctx, p)
+
+				Expect(err).To(BeNil())
+				Expect(res).To(Equal(&service.RetrieveUploadResult{
+					Success: system.Success{
+						Code:    1000,
+						Message: "success retrieve upload",
+					},
+					UniqueId:  "mock-upload-id",
+					Name:      "dolphin",
+					Extension: "jpg",
+					Size:      2048,
+					Offset:    1024,
+					CreatedAt: currentTs,
+				}))
+			})
+		})
+
+		When("upload is received but not finished", func() {
+			It("should not finish the upload", func() {
+				retrieveRes.Offset = 2048
+				uploadRepo.
+					EXPECT().
+					RetrieveUpload(gomock.Eq(ctx), gomock.Eq(retrieveParam)).
+					Return(retrieveRes, nil).
+					Times(1)
+
+				res, err := s.RetrieveUpload(ctx, p)
+
+				Expect(err).To(BeNil())
+				Expect(res.Offset).To(Equal(int64(2048)))
+				Expect(res.FileId).To(BeEmpty())
+				Expect(res.CompletedAt).To(BeNil())
+			})
+		})
+	})
+
+	Context("AppendUpload function", Label("unit"), func() {
+		var (
+			p           service.AppendUploadParam
+			appendRes   *repository.AppendUploadResult
+			retrieveRes *repository.RetrieveUploadResult
+		)
+
+		BeforeEach(func() {
+			p = service.AppendUploadParam{
+				UploadId: "mock-upload-id",
+				Offset:   0,
+				Reader:   strings.NewReader("content"),
+			}
+			retrieveRes = &repository.RetrieveUploadResult{
+				UniqueId:      "mock-upload-id",
+				Size:          2048,
+				Offset:        0,
+				OwnerClientId: "owner-id",
+			}
+			appendRes = &repository.AppendUploadResult{
+				UniqueId:  "mock-upload-id",
+				Size:      2048,
+				Offset:    1024,
+				UpdatedAt: currentTs,
+			}
+
+			log.
+				EXPECT().
+				Debug("In function: AppendUpload").
+				Times(1)
+			log.
+				EXPECT().
+				Debug("Returning function: AppendUpload").
+				Times(1)
+		})
+
+		When("parameter is not valid", func() {
+			It("should return error", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(fmt.Errorf("invalid data")).
+					Times(1)
+
+				res, err := s.AppendUpload(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(&system.Error{
+					Code:    1002,
+					Message: "invalid data",
+				}))
+			})
+		})
+
+		When("chunk is not specified", func() {
+			It("should return error", func() {
+				p.Reader = nil
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				res, err := s.AppendUpload(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(&system.Error{
+					Code:    1002,
+					Message: "chunk is not specified",
+				}))
+			})
+		})
+
+		When("upload is created by other client", func() {
+			It("should return error", func() {
+				ctx := auth.WithIdentity(ctx, &auth.Identity{
+					Id:       "other-id",
+					ClientId: "other-client",
+				})
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				uploadRepo.
+					EXPECT().
+					RetrieveUpload(gomock.Eq(ctx), gomock.Any()).
+					Return(retrieveRes, nil).
+					Times(1)
+
+				res, err := s.AppendUpload(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(&system.Error{
+					Code:    1003,
+					Message: "upload is not accessible",
+				}))
+			})
+		})
+
+		When("offset is not matched", func() {
+			It("should return error", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				uploadRepo.
+					EXPECT().
+					RetrieveUpload(gomock.Eq(ctx), gomock.Eq(repository.RetrieveUploadParam{
+						UniqueId: p.UploadId,
+					})).
+					Return(retrieveRes, nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				uploadRepo.
+					EXPECT().
+					AppendUpload(gomock.Eq(ctx), gomock.Any()).
+					Return(nil, repository.ErrConflict).
+					Times(1)
+
+				res, err := s.AppendUpload(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(&system.Error{
+					Code:    service.UPLOAD_OFFSET_CONFLICT,
+					Message: "upload offset is not matched",
+				}))
+			})
+		})
+
+		When("chunk is exceeding the upload size", func() {
+			It("should return error", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				uploadRepo.
+					EXPECT().
+					RetrieveUpload(gomock.Eq(ctx), gomock.Eq(repository.RetrieveUploadParam{
+						UniqueId: p.UploadId,
+					})).
+					Return(retrieveRes, nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				uploadRepo.
+					EXPECT().
+					AppendUpload(gomock.Eq(ctx), gomock.Any()).
+					Return(nil, file.ErrExceeded).
+					Times(1)
+
+				res, err := s.AppendUpload(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(&system.Error{
+					Code:    service.UPLOAD_SIZE_EXCEEDED,
+					Message: "upload size is exceeded",
+				}))
+			})
+		})
+
+		When("upload is not available", func() {
+			It("should return error", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				uploadRepo.
+					EXPECT().
+					RetrieveUpload(gomock.Eq(ctx), gomock.Any()).
+					Return(nil, repository.ErrNotFound).
+					Times(1)
+
+				res, err := s.AppendUpload(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(&system.Error{
+					Code:    1004,
+					Message: "upload is not found",
+				}))
+			})
+		})
+
+		When("chunk is appended", func() {
+			It("should return result", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				uploadRepo.
+					EXPECT().
+					RetrieveUpload(gomock.Eq(ctx), gomock.Eq(repository.RetrieveUploadParam{
+						UniqueId: p.UploadId,
+					})).
+					Return(retrieveRes, nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				uploadRepo.
+					EXPECT().
+					AppendUpload(gomock.Eq(ctx), gomock.Any()).
+					Return(appendRes, nil).
+					Times(1)
+
+				res, err := s.AppendUpload(ctx, p)
+
+				Expect(err).To(BeNil())
+				Expect(res).To(Equal(&service.AppendUploadResult{
+					Success: system.Success{
+						Code:    1000,
+						Message: "success append upload",
+					},
+					UniqueId: "mock-upload-id",
+					Size:     2048,
+					Offset:   1024,
+				}))
+			})
+		})
+
+		When("failed finish the upload", func() {
+			It("should return error", func() {
+				appendRes.Offset = 2048
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				uploadRepo.
+					EXPECT().
+					AppendUpload(gomock.Eq(ctx), gomock.Any()).
+					Return(appendRes, nil).
+					Times(1)
+
+				uploadRepo.
+					EXPECT().
+					RetrieveUpload(gomock.Eq(ctx), gomock.Eq(repository.RetrieveUploadParam{
+						UniqueId: p.UploadId,
+					})).
+					Return(&repository.RetrieveUploadResult{
+						UniqueId: "mock-upload-id",
+						Path:     "temp/partial/mock-upload-id",
+						Size:     2048,
+						Offset:   2048,
+					}, nil).
+					Times(2)
+
+				partialManager.
+					EXPECT().
+					OpenPartial(gomock.Eq(ctx), gomock.Any()).
+					Return(&filesystem.OpenPartialResult{
+						File: io.NopCloser(strings.NewReader("content")),
+					}, nil).
+					Times(1)
+
+				fileClient.
+					EXPECT().
+					UploadFile(gomock.Eq(ctx), gomock.Any(), gomock.Any()).
+					Return(nil, &system.Error{
+						Code:    1001,
+						Message: "disk error",
+					}).
+					Times(1)
+
+				res, err := s.AppendUpload(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(&system.Error{
+					Code:    1001,
+					Message: "disk error",
+				}))
+			})
+		})
+
+		When("upload is finished concurrently", func() {
+			It("should remove the uploaded file", func() {
+				appendRes.Offset = 2048
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(2)
+
+				uploadRepo.
+					EXPECT().
+					AppendUpload(gomock.Eq(ctx), gomock.Any()).
+					Return(appendRes, nil).
+					Times(1)
+
+				uploadRepo.
+					EXPECT().
+					RetrieveUpload(gomock.Eq(ctx), gomock.Any()).
+					Return(&repository.RetrieveUploadResult{
+						UniqueId: "mock-upload-id",
+						Path:     "temp/partial/mock-upload-id",
+						Size:     2048,
+						Offset:   2048,
+					}, nil).
+					Times(2)
+
+				partialManager.
+					EXPECT().
+					OpenPartial(gomock.Eq(ctx), gomock.Any()).
+					Return(&filesystem.OpenPartialResult{
+						File: io.NopCloser(strings.NewReader("content")),
+					}, nil).
+					Times(1)
+
+				fileClient.
+					EXPECT().
+					UploadFile(gomock.Eq(ctx), gomock.Any(), gomock.Any()).
+					Return(&service.UploadFileResult{
+						UniqueId: "mock-file-id",
+					}, nil).
+					Times(1)
+
+				uploadRepo.
+					EXPECT().
+					CompleteUpload(gomock.Eq(ctx), gomock.Any()).
+					Return(nil, repository.ErrNotFound).
+					Times(1)
+
+				fileClient.
+					EXPECT().
+					DeleteFile(gomock.Eq(ctx), gomock.Eq(service.DeleteFileParam{
+						FileId: "mock-file-id",
+					})).
+					Return(nil, &system.Error{
+						Code:    1001,
+						Message: "db error",
+					}).
+					Times(1)
+
+				log.
+					EXPECT().
+					Warnf(gomock.Eq("Failed removing uploaded file, err: %s"), gomock.Eq("db error")).
+					Times(1)
+
+				uploadRepo.
+					EXPECT().
+					RetrieveUpload(gomock.Eq(ctx), gomock.Any()).
+					Return(&repository.RetrieveUploadResult{
+						UniqueId:    "mock-upload-id",
+						Path:        "temp/partial/mock-upload-id",
+						Size:        2048,
+						Offset:      2048,
+						FileId:      "other-file-id",
+						CompletedAt: &currentTs,
+					}, nil).
+					Times(1)
+
+				res, err := s.AppendUpload(ctx, p)
+
+				Expect(err).To(BeNil())
+				Expect(res.FileId).To(Equal("other-file-id"))
+				Expect(res.CompletedAt).To(Equal(&currentTs))
+			})
+		})
+
+		When("last chunk is appended", func() {
+			It("should finish the upload", func() {
+				appendRes.Offset = 2048
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(2)
+
+				uploadRepo.
+					EXPECT().
+					AppendUpload(gomock.Eq(ctx), gomock.Any()).
+					Return(appendRes, nil).
+					Times(1)
+
+				uploadRepo.
+					EXPECT().
+					RetrieveUpload(gomock.Eq(ctx), gomock.Any()).
+					Return(&repository.RetrieveUploadResult{
+						UniqueId: "mock-upload-id",
+						Path:     "temp/partial/mock-upload-id",
+						Size:     2048,
+						Offset:   2048,
+					}, nil).
+					Times(2)
+
+				partialManager.
+					EXPECT().
+					OpenPartial(gomock.Eq(ctx), gomock.Any()).
+					Return(&filesystem.OpenPartialResult{
+						File: io.NopCloser(strings.NewReader("content")),
+					}, nil).
+					Times(1)
+
+				fileClient.
+					EXPECT().
+					UploadFile(gomock.Eq(ctx), gomock.Any(), gomock.Any()).
+					Return(&service.UploadFileResult{
+						UniqueId: "mock-file-id",
+					}, nil).
+					Times(1)
+
+				uploadRepo.
+					EXPECT().
+					CompleteUpload(gomock.Eq(ctx), gomock.Any()).
+					Return(&repository.CompleteUploadResult{
+						UniqueId:    "mock-upload-id",
+						FileId:      "mock-file-id",
+						CompletedAt: currentTs,
+					}, nil).
+					Times(1)
+
+				partialManager.
+					EXPECT().
+					RemovePartial(gomock.Eq(ctx), gomock.Any()).
+					Return(&filesystem.RemovePartialResult{}, nil).
+					Times(1)
+
+				res, err := s.AppendUpload(ctx, p)
+
+				Expect(err).To(BeNil())
+				Expect(res).To(Equal(&service.AppendUploadResult{
+					Success: system.Success{
+						Code:    1000,
+						Message: "success append upload",
+					},
+					UniqueId:    "mock-upload-id",
+					Size:        2048,
+					Offset:      2048,
+					FileId:      "mock-file-id",
+					CompletedAt: &currentTs,
+				}))
+			})
+		})
+	})
+
+	Context("DeleteUpload function", Label("unit"), func() {
+		var (
+			p           service.DeleteUploadParam
+			retrieveRes *repository.RetrieveUploadResult
+		)
+
+		BeforeEach(func() {
+			p = service.DeleteUploadParam{
+				UploadId: "mock-upload-id",
+			}
+			retrieveRes = &repository.RetrieveUploadResult{
+				UniqueId:      "mock-upload-id",
+				OwnerClientId: "owner-id",
+			}
+
+			log.
+				EXPECT().
+				Debug("In function: DeleteUpload").
+				Times(1)
+			log.
+				EXPECT().
+				Debug("Returning function: DeleteUpload").
+				Times(1)
+		})
+
+		When("parameter is not valid", func() {
+			It("should return error", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(fmt.Errorf("invalid data")).
+					Times(1)
+
+				res, err := s.DeleteUpload(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(&system.Error{
+					Code:    1002,
+					Message: "invalid data",
+				}))
+			})
+		})
+
+		When("upload is not available", func() {
+			It("should return error", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				uploadRepo.
+					EXPECT().
+					RetrieveUpload(gomock.Eq(ctx), gomock.Any()).
+					Return(nil, repository.ErrNotFound).
+					Times(1)
+
+				res, err := s.DeleteUpload(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(&system.Error{
+					Code:    1004,
+					Message: "upload is not found",
+				}))
+			})
+		})
+
+		When("upload is created by other client", func() {
+			It("should return error", func() {
+				ctx := auth.WithIdentity(ctx, &auth.Identity{
+					Id:       "other-id",
+					ClientId: "other-client",
+				})
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				uploadRepo.
+					EXPECT().
+					RetrieveUpload(gomock.Eq(ctx), gomock.Any()).
+					Return(retrieveRes, nil).
+					Times(1)
+
+				res, err := s.DeleteUpload(ctx, p)
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(&system.Error{
+					Code:    1003,
+					Message: "upload is not accessible",
+				}))
+			})
+		})
+
+		When("success delete upload", func() {
+			It("should return result", func() {
+				validator.
+					EXPECT().
+					Validate(gomock.Eq(p)).
+					Return(nil).
+					Times(1)
+
+				ctx := auth.WithIdentity(ctx, &auth.Identity{
+					Id:       "owner-id",
+					ClientId: "owner-client",
+				})
+				uploadRepo.
+					EXPECT().
+					RetrieveUpload(gomock.Eq(ctx), gomock.Eq(repository.RetrieveUploadParam{
+						UniqueId: p.UploadId,
+					})).
+					Return(retrieveRes, nil).
+					Times(1)
+
+				clock.
+					EXPECT().
+					Now().
+					Return(currentTs).
+					Times(1)
+
+				uploadRepo.
+					EXPECT().
+					DeleteUpload(gomock.Eq(ctx), gomock.Any()).
+					Return(&repository.DeleteUploadResult{
+						DeletedAt: currentTs,
+					}, nil).
+					Times(1)
+
+				res, err := s.DeleteUpload(ctx, p)
+
+				Expect(err).To(BeNil())
+				Expect(res).To(Equal(&service.DeleteUploadResult{
+					Success: system.Success{
+						Code:    1000,
+						Message: "success delete upload",
+					},
+					DeletedAt: currentTs,
+				}))
+			})
+		})
+	})
+
+	Context("NewAppendFn function", Label("unit"), func() {
+		var (
+			fn repository.AppendFn
+		)
+
+		BeforeEach(func() {
+			fn = service.NewAppendFn(strings.NewReader("content"), partialManager)
+		})
+
+		When("chunk is exceeding the upload size", func() {
+			It("should return error", func() {
+				partialManager.
+					EXPECT().
+					AppendPartial(gomock.Eq(ctx), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, p filesystem.AppendPartialParam) (*filesystem.AppendPartialResult, error) {
+						_, err := io.ReadAll(p.Reader)
+						return nil, err
+					}).
+					Times(1)
+
+				res, err := fn(ctx, repository.AppendFnParam{
+					FilePath: "temp/partial/mock-upload-id",
+					Offset:   4,
+					Size:     8,
+				})
+
+				Expect(res).To(BeNil())
+				Expect(err).To(Equal(file.ErrExceeded))
+			})
+		})
+
+		When("chunk is read using empty buffer", func() {
+			It("should not read the chunk", func() {
+				partialManager.
+					EXPECT().
+					AppendPartial(gomock.Eq(ctx), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, p filesystem.AppendPartialParam) (*filesystem.AppendPartialResult, error) {
+						n, err := p.Reader.Read([]byte{})
+						if err != nil {
+							return nil, err
+						}
+						return &filesystem.AppendPartialResult{
+							Size: p.Offset + int64(n),
+						}, nil
+					}).
+					Times(1)
+
+				res, err := fn(ctx, repository.AppendFnParam{
+					FilePath: "temp/partial/mock-upload-id",
+					Offset:   8,
+					Size:     8,
+				})
+
+				Expect(err).To(BeNil())
+				Expect(res).To(Equal(&repository.AppendFnResult{
+					Size: 8,
+				}))
+			})
+		})
+
+		When("success append chunk", func() {
+			It("should return result", func() {
+				partialManager.
+					EXPECT().
+					AppendPartial(gomock.Eq(ctx), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, p filesystem.AppendPartialParam) (*filesystem.AppendPartialResult, error) {
+						data, err := io.ReadAll(p.Reader)
+						if err != nil {
+							return nil, err
+						}
+						return &filesystem.AppendPartialResult{
+							Size: p.Offset + int64(len(data)),
+						}, nil
+					}).
+					Times(1)
+
+				res, err := fn(ctx, repository.AppendFnParam{
+					FilePath: "temp/partial/mock-upload-id",
+					Offset:   1,
+					Size:     8,
+				})
+
+				Expect(err).To(BeNil())
+				Expect(res).To(Equal(&repository.AppendFnResult{
+					Size: 8,
+				}))
+			})
+		})
+	})
+
+	Context("NewDeletePartialFn function", Label("unit"), func() {
+		var (
+			fn          repository.DeleteFn
+			removeParam filesystem.RemovePartialParam
+		)
+
+		BeforeEach(func() {
+			fn = service.NewDeletePartialFn(partialManager)
+			removeParam = filesystem.RemovePartialParam{
+				Path: "temp/partial/mock-upload-id",
+			}
+		})
+
+		When("failed remove partial file", func() {
+			It("should return error", func() {
+				partialManager.
+					EXPECT().
+					RemovePartial(gomock.Eq(ctx), gomock.Eq(removeParam)).
+					Return(nil, fmt.Errorf("disk error")).
+					Times(1)
+
+				err := fn(ctx, repository.DeleteFnParam{
+					FilePath: removeParam.Path,
+				})
+
+				Expect(err).To(Equal(fmt.Errorf("disk error")))
+			})
+		})
+
+		When("partial file is already removed", func() {
+			It("should return result", func() {
+				partialManager.
+					EXPECT().
+					RemovePartial(gomock.Eq(ctx), gomock.Eq(removeParam)).
+					Return(nil, filesystem.ErrorFileNotFound).
+					Times(1)
+
+				err := fn(ctx, repository.DeleteFnParam{
+					FilePath: removeParam.Path,
+				})
+
+				Expect(err).To(BeNil())
+			})
+		})
+	})
+})
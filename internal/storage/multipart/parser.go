@@ -1,64 +1,68 @@
 package multipart
 
 import (
+	"bufio"
 	"fmt"
 	"io"
 	"mime/multipart"
-	"net/http"
 	"strings"
+
+	"github.com/go-seidon/hippo/internal/file"
 )
 
-type Parser = func(h *multipart.FileHeader) (*FileInfo, error)
+type Parser = func(p *multipart.Part) (*FileInfo, error)
 
 type FileInfo struct {
 	Name      string
 	Size      int64
 	Extension string
 	Mimetype  string
-	Data      multipart.File
+	Data      io.ReadCloser
 }
 
-func FileParser(h *multipart.FileHeader) (*FileInfo, error) {
-	if h == nil {
-		return nil, fmt.Errorf("invalid header")
+// @note: the part is streamed so the size is unknown (zero),
+// the mimetype is detected from the peeked header without consuming it
+func FileParser(p *multipart.Part) (*FileInfo, error) {
+	if p == nil {
+		return nil, fmt.Errorf("invalid part")
 	}
 
-	data, err := h.Open()
-	if err != nil {
-		return nil, err
-	}
-
-	buff := make([]byte, 512)
-	n, err := data.Read(buff)
+	reader := bufio.NewReaderSize(p, file.SNIFF_SIZE)
+	header, err := reader.Peek(file.SNIFF_SIZE)
 	if err != nil && err != io.EOF {
 		return nil, err
 	}
-	buff = buff[:n]
-
-	_, err = data.Seek(0, io.SeekStart)
-	if err != nil {
-		return nil, err
-	}
 
 	info := &FileInfo{
-		Size:      h.Size,
-		Name:      FileName(h),
-		Extension: FileExtension(h),
-		Mimetype:  http.DetectContentType(buff),
-		Data:      data,
+		Name:      FileName(p.FileName()),
+		Extension: FileExtension(p.FileName()),
+		Mimetype:  file.DetectMimetype(header),
+		Data: &partReader{
+			Reader: reader,
+			Closer: p,
+		},
 	}
 	return info, nil
 }
 
-func FileName(fh *multipart.FileHeader) string {
-	names := strings.Split(fh.Filename, ".")
-	return names[0]
+// @note: only the last segment is used as the extension
+func FileName(name string) string {
+	idx := strings.LastIndex(name, ".")
+	if idx < 0 {
+		return name
+	}
+	return name[:idx]
 }
 
-func FileExtension(fh *multipart.FileHeader) string {
-	names := strings.Split(fh.Filename, ".")
-	if len(names) == 1 {
+func FileExtension(name string) string {
+	idx := strings.LastIndex(name, ".")
+	if idx < 0 {
 		return ""
 	}
-	return names[len(names)-1]
+	return name[idx+1:]
+}
+
+type partReader struct {
+	io.Reader
+	io.Closer
 }
@@ -0,0 +1,4 @@
+
+ALTER TABLE `file` DROP COLUMN `checksum_md5`;
+
+ALTER TABLE `file` DROP COLUMN `checksum_sha256`;
@@ -0,0 +1,4 @@
+
+ALTER TABLE `file` ADD COLUMN `checksum_sha256` VARCHAR(64) NOT NULL DEFAULT '' AFTER `size`;
+
+ALTER TABLE `file` ADD COLUMN `checksum_md5` VARCHAR(32) NOT NULL DEFAULT '' AFTER `checksum_sha256`;
@@ -0,0 +1,12 @@
+
+CREATE TABLE IF NOT EXISTS `file_blob` (
+  `checksum_sha256` VARCHAR(64) NOT NULL,
+  `path` TEXT NOT NULL,
+  `ref_count` BIGINT NOT NULL,
+  `created_at` BIGINT NOT NULL,
+  `updated_at` BIGINT NOT NULL,
+  PRIMARY KEY (`checksum_sha256`)
+) 
+DEFAULT CHARACTER SET utf8mb4
+COLLATE utf8mb4_unicode_ci
+ENGINE = InnoDB;
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS `upload` (
+  `id` VARCHAR(128) NOT NULL,
+  `name` VARCHAR(4096) NOT NULL,
+  `extension` VARCHAR(128) NOT NULL,
+  `path` TEXT NOT NULL,
+  `size` BIGINT NOT NULL,
+  `offset` BIGINT NOT NULL DEFAULT 0,
+  `file_id` VARCHAR(128) NOT NULL DEFAULT '',
+  `created_at` BIGINT NOT NULL,
+  `updated_at` BIGINT NOT NULL,
+  `completed_at` BIGINT NULL DEFAULT NULL,
+  PRIMARY KEY (`id`)
+) 
+DEFAULT CHARACTER SET utf8mb4
+COLLATE utf8mb4_unicode_ci
+ENGINE = InnoDB;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS multipart_part;
+
+DROP TABLE IF EXISTS multipart;
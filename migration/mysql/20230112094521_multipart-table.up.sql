@@ -0,0 +1,29 @@
+CREATE TABLE IF NOT EXISTS `multipart` (
+  `id` VARCHAR(128) NOT NULL,
+  `name` VARCHAR(4096) NOT NULL,
+  `mimetype` VARCHAR(256) NOT NULL,
+  `extension` VARCHAR(128) NOT NULL,
+  `file_id` VARCHAR(128) NOT NULL DEFAULT '',
+  `created_at` BIGINT NOT NULL,
+  `updated_at` BIGINT NOT NULL,
+  `completed_at` BIGINT NULL DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  INDEX idx_created_at(`created_at`)
+) 
+DEFAULT CHARACTER SET utf8mb4
+COLLATE utf8mb4_unicode_ci
+ENGINE = InnoDB;
+
+CREATE TABLE IF NOT EXISTS `multipart_part` (
+  `multipart_id` VARCHAR(128) NOT NULL,
+  `part_number` INT NOT NULL,
+  `path` TEXT NOT NULL,
+  `size` BIGINT NOT NULL,
+  `checksum_sha256` VARCHAR(64) NOT NULL,
+  `created_at` BIGINT NOT NULL,
+  `updated_at` BIGINT NOT NULL,
+  PRIMARY KEY (`multipart_id`, `part_number`)
+) 
+DEFAULT CHARACTER SET utf8mb4
+COLLATE utf8mb4_unicode_ci
+ENGINE = InnoDB;
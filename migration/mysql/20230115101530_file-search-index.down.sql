@@ -0,0 +1,5 @@
+ALTER TABLE `file` DROP INDEX `idx_mimetype`;
+
+ALTER TABLE `file` DROP INDEX `idx_extension`;
+
+ALTER TABLE `file` DROP INDEX `idx_created_at`;
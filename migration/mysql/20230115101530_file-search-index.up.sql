@@ -0,0 +1,5 @@
+ALTER TABLE `file` ADD INDEX idx_mimetype(`mimetype`);
+
+ALTER TABLE `file` ADD INDEX idx_extension(`extension`);
+
+ALTER TABLE `file` ADD INDEX idx_created_at(`created_at`);
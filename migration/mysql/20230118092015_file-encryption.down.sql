@@ -0,0 +1,5 @@
+ALTER TABLE `file` DROP INDEX `idx_encryption_key_id`;
+
+ALTER TABLE `file` DROP COLUMN `encryption_data_key`;
+
+ALTER TABLE `file` DROP COLUMN `encryption_key_id`;
\ No newline at end of file
@@ -0,0 +1,5 @@
+ALTER TABLE `file` ADD COLUMN `encryption_key_id` VARCHAR(64) NOT NULL DEFAULT '' AFTER `checksum_md5`;
+
+ALTER TABLE `file` ADD COLUMN `encryption_data_key` VARCHAR(128) NOT NULL DEFAULT '' AFTER `encryption_key_id`;
+
+ALTER TABLE `file` ADD INDEX idx_encryption_key_id(`encryption_key_id`);
\ No newline at end of file
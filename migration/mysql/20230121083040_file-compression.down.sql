@@ -0,0 +1,3 @@
+ALTER TABLE `file` DROP COLUMN `compression`;
+
+ALTER TABLE `file` DROP COLUMN `stored_size`;
\ No newline at end of file
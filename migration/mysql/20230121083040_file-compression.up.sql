@@ -0,0 +1,3 @@
+ALTER TABLE `file` ADD COLUMN `stored_size` BIGINT NOT NULL DEFAULT 0 AFTER `size`;
+
+ALTER TABLE `file` ADD COLUMN `compression` VARCHAR(16) NOT NULL DEFAULT '' AFTER `encryption_data_key`;
\ No newline at end of file
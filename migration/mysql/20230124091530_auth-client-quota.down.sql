@@ -0,0 +1,15 @@
+ALTER TABLE `auth_client` DROP COLUMN `usage_daily_at`;
+
+ALTER TABLE `auth_client` DROP COLUMN `usage_daily_uploads`;
+
+ALTER TABLE `auth_client` DROP COLUMN `usage_total_files`;
+
+ALTER TABLE `auth_client` DROP COLUMN `usage_stored_size`;
+
+ALTER TABLE `auth_client` DROP COLUMN `quota_max_daily_uploads`;
+
+ALTER TABLE `auth_client` DROP COLUMN `quota_max_file_size`;
+
+ALTER TABLE `auth_client` DROP COLUMN `quota_max_total_files`;
+
+ALTER TABLE `auth_client` DROP COLUMN `quota_max_stored_size`;
\ No newline at end of file
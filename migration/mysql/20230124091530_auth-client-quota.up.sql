@@ -0,0 +1,15 @@
+ALTER TABLE `auth_client` ADD COLUMN `quota_max_stored_size` BIGINT NOT NULL DEFAULT 0 AFTER `status`;
+
+ALTER TABLE `auth_client` ADD COLUMN `quota_max_total_files` BIGINT NOT NULL DEFAULT 0 AFTER `quota_max_stored_size`;
+
+ALTER TABLE `auth_client` ADD COLUMN `quota_max_file_size` BIGINT NOT NULL DEFAULT 0 AFTER `quota_max_total_files`;
+
+ALTER TABLE `auth_client` ADD COLUMN `quota_max_daily_uploads` BIGINT NOT NULL DEFAULT 0 AFTER `quota_max_file_size`;
+
+ALTER TABLE `auth_client` ADD COLUMN `usage_stored_size` BIGINT NOT NULL DEFAULT 0 AFTER `quota_max_daily_uploads`;
+
+ALTER TABLE `auth_client` ADD COLUMN `usage_total_files` BIGINT NOT NULL DEFAULT 0 AFTER `usage_stored_size`;
+
+ALTER TABLE `auth_client` ADD COLUMN `usage_daily_uploads` BIGINT NOT NULL DEFAULT 0 AFTER `usage_total_files`;
+
+ALTER TABLE `auth_client` ADD COLUMN `usage_daily_at` BIGINT NOT NULL DEFAULT 0 AFTER `usage_daily_uploads`;
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE `file` DROP INDEX `idx_owner_client_id`;
+
+ALTER TABLE `file` DROP COLUMN `owner_client_id`;
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE `file` ADD COLUMN `owner_client_id` VARCHAR(128) NOT NULL DEFAULT '' AFTER `compression`;
+
+ALTER TABLE `file` ADD INDEX idx_owner_client_id(`owner_client_id`);
\ No newline at end of file
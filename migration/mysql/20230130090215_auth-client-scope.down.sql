@@ -0,0 +1 @@
+ALTER TABLE `auth_client` DROP COLUMN `scopes`;
\ No newline at end of file
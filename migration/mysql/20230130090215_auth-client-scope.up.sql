@@ -0,0 +1,3 @@
+ALTER TABLE `auth_client` ADD COLUMN `scopes` VARCHAR(255) NOT NULL DEFAULT '' AFTER `status`;
+
+UPDATE `auth_client` SET `scopes` = 'file:read file:write file:delete';
\ No newline at end of file
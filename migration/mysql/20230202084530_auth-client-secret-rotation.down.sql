@@ -0,0 +1,5 @@
+ALTER TABLE `auth_client` DROP COLUMN `secret_rotated_at`;
+
+ALTER TABLE `auth_client` DROP COLUMN `previous_secret_expires_at`;
+
+ALTER TABLE `auth_client` DROP COLUMN `previous_client_secret`;
\ No newline at end of file
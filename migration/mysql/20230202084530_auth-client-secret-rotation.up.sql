@@ -0,0 +1,5 @@
+ALTER TABLE `auth_client` ADD COLUMN `previous_client_secret` VARCHAR(255) NOT NULL DEFAULT '' AFTER `client_secret`;
+
+ALTER TABLE `auth_client` ADD COLUMN `previous_secret_expires_at` BIGINT NOT NULL DEFAULT 0 AFTER `previous_client_secret`;
+
+ALTER TABLE `auth_client` ADD COLUMN `secret_rotated_at` BIGINT NOT NULL DEFAULT 0 AFTER `previous_secret_expires_at`;
\ No newline at end of file
@@ -0,0 +1,5 @@
+DELETE FROM `auth_client` WHERE `deleted_at` <> 0;
+
+ALTER TABLE `auth_client` DROP INDEX `uk_client_id`, ADD UNIQUE uk_client_id(`client_id`);
+
+ALTER TABLE `auth_client` DROP COLUMN `deleted_at`;
\ No newline at end of file
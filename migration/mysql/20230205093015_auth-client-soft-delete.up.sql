@@ -0,0 +1,3 @@
+ALTER TABLE `auth_client` ADD COLUMN `deleted_at` BIGINT NOT NULL DEFAULT 0 AFTER `updated_at`;
+
+ALTER TABLE `auth_client` DROP INDEX `uk_client_id`, ADD UNIQUE uk_client_id(`client_id`, `deleted_at`);
\ No newline at end of file
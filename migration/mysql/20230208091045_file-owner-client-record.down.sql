@@ -0,0 +1,3 @@
+UPDATE `file` f
+INNER JOIN `auth_client` c ON c.`id` = f.`owner_client_id`
+SET f.`owner_client_id` = c.`client_id`;
\ No newline at end of file
@@ -0,0 +1,5 @@
+UPDATE `file` f
+INNER JOIN `auth_client` c ON c.`client_id` = f.`owner_client_id`
+  AND c.`created_at` <= f.`created_at`
+  AND (c.`deleted_at` = 0 OR c.`deleted_at` >= f.`created_at`)
+SET f.`owner_client_id` = c.`id`;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE `upload` DROP COLUMN `owner_client_id`;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE `upload` ADD COLUMN `owner_client_id` VARCHAR(128) NOT NULL DEFAULT '' AFTER `file_id`;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE `multipart` DROP COLUMN `owner_client_id`;
\ No newline at end of file
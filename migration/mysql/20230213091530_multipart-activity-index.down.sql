@@ -0,0 +1 @@
+ALTER TABLE `multipart` DROP INDEX `idx_updated_at`;
\ No newline at end of file
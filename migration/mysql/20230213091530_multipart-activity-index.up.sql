@@ -0,0 +1 @@
+ALTER TABLE `multipart` ADD INDEX idx_updated_at(`updated_at`);
\ No newline at end of file